@@ -74,3 +74,10 @@ const (
 )
 
 const comma = ","
+
+const (
+	// path of the projector rebuild admin API served by the router, see vvm.RebuildProjectorPath
+	rebuildProjectorPath = "/admin/projectors/rebuild"
+
+	rebuildStatusDone = "done"
+)
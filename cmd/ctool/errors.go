@@ -68,3 +68,7 @@ const errSshKeyNotFound = "ssh key %s not found: %w"
 var ErrDomainsNotFound = errors.New("domains not found")
 
 const errDomainsNotFound = "domains %s not found in cluster: %w"
+
+var ErrProjectorRebuildFailed = errors.New("projector rebuild failed")
+
+const errRebuildFailed = "%s %s: %w"
@@ -6,10 +6,14 @@
 package main
 
 import (
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/stretchr/testify/require"
@@ -273,6 +277,53 @@ func TestAcmeDomains(t *testing.T) {
 	require.Equal(cluster.Acme.domains(), "domain1.io,domain3")
 }
 
+func TestProjectorRebuild(t *testing.T) {
+	require := require.New(t)
+
+	rebuildPollInterval = time.Millisecond
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(rebuildProjectorPath, r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("projector") == "air.Unknown" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.Equal("untill/airsbp", r.URL.Query().Get("app"))
+		status := "replaying"
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			if polls++; polls > 2 {
+				status = rebuildStatusDone
+			}
+		}
+		fmt.Fprintf(w, `{"App":"untill/airsbp","Projector":"air.UpdateStats","Partitions":[{"Partition":1,"Status":"%s","Offset":%d}]}`, status, polls)
+	}))
+	defer server.Close()
+
+	require.NoError(rebuildProjector(server.URL, "token", "untill/airsbp", "air.UpdateStats", true))
+	require.Equal(3, polls)
+
+	polls = 0
+	require.NoError(rebuildProjector(server.URL, "token", "untill/airsbp", "air.UpdateStats", false))
+	require.Zero(polls)
+
+	err := rebuildProjector(server.URL, "token", "untill/airsbp", "air.Unknown", true)
+	require.ErrorIs(err, ErrProjectorRebuildFailed)
+
+	err = rebuildProjector(server.URL, "wrong", "untill/airsbp", "air.UpdateStats", true)
+	require.ErrorIs(err, ErrProjectorRebuildFailed)
+
+	err = execRootCmd([]string{"./ctool", "projector", "rebuild", "untill/airsbp"}, version)
+	require.ErrorIs(err, ErrInvalidNumberOfArguments)
+}
+
 // Testing the availability of the variable environment from scripts caused by PipedExec
 func TestVariableEnvironment(t *testing.T) {
 	require := require.New(t)
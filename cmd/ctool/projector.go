@@ -0,0 +1,158 @@
+/*
+* Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// router URL of the cluster (flag --url)
+var routerURL string
+
+// system principal token of the application to authorize the admin API request (flag --token)
+var systemToken string
+
+// do not wait for the projector rebuild to finish (flag --no-wait)
+var noWait bool
+
+// interval of the projector rebuild progress polling
+var rebuildPollInterval = time.Second
+
+// progress of the projector rebuild, returned by the admin API
+type rebuildProgressType struct {
+	App        string
+	Projector  string
+	Partitions []struct {
+		Partition int
+		Status    string
+		Offset    uint64
+	}
+}
+
+func (p *rebuildProgressType) done() bool {
+	for _, pp := range p.Partitions {
+		if pp.Status != rebuildStatusDone {
+			return false
+		}
+	}
+	return true
+}
+
+// nolint
+func newProjectorCmd() *cobra.Command {
+	projectorRebuildCmd := &cobra.Command{
+		Use:   "rebuild [<app> <projector>]",
+		Short: "Rebuilds async projector: truncates its views and replays PLog, e.g. rebuild untill/airsbp air.UpdateStats",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return ErrInvalidNumberOfArguments
+			}
+			return nil
+		},
+		RunE: projectorRebuild,
+	}
+	projectorRebuildCmd.PersistentFlags().StringVar(&routerURL, "url", "", "Router URL of the cluster, https://<first ACME domain of the cluster> is used by default")
+	projectorRebuildCmd.PersistentFlags().StringVar(&systemToken, "token", "", "System principal token of the application")
+	projectorRebuildCmd.PersistentFlags().BoolVar(&noWait, "no-wait", false, "Do not wait for the rebuild to finish")
+	if err := projectorRebuildCmd.MarkPersistentFlagRequired("token"); err != nil {
+		loggerError(err.Error())
+		return nil
+	}
+
+	projectorCmd := &cobra.Command{
+		Use:   "projector",
+		Short: "Async projectors management",
+	}
+
+	projectorCmd.AddCommand(projectorRebuildCmd)
+
+	return projectorCmd
+}
+
+func projectorRebuild(cmd *cobra.Command, args []string) error {
+	baseURL := routerURL
+	if len(baseURL) == 0 {
+		cluster := newCluster()
+		if !cluster.clusterConfigFileExists() {
+			return ErrClusterConfNotFound
+		}
+		if len(cluster.Acme.Domains) == 0 {
+			return ErrDomainsNotFound
+		}
+		baseURL = "https://" + cluster.Acme.Domains[0]
+	}
+
+	loggerInfo("Rebuild projector", args[1], "of application", args[0], "on", baseURL)
+	return rebuildProjector(baseURL, systemToken, args[0], args[1], !noWait)
+}
+
+// Starts rebuild of the projector and waits for the rebuild to finish if wait is true. Progress is printed on each change
+func rebuildProjector(baseURL, token, app, projector string, wait bool) error {
+	rebuildURL := fmt.Sprintf("%s%s?%s", strings.TrimSuffix(baseURL, "/"), rebuildProjectorPath, url.Values{"app": {app}, "projector": {projector}}.Encode())
+
+	progress, err := rebuildRequest(http.MethodPost, rebuildURL, token, http.StatusAccepted)
+	if err != nil {
+		return err
+	}
+	printRebuildProgress(progress)
+
+	for wait && !progress.done() {
+		time.Sleep(rebuildPollInterval)
+		p, err := rebuildRequest(http.MethodGet, rebuildURL, token, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		if fmt.Sprint(p) != fmt.Sprint(progress) {
+			printRebuildProgress(p)
+		}
+		progress = p
+	}
+
+	if progress.done() {
+		loggerInfoGreen("Projector", projector, "is rebuilt")
+	}
+	return nil
+}
+
+func rebuildRequest(method, rebuildURL, token string, expectedStatus int) (*rebuildProgressType, error) {
+	req, err := http.NewRequest(method, rebuildURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != expectedStatus {
+		return nil, fmt.Errorf(errRebuildFailed, resp.Status, string(body), ErrProjectorRebuildFailed)
+	}
+
+	progress := &rebuildProgressType{}
+	if err := json.Unmarshal(body, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func printRebuildProgress(progress *rebuildProgressType) {
+	for _, p := range progress.Partitions {
+		loggerInfo(fmt.Sprintf("  partition %d: %s, offset %d", p.Partition, p.Status, p.Offset))
+	}
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/untillpro/goutils/logger"
+
+	"github.com/voedger/voedger/pkg/appbackup"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/apps"
+	"github.com/voedger/voedger/pkg/apps/sys/blobberapp"
+	"github.com/voedger/voedger/pkg/apps/sys/registryapp"
+	"github.com/voedger/voedger/pkg/apps/sys/routerapp"
+	"github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/isecretsimpl"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/pipeline"
+	"github.com/voedger/voedger/pkg/projectors"
+	"github.com/voedger/voedger/pkg/sys/builtin"
+	"github.com/voedger/voedger/pkg/sys/smtp"
+	"github.com/voedger/voedger/pkg/vvm"
+)
+
+func newBackupCmd() *cobra.Command {
+	var storageParams apps.CLIParams
+	var app, fileName string
+	var partitions int
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Backup application storage to the archive file, application should be stopped",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			appQName, err := istructs.ParseAppQName(app)
+			if err != nil {
+				return err
+			}
+			asp, err := cliStorageProvider(storageParams)
+			if err != nil {
+				return err
+			}
+			return backupApp(cmd.Context(), asp, appQName, partitions, fileName)
+		},
+	}
+	backupCmd.Flags().StringVar(&app, "app", "", "application to backup, e.g. untill/airs-bp")
+	backupCmd.Flags().StringVar(&storageParams.Storage, "storage", "", "storage: cas1, cas3, mem, leveldb or bbolt")
+	backupCmd.Flags().StringVar(&storageParams.StorageDir, "storage-dir", "", "data directory of the embedded storage")
+	backupCmd.Flags().IntVar(&partitions, "partitions", 0, "partitions count of the application")
+	backupCmd.Flags().StringVar(&fileName, "file", "", "archive file to create")
+	_ = backupCmd.MarkFlagRequired("app")
+	_ = backupCmd.MarkFlagRequired("storage")
+	_ = backupCmd.MarkFlagRequired("partitions")
+	_ = backupCmd.MarkFlagRequired("file")
+	return backupCmd
+}
+
+func backupApp(ctx context.Context, asp istorage.IAppStorageProvider, appQName istructs.AppQName, partitions int, fileName string) error {
+	// storage to backup must not be initialized if it is missed
+	storage, err := asp.(istorage.IAppStorageLookup).ExistingAppStorage(appQName)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err = appbackup.Backup(ctx, storage, appQName, partitions, w); err == nil {
+		err = w.Flush()
+	}
+	err = errors.Join(err, f.Close())
+	if err != nil {
+		// do not keep incomplete archive
+		return errors.Join(err, os.Remove(fileName))
+	}
+	logger.Info(fmt.Sprintf("%s backed up to %s", appQName, fileName))
+	return nil
+}
+
+func newRestoreCmd() *cobra.Command {
+	var storageParams apps.CLIParams
+	var app, fileName string
+	var toPLogOffsets []string
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore application storage from the archive file made by backup command, storage of the application must be empty",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			appQName, err := istructs.ParseAppQName(app)
+			if err != nil {
+				return err
+			}
+			toOffsets, err := parseToOffsets(toPLogOffsets)
+			if err != nil {
+				return err
+			}
+			asp, err := cliStorageProvider(storageParams)
+			if err != nil {
+				return err
+			}
+			return restoreApp(cmd.Context(), asp, appQName, fileName, toOffsets)
+		},
+	}
+	restoreCmd.Flags().StringVar(&app, "app", "", "application to restore, e.g. untill/airs-bp")
+	restoreCmd.Flags().StringVar(&storageParams.Storage, "storage", "", "storage: cas1, cas3, mem, leveldb or bbolt")
+	restoreCmd.Flags().StringVar(&storageParams.StorageDir, "storage-dir", "", "data directory of the embedded storage")
+	restoreCmd.Flags().StringVar(&fileName, "file", "", "archive file made by backup command")
+	restoreCmd.Flags().StringArrayVar(&toPLogOffsets, "to-plog-offset", nil,
+		"point-in-time restore: last PLog offset to restore as partition=offset, may be repeated, partitions missed are restored completely. Built-in applications only")
+	_ = restoreCmd.MarkFlagRequired("app")
+	_ = restoreCmd.MarkFlagRequired("storage")
+	_ = restoreCmd.MarkFlagRequired("file")
+	return restoreCmd
+}
+
+// nil toOffsets means the whole archive is restored as is
+func restoreApp(ctx context.Context, asp istorage.IAppStorageProvider, appQName istructs.AppQName, fileName string, toOffsets map[istructs.PartitionID]istructs.Offset) error {
+	opts := appbackup.RestoreOptions{}
+	if toOffsets != nil {
+		var err error
+		if opts, err = pointInTimeRestoreOptions(ctx, asp, appQName, toOffsets); err != nil {
+			return err
+		}
+	}
+	storage, err := asp.AppStorage(appQName)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	hdr, err := appbackup.Restore(ctx, bufio.NewReader(f), storage, opts)
+	if err != nil {
+		return err
+	}
+	if hdr.App != appQName {
+		logger.Warning(fmt.Sprintf("archive of %s is restored to %s", hdr.App, appQName))
+	}
+	logger.Info(fmt.Sprintf("%s restored from %s made at %s", appQName, fileName, hdr.Created))
+	return nil
+}
+
+// Parses partition=offset pairs. Returns nil if there are no pairs
+func parseToOffsets(pairs []string) (map[istructs.PartitionID]istructs.Offset, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	res := make(map[istructs.PartitionID]istructs.Offset, len(pairs))
+	for _, pair := range pairs {
+		partition, offset, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, partition=offset expected", errWrongToPLogOffset, pair)
+		}
+		p, err := strconv.ParseUint(partition, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: partition: %w", errWrongToPLogOffset, pair, err)
+		}
+		o, err := strconv.ParseUint(offset, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: offset: %w", errWrongToPLogOffset, pair, err)
+		}
+		res[istructs.PartitionID(p)] = istructs.Offset(o)
+	}
+	return res, nil
+}
+
+// Built-in applications only could be restored to the point in time: application definitions and sync projectors are needed to restore events
+func restoreAppsBuilder() vvm.VVMAppsBuilder {
+	b := vvm.VVMAppsBuilder{}
+	b.Add(istructs.AppQName_sys_registry, registryapp.Provide(smtp.Cfg{}, nil))
+	b.Add(istructs.AppQName_sys_blobber, blobberapp.Provide(smtp.Cfg{}))
+	b.Add(istructs.AppQName_sys_router, routerapp.Provide(smtp.Cfg{}))
+	return b
+}
+
+// Returns application structures of the built-in application bound to the storage
+func restoreAppStructs(asp istorage.IAppStorageProvider, appQName istructs.AppQName) (istructs.IAppStructs, error) {
+	builder := restoreAppsBuilder()
+	if _, ok := builder[appQName]; !ok {
+		return nil, fmt.Errorf("%w: %s", errPointInTimeRestoreNotSupported, appQName)
+	}
+	cfgs := istructsmem.AppConfigsType{}
+	// tokens are not issued while restoring, so the key is not important
+	tokens := itokensjwt.ProvideITokens(itokensjwt.SecretKeyExample, time.Now)
+	appTokensFactory := payloads.ProvideIAppTokensFactory(tokens)
+	structsProvider := istructsmem.Provide(cfgs, func() irates.IBuckets { return iratesce.Provide(time.Now) }, appTokensFactory, asp)
+	apis := apps.APIs{
+		ITokens:             tokens,
+		IAppStructsProvider: structsProvider,
+		AppConfigsType:      cfgs,
+		IAppStorageProvider: asp,
+		IAppTokensFactory:   appTokensFactory,
+		TimeFunc:            time.Now,
+	}
+	if _, err := builder.Build(cfgs, apis, builder.PrepareAppsExtensionPoints()); err != nil {
+		return nil, err
+	}
+	return structsProvider.AppStructs(appQName)
+}
+
+// Views of sync projectors are rebuilt by the sync actualizer as the command processor does
+func pointInTimeRestoreOptions(ctx context.Context, asp istorage.IAppStorageProvider, appQName istructs.AppQName, toOffsets map[istructs.PartitionID]istructs.Offset) (appbackup.RestoreOptions, error) {
+	if _, ok := restoreAppsBuilder()[appQName]; !ok {
+		return appbackup.RestoreOptions{}, fmt.Errorf("%w: %s", errPointInTimeRestoreNotSupported, appQName)
+	}
+	var actualizer pipeline.ISyncOperator
+	return appbackup.RestoreOptions{
+		ToOffsets: toOffsets,
+		AppStructs: func() (istructs.IAppStructs, error) {
+			return restoreAppStructs(asp, appQName)
+		},
+		OnEvent: func(as istructs.IAppStructs, event istructs.IPLogEvent) error {
+			if len(as.SyncProjectors()) == 0 {
+				return nil
+			}
+			if actualizer == nil {
+				conf := projectors.SyncActualizerConf{
+					Ctx:          ctx,
+					AppStructs:   func() istructs.IAppStructs { return as },
+					SecretReader: isecretsimpl.ProvideSecretReader(),
+					// sync projectors of built-in applications do not depend on the partition
+					Partition:    istructs.PartitionID(0),
+					WorkToEvent:  func(work interface{}) istructs.IPLogEvent { return work.(istructs.IPLogEvent) },
+					N10nFunc:     func(appdef.QName, istructs.WSID, istructs.Offset, func(int) []byte) {},
+					IntentsLimit: builtin.MaxCUDs,
+				}
+				actualizer = projectors.ProvideSyncActualizerFactory()(conf, as.SyncProjectors()[0], as.SyncProjectors()[1:]...)
+			}
+			return actualizer.DoSync(ctx, event)
+		},
+	}, nil
+}
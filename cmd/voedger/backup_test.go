@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/registry"
+)
+
+func TestPointInTimeRestore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	app := istructs.AppQName_sys_registry
+	appWSID := istructs.NewWSID(istructs.MainClusterID, istructs.FirstBaseAppWSID)
+	logins := []string{"login1", "login2", "login3"} // one event per login
+	appIDLoginHash := func(login string) string { return app.String() + "/" + registry.GetLoginHash(login) }
+
+	// fill the registry as the command processor does
+	srcASP := istorageimpl.Provide(mem.Provide())
+	as, err := restoreAppStructs(srcASP, app)
+	require.NoError(err)
+	opts, err := pointInTimeRestoreOptions(ctx, srcASP, app, nil)
+	require.NoError(err)
+	ids := make([]istructs.RecordID, len(logins))
+	gen := istructsmem.NewIDGenerator()
+	for i, login := range logins {
+		ofs := istructs.Offset(i) + istructs.FirstOffset
+		bld := as.Events().GetSyncRawEventBuilder(istructs.SyncRawEventBuilderParams{
+			GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+				HandlingPartition: 0,
+				PLogOffset:        ofs,
+				Workspace:         appWSID,
+				WLogOffset:        ofs,
+				QName:             istructs.QNameCommandCUD,
+			},
+		})
+		cdocLogin := bld.CUDBuilder().Create(registry.QNameCDocLogin)
+		cdocLogin.PutRecordID(appdef.SystemField_ID, 1)
+		cdocLogin.PutInt32("ProfileCluster", int32(istructs.MainClusterID))
+		cdocLogin.PutBytes("PwdHash", []byte("hash"))
+		cdocLogin.PutString("AppName", app.String())
+		cdocLogin.PutString("LoginHash", registry.GetLoginHash(login))
+		cdocLogin.PutString("WSKindInitializationData", "{}")
+		rawEvent, err := bld.BuildRawEvent()
+		require.NoError(err)
+		event, err := as.Events().PutPlog(rawEvent, nil, gen)
+		require.NoError(err)
+		require.NoError(as.Events().PutWlog(event))
+		require.NoError(as.Records().Apply2(event, func(rec istructs.IRecord) { ids[i] = rec.ID() }))
+		require.NoError(opts.OnEvent(as, event))
+	}
+
+	fileName := filepath.Join(t.TempDir(), "registry.bak")
+	require.NoError(backupApp(ctx, srcASP, app, 1, fileName))
+
+	dstASP := istorageimpl.Provide(mem.Provide())
+	require.NoError(restoreApp(ctx, dstASP, app, fileName, map[istructs.PartitionID]istructs.Offset{0: 2}))
+
+	restored, err := restoreAppStructs(dstASP, app)
+	require.NoError(err)
+	for i, login := range logins {
+		kb := restored.ViewRecords().KeyBuilder(registry.QNameViewLoginIdx)
+		kb.PutInt64("AppWSID", int64(appWSID))
+		kb.PutString("AppIDLoginHash", appIDLoginHash(login))
+		idx, idxErr := restored.ViewRecords().Get(appWSID, kb)
+		rec, recErr := restored.Records().Get(appWSID, true, ids[i])
+		require.NoError(recErr)
+		if i < 2 {
+			require.NoError(idxErr, login)
+			require.Equal(ids[i], idx.AsRecordID("CDocLoginID"))
+			require.Equal(registry.QNameCDocLogin, rec.QName())
+		} else {
+			require.ErrorIs(idxErr, istructsmem.ErrRecordNotFound, login)
+			require.Equal(appdef.NullQName, rec.QName(), "record created after the point in time must not be restored")
+		}
+	}
+
+	t.Run("Should not restore unknown application to the point in time", func(t *testing.T) {
+		err := restoreApp(ctx, istorageimpl.Provide(mem.Provide()), istructs.AppQName_test1_app1, fileName, map[istructs.PartitionID]istructs.Offset{0: 2})
+		require.ErrorIs(err, errPointInTimeRestoreNotSupported)
+	})
+}
+
+func TestParseToOffsets(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("Should return nil if no offsets", func(t *testing.T) {
+		offsets, err := parseToOffsets(nil)
+		require.NoError(err)
+		require.Nil(offsets)
+	})
+
+	t.Run("Should parse partition=offset pairs", func(t *testing.T) {
+		offsets, err := parseToOffsets([]string{"0=10", "5=1"})
+		require.NoError(err)
+		require.Equal(map[istructs.PartitionID]istructs.Offset{0: 10, 5: 1}, offsets)
+	})
+
+	for _, wrong := range []string{"", "10", "a=1", "1=b", "1=-1", "65536=1"} {
+		t.Run(fmt.Sprintf("Should fail on %q", wrong), func(t *testing.T) {
+			_, err := parseToOffsets([]string{wrong})
+			require.ErrorIs(err, errWrongToPLogOffset)
+		})
+	}
+}
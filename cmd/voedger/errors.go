@@ -0,0 +1,11 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package main
+
+import "errors"
+
+var errWrongToPLogOffset = errors.New("wrong --to-plog-offset value")
+
+var errPointInTimeRestoreNotSupported = errors.New("point-in-time restore is supported for built-in applications only")
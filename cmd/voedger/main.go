@@ -29,6 +29,9 @@ func execRootCmd(args []string, ver string) error {
 		args,
 		ver,
 		newServerCmd(),
+		newMigrateCmd(),
+		newBackupCmd(),
+		newRestoreCmd(),
 	)
 
 	return cobrau.ExecCommandAndCatchInterrupt(rootCmd)
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/untillpro/goutils/logger"
+
+	"github.com/voedger/voedger/pkg/apps"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istoragemigrate"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func newMigrateCmd() *cobra.Command {
+	var fromParams, toParams apps.CLIParams
+	var app string
+	var params istoragemigrate.Params
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy application storage to another storage, e.g. from bbolt to cas",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if params.App, err = istructs.ParseAppQName(app); err != nil {
+				return err
+			}
+			from, err := cliStorageProvider(fromParams)
+			if err != nil {
+				return fmt.Errorf("source storage: %w", err)
+			}
+			to, err := cliStorageProvider(toParams)
+			if err != nil {
+				return fmt.Errorf("target storage: %w", err)
+			}
+			params.OnPartition = func(pKey []byte, records int) {
+				logger.Verbose(fmt.Sprintf("partition %x: %d records migrated", pKey, records))
+			}
+			res, err := istoragemigrate.Migrate(cmd.Context(), from, to, params)
+			if err != nil {
+				return err
+			}
+			logger.Info(fmt.Sprintf("%s migrated: %d partitions, %d records, %d partitions skipped as migrated before",
+				params.App, res.Partitions, res.Records, res.SkippedPartitions))
+			return nil
+		},
+	}
+	migrateCmd.Flags().StringVar(&app, "app", "", "application to migrate, e.g. untill/airs-bp")
+	migrateCmd.Flags().StringVar(&fromParams.Storage, "from-storage", "", "source storage: cas1, cas3, mem, leveldb or bbolt")
+	migrateCmd.Flags().StringVar(&fromParams.StorageDir, "from-storage-dir", "", "data directory of the embedded source storage")
+	migrateCmd.Flags().StringVar(&toParams.Storage, "to-storage", "", "target storage: cas1, cas3, mem, leveldb or bbolt")
+	migrateCmd.Flags().StringVar(&toParams.StorageDir, "to-storage-dir", "", "data directory of the embedded target storage")
+	migrateCmd.Flags().StringVar(&params.ProgressFile, "progress", "", "file to keep migrated partitions in to resume the interrupted migration")
+	migrateCmd.Flags().IntVar(&params.RecordsPerSecond, "rps", 0, "max records copied per second, zero means unlimited")
+	_ = migrateCmd.MarkFlagRequired("app")
+	_ = migrateCmd.MarkFlagRequired("from-storage")
+	_ = migrateCmd.MarkFlagRequired("to-storage")
+	return migrateCmd
+}
+
+func cliStorageProvider(params apps.CLIParams) (istorage.IAppStorageProvider, error) {
+	factory, err := apps.NewAppStorageFactory(params)
+	if err != nil {
+		return nil, err
+	}
+	return provideAppStorageProvider(factory), nil
+}
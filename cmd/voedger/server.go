@@ -41,6 +41,7 @@ func newServerCmd() *cobra.Command {
 	}
 	serverCmd.PersistentFlags().IntVar(&httpCLIParams.Port, "ihttp.Port", Default_ihttp_Port, "")
 	serverCmd.Flags().StringVar(&appsCLIParams.Storage, "storage", "", "")
+	serverCmd.Flags().StringVar(&appsCLIParams.StorageDir, "storage-dir", "", "")
 	serverCmd.Flags().StringArrayVar((*[]string)(&httpCLIParams.AcmeDomains), "acme-domain", []string{}, "")
 	return serverCmd
 }
@@ -0,0 +1,24 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package appbackup
+
+// archive signature, written before the format version
+const archiveMagic = "VBAK"
+
+const archiveFormatVersion byte = 2
+
+// frame kinds of the archive
+const (
+	frameKind_End byte = iota
+	frameKind_SysView
+	frameKind_PLog
+	frameKind_Data
+)
+
+// max length of key or value in the archive, protects restore against corrupted archives
+const maxDataLen = 64 * 1024 * 1024
+
+// max count of records written to the storage by one batch while restoring
+const restoreBatchSize = 256
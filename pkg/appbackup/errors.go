@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package appbackup
+
+import "errors"
+
+var ErrNotAnArchive = errors.New("not an application backup archive")
+
+var ErrUnsupportedArchiveVersion = errors.New("unsupported archive format version")
+
+var ErrCorruptedArchive = errors.New("archive is corrupted")
+
+var ErrStorageNotEmpty = errors.New("storage to restore to is not empty")
+
+var ErrAppStructsMissed = errors.New("application structures to restore events are missed")
+
+var ErrOnEventMissed = errors.New("event handler to rebuild views of sync projectors is missed")
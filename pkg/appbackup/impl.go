@@ -0,0 +1,327 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package appbackup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+func backup(ctx context.Context, storage istorage.IAppStorage, app istructs.AppQName, partitions int, w io.Writer) (err error) {
+	gz := gzip.NewWriter(w)
+	aw := &archiveWriter{w: gz}
+
+	if err := aw.writeHeader(Header{App: app, Created: time.Now(), Partitions: partitions}); err != nil {
+		return err
+	}
+
+	// WLog, records and views are read first, so they are not ahead of the backed up PLog
+	err = istructsmem.ReadRawData(ctx, storage,
+		func(pKey, cCols, value []byte, ttl time.Duration) error {
+			return aw.writeFrame(frame{kind: frameKind_Data, pKey: pKey, cCols: cCols, value: value, ttl: ttl})
+		})
+	if err != nil {
+		return fmt.Errorf("data backup failed: %w", err)
+	}
+
+	for p := 0; p < partitions; p++ {
+		err := istructsmem.ReadRawPLog(ctx, storage, istructs.PartitionID(p),
+			func(offset istructs.Offset, pKey, cCols, value []byte) error {
+				return aw.writeFrame(frame{kind: frameKind_PLog, partition: istructs.PartitionID(p), offset: offset, pKey: pKey, cCols: cCols, value: value})
+			})
+		if err != nil {
+			return fmt.Errorf("PLog partition %d backup failed: %w", p, err)
+		}
+	}
+
+	// system views are read after PLog to contain all QNames and containers used by the backed up events
+	err = istructsmem.ReadRawSysViews(ctx, storage,
+		func(pKey, cCols, value []byte) error {
+			return aw.writeFrame(frame{kind: frameKind_SysView, pKey: pKey, cCols: cCols, value: value})
+		})
+	if err != nil {
+		return fmt.Errorf("system views backup failed: %w", err)
+	}
+
+	if err := aw.writeFrame(frame{kind: frameKind_End}); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func restore(ctx context.Context, r io.Reader, storage istorage.IAppStorage, opts RestoreOptions) (hdr Header, err error) {
+	pointInTime := opts.ToOffsets != nil
+	if pointInTime {
+		if opts.AppStructs == nil {
+			return hdr, ErrAppStructsMissed
+		}
+		if opts.OnEvent == nil {
+			return hdr, ErrOnEventMissed
+		}
+	}
+	if err := checkEmpty(ctx, storage); err != nil {
+		return hdr, err
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return hdr, fmt.Errorf("%w: %w", ErrNotAnArchive, err)
+	}
+	ar := &archiveReader{r: bufio.NewReader(gz)}
+	if hdr, err = ar.readHeader(); err != nil {
+		return hdr, err
+	}
+
+	partitions, err := restoreRaw(storage, ar, pointInTime, opts.ToOffsets)
+	if err != nil {
+		return hdr, err
+	}
+	if !pointInTime {
+		return hdr, nil
+	}
+
+	as, err := opts.AppStructs()
+	if err != nil {
+		return hdr, err
+	}
+	for _, p := range partitions {
+		if err := restoreEvents(ctx, as, p, opts.OnEvent); err != nil {
+			return hdr, err
+		}
+	}
+
+	return hdr, nil
+}
+
+// checkEmpty returns error if storage contains any system view data
+func checkEmpty(ctx context.Context, storage istorage.IAppStorage) error {
+	errFound := errors.New("found")
+	err := istructsmem.ReadRawSysViews(ctx, storage, func(_, _, _ []byte) error { return errFound })
+	if errors.Is(err, errFound) {
+		return ErrStorageNotEmpty
+	}
+	return err
+}
+
+// restoreRaw writes archived data to the storage.
+// For point-in-time restore only system views and PLog events up to toOffsets are written.
+// Returns sorted partitions of the restored events
+func restoreRaw(storage istorage.IAppStorage, ar *archiveReader, pointInTime bool, toOffsets map[istructs.PartitionID]istructs.Offset) ([]istructs.PartitionID, error) {
+	restored := make(map[istructs.PartitionID]bool)
+	batch := make([]istorage.BatchItem, 0, restoreBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := storage.PutBatch(batch)
+		batch = batch[:0]
+		return err
+	}
+
+	for {
+		f, err := ar.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		if f.kind == frameKind_End {
+			break
+		}
+		switch f.kind {
+		case frameKind_Data:
+			if pointInTime {
+				continue
+			}
+		case frameKind_PLog:
+			if to, ok := toOffsets[f.partition]; ok && f.offset > to {
+				continue
+			}
+			restored[f.partition] = true
+		}
+		batch = append(batch, istorage.BatchItem{PKey: f.pKey, CCols: f.cCols, Value: f.value, TTL: f.ttl})
+		if len(batch) == restoreBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	partitions := make([]istructs.PartitionID, 0, len(restored))
+	for p := range restored {
+		partitions = append(partitions, p)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	return partitions, nil
+}
+
+// restoreEvents restores WLog events and records of the PLog partition.
+//
+// Events are read by batches and restored out of the read callback, since some storages
+// (e.g. bbolt) do not allow to write while the read transaction is open
+func restoreEvents(ctx context.Context, as istructs.IAppStructs, partition istructs.PartitionID, onEvent func(istructs.IAppStructs, istructs.IPLogEvent) error) error {
+	offsets := make([]istructs.Offset, 0, restoreBatchSize)
+	events := make([]istructs.IPLogEvent, 0, restoreBatchSize)
+	for next := istructs.FirstOffset; ; next = offsets[len(offsets)-1] + 1 {
+		offsets, events = offsets[:0], events[:0]
+		err := as.Events().ReadPLog(ctx, partition, next, restoreBatchSize,
+			func(offset istructs.Offset, event istructs.IPLogEvent) error {
+				offsets = append(offsets, offset)
+				events = append(events, event)
+				return nil
+			})
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		for i, event := range events {
+			if err := restoreEvent(as, event, onEvent); err != nil {
+				return fmt.Errorf("PLog partition %d event %d restore failed: %w", partition, offsets[i], err)
+			}
+		}
+	}
+}
+
+// restoreEvent restores WLog event and records changed by the PLog event
+func restoreEvent(as istructs.IAppStructs, event istructs.IPLogEvent, onEvent func(istructs.IAppStructs, istructs.IPLogEvent) error) error {
+	if err := as.Events().PutWlog(event); err != nil {
+		return err
+	}
+	if event.Error().ValidEvent() {
+		if err := as.Records().Apply(event); err != nil {
+			return err
+		}
+	}
+	if onEvent != nil {
+		return onEvent(as, event)
+	}
+	return nil
+}
+
+func (aw *archiveWriter) writeHeader(hdr Header) error {
+	data, err := json.Marshal(hdr)
+	if err != nil {
+		return err
+	}
+	aw.buf = append(aw.buf[:0], archiveMagic...)
+	aw.buf = append(aw.buf, archiveFormatVersion)
+	aw.buf = appendBytes(aw.buf, data)
+	_, err = aw.w.Write(aw.buf)
+	return err
+}
+
+func (aw *archiveWriter) writeFrame(f frame) error {
+	aw.buf = append(aw.buf[:0], f.kind)
+	if f.kind != frameKind_End {
+		if f.kind == frameKind_PLog {
+			aw.buf = binary.AppendUvarint(aw.buf, uint64(f.partition))
+			aw.buf = binary.AppendUvarint(aw.buf, uint64(f.offset))
+		}
+		aw.buf = appendBytes(aw.buf, f.pKey)
+		aw.buf = appendBytes(aw.buf, f.cCols)
+		aw.buf = appendBytes(aw.buf, f.value)
+		if f.kind == frameKind_Data {
+			aw.buf = binary.AppendUvarint(aw.buf, uint64(f.ttl))
+		}
+	}
+	_, err := aw.w.Write(aw.buf)
+	return err
+}
+
+func (ar *archiveReader) readHeader() (hdr Header, err error) {
+	sign := make([]byte, len(archiveMagic)+1)
+	if _, err := io.ReadFull(ar.r, sign); err != nil {
+		return hdr, fmt.Errorf("%w: %w", ErrNotAnArchive, err)
+	}
+	if string(sign[:len(archiveMagic)]) != archiveMagic {
+		return hdr, ErrNotAnArchive
+	}
+	if ver := sign[len(archiveMagic)]; ver != archiveFormatVersion {
+		return hdr, fmt.Errorf("%w: %d", ErrUnsupportedArchiveVersion, ver)
+	}
+	data, err := ar.readBytes()
+	if err != nil {
+		return hdr, err
+	}
+	if err := json.Unmarshal(data, &hdr); err != nil {
+		return hdr, fmt.Errorf("%w: header: %w", ErrCorruptedArchive, err)
+	}
+	return hdr, nil
+}
+
+func (ar *archiveReader) readFrame() (f frame, err error) {
+	if f.kind, err = ar.r.ReadByte(); err != nil {
+		return f, fmt.Errorf("%w: %w", ErrCorruptedArchive, err)
+	}
+	switch f.kind {
+	case frameKind_End:
+		return f, nil
+	case frameKind_PLog:
+		p, err := binary.ReadUvarint(ar.r)
+		if err != nil {
+			return f, fmt.Errorf("%w: %w", ErrCorruptedArchive, err)
+		}
+		ofs, err := binary.ReadUvarint(ar.r)
+		if err != nil {
+			return f, fmt.Errorf("%w: %w", ErrCorruptedArchive, err)
+		}
+		f.partition, f.offset = istructs.PartitionID(p), istructs.Offset(ofs)
+	case frameKind_SysView, frameKind_Data:
+	default:
+		return f, fmt.Errorf("%w: unknown frame kind %d", ErrCorruptedArchive, f.kind)
+	}
+	if f.pKey, err = ar.readBytes(); err != nil {
+		return f, err
+	}
+	if f.cCols, err = ar.readBytes(); err != nil {
+		return f, err
+	}
+	if f.value, err = ar.readBytes(); err != nil {
+		return f, err
+	}
+	if f.kind == frameKind_Data {
+		ttl, err := binary.ReadUvarint(ar.r)
+		if err != nil {
+			return f, fmt.Errorf("%w: %w", ErrCorruptedArchive, err)
+		}
+		f.ttl = time.Duration(ttl)
+	}
+	return f, nil
+}
+
+func (ar *archiveReader) readBytes() ([]byte, error) {
+	l, err := binary.ReadUvarint(ar.r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptedArchive, err)
+	}
+	if l > maxDataLen {
+		return nil, fmt.Errorf("%w: data length %d exceeds %d", ErrCorruptedArchive, l, maxDataLen)
+	}
+	data := make([]byte, l)
+	if _, err := io.ReadFull(ar.r, data); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptedArchive, err)
+	}
+	return data, nil
+}
+
+func appendBytes(buf, data []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
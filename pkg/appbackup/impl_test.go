@@ -0,0 +1,295 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package appbackup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/bbolt"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+)
+
+var (
+	testApp     = istructs.AppQName_test1_app1
+	testDoc     = appdef.NewQName("test", "doc")
+	testCmd     = appdef.NewQName("test", "cmd")
+	testView    = appdef.NewQName("test", "amounts") // view of the sync projector, see project()
+	testAmount  = "Amount"
+	testWS      = "WS"
+	testOffset  = "Offset"
+	testWSID    = map[istructs.PartitionID]istructs.WSID{0: 1, 1: 2}
+	testAmounts = []int32{10, 20, 30} // one event per amount: create doc, then update it
+	testTTL     = time.Hour           // TTL of the view record of the last event
+)
+
+// appStructs returns application structures for the storage, application configuration is bound to the storage, so it is new each time
+func appStructs(storage istorage.IAppStorageProvider) (istructs.IAppStructs, error) {
+	adb := appdef.New()
+	adb.AddCDoc(testDoc).AddField(testAmount, appdef.DataKind_int32, true)
+	adb.AddCommand(testCmd)
+	v := adb.AddView(testView)
+	v.KeyBuilder().PartKeyBuilder().AddField(testWS, appdef.DataKind_int64)
+	v.KeyBuilder().ClustColsBuilder().AddField(testOffset, appdef.DataKind_int64)
+	v.ValueBuilder().AddField(testAmount, appdef.DataKind_int32, true)
+
+	cfgs := make(istructsmem.AppConfigsType)
+	cfg := cfgs.AddConfig(testApp, adb)
+	cfg.Resources.Add(istructsmem.NewCommandFunction(testCmd, istructsmem.NullCommandExec))
+
+	provider := istructsmem.Provide(cfgs, iratesce.TestBucketsFactory,
+		payloads.ProvideIAppTokensFactory(itokensjwt.TestTokensJWT()), storage)
+	return provider.AppStructs(testApp)
+}
+
+// project puts amount of the event doc to the view as the sync projector does
+func project(as istructs.IAppStructs, event istructs.IPLogEvent) (err error) {
+	event.CUDs(func(rec istructs.ICUDRow) {
+		kb := as.ViewRecords().KeyBuilder(testView)
+		kb.PutInt64(testWS, int64(event.Workspace()))
+		kb.PutInt64(testOffset, int64(event.WLogOffset()))
+		vb := as.ViewRecords().NewValueBuilder(testView)
+		vb.PutInt32(testAmount, rec.AsInt32(testAmount))
+		ttl := time.Duration(0)
+		if int(event.WLogOffset()) == len(testAmounts) {
+			ttl = testTTL
+		}
+		err = errors.Join(err, as.ViewRecords().PutWithTTL(event.Workspace(), kb, vb, ttl))
+	})
+	return err
+}
+
+// viewAmounts returns amounts of the workspace view
+func viewAmounts(t *testing.T, as istructs.IAppStructs, ws istructs.WSID) []int32 {
+	amounts := []int32{}
+	kb := as.ViewRecords().KeyBuilder(testView)
+	kb.PutInt64(testWS, int64(ws))
+	require.NoError(t, as.ViewRecords().Read(context.Background(), ws, kb, func(_ istructs.IKey, v istructs.IValue) error {
+		amounts = append(amounts, v.AsInt32(testAmount))
+		return nil
+	}))
+	return amounts
+}
+
+// fillApp puts events to PLog and WLog, applies them to records and projects to the view as the command processor does. Returns IDs of the docs
+func fillApp(t *testing.T, as istructs.IAppStructs) map[istructs.PartitionID]istructs.RecordID {
+	require := require.New(t)
+	ids := make(map[istructs.PartitionID]istructs.RecordID)
+	gen := istructsmem.NewIDGenerator()
+	for p, ws := range testWSID {
+		for i, amount := range testAmounts {
+			ofs := istructs.Offset(i) + istructs.FirstOffset
+			bld := as.Events().GetSyncRawEventBuilder(istructs.SyncRawEventBuilderParams{
+				GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+					HandlingPartition: p,
+					PLogOffset:        ofs,
+					Workspace:         ws,
+					WLogOffset:        ofs,
+					QName:             testCmd,
+				},
+			})
+			if i == 0 {
+				doc := bld.CUDBuilder().Create(testDoc)
+				doc.PutRecordID(appdef.SystemField_ID, 1)
+				doc.PutInt32(testAmount, amount)
+			} else {
+				rec, err := as.Records().Get(ws, true, ids[p])
+				require.NoError(err)
+				bld.CUDBuilder().Update(rec).PutInt32(testAmount, amount)
+			}
+			rawEvent, buildErr := bld.BuildRawEvent()
+			require.NoError(buildErr)
+			event, err := as.Events().PutPlog(rawEvent, nil, gen)
+			require.NoError(err)
+			require.NoError(as.Events().PutWlog(event))
+			require.NoError(as.Records().Apply2(event, func(rec istructs.IRecord) { ids[p] = rec.ID() }))
+			require.NoError(project(as, event))
+		}
+	}
+	return ids
+}
+
+func backupApp(t *testing.T) (archive []byte, storage istorage.IAppStorage, ids map[istructs.PartitionID]istructs.RecordID) {
+	asp := istorageimpl.Provide(mem.Provide())
+	as, err := appStructs(asp)
+	require.NoError(t, err)
+	ids = fillApp(t, as)
+
+	storage, err = asp.AppStorage(testApp)
+	require.NoError(t, err)
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, Backup(context.Background(), storage, testApp, len(testWSID), buf))
+	return buf.Bytes(), storage, ids
+}
+
+func restoreApp(t *testing.T, asp istorage.IAppStorageProvider, archive []byte, opts RestoreOptions) (istructs.IAppStructs, Header, error) {
+	storage, err := asp.AppStorage(testApp)
+	require.NoError(t, err)
+	var as istructs.IAppStructs
+	if opts.ToOffsets != nil {
+		opts.AppStructs = func() (istructs.IAppStructs, error) {
+			as, err = appStructs(asp)
+			return as, err
+		}
+	}
+	hdr, err := Restore(context.Background(), bytes.NewReader(archive), storage, opts)
+	if err == nil && as == nil {
+		as, err = appStructs(asp)
+	}
+	return as, hdr, err
+}
+
+// storageData returns all records of the storage with their TTL rounded to minutes
+func storageData(t *testing.T, storage istorage.IAppStorage) map[string]time.Duration {
+	data := make(map[string]time.Duration)
+	pKeys := [][]byte{}
+	require.NoError(t, storage.(istorage.IAppStorageScanner).ScanPartitions(context.Background(), func(pKey []byte) error {
+		pKeys = append(pKeys, bytes.Clone(pKey))
+		return nil
+	}))
+	for _, pKey := range pKeys {
+		require.NoError(t, storage.Read(context.Background(), pKey, nil, nil, func(cCols, value []byte) error {
+			_, ttl, err := storage.TTLGet(pKey, cCols, new([]byte))
+			data[fmt.Sprintf("%x/%x/%x", pKey, cCols, value)] = ttl.Round(time.Minute)
+			return err
+		}))
+	}
+	return data
+}
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	archive, source, ids := backupApp(t)
+
+	storages := map[string]istorage.IAppStorageFactory{
+		"mem":   mem.Provide(),
+		"bbolt": bbolt.Provide(bbolt.ParamsType{DBDir: t.TempDir()}),
+	}
+	for name, factory := range storages {
+		t.Run(name, func(t *testing.T) {
+			asp := istorageimpl.Provide(factory)
+			storage, err := asp.AppStorage(testApp)
+			require.NoError(err)
+
+			hdr, err := Restore(context.Background(), bytes.NewReader(archive), storage, RestoreOptions{})
+			require.NoError(err)
+			require.Equal(testApp, hdr.App)
+			require.Equal(len(testWSID), hdr.Partitions)
+
+			t.Run("restored storage must be equal to the backed up one", func(t *testing.T) {
+				require.Equal(storageData(t, source), storageData(t, storage))
+			})
+
+			as, err := appStructs(asp)
+			require.NoError(err)
+			for p, ws := range testWSID {
+				rec, err := as.Records().Get(ws, true, ids[p])
+				require.NoError(err)
+				require.Equal(testDoc, rec.QName())
+				require.Equal(testAmounts[len(testAmounts)-1], rec.AsInt32(testAmount))
+
+				wlog := 0
+				require.NoError(as.Events().ReadWLog(context.Background(), ws, istructs.FirstOffset, istructs.ReadToTheEnd,
+					func(istructs.Offset, istructs.IWLogEvent) error {
+						wlog++
+						return nil
+					}))
+				require.Equal(len(testAmounts), wlog)
+
+				require.Equal(testAmounts, viewAmounts(t, as, ws))
+			}
+		})
+	}
+}
+
+func TestPointInTimeRestore(t *testing.T) {
+	require := require.New(t)
+	archive, _, ids := backupApp(t)
+
+	restored := make(map[istructs.WSID][]istructs.Offset)
+	as, _, err := restoreApp(t, istorageimpl.Provide(mem.Provide()), archive, RestoreOptions{
+		ToOffsets: map[istructs.PartitionID]istructs.Offset{0: 2},
+		OnEvent: func(as istructs.IAppStructs, event istructs.IPLogEvent) error {
+			restored[event.Workspace()] = append(restored[event.Workspace()], event.WLogOffset())
+			return project(as, event)
+		},
+	})
+	require.NoError(err)
+	require.Equal([]istructs.Offset{1, 2}, restored[testWSID[0]])
+	require.Equal([]istructs.Offset{1, 2, 3}, restored[testWSID[1]])
+
+	rec, err := as.Records().Get(testWSID[0], true, ids[0])
+	require.NoError(err)
+	require.Equal(testAmounts[1], rec.AsInt32(testAmount))
+	require.Equal(testAmounts[:2], viewAmounts(t, as, testWSID[0]))
+
+	plog := 0
+	require.NoError(as.Events().ReadPLog(context.Background(), 0, istructs.FirstOffset, istructs.ReadToTheEnd,
+		func(istructs.Offset, istructs.IPLogEvent) error {
+			plog++
+			return nil
+		}))
+	require.Equal(2, plog)
+
+	// partitions missed in ToOffsets are restored completely
+	rec, err = as.Records().Get(testWSID[1], true, ids[1])
+	require.NoError(err)
+	require.Equal(testAmounts[2], rec.AsInt32(testAmount))
+	require.Equal(testAmounts, viewAmounts(t, as, testWSID[1]))
+}
+
+func TestRestoreErrors(t *testing.T) {
+	require := require.New(t)
+	archive, _, _ := backupApp(t)
+
+	t.Run("should be error if storage is not empty", func(t *testing.T) {
+		asp := istorageimpl.Provide(mem.Provide())
+		_, _, err := restoreApp(t, asp, archive, RestoreOptions{})
+		require.NoError(err)
+		_, _, err = restoreApp(t, asp, archive, RestoreOptions{})
+		require.ErrorIs(err, ErrStorageNotEmpty)
+	})
+
+	t.Run("should be error if application structures missed for point-in-time restore", func(t *testing.T) {
+		storage, err := istorageimpl.Provide(mem.Provide()).AppStorage(testApp)
+		require.NoError(err)
+		_, err = Restore(context.Background(), bytes.NewReader(archive), storage, RestoreOptions{
+			ToOffsets: map[istructs.PartitionID]istructs.Offset{},
+			OnEvent:   project,
+		})
+		require.ErrorIs(err, ErrAppStructsMissed)
+	})
+
+	t.Run("should be error if event handler missed for point-in-time restore", func(t *testing.T) {
+		_, _, err := restoreApp(t, istorageimpl.Provide(mem.Provide()), archive, RestoreOptions{
+			ToOffsets: map[istructs.PartitionID]istructs.Offset{},
+		})
+		require.ErrorIs(err, ErrOnEventMissed)
+	})
+
+	t.Run("should be error if not an archive", func(t *testing.T) {
+		_, _, err := restoreApp(t, istorageimpl.Provide(mem.Provide()), []byte("not an archive"), RestoreOptions{})
+		require.ErrorIs(err, ErrNotAnArchive)
+	})
+
+	t.Run("should be error if archive is truncated", func(t *testing.T) {
+		_, _, err := restoreApp(t, istorageimpl.Provide(mem.Provide()), archive[:len(archive)/2], RestoreOptions{})
+		require.ErrorIs(err, ErrCorruptedArchive)
+	})
+}
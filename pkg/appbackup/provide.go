@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package appbackup
+
+import (
+	"context"
+	"io"
+
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Backup writes logical backup of the application storage to the compressed archive.
+//
+// Archive contains WLog, records, views and projectors offsets, PLog events of the given partitions and system views (QNames, containers, singletons).
+// Storage must implement istorage.IAppStorageScanner.
+// Application should be stopped while backup is made, otherwise WLog, records and views can be archived behind PLog.
+// PLog and system views are archived consistently anyway, so the online backup can be restored by point-in-time restore
+func Backup(ctx context.Context, storage istorage.IAppStorage, app istructs.AppQName, partitions int, w io.Writer) error {
+	return backup(ctx, storage, app, partitions, w)
+}
+
+// Restore restores the archive made by Backup() into the fresh (empty) application storage.
+//
+// If opts.ToOffsets is nil, all archived data is restored as is.
+// Otherwise events after opts.ToOffsets are not restored (point-in-time restore): WLog events and records
+// are rebuilt by replaying restored PLog events with opts.AppStructs, views of sync projectors are rebuilt by opts.OnEvent,
+// views of async projectors are rebuilt by actualizers.
+// Returns archive header
+func Restore(ctx context.Context, r io.Reader, storage istorage.IAppStorage, opts RestoreOptions) (Header, error) {
+	return restore(ctx, r, storage, opts)
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package appbackup
+
+import (
+	"bufio"
+	"io"
+	"time"
+
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Header of the archive
+type Header struct {
+	App        istructs.AppQName
+	Created    time.Time
+	Partitions int
+}
+
+// Options of the restore.
+//
+// If ToOffsets is nil, the whole archive is restored as is and other options are not used.
+// Otherwise the point-in-time restore is made: WLog, records and views are rebuilt by replaying PLog events
+type RestoreOptions struct {
+	// Point-in-time restore: last PLog offset to restore for partition.
+	// Partitions missed in the map are restored completely
+	ToOffsets map[istructs.PartitionID]istructs.Offset
+
+	// Required for point-in-time restore. Returns application structures for the restored storage.
+	// Called after PLog and system views are restored, must not be called before that since
+	// application structures preparation writes new QNames and containers to the storage
+	AppStructs func() (istructs.IAppStructs, error)
+
+	// Required for point-in-time restore. Called for each restored event after its WLog event and records are restored.
+	// Must rebuild views of sync projectors, e.g. by sync actualizer
+	OnEvent func(as istructs.IAppStructs, event istructs.IPLogEvent) error
+}
+
+type archiveWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+type archiveReader struct {
+	r *bufio.Reader
+}
+
+// frame of the archive
+type frame struct {
+	kind      byte
+	partition istructs.PartitionID
+	offset    istructs.Offset
+	pKey      []byte
+	cCols     []byte
+	value     []byte
+	ttl       time.Duration
+}
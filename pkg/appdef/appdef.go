@@ -18,13 +18,16 @@ type appDef struct {
 	comment
 	types        map[QName]interface{}
 	typesOrdered []interface{}
+	typeTags     map[QName]QNames
 	wsDesc       map[QName]IWorkspace
+	compiler     IExpressionCompiler
 }
 
 func newAppDef() *appDef {
 	app := appDef{
-		types:  make(map[QName]interface{}),
-		wsDesc: make(map[QName]IWorkspace),
+		types:    make(map[QName]interface{}),
+		typeTags: make(map[QName]QNames),
+		wsDesc:   make(map[QName]IWorkspace),
 	}
 	app.makeSysPackage()
 	return &app
@@ -57,6 +60,10 @@ func (app *appDef) AddGRecord(name QName) IGRecordBuilder {
 	return newGRecord(app, name)
 }
 
+func (app *appDef) AddLimit(name QName, on []QName, rate QName) ILimitBuilder {
+	return newLimit(app, name, on, rate)
+}
+
 func (app *appDef) AddObject(name QName) IObjectBuilder {
 	return newObject(app, name)
 }
@@ -77,12 +84,24 @@ func (app *appDef) AddQuery(name QName) IQueryBuilder {
 	return newQuery(app, name)
 }
 
+func (app *appDef) AddRate(name QName, count RateCount, period RatePeriod, scopes ...RateScope) IRateBuilder {
+	return newRate(app, name, count, period, scopes)
+}
+
+func (app *appDef) AddRole(name QName) IRoleBuilder {
+	return newRole(app, name)
+}
+
 func (app *appDef) AddSingleton(name QName) ICDocBuilder {
 	doc := newCDoc(app, name)
 	doc.SetSingleton()
 	return doc
 }
 
+func (app *appDef) AddTag(name QName) ITagBuilder {
+	return newTag(app, name)
+}
+
 func (app *appDef) AddView(name QName) IViewBuilder {
 	return newView(app, name)
 }
@@ -107,6 +126,18 @@ func (app *appDef) Build() (result IAppDef, err error) {
 		return nil, err
 	}
 
+	app.Structures(func(s IStructure) {
+		err = errors.Join(err, s.(interface {
+			compileChecks(IExpressionCompiler) error
+		}).compileChecks(app.compiler))
+		err = errors.Join(err, s.(interface {
+			compileGenerated(IExpressionCompiler) error
+		}).compileGenerated(app.compiler))
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return app, nil
 }
 
@@ -170,6 +201,21 @@ func (app *appDef) GRecord(name QName) IGRecord {
 	return nil
 }
 
+func (app *appDef) Limit(name QName) ILimit {
+	if t := app.typeByKind(name, TypeKind_Limit); t != nil {
+		return t.(ILimit)
+	}
+	return nil
+}
+
+func (app *appDef) Limits(cb func(ILimit)) {
+	app.Types(func(t IType) {
+		if l, ok := t.(ILimit); ok {
+			cb(l)
+		}
+	})
+}
+
 func (app *appDef) Object(name QName) IObject {
 	if t := app.typeByKind(name, TypeKind_Object); t != nil {
 		return t.(IObject)
@@ -230,6 +276,40 @@ func (app *appDef) Records(cb func(IRecord)) {
 	})
 }
 
+func (app *appDef) Rate(name QName) IRate {
+	if t := app.typeByKind(name, TypeKind_Rate); t != nil {
+		return t.(IRate)
+	}
+	return nil
+}
+
+func (app *appDef) Rates(cb func(IRate)) {
+	app.Types(func(t IType) {
+		if r, ok := t.(IRate); ok {
+			cb(r)
+		}
+	})
+}
+
+func (app *appDef) Role(name QName) IRole {
+	if t := app.typeByKind(name, TypeKind_Role); t != nil {
+		return t.(IRole)
+	}
+	return nil
+}
+
+func (app *appDef) Roles(cb func(IRole)) {
+	app.Types(func(t IType) {
+		if r, ok := t.(IRole); ok {
+			cb(r)
+		}
+	})
+}
+
+func (app *appDef) SetExpressionCompiler(c IExpressionCompiler) {
+	app.compiler = c
+}
+
 func (app *appDef) Structures(cb func(s IStructure)) {
 	app.Types(func(t IType) {
 		if s, ok := t.(IStructure); ok {
@@ -245,6 +325,25 @@ func (app *appDef) SysData(k DataKind) IData {
 	return nil
 }
 
+func (app *appDef) Tag(name QName) ITag {
+	if t := app.typeByKind(name, TypeKind_Tag); t != nil {
+		return t.(ITag)
+	}
+	return nil
+}
+
+func (app *appDef) Tags(cb func(ITag)) {
+	app.Types(func(t IType) {
+		if t.Kind() == TypeKind_Tag {
+			cb(t.(ITag))
+		}
+	})
+}
+
+func (app *appDef) TagsOf(name QName) QNames {
+	return app.typeTags[name]
+}
+
 func (app *appDef) Type(name QName) IType {
 	if t := app.TypeByName(name); t != nil {
 		return t
@@ -281,6 +380,15 @@ func (app *appDef) Types(cb func(IType)) {
 	}
 }
 
+func (app *appDef) TypesWithTag(tag QName) (names QNames) {
+	app.Types(func(t IType) {
+		if app.typeTags[t.QName()].Contains(tag) {
+			names = append(names, t.QName())
+		}
+	})
+	return names
+}
+
 func (app *appDef) View(name QName) IView {
 	if t := app.typeByKind(name, TypeKind_ViewRecord); t != nil {
 		return t.(IView)
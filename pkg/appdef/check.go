@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"errors"
+	"fmt"
+)
+
+// # Implements:
+//   - ICheck
+type check struct {
+	comment
+	emb  interface{}
+	name string
+	expr string
+	eval CheckFunc
+}
+
+func newCheck(embeds interface{}, name, expr string) *check {
+	return &check{
+		emb:  embeds,
+		name: name,
+		expr: expr,
+	}
+}
+
+func (c check) Evaluate(row ICheckRow) bool {
+	return c.eval(row)
+}
+
+func (c check) Expression() string {
+	return c.expr
+}
+
+func (c check) Name() string {
+	return c.name
+}
+
+func (c check) ParentStructure() IStructure {
+	return c.emb.(IStructure)
+}
+
+// # Implements:
+//   - IChecks
+//   - IChecksBuilder
+type checks struct {
+	emb           interface{}
+	checks        map[string]*check
+	checksOrdered []*check
+}
+
+func makeChecks(embeds interface{}) checks {
+	c := checks{
+		emb:    embeds,
+		checks: make(map[string]*check),
+	}
+	return c
+}
+
+func (cc *checks) AddCheck(name, expression string, comment ...string) IChecksBuilder {
+	if name == NullName {
+		panic(fmt.Errorf("%v: check name cannot be empty: %w", cc.embeds(), ErrNameMissed))
+	}
+	if ok, err := ValidIdent(name); !ok {
+		panic(fmt.Errorf("%v: check name «%v» is invalid: %w", cc.embeds(), name, err))
+	}
+	if cc.Check(name) != nil {
+		panic(fmt.Errorf("%v: check «%v» is already exists: %w", cc.embeds(), name, ErrNameUniqueViolation))
+	}
+	if expression == "" {
+		panic(fmt.Errorf("%v: check «%v» expression is empty: %w", cc.embeds(), name, ErrNameMissed))
+	}
+	if len(cc.checksOrdered) >= MaxTypeCheckCount {
+		panic(fmt.Errorf("%v: maximum checks (%d) is exceeded: %w", cc.embeds(), MaxTypeCheckCount, ErrTooManyChecks))
+	}
+
+	c := newCheck(cc.emb, name, expression)
+	c.SetComment(comment...)
+
+	cc.checks[name] = c
+	cc.checksOrdered = append(cc.checksOrdered, c)
+
+	return cc.emb.(IChecksBuilder)
+}
+
+func (cc *checks) Check(name string) ICheck {
+	if c, ok := cc.checks[name]; ok {
+		return c
+	}
+	return nil
+}
+
+func (cc *checks) CheckCount() int {
+	return len(cc.checksOrdered)
+}
+
+func (cc *checks) Checks(cb func(ICheck)) {
+	for _, c := range cc.checksOrdered {
+		cb(c)
+	}
+}
+
+// Compiles expressions of all checks
+func (cc *checks) compileChecks(compiler IExpressionCompiler) (err error) {
+	for _, c := range cc.checksOrdered {
+		if compiler == nil {
+			return fmt.Errorf("%v: unable to compile check «%s»: %w", cc.embeds(), c.name, ErrExpressionCompilerMissed)
+		}
+		eval, e := compiler.CompileCheck(c.expr, cc.emb.(IFields))
+		if e != nil {
+			err = errors.Join(err, fmt.Errorf("%v: unable to compile check «%s»: %w", cc.embeds(), c.name, e))
+			continue
+		}
+		c.eval = eval
+	}
+	return err
+}
+
+func (cc *checks) embeds() IStructure {
+	return cc.emb.(IStructure)
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Compiles expressions like "field > 0" only
+type testCompiler struct{}
+
+func (testCompiler) CompileCheck(expression string, fields IFields) (CheckFunc, error) {
+	var name string
+	if _, err := fmt.Sscanf(expression, "%s > 0", &name); err != nil {
+		return nil, err
+	}
+	if fields.Field(name) == nil {
+		return nil, fmt.Errorf("unknown field «%s»: %w", name, ErrNameNotFound)
+	}
+	return func(row ICheckRow) bool { return !row.HasValue(name) || (row.AsFloat64(name) > 0) }, nil
+}
+
+func (testCompiler) CompileGenerated(expression string, fields IFields, field string) (GeneratedFunc, error) {
+	var name string
+	if _, err := fmt.Sscanf(expression, "%s * 2", &name); err != nil {
+		return nil, err
+	}
+	if fields.Field(name) == nil {
+		return nil, fmt.Errorf("unknown field «%s»: %w", name, ErrNameNotFound)
+	}
+	return func(row ICheckRow) (interface{}, error) {
+		if !row.HasValue(name) {
+			return nil, nil
+		}
+		return row.AsFloat64(name) * 2, nil
+	}, nil
+}
+
+type testCheckRow struct {
+	ICheckRow
+	values map[string]float64
+}
+
+func (r testCheckRow) HasValue(name string) bool {
+	_, ok := r.values[name]
+	return ok
+}
+
+func (r testCheckRow) AsFloat64(name string) float64 { return r.values[name] }
+
+func Test_AddCheck(t *testing.T) {
+	require := require.New(t)
+
+	qName := NewQName("test", "deal")
+
+	appDef := New()
+	appDef.SetExpressionCompiler(testCompiler{})
+
+	doc := appDef.AddCDoc(qName)
+	doc.
+		AddField("price", DataKind_float64, true).
+		AddField("discount", DataKind_float64, false)
+	doc.
+		AddCheck("positivePrice", "price > 0").
+		AddCheck("positiveDiscount", "discount > 0", "discount should be positive")
+
+	t.Run("should be ok to build type with checks", func(t *testing.T) {
+		app, err := appDef.Build()
+		require.NoError(err)
+
+		doc := app.CDoc(qName)
+		require.Equal(2, doc.CheckCount())
+
+		c := doc.Check("positiveDiscount")
+		require.NotNil(c)
+		require.Equal(qName, c.ParentStructure().QName())
+		require.Equal("positiveDiscount", c.Name())
+		require.Equal("discount > 0", c.Expression())
+		require.Equal("discount should be positive", c.Comment())
+
+		require.Nil(doc.Check("unknown"))
+
+		names := []string{}
+		doc.Checks(func(c ICheck) { names = append(names, c.Name()) })
+		require.Equal([]string{"positivePrice", "positiveDiscount"}, names)
+	})
+
+	t.Run("should be ok to evaluate compiled checks", func(t *testing.T) {
+		app, err := appDef.Build()
+		require.NoError(err)
+
+		violated := func(values map[string]float64) (names []string) {
+			app.CDoc(qName).Checks(func(c ICheck) {
+				if !c.Evaluate(testCheckRow{values: values}) {
+					names = append(names, c.Name())
+				}
+			})
+			return names
+		}
+		require.Empty(violated(map[string]float64{"price": 1}))
+		require.Equal([]string{"positivePrice", "positiveDiscount"}, violated(map[string]float64{"price": 0, "discount": -1}))
+	})
+
+	t.Run("should be build errors", func(t *testing.T) {
+		t.Run("if expression compiler is not set", func(t *testing.T) {
+			app := New()
+			doc := app.AddCDoc(qName)
+			doc.AddField("price", DataKind_float64, true)
+			doc.AddCheck("positivePrice", "price > 0")
+			_, err := app.Build()
+			require.ErrorIs(err, ErrExpressionCompilerMissed)
+		})
+
+		t.Run("if check can not be compiled", func(t *testing.T) {
+			app := New()
+			app.SetExpressionCompiler(testCompiler{})
+			doc := app.AddCDoc(qName)
+			doc.AddField("price", DataKind_float64, true)
+			doc.AddCheck("positiveCost", "cost > 0")
+			_, err := app.Build()
+			require.ErrorIs(err, ErrNameNotFound)
+			require.ErrorContains(err, "unable to compile check «positiveCost»")
+		})
+	})
+
+	t.Run("should be panics", func(t *testing.T) {
+		require.Panics(func() {
+			doc.AddCheck("", "price > 0")
+		}, "if empty check name")
+
+		require.Panics(func() {
+			doc.AddCheck("naked-🔫", "price > 0")
+		}, "if invalid check name")
+
+		require.Panics(func() {
+			doc.AddCheck("positivePrice", "price >= 0")
+		}, "if check with name is already exists")
+
+		require.Panics(func() {
+			doc.AddCheck("emptyCheck", "")
+		}, "if check expression is empty")
+
+		t.Run("if too many checks", func(t *testing.T) {
+			rec := New().AddCRecord(NewQName("test", "rec"))
+			rec.AddField("f", DataKind_int32, false)
+			for i := 0; i < MaxTypeCheckCount; i++ {
+				rec.AddCheck(fmt.Sprintf("check%d", i), "f > 0")
+			}
+			require.Panics(func() { rec.AddCheck("lastStraw", "f > 0") })
+		})
+	})
+}
@@ -7,6 +7,7 @@ package appdef
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -142,6 +143,34 @@ func Test_AppDef_AddCommand(t *testing.T) {
 		require.Panics(func() { cmd.SetEngine(ExtensionEngineKind_null) })
 		require.Panics(func() { cmd.SetEngine(ExtensionEngineKind_Count) })
 	})
+
+	t.Run("panic if limits are set for not WASM extension", func(t *testing.T) {
+		apb := New()
+		cmd := apb.AddCommand(NewQName("test", "cmd"))
+		require.Panics(func() { cmd.SetLimits(ExtensionLimits{MaxIntents: 1}) })
+		require.NotPanics(func() { cmd.SetLimits(ExtensionLimits{}) })
+	})
+}
+
+func Test_ExtensionLimits(t *testing.T) {
+	require := require.New(t)
+
+	cmdName := NewQName("test", "cmd")
+	limits := ExtensionLimits{
+		MaxDuration:    100 * time.Millisecond,
+		MaxHostCalls:   1000,
+		MaxIntents:     10,
+		MaxMemoryPages: 64,
+	}
+
+	adb := New()
+	cmd := adb.AddCommand(cmdName)
+	require.Zero(cmd.Limits())
+	cmd.SetEngine(ExtensionEngineKind_WASM).SetLimits(limits)
+
+	app, err := adb.Build()
+	require.NoError(err)
+	require.Equal(limits, app.Command(cmdName).Limits())
 }
 
 func Test_CommandValidate(t *testing.T) {
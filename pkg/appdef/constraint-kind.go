@@ -28,6 +28,9 @@ const (
 
 	ConstraintKind_Enum
 
+	ConstraintKind_Precision
+	ConstraintKind_Scale
+
 	ConstraintKind_Count
 )
 
@@ -104,6 +104,51 @@ func MaxExcl(v float64, c ...string) IConstraint {
 	return newDataConstraint(ConstraintKind_MaxExcl, v, c...)
 }
 
+// Return new precision constraint for decimal data type.
+//
+// Precision is the total number of significant digits, both before and after the decimal point.
+//
+// # Panics:
+//   - if value is zero
+//   - if value is greater than MaxDecimalPrecision (18)
+func Precision(v uint8, c ...string) IConstraint {
+	if v == 0 {
+		panic(fmt.Errorf("decimal precision value is zero: %w", ErrIncompatibleConstraints))
+	}
+	if v > MaxDecimalPrecision {
+		panic(fmt.Errorf("decimal precision value %d exceeds maximum %d: %w", v, MaxDecimalPrecision, ErrIncompatibleConstraints))
+	}
+	return newDataConstraint(ConstraintKind_Precision, v, c...)
+}
+
+// Return new scale constraint for decimal data type.
+//
+// Scale is the number of digits after the decimal point. Scale should not exceed precision.
+//
+// # Panics:
+//   - if value is greater than MaxDecimalPrecision (18)
+func Scale(v uint8, c ...string) IConstraint {
+	if v > MaxDecimalPrecision {
+		panic(fmt.Errorf("decimal scale value %d exceeds maximum %d: %w", v, MaxDecimalPrecision, ErrIncompatibleConstraints))
+	}
+	return newDataConstraint(ConstraintKind_Scale, v, c...)
+}
+
+// Returns decimal precision and scale from specified constraints.
+//
+// If precision is not specified then DefaultDecimalPrecision is returned.
+// If scale is not specified then zero is returned.
+func DecimalPrecisionScale(cc map[ConstraintKind]IConstraint) (precision, scale uint8) {
+	precision = DefaultDecimalPrecision
+	if c, ok := cc[ConstraintKind_Precision]; ok {
+		precision = c.Value().(uint8)
+	}
+	if c, ok := cc[ConstraintKind_Scale]; ok {
+		scale = c.Value().(uint8)
+	}
+	return precision, scale
+}
+
 type enumerable interface {
 	string | int32 | int64 | float32 | float64
 }
@@ -158,6 +203,10 @@ func NewConstraint(kind ConstraintKind, value any, c ...string) IConstraint {
 		return MaxIncl(value.(float64), c...)
 	case ConstraintKind_MaxExcl:
 		return MaxExcl(value.(float64), c...)
+	case ConstraintKind_Precision:
+		return Precision(value.(uint8), c...)
+	case ConstraintKind_Scale:
+		return Scale(value.(uint8), c...)
 	case ConstraintKind_Enum:
 		var enum IConstraint
 		switch v := value.(type) {
@@ -75,6 +75,14 @@ func TestNewConstraint(t *testing.T) {
 			args{ConstraintKind_Enum, []float64{3, 1, 2, 2, 3}, []string{"test float64 enum"}},
 			args{ConstraintKind_Enum, []float64{1, 2, 3}, []string{"test float64 enum"}},
 		},
+		{"Precision",
+			args{ConstraintKind_Precision, uint8(10), []string{"test precision"}},
+			args{ConstraintKind_Precision, 10, []string{"test precision"}},
+		},
+		{"Scale",
+			args{ConstraintKind_Scale, uint8(2), []string{"test scale"}},
+			args{ConstraintKind_Scale, 2, []string{"test scale"}},
+		},
 	}
 	require := require.New(t)
 	for _, tt := range tests {
@@ -136,6 +144,15 @@ func TestNewConstraintPanics(t *testing.T) {
 		{"Enum([][]byte)",
 			args{ConstraintKind_Enum, [][]byte{{1, 2, 3}, {4, 5, 6}}},
 		},
+		{"Precision(0)",
+			args{ConstraintKind_Precision, uint8(0)},
+		},
+		{"Precision(19)",
+			args{ConstraintKind_Precision, uint8(19)},
+		},
+		{"Scale(19)",
+			args{ConstraintKind_Scale, uint8(19)},
+		},
 		{"???(0)",
 			args{ConstraintKind_Count, 0},
 		},
@@ -148,6 +165,21 @@ func TestNewConstraintPanics(t *testing.T) {
 	}
 }
 
+func TestDecimalPrecisionScale(t *testing.T) {
+	require := require.New(t)
+
+	p, s := DecimalPrecisionScale(nil)
+	require.Equal(DefaultDecimalPrecision, p)
+	require.Zero(s)
+
+	p, s = DecimalPrecisionScale(map[ConstraintKind]IConstraint{
+		ConstraintKind_Precision: Precision(10),
+		ConstraintKind_Scale:     Scale(2),
+	})
+	require.EqualValues(10, p)
+	require.EqualValues(2, s)
+}
+
 func Test_dataConstraint_String(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -163,6 +195,8 @@ func Test_dataConstraint_String(t *testing.T) {
 		{"MaxIncl", MaxIncl(100), "MaxIncl: 100"},
 		{"MaxExcl", MaxExcl(100), "MaxExcl: 100"},
 		{"MaxExcl(+∞)", MaxExcl(math.Inf(+1)), "MaxExcl: +Inf"},
+		{"Precision", Precision(10), "Precision: 10"},
+		{"Scale", Scale(2), "Scale: 2"},
 		{"Enum(string)", Enum("c", "d", "a", "a", "b", "c"), "Enum: [a b c d]"},
 		{"Enum(float64)", Enum(float64(1), 2, 3, 4, math.Round(100*math.Pi)/100, math.Inf(-1)), "Enum: [-Inf 1 2 3 3.14 4]"},
 		{"Enum(long case)", Enum("b", "d", "a", strings.Repeat("c", 100)), "Enum: [a b cccccccccccccccccccccccccccccccccccccccccccccccccccc…"},
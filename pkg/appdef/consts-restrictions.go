@@ -22,6 +22,15 @@ const MaxTypeUniqueFieldsCount = 256
 // Maximum uniques
 const MaxTypeUniqueCount = 100
 
+// Maximum checks per one structured type
+const MaxTypeCheckCount = 100
+
+// Maximum fields per one index
+const MaxIndexFieldCount = 16
+
+// Maximum indexes per one record type
+const MaxTypeIndexCount = 100
+
 // Maximum string and bytes data length
 const MaxFieldLength = uint16(math.MaxUint16)
 
@@ -29,3 +38,11 @@ const MaxFieldLength = uint16(math.MaxUint16)
 //
 // This value is used for MaxLen() constraint in system data types `sys.string` and `sys.bytes`.
 const DefaultFieldMaxLength = uint16(255)
+
+// Maximum decimal precision, total number of significant digits.
+//
+// Decimal values are stored as int64, so precision can not exceed 18 digits.
+const MaxDecimalPrecision = uint8(18)
+
+// Default decimal precision. Used if Precision() constraint is not specified
+const DefaultDecimalPrecision = MaxDecimalPrecision
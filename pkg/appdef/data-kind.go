@@ -33,6 +33,18 @@ const (
 	DataKind_Record
 	DataKind_Event
 
+	// Fixed point number. Stored as int64 unscaled value, precision and scale are specified by constraints
+	DataKind_decimal
+
+	// Calendar date. Stored as int32 days since 1970-01-01
+	DataKind_date
+
+	// Point in time. Stored as int64 milliseconds since 1970-01-01T00:00:00Z
+	DataKind_timestamp
+
+	// Universally unique identifier. Stored as 16 bytes
+	DataKind_UUID
+
 	DataKind_FakeLast
 )
 
@@ -46,7 +58,32 @@ func (k DataKind) IsFixed() bool {
 		DataKind_float64,
 		DataKind_QName,
 		DataKind_bool,
-		DataKind_RecordID:
+		DataKind_RecordID,
+		DataKind_decimal,
+		DataKind_date,
+		DataKind_timestamp,
+		DataKind_UUID:
+		return true
+	}
+	return false
+}
+
+// Returns is data kind can be used as array field item kind.
+//
+// Array items can be numbers (include decimals, dates and timestamps), strings and booleans.
+// Bytes, QNames, UUIDs and references can not be array items.
+func (k DataKind) IsSupportedArrayItem() bool {
+	switch k {
+	case
+		DataKind_int32,
+		DataKind_int64,
+		DataKind_float32,
+		DataKind_float64,
+		DataKind_string,
+		DataKind_bool,
+		DataKind_decimal,
+		DataKind_date,
+		DataKind_timestamp:
 		return true
 	}
 	return false
@@ -71,6 +108,14 @@ func (k DataKind) IsFixed() bool {
 //   - ConstraintKind_MaxIncl
 //   - ConstraintKind_MaxExcl
 //   - ConstraintKind_Enum
+//
+// # Decimal data supports:
+//   - ConstraintKind_Precision
+//   - ConstraintKind_Scale
+//   - ConstraintKind_MinIncl
+//   - ConstraintKind_MinExcl
+//   - ConstraintKind_MaxIncl
+//   - ConstraintKind_MaxExcl
 func (k DataKind) IsSupportedConstraint(c ConstraintKind) bool {
 	switch k {
 	case DataKind_bytes:
@@ -100,6 +145,17 @@ func (k DataKind) IsSupportedConstraint(c ConstraintKind) bool {
 			ConstraintKind_Enum:
 			return true
 		}
+	case DataKind_decimal:
+		switch c {
+		case
+			ConstraintKind_Precision,
+			ConstraintKind_Scale,
+			ConstraintKind_MinIncl,
+			ConstraintKind_MinExcl,
+			ConstraintKind_MaxIncl,
+			ConstraintKind_MaxExcl:
+			return true
+		}
 	}
 	return false
 }
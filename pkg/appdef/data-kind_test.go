@@ -25,6 +25,12 @@ func TestDataKindType_IsFixed(t *testing.T) {
 		{name: "string must be variable",
 			args: args{kind: DataKind_string},
 			want: false},
+		{name: "decimal must be fixed",
+			args: args{kind: DataKind_decimal},
+			want: true},
+		{name: "UUID must be fixed",
+			args: args{kind: DataKind_UUID},
+			want: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -35,6 +41,34 @@ func TestDataKindType_IsFixed(t *testing.T) {
 	}
 }
 
+func TestDataKind_IsSupportedArrayItem(t *testing.T) {
+	tests := []struct {
+		kind DataKind
+		want bool
+	}{
+		{DataKind_int32, true},
+		{DataKind_float64, true},
+		{DataKind_string, true},
+		{DataKind_bool, true},
+		{DataKind_decimal, true},
+		{DataKind_date, true},
+		{DataKind_timestamp, true},
+		{DataKind_null, false},
+		{DataKind_bytes, false},
+		{DataKind_QName, false},
+		{DataKind_RecordID, false},
+		{DataKind_UUID, false},
+		{DataKind_Record, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind.TrimString(), func(t *testing.T) {
+			if got := tt.kind.IsSupportedArrayItem(); got != tt.want {
+				t.Errorf("%v.IsSupportedArrayItem() = %v, want %v", tt.kind, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDataKindType_MarshalText(t *testing.T) {
 	tests := []struct {
 		name string
@@ -52,7 +86,7 @@ func TestDataKindType_MarshalText(t *testing.T) {
 			want: `DataKind_int32`,
 		},
 		{
-			name: `DataKind_FakeLast —> 12`,
+			name: `DataKind_FakeLast —> 16`,
 			k:    DataKind_FakeLast,
 			want: strconv.FormatUint(uint64(DataKind_FakeLast), 10),
 		},
@@ -161,6 +195,20 @@ func TestDataKind_IsSupportedConstraint(t *testing.T) {
 		{"float64: MaxIncl", DataKind_float64, args{ConstraintKind_MaxIncl}, true},
 		{"float64: MaxExcl", DataKind_float64, args{ConstraintKind_MaxExcl}, true},
 		{"float64: Enum", DataKind_float64, args{ConstraintKind_Enum}, true},
+		{"float64: Precision", DataKind_float64, args{ConstraintKind_Precision}, false},
+		//-
+		{"decimal: MaxLen", DataKind_decimal, args{ConstraintKind_MaxLen}, false},
+		{"decimal: MinIncl", DataKind_decimal, args{ConstraintKind_MinIncl}, true},
+		{"decimal: MinExcl", DataKind_decimal, args{ConstraintKind_MinExcl}, true},
+		{"decimal: MaxIncl", DataKind_decimal, args{ConstraintKind_MaxIncl}, true},
+		{"decimal: MaxExcl", DataKind_decimal, args{ConstraintKind_MaxExcl}, true},
+		{"decimal: Enum", DataKind_decimal, args{ConstraintKind_Enum}, false},
+		{"decimal: Precision", DataKind_decimal, args{ConstraintKind_Precision}, true},
+		{"decimal: Scale", DataKind_decimal, args{ConstraintKind_Scale}, true},
+		//-
+		{"date: MinIncl", DataKind_date, args{ConstraintKind_MinIncl}, false},
+		{"timestamp: MinIncl", DataKind_timestamp, args{ConstraintKind_MinIncl}, false},
+		{"UUID: MaxLen", DataKind_UUID, args{ConstraintKind_MaxLen}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -17,15 +17,19 @@ func SysDataName(k DataKind) QName {
 
 var (
 	// System data type names
-	SysData_int32    QName = SysDataName(DataKind_int32)
-	SysData_int64    QName = SysDataName(DataKind_int64)
-	SysData_float32  QName = SysDataName(DataKind_float32)
-	SysData_float64  QName = SysDataName(DataKind_float64)
-	SysData_bytes    QName = SysDataName(DataKind_bytes)
-	SysData_String   QName = SysDataName(DataKind_string)
-	SysData_QName    QName = SysDataName(DataKind_QName)
-	SysData_bool     QName = SysDataName(DataKind_bool)
-	SysData_RecordID QName = SysDataName(DataKind_RecordID)
+	SysData_int32     QName = SysDataName(DataKind_int32)
+	SysData_int64     QName = SysDataName(DataKind_int64)
+	SysData_float32   QName = SysDataName(DataKind_float32)
+	SysData_float64   QName = SysDataName(DataKind_float64)
+	SysData_bytes     QName = SysDataName(DataKind_bytes)
+	SysData_String    QName = SysDataName(DataKind_string)
+	SysData_QName     QName = SysDataName(DataKind_QName)
+	SysData_bool      QName = SysDataName(DataKind_bool)
+	SysData_RecordID  QName = SysDataName(DataKind_RecordID)
+	SysData_decimal   QName = SysDataName(DataKind_decimal)
+	SysData_date      QName = SysDataName(DataKind_date)
+	SysData_timestamp QName = SysDataName(DataKind_timestamp)
+	SysData_UUID      QName = SysDataName(DataKind_UUID)
 )
 
 // Creates and returns new system type by data kind.
@@ -82,6 +82,11 @@ func (d *data) AddConstraints(cc ...IConstraint) IDataBuilder {
 		}
 		d.constraints[ck] = c
 	}
+	if dk == DataKind_decimal {
+		if p, s := DecimalPrecisionScale(d.Constraints(true)); s > p {
+			panic(fmt.Errorf("%v decimal scale %d exceeds precision %d: %w", d, s, p, ErrIncompatibleConstraints))
+		}
+	}
 	return d
 }
 
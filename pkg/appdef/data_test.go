@@ -165,6 +165,7 @@ func Test_AppDef_AddData(t *testing.T) {
 		apb := New()
 		require.Panics(func() { _ = apb.AddData(strName, DataKind_string, NullQName, MinIncl(1)) })
 		require.Panics(func() { _ = apb.AddData(intName, DataKind_float64, NullQName, MaxLen(100)) })
+		require.Panics(func() { _ = apb.AddData(intName, DataKind_decimal, NullQName, Precision(5), Scale(6)) })
 	})
 }
 
@@ -205,6 +206,15 @@ func Test_data_AddConstraint(t *testing.T) {
 			args{DataKind_string, ConstraintKind_Enum, []string{"a", "b", "c"}}, false},
 		{"string: enum constraint must fail if wrong enum type",
 			args{DataKind_float64, ConstraintKind_Enum, []int32{1, 2, 3}}, true},
+		//- Decimal
+		{"decimal: precision constraint must be ok",
+			args{DataKind_decimal, ConstraintKind_Precision, uint8(10)}, false},
+		{"decimal: scale constraint must be ok",
+			args{DataKind_decimal, ConstraintKind_Scale, uint8(2)}, false},
+		{"decimal: scale constraint must be ok up to default precision",
+			args{DataKind_decimal, ConstraintKind_Scale, uint8(18)}, false},
+		{"int64: precision constraint must fail",
+			args{DataKind_int64, ConstraintKind_Precision, uint8(10)}, true},
 	}
 	require := require.New(t)
 	for _, tt := range tests {
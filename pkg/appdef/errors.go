@@ -28,6 +28,12 @@ var ErrTooManyContainers = errors.New("too many containers")
 
 var ErrTooManyUniques = errors.New("too many uniques")
 
+var ErrTooManyChecks = errors.New("too many checks")
+
+var ErrExpressionCompilerMissed = errors.New("expression compiler is missed")
+
+var ErrTooManyIndexes = errors.New("too many indexes")
+
 var ErrInvalidDataKind = errors.New("invalid data kind")
 
 var ErrInvalidOccurs = errors.New("invalid occurs value")
@@ -45,3 +51,9 @@ var ErrWorkspaceShouldBeAbstract = errors.New("workspace should be abstract")
 var ErrInvalidProjectorEventKind = errors.New("invalid projector event kind")
 
 var ErrEmptyProjectorEvents = errors.New("empty projector events")
+
+var ErrInvalidPrivilegeKind = errors.New("invalid privilege kind")
+
+var ErrInvalidRate = errors.New("invalid rate")
+
+var ErrInvalidTTL = errors.New("invalid time to live")
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef_test
+
+import (
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+func ExampleIRole() {
+
+	var app appdef.IAppDef
+	docName, cmdName := appdef.NewQName("test", "doc"), appdef.NewQName("test", "cmd")
+	readerName, writerName := appdef.NewQName("test", "reader"), appdef.NewQName("test", "writer")
+
+	// how to build AppDef with roles
+	{
+		appDef := appdef.New()
+
+		appDef.AddCDoc(docName).
+			AddField("f1", appdef.DataKind_int64, true).
+			AddField("f2", appdef.DataKind_string, false)
+		appDef.AddCommand(cmdName)
+
+		appDef.AddRole(readerName).
+			Grant([]appdef.PrivilegeKind{appdef.PrivilegeKind_Select}, []appdef.QName{docName}, []string{"f1"}, "reader can select f1 only")
+
+		appDef.AddRole(writerName).
+			GrantAll([]appdef.QName{docName, cmdName})
+
+		if a, err := appDef.Build(); err == nil {
+			app = a
+		} else {
+			panic(err)
+		}
+	}
+
+	// how to inspect roles
+	{
+		app.Roles(func(r appdef.IRole) {
+			fmt.Printf("%v:\n", r)
+			r.Privileges(func(p appdef.IPrivilege) {
+				fmt.Printf("- %v %v, fields: %v\n", p.Kinds(), p.On(), p.Fields())
+			})
+		})
+	}
+
+	// how to check privileges
+	{
+		reader := app.Role(readerName)
+		fmt.Println()
+		fmt.Println("reader can select f1:", reader.IsGranted(appdef.PrivilegeKind_Select, docName, "f1"))
+		fmt.Println("reader can select f2:", reader.IsGranted(appdef.PrivilegeKind_Select, docName, "f2"))
+		fmt.Println("reader can execute cmd:", reader.IsGranted(appdef.PrivilegeKind_Execute, cmdName))
+		fmt.Println("writer can execute cmd:", app.Role(writerName).IsGranted(appdef.PrivilegeKind_Execute, cmdName))
+	}
+
+	// Output:
+	// Role «test.reader»:
+	// - [PrivilegeKind_Select] [test.doc], fields: [f1]
+	// Role «test.writer»:
+	// - [PrivilegeKind_Insert PrivilegeKind_Update PrivilegeKind_Select] [test.doc], fields: []
+	// - [PrivilegeKind_Execute] [test.cmd], fields: []
+	//
+	// reader can select f1: true
+	// reader can select f2: false
+	// reader can execute cmd: false
+	// writer can execute cmd: true
+}
@@ -17,6 +17,7 @@ type extension struct {
 	embeds interface{}
 	name   string
 	engine ExtensionEngineKind
+	limits ExtensionLimits
 }
 
 func makeExtension(app *appDef, name QName, kind TypeKind, embeds interface{}) extension {
@@ -46,6 +47,18 @@ func (ex *extension) SetEngine(engine ExtensionEngineKind) IExtensionBuilder {
 	return ex.embeds.(IExtensionBuilder)
 }
 
+func (ex extension) Limits() ExtensionLimits {
+	return ex.limits
+}
+
+func (ex *extension) SetLimits(limits ExtensionLimits) IExtensionBuilder {
+	if (limits != ExtensionLimits{}) && (ex.engine != ExtensionEngineKind_WASM) {
+		panic(fmt.Errorf("%v: limits are supported by %v engine only: %w", ex, ExtensionEngineKind_WASM.TrimString(), ErrInvalidExtensionEngineKind))
+	}
+	ex.limits = limits
+	return ex.embeds.(IExtensionBuilder)
+}
+
 func (ex *extension) SetName(name string) IExtensionBuilder {
 	if name == "" {
 		panic(fmt.Errorf("%v: extension name is empty: %w", ex, ErrNameMissed))
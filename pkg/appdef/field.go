@@ -30,6 +30,11 @@ type field struct {
 	verifiable  bool
 	verify      map[VerificationKind]bool
 	constraints map[ConstraintKind]IConstraint
+	array       bool
+	minItems    Occurs
+	maxItems    Occurs
+	generated   string
+	calc        GeneratedFunc
 }
 
 func makeField(name string, data IData, required bool, comments ...string) field {
@@ -57,19 +62,34 @@ func (fld *field) Data() IData { return fld.data }
 
 func (fld *field) DataKind() DataKind { return fld.Data().DataKind() }
 
+func (fld *field) IsArray() bool { return fld.array }
+
+func (fld *field) GeneratedAs() string { return fld.generated }
+
+func (fld *field) Generate(row ICheckRow) (interface{}, error) { return fld.calc(row) }
+
 func (fld *field) IsFixedWidth() bool {
-	return fld.DataKind().IsFixed()
+	return !fld.array && fld.DataKind().IsFixed()
 }
 
+func (fld *field) IsGenerated() bool { return fld.generated != "" }
+
 func (fld *field) IsSys() bool {
 	return IsSysField(fld.Name())
 }
 
+func (fld *field) MaxItems() Occurs { return fld.maxItems }
+
+func (fld *field) MinItems() Occurs { return fld.minItems }
+
 func (fld *field) Name() string { return fld.name }
 
 func (fld *field) Required() bool { return fld.required }
 
 func (fld field) String() string {
+	if fld.array {
+		return fmt.Sprintf("%s-array-field «%s»", fld.DataKind().TrimString(), fld.Name())
+	}
 	return fmt.Sprintf("%s-field «%s»", fld.DataKind().TrimString(), fld.Name())
 }
 
@@ -79,6 +99,14 @@ func (fld *field) VerificationKind(vk VerificationKind) bool {
 	return fld.verifiable && fld.verify[vk]
 }
 
+func (fld *field) setCalc(calc GeneratedFunc) {
+	fld.calc = calc
+}
+
+func (fld *field) setGenerated(expression string) {
+	fld.generated = expression
+}
+
 func (fld *field) setVerify(k ...VerificationKind) {
 	fld.verify = make(map[VerificationKind]bool)
 	for _, kind := range k {
@@ -120,6 +148,27 @@ func makeFields(app *appDef, embeds interface{}) fields {
 	return ff
 }
 
+func (ff *fields) AddArrayField(name string, kind DataKind, minItems, maxItems Occurs, constraints ...IConstraint) IFieldsBuilder {
+	if !kind.IsSupportedArrayItem() {
+		panic(fmt.Errorf("%v: %s-data can not be array item: %w", ff.embeds(), kind.TrimString(), ErrInvalidDataKind))
+	}
+	if maxItems == 0 {
+		panic(fmt.Errorf("%v: max items value (0) must be positive number: %w", ff.embeds(), ErrInvalidOccurs))
+	}
+	if maxItems < minItems {
+		panic(fmt.Errorf("%v: max items (%v) must be greater or equal to min items (%v): %w", ff.embeds(), maxItems, minItems, ErrInvalidOccurs))
+	}
+	d := ff.app.SysData(kind)
+	if len(constraints) > 0 {
+		d = newAnonymousData(ff.app, d.DataKind(), d.QName(), constraints...)
+	}
+	f := newField(name, d, minItems > 0)
+	f.array = true
+	f.minItems, f.maxItems = minItems, maxItems
+	ff.appendField(name, f)
+	return ff.emb.(IFieldsBuilder)
+}
+
 func (ff *fields) AddDataField(name string, data QName, required bool, constraints ...IConstraint) IFieldsBuilder {
 	d := ff.app.Data(data)
 	if d == nil {
@@ -192,6 +241,22 @@ func (ff *fields) SetFieldComment(name string, comment ...string) IFieldsBuilder
 	return ff.emb.(IFieldsBuilder)
 }
 
+func (ff *fields) SetFieldGenerated(name string, expression string) IFieldsBuilder {
+	fld := ff.Field(name)
+	if fld == nil {
+		panic(fmt.Errorf("%v: field «%s» not found: %w", ff.embeds(), name, ErrNameNotFound))
+	}
+	if fld.IsSys() {
+		panic(fmt.Errorf("%v: system %v can not be generated: %w", ff.embeds(), fld, ErrIncompatibleConstraints))
+	}
+	if fld.IsArray() {
+		panic(fmt.Errorf("%v: %v can not be generated: %w", ff.embeds(), fld, ErrIncompatibleConstraints))
+	}
+	gf := ff.fields[name].(interface{ setGenerated(string) })
+	gf.setGenerated(expression)
+	return ff.emb.(IFieldsBuilder)
+}
+
 func (ff *fields) SetFieldVerify(name string, vk ...VerificationKind) IFieldsBuilder {
 	fld := ff.fields[name]
 	if fld == nil {
@@ -250,6 +315,25 @@ func (ff *fields) appendField(name string, fld interface{}) {
 }
 
 // Returns type that embeds fields
+// Compiles expressions of generated fields
+func (ff *fields) compileGenerated(compiler IExpressionCompiler) (err error) {
+	for _, fld := range ff.fieldsOrdered {
+		if !fld.IsGenerated() {
+			continue
+		}
+		if compiler == nil {
+			return fmt.Errorf("%v: unable to compile generated %v: %w", ff.embeds(), fld, ErrExpressionCompilerMissed)
+		}
+		calc, e := compiler.CompileGenerated(fld.GeneratedAs(), ff.emb.(IFields), fld.Name())
+		if e != nil {
+			err = errors.Join(err, fmt.Errorf("%v: unable to compile generated %v: %w", ff.embeds(), fld, e))
+			continue
+		}
+		fld.(interface{ setCalc(GeneratedFunc) }).setCalc(calc)
+	}
+	return err
+}
+
 func (ff *fields) embeds() IType {
 	return ff.emb.(IType)
 }
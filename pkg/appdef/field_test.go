@@ -287,6 +287,145 @@ func Test_AddRefField(t *testing.T) {
 	})
 }
 
+func Test_AddArrayField(t *testing.T) {
+	require := require.New(t)
+
+	docName := NewQName("test", "doc")
+	viewName := NewQName("test", "view")
+	var app IAppDef
+
+	t.Run("must be ok to add array fields", func(t *testing.T) {
+		appDef := New()
+		doc := appDef.AddCDoc(docName)
+		doc.
+			AddArrayField("tags", DataKind_string, 0, Occurs_Unbounded, MaxLen(32)).
+			AddArrayField("phones", DataKind_string, 1, 3)
+
+		view := appDef.AddView(viewName)
+		view.KeyBuilder().PartKeyBuilder().AddField("pk", DataKind_int64)
+		view.KeyBuilder().ClustColsBuilder().AddField("cc", DataKind_string)
+		view.ValueBuilder().AddArrayField("amounts", DataKind_decimal, 0, 10, Scale(2))
+
+		a, err := appDef.Build()
+		require.NoError(err)
+
+		app = a
+	})
+
+	t.Run("must be ok to read array fields", func(t *testing.T) {
+		doc := app.CDoc(docName)
+
+		tags := doc.Field("tags")
+		require.NotNil(tags)
+		require.True(tags.IsArray())
+		require.Equal(DataKind_string, tags.DataKind())
+		require.False(tags.Required())
+		require.False(tags.IsFixedWidth())
+		require.Zero(tags.MinItems())
+		require.Equal(Occurs_Unbounded, tags.MaxItems())
+		require.EqualValues(32, tags.Constraints()[ConstraintKind_MaxLen].Value())
+		require.Equal("string-array-field «tags»", fmt.Sprint(tags))
+
+		phones := doc.Field("phones")
+		require.True(phones.Required())
+		require.EqualValues(1, phones.MinItems())
+		require.EqualValues(3, phones.MaxItems())
+
+		amounts := app.View(viewName).Value().Field("amounts")
+		require.NotNil(amounts)
+		require.True(amounts.IsArray())
+		require.Equal(DataKind_decimal, amounts.DataKind())
+		require.EqualValues(10, amounts.MaxItems())
+
+		require.False(doc.Field(SystemField_ID).IsArray())
+		require.Zero(doc.Field(SystemField_ID).MaxItems())
+	})
+
+	t.Run("must be panic if invalid array field", func(t *testing.T) {
+		appDef := New()
+		doc := appDef.AddCDoc(docName)
+		require.Panics(func() { doc.AddArrayField("", DataKind_int32, 0, 1) }, "empty name")
+		require.Panics(func() { doc.AddArrayField("f", DataKind_bytes, 0, 1) }, "not array item kind")
+		require.Panics(func() { doc.AddArrayField("f", DataKind_RecordID, 0, 1) }, "not array item kind")
+		require.Panics(func() { doc.AddArrayField("f", DataKind_int32, 0, 0) }, "zero max items")
+		require.Panics(func() { doc.AddArrayField("f", DataKind_int32, 2, 1) }, "max items less then min")
+		require.Panics(func() { doc.AddArrayField("f", DataKind_int32, 0, 1, MaxLen(1)) }, "incompatible constraint")
+	})
+}
+
+func Test_SetFieldGenerated(t *testing.T) {
+	require := require.New(t)
+
+	docName := NewQName("test", "doc")
+	var app IAppDef
+
+	t.Run("must be ok to add generated fields", func(t *testing.T) {
+		appDef := New()
+		appDef.SetExpressionCompiler(testCompiler{})
+		doc := appDef.AddCDoc(docName)
+		doc.
+			AddField("price", DataKind_float64, true).
+			AddField("total", DataKind_float64, false).
+			SetFieldGenerated("total", "price * 2")
+
+		a, err := appDef.Build()
+		require.NoError(err)
+
+		app = a
+	})
+
+	t.Run("must be ok to read generated fields", func(t *testing.T) {
+		doc := app.CDoc(docName)
+
+		f := doc.Field("total")
+		require.True(f.IsGenerated())
+		require.Equal("price * 2", f.GeneratedAs())
+
+		f = doc.Field("price")
+		require.False(f.IsGenerated())
+		require.Empty(f.GeneratedAs())
+	})
+
+	t.Run("must be ok to generate field values", func(t *testing.T) {
+		f := app.CDoc(docName).Field("total")
+
+		v, err := f.Generate(testCheckRow{values: map[string]float64{"price": 1.5}})
+		require.NoError(err)
+		require.Equal(3.0, v)
+
+		v, err = f.Generate(testCheckRow{})
+		require.NoError(err)
+		require.Nil(v)
+	})
+
+	t.Run("must be panic if invalid generated field", func(t *testing.T) {
+		appDef := New()
+		doc := appDef.AddCDoc(docName)
+		doc.AddArrayField("tags", DataKind_string, 0, Occurs_Unbounded)
+		require.Panics(func() { doc.SetFieldGenerated("unknown", "1") }, "unknown field")
+		require.Panics(func() { doc.SetFieldGenerated(SystemField_IsActive, "true") }, "system field")
+		require.Panics(func() { doc.SetFieldGenerated("tags", "'a'") }, "array field")
+	})
+
+	t.Run("must be error if generated field can not be compiled", func(t *testing.T) {
+		appDef := New()
+		doc := appDef.AddCDoc(docName)
+		doc.
+			AddField("price", DataKind_float64, true).
+			AddField("total", DataKind_float64, false).
+			SetFieldGenerated("total", "price * 2")
+
+		_, err := appDef.Build()
+		require.ErrorIs(err, ErrExpressionCompilerMissed)
+
+		appDef.SetExpressionCompiler(testCompiler{})
+		doc.SetFieldGenerated("total", "unknown * 2")
+		_, err = appDef.Build()
+		require.ErrorIs(err, ErrNameNotFound)
+		require.ErrorContains(err, "unable to compile generated float64-field «total»")
+	})
+}
+
 func Test_UserFields(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"fmt"
+)
+
+// # Implements:
+//   - IIndex
+type index struct {
+	comment
+	emb    interface{}
+	name   QName
+	fields []IField
+}
+
+func newIndex(embeds interface{}, name QName, fields []IField) *index {
+	return &index{
+		emb:    embeds,
+		name:   name,
+		fields: fields,
+	}
+}
+
+func (i index) Fields() []IField {
+	return i.fields
+}
+
+func (i index) Name() QName {
+	return i.name
+}
+
+func (i index) ParentStructure() IStructure {
+	return i.emb.(IStructure)
+}
+
+// # Implements:
+//   - IIndexes
+//   - IIndexesBuilder
+type indexes struct {
+	emb            interface{}
+	indexes        map[QName]*index
+	indexesOrdered []IIndex
+}
+
+func makeIndexes(embeds interface{}) indexes {
+	ii := indexes{
+		emb:     embeds,
+		indexes: make(map[QName]*index),
+	}
+	return ii
+}
+
+func (ii *indexes) AddIndex(name QName, fields []string, comment ...string) IIndexesBuilder {
+	if name == NullQName {
+		panic(fmt.Errorf("%v: index name cannot be empty: %w", ii.embeds(), ErrNameMissed))
+	}
+	if ok, err := ValidQName(name); !ok {
+		panic(fmt.Errorf("%v: index name «%v» is invalid: %w", ii.embeds(), name, err))
+	}
+	if ii.Index(name) != nil {
+		panic(fmt.Errorf("%v: index «%v» is already exists: %w", ii.embeds(), name, ErrNameUniqueViolation))
+	}
+	if app := ii.embeds().App(); app != nil {
+		if t := app.TypeByName(name); t != nil {
+			panic(fmt.Errorf("%v: index name «%v» is already used by type %v: %w", ii.embeds(), name, t, ErrNameUniqueViolation))
+		}
+	}
+
+	switch k := ii.embeds().Kind(); k {
+	case TypeKind_CDoc, TypeKind_CRecord, TypeKind_WDoc, TypeKind_WRecord:
+	default:
+		panic(fmt.Errorf("%v: type kind «%v» does not support indexes: %w", ii.embeds(), k, ErrInvalidTypeKind))
+	}
+
+	if len(fields) == 0 {
+		panic(fmt.Errorf("%v: no fields specified for index «%v»: %w", ii.embeds(), name, ErrNameMissed))
+	}
+	if i, j := duplicates(fields); i >= 0 {
+		panic(fmt.Errorf("%v: index «%v» has duplicates (fields[%d] == fields[%d] == %q): %w", ii.embeds(), name, i, j, fields[i], ErrNameUniqueViolation))
+	}
+	if len(fields) > MaxIndexFieldCount {
+		panic(fmt.Errorf("%v: index «%v» exceeds maximum fields (%d): %w", ii.embeds(), name, MaxIndexFieldCount, ErrTooManyFields))
+	}
+	if len(ii.indexesOrdered) >= MaxTypeIndexCount {
+		panic(fmt.Errorf("%v: maximum indexes (%d) is exceeded: %w", ii.embeds(), MaxTypeIndexCount, ErrTooManyIndexes))
+	}
+
+	ff := make([]IField, 0, len(fields))
+	for _, f := range fields {
+		fld := ii.embeds().Field(f)
+		if fld == nil {
+			panic(fmt.Errorf("%v: can not create index «%v»: field «%s» not found: %w", ii.embeds(), name, f, ErrNameNotFound))
+		}
+		if fld.IsArray() || (fld.DataKind() == DataKind_bytes) {
+			panic(fmt.Errorf("%v: can not create index «%v»: %v can not be indexed: %w", ii.embeds(), name, fld, ErrInvalidDataKind))
+		}
+		ff = append(ff, fld)
+	}
+
+	i := newIndex(ii.emb, name, ff)
+	i.SetComment(comment...)
+
+	ii.indexes[name] = i
+	ii.indexesOrdered = append(ii.indexesOrdered, i)
+
+	return ii.emb.(IIndexesBuilder)
+}
+
+func (ii *indexes) Index(name QName) IIndex {
+	if i, ok := ii.indexes[name]; ok {
+		return i
+	}
+	return nil
+}
+
+func (ii *indexes) IndexCount() int {
+	return len(ii.indexesOrdered)
+}
+
+func (ii *indexes) Indexes() []IIndex {
+	return ii.indexesOrdered
+}
+
+func (ii *indexes) embeds() IStructure {
+	return ii.emb.(IStructure)
+}
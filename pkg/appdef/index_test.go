@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddIndex(t *testing.T) {
+	require := require.New(t)
+
+	docName := NewQName("test", "user")
+	recName := NewQName("test", "rec")
+	objName := NewQName("test", "obj")
+	idxEMail := NewQName("test", "userByEMail")
+	idxName := NewQName("test", "userByName")
+
+	appDef := New()
+
+	doc := appDef.AddCDoc(docName)
+	doc.
+		AddField("name", DataKind_string, true).
+		AddField("surname", DataKind_string, false).
+		AddField("eMail", DataKind_string, false).
+		AddField("photo", DataKind_bytes, false).
+		AddArrayField("tags", DataKind_string, 0, Occurs_Unbounded)
+	doc.
+		AddIndex(idxEMail, []string{"eMail"}, "index by e-mail").
+		AddIndex(idxName, []string{"surname", "name"})
+
+	rec := appDef.AddCRecord(recName)
+	rec.AddField("f", DataKind_int32, false)
+
+	obj := appDef.AddObject(objName)
+	obj.AddField("f", DataKind_int32, false)
+
+	t.Run("should be ok to read indexes", func(t *testing.T) {
+		app, err := appDef.Build()
+		require.NoError(err)
+
+		doc := app.CDoc(docName)
+		require.Equal(2, doc.IndexCount())
+
+		i := doc.Index(idxName)
+		require.Equal(idxName, i.Name())
+		require.Equal(docName, i.ParentStructure().QName())
+		require.Len(i.Fields(), 2)
+		require.Equal("surname", i.Fields()[0].Name())
+		require.Equal("name", i.Fields()[1].Name())
+
+		require.Equal("index by e-mail", doc.Index(idxEMail).Comment())
+
+		require.Len(doc.Indexes(), 2)
+		require.Equal(idxEMail, doc.Indexes()[0].Name())
+		require.Equal(idxName, doc.Indexes()[1].Name())
+
+		require.Nil(doc.Index(NewQName("test", "unknown")))
+		require.Zero(app.CRecord(recName).IndexCount())
+	})
+
+	t.Run("should be panics", func(t *testing.T) {
+		require.Panics(func() { doc.AddIndex(NullQName, []string{"name"}) },
+			"if empty index name")
+		require.Panics(func() { doc.AddIndex(NewQName("test", "naked-🔫"), []string{"name"}) },
+			"if invalid index name")
+		require.Panics(func() { doc.AddIndex(idxEMail, []string{"name"}) },
+			"if index name is already exists")
+		require.Panics(func() { doc.AddIndex(recName, []string{"name"}) },
+			"if index name is used by type")
+		require.Panics(func() { doc.AddIndex(NewQName("test", "idx"), []string{}) },
+			"if fields list is empty")
+		require.Panics(func() { doc.AddIndex(NewQName("test", "idx"), []string{"name", "eMail", "name"}) },
+			"if fields has duplicates")
+		require.Panics(func() { doc.AddIndex(NewQName("test", "idx"), []string{"unknown"}) },
+			"if field not found")
+		require.Panics(func() { doc.AddIndex(NewQName("test", "idx"), []string{"photo"}) },
+			"if bytes field")
+		require.Panics(func() { doc.AddIndex(NewQName("test", "idx"), []string{"tags"}) },
+			"if array field")
+
+		odoc := appDef.AddODoc(NewQName("test", "odoc"))
+		odoc.AddField("f", DataKind_int32, false)
+		require.Panics(func() { odoc.AddIndex(NewQName("test", "idx"), []string{"f"}) },
+			"if type kind does not support indexes")
+
+		t.Run("if too many fields", func(t *testing.T) {
+			rec := New().AddCRecord(recName)
+			ff := make([]string, 0, MaxIndexFieldCount+1)
+			for i := 0; i <= MaxIndexFieldCount; i++ {
+				ff = append(ff, fmt.Sprintf("f_%#x", i))
+				rec.AddField(ff[i], DataKind_int32, false)
+			}
+			require.Panics(func() { rec.AddIndex(NewQName("test", "idx"), ff) })
+		})
+
+		t.Run("if too many indexes", func(t *testing.T) {
+			rec := New().AddCRecord(recName)
+			rec.AddField("f", DataKind_int32, false)
+			for i := 0; i < MaxTypeIndexCount; i++ {
+				rec.AddIndex(NewQName("test", fmt.Sprintf("idx_%#x", i)), []string{"f"})
+			}
+			require.Panics(func() { rec.AddIndex(NewQName("test", "idx"), []string{"f"}) })
+		})
+	})
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+// Structures with row-level checks.
+//
+// Checks are validated for CUD records.
+//
+// Ref. to check.go for implementation
+type IChecks interface {
+	// Returns check by name.
+	//
+	// Returns nil if check not found
+	Check(name string) ICheck
+
+	// Returns checks count
+	CheckCount() int
+
+	// Enumerates all checks in add order
+	Checks(func(ICheck))
+}
+
+type IChecksBuilder interface {
+	// Adds new check with specified name and expression.
+	//
+	// Expression is VSQL boolean expression over fields of structure, e.g. `Discount <= Price AND Name IS NOT NULL`.
+	// Expression is compiled by IAppDefBuilder.Build() using expression compiler.
+	//
+	// # Panics:
+	//   - if check name is empty,
+	//   - if check name is invalid,
+	//   - if check with name is already exists,
+	//   - if expression is empty,
+	//   - if maximum checks count is exceeded.
+	AddCheck(name, expression string, comment ...string) IChecksBuilder
+}
+
+// Describe single row-level check for structure.
+//
+// Ref. to check.go for implementation
+type ICheck interface {
+	IComment
+
+	// Returns parent structure
+	ParentStructure() IStructure
+
+	// Returns check name
+	Name() string
+
+	// Returns check expression
+	Expression() string
+
+	// Evaluates compiled check expression for row.
+	// Returns false if row violates the check. Expressions evaluating to TRUE or UNKNOWN succeed.
+	//
+	// # Panics:
+	//   - if check is not compiled, see IAppDefBuilder.Build()
+	Evaluate(row ICheckRow) bool
+}
+
+// Row to be evaluated by compiled expression.
+type ICheckRow interface {
+	// Returns is field has value. Fields without value are NULL in expressions
+	HasValue(name string) bool
+
+	AsInt32(name string) int32
+	AsInt64(name string) int64
+	AsFloat32(name string) float32
+	AsFloat64(name string) float64
+	AsBytes(name string) []byte
+	AsString(name string) string
+	AsQName(name string) QName
+	AsBool(name string) bool
+}
+
+// Compiled check expression.
+//
+// Returns false if row violates the check.
+type CheckFunc func(row ICheckRow) bool
+
+// Compiled generated field expression.
+//
+// Returns value of field data kind: int32 for int32 and date, int64 for int64, timestamp and decimal (unscaled),
+// float32, float64, string or bool. Returns nil if expression evaluates to NULL.
+// Returns error if value is out of field data kind range.
+type GeneratedFunc func(row ICheckRow) (interface{}, error)
+
+// Compiles VSQL expressions of structures.
+//
+// Compiler is used by IAppDefBuilder.Build(). Parser provides compiler and sets it for builders it fills.
+type IExpressionCompiler interface {
+	// Compiles check expression over specified fields.
+	CompileCheck(expression string, fields IFields) (CheckFunc, error)
+
+	// Compiles expression of specified generated field over fields.
+	CompileGenerated(expression string, fields IFields, field string) (GeneratedFunc, error)
+}
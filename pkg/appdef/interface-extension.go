@@ -5,6 +5,8 @@
 
 package appdef
 
+import "time"
+
 // Extension engine kind enumeration.
 //
 // Ref. to extension-engine-kind.go for constants and methods
@@ -25,6 +27,28 @@ type IExtension interface {
 	//
 	// After construction new extension has a default BuiltIn engine.
 	Engine() ExtensionEngineKind
+
+	// Execution limits.
+	//
+	// After construction new extension has no limits.
+	Limits() ExtensionLimits
+}
+
+// Extension execution limits. Zero values means no limit.
+//
+// Limits are enforced by extension engine on each invocation.
+type ExtensionLimits struct {
+	// Maximum wall time of invocation
+	MaxDuration time.Duration
+
+	// Maximum number of host (state and intents) functions calls
+	MaxHostCalls uint
+
+	// Maximum number of intents created
+	MaxIntents uint
+
+	// Maximum memory pages (64 KiB each) used by extension
+	MaxMemoryPages uint
 }
 
 type IExtensionBuilder interface {
@@ -39,4 +63,10 @@ type IExtensionBuilder interface {
 
 	// Sets engine.
 	SetEngine(ExtensionEngineKind) IExtensionBuilder
+
+	// Sets execution limits.
+	//
+	// # Panics:
+	//	- if extension engine is not WASM and limits are not empty
+	SetLimits(ExtensionLimits) IExtensionBuilder
 }
@@ -56,6 +56,20 @@ type IFieldsBuilder interface {
 	//	 - if constraints are not compatible with specified data type.
 	AddField(name string, kind DataKind, required bool, constraints ...IConstraint) IFieldsBuilder
 
+	// Adds array (repeated) field with specified name and items data kind.
+	//
+	// Field is required if minItems is positive. Constraints are applied to every array item.
+	//
+	// # Panics:
+	//   - if name is empty,
+	//   - if name is invalid,
+	//   - if field with name is already exists,
+	//   - if specified data kind can not be array item,
+	//   - if specified data kind is not allowed by structured type kind,
+	//   - if maxItems is zero or less then minItems,
+	//	 - if constraints are not compatible with specified data type.
+	AddArrayField(name string, kind DataKind, minItems, maxItems Occurs, constraints ...IConstraint) IFieldsBuilder
+
 	// Adds field with specified data type.
 	//
 	// If constraints specified, then new anonymous data type inherits from specified
@@ -93,6 +107,18 @@ type IFieldsBuilder interface {
 	// # Panics:
 	//   - if field not found.
 	SetFieldVerify(name string, vk ...VerificationKind) IFieldsBuilder
+
+	// Sets expression to calculate value of specified field.
+	//
+	// Generated field is read-only: its value is calculated from other fields of the same row
+	// every time the row is created or updated.
+	// If empty expression is specified then it means that field is not generated.
+	//
+	// # Panics:
+	//   - if field not found,
+	//   - if field is system,
+	//   - if field is array.
+	SetFieldGenerated(name string, expression string) IFieldsBuilder
 }
 
 // Describe single field.
@@ -125,6 +151,27 @@ type IField interface {
 	// Returns is field system
 	IsSys() bool
 
+	// Returns is field array (repeated) of data kind items
+	IsArray() bool
+
+	// Returns is field generated (read-only, calculated by expression)
+	IsGenerated() bool
+
+	// Returns expression to calculate generated field value. Returns empty string for not generated field
+	GeneratedAs() string
+
+	// Calculates generated field value for row. Value is nil if expression evaluates to NULL.
+	//
+	// # Panics:
+	//   - if field is not generated or not compiled, see IAppDefBuilder.Build()
+	Generate(row ICheckRow) (interface{}, error)
+
+	// Returns minimum array items count. Returns zero for not array field
+	MinItems() Occurs
+
+	// Returns maximum array items count. Returns zero for not array field
+	MaxItems() Occurs
+
 	// All field constraints.
 	//
 	// Result contains throughout the data types hierarchy, include all ancestors recursively.
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+// Records with secondary indexes.
+//
+// Indexes are available for TypeKind_CDoc, TypeKind_CRecord, TypeKind_WDoc and TypeKind_WRecord.
+// Index rows are maintained by core when records are created or updated.
+//
+// Ref. to index.go for implementation
+type IIndexes interface {
+	// Returns index by qualified name.
+	//
+	// Returns nil if index not found
+	Index(QName) IIndex
+
+	// Returns indexes count
+	IndexCount() int
+
+	// All indexes in add order
+	Indexes() []IIndex
+}
+
+type IIndexesBuilder interface {
+	// Adds new secondary index with specified name and fields.
+	//
+	// Fields order is significant: records can be looked up by values of any leading fields of index.
+	//
+	// # Panics:
+	//   - if index name is empty,
+	//   - if index name is invalid,
+	//   - if name is already exists,
+	//   - if type kind does not support indexes,
+	//   - if fields list is empty,
+	//   - if fields has duplicates,
+	//   - if some field not found,
+	//   - if some field is array or has bytes data kind,
+	//   - if maximum indexes or index fields count is exceeded.
+	AddIndex(name QName, fields []string, comment ...string) IIndexesBuilder
+}
+
+// Describe single secondary index of record.
+//
+// Ref. to index.go for implementation
+type IIndex interface {
+	IComment
+
+	// Returns parent record
+	ParentStructure() IStructure
+
+	// Returns qualified name of index.
+	Name() QName
+
+	// Returns index fields in index order
+	Fields() []IField
+}
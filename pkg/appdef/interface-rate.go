@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import "time"
+
+// Rate is a maximum number of operations allowed per period within scopes.
+//
+// Ref. to rate.go for implementation
+type IRate interface {
+	IType
+
+	// Returns maximum number of operations allowed per period.
+	Count() RateCount
+
+	// Returns rate period.
+	Period() RatePeriod
+
+	// Returns set (sorted slice) of scopes in which rate is counted.
+	//
+	// If no scopes declared, then DefaultRateScopes are returned.
+	Scopes() []RateScope
+}
+
+type RateCount = uint
+
+type RatePeriod = time.Duration
+
+// Rate scopes enumeration.
+//
+// Ref. to rate-scope.go for constants and methods
+type RateScope uint8
+
+// Limit restricts operations on commands, queries and tables with rate.
+//
+// Ref. to rate.go for implementation
+type ILimit interface {
+	IType
+
+	// Returns limited types: commands, queries, tables (documents and records) and tags.
+	On() QNames
+
+	// Returns names of limited types.
+	//
+	// Tags are expanded to non-abstract commands, queries and tables with the tag.
+	Limited() QNames
+
+	// Returns rate applied to limited types.
+	Rate() IRate
+}
+
+type IRateBuilder interface {
+	IRate
+	ITypeBuilder
+}
+
+type ILimitBuilder interface {
+	ILimit
+	ITypeBuilder
+}
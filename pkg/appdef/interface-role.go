@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+// Role is a named set of privileges, which are granted to principals with the role.
+//
+// Ref. to role.go for implementation
+type IRole interface {
+	IType
+
+	// Enumerates all privileges granted to the role.
+	//
+	// Privileges are enumerated in the order they are granted.
+	Privileges(func(IPrivilege))
+
+	// Returns is privilege of specified kind on specified type granted to the role.
+	//
+	// If fields are specified, then privilege should be granted on every field.
+	// System fields (sys.ID, sys.QName, etc.) are always granted with the type.
+	IsGranted(kind PrivilegeKind, on QName, fields ...string) bool
+}
+
+// Privilege granted to the role.
+type IPrivilege interface {
+	IComment
+
+	// Returns set (sorted slice) of granted privilege kinds.
+	Kinds() []PrivilegeKind
+
+	// Returns types on which privilege is granted.
+	On() QNames
+
+	// Returns fields on which privilege is granted.
+	//
+	// If empty, then privilege is granted on all fields.
+	Fields() []string
+}
+
+// Privileges kinds enumeration.
+//
+// Ref. to privilege-kind.go for constants and methods
+type PrivilegeKind uint8
+
+type IRoleBuilder interface {
+	IRole
+	ITypeBuilder
+
+	// Grants privileges of specified kinds on specified types to the role.
+	//
+	// If fields are specified, then privileges are granted on these fields only.
+	//
+	// # Panics:
+	//	 - if kinds are empty,
+	//	 - if types are empty,
+	//	 - if type is not found,
+	//	 - if privilege kind is not compatible with type,
+	//	 - if field is not found in type.
+	Grant(kinds []PrivilegeKind, on []QName, fields []string, comment ...string) IRoleBuilder
+
+	// Grants all privileges available for specified types to the role.
+	//
+	// Insert, update and select privileges are granted on records, select on views, insert on workspaces
+	// and execute on commands and queries.
+	//
+	// # Panics:
+	//	 - if types are empty,
+	//	 - if type is not found,
+	//	 - if no privilege is available for type.
+	GrantAll(on []QName, comment ...string) IRoleBuilder
+}
@@ -5,7 +5,7 @@
 
 package appdef
 
-// Structure is a type with fields, containers and uniques.
+// Structure is a type with fields, containers, uniques and checks.
 //
 // Ref. to structure.go for implementation
 type IStructure interface {
@@ -13,6 +13,7 @@ type IStructure interface {
 	IFields
 	IContainers
 	IUniques
+	IChecks
 	IWithAbstract
 
 	// Returns definition for «sys.QName» field
@@ -25,16 +26,18 @@ type IStructureBuilder interface {
 	IFieldsBuilder
 	IContainersBuilder
 	IUniquesBuilder
+	IChecksBuilder
 	IWithAbstractBuilder
 }
 
 // Record is a structure.
 //
-// Record has ID field.
+// Record has ID field and may have secondary indexes.
 //
 // Ref. to structure.go for implementation
 type IRecord interface {
 	IStructure
+	IIndexes
 
 	// Returns definition for «sys.ID» field
 	SystemField_ID() IField
@@ -43,6 +46,7 @@ type IRecord interface {
 type IRecordBuilder interface {
 	IRecord
 	IStructureBuilder
+	IIndexesBuilder
 }
 
 // Document is a record.
@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+// Tag is a named label, which can be assigned to types to group them.
+//
+// Tagged types can be granted or limited by tag at once.
+//
+// Ref. to tag.go for implementation
+type ITag interface {
+	IType
+}
+
+type ITagBuilder interface {
+	ITag
+	ITypeBuilder
+}
@@ -64,4 +64,10 @@ type IWithTypes interface {
 type ITypeBuilder interface {
 	IType
 	ICommentBuilder
+
+	// Adds tags to type.
+	//
+	// # Panics:
+	//   - if tag is not found.
+	AddTag(tags ...QName)
 }
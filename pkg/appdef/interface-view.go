@@ -5,6 +5,8 @@
 
 package appdef
 
+import "time"
+
 // View is a type with key and value.
 //
 // Ref to view.go for implementation
@@ -19,6 +21,11 @@ type IView interface {
 
 	// Returns view value
 	Value() IViewValue
+
+	// Returns time to live of view records.
+	//
+	// Zero means records never expire. Expired records are not returned by reads.
+	TTL() time.Duration
 }
 
 type IViewBuilder interface {
@@ -30,6 +37,12 @@ type IViewBuilder interface {
 
 	// Returns view value builder
 	ValueBuilder() IViewValueBuilder
+
+	// Sets time to live of view records.
+	//
+	// # Panics:
+	//   - if ttl is negative
+	SetTTL(ttl time.Duration) IViewBuilder
 }
 
 // View full (pk + cc) key.
@@ -128,6 +128,56 @@ type IAppDef interface {
 	//
 	// Returns nil if not found.
 	WorkspaceByDescriptor(QName) IWorkspace
+
+	// Returns role by name.
+	//
+	// Returns nil if not found.
+	Role(QName) IRole
+
+	// Enumerates all application roles.
+	//
+	// Roles are enumerated in alphabetical order by QName.
+	Roles(func(IRole))
+
+	// Returns rate by name.
+	//
+	// Returns nil if not found.
+	Rate(QName) IRate
+
+	// Enumerates all application rates.
+	//
+	// Rates are enumerated in alphabetical order by QName.
+	Rates(func(IRate))
+
+	// Returns limit by name.
+	//
+	// Returns nil if not found.
+	Limit(QName) ILimit
+
+	// Enumerates all application limits.
+	//
+	// Limits are enumerated in alphabetical order by QName.
+	Limits(func(ILimit))
+
+	// Returns tag by name.
+	//
+	// Returns nil if not found.
+	Tag(QName) ITag
+
+	// Enumerates all application tags.
+	//
+	// Tags are enumerated in alphabetical order by QName.
+	Tags(func(ITag))
+
+	// Returns tags of type with specified name.
+	//
+	// Returns empty QNames if type is not found or has no tags.
+	TagsOf(QName) QNames
+
+	// Returns names of types with specified tag.
+	//
+	// Names are returned in alphabetical order.
+	TypesWithTag(QName) QNames
 }
 
 type IAppDefBuilder interface {
@@ -269,9 +319,54 @@ type IAppDefBuilder interface {
 	//   - if type with name already exists.
 	AddWorkspace(QName) IWorkspaceBuilder
 
+	// Adds new role.
+	//
+	// # Panics:
+	//   - if name is empty (appdef.NullQName),
+	//   - if name is invalid,
+	//   - if type with name already exists.
+	AddRole(QName) IRoleBuilder
+
+	// Adds new tag.
+	//
+	// Use ITypeBuilder.AddTag to assign tag to types.
+	//
+	// # Panics:
+	//   - if name is empty (appdef.NullQName),
+	//   - if name is invalid,
+	//   - if type with name already exists.
+	AddTag(QName) ITagBuilder
+
+	// Adds new rate.
+	//
+	// If scopes are empty, then DefaultRateScopes are used.
+	//
+	// # Panics:
+	//   - if name is empty (appdef.NullQName),
+	//   - if name is invalid,
+	//   - if type with name already exists,
+	//   - if count or period is zero,
+	//   - if scope is unknown.
+	AddRate(name QName, count RateCount, period RatePeriod, scopes ...RateScope) IRateBuilder
+
+	// Adds new limit on specified types with specified rate.
+	//
+	// # Panics:
+	//   - if name is empty (appdef.NullQName),
+	//   - if name is invalid,
+	//   - if type with name already exists,
+	//   - if limited types are empty,
+	//   - if limited type is not found or is not command, query, tag, document or record (except ODoc and ORecord),
+	//   - if rate is not found.
+	AddLimit(name QName, on []QName, rate QName) ILimitBuilder
+
+	// Sets compiler for expressions of structures checks and generated fields.
+	SetExpressionCompiler(IExpressionCompiler)
+
 	// Builds application definition.
 	//
 	// Validates and returns builded application type or error.
+	// Compiles structures checks and generated fields, error is returned if some expression can not be compiled or expression compiler is not set.
 	// Must be called after all entities added.
 	Build() (IAppDef, error)
 }
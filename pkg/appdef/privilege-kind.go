@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"strconv"
+	"strings"
+)
+
+//go:generate stringer -type=PrivilegeKind -output=privilege-kind_string.go
+
+const (
+	PrivilegeKind_Insert PrivilegeKind = iota + 1
+	PrivilegeKind_Update
+	PrivilegeKind_Select
+	PrivilegeKind_Execute
+
+	PrivilegeKind_Count
+)
+
+func (i PrivilegeKind) MarshalText() ([]byte, error) {
+	var s string
+	if (i > 0) && (i < PrivilegeKind_Count) {
+		s = i.String()
+	} else {
+		const base = 10
+		s = strconv.FormatUint(uint64(i), base)
+	}
+	return []byte(s), nil
+}
+
+// Renders an PrivilegeKind in human-readable form, without `PrivilegeKind_` prefix,
+// suitable for debugging or error messages
+func (i PrivilegeKind) TrimString() string {
+	const pref = "PrivilegeKind_"
+	return strings.TrimPrefix(i.String(), pref)
+}
+
+// Returns all privilege kinds which are compatible with type kind.
+func privilegeKindsFor(kind TypeKind) (kinds []PrivilegeKind) {
+	for k := PrivilegeKind(1); k < PrivilegeKind_Count; k++ {
+		if k.typeCompatible(kind) {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+// Returns is privilege kind compatible with type kind.
+//
+// # Compatibles:
+//
+//   - Any document or record, except ODoc and ORecord, can be inserted and updated.
+//   - Any document or record and view can be selected.
+//   - Workspace can be inserted (created).
+//   - Command and query can be executed.
+func (i PrivilegeKind) typeCompatible(kind TypeKind) bool {
+	switch i {
+	case PrivilegeKind_Insert:
+		return kind == TypeKind_GDoc || kind == TypeKind_GRecord ||
+			kind == TypeKind_CDoc || kind == TypeKind_CRecord ||
+			kind == TypeKind_WDoc || kind == TypeKind_WRecord ||
+			kind == TypeKind_Workspace
+	case PrivilegeKind_Update:
+		return kind == TypeKind_GDoc || kind == TypeKind_GRecord ||
+			kind == TypeKind_CDoc || kind == TypeKind_CRecord ||
+			kind == TypeKind_WDoc || kind == TypeKind_WRecord
+	case PrivilegeKind_Select:
+		return kind == TypeKind_GDoc || kind == TypeKind_GRecord ||
+			kind == TypeKind_CDoc || kind == TypeKind_CRecord ||
+			kind == TypeKind_WDoc || kind == TypeKind_WRecord ||
+			kind == TypeKind_ODoc || kind == TypeKind_ORecord ||
+			kind == TypeKind_ViewRecord
+	case PrivilegeKind_Execute:
+		return kind == TypeKind_Command || kind == TypeKind_Query
+	}
+	return false
+}
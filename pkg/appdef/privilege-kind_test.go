@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivilegeKind_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		k    PrivilegeKind
+		want string
+	}{
+		{name: `1 —> "PrivilegeKind_Insert"`,
+			k:    PrivilegeKind_Insert,
+			want: `PrivilegeKind_Insert`,
+		},
+		{name: `4 —> "PrivilegeKind_Execute"`,
+			k:    PrivilegeKind_Execute,
+			want: `PrivilegeKind_Execute`,
+		},
+		{name: `PrivilegeKind_Count —> <number>`,
+			k:    PrivilegeKind_Count,
+			want: strconv.FormatUint(uint64(PrivilegeKind_Count), 10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.k.MarshalText()
+			if err != nil {
+				t.Errorf("PrivilegeKind.MarshalText() unexpected error %v", err)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("PrivilegeKind.MarshalText() = %s, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("100% cover PrivilegeKind.String()", func(t *testing.T) {
+		const tested = PrivilegeKind_Count + 1
+		want := "PrivilegeKind(" + strconv.FormatInt(int64(tested), 10) + ")"
+		got := tested.String()
+		if got != want {
+			t.Errorf("(PrivilegeKind_Count + 1).String() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestPrivilegeKindTrimString(t *testing.T) {
+	tests := []struct {
+		name string
+		k    PrivilegeKind
+		want string
+	}{
+		{name: "basic", k: PrivilegeKind_Update, want: "Update"},
+		{name: "out of range", k: PrivilegeKind_Count + 1, want: (PrivilegeKind_Count + 1).String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.k.TrimString(); got != tt.want {
+				t.Errorf("%v.(PrivilegeKind).TrimString() = %v, want %v", tt.k, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrivilegeKind_typeCompatible(t *testing.T) {
+	tests := []struct {
+		name string
+		i    PrivilegeKind
+		kind TypeKind
+		want bool
+	}{
+		{"ok Insert CDoc", PrivilegeKind_Insert, TypeKind_CDoc, true},
+		{"ok Insert Workspace", PrivilegeKind_Insert, TypeKind_Workspace, true},
+		{"ok Update WRecord", PrivilegeKind_Update, TypeKind_WRecord, true},
+		{"ok Select ODoc", PrivilegeKind_Select, TypeKind_ODoc, true},
+		{"ok Select View", PrivilegeKind_Select, TypeKind_ViewRecord, true},
+		{"ok Execute Command", PrivilegeKind_Execute, TypeKind_Command, true},
+		{"ok Execute Query", PrivilegeKind_Execute, TypeKind_Query, true},
+
+		{"fail Insert ODoc", PrivilegeKind_Insert, TypeKind_ODoc, false},
+		{"fail Update Workspace", PrivilegeKind_Update, TypeKind_Workspace, false},
+		{"fail Select Query", PrivilegeKind_Select, TypeKind_Query, false},
+		{"fail Execute Projector", PrivilegeKind_Execute, TypeKind_Projector, false},
+		{"fail out of bounds", PrivilegeKind_Count + 1, TypeKind_CDoc, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.i.typeCompatible(tt.kind); got != tt.want {
+				t.Errorf("%v.typeCompatible(%v) = %v, want %v", tt.i, tt.kind, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("privilegeKindsFor", func(t *testing.T) {
+		require := require.New(t)
+		require.Equal([]PrivilegeKind{PrivilegeKind_Insert, PrivilegeKind_Update, PrivilegeKind_Select}, privilegeKindsFor(TypeKind_CDoc))
+		require.Equal([]PrivilegeKind{PrivilegeKind_Select}, privilegeKindsFor(TypeKind_ODoc))
+		require.Equal([]PrivilegeKind{PrivilegeKind_Execute}, privilegeKindsFor(TypeKind_Query))
+		require.Empty(privilegeKindsFor(TypeKind_Role))
+	})
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"strconv"
+	"strings"
+)
+
+//go:generate stringer -type=RateScope -output=rate-scope_string.go
+
+const (
+	// Rate is counted per application partition
+	RateScope_AppPartition RateScope = iota + 1
+
+	// Rate is counted per workspace
+	RateScope_Workspace
+
+	// Rate is counted per user (principal)
+	RateScope_User
+
+	// Rate is counted per remote IP address
+	RateScope_IP
+
+	RateScope_Count
+)
+
+// Default scopes for rate if no scopes declared.
+var DefaultRateScopes = []RateScope{RateScope_AppPartition}
+
+func (i RateScope) MarshalText() ([]byte, error) {
+	var s string
+	if (i > 0) && (i < RateScope_Count) {
+		s = i.String()
+	} else {
+		const base = 10
+		s = strconv.FormatUint(uint64(i), base)
+	}
+	return []byte(s), nil
+}
+
+// Renders an RateScope in human-readable form, without `RateScope_` prefix,
+// suitable for debugging or error messages
+func (i RateScope) TrimString() string {
+	const pref = "RateScope_"
+	return strings.TrimPrefix(i.String(), pref)
+}
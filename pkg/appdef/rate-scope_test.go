@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRateScope_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		s    RateScope
+		want string
+	}{
+		{name: `1 —> "RateScope_AppPartition"`,
+			s:    RateScope_AppPartition,
+			want: `RateScope_AppPartition`,
+		},
+		{name: `RateScope_Count —> <number>`,
+			s:    RateScope_Count,
+			want: strconv.FormatUint(uint64(RateScope_Count), 10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.MarshalText()
+			if err != nil {
+				t.Errorf("RateScope.MarshalText() unexpected error %v", err)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("RateScope.MarshalText() = %s, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("100% cover RateScope.String()", func(t *testing.T) {
+		const tested = RateScope_Count + 1
+		want := "RateScope(" + strconv.FormatInt(int64(tested), 10) + ")"
+		got := tested.String()
+		if got != want {
+			t.Errorf("(RateScope_Count + 1).String() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestRateScopeTrimString(t *testing.T) {
+	tests := []struct {
+		name string
+		s    RateScope
+		want string
+	}{
+		{name: "basic", s: RateScope_IP, want: "IP"},
+		{name: "out of range", s: RateScope_Count + 1, want: (RateScope_Count + 1).String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.TrimString(); got != tt.want {
+				t.Errorf("%v.(RateScope).TrimString() = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"fmt"
+	"slices"
+)
+
+// # Implements:
+//   - IRate, IRateBuilder
+type rate struct {
+	typ
+	count  RateCount
+	period RatePeriod
+	scopes []RateScope
+}
+
+// Creates and returns new rate.
+//
+// # Panics:
+//   - if count or period is zero,
+//   - if scope is unknown.
+func newRate(app *appDef, name QName, count RateCount, period RatePeriod, scopes []RateScope) *rate {
+	r := &rate{
+		typ:    makeType(app, name, TypeKind_Rate),
+		count:  count,
+		period: period,
+	}
+	if count == 0 {
+		panic(fmt.Errorf("%v: rate count should be positive: %w", r, ErrInvalidRate))
+	}
+	if period <= 0 {
+		panic(fmt.Errorf("%v: rate period should be positive: %w", r, ErrInvalidRate))
+	}
+	if len(scopes) == 0 {
+		scopes = DefaultRateScopes
+	}
+	for _, s := range scopes {
+		if (s == 0) || (s >= RateScope_Count) {
+			panic(fmt.Errorf("%v: unknown rate scope «%v»: %w", r, s, ErrInvalidRate))
+		}
+	}
+	r.scopes = slices.Clone(scopes)
+	slices.Sort(r.scopes)
+	r.scopes = slices.Compact(r.scopes)
+
+	app.appendType(r)
+	return r
+}
+
+func (r *rate) Count() RateCount {
+	return r.count
+}
+
+func (r *rate) Period() RatePeriod {
+	return r.period
+}
+
+func (r *rate) Scopes() []RateScope {
+	return r.scopes
+}
+
+// # Implements:
+//   - ILimit, ILimitBuilder
+type limit struct {
+	typ
+	on   QNames
+	rate IRate
+}
+
+// Creates and returns new limit.
+//
+// # Panics:
+//   - if types are empty,
+//   - if type is not found or can not be limited,
+//   - if rate is not found.
+func newLimit(app *appDef, name QName, on []QName, rate QName) *limit {
+	l := &limit{
+		typ: makeType(app, name, TypeKind_Limit),
+		on:  QNamesFrom(on...),
+	}
+	if len(l.on) == 0 {
+		panic(fmt.Errorf("%v: limited types are empty: %w", l, ErrNameMissed))
+	}
+	for _, n := range l.on {
+		t := app.TypeByName(n)
+		if t == nil {
+			panic(fmt.Errorf("%v: limited type «%v» not found: %w", l, n, ErrNameNotFound))
+		}
+		if (t.Kind() != TypeKind_Tag) && !limitableTypeKind(t.Kind()) {
+			panic(fmt.Errorf("%v: %v can not be limited: %w", l, t, ErrInvalidTypeKind))
+		}
+	}
+	if l.rate = app.Rate(rate); l.rate == nil {
+		panic(fmt.Errorf("%v: rate «%v» not found: %w", l, rate, ErrNameNotFound))
+	}
+
+	app.appendType(l)
+	return l
+}
+
+func (l *limit) On() QNames {
+	return l.on
+}
+
+func (l *limit) Rate() IRate {
+	return l.rate
+}
+
+func (l *limit) Limited() (names QNames) {
+	for _, n := range l.on {
+		if l.app.Tag(n) == nil {
+			names.Add(n)
+			continue
+		}
+		for _, t := range l.app.TypesWithTag(n) {
+			typ := l.app.TypeByName(t)
+			if !limitableTypeKind(typ.Kind()) {
+				continue
+			}
+			if a, ok := typ.(IWithAbstract); ok && a.Abstract() {
+				continue
+			}
+			names.Add(t)
+		}
+	}
+	return names
+}
+
+// Returns is type kind can be limited: commands, queries and any documents or records, except ODoc and ORecord.
+func limitableTypeKind(kind TypeKind) bool {
+	switch kind {
+	case TypeKind_Command, TypeKind_Query,
+		TypeKind_GDoc, TypeKind_GRecord,
+		TypeKind_CDoc, TypeKind_CRecord,
+		TypeKind_WDoc, TypeKind_WRecord:
+		return true
+	}
+	return false
+}
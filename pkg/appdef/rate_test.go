@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AppDef_AddRateAndLimit(t *testing.T) {
+	require := require.New(t)
+
+	docName, cmdName, queryName := NewQName("test", "doc"), NewQName("test", "cmd"), NewQName("test", "query")
+	rateName, ipRateName := NewQName("test", "rate"), NewQName("test", "ipRate")
+	limitName, cudLimitName, tagLimitName := NewQName("test", "limit"), NewQName("test", "cudLimit"), NewQName("test", "tagLimit")
+	tagName, absDocName := NewQName("test", "tag"), NewQName("test", "absDoc")
+
+	var app IAppDef
+
+	t.Run("must be ok to add rates and limits", func(t *testing.T) {
+		appDef := New()
+
+		appDef.AddTag(tagName)
+
+		appDef.AddCDoc(docName).AddTag(tagName)
+		_ = appDef.AddCommand(cmdName)
+		appDef.AddQuery(queryName).AddTag(tagName)
+
+		absDoc := appDef.AddCDoc(absDocName)
+		absDoc.SetAbstract()
+		absDoc.AddTag(tagName)
+
+		appDef.AddRate(rateName, 10, time.Minute).SetComment("default rate")
+		_ = appDef.AddRate(ipRateName, 100, time.Hour, RateScope_IP, RateScope_AppPartition, RateScope_IP)
+
+		appDef.AddLimit(limitName, []QName{cmdName, queryName}, rateName).SetComment("limit for functions")
+		_ = appDef.AddLimit(cudLimitName, []QName{docName}, ipRateName)
+		_ = appDef.AddLimit(tagLimitName, []QName{tagName}, rateName)
+
+		a, err := appDef.Build()
+		require.NoError(err)
+
+		app = a
+	})
+
+	t.Run("must be ok to find rates", func(t *testing.T) {
+		r := app.Rate(rateName)
+		require.Equal(TypeKind_Rate, r.Kind())
+		require.Equal("default rate", r.Comment())
+		require.EqualValues(10, r.Count())
+		require.Equal(time.Minute, r.Period())
+		require.Equal(DefaultRateScopes, r.Scopes())
+
+		require.Equal([]RateScope{RateScope_AppPartition, RateScope_IP}, app.Rate(ipRateName).Scopes())
+
+		require.Nil(app.Rate(docName), "must be nil if not rate")
+
+		names := QNames{}
+		app.Rates(func(r IRate) { names = append(names, r.QName()) })
+		require.Equal(QNames{ipRateName, rateName}, names)
+	})
+
+	t.Run("must be ok to find limits", func(t *testing.T) {
+		l := app.Limit(limitName)
+		require.Equal(TypeKind_Limit, l.Kind())
+		require.Equal("limit for functions", l.Comment())
+		require.Equal(QNamesFrom(cmdName, queryName), l.On())
+		require.Equal(QNamesFrom(cmdName, queryName), l.Limited())
+		require.Equal(rateName, l.Rate().QName())
+
+		require.Nil(app.Limit(rateName), "must be nil if not limit")
+
+		names := QNames{}
+		app.Limits(func(l ILimit) { names = append(names, l.QName()) })
+		require.Equal(QNames{cudLimitName, limitName, tagLimitName}, names)
+
+		tl := app.Limit(tagLimitName)
+		require.Equal(QNamesFrom(tagName), tl.On())
+		require.Equal(QNamesFrom(docName, queryName), tl.Limited(), "abstract types are not limited")
+	})
+
+	t.Run("must be panic", func(t *testing.T) {
+		appDef := New()
+		_ = appDef.AddODoc(docName)
+		_ = appDef.AddCommand(cmdName)
+
+		require.Panics(func() { appDef.AddRate(rateName, 0, time.Minute) }, "if count is zero")
+		require.Panics(func() { appDef.AddRate(rateName, 1, 0) }, "if period is zero")
+		require.Panics(func() { appDef.AddRate(rateName, 1, time.Minute, RateScope_Count) }, "if scope is unknown")
+		require.Panics(func() { appDef.AddRate(docName, 1, time.Minute) }, "if name already used")
+
+		_ = appDef.AddRate(rateName, 1, time.Minute)
+
+		require.Panics(func() { appDef.AddLimit(limitName, nil, rateName) }, "if types are empty")
+		require.Panics(func() { appDef.AddLimit(limitName, []QName{NewQName("test", "unknown")}, rateName) }, "if type not found")
+		require.Panics(func() { appDef.AddLimit(limitName, []QName{docName}, rateName) }, "if type can not be limited")
+		require.Panics(func() { appDef.AddLimit(limitName, []QName{cmdName}, NewQName("test", "unknown")) }, "if rate not found")
+	})
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// # Implements:
+//   - IRole, IRoleBuilder
+type role struct {
+	typ
+	privileges []*privilege
+}
+
+func newRole(app *appDef, name QName) *role {
+	r := &role{
+		typ: makeType(app, name, TypeKind_Role),
+	}
+	app.appendType(r)
+	return r
+}
+
+func (r *role) Grant(kinds []PrivilegeKind, on []QName, fields []string, comment ...string) IRoleBuilder {
+	r.privileges = append(r.privileges, newPrivilege(r, kinds, on, fields, comment...))
+	return r
+}
+
+func (r *role) GrantAll(on []QName, comment ...string) IRoleBuilder {
+	if len(on) == 0 {
+		panic(fmt.Errorf("%v: types to grant are empty: %w", r, ErrNameMissed))
+	}
+	for _, n := range on {
+		t := r.app.TypeByName(n)
+		if t == nil {
+			panic(fmt.Errorf("%v: type «%v» not found: %w", r, n, ErrNameNotFound))
+		}
+		kinds := privilegeKindsFor(t.Kind())
+		if len(kinds) == 0 {
+			panic(fmt.Errorf("%v: no privileges are applicable for %v: %w", r, t, ErrInvalidPrivilegeKind))
+		}
+		r.Grant(kinds, []QName{n}, nil, comment...)
+	}
+	return r
+}
+
+func (r *role) IsGranted(kind PrivilegeKind, on QName, fields ...string) bool {
+	granted := map[string]bool{}
+	for _, p := range r.privileges {
+		if !p.granted(kind, on) {
+			continue
+		}
+		if len(p.fields) == 0 {
+			return true
+		}
+		for _, f := range p.fields {
+			granted[f] = true
+		}
+	}
+	if len(granted) == 0 || len(fields) == 0 {
+		return false
+	}
+	for _, f := range fields {
+		if !granted[f] && !IsSysField(f) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *role) Privileges(cb func(IPrivilege)) {
+	for _, p := range r.privileges {
+		cb(p)
+	}
+}
+
+// # Implements:
+//   - IPrivilege
+type privilege struct {
+	comment
+	kinds  uint64 // bitmap[PrivilegeKind]
+	on     QNames
+	fields []string
+}
+
+// Creates and returns new privilege.
+//
+// # Panics:
+//   - if kinds or types are empty,
+//   - if type is not found or not compatible with privilege kind,
+//   - if field is not found in type.
+func newPrivilege(r *role, kinds []PrivilegeKind, on []QName, fields []string, comment ...string) *privilege {
+	if len(kinds) == 0 {
+		panic(fmt.Errorf("%v: privilege kinds are empty: %w", r, ErrInvalidPrivilegeKind))
+	}
+	if len(on) == 0 {
+		panic(fmt.Errorf("%v: types to grant are empty: %w", r, ErrNameMissed))
+	}
+
+	p := &privilege{
+		comment: makeComment(comment...),
+		on:      QNamesFrom(on...),
+	}
+
+	for _, n := range p.on {
+		t := r.app.TypeByName(n)
+		if t == nil {
+			panic(fmt.Errorf("%v: type «%v» not found: %w", r, n, ErrNameNotFound))
+		}
+		for _, k := range kinds {
+			if !k.typeCompatible(t.Kind()) {
+				panic(fmt.Errorf("%v: %s privilege is not applicable for %v: %w", r, k.TrimString(), t, ErrInvalidPrivilegeKind))
+			}
+			p.kinds |= 1 << k
+		}
+		for _, f := range fields {
+			if !typeHasField(t, f) {
+				panic(fmt.Errorf("%v: field «%s» not found in %v: %w", r, f, t, ErrNameNotFound))
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		p.fields = slices.Clone(fields)
+		slices.Sort(p.fields)
+		p.fields = slices.Compact(p.fields)
+	}
+
+	return p
+}
+
+func (p *privilege) Fields() []string {
+	return p.fields
+}
+
+func (p *privilege) Kinds() (kinds []PrivilegeKind) {
+	for k := PrivilegeKind(1); k < PrivilegeKind_Count; k++ {
+		if p.kinds&(1<<k) != 0 {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+func (p *privilege) On() QNames {
+	return p.on
+}
+
+func (p privilege) String() string {
+	s := []string{}
+	for _, k := range p.Kinds() {
+		s = append(s, k.TrimString())
+	}
+	return fmt.Sprintf("[%s] on %v", strings.Join(s, " "), p.on)
+}
+
+// Returns is privilege of specified kind on specified type granted.
+func (p *privilege) granted(kind PrivilegeKind, on QName) bool {
+	return (p.kinds&(1<<kind) != 0) && p.on.Contains(on)
+}
+
+// Returns is type has field or container with specified name.
+func typeHasField(t IType, name string) bool {
+	if ff, ok := t.(IFields); ok && ff.Field(name) != nil {
+		return true
+	}
+	if cc, ok := t.(IContainers); ok && cc.Container(name) != nil {
+		return true
+	}
+	return false
+}
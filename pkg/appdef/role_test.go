@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AppDef_AddRole(t *testing.T) {
+	require := require.New(t)
+
+	wsName := NewQName("test", "ws")
+	docName, viewName := NewQName("test", "doc"), NewQName("test", "view")
+	cmdName, queryName := NewQName("test", "cmd"), NewQName("test", "query")
+	readerName, writerName := NewQName("test", "reader"), NewQName("test", "writer")
+
+	var app IAppDef
+
+	t.Run("must be ok to add roles", func(t *testing.T) {
+		appDef := New()
+
+		_ = appDef.AddWorkspace(wsName)
+
+		doc := appDef.AddCDoc(docName)
+		doc.AddField("field1", DataKind_int32, true)
+		doc.AddField("field2", DataKind_string, false)
+
+		view := appDef.AddView(viewName)
+		view.KeyBuilder().PartKeyBuilder().AddField("pk_1", DataKind_int32)
+		view.KeyBuilder().ClustColsBuilder().AddField("cc_1", DataKind_string)
+		view.ValueBuilder().AddField("vf_1", DataKind_string, false)
+
+		_ = appDef.AddCommand(cmdName)
+		_ = appDef.AddQuery(queryName)
+
+		reader := appDef.AddRole(readerName)
+		reader.SetComment("read-only role")
+		reader.Grant([]PrivilegeKind{PrivilegeKind_Select}, []QName{docName, viewName}, nil, "allow reader to select all fields")
+		reader.Grant([]PrivilegeKind{PrivilegeKind_Execute}, []QName{queryName}, nil)
+
+		writer := appDef.AddRole(writerName)
+		writer.GrantAll([]QName{docName, cmdName, wsName})
+		writer.Grant([]PrivilegeKind{PrivilegeKind_Select}, []QName{viewName}, []string{"vf_1"})
+
+		a, err := appDef.Build()
+		require.NoError(err)
+
+		app = a
+	})
+
+	t.Run("must be ok to find roles", func(t *testing.T) {
+		typ := app.Type(readerName)
+		require.Equal(TypeKind_Role, typ.Kind())
+
+		reader := app.Role(readerName)
+		require.Equal(typ.(IRole), reader)
+		require.Equal("read-only role", reader.Comment())
+
+		require.Nil(app.Role(docName), "must be nil if not role")
+		require.Nil(app.Role(NewQName("test", "unknown")), "must be nil if unknown")
+
+		cnt := 0
+		app.Roles(func(r IRole) {
+			cnt++
+			switch cnt {
+			case 1:
+				require.Equal(readerName, r.QName())
+			case 2:
+				require.Equal(writerName, r.QName())
+			default:
+				require.Fail("unexpected role", "role: %v", r)
+			}
+		})
+		require.Equal(2, cnt)
+	})
+
+	t.Run("must be ok to enum privileges", func(t *testing.T) {
+		reader := app.Role(readerName)
+		cnt := 0
+		reader.Privileges(func(p IPrivilege) {
+			cnt++
+			switch cnt {
+			case 1:
+				require.Equal([]PrivilegeKind{PrivilegeKind_Select}, p.Kinds())
+				require.Equal(QNamesFrom(docName, viewName), p.On())
+				require.Empty(p.Fields())
+				require.Equal("allow reader to select all fields", p.Comment())
+			case 2:
+				require.Equal([]PrivilegeKind{PrivilegeKind_Execute}, p.Kinds())
+				require.Equal(QNamesFrom(queryName), p.On())
+			default:
+				require.Fail("unexpected privilege", "privilege: %v", p)
+			}
+		})
+		require.Equal(2, cnt)
+
+		writer := app.Role(writerName)
+		kinds := map[QName][]PrivilegeKind{}
+		writer.Privileges(func(p IPrivilege) {
+			for _, n := range p.On() {
+				kinds[n] = append(kinds[n], p.Kinds()...)
+			}
+		})
+		require.Equal(map[QName][]PrivilegeKind{
+			docName:  {PrivilegeKind_Insert, PrivilegeKind_Update, PrivilegeKind_Select},
+			cmdName:  {PrivilegeKind_Execute},
+			wsName:   {PrivilegeKind_Insert},
+			viewName: {PrivilegeKind_Select},
+		}, kinds)
+	})
+
+	t.Run("must be ok to check granted privileges", func(t *testing.T) {
+		reader := app.Role(readerName)
+		require.True(reader.IsGranted(PrivilegeKind_Select, docName))
+		require.True(reader.IsGranted(PrivilegeKind_Select, docName, "field1", "field2"))
+		require.True(reader.IsGranted(PrivilegeKind_Execute, queryName))
+		require.False(reader.IsGranted(PrivilegeKind_Update, docName))
+		require.False(reader.IsGranted(PrivilegeKind_Execute, cmdName))
+
+		writer := app.Role(writerName)
+		require.True(writer.IsGranted(PrivilegeKind_Insert, docName, "field1"))
+		require.True(writer.IsGranted(PrivilegeKind_Insert, wsName))
+		require.True(writer.IsGranted(PrivilegeKind_Select, viewName, "vf_1"))
+		require.True(writer.IsGranted(PrivilegeKind_Select, viewName, "vf_1", SystemField_QName), "system fields are granted with type")
+		require.False(writer.IsGranted(PrivilegeKind_Select, viewName, "vf_1", "pk_1"))
+		require.False(writer.IsGranted(PrivilegeKind_Select, viewName), "must be false if not all fields granted")
+		require.False(writer.IsGranted(PrivilegeKind_Execute, queryName))
+	})
+
+	t.Run("must be panic", func(t *testing.T) {
+		appDef := New()
+		doc := appDef.AddODoc(docName)
+		doc.AddField("field1", DataKind_int32, true)
+		_ = appDef.AddQuery(queryName)
+
+		role := appDef.AddRole(readerName)
+
+		require.Panics(func() { role.Grant(nil, []QName{docName}, nil) }, "if kinds are empty")
+		require.Panics(func() { role.Grant([]PrivilegeKind{PrivilegeKind_Select}, nil, nil) }, "if types are empty")
+		require.Panics(func() { role.Grant([]PrivilegeKind{PrivilegeKind_Select}, []QName{NewQName("test", "unknown")}, nil) }, "if type not found")
+		require.Panics(func() { role.Grant([]PrivilegeKind{PrivilegeKind_Insert}, []QName{docName}, nil) }, "if privilege is not applicable for type")
+		require.Panics(func() { role.Grant([]PrivilegeKind{PrivilegeKind_Select}, []QName{docName}, []string{"unknown"}) }, "if field not found")
+
+		require.Panics(func() { role.GrantAll(nil) }, "if types are empty")
+		require.Panics(func() { role.GrantAll([]QName{NewQName("test", "unknown")}) }, "if type not found")
+		require.Panics(func() { role.GrantAll([]QName{readerName}) }, "if no privileges applicable")
+
+		require.Panics(func() { appDef.AddRole(queryName) }, "if name already used")
+	})
+}
@@ -13,6 +13,7 @@ type structure struct {
 	fields
 	containers
 	uniques
+	checks
 	withAbstract
 }
 
@@ -25,6 +26,7 @@ func makeStructure(app *appDef, name QName, kind TypeKind, embeds interface{}) s
 	s.fields.makeSysFields(kind)
 	s.containers = makeContainers(embeds)
 	s.uniques = makeUniques(embeds)
+	s.checks = makeChecks(embeds)
 	return s
 }
 
@@ -37,6 +39,7 @@ func (s structure) SystemField_QName() IField {
 //	- IRecordBuilder
 type record struct {
 	structure
+	indexes
 }
 
 func (r record) SystemField_ID() IField {
@@ -48,6 +51,7 @@ func makeRecord(app *appDef, name QName, kind TypeKind, parent interface{}) reco
 	r := record{
 		structure: makeStructure(app, name, kind, parent),
 	}
+	r.indexes = makeIndexes(parent)
 	return r
 }
 
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import "fmt"
+
+// # Implements:
+//   - ITag, ITagBuilder
+type tag struct {
+	typ
+}
+
+func newTag(app *appDef, name QName) *tag {
+	t := &tag{
+		typ: makeType(app, name, TypeKind_Tag),
+	}
+	app.appendType(t)
+	return t
+}
+
+// Adds tags to type with specified name.
+//
+// # Panics:
+//   - if tag is not found.
+func (app *appDef) addTypeTags(name QName, tags []QName) {
+	for _, t := range tags {
+		if app.Tag(t) == nil {
+			panic(fmt.Errorf("tag «%v» for type «%v» not found: %w", t, name, ErrNameNotFound))
+		}
+	}
+	app.typeTags[name] = QNamesFrom(append(app.typeTags[name], tags...)...)
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appdef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AppDef_AddTag(t *testing.T) {
+	require := require.New(t)
+
+	boTag, posTag := NewQName("test", "backoffice"), NewQName("test", "pos")
+	docName, cmdName, queryName := NewQName("test", "doc"), NewQName("test", "cmd"), NewQName("test", "query")
+
+	var app IAppDef
+
+	t.Run("must be ok to add tags", func(t *testing.T) {
+		appDef := New()
+
+		appDef.AddTag(boTag).SetComment("backoffice types")
+		_ = appDef.AddTag(posTag)
+
+		doc := appDef.AddCDoc(docName)
+		doc.AddTag(boTag)
+		doc.AddTag(posTag, boTag)
+
+		appDef.AddCommand(cmdName).AddTag(boTag)
+		_ = appDef.AddQuery(queryName)
+
+		a, err := appDef.Build()
+		require.NoError(err)
+
+		app = a
+	})
+
+	t.Run("must be ok to find tags", func(t *testing.T) {
+		tag := app.Tag(boTag)
+		require.Equal(TypeKind_Tag, tag.Kind())
+		require.Equal("backoffice types", tag.Comment())
+
+		require.Nil(app.Tag(docName), "must be nil if not tag")
+
+		tags := QNames{}
+		app.Tags(func(t ITag) { tags = append(tags, t.QName()) })
+		require.Equal(QNames{boTag, posTag}, tags)
+	})
+
+	t.Run("must be ok to get tags of type", func(t *testing.T) {
+		require.Equal(QNamesFrom(boTag, posTag), app.TagsOf(docName))
+		require.Equal(QNamesFrom(boTag), app.TagsOf(cmdName))
+		require.Empty(app.TagsOf(queryName))
+		require.Empty(app.TagsOf(NewQName("test", "unknown")))
+	})
+
+	t.Run("must be ok to get types with tag", func(t *testing.T) {
+		require.Equal(QNames{cmdName, docName}, app.TypesWithTag(boTag))
+		require.Equal(QNames{docName}, app.TypesWithTag(posTag))
+		require.Empty(app.TypesWithTag(NewQName("test", "unknown")))
+	})
+
+	t.Run("must be panic", func(t *testing.T) {
+		appDef := New()
+		doc := appDef.AddCDoc(docName)
+		require.Panics(func() { doc.AddTag(boTag) }, "if tag not found")
+		require.Panics(func() { doc.AddTag(docName) }, "if not a tag")
+		require.Panics(func() { appDef.AddTag(docName) }, "if name already used")
+	})
+}
@@ -18,15 +18,19 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_GDoc: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:       true,
@@ -39,15 +43,19 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_CDoc: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:       true,
@@ -60,15 +68,19 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_ODoc: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:    true,
@@ -81,15 +93,19 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_WDoc: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:       true,
@@ -102,15 +118,19 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_GRecord: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:        true,
@@ -125,15 +145,19 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_CRecord: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:        true,
@@ -148,15 +172,19 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_ORecord: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:        true,
@@ -170,15 +198,19 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_WRecord: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_ID:        true,
@@ -193,17 +225,21 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_ViewRecord: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
-			DataKind_Record:   true,
-			DataKind_Event:    true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
+			DataKind_Record:    true,
+			DataKind_Event:     true,
 		},
 		systemFields: map[string]bool{
 			SystemField_QName: true,
@@ -212,15 +248,19 @@ var typeKindProps = map[TypeKind]struct {
 	},
 	TypeKind_Object: {
 		fieldKinds: map[DataKind]bool{
-			DataKind_int32:    true,
-			DataKind_int64:    true,
-			DataKind_float32:  true,
-			DataKind_float64:  true,
-			DataKind_bytes:    true,
-			DataKind_string:   true,
-			DataKind_QName:    true,
-			DataKind_bool:     true,
-			DataKind_RecordID: true,
+			DataKind_int32:     true,
+			DataKind_int64:     true,
+			DataKind_float32:   true,
+			DataKind_float64:   true,
+			DataKind_bytes:     true,
+			DataKind_string:    true,
+			DataKind_QName:     true,
+			DataKind_bool:      true,
+			DataKind_RecordID:  true,
+			DataKind_decimal:   true,
+			DataKind_date:      true,
+			DataKind_timestamp: true,
+			DataKind_UUID:      true,
 		},
 		systemFields: map[string]bool{
 			SystemField_QName:     true,
@@ -245,4 +285,24 @@ var typeKindProps = map[TypeKind]struct {
 		systemFields:   map[string]bool{},
 		containerKinds: map[TypeKind]bool{},
 	},
+	TypeKind_Role: {
+		fieldKinds:     map[DataKind]bool{},
+		systemFields:   map[string]bool{},
+		containerKinds: map[TypeKind]bool{},
+	},
+	TypeKind_Rate: {
+		fieldKinds:     map[DataKind]bool{},
+		systemFields:   map[string]bool{},
+		containerKinds: map[TypeKind]bool{},
+	},
+	TypeKind_Limit: {
+		fieldKinds:     map[DataKind]bool{},
+		systemFields:   map[string]bool{},
+		containerKinds: map[TypeKind]bool{},
+	},
+	TypeKind_Tag: {
+		fieldKinds:     map[DataKind]bool{},
+		systemFields:   map[string]bool{},
+		containerKinds: map[TypeKind]bool{},
+	},
 }
@@ -61,6 +61,18 @@ const (
 
 	TypeKind_Workspace
 
+	// Set of privileges granted to principals
+	TypeKind_Role
+
+	// Maximum number of operations allowed per period
+	TypeKind_Rate
+
+	// Rate applied to commands, queries and tables
+	TypeKind_Limit
+
+	// Label to group types
+	TypeKind_Tag
+
 	TypeKind_FakeLast
 )
 
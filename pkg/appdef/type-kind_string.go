@@ -25,12 +25,16 @@ func _() {
 	_ = x[TypeKind_Command-14]
 	_ = x[TypeKind_Projector-15]
 	_ = x[TypeKind_Workspace-16]
-	_ = x[TypeKind_FakeLast-17]
+	_ = x[TypeKind_Role-17]
+	_ = x[TypeKind_Rate-18]
+	_ = x[TypeKind_Limit-19]
+	_ = x[TypeKind_Tag-20]
+	_ = x[TypeKind_FakeLast-21]
 }
 
-const _TypeKind_name = "TypeKind_nullTypeKind_AnyTypeKind_DataTypeKind_GDocTypeKind_CDocTypeKind_ODocTypeKind_WDocTypeKind_GRecordTypeKind_CRecordTypeKind_ORecordTypeKind_WRecordTypeKind_ViewRecordTypeKind_ObjectTypeKind_QueryTypeKind_CommandTypeKind_ProjectorTypeKind_WorkspaceTypeKind_FakeLast"
+const _TypeKind_name = "TypeKind_nullTypeKind_AnyTypeKind_DataTypeKind_GDocTypeKind_CDocTypeKind_ODocTypeKind_WDocTypeKind_GRecordTypeKind_CRecordTypeKind_ORecordTypeKind_WRecordTypeKind_ViewRecordTypeKind_ObjectTypeKind_QueryTypeKind_CommandTypeKind_ProjectorTypeKind_WorkspaceTypeKind_RoleTypeKind_RateTypeKind_LimitTypeKind_TagTypeKind_FakeLast"
 
-var _TypeKind_index = [...]uint16{0, 13, 25, 38, 51, 64, 77, 90, 106, 122, 138, 154, 173, 188, 202, 218, 236, 254, 271}
+var _TypeKind_index = [...]uint16{0, 13, 25, 38, 51, 64, 77, 90, 106, 122, 138, 154, 173, 188, 202, 218, 236, 254, 267, 280, 294, 306, 323}
 
 func (i TypeKind) String() string {
 	if i >= TypeKind(len(_TypeKind_index)-1) {
@@ -32,6 +32,10 @@ func makeType(app *appDef, name QName, kind TypeKind) typ {
 	return typ{comment{}, app, name, kind}
 }
 
+func (t *typ) AddTag(tags ...QName) {
+	t.app.addTypeTags(t.name, tags)
+}
+
 func (t *typ) App() IAppDef {
 	return t.app
 }
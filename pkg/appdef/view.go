@@ -8,6 +8,7 @@ package appdef
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // # Implements:
@@ -18,6 +19,7 @@ type view struct {
 	fields // all fields, include key and value
 	key    *viewKey
 	value  *viewValue
+	ttl    time.Duration
 }
 
 func newView(app *appDef, name QName) *view {
@@ -51,6 +53,18 @@ func (v *view) ValueBuilder() IViewValueBuilder {
 	return v.value
 }
 
+func (v *view) TTL() time.Duration {
+	return v.ttl
+}
+
+func (v *view) SetTTL(ttl time.Duration) IViewBuilder {
+	if ttl < 0 {
+		panic(fmt.Errorf("%v: TTL %v is negative: %w", v, ttl, ErrInvalidTTL))
+	}
+	v.ttl = ttl
+	return v
+}
+
 // Validates view
 func (v *view) Validate() error {
 	return errors.Join(
@@ -264,6 +278,12 @@ func newViewValue(v *view) *viewValue {
 	return val
 }
 
+func (v *viewValue) AddArrayField(name string, kind DataKind, minItems, maxItems Occurs, constraints ...IConstraint) IFieldsBuilder {
+	v.view.AddArrayField(name, kind, minItems, maxItems, constraints...)
+	v.fields.AddArrayField(name, kind, minItems, maxItems, constraints...)
+	return v
+}
+
 func (v *viewValue) AddDataField(name string, dataType QName, required bool, constraints ...IConstraint) IFieldsBuilder {
 	v.view.AddDataField(name, dataType, required, constraints...)
 	v.fields.AddDataField(name, dataType, required, constraints...)
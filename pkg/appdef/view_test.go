@@ -8,6 +8,7 @@ package appdef
 import (
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -367,3 +368,28 @@ func TestViewValidate(t *testing.T) {
 	_, err := app.Build()
 	require.NoError(err)
 }
+
+func TestViewTTL(t *testing.T) {
+	require := require.New(t)
+
+	adb := New()
+	viewName := NewQName("test", "view")
+	v := adb.AddView(viewName)
+	v.KeyBuilder().PartKeyBuilder().AddField("pk1", DataKind_int64)
+	v.KeyBuilder().ClustColsBuilder().AddField("cc1", DataKind_int64)
+
+	t.Run("must be zero TTL by default", func(t *testing.T) {
+		require.Zero(v.TTL())
+	})
+
+	t.Run("must be ok to set TTL", func(t *testing.T) {
+		v.SetTTL(time.Hour)
+		app, err := adb.Build()
+		require.NoError(err)
+		require.Equal(time.Hour, app.View(viewName).TTL())
+	})
+
+	t.Run("must be panic if TTL is negative", func(t *testing.T) {
+		require.Panics(func() { v.SetTTL(-time.Second) })
+	})
+}
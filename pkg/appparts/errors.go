@@ -15,3 +15,5 @@ const (
 )
 
 var ErrNotAvailableEngines = errors.New("no available engines")
+
+const errExtensionNotFound = "extension %v not found: %w"
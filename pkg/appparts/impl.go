@@ -6,45 +6,85 @@
 package appparts
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"sync"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/cluster"
+	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
 type apps struct {
-	structs istructs.IAppStructsProvider
-	apps    map[istructs.AppQName]*app
-	mx      sync.RWMutex
+	ctx        context.Context
+	structs    istructs.IAppStructsProvider
+	extEngines iextengine.IExtensionEngineFactories
+	extConfig  *iextengine.ExtEngineConfig
+	apps       map[istructs.AppQName]*app
+	mx         sync.RWMutex
 }
 
-func newAppPartitions(structs istructs.IAppStructsProvider) (ap IAppPartitions, cleanup func(), err error) {
+func newAppPartitions(ctx context.Context, structs istructs.IAppStructsProvider, extEngines iextengine.IExtensionEngineFactories, extConfig *iextengine.ExtEngineConfig) (ap IAppPartitions, cleanup func(), err error) {
+	if extConfig == nil {
+		extConfig = &iextengine.ExtEngineConfig{}
+	}
 	a := &apps{
-		structs: structs,
-		apps:    map[istructs.AppQName]*app{},
-		mx:      sync.RWMutex{},
+		ctx:        ctx,
+		structs:    structs,
+		extEngines: extEngines,
+		extConfig:  extConfig,
+		apps:       map[istructs.AppQName]*app{},
+		mx:         sync.RWMutex{},
+	}
+	return a, a.close, err
+}
+
+// Closes extension engines of all applications
+func (aps *apps) close() {
+	aps.mx.Lock()
+	defer aps.mx.Unlock()
+
+	for _, a := range aps.apps {
+		a.retireEngines()
+	}
+}
+
+func (aps *apps) DeployApp(name istructs.AppQName, extModuleURLs map[string]*url.URL, def appdef.IAppDef, partsCount int, engines [cluster.ProcessorKind_Count]int) error {
+	drains, err := aps.deployApp(name, extModuleURLs, def, partsCount, engines)
+	if err != nil {
+		return err
+	}
+
+	// in-flight invocations are drained outside of lock to not block borrowing of partitions
+	for _, drain := range drains {
+		drain(aps.ctx)
 	}
-	return a, func() {}, err
+	return nil
 }
 
-func (aps *apps) DeployApp(name istructs.AppQName, def appdef.IAppDef, partsCount int, engines [cluster.ProcessorKind_Count]int) {
+func (aps *apps) deployApp(name istructs.AppQName, extModuleURLs map[string]*url.URL, def appdef.IAppDef, partsCount int, engines [cluster.ProcessorKind_Count]int) ([]func(context.Context), error) {
 	aps.mx.Lock()
 	defer aps.mx.Unlock()
 
+	appStructs, err := aps.structs.AppStructsByDef(name, def)
+	if err != nil {
+		return nil, err
+	}
+
 	a, ok := aps.apps[name]
 	if !ok {
-		a = newApplication(name)
-		aps.apps[name] = a
+		a = newApplication(aps, name)
 	}
 
-	appStructs, err := aps.structs.AppStructsByDef(name, def)
+	drains, err := a.deploy(def, extModuleURLs, appStructs, partsCount, engines)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	a.deploy(def, appStructs, partsCount, engines)
+	aps.apps[name] = a
+	return drains, nil
 }
 
 func (aps *apps) DeployAppPartitions(appName istructs.AppQName, partIDs []istructs.PartitionID) {
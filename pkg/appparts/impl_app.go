@@ -6,18 +6,26 @@
 package appparts
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"sync"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appparts/internal/pool"
 	"github.com/voedger/voedger/pkg/cluster"
+	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
-// engine placeholder
+// Processor engine with extension engines
 type engine struct {
 	cluster.ProcessorKind
-	pool *pool.Pool[*engine]
+	extensions [appdef.ExtensionEngineKind_Count]iextengine.IExtensionEngine
+
+	mx      sync.Mutex
+	pool    *pool.Pool[*engine]
+	retired bool
 }
 
 func newEngine(kind cluster.ProcessorKind) *engine {
@@ -27,40 +35,188 @@ func newEngine(kind cluster.ProcessorKind) *engine {
 }
 
 func (e *engine) release() {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
 	if p := e.pool; p != nil {
 		e.pool = nil
+		if e.retired {
+			e.close()
+			return
+		}
 		p.Release(e)
 	}
 }
 
+// Marks engine as not used anymore. Idle engine is closed immediately, borrowed engine is closed on release
+func (e *engine) retire() {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	e.retired = true
+	if e.pool == nil {
+		e.close()
+	}
+}
+
+func (e *engine) close() {
+	for _, ext := range e.extensions {
+		if ext != nil {
+			ext.Close(context.Background())
+		}
+	}
+}
+
 type app struct {
+	apps       *apps
 	name       istructs.AppQName
 	def        appdef.IAppDef
 	partsCount int
 	structs    istructs.IAppStructs
 	engines    [cluster.ProcessorKind_Count]*pool.Pool[*engine]
+	all        [cluster.ProcessorKind_Count][]*engine
 	// no locks need. Owned apps structure will locks access to this structure
 	parts map[istructs.PartitionID]*partition
 }
 
-func newApplication(name istructs.AppQName) *app {
+func newApplication(apps *apps, name istructs.AppQName) *app {
 	return &app{
+		apps:  apps,
 		name:  name,
 		parts: map[istructs.PartitionID]*partition{},
 	}
 }
 
-func (a *app) deploy(def appdef.IAppDef, structs istructs.IAppStructs, partsCount int, engines [cluster.ProcessorKind_Count]int) {
-	a.def = def
-	a.structs = structs
-	a.partsCount = partsCount
+// Deploys application. Returns functions to drain in-flight invocations of replaced modules, they should be called outside of partitions lock
+func (a *app) deploy(def appdef.IAppDef, extModuleURLs map[string]*url.URL, structs istructs.IAppStructs, partsCount int, engines [cluster.ProcessorKind_Count]int) (drains []func(context.Context), err error) {
+	packages := extensionPackages(def, extModuleURLs)
+
+	if (a.def != nil) && (len(a.apps.extEngines) > 0) {
+		sameSizes := true
+		for k, cnt := range engines {
+			sameSizes = sameSizes && (len(a.all[k]) == cnt)
+		}
+		if sameSizes {
+			if drains, err = a.reloadEngines(packages); err != nil {
+				return nil, err
+			}
+			a.def = def
+			a.structs = structs
+			a.partsCount = partsCount
+			return drains, nil
+		}
+	}
+
+	var all [cluster.ProcessorKind_Count][]*engine
 	for k, cnt := range engines {
 		ee := make([]*engine, cnt)
 		for i := 0; i < cnt; i++ {
 			ee[i] = newEngine(cluster.ProcessorKind(k))
 		}
+		all[k] = ee
+	}
+	extConfig := *a.apps.extConfig
+	extConfig.AppQName = a.name
+	for extKind, factory := range a.apps.extEngines {
+		for _, ee := range all {
+			if len(ee) == 0 {
+				continue
+			}
+			extEngines, err := factory.New(a.apps.ctx, packages, &extConfig, len(ee))
+			if err != nil {
+				for _, e := range extEngines {
+					e.Close(a.apps.ctx)
+				}
+				for _, ee := range all {
+					for _, e := range ee {
+						e.close()
+					}
+				}
+				return nil, fmt.Errorf("unable to create %v extension engines for application %v: %w", extKind.TrimString(), a.name, err)
+			}
+			for i, e := range ee {
+				e.extensions[extKind] = extEngines[i]
+			}
+		}
+	}
+
+	a.retireEngines()
+
+	a.def = def
+	a.structs = structs
+	a.partsCount = partsCount
+	a.all = all
+	for k, ee := range all {
 		a.engines[k] = pool.New[*engine](ee)
 	}
+	return nil, nil
+}
+
+// Replaces modules of reloadable extension engines.
+//
+// New modules are prepared for all engines first. If any preparation fails, then all prepared modules are closed and engines are kept untouched.
+// Returns functions to drain in-flight invocations of replaced modules.
+func (a *app) reloadEngines(packages []iextengine.ExtensionPackage) (drains []func(context.Context), err error) {
+	if len(packages) == 0 {
+		return nil, nil
+	}
+	reloads := make([]iextengine.IExtensionEngineReload, 0)
+	for _, ee := range a.all {
+		for _, e := range ee {
+			for _, ext := range e.extensions {
+				if r, ok := ext.(iextengine.IReloadableExtensionEngine); ok {
+					reload, err := r.PrepareReload(a.apps.ctx, packages)
+					if err != nil {
+						for _, r := range reloads {
+							r.Rollback(a.apps.ctx)
+						}
+						return nil, fmt.Errorf("unable to reload extensions of application %v: %w", a.name, err)
+					}
+					reloads = append(reloads, reload)
+				}
+			}
+		}
+	}
+	drains = make([]func(context.Context), 0, len(reloads))
+	for _, r := range reloads {
+		drains = append(drains, r.Commit())
+	}
+	return drains, nil
+}
+
+func (a *app) retireEngines() {
+	for _, ee := range a.all {
+		for _, e := range ee {
+			e.retire()
+		}
+	}
+}
+
+// Returns extension packages with WASM extensions and their limits from application definition
+func extensionPackages(def appdef.IAppDef, extModuleURLs map[string]*url.URL) []iextengine.ExtensionPackage {
+	packages := make([]iextengine.ExtensionPackage, 0, len(extModuleURLs))
+	for pkg, url := range extModuleURLs {
+		p := iextengine.ExtensionPackage{
+			QualifiedName:   pkg,
+			ModuleUrl:       url,
+			ExtensionLimits: make(map[string]iextengine.ExtensionLimits),
+		}
+		def.Extensions(func(e appdef.IExtension) {
+			if (e.Engine() == appdef.ExtensionEngineKind_WASM) && (e.QName().Pkg() == pkg) {
+				p.ExtensionNames = append(p.ExtensionNames, e.Name())
+				if l := e.Limits(); l != (appdef.ExtensionLimits{}) {
+					p.ExtensionLimits[e.Name()] = iextengine.ExtensionLimits{
+						ExecutionInterval: l.MaxDuration,
+						MaxHostCalls:      l.MaxHostCalls,
+						MaxIntents:        l.MaxIntents,
+						MaxMemoryPages:    l.MaxMemoryPages,
+					}
+				}
+			}
+		})
+		packages = append(packages, p)
+	}
+	return packages
 }
 
 type partition struct {
@@ -106,6 +262,18 @@ func (rt *partitionRT) App() istructs.AppQName           { return rt.part.app.na
 func (rt *partitionRT) AppStructs() istructs.IAppStructs { return rt.appStructs }
 func (rt *partitionRT) ID() istructs.PartitionID         { return rt.part.id }
 
+func (rt *partitionRT) Invoke(ctx context.Context, name appdef.QName, io iextengine.IExtensionIO) error {
+	e, ok := rt.appDef.TypeByName(name).(appdef.IExtension)
+	if !ok {
+		return fmt.Errorf(errExtensionNotFound, name, ErrNotFound)
+	}
+	ext := rt.borrowed.extensions[e.Engine()]
+	if ext == nil {
+		return fmt.Errorf("%w: %v for extension %v", ErrNotAvailableEngines, e.Engine().TrimString(), name)
+	}
+	return ext.Invoke(ctx, iextengine.NewExtQName(name.Pkg(), e.Name()), io)
+}
+
 func (rt *partitionRT) Release() {
 	if e := rt.borrowed; e != nil {
 		rt.borrowed = nil
@@ -120,7 +288,9 @@ func (rt *partitionRT) init(proc cluster.ProcessorKind) error {
 	if err != nil {
 		return fmt.Errorf("%w (%w): %s", ErrNotAvailableEngines, err, proc.TrimString())
 	}
+	engine.mx.Lock()
 	engine.pool = pool
+	engine.mx.Unlock()
 	rt.borrowed = engine
 	return nil
 }
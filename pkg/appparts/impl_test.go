@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package appparts
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/cluster"
+	"github.com/voedger/voedger/pkg/iextengine"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+)
+
+type mockExtEngine struct {
+	mx        sync.Mutex
+	packages  []iextengine.ExtensionPackage
+	invoked   []iextengine.ExtQName
+	reloads   int
+	rollbacks int
+	reloadErr error
+	closed    bool
+}
+
+func (e *mockExtEngine) SetLimits(iextengine.ExtensionLimits) {}
+
+func (e *mockExtEngine) Invoke(_ context.Context, extName iextengine.ExtQName, _ iextengine.IExtensionIO) error {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	e.invoked = append(e.invoked, extName)
+	return nil
+}
+
+func (e *mockExtEngine) Close(context.Context) {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	e.closed = true
+}
+
+func (e *mockExtEngine) PrepareReload(_ context.Context, packages []iextengine.ExtensionPackage) (iextengine.IExtensionEngineReload, error) {
+	if e.reloadErr != nil {
+		return nil, e.reloadErr
+	}
+	return &mockExtEngineReload{engine: e, packages: packages}, nil
+}
+
+type mockExtEngineReload struct {
+	engine   *mockExtEngine
+	packages []iextengine.ExtensionPackage
+}
+
+func (r *mockExtEngineReload) Commit() func(context.Context) {
+	r.engine.mx.Lock()
+	defer r.engine.mx.Unlock()
+	r.engine.packages = r.packages
+	r.engine.reloads++
+	return func(context.Context) {}
+}
+
+func (r *mockExtEngineReload) Rollback(context.Context) {
+	r.engine.mx.Lock()
+	defer r.engine.mx.Unlock()
+	r.engine.rollbacks++
+}
+
+type mockExtEngineFactory struct {
+	engines []*mockExtEngine
+	config  *iextengine.ExtEngineConfig
+}
+
+func (f *mockExtEngineFactory) New(_ context.Context, packages []iextengine.ExtensionPackage, config *iextengine.ExtEngineConfig, numEngines int) ([]iextengine.IExtensionEngine, error) {
+	f.config = config
+	ee := make([]iextengine.IExtensionEngine, numEngines)
+	for i := range ee {
+		e := &mockExtEngine{packages: packages}
+		f.engines = append(f.engines, e)
+		ee[i] = e
+	}
+	return ee, nil
+}
+
+func Test_DeployAppWithExtEngines(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	appName := istructs.AppQName_test1_app1
+	cmdName := appdef.NewQName("test", "cmd")
+
+	adb := appdef.New()
+	adb.AddCommand(cmdName).SetName("execCmd").SetEngine(appdef.ExtensionEngineKind_WASM).SetLimits(appdef.ExtensionLimits{MaxIntents: 10})
+	appConfigs := istructsmem.AppConfigsType{}
+	appConfigs.AddConfig(appName, adb)
+	appDef, err := adb.Build()
+	require.NoError(err)
+
+	appStructs := istructsmem.Provide(
+		appConfigs,
+		iratesce.TestBucketsFactory,
+		payloads.TestAppTokensFactory(itokensjwt.TestTokensJWT()),
+		provider.Provide(mem.Provide(), ""))
+
+	factory := &mockExtEngineFactory{}
+	appParts, cleanup, err := NewWithExtEngines(ctx, appStructs, iextengine.IExtensionEngineFactories{appdef.ExtensionEngineKind_WASM: factory}, nil)
+	require.NoError(err)
+	defer cleanup()
+
+	moduleURL, err := url.Parse("file:///test/pkg.wasm")
+	require.NoError(err)
+	extModuleURLs := map[string]*url.URL{"test": moduleURL}
+
+	require.NoError(appParts.DeployApp(appName, extModuleURLs, appDef, 1, [cluster.ProcessorKind_Count]int{2, 1, 1}))
+	appParts.DeployAppPartitions(appName, []istructs.PartitionID{1})
+
+	require.Len(factory.engines, 4)
+	require.Equal([]iextengine.ExtensionPackage{{
+		QualifiedName:   "test",
+		ModuleUrl:       moduleURL,
+		ExtensionNames:  []string{"execCmd"},
+		ExtensionLimits: map[string]iextengine.ExtensionLimits{"execCmd": {MaxIntents: 10}},
+	}}, factory.engines[0].packages)
+	require.Equal(appName, factory.config.AppQName)
+
+	t.Run("should invoke extension by borrowed engine", func(t *testing.T) {
+		part, err := appParts.Borrow(appName, 1, cluster.ProcessorKind_Command)
+		require.NoError(err)
+		defer part.Release()
+
+		require.NoError(part.Invoke(ctx, cmdName, nil))
+		invoked := 0
+		for _, e := range factory.engines {
+			invoked += len(e.invoked)
+		}
+		require.Equal(1, invoked)
+
+		err = part.Invoke(ctx, appdef.NewQName("test", "unknown"), nil)
+		require.ErrorIs(err, ErrNotFound)
+	})
+
+	t.Run("should reload modules if engines pools sizes are not changed", func(t *testing.T) {
+		require.NoError(appParts.DeployApp(appName, extModuleURLs, appDef, 1, [cluster.ProcessorKind_Count]int{2, 1, 1}))
+
+		require.Len(factory.engines, 4)
+		for _, e := range factory.engines {
+			require.Equal(1, e.reloads)
+			require.False(e.closed)
+		}
+	})
+
+	t.Run("should not reload any engine if some engine can not be reloaded", func(t *testing.T) {
+		testErr := errors.New("test error")
+		factory.engines[len(factory.engines)-1].reloadErr = testErr
+		defer func() { factory.engines[len(factory.engines)-1].reloadErr = nil }()
+
+		err := appParts.DeployApp(appName, extModuleURLs, appDef, 1, [cluster.ProcessorKind_Count]int{2, 1, 1})
+		require.ErrorIs(err, testErr)
+
+		rollbacks := 0
+		for _, e := range factory.engines {
+			require.Equal(1, e.reloads)
+			require.False(e.closed)
+			rollbacks += e.rollbacks
+		}
+		require.Equal(len(factory.engines)-1, rollbacks, "prepared reloads should be rolled back")
+	})
+
+	t.Run("should retire old engines if engines pools sizes are changed", func(t *testing.T) {
+		part, err := appParts.Borrow(appName, 1, cluster.ProcessorKind_Query)
+		require.NoError(err)
+
+		require.NoError(appParts.DeployApp(appName, extModuleURLs, appDef, 1, [cluster.ProcessorKind_Count]int{1, 1, 1}))
+		require.Len(factory.engines, 7)
+
+		closed := 0
+		for _, e := range factory.engines[:4] {
+			if e.closed {
+				closed++
+			}
+		}
+		require.Equal(3, closed, "idle engines should be closed")
+
+		part.Release()
+		for _, e := range factory.engines[:4] {
+			require.True(e.closed, "borrowed engine should be closed on release")
+		}
+		for _, e := range factory.engines[4:] {
+			require.False(e.closed)
+		}
+	})
+}
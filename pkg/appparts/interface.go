@@ -6,8 +6,12 @@
 package appparts
 
 import (
+	"context"
+	"net/url"
+
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/cluster"
+	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
@@ -15,10 +19,18 @@ import (
 type IAppPartitions interface {
 	// Adds new application or update existing.
 	//
+	// extModuleURLs is URLs of WASM modules by package names. Extensions of package are taken from application definition.
+	//
 	// If application with the same name exists, then its definition will be updated.
+	// If engines pools sizes are not changed, then extension engines are kept and
+	// their modules are replaced. Modules of all engines are replaced or none of them.
+	// Returns after in-flight invocations of replaced modules are finished.
+	//
+	// Returns error if application structures or extension engines can not be created or modules can not be replaced.
+	// In this case application is kept unchanged.
 	//
 	// @ConcurrentAccess
-	DeployApp(name istructs.AppQName, def appdef.IAppDef, partsCount int, engines [cluster.ProcessorKind_Count]int)
+	DeployApp(name istructs.AppQName, extModuleURLs map[string]*url.URL, def appdef.IAppDef, partsCount int, engines [cluster.ProcessorKind_Count]int) error
 
 	// Deploys new partitions for specified application or update existing.
 	//
@@ -55,6 +67,11 @@ type IAppPartition interface {
 
 	AppStructs() istructs.IAppStructs
 
+	// Invokes extension by name using borrowed engine.
+	//
+	// Returns error if extension not found or there is no engine for extension engine kind.
+	Invoke(ctx context.Context, name appdef.QName, io iextengine.IExtensionIO) error
+
 	// Releases borrowed partition.
 	//
 	// @ConcurrentAccess
@@ -6,9 +6,20 @@
 package appparts
 
 import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
 func New(structs istructs.IAppStructsProvider) (ap IAppPartitions, cleanup func(), err error) {
-	return newAppPartitions(structs)
+	return newAppPartitions(context.Background(), structs, nil, nil)
+}
+
+// Returns partitions manager, which engines are provided with extension engines created by specified factories.
+//
+// Context is used to create extension engines and replace their modules.
+// Cleanup closes all extension engines.
+func NewWithExtEngines(ctx context.Context, structs istructs.IAppStructsProvider, extEngines iextengine.IExtensionEngineFactories, extConfig *iextengine.ExtEngineConfig) (ap IAppPartitions, cleanup func(), err error) {
+	return newAppPartitions(ctx, structs, extEngines, extConfig)
 }
@@ -23,20 +23,21 @@ func newAppPartitionsController(parts appparts.IAppPartitions, apps []BuiltInApp
 	return &apc, func() {}, err
 }
 
+// Deploys built-in applications and their partitions
 func (ctl *appPartitionsController) Prepare() (err error) {
-	return err
-}
-
-func (ctl *appPartitionsController) Run(ctx context.Context) {
-
 	for _, app := range ctl.apps {
-		ctl.parts.DeployApp(app.Name, app.Def, app.PartsCount, app.EnginePoolSize)
+		if err := ctl.parts.DeployApp(app.Name, app.ExtModuleURLs, app.Def, app.PartsCount, app.EnginePoolSize); err != nil {
+			return err
+		}
 		ids := make([]istructs.PartitionID, app.PartsCount)
 		for id := 0; id < app.PartsCount; id++ {
 			ids[id] = istructs.PartitionID(id)
 		}
 		ctl.parts.DeployAppPartitions(app.Name, ids)
 	}
+	return nil
+}
 
+func (ctl *appPartitionsController) Run(ctx context.Context) {
 	<-ctx.Done()
 }
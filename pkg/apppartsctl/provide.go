@@ -6,6 +6,8 @@
 package apppartsctl
 
 import (
+	"net/url"
+
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/cluster"
@@ -24,6 +26,9 @@ type BuiltInApp struct {
 	// Application definition will use to generate AppStructs
 	Def appdef.IAppDef
 
+	// URLs of WASM modules by package names
+	ExtModuleURLs map[string]*url.URL
+
 	// Number of partitions. Partitions IDs will be generated from 0 to PartsCount-1
 	PartsCount int
 
@@ -15,6 +15,8 @@ const (
 	storageTypeCas1         string                = "cas1"
 	storageTypeCas3         string                = "cas3"
 	storageTypeMem          string                = "mem"
+	storageTypeLevelDB      string                = "leveldb"
+	storageTypeBBolt        string                = "bbolt"
 	cas1ReplicationStrategy string                = "{'class': 'SimpleStrategy', 'replication_factor': '1'}"
 	cas3ReplicationStrategy string                = "{ 'class': 'NetworkTopologyStrategy', 'dc1': 2, 'dc2': 1}"
 )
@@ -23,6 +25,7 @@ const (
 	defaultGrafanaPort    = 3000
 	defaultPrometheusPort = 9090
 	defaultCassandraPort  = 9042
+	defaultStorageDir     = "db"
 )
 
 var defaultCasParams = cas.CassandraParamsType{
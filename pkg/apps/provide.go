@@ -15,7 +15,9 @@ import (
 	sysmonitor "github.com/voedger/voedger/pkg/apps/sys.monitor"
 	"github.com/voedger/voedger/pkg/ihttpctl"
 	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/bbolt"
 	"github.com/voedger/voedger/pkg/istorage/cas"
+	"github.com/voedger/voedger/pkg/istorage/leveldb"
 	"github.com/voedger/voedger/pkg/istorage/mem"
 	"github.com/voedger/voedger/pkg/istructs"
 	coreutils "github.com/voedger/voedger/pkg/utils"
@@ -53,6 +55,16 @@ func NewAppStorageFactory(params CLIParams) (istorage.IAppStorageFactory, error)
 		casParams.KeyspaceWithReplication = cas3ReplicationStrategy
 	case storageTypeMem:
 		return mem.Provide(), nil
+	case storageTypeLevelDB:
+		if len(params.StorageDir) == 0 {
+			params.StorageDir = defaultStorageDir
+		}
+		return leveldb.Provide(leveldb.ParamsType{DBDir: params.StorageDir}), nil
+	case storageTypeBBolt:
+		if len(params.StorageDir) == 0 {
+			params.StorageDir = defaultStorageDir
+		}
+		return bbolt.Provide(bbolt.ParamsType{DBDir: params.StorageDir}), nil
 	default:
 		return nil, fmt.Errorf("unable to define replication strategy")
 	}
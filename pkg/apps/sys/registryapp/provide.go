@@ -17,7 +17,7 @@ import (
 	"github.com/voedger/voedger/pkg/sys/smtp"
 )
 
-func Provide(smtpCfg smtp.Cfg) apps.AppBuilder {
+func Provide(smtpCfg smtp.Cfg, oidc registry.OIDCProviders) apps.AppBuilder {
 	return func(apis apps.APIs, cfg *istructsmem.AppConfigType, appDefBuilder appdef.IAppDefBuilder, ep extensionpoints.IExtensionPoint) apps.AppPackages {
 
 		// sys package
@@ -25,8 +25,8 @@ func Provide(smtpCfg smtp.Cfg) apps.AppBuilder {
 			apis.NumCommandProcessors, nil, apis.IAppStorageProvider)
 
 		// sys/registry resources
-		registryPackageFS := registry.Provide(cfg, apis.IAppStructsProvider, apis.ITokens, apis.IFederation)
-		cfg.AddSyncProjectors(registry.ProvideSyncProjectorLoginIdxFactory())
+		registryPackageFS := registry.Provide(cfg, apis.IAppStructsProvider, apis.ITokens, apis.IFederation, oidc, apis.TimeFunc)
+		cfg.AddSyncProjectors(registry.ProvideSyncProjectorLoginIdxFactory(), registry.ProvideSyncProjectorExternalIdentityIdxFactory())
 		registryAppPackageFS := parser.PackageFS{
 			QualifiedPackageName: RegistryAppFQN,
 			FS:                   registryAppSchemaFS,
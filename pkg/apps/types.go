@@ -42,4 +42,6 @@ type AppPackages struct {
 }
 type CLIParams struct {
 	Storage string
+	// data directory of the embedded storage
+	StorageDir string
 }
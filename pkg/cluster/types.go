@@ -5,6 +5,8 @@
 
 package cluster
 
+import "net/url"
+
 // ProcessorKind is a enumeration of processors.
 type ProcessorKind uint8
 
@@ -21,6 +23,9 @@ const (
 type AppDeploymentDescriptor struct {
 	PartsCount     int
 	EnginePoolSize [ProcessorKind_Count]int
+
+	// URLs of WASM modules by package names
+	ExtModuleURLs map[string]*url.URL
 }
 
 func PoolSize(c, q, p int) [ProcessorKind_Count]int { return [ProcessorKind_Count]int{c, q, p} }
@@ -0,0 +1,49 @@
+-- Copyright (c) 2024-present unTill Pro, Ltd.
+
+APPLICATION shop();
+
+WORKSPACE ShopWS (
+	DESCRIPTOR ShopWSDescriptor ();
+
+	TABLE Purchase INHERITS CDoc (
+		Customer int32 NOT NULL,
+		Day int32 NOT NULL,
+		Amount int32 NOT NULL
+	);
+
+	TABLE ShopStats INHERITS Singleton (
+		Purchases int32 NOT NULL
+	);
+
+	TYPE PurchaseParams (
+		Customer int32 NOT NULL,
+		Day int32 NOT NULL,
+		Amount int32 NOT NULL
+	);
+
+	TYPE PurchaseResult (
+		DayTotal int32 NOT NULL
+	);
+
+	TYPE CustomerParams (
+		Customer int32 NOT NULL
+	);
+
+	TYPE DayTotal (
+		Day int32 NOT NULL,
+		Total int32 NOT NULL
+	);
+
+	VIEW DailyTotals (
+		Customer int32 NOT NULL,
+		Day int32 NOT NULL,
+		Total int32 NOT NULL,
+		PRIMARY KEY ((Customer), Day)
+	) AS RESULT OF CountTotals;
+
+	EXTENSION ENGINE WASM (
+		COMMAND MakePurchase(PurchaseParams) RETURNS PurchaseResult;
+		QUERY CustomerTotals(CustomerParams) RETURNS DayTotal;
+		PROJECTOR CountTotals AFTER EXECUTE ON MakePurchase INTENTS(View(DailyTotals));
+	);
+);
@@ -0,0 +1,25 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package exttinygotests
+
+import (
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys/builtin"
+)
+
+const (
+	ProcessorKind_Command ProcessorKind = iota
+	ProcessorKind_Query
+)
+
+const (
+	testWSID        = istructs.WSID(1)
+	testPartitionID = istructs.PartitionID(1)
+
+	// intents limit is the same as command processor uses
+	commandIntentsLimit = builtin.MaxCUDs
+)
+
+var testApp = istructs.AppQName_test1_app1
@@ -0,0 +1,16 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package exttinygotests
+
+import "errors"
+
+var (
+	ErrExtensionNotFound   = errors.New("extension not found")
+	ErrSecretNotFound      = errors.New("secret not found")
+	ErrIncorrectKeyBuilder = errors.New("incorrect key builder")
+	ErrIncorrectKey        = errors.New("incorrect key")
+	ErrIncorrectValue      = errors.New("incorrect value")
+	ErrIncorrectIntent     = errors.New("incorrect intent")
+)
@@ -0,0 +1,297 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package exttinygotests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+
+	ext "github.com/voedger/exttinygo"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/state"
+)
+
+// PutRecord puts the record to the workspace as the sys.CUD command does. Returns ID of the record.
+//
+// Entity is the name of the document or record, fields are put as JSON values
+func (ts *TestState) PutRecord(entity string, fields map[string]any) istructs.RecordID {
+	bld := ts.appStructs.Events().GetSyncRawEventBuilder(ts.eventParams(istructs.QNameCommandCUD))
+	rec := bld.CUDBuilder().Create(ts.qname(entity))
+	rec.PutRecordID(appdef.SystemField_ID, istructs.MinRawRecordID)
+	rec.PutFromJSON(jsonFields(fields))
+
+	id := istructs.NullRecordID
+	require.NoError(ts.t, ts.putEvent(bld, func(r istructs.IRecord) { id = r.ID() }))
+	return id
+}
+
+// PutView puts the view record to the workspace. Entity is the name of the view
+func (ts *TestState) PutView(entity string, key, value map[string]any) {
+	view := ts.qname(entity)
+	kb := ts.appStructs.ViewRecords().KeyBuilder(view)
+	kb.PutFromJSON(jsonFields(key))
+	vb := ts.appStructs.ViewRecords().NewValueBuilder(view)
+	vb.PutFromJSON(jsonFields(value))
+	require.NoError(ts.t, ts.appStructs.ViewRecords().Put(testWSID, kb, vb))
+}
+
+// PutSecret makes the secret available through the sys.AppSecret storage
+func (ts *TestState) PutSecret(name string, value []byte) {
+	ts.secrets[name] = value
+}
+
+// PutArgument sets fields of the argument object for the following runs
+func (ts *TestState) PutArgument(fields map[string]any) {
+	ts.argument = jsonFields(fields)
+}
+
+// Run runs the extension against the state and applies intents made, the same way the processor does.
+//
+// The command event is stored with its CUDs, so the following runs see changes made by the command.
+// Returns error if the extension panics, the event or the result are invalid
+func (ts *TestState) Run(extension func()) (err error) {
+	ts.intents = nil
+	ts.cmdResult = nil
+	ts.queryResult = nil
+
+	var cmd *commandRun
+	var hostState state.IHostState
+	if ts.kind == ProcessorKind_Command {
+		if cmd, err = ts.newCommandRun(); err != nil {
+			return err
+		}
+		hostState = cmd.state
+	} else if hostState, err = ts.queryState(); err != nil {
+		return err
+	}
+
+	host := &nativeHost{state: hostState}
+	prev := ext.SetNativeHost(host)
+	defer ext.SetNativeHost(prev)
+
+	err = host.run(extension)
+	for _, i := range host.intents {
+		ts.intents = append(ts.intents, i.Intent)
+	}
+	if err != nil {
+		return err
+	}
+
+	if cmd == nil {
+		return hostState.ApplyIntents()
+	}
+
+	// command intents are written to the CUDs and to the result directly, the same as the command processor does
+	if cmd.resultBuilder != nil {
+		if ts.cmdResult, err = cmd.resultBuilder.Build(); err != nil {
+			return err
+		}
+	}
+	return ts.putEvent(cmd.reb, nil)
+}
+
+// Intents returns intents made by the last run
+func (ts *TestState) Intents() []Intent {
+	intents := make([]Intent, 0, len(ts.intents))
+	for _, i := range ts.intents {
+		intents = append(intents, *i)
+	}
+	return intents
+}
+
+// RequireIntent requires the last run made the intent for the storage and entity and returns it.
+//
+// Storage is one of exttinygo Storage* constants, entity is the name of the entity or empty string
+func (ts *TestState) RequireIntent(storage, entity string) Intent {
+	s, err := appdef.ParseQName(storage)
+	require.NoError(ts.t, err)
+	e := appdef.NullQName
+	if entity != ext.NullEntity {
+		e = ts.qname(entity)
+	}
+	for _, i := range ts.intents {
+		if i.Storage == s && i.Entity == e {
+			return *i
+		}
+	}
+	require.Fail(ts.t, "intent not found", "storage «%v», entity «%v»", s, e)
+	return Intent{}
+}
+
+// RequireNoIntents requires the last run made no intents
+func (ts *TestState) RequireNoIntents() {
+	require.Empty(ts.t, ts.intents)
+}
+
+// CommandResult returns the result object built by the command on the last run, nil if the command has no result
+func (ts *TestState) CommandResult() istructs.IObject {
+	return ts.cmdResult
+}
+
+// QueryResult returns result objects sent by the query on the last run
+func (ts *TestState) QueryResult() []istructs.IObject {
+	return ts.queryResult
+}
+
+// Record returns the record from the workspace, test fails if the record does not exist
+func (ts *TestState) Record(id istructs.RecordID) istructs.IRecord {
+	rec, err := ts.appStructs.Records().Get(testWSID, true, id)
+	require.NoError(ts.t, err)
+	require.NotEqual(ts.t, appdef.NullQName, rec.QName(), "record %d not found", id)
+	return rec
+}
+
+func (ts *TestState) newCommandRun() (*commandRun, error) {
+	cmd := ts.appStructs.AppDef().Command(ts.extension)
+	run := &commandRun{
+		reb: ts.appStructs.Events().GetSyncRawEventBuilder(ts.eventParams(ts.extension)),
+	}
+
+	var arg istructs.IObject
+	if cmd.Param() != nil {
+		aob := run.reb.ArgumentObjectBuilder()
+		aob.FillFromJSON(ts.argument)
+		obj, err := aob.Build()
+		if err != nil {
+			return nil, err
+		}
+		arg = obj
+	}
+
+	if cmd.Result() != nil {
+		run.resultBuilder = ts.appStructs.ObjectBuilder(cmd.Result().QName())
+	}
+
+	run.state = state.ProvideCommandProcessorStateFactory()(
+		context.Background(),
+		func() istructs.IAppStructs { return ts.appStructs },
+		state.SimplePartitionIDFunc(testPartitionID),
+		state.SimpleWSIDFunc(testWSID),
+		ts.secrets,
+		func() istructs.ICUD { return run.reb.CUDBuilder() },
+		func() []iauthnz.Principal { return nil },
+		func() string { return "" },
+		commandIntentsLimit,
+		func() istructs.IObjectBuilder { return run.resultBuilder },
+		func() istructs.IObject { return arg },
+		func() istructs.IObject { return nil },
+	)
+	return run, nil
+}
+
+func (ts *TestState) queryState() (state.IHostState, error) {
+	query := ts.appStructs.AppDef().Query(ts.extension)
+
+	var arg istructs.IObject
+	if query.Param() != nil {
+		aob := ts.appStructs.ObjectBuilder(query.Param().QName())
+		aob.FillFromJSON(ts.argument)
+		obj, err := aob.Build()
+		if err != nil {
+			return nil, err
+		}
+		arg = obj
+	}
+
+	resultType := appdef.NullQName
+	if query.Result() != nil {
+		resultType = query.Result().QName()
+	}
+
+	return state.ProvideQueryProcessorStateFactory()(
+		context.Background(),
+		ts.appStructs,
+		state.SimplePartitionIDFunc(testPartitionID),
+		state.SimpleWSIDFunc(testWSID),
+		ts.secrets,
+		func() []iauthnz.Principal { return nil },
+		func() string { return "" },
+		func() istructs.IObject { return arg },
+		func() istructs.IObjectBuilder { return ts.appStructs.ObjectBuilder(resultType) },
+		func() istructs.ExecQueryCallback {
+			return func(object istructs.IObject) error {
+				ts.queryResult = append(ts.queryResult, object)
+				return nil
+			}
+		},
+	), nil
+}
+
+func (ts *TestState) eventParams(command appdef.QName) istructs.SyncRawEventBuilderParams {
+	return istructs.SyncRawEventBuilderParams{
+		GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+			HandlingPartition: testPartitionID,
+			PLogOffset:        ts.plogOffset,
+			Workspace:         testWSID,
+			WLogOffset:        ts.wlogOffset,
+			QName:             command,
+		},
+	}
+}
+
+// putEvent puts the event to PLog and WLog and applies it to records as the command processor does
+func (ts *TestState) putEvent(reb istructs.IRawEventBuilder, cb func(istructs.IRecord)) error {
+	rawEvent, err := reb.BuildRawEvent()
+	if err != nil {
+		return err
+	}
+	event, err := ts.appStructs.Events().PutPlog(rawEvent, nil, ts.idGen)
+	if err != nil {
+		return err
+	}
+	ts.plogOffset++
+	if err = ts.appStructs.Events().PutWlog(event); err != nil {
+		return err
+	}
+	ts.wlogOffset++
+	if cb == nil {
+		cb = func(istructs.IRecord) {}
+	}
+	return ts.appStructs.Records().Apply2(event, cb)
+}
+
+// qname returns the qualified name of the package entity. Qualified names are returned as is
+func (ts *TestState) qname(entity string) appdef.QName {
+	if strings.Contains(entity, appdef.QNameQualifierChar) {
+		return appdef.MustParseQName(entity)
+	}
+	return appdef.NewQName(ts.pkgName, entity)
+}
+
+// jsonFields converts Go numbers to float64, as fields are put from JSON
+func jsonFields(fields map[string]any) map[string]any {
+	res := make(map[string]any, len(fields))
+	for n, v := range fields {
+		switch v := v.(type) {
+		case int:
+			res[n] = float64(v)
+		case int32:
+			res[n] = float64(v)
+		case int64:
+			res[n] = float64(v)
+		case float32:
+			res[n] = float64(v)
+		case istructs.RecordID:
+			res[n] = float64(v)
+		case appdef.QName:
+			res[n] = v.String()
+		default:
+			res[n] = v
+		}
+	}
+	return res
+}
+
+func (r secretReader) ReadSecret(name string) ([]byte, error) {
+	if bb, ok := r[name]; ok {
+		return bb, nil
+	}
+	return nil, fmt.Errorf("secret «%s»: %w", name, ErrSecretNotFound)
+}
@@ -0,0 +1,300 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package exttinygotests
+
+import (
+	"errors"
+	"fmt"
+
+	ext "github.com/voedger/exttinygo"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// run runs the extension, panics of the extension and of the host are returned as errors
+func (h *nativeHost) run(extension func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch r := r.(type) {
+			case error:
+				err = r
+			default:
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+	extension()
+	return nil
+}
+
+func (h *nativeHost) Panic(msg string) {
+	panic(errors.New(msg))
+}
+
+func (h *nativeHost) KeyBuilder(storage, entity string) uint64 {
+	s, err := appdef.ParseQName(storage)
+	if err != nil {
+		panic(err)
+	}
+	e := appdef.NullQName
+	if entity != ext.NullEntity {
+		if e, err = appdef.ParseQName(entity); err != nil {
+			panic(err)
+		}
+	}
+	kb, err := h.state.KeyBuilder(s, e)
+	if err != nil {
+		panic(err)
+	}
+	h.keyBuilders = append(h.keyBuilders, &keyBuilder{IStateKeyBuilder: kb, storage: s, entity: e, fields: make(map[string]any)})
+	return uint64(len(h.keyBuilders) - 1)
+}
+
+func (h *nativeHost) GetValue(keyBuilder uint64) uint64 {
+	v, err := h.state.MustExist(h.keyBuilder(keyBuilder).IStateKeyBuilder)
+	if err != nil {
+		panic(err)
+	}
+	return h.addValue(v)
+}
+
+func (h *nativeHost) QueryValue(keyBuilder uint64) (uint64, bool) {
+	v, ok, err := h.state.CanExist(h.keyBuilder(keyBuilder).IStateKeyBuilder)
+	if err != nil {
+		panic(err)
+	}
+	if !ok {
+		return 0, false
+	}
+	return h.addValue(v), true
+}
+
+func (h *nativeHost) ReadValues(keyBuilder uint64, callback func(key, value uint64)) {
+	err := h.state.Read(h.keyBuilder(keyBuilder).IStateKeyBuilder, func(key istructs.IKey, value istructs.IStateValue) error {
+		h.keys = append(h.keys, key)
+		callback(uint64(len(h.keys)-1), h.addValue(value))
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (h *nativeHost) NewValue(keyBuilder uint64) uint64 {
+	kb := h.keyBuilder(keyBuilder)
+	vb, err := h.state.NewValue(kb.IStateKeyBuilder)
+	if err != nil {
+		panic(err)
+	}
+	return h.addIntent(kb, vb)
+}
+
+func (h *nativeHost) UpdateValue(keyBuilder, existingValue uint64) uint64 {
+	kb := h.keyBuilder(keyBuilder)
+	vb, err := h.state.UpdateValue(kb.IStateKeyBuilder, h.value(existingValue))
+	if err != nil {
+		panic(err)
+	}
+	return h.addIntent(kb, vb)
+}
+
+func (h *nativeHost) RowWriterPutString(id uint64, isIntent bool, name string, value string) {
+	h.rowWriter(id, isIntent, name, value).PutString(name, value)
+}
+
+func (h *nativeHost) RowWriterPutBytes(id uint64, isIntent bool, name string, value []byte) {
+	h.rowWriter(id, isIntent, name, value).PutBytes(name, value)
+}
+
+func (h *nativeHost) RowWriterPutQName(id uint64, isIntent bool, name string, value ext.QName) {
+	qName := appdef.NewQName(value.Pkg, value.Entity)
+	h.rowWriter(id, isIntent, name, qName).PutQName(name, qName)
+}
+
+func (h *nativeHost) RowWriterPutBool(id uint64, isIntent bool, name string, value bool) {
+	h.rowWriter(id, isIntent, name, value).PutBool(name, value)
+}
+
+func (h *nativeHost) RowWriterPutInt32(id uint64, isIntent bool, name string, value int32) {
+	h.rowWriter(id, isIntent, name, value).PutInt32(name, value)
+}
+
+func (h *nativeHost) RowWriterPutInt64(id uint64, isIntent bool, name string, value int64) {
+	h.rowWriter(id, isIntent, name, value).PutInt64(name, value)
+}
+
+func (h *nativeHost) RowWriterPutFloat32(id uint64, isIntent bool, name string, value float32) {
+	h.rowWriter(id, isIntent, name, value).PutFloat32(name, value)
+}
+
+func (h *nativeHost) RowWriterPutFloat64(id uint64, isIntent bool, name string, value float64) {
+	h.rowWriter(id, isIntent, name, value).PutFloat64(name, value)
+}
+
+func (h *nativeHost) KeyAsString(key uint64, name string) string {
+	return h.key(key).AsString(name)
+}
+
+func (h *nativeHost) KeyAsBytes(key uint64, name string) []byte {
+	return h.key(key).AsBytes(name)
+}
+
+func (h *nativeHost) KeyAsQName(key uint64, name string) ext.QName {
+	return extQName(h.key(key).AsQName(name))
+}
+
+func (h *nativeHost) KeyAsBool(key uint64, name string) bool {
+	return h.key(key).AsBool(name)
+}
+
+func (h *nativeHost) KeyAsInt32(key uint64, name string) int32 {
+	return h.key(key).AsInt32(name)
+}
+
+func (h *nativeHost) KeyAsInt64(key uint64, name string) int64 {
+	return h.key(key).AsInt64(name)
+}
+
+func (h *nativeHost) KeyAsFloat32(key uint64, name string) float32 {
+	return h.key(key).AsFloat32(name)
+}
+
+func (h *nativeHost) KeyAsFloat64(key uint64, name string) float64 {
+	return h.key(key).AsFloat64(name)
+}
+
+func (h *nativeHost) ValueLength(value uint64) uint32 {
+	return uint32(h.value(value).Length())
+}
+
+func (h *nativeHost) ValueAsString(value uint64, name string) string {
+	return h.value(value).AsString(name)
+}
+
+func (h *nativeHost) ValueAsBytes(value uint64, name string) []byte {
+	return h.value(value).AsBytes(name)
+}
+
+func (h *nativeHost) ValueAsQName(value uint64, name string) ext.QName {
+	return extQName(h.value(value).AsQName(name))
+}
+
+func (h *nativeHost) ValueAsBool(value uint64, name string) bool {
+	return h.value(value).AsBool(name)
+}
+
+func (h *nativeHost) ValueAsInt32(value uint64, name string) int32 {
+	return h.value(value).AsInt32(name)
+}
+
+func (h *nativeHost) ValueAsInt64(value uint64, name string) int64 {
+	return h.value(value).AsInt64(name)
+}
+
+func (h *nativeHost) ValueAsFloat32(value uint64, name string) float32 {
+	return h.value(value).AsFloat32(name)
+}
+
+func (h *nativeHost) ValueAsFloat64(value uint64, name string) float64 {
+	return h.value(value).AsFloat64(name)
+}
+
+func (h *nativeHost) ValueAsValue(value uint64, name string) uint64 {
+	return h.addValue(h.value(value).AsValue(name))
+}
+
+func (h *nativeHost) ValueGetAsString(value uint64, index int) string {
+	return h.value(value).GetAsString(index)
+}
+
+func (h *nativeHost) ValueGetAsBytes(value uint64, index int) []byte {
+	return h.value(value).GetAsBytes(index)
+}
+
+func (h *nativeHost) ValueGetAsQName(value uint64, index int) ext.QName {
+	return extQName(h.value(value).GetAsQName(index))
+}
+
+func (h *nativeHost) ValueGetAsBool(value uint64, index int) bool {
+	return h.value(value).GetAsBool(index)
+}
+
+func (h *nativeHost) ValueGetAsInt32(value uint64, index int) int32 {
+	return h.value(value).GetAsInt32(index)
+}
+
+func (h *nativeHost) ValueGetAsInt64(value uint64, index int) int64 {
+	return h.value(value).GetAsInt64(index)
+}
+
+func (h *nativeHost) ValueGetAsFloat32(value uint64, index int) float32 {
+	return h.value(value).GetAsFloat32(index)
+}
+
+func (h *nativeHost) ValueGetAsFloat64(value uint64, index int) float64 {
+	return h.value(value).GetAsFloat64(index)
+}
+
+func (h *nativeHost) ValueGetAsValue(value uint64, index int) uint64 {
+	return h.addValue(h.value(value).GetAsValue(index))
+}
+
+func (h *nativeHost) keyBuilder(id uint64) *keyBuilder {
+	if id >= uint64(len(h.keyBuilders)) {
+		panic(ErrIncorrectKeyBuilder)
+	}
+	return h.keyBuilders[id]
+}
+
+func (h *nativeHost) key(id uint64) istructs.IKey {
+	if id >= uint64(len(h.keys)) {
+		panic(ErrIncorrectKey)
+	}
+	return h.keys[id]
+}
+
+func (h *nativeHost) value(id uint64) istructs.IStateValue {
+	if id >= uint64(len(h.values)) {
+		panic(ErrIncorrectValue)
+	}
+	return h.values[id]
+}
+
+func (h *nativeHost) addValue(v istructs.IStateValue) uint64 {
+	h.values = append(h.values, v)
+	return uint64(len(h.values) - 1)
+}
+
+func (h *nativeHost) addIntent(kb *keyBuilder, vb istructs.IStateValueBuilder) uint64 {
+	h.intents = append(h.intents, &intent{
+		IStateValueBuilder: vb,
+		Intent: &Intent{
+			Storage: kb.storage,
+			Entity:  kb.entity,
+			Key:     kb.fields,
+			Value:   make(map[string]any),
+		},
+	})
+	return uint64(len(h.intents) - 1)
+}
+
+// rowWriter returns the key builder or the intent and remembers the value put
+func (h *nativeHost) rowWriter(id uint64, isIntent bool, name string, value any) istructs.IRowWriter {
+	if isIntent {
+		if id >= uint64(len(h.intents)) {
+			panic(ErrIncorrectIntent)
+		}
+		i := h.intents[id]
+		i.Value[name] = value
+		return i
+	}
+	kb := h.keyBuilder(id)
+	kb.fields[name] = value
+	return kb
+}
+
+func extQName(qName appdef.QName) ext.QName {
+	return ext.QName{Pkg: qName.Pkg(), Entity: qName.Entity()}
+}
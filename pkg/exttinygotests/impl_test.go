@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package exttinygotests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ext "github.com/voedger/exttinygo"
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+const (
+	testPkgPath = "github.com/untillpro/shop"
+	testPkgDir  = "_testdata"
+)
+
+// makePurchase is the command extension: counts purchases and returns the total of the customer for the day
+func makePurchase() {
+	arg := ext.MustGetArgument()
+	customer, day, amount := arg.AsInt32("Customer"), arg.AsInt32("Day"), arg.AsInt32("Amount")
+
+	kb := ext.KeyBuilder(ext.StorageViewRecords, "shop.DailyTotals")
+	kb.PutInt32("Customer", customer)
+	kb.PutInt32("Day", day)
+	total := amount
+	if exists, value := ext.QueryValue(kb); exists {
+		total += value.AsInt32("Total")
+	}
+
+	stats := ext.KeyBuilder(ext.StorageRecords, ext.NullEntity)
+	stats.PutQName("Singleton", ext.QName{Pkg: "shop", Entity: "ShopStats"})
+	value := ext.MustGetValue(stats)
+	ext.UpdateValue(stats, value).PutInt32("Purchases", value.AsInt32("Purchases")+1)
+
+	ext.NewResult().PutInt32("DayTotal", total)
+}
+
+// customerTotals is the query extension: returns daily totals of the customer
+func customerTotals() {
+	kb := ext.KeyBuilder(ext.StorageViewRecords, "shop.DailyTotals")
+	kb.PutInt32("Customer", ext.MustGetArgument().AsInt32("Customer"))
+	ext.ReadValues(kb, func(key ext.TKey, value ext.TValue) {
+		result := ext.NewResult()
+		result.PutInt32("Day", key.AsInt32("Day"))
+		result.PutInt32("Total", value.AsInt32("Total"))
+	})
+}
+
+func TestBasicUsage_Command(t *testing.T) {
+	require := require.New(t)
+
+	ts := NewTestState(t, ProcessorKind_Command, testPkgPath, testPkgDir, "MakePurchase")
+	ts.PutRecord("ShopStats", map[string]any{"Purchases": 10})
+	ts.PutView("DailyTotals", map[string]any{"Customer": 1, "Day": 20}, map[string]any{"Total": 100})
+
+	ts.PutArgument(map[string]any{"Customer": 1, "Day": 20, "Amount": 42})
+	require.NoError(ts.Run(makePurchase))
+
+	stats := ts.RequireIntent(ext.StorageRecords, ext.NullEntity)
+	require.Equal(map[string]any{"Singleton": appdef.NewQName("shop", "ShopStats")}, stats.Key)
+	require.Equal(map[string]any{"Purchases": int32(11)}, stats.Value)
+	ts.RequireIntent(ext.StorageResult, ext.NullEntity)
+	require.Len(ts.Intents(), 2)
+
+	require.EqualValues(142, ts.CommandResult().AsInt32("DayTotal"))
+
+	t.Run("no view record", func(t *testing.T) {
+		ts.PutArgument(map[string]any{"Customer": 1, "Day": 21, "Amount": 7})
+		require.NoError(ts.Run(makePurchase))
+		require.EqualValues(7, ts.CommandResult().AsInt32("DayTotal"))
+	})
+}
+
+func TestBasicUsage_Query(t *testing.T) {
+	require := require.New(t)
+
+	ts := NewTestState(t, ProcessorKind_Query, testPkgPath, testPkgDir, "CustomerTotals")
+	ts.PutView("DailyTotals", map[string]any{"Customer": 1, "Day": 20}, map[string]any{"Total": 100})
+	ts.PutView("DailyTotals", map[string]any{"Customer": 1, "Day": 21}, map[string]any{"Total": 200})
+	ts.PutView("DailyTotals", map[string]any{"Customer": 2, "Day": 20}, map[string]any{"Total": 300})
+
+	ts.PutArgument(map[string]any{"Customer": 1})
+	require.NoError(ts.Run(customerTotals))
+
+	require.Len(ts.Intents(), 2)
+	result := ts.QueryResult()
+	require.Len(result, 2)
+	require.EqualValues(20, result[0].AsInt32("Day"))
+	require.EqualValues(100, result[0].AsInt32("Total"))
+	require.EqualValues(21, result[1].AsInt32("Day"))
+	require.EqualValues(200, result[1].AsInt32("Total"))
+}
+
+func TestRecords(t *testing.T) {
+	require := require.New(t)
+
+	ts := NewTestState(t, ProcessorKind_Command, testPkgPath, testPkgDir, "MakePurchase")
+	id := ts.PutRecord("Purchase", map[string]any{"Customer": 1, "Day": 20, "Amount": 42})
+	require.EqualValues(42, ts.Record(id).AsInt32("Amount"))
+
+	statsID := ts.PutRecord("ShopStats", map[string]any{"Purchases": 1})
+	ts.PutArgument(map[string]any{"Customer": 1, "Day": 20, "Amount": 7})
+	for i := 0; i < 3; i++ {
+		require.NoError(ts.Run(makePurchase))
+	}
+
+	// changes made by the command are stored
+	require.EqualValues(4, ts.Record(statsID).AsInt32("Purchases"))
+}
+
+func TestErrors(t *testing.T) {
+	require := require.New(t)
+
+	ts := NewTestState(t, ProcessorKind_Command, testPkgPath, testPkgDir, "MakePurchase")
+	ts.PutArgument(map[string]any{"Customer": 1, "Day": 20, "Amount": 42})
+
+	t.Run("extension panics", func(t *testing.T) {
+		err := ts.Run(func() { ext.Assert(false, "test") })
+		require.ErrorContains(err, "assertion failed: test")
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		ts.PutArgument(map[string]any{"Customer": 1})
+		require.Error(ts.Run(makePurchase))
+	})
+
+	t.Run("unknown storage", func(t *testing.T) {
+		ts.PutArgument(map[string]any{"Customer": 1, "Day": 20, "Amount": 42})
+		err := ts.Run(func() { ext.KeyBuilder("sys.Unknown", ext.NullEntity) })
+		require.Error(err)
+	})
+
+	t.Run("invalid intent", func(t *testing.T) {
+		err := ts.Run(func() {
+			purchase := ext.NewValue(ext.KeyBuilder(ext.StorageRecords, "shop.Purchase"))
+			purchase.PutInt32("Customer", 1)
+		})
+		require.Error(err)
+		require.Equal(map[string]any{"Customer": int32(1)}, ts.RequireIntent(ext.StorageRecords, "Purchase").Value)
+	})
+}
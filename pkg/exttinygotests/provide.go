@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package exttinygotests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/parser"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+// NewTestState returns the state to run the extension natively.
+//
+// VSQL of the application package is read from dir, packagePath is the qualified name of the package.
+// Extension is the name of the command or query declared in the package, e.g. "NewOrder".
+//
+// Test fails if the package can not be parsed or the extension is not found.
+func NewTestState(t testing.TB, kind ProcessorKind, packagePath, dir, extension string) *TestState {
+	require := require.New(t)
+
+	pkgAST, err := parser.ParsePackageDir(packagePath, os.DirFS(dir).(parser.IReadFS), ".")
+	require.NoError(err)
+	sysAST, err := parser.ParsePackageDir(appdef.SysPackage, sys.SysFS, ".")
+	require.NoError(err)
+	appSchema, err := parser.BuildAppSchema([]*parser.PackageSchemaAST{pkgAST, sysAST})
+	require.NoError(err)
+	adb := appdef.New()
+	require.NoError(parser.BuildAppDefs(appSchema, adb))
+
+	cfgs := make(istructsmem.AppConfigsType)
+	cfgs.AddConfig(testApp, adb)
+	provider := istructsmem.Provide(cfgs, iratesce.TestBucketsFactory,
+		payloads.ProvideIAppTokensFactory(itokensjwt.TestTokensJWT()), istorageimpl.Provide(mem.Provide()))
+	as, err := provider.AppStructs(testApp)
+	require.NoError(err)
+
+	ts := &TestState{
+		t:          t,
+		kind:       kind,
+		pkgName:    pkgAST.Name,
+		appStructs: as,
+		idGen:      istructsmem.NewIDGenerator(),
+		plogOffset: istructs.FirstOffset,
+		wlogOffset: istructs.FirstOffset,
+		secrets:    make(secretReader),
+	}
+	ts.extension = ts.qname(extension)
+
+	switch kind {
+	case ProcessorKind_Command:
+		require.NotNil(as.AppDef().Command(ts.extension), "command «%v»: %v", ts.extension, ErrExtensionNotFound)
+	case ProcessorKind_Query:
+		require.NotNil(as.AppDef().Query(ts.extension), "query «%v»: %v", ts.extension, ErrExtensionNotFound)
+	default:
+		require.Fail("unknown processor kind", kind)
+	}
+
+	return ts
+}
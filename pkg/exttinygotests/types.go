@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package exttinygotests
+
+import (
+	"testing"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/state"
+)
+
+// ProcessorKind is the kind of processor which state the extension runs against
+type ProcessorKind int
+
+// Intent is the intent made by the extension
+type Intent struct {
+	Storage appdef.QName
+	Entity  appdef.QName
+
+	// Fields put into the key builder of the intent
+	Key map[string]any
+
+	// Fields put into the intent
+	Value map[string]any
+}
+
+// TestState runs exttinygo extensions natively, as plain Go code, against the state
+// of the command or query processor. The state is built from the VSQL of the application package
+// and is backed by the in-memory storage.
+//
+// Use TestState from a single goroutine only, extensions are run one by one.
+type TestState struct {
+	t           testing.TB
+	kind        ProcessorKind
+	pkgName     string
+	appStructs  istructs.IAppStructs
+	extension   appdef.QName
+	idGen       istructs.IIDGenerator
+	plogOffset  istructs.Offset
+	wlogOffset  istructs.Offset
+	secrets     secretReader
+	argument    map[string]any
+	intents     []*Intent
+	cmdResult   istructs.IObject
+	queryResult []istructs.IObject
+}
+
+// nativeHost implements exttinygo INativeHost over the processor state.
+//
+// Keys, values, key builders and intents are referenced by extensions by indexes in the slices,
+// the same way the WASM extension engine does
+type nativeHost struct {
+	state       state.IHostState
+	keyBuilders []*keyBuilder
+	keys        []istructs.IKey
+	values      []istructs.IStateValue
+	intents     []*intent
+}
+
+type keyBuilder struct {
+	istructs.IStateKeyBuilder
+	storage appdef.QName
+	entity  appdef.QName
+	fields  map[string]any
+}
+
+type intent struct {
+	istructs.IStateValueBuilder
+	*Intent
+}
+
+type commandRun struct {
+	reb           istructs.IRawEventBuilder
+	resultBuilder istructs.IObjectBuilder
+	state         state.IHostState
+}
+
+type secretReader map[string][]byte
@@ -28,6 +28,11 @@ var (
 	qNameCmdLinkDeviceToRestaurant                  = appdef.NewQName(airPackage, "LinkDeviceToRestaurant")
 	qNameQryIssuePrincipalToken                     = appdef.NewQName(registryPackage, "IssuePrincipalToken")
 	qNameCmdCreateLogin                             = appdef.NewQName(registryPackage, "CreateLogin")
+	qNameQryIssueExternalIdentityToken              = appdef.NewQName(registryPackage, "IssueExternalIdentityToken")
+	qNameCmdCreateLoginByExternalIdentity           = appdef.NewQName(registryPackage, "CreateLoginByExternalIdentity")
+	qNameCmdLinkExternalIdentity                    = appdef.NewQName(registryPackage, "LinkExternalIdentity")
+	qNameQryIssuePrincipalTokenByExternalIdentity   = appdef.NewQName(registryPackage, "IssuePrincipalTokenByExternalIdentity")
+	qNameCDocExternalIdentity                       = appdef.NewQName(registryPackage, "ExternalIdentity")
 	qNameQryEcho                                    = appdef.NewQName(appdef.SysPackage, "Echo")
 	qNameQryGRCount                                 = appdef.NewQName(appdef.SysPackage, "GRCount")
 	qNameCmdSendEmailVerificationCode               = appdef.NewQName(appdef.SysPackage, "SendEmailVerificationCode")
@@ -181,11 +181,23 @@ func (i *implIAuthenticator) Authenticate(requestContext context.Context, as ist
 }
 
 // principals obtained from IAuhtenticator
+//
+// Denying ACL rules are checked first and can not be overridden by the application.
+// Then application roles decide for QNames they grant privileges on, ACL decides for others
 func (i *implIAuthorizer) Authorize(as istructs.IAppStructs, principals []iauthnz.Principal, req iauthnz.AuthzRequest) (ok bool, err error) {
 	for _, prn := range principals {
 		if prn.Kind == iauthnz.PrincipalKind_Role && prn.QName == iauthnz.QNameRoleSystem {
 			return true, nil
 		}
 	}
-	return i.acl.IsAllowed(principals, req), nil
+	policy, matched := i.acl.policy(principals, req)
+	if matched && policy == ACPolicy_Deny {
+		return false, nil
+	}
+	if as != nil {
+		if granted, governed := isGrantedByAppRoles(as.AppDef(), i.appRolesGoverned(as.AppQName(), as.AppDef()), principals, req); governed {
+			return granted, nil
+		}
+	}
+	return policy == ACPolicy_Allow, nil
 }
@@ -14,13 +14,20 @@ import (
 )
 
 func (acl ACL) IsAllowed(principals []iauthnz.Principal, req iauthnz.AuthzRequest) bool {
-	policy := ACPolicy_Deny
+	policy, _ := acl.policy(principals, req)
+	return policy == ACPolicy_Allow
+}
+
+// Returns policy of the last matching element, matched is false if there are no matching elements and policy is deny by default
+func (acl ACL) policy(principals []iauthnz.Principal, req iauthnz.AuthzRequest) (policy ACPolicyType, matched bool) {
+	policy = ACPolicy_Deny
 	var lastDenyingACElem ACElem
 	for _, acElem := range acl {
 		if matchOrNotSpecified_OpKinds(acElem.pattern.opKindsPattern, req.OperationKind) &&
 			matchOrNotSpecified_QNames(acElem.pattern.qNamesPattern, req.Resource) &&
 			matchOrNotSpecified_Fields(acElem.pattern.fieldsPattern, req.Fields) &&
 			matchOrNotSpecified_Principals(acElem.pattern.principalsPattern, principals) {
+			matched = true
 			if policy = acElem.policy; policy == ACPolicy_Deny {
 				lastDenyingACElem = acElem
 			}
@@ -29,7 +36,7 @@ func (acl ACL) IsAllowed(principals []iauthnz.Principal, req iauthnz.AuthzReques
 	if policy == ACPolicy_Deny && logger.IsVerbose() {
 		logger.Verbose(fmt.Sprintf("%s for %s: %s -> deny", authNZToString(req), prnsToString(principals), lastDenyingACElem.desc))
 	}
-	return policy == ACPolicy_Allow
+	return policy, matched
 }
 
 var defaultACL = ACL{
@@ -40,6 +47,10 @@ var defaultACL = ACL{
 				qNameCmdLinkDeviceToRestaurant,
 				qNameQryIssuePrincipalToken,
 				qNameCmdCreateLogin,
+				qNameQryIssueExternalIdentityToken,
+				qNameCmdCreateLoginByExternalIdentity,
+				qNameCmdLinkExternalIdentity,
+				qNameQryIssuePrincipalTokenByExternalIdentity,
 				qNameQryEcho,
 				qNameQryGRCount,
 				qNameCmdResetPasswordByEmail,
@@ -65,7 +76,7 @@ var defaultACL = ACL{
 				qNameCmdStoreSubscriptionProfile, qNameCmdUpdateSubscription,
 
 				qNameCDocSubscriptionProfile, qNameCDocUnTillOrders, qNameCDocUnTillPBill,
-				qNameTestDeniedCmd, qNameTestDeniedCDoc, qNameCDocLogin, qNameCDocChildWorkspace, qNameTestDeniedQry,
+				qNameTestDeniedCmd, qNameTestDeniedCDoc, qNameCDocLogin, qNameCDocExternalIdentity, qNameCDocChildWorkspace, qNameTestDeniedQry,
 
 				qNameCDocWorkspaceKindUser,
 				qNameCDocWorkspaceKindDevice,
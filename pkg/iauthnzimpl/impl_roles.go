@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package iauthnzimpl
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Returns is operation granted by application roles (see VSQL ROLE and GRANT) to any role principal.
+//
+// Returns governed == false if resource is system or no application role has privileges on it (see governedQNames),
+// in this case application roles do not decide
+func isGrantedByAppRoles(appDef appdef.IAppDef, governedQNames map[appdef.QName]struct{}, principals []iauthnz.Principal, req iauthnz.AuthzRequest) (granted, governed bool) {
+	if appDef == nil || req.Resource.Pkg() == appdef.SysPackage {
+		return false, false
+	}
+	kind, ok := privilegeKind(appDef, req)
+	if !ok {
+		return false, false
+	}
+	if _, ok := governedQNames[req.Resource]; !ok {
+		return false, false
+	}
+	for _, prn := range principals {
+		if prn.Kind != iauthnz.PrincipalKind_Role {
+			continue
+		}
+		role := appDef.Role(prn.QName)
+		if role == nil {
+			continue
+		}
+		if role.IsGranted(kind, req.Resource, req.Fields...) {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// Returns QNames on which any application role has privileges.
+//
+// QNames are computed on the first call for application definition and then taken from cache,
+// cache keeps the last definition of application only, so definitions of redeployed applications are not kept
+func (i *implIAuthorizer) appRolesGoverned(app istructs.AppQName, appDef appdef.IAppDef) map[appdef.QName]struct{} {
+	if appDef == nil {
+		return nil
+	}
+	if g, ok := i.appRoles.Load(app); ok && g.(*appRolesGovernedQNames).appDef == appDef {
+		return g.(*appRolesGovernedQNames).qNames
+	}
+	g := &appRolesGovernedQNames{appDef: appDef, qNames: make(map[appdef.QName]struct{})}
+	appDef.Roles(func(r appdef.IRole) {
+		r.Privileges(func(p appdef.IPrivilege) {
+			for _, n := range p.On() {
+				g.qNames[n] = struct{}{}
+			}
+		})
+	})
+	i.appRoles.Store(app, g)
+	return g.qNames
+}
+
+func privilegeKind(appDef appdef.IAppDef, req iauthnz.AuthzRequest) (appdef.PrivilegeKind, bool) {
+	switch req.OperationKind {
+	case iauthnz.OperationKind_INSERT:
+		return appdef.PrivilegeKind_Insert, true
+	case iauthnz.OperationKind_UPDATE:
+		return appdef.PrivilegeKind_Update, true
+	case iauthnz.OperationKind_EXECUTE:
+		return appdef.PrivilegeKind_Execute, true
+	case iauthnz.OperationKind_SELECT:
+		if appDef.Query(req.Resource) != nil {
+			// query result fields are available to everyone who is granted to execute the query
+			return appdef.PrivilegeKind_Execute, true
+		}
+		return appdef.PrivilegeKind_Select, true
+	}
+	return appdef.PrivilegeKind(0), false
+}
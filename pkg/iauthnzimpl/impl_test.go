@@ -985,6 +985,82 @@ func TestErrors(t *testing.T) {
 
 // with principals cache:  1455242       782.8 ns/op	     432 B/op	       9 allocs/op
 // without principals cache: 45534	     24370 ns/op	    7964 B/op	     126 allocs/op
+func TestAuthorizeByAppRoles(t *testing.T) {
+	require := require.New(t)
+
+	docName := appdef.NewQName("test", "Doc")
+	cmdName := appdef.NewQName("test", "Cmd")
+	queryName := appdef.NewQName("test", "Qry")
+	roleName := appdef.NewQName("test", "Manager")
+
+	freeCmdName := appdef.NewQName("test", "FreeCmd")
+
+	adb := appdef.New()
+	adb.AddCDoc(docName).
+		AddField("Name", appdef.DataKind_string, false).
+		AddField("Price", appdef.DataKind_int32, false)
+	adb.AddCommand(cmdName)
+	adb.AddCommand(freeCmdName)
+	adb.AddQuery(queryName)
+	adb.AddRole(roleName).
+		Grant([]appdef.PrivilegeKind{appdef.PrivilegeKind_Execute}, []appdef.QName{cmdName, queryName}, nil).
+		Grant([]appdef.PrivilegeKind{appdef.PrivilegeKind_Insert, appdef.PrivilegeKind_Update}, []appdef.QName{docName}, []string{"Name"})
+	appDef, err := adb.Build()
+	require.NoError(err)
+
+	appStructs := &implIAppStructs{appDef: appDef}
+	authz := NewDefaultAuthorizer()
+
+	manager := []iauthnz.Principal{{Kind: iauthnz.PrincipalKind_Role, WSID: 1, QName: roleName}}
+	other := []iauthnz.Principal{{Kind: iauthnz.PrincipalKind_Role, WSID: 1, QName: appdef.NewQName("test", "Other")}}
+	owner := []iauthnz.Principal{{Kind: iauthnz.PrincipalKind_Role, WSID: 1, QName: iauthnz.QNameRoleWorkspaceOwner}}
+	deniedManager := append([]iauthnz.Principal{{Kind: iauthnz.PrincipalKind_User, WSID: 1, Name: untillChargebeeAgentLogin}}, manager...)
+
+	tests := []struct {
+		name       string
+		principals []iauthnz.Principal
+		req        iauthnz.AuthzRequest
+		expected   bool
+	}{
+		{"execute command", manager, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_EXECUTE, Resource: cmdName}, true},
+		{"execute query", manager, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_EXECUTE, Resource: queryName}, true},
+		{"select query result", manager, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_SELECT, Resource: queryName, Fields: []string{"fld"}}, true},
+		{"insert granted field", manager, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_INSERT, Resource: docName, Fields: []string{appdef.SystemField_ID, appdef.SystemField_QName, "Name"}}, true},
+		{"insert not granted field", manager, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_INSERT, Resource: docName, Fields: []string{"Name", "Price"}}, false},
+		{"select not granted", manager, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_SELECT, Resource: docName}, false},
+		{"not granted role", other, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_EXECUTE, Resource: cmdName}, false},
+		{"denied by ACL although granted", deniedManager, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_EXECUTE, Resource: cmdName}, false},
+		{"allowed by ACL but not granted", owner, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_EXECUTE, Resource: cmdName}, false},
+		{"allowed by ACL if no grants", owner, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_EXECUTE, Resource: freeCmdName}, true},
+		{"denied by ACL if no grants", manager, iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_EXECUTE, Resource: freeCmdName}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := authz.Authorize(appStructs, tc.principals, tc.req)
+			require.NoError(err)
+			require.Equal(tc.expected, ok)
+		})
+	}
+
+	t.Run("redeployed application is authorized by new roles", func(t *testing.T) {
+		adb := appdef.New()
+		adb.AddCommand(freeCmdName)
+		adb.AddRole(roleName).
+			Grant([]appdef.PrivilegeKind{appdef.PrivilegeKind_Execute}, []appdef.QName{freeCmdName}, nil)
+		newAppDef, err := adb.Build()
+		require.NoError(err)
+		appStructs.appDef = newAppDef
+
+		req := iauthnz.AuthzRequest{OperationKind: iauthnz.OperationKind_EXECUTE, Resource: freeCmdName}
+		ok, err := authz.Authorize(appStructs, manager, req)
+		require.NoError(err)
+		require.True(ok)
+		ok, err = authz.Authorize(appStructs, owner, req)
+		require.NoError(err)
+		require.False(ok)
+	})
+}
+
 func BenchmarkBasic(b *testing.B) {
 	tokens := itokensjwt.ProvideITokens(itokensjwt.SecretKeyExample, time.Now)
 	appTokens := payloads.ProvideIAppTokensFactory(tokens).New(istructs.AppQName_test1_app1)
@@ -1030,21 +1106,27 @@ func AppStructsWithTestStorage(data map[istructs.WSID]map[appdef.QName]map[istru
 type implIAppStructs struct {
 	records *implIRecords
 	views   *implIViewRecords
+	appDef  appdef.IAppDef
 }
 
-func (as *implIAppStructs) AppDef() appdef.IAppDef                             { panic("") }
+func (as *implIAppStructs) AppDef() appdef.IAppDef                             { return as.appDef }
 func (as *implIAppStructs) Events() istructs.IEvents                           { panic("") }
 func (as *implIAppStructs) Records() istructs.IRecords                         { return as.records }
 func (as *implIAppStructs) ViewRecords() istructs.IViewRecords                 { return as.views }
 func (as *implIAppStructs) ObjectBuilder(appdef.QName) istructs.IObjectBuilder { panic("") }
 func (as *implIAppStructs) Resources() istructs.IResources                     { panic("") }
 func (as *implIAppStructs) ClusterAppID() istructs.ClusterAppID                { panic("") }
-func (as *implIAppStructs) AppQName() istructs.AppQName                        { panic("") }
+func (as *implIAppStructs) AppQName() istructs.AppQName                        { return istructs.AppQName_test1_app1 }
 func (as *implIAppStructs) IsFunctionRateLimitsExceeded(appdef.QName, istructs.WSID) bool {
 	panic("")
 }
-func (as *implIAppStructs) DescribePackageNames() []string               { panic("") }
-func (as *implIAppStructs) DescribePackage(string) interface{}           { panic("") }
+func (as *implIAppStructs) IsLimitExceeded(appdef.QName, istructs.LimitScopes) bool {
+	panic("")
+}
+func (as *implIAppStructs) DescribePackageNames() []string { panic("") }
+func (as *implIAppStructs) DescribePackage(string, ...appdef.QName) interface{} {
+	panic("")
+}
 func (as *implIAppStructs) SyncProjectors() []istructs.ProjectorFactory  { panic("") }
 func (as *implIAppStructs) AsyncProjectors() []istructs.ProjectorFactory { panic("") }
 func (as *implIAppStructs) CUDValidators() []istructs.CUDValidator       { panic("") }
@@ -1120,6 +1202,9 @@ func (vr *implIViewRecords) UpdateValueBuilder(view appdef.QName, existing istru
 func (vr *implIViewRecords) Put(workspace istructs.WSID, key istructs.IKeyBuilder, value istructs.IValueBuilder) (err error) {
 	panic("")
 }
+func (vr *implIViewRecords) PutWithTTL(workspace istructs.WSID, key istructs.IKeyBuilder, value istructs.IValueBuilder, ttl time.Duration) (err error) {
+	panic("")
+}
 func (vr *implIViewRecords) PutBatch(workspace istructs.WSID, batch []istructs.ViewKV) (err error) {
 	panic("")
 }
@@ -1154,6 +1239,9 @@ func (vr *implIViewRecords) GetBatch(workspace istructs.WSID, kv []istructs.View
 func (vr *implIViewRecords) Read(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
 	panic("")
 }
+func (vr *implIViewRecords) ReadFrom(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, from istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
+	panic("")
+}
 
 type implIKeyBuilder struct {
 	coreutils.TestObject
@@ -6,6 +6,7 @@ package iauthnzimpl
 
 import (
 	"context"
+	"sync"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/iauthnz"
@@ -14,6 +15,14 @@ import (
 
 type implIAuthorizer struct {
 	acl ACL
+	// QNames governed by application roles by application, see appRolesGoverned()
+	appRoles sync.Map
+}
+
+// QNames on which application roles grant privileges, computed once for application definition
+type appRolesGovernedQNames struct {
+	appDef appdef.IAppDef
+	qNames map[appdef.QName]struct{}
 }
 
 type implIAuthenticator struct {
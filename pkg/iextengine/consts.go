@@ -9,4 +9,22 @@ const ExtEngineKind_BuiltIn = 0
 const ExtEngineKind_WASM = 1
 
 const DefaultMemoryLimitPages = 256
+const DefaultInstancesPerPackage = 1
 const MemoryPageSize = 65536
+
+// Names of limits reported by LimitExceededError
+const (
+	Limit_ExecutionInterval = "ExecutionInterval"
+	Limit_HostCalls         = "MaxHostCalls"
+	Limit_Intents           = "MaxIntents"
+	Limit_MemoryPages       = "MaxMemoryPages"
+)
+
+// Names of extension metrics
+const (
+	Metric_ExtInvocationsTotal    = "voedger_ext_invocations_total"
+	Metric_ExtInvocationsSeconds  = "voedger_ext_invocations_seconds"
+	Metric_ExtHostCallsTotal      = "voedger_ext_host_calls_total"
+	Metric_ExtIntentsTotal        = "voedger_ext_intents_total"
+	Metric_ExtLimitsExceededTotal = "voedger_ext_limits_exceeded_total"
+)
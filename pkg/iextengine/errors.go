@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2024-present unTill Software Development Group B.V.
+ */
+
+package iextengine
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrLimitExceeded = errors.New("extension limit exceeded")
+
+// Returned by IExtensionEngine.Invoke if extension exceeds one of ExtensionLimits.
+//
+// errors.Is(err, ErrLimitExceeded) is true for this error
+type LimitExceededError struct {
+	Extension ExtQName
+	Limit     string // one of Limit_* constants
+	Max       uint64 // milliseconds for Limit_ExecutionInterval
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%v: %s, %s is %d", ErrLimitExceeded, e.Extension, e.Limit, e.Max)
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
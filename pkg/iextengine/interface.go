@@ -12,6 +12,7 @@ import (
 
 	"github.com/voedger/voedger/pkg/appdef"
 	istructs "github.com/voedger/voedger/pkg/istructs"
+	imetrics "github.com/voedger/voedger/pkg/metrics"
 )
 
 type IExtensionsModule interface {
@@ -25,6 +26,21 @@ type ExtensionLimits struct {
 
 	// Default is 0 (execution interval not specified)
 	ExecutionInterval time.Duration
+
+	// Maximum number of host functions calls per invocation.
+	//
+	// Default is 0 (not limited)
+	MaxHostCalls uint
+
+	// Maximum number of intents (new and updated values) per invocation.
+	//
+	// Default is 0 (not limited)
+	MaxIntents uint
+
+	// Maximum number of memory pages the extension module can grow up to.
+	//
+	// Default is 0 (limited by ExtEngineConfig.MemoryLimitPages only)
+	MaxMemoryPages uint
 }
 
 type ExtEngineConfig struct {
@@ -34,6 +50,25 @@ type ExtEngineConfig struct {
 	// Default value is 2^8 so the total available memory is 2^24 bytes
 	MemoryLimitPages uint
 
+	// InstancesPerPackage is the number of warm module instances kept by engine for each package.
+	// Invocations of the same package can run concurrently up to this number.
+	//
+	// Default value is 1
+	InstancesPerPackage uint
+
+	// CompilationCacheDir is the directory where compiled modules are cached between VVM launches.
+	// Cached modules are keyed by wasm hash.
+	//
+	// Default value is empty, compiled modules are cached in memory only
+	CompilationCacheDir string
+
+	// Metrics, if specified, collects invocations statistics per extension
+	Metrics imetrics.IMetrics
+
+	// VvmName and AppQName are used as labels of extension metrics
+	VvmName  string
+	AppQName istructs.AppQName
+
 	//Compile bool
 }
 
@@ -51,6 +86,31 @@ type IExtensionEngine interface {
 	Close(ctx context.Context)
 }
 
+// Extension engine which packages modules can be replaced while engine is running
+type IReloadableExtensionEngine interface {
+	IExtensionEngine
+
+	// Instantiates new modules of specified packages. Packages, which are not specified, are kept as is.
+	//
+	// Engine is not changed until returned reload is committed.
+	// If error is returned, then nothing should be committed or rolled back.
+	//
+	// @ConcurrentAccess
+	PrepareReload(ctx context.Context, packages []ExtensionPackage) (IExtensionEngineReload, error)
+}
+
+// Prepared replacement of extension engine modules. Should be either committed or rolled back
+type IExtensionEngineReload interface {
+	// Replaces modules by new ones, new invocations are routed to new modules.
+	//
+	// Returns function which waits for in-flight invocations of old modules to finish and closes old modules.
+	// Function should be called without locks which in-flight invocations may wait for.
+	Commit() (drain func(context.Context))
+
+	// Closes new modules, engine is kept as is
+	Rollback(ctx context.Context)
+}
+
 type IExtensionEngineFactories map[appdef.ExtensionEngineKind]IExtensionEngineFactory
 
 type ExtQName struct {
@@ -69,6 +129,9 @@ type ExtensionPackage struct {
 	QualifiedName  string
 	ModuleUrl      *url.URL
 	ExtensionNames []string
+
+	// Limits of extensions by extension name. Extensions, which are not specified, are limited by engine limits
+	ExtensionLimits map[string]ExtensionLimits
 }
 
 type IExtensionEngineFactory interface {
@@ -77,3 +140,11 @@ type IExtensionEngineFactory interface {
 	// - config is not used for ExtensionEngineKind_BuiltIn
 	New(ctx context.Context, packages []ExtensionPackage, config *ExtEngineConfig, numEngines int) ([]IExtensionEngine, error)
 }
+
+// Extension engine factory which keeps resources shared by created engines, e.g. compilation cache
+type ICloseableExtensionEngineFactory interface {
+	IExtensionEngineFactory
+
+	// Releases shared resources. Should be called after all engines created by factory are closed
+	Close(ctx context.Context)
+}
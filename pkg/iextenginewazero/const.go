@@ -14,6 +14,9 @@ const valueBuildersCapacity = 10
 const (
 	maxMemoryPages = 0xffff
 
+	// frame of the wasm stack trace of the trap when TinyGo runtime is failed to grow memory for allocation
+	outOfMemoryFrame = ".runtime.alloc("
+
 	WasmPreallocatedBufferIncrease = 1000
 )
 
@@ -10,6 +10,8 @@ import "errors"
 var (
 	ErrUnableToReadMemory = errors.New("unable to read result from WASM module")
 
+	errInstanceMemoryLimit = errors.New("unable to instantiate module within memory limit")
+
 	PanicIncorrectKey        = "incorrect key"
 	PanicIncorrectKeyBuilder = "incorrect key builder"
 	PanicIncorrectValue      = "incorrect value"
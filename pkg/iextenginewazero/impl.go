@@ -11,21 +11,63 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net/url"
 	"os"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tetratelabs/wazero"
 	"github.com/tetratelabs/wazero/api"
 	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/untillpro/goutils/logger"
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
+// Pool of warm instances of package module with the same memory limit
+type wazeroExtPool struct {
+	// memory limit of instances runtime, memory can not be grown over it
+	memPages uint
+	idle     chan *wazeroExtInstance
+
+	mx sync.Mutex
+	// instances of pool, including borrowed ones. Unhealthy instances are dropped from pool
+	instances []*wazeroExtInstance
+	// pool size, including instances which are being created
+	size uint
+}
+
+// Package module with pools of instances
 type wazeroExtPkg struct {
-	module api.Module
-	exts   map[string]api.Function
+	name     string
+	wasmdata []byte
+	extNames []string
+	limits   map[string]iextengine.ExtensionLimits
+
+	// instances with engine memory limit
+	wazeroExtPool
+
+	// instances with memory limits of extensions which are lower than engine one, created on demand
+	poolsMx sync.Mutex
+	pools   map[uint]*wazeroExtPool
+
+	// in-flight invocations, should be finished before package is closed
+	inFlight sync.WaitGroup
+}
+
+// Instance of package module.
+//
+// Each instance has own runtime, host functions are bound to instance
+type wazeroExtInstance struct {
+	engine   *wazeroExtEngine
+	memPages uint // memory limit of runtime
+	module   api.Module
+	exts     map[string]api.Function
+	host     api.Module
+	rtm      wazero.Runtime
 
 	funcMalloc api.Function
 	funcFree   api.Function
@@ -40,19 +82,7 @@ type wazeroExtPkg struct {
 
 	allocatedBufs []*allocatedBuf
 	recoverMem    []byte
-}
-
-type wazeroExtEngine struct {
-	compile bool
-	config  *iextengine.ExtEngineConfig
-	modules map[string]*wazeroExtPkg
-	host    api.Module
-	rtm     wazero.Runtime
-	//recoverMem api.Memory
 
-	//mwasi api.Module
-	// ce    api.ICallEngine
-	// cep   api.CallEngineParams
 	keys          []istructs.IKey
 	keyBuilders   []istructs.IStateKeyBuilder
 	values        []istructs.IStateValue
@@ -60,9 +90,27 @@ type wazeroExtEngine struct {
 	wasiCloser    api.Closer
 
 	// Invoke-related!
-	io  iextengine.IExtensionIO
-	ctx context.Context
-	pkg *wazeroExtPkg
+	io        iextengine.IExtensionIO
+	ctx       context.Context
+	extension iextengine.ExtQName
+	limits    iextengine.ExtensionLimits
+	hostCalls uint
+	intents   uint
+	exceeded  *iextengine.LimitExceededError
+}
+
+// Extension engine. Invocations are concurrency safe, invocations of the same package
+// are limited by InstancesPerPackage config value.
+type wazeroExtEngine struct {
+	compile   bool
+	config    *iextengine.ExtEngineConfig
+	rtConf    wazero.RuntimeConfig
+	instances uint
+	memPages  uint
+
+	mx      sync.RWMutex
+	modules map[string]*wazeroExtPkg
+	limits  iextengine.ExtensionLimits
 }
 
 type allocatedBuf struct {
@@ -73,53 +121,105 @@ type allocatedBuf struct {
 
 type extensionEngineFactory struct {
 	compile bool
+
+	mx sync.Mutex
+	// compilation caches by directory, empty directory is for in-memory cache
+	caches map[string]wazero.CompilationCache
 }
 
-func (f extensionEngineFactory) New(ctx context.Context, packages []iextengine.ExtensionPackage, config *iextengine.ExtEngineConfig, numEngines int) (engines []iextengine.IExtensionEngine, err error) {
+func (f *extensionEngineFactory) New(ctx context.Context, packages []iextengine.ExtensionPackage, config *iextengine.ExtEngineConfig, numEngines int) (engines []iextengine.IExtensionEngine, err error) {
+	cache, err := f.cache(config.CompilationCacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	wasmdata := make(map[string][]byte, len(packages))
+	for _, pkg := range packages {
+		if wasmdata[pkg.QualifiedName], err = readModule(pkg.ModuleUrl); err != nil {
+			return nil, err
+		}
+	}
+
 	for i := 0; i < numEngines; i++ {
 		engine := &wazeroExtEngine{
 			modules: make(map[string]*wazeroExtPkg),
 			config:  config,
 			compile: f.compile,
 		}
-		err = engine.init(ctx)
+		err = engine.init(cache)
 		if err != nil {
 			return engines, err
 		}
+		for _, pkg := range packages {
+			ePkg, err := engine.newPkg(ctx, pkg, wasmdata[pkg.QualifiedName])
+			if err != nil {
+				engine.Close(ctx)
+				return nil, err
+			}
+			engine.modules[pkg.QualifiedName] = ePkg
+		}
 		engines = append(engines, engine)
 	}
 
-	for _, pkg := range packages {
-		if pkg.ModuleUrl.Scheme == "file" && (pkg.ModuleUrl.Host == "" || strings.EqualFold("localhost", pkg.ModuleUrl.Scheme)) {
-			path := pkg.ModuleUrl.Path
-			if runtime.GOOS == "windows" {
-				path = strings.TrimPrefix(path, "/")
-			}
+	return engines, nil
+}
 
-			wasmdata, err := os.ReadFile(path)
+// Returns compilation cache for specified directory. Cache is shared by all engines created by factory,
+// so the same wasm is compiled once.
+func (f *extensionEngineFactory) cache(dir string) (wazero.CompilationCache, error) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
 
-			if err != nil {
-				return nil, err
-			}
+	if c, ok := f.caches[dir]; ok {
+		return c, nil
+	}
 
-			for _, eng := range engines {
-				err = eng.(*wazeroExtEngine).initModule(ctx, pkg.QualifiedName, wasmdata, pkg.ExtensionNames)
-				if err != nil {
-					return nil, err
-				}
-			}
-		} else {
-			return nil, fmt.Errorf("unsupported URL: " + pkg.ModuleUrl.String())
+	var c wazero.CompilationCache
+	if dir == "" {
+		c = wazero.NewCompilationCache()
+	} else {
+		var err error
+		if c, err = wazero.NewCompilationCacheWithDir(dir); err != nil {
+			return nil, err
 		}
 	}
-	return engines, nil
+	f.caches[dir] = c
+	return c, nil
+}
+
+// Closes compilation caches
+func (f *extensionEngineFactory) Close(ctx context.Context) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	for dir, c := range f.caches {
+		if err := c.Close(ctx); err != nil {
+			logger.Error(fmt.Sprintf("unable to close compilation cache %q: %v", dir, err))
+		}
+		delete(f.caches, dir)
+	}
+}
+
+// Reads wasm module data from specified URL
+func readModule(moduleUrl *url.URL) ([]byte, error) {
+	if moduleUrl.Scheme == "file" && (moduleUrl.Host == "" || strings.EqualFold("localhost", moduleUrl.Host)) {
+		path := moduleUrl.Path
+		if runtime.GOOS == "windows" {
+			path = strings.TrimPrefix(path, "/")
+		}
+		return os.ReadFile(path)
+	}
+	return nil, fmt.Errorf("unsupported URL: " + moduleUrl.String())
 }
 
+// Sets limits for extensions which have no own limits in package
 func (f *wazeroExtEngine) SetLimits(limits iextengine.ExtensionLimits) {
-	//f.cep.Duration = limits.ExecutionInterval
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	f.limits = limits
 }
 
-func (f *wazeroExtPkg) importFuncs(funcs map[string]*api.Function) error {
+func (f *wazeroExtInstance) importFuncs(funcs map[string]*api.Function) error {
 
 	for k, v := range funcs {
 		*v = f.module.ExportedFunction(k)
@@ -130,8 +230,7 @@ func (f *wazeroExtPkg) importFuncs(funcs map[string]*api.Function) error {
 	return nil
 }
 
-func (f *wazeroExtEngine) init(ctx context.Context) error {
-	var err error
+func (f *wazeroExtEngine) init(cache wazero.CompilationCache) error {
 	var memPages = f.config.MemoryLimitPages
 	if memPages == 0 {
 		memPages = iextengine.DefaultMemoryLimitPages
@@ -147,20 +246,67 @@ func (f *wazeroExtEngine) init(ctx context.Context) error {
 		return fmt.Errorf("the minimum limit of memory is: %.1f bytes, requested limit is: %.1f", limit, float32(memoryLimit))
 	}
 
-	var rtConf wazero.RuntimeConfig
+	f.memPages = memPages
+
+	f.instances = f.config.InstancesPerPackage
+	if f.instances == 0 {
+		f.instances = iextengine.DefaultInstancesPerPackage
+	}
 
 	if f.compile {
-		rtConf = wazero.NewRuntimeConfigCompiler()
+		f.rtConf = wazero.NewRuntimeConfigCompiler()
 	} else {
-		rtConf = wazero.NewRuntimeConfigInterpreter()
+		f.rtConf = wazero.NewRuntimeConfigInterpreter()
 	}
-	rtConf = rtConf.
+	f.rtConf = f.rtConf.
 		WithCoreFeatures(api.CoreFeatureBulkMemoryOperations).
 		WithCloseOnContextDone(true).
 		WithMemoryCapacityFromMax(true).
-		WithMemoryLimitPages(uint32(memPages))
+		WithCompilationCache(cache)
+
+	return nil
+}
+
+// Creates package with warm instances of module
+func (f *wazeroExtEngine) newPkg(ctx context.Context, pkg iextengine.ExtensionPackage, wasmdata []byte) (ePkg *wazeroExtPkg, err error) {
+	ePkg = &wazeroExtPkg{
+		name:          pkg.QualifiedName,
+		wasmdata:      wasmdata,
+		extNames:      pkg.ExtensionNames,
+		limits:        pkg.ExtensionLimits,
+		wazeroExtPool: wazeroExtPool{memPages: f.memPages, idle: make(chan *wazeroExtInstance, f.instances)},
+		pools:         make(map[uint]*wazeroExtPool),
+	}
+	for i := uint(0); i < f.instances; i++ {
+		inst, err := f.newInstance(ctx, wasmdata, ePkg.extNames, f.memPages)
+		if err != nil {
+			ePkg.close(ctx)
+			return nil, err
+		}
+		ePkg.instances = append(ePkg.instances, inst)
+		ePkg.size++
+		ePkg.idle <- inst
+	}
+	return ePkg, nil
+}
 
-	f.rtm = wazero.NewRuntimeWithConfig(ctx, rtConf)
+func (f *wazeroExtEngine) newInstance(ctx context.Context, wasmdata []byte, extNames []string, memPages uint) (inst *wazeroExtInstance, err error) {
+	inst = &wazeroExtInstance{engine: f, memPages: memPages}
+	if err = inst.init(ctx); err != nil {
+		inst.close(ctx)
+		return nil, err
+	}
+	if err = inst.initModule(ctx, wasmdata, extNames); err != nil {
+		inst.close(ctx)
+		return nil, err
+	}
+	return inst, nil
+}
+
+func (f *wazeroExtInstance) init(ctx context.Context) error {
+	var err error
+
+	f.rtm = wazero.NewRuntimeWithConfig(ctx, f.engine.rtConf.WithMemoryLimitPages(uint32(f.memPages)))
 	f.wasiCloser, err = wasi_snapshot_preview1.Instantiate(ctx, f.rtm)
 
 	if err != nil {
@@ -232,73 +378,64 @@ func (f *wazeroExtEngine) init(ctx context.Context) error {
 
 }
 
-func (f *wazeroExtEngine) initModule(ctx context.Context, pkgName string, wasmdata []byte, extNames []string) (err error) {
-	ePkg := &wazeroExtPkg{}
-
+func (f *wazeroExtInstance) initModule(ctx context.Context, wasmdata []byte, extNames []string) (err error) {
 	moduleCfg := wazero.NewModuleConfig().WithName("wasm").WithStdout(io.Discard).WithStderr(io.Discard)
-	if f.compile {
-		compiledWasm, err := f.rtm.CompileModule(ctx, wasmdata)
-		if err != nil {
-			return err
-		}
+	compiledWasm, err := f.rtm.CompileModule(ctx, wasmdata)
+	if err != nil {
+		return err
+	}
 
-		ePkg.module, err = f.rtm.InstantiateModule(ctx, compiledWasm, moduleCfg)
-		if err != nil {
-			return err
-		}
-	} else {
-		ePkg.module, err = f.rtm.InstantiateWithConfig(ctx, wasmdata, moduleCfg)
-		if err != nil {
-			return err
-		}
+	f.module, err = f.rtm.InstantiateModule(ctx, compiledWasm, moduleCfg)
+	if err != nil {
+		return err
 	}
 
-	err = ePkg.importFuncs(map[string]*api.Function{
-		"malloc":               &ePkg.funcMalloc,
-		"free":                 &ePkg.funcFree,
-		"WasmAbiVersion_0_0_1": &ePkg.funcVer,
-		"WasmGetHeapInuse":     &ePkg.funcGetHeapInuse,
-		"WasmGetHeapSys":       &ePkg.funcGetHeapSys,
-		"WasmGetMallocs":       &ePkg.funcGetMallocs,
-		"WasmGetFrees":         &ePkg.funcGetFrees,
-		"WasmGC":               &ePkg.funcGc,
-		"WasmOnReadValue":      &ePkg.funcOnReadValue,
+	err = f.importFuncs(map[string]*api.Function{
+		"malloc":               &f.funcMalloc,
+		"free":                 &f.funcFree,
+		"WasmAbiVersion_0_0_1": &f.funcVer,
+		"WasmGetHeapInuse":     &f.funcGetHeapInuse,
+		"WasmGetHeapSys":       &f.funcGetHeapSys,
+		"WasmGetMallocs":       &f.funcGetMallocs,
+		"WasmGetFrees":         &f.funcGetFrees,
+		"WasmGC":               &f.funcGc,
+		"WasmOnReadValue":      &f.funcOnReadValue,
 	})
 	if err != nil {
 		return err
 	}
 
 	// Check WASM SDK version
-	_, err = ePkg.funcVer.Call(ctx)
+	_, err = f.funcVer.Call(ctx)
 	if err != nil {
 		return errors.New("unsupported WASM version")
 	}
-	res, err := ePkg.funcMalloc.Call(ctx, uint64(WasmPreallocatedBufferSize))
+	res, err := f.funcMalloc.Call(ctx, uint64(WasmPreallocatedBufferSize))
 	if err != nil {
 		return err
 	}
-	ePkg.allocatedBufs = append(ePkg.allocatedBufs, &allocatedBuf{
+	f.allocatedBufs = append(f.allocatedBufs, &allocatedBuf{
 		addr: uint32(res[0]),
 		offs: 0,
 		cap:  WasmPreallocatedBufferSize,
 	})
 
-	backup, read := ePkg.module.Memory().Read(0, ePkg.module.Memory().Size())
+	backup, read := f.module.Memory().Read(0, f.module.Memory().Size())
 	if !read {
 		return fmt.Errorf("unable to backup memory")
 	}
 
-	ePkg.recoverMem = make([]byte, ePkg.module.Memory().Size())
-	copy(ePkg.recoverMem[0:], backup[0:])
+	f.recoverMem = make([]byte, f.module.Memory().Size())
+	copy(f.recoverMem[0:], backup[0:])
 
-	ePkg.exts = make(map[string]api.Function)
+	f.exts = make(map[string]api.Function)
 
 	for _, name := range extNames {
 		if !strings.HasPrefix(name, "Wasm") && name != "alloc" && name != "free" &&
 			name != "calloc" && name != "realloc" && name != "malloc" && name != "_start" && name != "memory" {
-			expFunc := ePkg.module.ExportedFunction(name)
+			expFunc := f.module.ExportedFunction(name)
 			if expFunc != nil {
-				ePkg.exts[name] = expFunc
+				f.exts[name] = expFunc
 			} else {
 				return missingExportedFunction(name)
 			}
@@ -307,15 +444,12 @@ func (f *wazeroExtEngine) initModule(ctx context.Context, pkgName string, wasmda
 		}
 	}
 
-	f.modules[pkgName] = ePkg
 	return nil
 }
 
-func (f *wazeroExtEngine) Close(ctx context.Context) {
-	for _, m := range f.modules {
-		if m.module != nil {
-			m.module.Close(ctx)
-		}
+func (f *wazeroExtInstance) close(ctx context.Context) {
+	if f.module != nil {
+		f.module.Close(ctx)
 	}
 	if f.host != nil {
 		f.host.Close(ctx)
@@ -323,29 +457,294 @@ func (f *wazeroExtEngine) Close(ctx context.Context) {
 	if f.wasiCloser != nil {
 		f.wasiCloser.Close(ctx)
 	}
+	if f.rtm != nil {
+		f.rtm.Close(ctx)
+	}
 }
 
-func (f *wazeroExtEngine) recover() {
-	if !f.pkg.module.Memory().Write(0, f.pkg.recoverMem) {
-		panic("unable to restore memory")
+func (f *wazeroExtPkg) close(ctx context.Context) {
+	f.wazeroExtPool.close(ctx)
+
+	f.poolsMx.Lock()
+	defer f.poolsMx.Unlock()
+	for _, p := range f.pools {
+		p.close(ctx)
 	}
 }
 
-func (f *wazeroExtEngine) Invoke(ctx context.Context, extension iextengine.ExtQName, io iextengine.IExtensionIO) (err error) {
+func (f *wazeroExtPool) close(ctx context.Context) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
 
-	var ok bool
-	f.pkg, ok = f.modules[extension.PackageName]
+	for _, inst := range f.instances {
+		inst.close(ctx)
+	}
+	f.instances = nil
+	f.size = 0
+}
+
+// Returns pool of instances which memory can not be grown over specified limit.
+// Pool with lower limit than engine one is created empty, instances are created by invocations
+func (f *wazeroExtPkg) pool(memPages uint, instances uint) *wazeroExtPool {
+	if memPages == 0 || memPages >= f.memPages {
+		return &f.wazeroExtPool
+	}
+
+	f.poolsMx.Lock()
+	defer f.poolsMx.Unlock()
+	p, ok := f.pools[memPages]
+	if !ok {
+		p = &wazeroExtPool{memPages: memPages, idle: make(chan *wazeroExtInstance, instances)}
+		f.pools[memPages] = p
+	}
+	return p
+}
+
+func (f *wazeroExtEngine) Close(ctx context.Context) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	for _, m := range f.modules {
+		m.inFlight.Wait()
+		m.close(ctx)
+	}
+	f.modules = make(map[string]*wazeroExtPkg)
+}
+
+func (f *wazeroExtEngine) PrepareReload(ctx context.Context, packages []iextengine.ExtensionPackage) (iextengine.IExtensionEngineReload, error) {
+	r := &wazeroExtReload{engine: f, pkgs: make([]*wazeroExtPkg, 0, len(packages))}
+	for _, pkg := range packages {
+		wasmdata, err := readModule(pkg.ModuleUrl)
+		if err == nil {
+			var ePkg *wazeroExtPkg
+			if ePkg, err = f.newPkg(ctx, pkg, wasmdata); err == nil {
+				r.pkgs = append(r.pkgs, ePkg)
+				continue
+			}
+		}
+		r.Rollback(ctx)
+		return nil, fmt.Errorf("unable to reload package %s: %w", pkg.QualifiedName, err)
+	}
+	return r, nil
+}
+
+// Prepared packages to replace engine packages
+type wazeroExtReload struct {
+	engine *wazeroExtEngine
+	pkgs   []*wazeroExtPkg
+}
+
+func (r *wazeroExtReload) Commit() (drain func(context.Context)) {
+	oldPkgs := make([]*wazeroExtPkg, 0, len(r.pkgs))
+	r.engine.mx.Lock()
+	for _, p := range r.pkgs {
+		if old, ok := r.engine.modules[p.name]; ok {
+			oldPkgs = append(oldPkgs, old)
+		}
+		r.engine.modules[p.name] = p
+	}
+	r.engine.mx.Unlock()
+	r.pkgs = nil
+
+	// new invocations are routed to new packages, drain in-flight invocations of old ones
+	return func(ctx context.Context) {
+		for _, p := range oldPkgs {
+			p.inFlight.Wait()
+			p.close(ctx)
+		}
+	}
+}
+
+func (r *wazeroExtReload) Rollback(ctx context.Context) {
+	for _, p := range r.pkgs {
+		p.close(ctx)
+	}
+	r.pkgs = nil
+}
+
+// Restores module memory after failed invocation. If memory can not be restored, then module is closed to drop instance from pool
+func (f *wazeroExtInstance) recover() {
+	if !f.module.Memory().Write(0, f.recoverMem) {
+		f.module.Close(context.Background())
+	}
+}
+
+// Returns package by name and limits of specified extension. Package in-flight counter is incremented,
+// caller should decrement it when invocation is finished
+func (f *wazeroExtEngine) borrowPkg(extension iextengine.ExtQName) (*wazeroExtPkg, iextengine.ExtensionLimits, error) {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	pkg, ok := f.modules[extension.PackageName]
 	if !ok {
-		return undefinedPackage(extension.PackageName)
+		return nil, iextengine.ExtensionLimits{}, undefinedPackage(extension.PackageName)
+	}
+	pkg.inFlight.Add(1)
+	return pkg, pkg.extLimits(extension.ExtName, f.limits), nil
+}
+
+// Returns limits of extension. Limits, which are not specified for extension, are taken from engine limits
+func (f *wazeroExtPkg) extLimits(extName string, def iextengine.ExtensionLimits) iextengine.ExtensionLimits {
+	l, ok := f.limits[extName]
+	if !ok {
+		return def
+	}
+	if l.ExecutionInterval == 0 {
+		l.ExecutionInterval = def.ExecutionInterval
+	}
+	if l.MaxHostCalls == 0 {
+		l.MaxHostCalls = def.MaxHostCalls
+	}
+	if l.MaxIntents == 0 {
+		l.MaxIntents = def.MaxIntents
+	}
+	if l.MaxMemoryPages == 0 {
+		l.MaxMemoryPages = def.MaxMemoryPages
+	}
+	return l
+}
+
+func (f *wazeroExtEngine) Invoke(ctx context.Context, extension iextengine.ExtQName, io iextengine.IExtensionIO) (err error) {
+	pkg, limits, err := f.borrowPkg(extension)
+	if err != nil {
+		return err
+	}
+	defer pkg.inFlight.Done()
+
+	pool := pkg.pool(limits.MaxMemoryPages, f.instances)
+	inst, err := f.borrowInstance(ctx, pkg, pool)
+	if err != nil {
+		if errors.Is(err, errInstanceMemoryLimit) {
+			// module does not fit into memory limit of extension
+			err = &iextengine.LimitExceededError{Extension: extension, Limit: iextengine.Limit_MemoryPages, Max: uint64(limits.MaxMemoryPages)}
+			f.collectMetrics(extension, nil, 0, err)
+		}
+		return err
+	}
+	defer pool.release(inst)
+
+	start := time.Now()
+	err = inst.invoke(ctx, extension, limits, io)
+	f.collectMetrics(extension, inst, time.Since(start), err)
+
+	return err
+}
+
+// Returns idle instance of package from pool.
+//
+// If pool size is less than configured (unhealthy instances were dropped or pool is created on demand), then new instance is created instead of waiting for idle one
+func (f *wazeroExtEngine) borrowInstance(ctx context.Context, pkg *wazeroExtPkg, pool *wazeroExtPool) (*wazeroExtInstance, error) {
+	select {
+	case inst := <-pool.idle:
+		return inst, nil
+	default:
+	}
+
+	if pool.grow(f.instances) {
+		inst, err := f.newInstance(ctx, pkg.wasmdata, pkg.extNames, pool.memPages)
+		if err != nil {
+			pool.shrink(nil)
+			if pool.memPages < f.memPages {
+				// the same module is instantiated with engine memory limit, so memory limit of pool is too low
+				return nil, fmt.Errorf("%w: %w", errInstanceMemoryLimit, err)
+			}
+			return nil, err
+		}
+		pool.add(inst)
+		return inst, nil
+	}
+
+	select {
+	case inst := <-pool.idle:
+		return inst, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Collects invocation statistics, if metrics are specified in engine config. Instance is nil if invocation is failed before it is borrowed
+func (f *wazeroExtEngine) collectMetrics(extension iextengine.ExtQName, inst *wazeroExtInstance, duration time.Duration, err error) {
+	metrics := f.config.Metrics
+	if metrics == nil {
+		return
+	}
+	vvm, app, ext := f.config.VvmName, f.config.AppQName, appdef.NewQName(extension.PackageName, extension.ExtName)
+	metrics.IncreaseExt(iextengine.Metric_ExtInvocationsTotal, vvm, app, ext, 1)
+	metrics.IncreaseExt(iextengine.Metric_ExtInvocationsSeconds, vvm, app, ext, duration.Seconds())
+	if inst != nil {
+		metrics.IncreaseExt(iextengine.Metric_ExtHostCallsTotal, vvm, app, ext, float64(inst.hostCalls))
+		metrics.IncreaseExt(iextengine.Metric_ExtIntentsTotal, vvm, app, ext, float64(inst.intents))
+	}
+	if errors.Is(err, iextengine.ErrLimitExceeded) {
+		metrics.IncreaseExt(iextengine.Metric_ExtLimitsExceededTotal, vvm, app, ext, 1)
+	}
+}
+
+// Returns healthy instance to pool. Instance, which module is closed (by wazero if context is done or by engine if memory limit exceeded),
+// is dropped from pool and pool size is decremented
+func (f *wazeroExtPool) release(inst *wazeroExtInstance) {
+	if !inst.module.IsClosed() {
+		f.idle <- inst
+		return
+	}
+	f.shrink(inst)
+	inst.close(context.Background())
+}
+
+// Increments pool size if it is less than specified limit. Returns true if incremented
+func (f *wazeroExtPool) grow(limit uint) bool {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if f.size >= limit {
+		return false
+	}
+	f.size++
+	return true
+}
+
+// Adds created instance to pool. Pool size should be already incremented by grow
+func (f *wazeroExtPool) add(inst *wazeroExtInstance) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	f.instances = append(f.instances, inst)
+}
+
+// Removes instance, if specified, from pool and decrements pool size
+func (f *wazeroExtPool) shrink(inst *wazeroExtInstance) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	for i, ii := range f.instances {
+		if ii == inst {
+			f.instances = append(f.instances[:i], f.instances[i+1:]...)
+			break
+		}
 	}
+	f.size--
+}
 
-	funct := f.pkg.exts[extension.ExtName]
+func (f *wazeroExtInstance) invoke(ctx context.Context, extension iextengine.ExtQName, limits iextengine.ExtensionLimits, io iextengine.IExtensionIO) (err error) {
+	funct := f.exts[extension.ExtName]
 	if funct == nil {
 		return invalidExtensionName(extension.ExtName)
 	}
 
+	callCtx := ctx
+	if limits.ExecutionInterval > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, limits.ExecutionInterval)
+		defer cancel()
+	}
+
 	f.io = io
-	f.ctx = ctx
+	f.ctx = callCtx
+	f.extension = extension
+	f.limits = limits
+	f.hostCalls = 0
+	f.intents = 0
+	f.exceeded = nil
 
 	if len(f.keys) > 0 {
 		f.keys = make([]istructs.IKey, 0, keysCapacity)
@@ -359,28 +758,69 @@ func (f *wazeroExtEngine) Invoke(ctx context.Context, extension iextengine.ExtQN
 	if len(f.valueBuilders) > 0 {
 		f.valueBuilders = make([]istructs.IStateValueBuilder, 0, valueBuildersCapacity)
 	}
-	for i := range f.pkg.allocatedBufs {
-		f.pkg.allocatedBufs[i].offs = 0 // reuse pre-allocated memory
+	for i := range f.allocatedBufs {
+		f.allocatedBufs[i].offs = 0 // reuse pre-allocated memory
 	}
 
-	_, err = funct.Call(ctx)
+	_, err = funct.Call(callCtx)
 
 	if err != nil {
+		if f.exceeded != nil {
+			err = f.exceeded
+		} else if errors.Is(callCtx.Err(), context.DeadlineExceeded) && (ctx.Err() == nil) {
+			err = f.limitExceeded(iextengine.Limit_ExecutionInterval, uint64(limits.ExecutionInterval.Milliseconds()))
+		} else if f.memoryExhausted(err) {
+			err = f.limitExceeded(iextengine.Limit_MemoryPages, uint64(limits.MaxMemoryPages))
+		}
+	}
+
+	if (f.exceeded != nil) && (f.exceeded.Limit == iextengine.Limit_MemoryPages) {
+		// grown memory can not be shrunk, so module is closed to be replaced by new instance
+		f.module.Close(context.Background())
+	}
+
+	if err != nil && !f.module.IsClosed() {
 		f.recover()
 	}
 
 	return err
 }
 
-func (f *wazeroExtEngine) decodeStr(ptr, size uint32) string {
-	if bytes, ok := f.pkg.module.Memory().Read(uint32(ptr), uint32(size)); ok {
+func (f *wazeroExtInstance) limitExceeded(limit string, max uint64) *iextengine.LimitExceededError {
+	f.exceeded = &iextengine.LimitExceededError{Extension: f.extension, Limit: limit, Max: max}
+	return f.exceeded
+}
+
+// Returns true if memory of instance is limited by extension limit and the invocation is trapped since memory can not be grown for allocation
+func (f *wazeroExtInstance) memoryExhausted(err error) bool {
+	return (f.memPages < f.engine.memPages) && strings.Contains(err.Error(), outOfMemoryFrame)
+}
+
+// Should be called at the beginning of each host function. Panics if invocation limits are exceeded
+func (f *wazeroExtInstance) hostCall() {
+	if (f.limits.MaxHostCalls > 0) && (f.hostCalls >= f.limits.MaxHostCalls) {
+		panic(f.limitExceeded(iextengine.Limit_HostCalls, uint64(f.limits.MaxHostCalls)))
+	}
+	f.hostCalls++
+}
+
+// Should be called by host functions which create intents. Panics if intents limit is exceeded
+func (f *wazeroExtInstance) intent() {
+	if (f.limits.MaxIntents > 0) && (f.intents >= f.limits.MaxIntents) {
+		panic(f.limitExceeded(iextengine.Limit_Intents, uint64(f.limits.MaxIntents)))
+	}
+	f.intents++
+}
+
+func (f *wazeroExtInstance) decodeStr(ptr, size uint32) string {
+	if bytes, ok := f.module.Memory().Read(uint32(ptr), uint32(size)); ok {
 		return string(bytes)
 	}
 	panic(ErrUnableToReadMemory)
 }
 
-func (f *wazeroExtEngine) hostGetKey(storagePtr, storageSize, entityPtr, entitySize uint32) (res uint64) {
-
+func (f *wazeroExtInstance) hostGetKey(storagePtr, storageSize, entityPtr, entitySize uint32) (res uint64) {
+	f.hostCall()
 	var storage appdef.QName
 	var entity appdef.QName
 	var err error
@@ -404,11 +844,12 @@ func (f *wazeroExtEngine) hostGetKey(storagePtr, storageSize, entityPtr, entityS
 	return
 }
 
-func (f *wazeroExtEngine) hostPanic(namePtr, nameSize uint32) {
+func (f *wazeroExtInstance) hostPanic(namePtr, nameSize uint32) {
 	panic(f.decodeStr(namePtr, nameSize))
 }
 
-func (f *wazeroExtEngine) hostReadValues(keyId uint64) {
+func (f *wazeroExtInstance) hostReadValues(keyId uint64) {
+	f.hostCall()
 	if int(keyId) >= len(f.keyBuilders) {
 		panic(PanicIncorrectKeyBuilder)
 	}
@@ -424,7 +865,7 @@ func (f *wazeroExtEngine) hostReadValues(keyId uint64) {
 			f.keys[keyIndex] = key
 			f.values[valueIndex] = value
 		}
-		_, err = f.pkg.funcOnReadValue.Call(f.ctx, uint64(keyIndex), uint64(valueIndex))
+		_, err = f.funcOnReadValue.Call(f.ctx, uint64(keyIndex), uint64(valueIndex))
 		return err
 	})
 	if err != nil {
@@ -432,8 +873,8 @@ func (f *wazeroExtEngine) hostReadValues(keyId uint64) {
 	}
 }
 
-func (f *wazeroExtEngine) hostMustExist(keyId uint64) (result uint64) {
-
+func (f *wazeroExtInstance) hostMustExist(keyId uint64) (result uint64) {
+	f.hostCall()
 	if int(keyId) >= len(f.keyBuilders) {
 		panic(PanicIncorrectKeyBuilder)
 	}
@@ -448,7 +889,8 @@ func (f *wazeroExtEngine) hostMustExist(keyId uint64) (result uint64) {
 
 const maxUint64 = ^uint64(0)
 
-func (f *wazeroExtEngine) hostCanExist(keyId uint64) (result uint64) {
+func (f *wazeroExtInstance) hostCanExist(keyId uint64) (result uint64) {
+	f.hostCall()
 	if int(keyId) >= len(f.keyBuilders) {
 		panic(PanicIncorrectKeyBuilder)
 	}
@@ -464,59 +906,64 @@ func (f *wazeroExtEngine) hostCanExist(keyId uint64) (result uint64) {
 	return
 }
 
-func (f *wazeroExtEngine) allocAndSend(buf []byte) (result uint64) {
+func (f *wazeroExtInstance) allocAndSend(buf []byte) (result uint64) {
 	addrPkg, e := f.allocBuf(uint32(len(buf)))
 	if e != nil {
 		panic(e)
 	}
-	if !f.pkg.module.Memory().Write(addrPkg, buf) {
+	if !f.module.Memory().Write(addrPkg, buf) {
 		panic(e)
 	}
 	return (uint64(addrPkg) << uint64(bitsInFourBytes)) | uint64(len(buf))
 }
 
-func (f *wazeroExtEngine) keyargs(id uint64, namePtr uint32, nameSize uint32) (istructs.IKey, string) {
+func (f *wazeroExtInstance) keyargs(id uint64, namePtr uint32, nameSize uint32) (istructs.IKey, string) {
 	if int(id) >= len(f.keys) {
 		panic(PanicIncorrectKey)
 	}
 	return f.keys[id], f.decodeStr(namePtr, nameSize)
 }
 
-func (f *wazeroExtEngine) valueargs(id uint64, namePtr uint32, nameSize uint32) (istructs.IStateValue, string) {
+func (f *wazeroExtInstance) valueargs(id uint64, namePtr uint32, nameSize uint32) (istructs.IStateValue, string) {
 	if int(id) >= len(f.values) {
 		panic(PanicIncorrectValue)
 	}
 	return f.values[id], f.decodeStr(namePtr, nameSize)
 }
 
-func (f *wazeroExtEngine) value(id uint64) istructs.IStateValue {
+func (f *wazeroExtInstance) value(id uint64) istructs.IStateValue {
 	if int(id) >= len(f.values) {
 		panic(PanicIncorrectValue)
 	}
 	return f.values[id]
 }
 
-func (f *wazeroExtEngine) hostKeyAsString(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostKeyAsString(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	key, name := f.keyargs(id, namePtr, nameSize)
 	return f.allocAndSend([]byte(key.AsString(name)))
 }
 
-func (f *wazeroExtEngine) hostKeyAsBytes(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostKeyAsBytes(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	key, name := f.keyargs(id, namePtr, nameSize)
 	return f.allocAndSend(key.AsBytes(name))
 }
 
-func (f *wazeroExtEngine) hostKeyAsInt32(id uint64, namePtr uint32, nameSize uint32) (result uint32) {
+func (f *wazeroExtInstance) hostKeyAsInt32(id uint64, namePtr uint32, nameSize uint32) (result uint32) {
+	f.hostCall()
 	key, name := f.keyargs(id, namePtr, nameSize)
 	return uint32(key.AsInt32(name))
 }
 
-func (f *wazeroExtEngine) hostKeyAsInt64(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostKeyAsInt64(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	key, name := f.keyargs(id, namePtr, nameSize)
 	return uint64(key.AsInt64(name))
 }
 
-func (f *wazeroExtEngine) hostKeyAsBool(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostKeyAsBool(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	key, name := f.keyargs(id, namePtr, nameSize)
 	if key.AsBool(name) {
 		return uint64(1)
@@ -524,51 +971,60 @@ func (f *wazeroExtEngine) hostKeyAsBool(id uint64, namePtr uint32, nameSize uint
 	return uint64(0)
 }
 
-func (f *wazeroExtEngine) hostKeyAsQNamePkg(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostKeyAsQNamePkg(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	key, name := f.keyargs(id, namePtr, nameSize)
 	qname := key.AsQName(name)
 	return f.allocAndSend([]byte(qname.Pkg()))
 }
 
-func (f *wazeroExtEngine) hostKeyAsQNameEntity(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostKeyAsQNameEntity(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	key, name := f.keyargs(id, namePtr, nameSize)
 	qname := key.AsQName(name)
 	return f.allocAndSend([]byte(qname.Entity()))
 }
 
-func (f *wazeroExtEngine) hostKeyAsFloat32(key uint64, namePtr uint32, nameSize uint32) (result float32) {
+func (f *wazeroExtInstance) hostKeyAsFloat32(key uint64, namePtr uint32, nameSize uint32) (result float32) {
+	f.hostCall()
 	k, name := f.keyargs(key, namePtr, nameSize)
 	return k.AsFloat32(name)
 }
 
-func (f *wazeroExtEngine) hostKeyAsFloat64(key uint64, namePtr uint32, nameSize uint32) (result float64) {
+func (f *wazeroExtInstance) hostKeyAsFloat64(key uint64, namePtr uint32, nameSize uint32) (result float64) {
+	f.hostCall()
 	k, name := f.keyargs(key, namePtr, nameSize)
 	return k.AsFloat64(name)
 }
 
-func (f *wazeroExtEngine) hostValueGetAsString(value uint64, index uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueGetAsString(value uint64, index uint32) (result uint64) {
+	f.hostCall()
 	v := f.value(value)
 	return f.allocAndSend([]byte(v.GetAsString(int(index))))
 }
 
-func (f *wazeroExtEngine) hostValueGetAsQNameEntity(value uint64, index uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueGetAsQNameEntity(value uint64, index uint32) (result uint64) {
+	f.hostCall()
 	v := f.value(value)
 	qname := v.GetAsQName(int(index))
 	return f.allocAndSend([]byte(qname.Entity()))
 }
 
-func (f *wazeroExtEngine) hostValueGetAsQNamePkg(value uint64, index uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueGetAsQNamePkg(value uint64, index uint32) (result uint64) {
+	f.hostCall()
 	v := f.value(value)
 	qname := v.GetAsQName(int(index))
 	return f.allocAndSend([]byte(qname.Pkg()))
 }
 
-func (f *wazeroExtEngine) hostValueGetAsBytes(value uint64, index uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueGetAsBytes(value uint64, index uint32) (result uint64) {
+	f.hostCall()
 	v := f.value(value)
 	return f.allocAndSend(v.GetAsBytes(int(index)))
 }
 
-func (f *wazeroExtEngine) hostValueGetAsBool(value uint64, index uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueGetAsBool(value uint64, index uint32) (result uint64) {
+	f.hostCall()
 	v := f.value(value)
 	if v.GetAsBool(int(index)) {
 		return 1
@@ -576,25 +1032,30 @@ func (f *wazeroExtEngine) hostValueGetAsBool(value uint64, index uint32) (result
 	return 0
 }
 
-func (f *wazeroExtEngine) hostValueGetAsInt32(value uint64, index uint32) (result int32) {
+func (f *wazeroExtInstance) hostValueGetAsInt32(value uint64, index uint32) (result int32) {
+	f.hostCall()
 	v := f.value(value)
 	return v.GetAsInt32(int(index))
 }
 
-func (f *wazeroExtEngine) hostValueGetAsInt64(value uint64, index uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueGetAsInt64(value uint64, index uint32) (result uint64) {
+	f.hostCall()
 	v := f.value(value)
 	return uint64(v.GetAsInt64(int(index)))
 }
 
-func (f *wazeroExtEngine) hostValueGetAsFloat32(id uint64, index uint32) float32 {
+func (f *wazeroExtInstance) hostValueGetAsFloat32(id uint64, index uint32) float32 {
+	f.hostCall()
 	return f.value(id).GetAsFloat32(int(index))
 }
 
-func (f *wazeroExtEngine) hostValueGetAsFloat64(id uint64, index uint32) float64 {
+func (f *wazeroExtInstance) hostValueGetAsFloat64(id uint64, index uint32) float64 {
+	f.hostCall()
 	return f.value(id).GetAsFloat64(int(index))
 }
 
-func (f *wazeroExtEngine) hostValueGetAsValue(val uint64, index uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueGetAsValue(val uint64, index uint32) (result uint64) {
+	f.hostCall()
 	v := f.value(val)
 	value := v.GetAsValue(int(index))
 	result = uint64(len(f.values))
@@ -602,27 +1063,32 @@ func (f *wazeroExtEngine) hostValueGetAsValue(val uint64, index uint32) (result
 	return
 }
 
-func (f *wazeroExtEngine) hostValueAsString(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueAsString(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	return f.allocAndSend([]byte(v.AsString(name)))
 }
 
-func (f *wazeroExtEngine) hostValueAsBytes(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueAsBytes(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	return f.allocAndSend(v.AsBytes(name))
 }
 
-func (f *wazeroExtEngine) hostValueAsInt32(id uint64, namePtr uint32, nameSize uint32) (result int32) {
+func (f *wazeroExtInstance) hostValueAsInt32(id uint64, namePtr uint32, nameSize uint32) (result int32) {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	return v.AsInt32(name)
 }
 
-func (f *wazeroExtEngine) hostValueAsInt64(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueAsInt64(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	return uint64(v.AsInt64(name))
 }
 
-func (f *wazeroExtEngine) hostValueAsBool(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueAsBool(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	if v.AsBool(name) {
 		return 1
@@ -630,29 +1096,34 @@ func (f *wazeroExtEngine) hostValueAsBool(id uint64, namePtr uint32, nameSize ui
 	return 0
 }
 
-func (f *wazeroExtEngine) hostValueAsFloat32(id uint64, namePtr, nameSize uint32) float32 {
+func (f *wazeroExtInstance) hostValueAsFloat32(id uint64, namePtr, nameSize uint32) float32 {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	return v.AsFloat32(name)
 }
 
-func (f *wazeroExtEngine) hostValueAsFloat64(id uint64, namePtr, nameSize uint32) float64 {
+func (f *wazeroExtInstance) hostValueAsFloat64(id uint64, namePtr, nameSize uint32) float64 {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	return v.AsFloat64(name)
 }
 
-func (f *wazeroExtEngine) hostValueAsQNameEntity(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueAsQNameEntity(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	qname := v.AsQName(name)
 	return f.allocAndSend([]byte(qname.Entity()))
 }
 
-func (f *wazeroExtEngine) hostValueAsQNamePkg(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueAsQNamePkg(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	qname := v.AsQName(name)
 	return f.allocAndSend([]byte(qname.Pkg()))
 }
 
-func (f *wazeroExtEngine) hostValueAsValue(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+func (f *wazeroExtInstance) hostValueAsValue(id uint64, namePtr uint32, nameSize uint32) (result uint64) {
+	f.hostCall()
 	v, name := f.valueargs(id, namePtr, nameSize)
 	value := v.AsValue(name)
 	result = uint64(len(f.values))
@@ -660,18 +1131,19 @@ func (f *wazeroExtEngine) hostValueAsValue(id uint64, namePtr uint32, nameSize u
 	return
 }
 
-func (f *wazeroExtEngine) hostValueLength(id uint64) (result uint32) {
+func (f *wazeroExtInstance) hostValueLength(id uint64) (result uint32) {
+	f.hostCall()
 	if int(id) >= len(f.values) {
 		panic(PanicIncorrectValue)
 	}
 	return uint32(f.values[id].Length())
 }
 
-func (f *wazeroExtEngine) allocBuf(size uint32) (addr uint32, err error) {
-	for i := range f.pkg.allocatedBufs {
-		if f.pkg.allocatedBufs[i].cap-f.pkg.allocatedBufs[i].offs >= size {
-			addr = f.pkg.allocatedBufs[i].addr + f.pkg.allocatedBufs[i].offs
-			f.pkg.allocatedBufs[i].offs += uint32(size)
+func (f *wazeroExtInstance) allocBuf(size uint32) (addr uint32, err error) {
+	for i := range f.allocatedBufs {
+		if f.allocatedBufs[i].cap-f.allocatedBufs[i].offs >= size {
+			addr = f.allocatedBufs[i].addr + f.allocatedBufs[i].offs
+			f.allocatedBufs[i].offs += uint32(size)
 			return
 		}
 	}
@@ -683,12 +1155,12 @@ func (f *wazeroExtEngine) allocBuf(size uint32) (addr uint32, err error) {
 	}
 
 	var res []uint64
-	res, err = f.pkg.funcMalloc.Call(f.ctx, uint64(newBufferSize))
+	res, err = f.funcMalloc.Call(f.ctx, uint64(newBufferSize))
 	if err != nil {
 		return 0, err
 	}
 	addr = uint32(res[0])
-	f.pkg.allocatedBufs = append(f.pkg.allocatedBufs, &allocatedBuf{
+	f.allocatedBufs = append(f.allocatedBufs, &allocatedBuf{
 		addr: addr,
 		offs: 0,
 		cap:  newBufferSize,
@@ -701,7 +1173,7 @@ func (f *wazeroExtEngine) getFrees(packageName string, ctx context.Context) (uin
 	if !ok {
 		return 0, undefinedPackage(packageName)
 	}
-	res, err := pkg.funcGetFrees.Call(ctx)
+	res, err := pkg.instances[0].funcGetFrees.Call(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -713,7 +1185,7 @@ func (f *wazeroExtEngine) gc(packageName string, ctx context.Context) error {
 	if !ok {
 		return undefinedPackage(packageName)
 	}
-	_, err := pkg.funcGc.Call(ctx)
+	_, err := pkg.instances[0].funcGc.Call(ctx)
 	if err != nil {
 		return err
 	}
@@ -725,7 +1197,7 @@ func (f *wazeroExtEngine) getHeapinuse(packageName string, ctx context.Context)
 	if !ok {
 		return 0, undefinedPackage(packageName)
 	}
-	res, err := pkg.funcGetHeapInuse.Call(ctx)
+	res, err := pkg.instances[0].funcGetHeapInuse.Call(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -737,7 +1209,7 @@ func (f *wazeroExtEngine) getHeapSys(packageName string, ctx context.Context) (u
 	if !ok {
 		return 0, undefinedPackage(packageName)
 	}
-	res, err := pkg.funcGetHeapSys.Call(ctx)
+	res, err := pkg.instances[0].funcGetHeapSys.Call(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -749,17 +1221,19 @@ func (f *wazeroExtEngine) getMallocs(packageName string, ctx context.Context) (u
 	if !ok {
 		return 0, undefinedPackage(packageName)
 	}
-	res, err := pkg.funcGetMallocs.Call(ctx)
+	res, err := pkg.instances[0].funcGetMallocs.Call(ctx)
 	if err != nil {
 		return 0, err
 	}
 	return res[0], nil
 }
 
-func (f *wazeroExtEngine) hostNewValue(keyId uint64) (result uint64) {
+func (f *wazeroExtInstance) hostNewValue(keyId uint64) (result uint64) {
+	f.hostCall()
 	if int(keyId) >= len(f.keyBuilders) {
 		panic(PanicIncorrectKeyBuilder)
 	}
+	f.intent()
 	vb, err := f.io.NewValue(f.keyBuilders[keyId])
 	if err != nil {
 		panic(err)
@@ -769,13 +1243,15 @@ func (f *wazeroExtEngine) hostNewValue(keyId uint64) (result uint64) {
 	return
 }
 
-func (f *wazeroExtEngine) hostUpdateValue(keyId, existingValueId uint64) (result uint64) {
+func (f *wazeroExtInstance) hostUpdateValue(keyId, existingValueId uint64) (result uint64) {
+	f.hostCall()
 	if int(keyId) >= len(f.keyBuilders) {
 		panic(PanicIncorrectKeyBuilder)
 	}
 	if int(existingValueId) >= len(f.values) {
 		panic(PanicIncorrectValue)
 	}
+	f.intent()
 	vb, err := f.io.UpdateValue(f.keyBuilders[keyId], f.values[existingValueId])
 	if err != nil {
 		panic(err)
@@ -785,7 +1261,7 @@ func (f *wazeroExtEngine) hostUpdateValue(keyId, existingValueId uint64) (result
 	return
 }
 
-func (f *wazeroExtEngine) getWriterArgs(id uint64, typ uint32, namePtr uint32, nameSize uint32) (writer istructs.IRowWriter, name string) {
+func (f *wazeroExtInstance) getWriterArgs(id uint64, typ uint32, namePtr uint32, nameSize uint32) (writer istructs.IRowWriter, name string) {
 	switch typ {
 	case 0:
 		if int(id) >= len(f.keyBuilders) {
@@ -802,17 +1278,19 @@ func (f *wazeroExtEngine) getWriterArgs(id uint64, typ uint32, namePtr uint32, n
 	return
 }
 
-func (f *wazeroExtEngine) hostRowWriterPutString(id uint64, typ uint32, namePtr uint32, nameSize, valuePtr, valueSize uint32) {
+func (f *wazeroExtInstance) hostRowWriterPutString(id uint64, typ uint32, namePtr uint32, nameSize, valuePtr, valueSize uint32) {
+	f.hostCall()
 	writer, name := f.getWriterArgs(id, typ, namePtr, nameSize)
 	writer.PutString(name, f.decodeStr(valuePtr, valueSize))
 }
 
-func (f *wazeroExtEngine) hostRowWriterPutBytes(id uint64, typ uint32, namePtr uint32, nameSize, valuePtr, valueSize uint32) {
+func (f *wazeroExtInstance) hostRowWriterPutBytes(id uint64, typ uint32, namePtr uint32, nameSize, valuePtr, valueSize uint32) {
+	f.hostCall()
 	writer, name := f.getWriterArgs(id, typ, namePtr, nameSize)
 
 	var bytes []byte
 	var ok bool
-	bytes, ok = f.pkg.module.Memory().Read(uint32(valuePtr), uint32(valueSize))
+	bytes, ok = f.module.Memory().Read(uint32(valuePtr), uint32(valueSize))
 	if !ok {
 		panic(ErrUnableToReadMemory)
 	}
@@ -820,34 +1298,40 @@ func (f *wazeroExtEngine) hostRowWriterPutBytes(id uint64, typ uint32, namePtr u
 	writer.PutBytes(name, bytes)
 }
 
-func (f *wazeroExtEngine) hostRowWriterPutInt32(id uint64, typ uint32, namePtr uint32, nameSize uint32, value int32) {
+func (f *wazeroExtInstance) hostRowWriterPutInt32(id uint64, typ uint32, namePtr uint32, nameSize uint32, value int32) {
+	f.hostCall()
 	writer, name := f.getWriterArgs(id, typ, namePtr, nameSize)
 	writer.PutInt32(name, value)
 }
 
-func (f *wazeroExtEngine) hostRowWriterPutInt64(id uint64, typ uint32, namePtr uint32, nameSize uint32, value int64) {
+func (f *wazeroExtInstance) hostRowWriterPutInt64(id uint64, typ uint32, namePtr uint32, nameSize uint32, value int64) {
+	f.hostCall()
 	writer, name := f.getWriterArgs(id, typ, namePtr, nameSize)
 	writer.PutInt64(name, value)
 }
 
-func (f *wazeroExtEngine) hostRowWriterPutQName(id uint64, typ uint32, namePtr uint32, nameSize uint32, value int64, pkgPtr, pkgSize, entityPtr, entitySize uint32) {
+func (f *wazeroExtInstance) hostRowWriterPutQName(id uint64, typ uint32, namePtr uint32, nameSize uint32, value int64, pkgPtr, pkgSize, entityPtr, entitySize uint32) {
+	f.hostCall()
 	writer, name := f.getWriterArgs(id, typ, namePtr, nameSize)
 	pkg := f.decodeStr(pkgPtr, pkgSize)
 	entity := f.decodeStr(entityPtr, entitySize)
 	writer.PutQName(name, appdef.NewQName(pkg, entity))
 }
 
-func (f *wazeroExtEngine) hostRowWriterPutBool(id uint64, typ uint32, namePtr uint32, nameSize uint32, value int32) {
+func (f *wazeroExtInstance) hostRowWriterPutBool(id uint64, typ uint32, namePtr uint32, nameSize uint32, value int32) {
+	f.hostCall()
 	writer, name := f.getWriterArgs(id, typ, namePtr, nameSize)
 	writer.PutBool(name, value > 0)
 }
 
-func (f *wazeroExtEngine) hostRowWriterPutFloat32(id uint64, typ uint32, namePtr uint32, nameSize uint32, value float32) {
+func (f *wazeroExtInstance) hostRowWriterPutFloat32(id uint64, typ uint32, namePtr uint32, nameSize uint32, value float32) {
+	f.hostCall()
 	writer, name := f.getWriterArgs(id, typ, namePtr, nameSize)
 	writer.PutFloat32(name, value)
 }
 
-func (f *wazeroExtEngine) hostRowWriterPutFloat64(id uint64, typ uint32, namePtr, nameSize uint32, value float64) {
+func (f *wazeroExtInstance) hostRowWriterPutFloat64(id uint64, typ uint32, namePtr, nameSize uint32, value float64) {
+	f.hostCall()
 	writer, name := f.getWriterArgs(id, typ, namePtr, nameSize)
 	writer.PutFloat64(name, value)
 }
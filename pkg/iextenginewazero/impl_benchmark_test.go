@@ -151,7 +151,7 @@ func benchmarkRecover(b *testing.B, limitPages uint, expectedRuns int) {
 	}
 	defer ee.Close(ctx)
 
-	we := ee.(*wazeroExtEngine)
+	we := ee.(*wazeroExtEngine).modules[testPkg].instances[0]
 
 	for runs := 0; runs < expectedRuns; runs++ {
 		if err := ee.Invoke(context.Background(), iextengine.NewExtQName("test", arrAppend2), extIO); err != nil {
@@ -182,7 +182,7 @@ func benchmarkRecoverClean(b *testing.B, limitPages uint) {
 		panic(err)
 	}
 	defer ee.Close(ctx)
-	we := ee.(*wazeroExtEngine)
+	we := ee.(*wazeroExtEngine).modules[testPkg].instances[0]
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		we.recover()
@@ -8,12 +8,16 @@ package iextenginewasm
 import (
 	"context"
 	"net/url"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tetratelabs/wazero/sys"
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/iextengine"
+	"github.com/voedger/voedger/pkg/istructs"
+	imetrics "github.com/voedger/voedger/pkg/metrics"
 	"github.com/voedger/voedger/pkg/state"
 )
 
@@ -226,7 +230,7 @@ func Test_SetLimitsExecutionInterval(t *testing.T) {
 	require := require.New(t)
 	ctx := context.Background()
 	moduleUrl := testModuleURL("./_testdata/allocs/pkg.wasm")
-	extEngine, err := testFactoryHelper(ctx, moduleUrl, []string{"longFunc"}, iextengine.ExtEngineConfig{}, false)
+	extEngine, err := testFactoryHelper(ctx, moduleUrl, []string{"longFunc", "arrReset"}, iextengine.ExtEngineConfig{}, false)
 	require.NoError(err)
 	defer extEngine.Close(ctx)
 
@@ -239,6 +243,16 @@ func Test_SetLimitsExecutionInterval(t *testing.T) {
 
 	require.ErrorIs(err, sys.NewExitError(sys.ExitCodeDeadlineExceeded))
 	require.Less(time.Since(t0), maxDuration*4)
+
+	// module closed by deadline is dropped from pool
+	pkg := extEngine.(*wazeroExtEngine).modules[testPkg]
+	require.Empty(pkg.instances)
+	require.Zero(pkg.size)
+
+	// new instance is created by next invocation
+	require.NoError(extEngine.Invoke(context.Background(), iextengine.NewExtQName(testPkg, "arrReset"), extIO))
+	require.Len(pkg.instances, 1)
+	require.EqualValues(1, pkg.size)
 }
 
 type panicsUnit struct {
@@ -397,3 +411,301 @@ func Test_NoAllocs(t *testing.T) {
 	require.Equal(int32(12346), v1.items["offs"])
 	require.Equal("sys.InvitationAccepted", v1.items["qname"])
 }
+
+// IO which blocks invocation on first KeyBuilder call until released
+type blockingIo struct {
+	*mockIo
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingIo() *blockingIo {
+	return &blockingIo{
+		mockIo:  &mockIo{},
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (s *blockingIo) KeyBuilder(storage, entity appdef.QName) (istructs.IStateKeyBuilder, error) {
+	select {
+	case <-s.started:
+	default:
+		close(s.started)
+		<-s.release
+	}
+	return s.mockIo.KeyBuilder(storage, entity)
+}
+
+func Test_InstancesPerPackage(t *testing.T) {
+	const exampleCommand = "exampleCommand"
+
+	require := require.New(t)
+	ctx := context.Background()
+	projectorMode = false
+	moduleUrl := testModuleURL("./_testdata/basicusage/pkg.wasm")
+	extEngine, err := testFactoryHelper(ctx, moduleUrl, []string{exampleCommand}, iextengine.ExtEngineConfig{InstancesPerPackage: 2}, false)
+	require.NoError(err)
+	defer extEngine.Close(ctx)
+
+	require.Len(extEngine.(*wazeroExtEngine).modules[testPkg].instances, 2)
+
+	ios := []*blockingIo{newBlockingIo(), newBlockingIo()}
+	errs := make(chan error, len(ios))
+	for _, io := range ios {
+		go func(io *blockingIo) {
+			errs <- extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, exampleCommand), io)
+		}(io)
+	}
+
+	// both invocations are in-flight simultaneously
+	for _, io := range ios {
+		<-io.started
+	}
+
+	t.Run("should wait for idle instance", func(t *testing.T) {
+		ctxTimeout, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+		defer cancel()
+		err := extEngine.Invoke(ctxTimeout, iextengine.NewExtQName(testPkg, exampleCommand), &mockIo{})
+		require.ErrorIs(err, context.DeadlineExceeded)
+	})
+
+	for _, io := range ios {
+		close(io.release)
+	}
+	for range ios {
+		require.NoError(<-errs)
+	}
+	for _, io := range ios {
+		require.Len(io.intents, 1)
+	}
+}
+
+func Test_Reload(t *testing.T) {
+	const (
+		exampleCommand = "exampleCommand"
+		testQueryValue = "testQueryValue"
+	)
+
+	require := require.New(t)
+	ctx := context.Background()
+	projectorMode = false
+	extEngine, err := testFactoryHelper(ctx, testModuleURL("./_testdata/basicusage/pkg.wasm"), []string{exampleCommand}, iextengine.ExtEngineConfig{}, false)
+	require.NoError(err)
+	defer extEngine.Close(ctx)
+
+	reloader, ok := extEngine.(iextengine.IReloadableExtensionEngine)
+	require.True(ok)
+
+	t.Run("should not replace package if new module can not be loaded", func(t *testing.T) {
+		_, err := reloader.PrepareReload(ctx, []iextengine.ExtensionPackage{{
+			QualifiedName:  testPkg,
+			ModuleUrl:      testModuleURL("./_testdata/unknown/pkg.wasm"),
+			ExtensionNames: []string{testQueryValue},
+		}})
+		require.Error(err)
+		require.NoError(extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, exampleCommand), &mockIo{}))
+	})
+
+	t.Run("should not replace package if reload is rolled back", func(t *testing.T) {
+		reload, err := reloader.PrepareReload(ctx, []iextengine.ExtensionPackage{{
+			QualifiedName:  testPkg,
+			ModuleUrl:      testModuleURL("./_testdata/tests/pkg.wasm"),
+			ExtensionNames: []string{testQueryValue},
+		}})
+		require.NoError(err)
+		reload.Rollback(ctx)
+		require.NoError(extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, exampleCommand), &mockIo{}))
+	})
+
+	t.Run("should replace package on commit and drain in-flight invocations", func(t *testing.T) {
+		io := newBlockingIo()
+		invoked := make(chan error)
+		go func() {
+			invoked <- extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, exampleCommand), io)
+		}()
+		<-io.started
+
+		reload, err := reloader.PrepareReload(ctx, []iextengine.ExtensionPackage{{
+			QualifiedName:  testPkg,
+			ModuleUrl:      testModuleURL("./_testdata/tests/pkg.wasm"),
+			ExtensionNames: []string{testQueryValue},
+		}})
+		require.NoError(err)
+		drain := reload.Commit()
+
+		require.NoError(extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, testQueryValue), &mockIo{}))
+		require.Error(extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, exampleCommand), &mockIo{}))
+
+		drained := make(chan struct{})
+		go func() {
+			drain(ctx)
+			close(drained)
+		}()
+
+		select {
+		case <-drained:
+			require.Fail("drain should wait for in-flight invocation")
+		case <-time.After(time.Millisecond * 50):
+		}
+
+		close(io.release)
+		require.NoError(<-invoked)
+		require.Len(io.intents, 1)
+		<-drained
+	})
+}
+
+func Test_CompilationCacheDir(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	moduleUrl := testModuleURL("./_testdata/basicusage/pkg.wasm")
+	extEngine, err := testFactoryHelper(ctx, moduleUrl, []string{"exampleCommand"}, iextengine.ExtEngineConfig{CompilationCacheDir: dir}, true)
+	require.NoError(err)
+	defer extEngine.Close(ctx)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.NotEmpty(entries, "compiled module should be cached on disk")
+}
+
+func Test_ExtensionLimits(t *testing.T) {
+	const (
+		testNoAllocs = "testNoAllocs"
+		longFunc     = "longFunc"
+		simple       = "simple"
+		arrReset     = "arrReset"
+	)
+
+	require := require.New(t)
+	ctx := context.Background()
+	projectorMode = false
+
+	metrics := imetrics.Provide()
+	cfg := iextengine.ExtEngineConfig{
+		MemoryLimitPages: 0x20,
+		Metrics:          metrics,
+		VvmName:          "vvm",
+		AppQName:         istructs.AppQName_test1_app1,
+	}
+
+	newEngine := func(wasm string, limits map[string]iextengine.ExtensionLimits, extNames ...string) iextengine.IExtensionEngine {
+		packages := []iextengine.ExtensionPackage{
+			{
+				QualifiedName:   testPkg,
+				ModuleUrl:       testModuleURL(wasm),
+				ExtensionNames:  extNames,
+				ExtensionLimits: limits,
+			},
+		}
+		engines, err := ProvideExtensionEngineFactory(false).New(ctx, packages, &cfg, 1)
+		require.NoError(err)
+		return engines[0]
+	}
+
+	requireLimitExceeded := func(err error, ext, limit string, max uint64) {
+		require.ErrorIs(err, iextengine.ErrLimitExceeded)
+		var e *iextengine.LimitExceededError
+		require.ErrorAs(err, &e)
+		require.Equal(iextengine.NewExtQName(testPkg, ext), e.Extension)
+		require.Equal(limit, e.Limit)
+		require.Equal(max, e.Max)
+	}
+
+	metric := func(name, ext string) float64 {
+		return float64(*metrics.ExtMetricAddr(name, "vvm", istructs.AppQName_test1_app1, appdef.NewQName(testPkg, ext)))
+	}
+
+	t.Run("should be error if host calls or intents limits are exceeded", func(t *testing.T) {
+		extEngine := newEngine("./_testdata/tests/pkg.wasm", nil, testNoAllocs)
+		defer extEngine.Close(ctx)
+
+		extEngine.SetLimits(iextengine.ExtensionLimits{MaxHostCalls: 3})
+		err := extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, testNoAllocs), &mockIo{})
+		requireLimitExceeded(err, testNoAllocs, iextengine.Limit_HostCalls, 3)
+
+		extEngine.SetLimits(iextengine.ExtensionLimits{MaxIntents: 1})
+		io := &mockIo{}
+		err = extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, testNoAllocs), io)
+		requireLimitExceeded(err, testNoAllocs, iextengine.Limit_Intents, 1)
+		require.Len(io.intents, 1)
+
+		extEngine.SetLimits(iextengine.ExtensionLimits{MaxIntents: 2})
+		require.NoError(extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, testNoAllocs), &mockIo{}))
+
+		require.Equal(3.0, metric(iextengine.Metric_ExtInvocationsTotal, testNoAllocs))
+		require.Equal(2.0, metric(iextengine.Metric_ExtLimitsExceededTotal, testNoAllocs))
+		require.Equal(3.0, metric(iextengine.Metric_ExtIntentsTotal, testNoAllocs))
+		require.Greater(metric(iextengine.Metric_ExtHostCallsTotal, testNoAllocs), 3.0)
+		require.Greater(metric(iextengine.Metric_ExtInvocationsSeconds, testNoAllocs), 0.0)
+	})
+
+	t.Run("should be error if execution interval or memory limits are exceeded", func(t *testing.T) {
+		extEngine := newEngine("./_testdata/allocs/pkg.wasm",
+			map[string]iextengine.ExtensionLimits{
+				longFunc: {ExecutionInterval: 50 * time.Millisecond},
+				simple:   {MaxMemoryPages: 1},
+			},
+			longFunc, simple, arrReset)
+		defer extEngine.Close(ctx)
+
+		t0 := time.Now()
+		err := extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, longFunc), extIO)
+		requireLimitExceeded(err, longFunc, iextengine.Limit_ExecutionInterval, 50)
+		require.Less(time.Since(t0), 200*time.Millisecond)
+
+		err = extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, simple), extIO)
+		requireLimitExceeded(err, simple, iextengine.Limit_MemoryPages, 1)
+
+		// modules closed by limits are dropped from pool, new instances are created by next invocations
+		require.NoError(extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, arrReset), extIO))
+
+		require.Equal(1.0, metric(iextengine.Metric_ExtLimitsExceededTotal, longFunc))
+		require.Equal(1.0, metric(iextengine.Metric_ExtLimitsExceededTotal, simple))
+		require.Equal(0.0, metric(iextengine.Metric_ExtLimitsExceededTotal, arrReset))
+	})
+
+	t.Run("should be error if memory is grown over limit without host calls", func(t *testing.T) {
+		cfg.MemoryLimitPages = 0x400
+		defer func() { cfg.MemoryLimitPages = 0x20 }()
+
+		// longFunc grows memory by map without host calls, module memory is 0x20 pages initially
+		extEngine := newEngine("./_testdata/allocs/pkg.wasm",
+			map[string]iextengine.ExtensionLimits{
+				longFunc: {MaxMemoryPages: 0x30},
+			},
+			longFunc, simple)
+		defer extEngine.Close(ctx)
+
+		err := extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, longFunc), extIO)
+		requireLimitExceeded(err, longFunc, iextengine.Limit_MemoryPages, 0x30)
+
+		// extensions without memory limit are invoked by instances with engine memory limit
+		require.NoError(extEngine.Invoke(ctx, iextengine.NewExtQName(testPkg, simple), extIO))
+	})
+}
+
+func Test_ReadModule(t *testing.T) {
+	require := require.New(t)
+	fileURL := testModuleURL("./_testdata/basicusage/pkg.wasm")
+	expected, err := readModule(fileURL)
+	require.NoError(err)
+	require.NotEmpty(expected)
+
+	t.Run("should read file of localhost", func(t *testing.T) {
+		u := *fileURL
+		u.Host = "LocalHost"
+		wasm, err := readModule(&u)
+		require.NoError(err)
+		require.Equal(expected, wasm)
+	})
+
+	t.Run("should be error if file is not local", func(t *testing.T) {
+		u := *fileURL
+		u.Host = "example.com"
+		_, err := readModule(&u)
+		require.ErrorContains(err, "unsupported URL")
+	})
+}
@@ -4,8 +4,14 @@
 */
 package iextenginewasm
 
-import "github.com/voedger/voedger/pkg/iextengine"
+import (
+	"github.com/tetratelabs/wazero"
+	"github.com/voedger/voedger/pkg/iextengine"
+)
 
-func ProvideExtensionEngineFactory(compile bool) iextengine.IExtensionEngineFactory {
-	return extensionEngineFactory{compile}
+func ProvideExtensionEngineFactory(compile bool) iextengine.ICloseableExtensionEngineFactory {
+	return &extensionEngineFactory{
+		compile: compile,
+		caches:  make(map[string]wazero.CompilationCache),
+	}
 }
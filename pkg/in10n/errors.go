@@ -15,3 +15,7 @@ var ErrQuotaExceeded_Subsciptions = errors.New(quotaExceededPrefix + "subsciptio
 var ErrQuotaExceeded_SubsciptionsPerSubject = errors.New(quotaExceededPrefix + "subsciptions per subject")
 
 var ErrChannelDoesNotExist = errors.New("channel does not exist")
+
+var ErrResumeNotSupported = errors.New("resumable subscriptions are not supported")
+
+var ErrRowsNotSupported = errors.New("delivery of changed rows is not supported")
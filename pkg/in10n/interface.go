@@ -59,6 +59,46 @@ type IN10nBroker interface {
 	MetricSubject(ctx context.Context, cb func(subject istructs.SubjectLogin, numChannels int, numSubscriptions int))
 }
 
+// IN10nResumableBroker allows offline-capable clients to resume after reconnect without missing projection updates
+type IN10nResumableBroker interface {
+	IN10nBroker
+
+	// Same as Subscribe() but delivery is resumed after the lastSeen offset:
+	//   - if lastSeen is NullOffset then the subject cursor is used, see Cursor()
+	//   - if the projection offset is greater than lastSeen then WatchChannel replays the latest offset
+	//   - if the broker can not prove that no updates were missed after lastSeen then WatchChannel delivers GapOffset
+	// Errors: same as Subscribe()
+	// @ConcurrentAccess
+	SubscribeFrom(channelID ChannelID, projection ProjectionKey, lastSeen istructs.Offset) (err error)
+
+	// Returns the offset delivered to the subject for the projection last time, NullOffset if there is no cursor
+	// Cursors outlive channels but not the broker: they are kept in the process memory, so delivery can be resumed
+	// only if the client reconnects to the same process. Otherwise the client must pass its own lastSeen offset.
+	// Cursor is evicted on Unsubscribe() or if it is not updated for a long time
+	// @ConcurrentAccess
+	Cursor(subject istructs.SubjectLogin, projection ProjectionKey) istructs.Offset
+}
+
+// IN10nRowsBroker allows subscribers to receive the changed view rows together with the offset
+type IN10nRowsBroker interface {
+	IN10nBroker
+
+	// Same as WatchChannel() but `rows` are passed to `notifySubscriber` if they are known for the delivered offset
+	// rows is nil if the subscriber has missed some updates or rows were too large, the subscriber must re-read the projection in this case
+	// @ConcurrentAccess
+	WatchChannelRows(ctx context.Context, channelID ChannelID, notifySubscriber func(projection ProjectionKey, offset istructs.Offset, rows []byte))
+
+	// Same as Update() but the changed rows are attached to the offset
+	// rows() returns JSON array of changed rows or nil if the array is larger than maxSize bytes.
+	// rows() is called only if there are channels watched by WatchChannelRows()
+	// @ConcurrentAccess
+	UpdateRows(projection ProjectionKey, offset istructs.Offset, rows func(maxSize int) []byte)
+}
+
+// GapOffset is delivered instead of the projection offset if some updates could be missed
+// Subscriber must re-read the projection
+const GapOffset = istructs.NullOffset
+
 type ChannelID string
 type SubscriptionID string
 
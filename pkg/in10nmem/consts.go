@@ -8,6 +8,14 @@
 
 package in10nmem
 
+import "time"
+
 const (
 	eventsChannelSize = 10
+
+	// changed rows which are larger are not delivered, subscribers receive the offset only
+	maxRowsSize = 64 * 1024
+
+	// cursors which are not updated during cursorTTL are evicted
+	cursorTTL = 24 * time.Hour
 )
@@ -31,6 +31,8 @@ type N10nBroker struct {
 	channels         map[in10n.ChannelID]*channelType
 	quotas           in10n.Quotas
 	metricBySubject  map[istructs.SubjectLogin]*metricType
+	cursorsBySubject map[istructs.SubjectLogin]map[in10n.ProjectionKey]*cursorType
+	cursorsSweptAt   time.Time
 	numSubscriptions int
 	now              coreutils.TimeFunc
 	events           chan event
@@ -45,6 +47,10 @@ type projection struct {
 
 	offsetPointer *istructs.Offset
 
+	// changed rows which moved the projection from rowsFrom offset to the current one, guarded by N10nBroker
+	rows     []byte
+	rowsFrom istructs.Offset
+
 	toSubscribe map[in10n.ChannelID]*channelType
 
 	// merged by pnotifier using toSubscribe, toUnsubscribe
@@ -54,6 +60,16 @@ type projection struct {
 type subscription struct {
 	deliveredOffset istructs.Offset
 	currentOffset   *istructs.Offset
+	prj             *projection
+	// GapOffset must be delivered since some updates could be missed
+	gap bool
+}
+
+// cursorType is the offset delivered to the subject last time.
+// Cursors are kept in the process memory only, so delivery can be resumed from the cursor within the same process
+type cursorType struct {
+	offset    istructs.Offset
+	updatedAt time.Time
 }
 
 type channelType struct {
@@ -62,6 +78,8 @@ type channelType struct {
 	channelDuration time.Duration
 	createTime      time.Time
 	cchan           chan struct{}
+	// channel is watched by WatchChannelRows()
+	rows bool
 }
 
 type metricType struct {
@@ -89,6 +107,7 @@ func (nb *N10nBroker) NewChannel(subject istructs.SubjectLogin, channelDuration
 		nb.metricBySubject[subject] = metric
 	}
 	metric.numChannels++
+	nb.sweepCursors()
 	channelID = in10n.ChannelID(uuid.New().String())
 	channel := channelType{
 		subject:         subject,
@@ -106,40 +125,146 @@ func (nb *N10nBroker) NewChannel(subject istructs.SubjectLogin, channelDuration
 func (nb *N10nBroker) Subscribe(channelID in10n.ChannelID, projectionKey in10n.ProjectionKey) (err error) {
 	nb.Lock()
 	defer nb.Unlock()
+	_, _, err = nb.subscribe(channelID, projectionKey)
+	return err
+}
+
+// SubscribeFrom @ConcurrentAccess
+// Subscribe to the channel for the projection and wake up the channel watcher to replay the latest offset or the gap
+func (nb *N10nBroker) SubscribeFrom(channelID in10n.ChannelID, projectionKey in10n.ProjectionKey, lastSeen istructs.Offset) (err error) {
+	nb.Lock()
+	defer nb.Unlock()
+	channel, subscription, err := nb.subscribe(channelID, projectionKey)
+	if err != nil {
+		return err
+	}
+	if lastSeen == istructs.NullOffset {
+		lastSeen = nb.cursor(channel.subject, projectionKey)
+	}
+	if lastSeen != istructs.NullOffset {
+		// offsets are not kept after restart, so the projection offset can be less than the client has seen
+		if *subscription.currentOffset < lastSeen {
+			subscription.gap = true
+		} else {
+			subscription.deliveredOffset = lastSeen
+		}
+	}
+	select {
+	case channel.cchan <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Cursor @ConcurrentAccess
+func (nb *N10nBroker) Cursor(subject istructs.SubjectLogin, projectionKey in10n.ProjectionKey) istructs.Offset {
+	nb.RLock()
+	defer nb.RUnlock()
+	return nb.cursor(subject, projectionKey)
+}
+
+// must be called under nb.RLock()
+func (nb *N10nBroker) cursor(subject istructs.SubjectLogin, projectionKey in10n.ProjectionKey) istructs.Offset {
+	if cursor, ok := nb.cursorsBySubject[subject][projectionKey]; ok && !nb.cursorExpired(cursor) {
+		return cursor.offset
+	}
+	return istructs.NullOffset
+}
+
+func (nb *N10nBroker) cursorExpired(cursor *cursorType) bool {
+	return nb.now().Sub(cursor.updatedAt) >= cursorTTL
+}
+
+// must be called under nb.Lock()
+func (nb *N10nBroker) subscribe(channelID in10n.ChannelID, projectionKey in10n.ProjectionKey) (*channelType, *subscription, error) {
 	channel, channelOK := nb.channels[channelID]
 	if !channelOK {
-		return in10n.ErrChannelDoesNotExist
+		return nil, nil, in10n.ErrChannelDoesNotExist
 	}
 
 	metric, metricOK := nb.metricBySubject[channel.subject]
 	if !metricOK {
-		return ErrMetricDoesNotExists
+		return nil, nil, ErrMetricDoesNotExists
 	}
 
 	if nb.numSubscriptions >= nb.quotas.Subsciptions {
-		return in10n.ErrQuotaExceeded_Subsciptions
+		return nil, nil, in10n.ErrQuotaExceeded_Subsciptions
 	}
 	if metric.numSubscriptions >= nb.quotas.SubsciptionsPerSubject {
-		return in10n.ErrQuotaExceeded_SubsciptionsPerSubject
+		return nil, nil, in10n.ErrQuotaExceeded_SubsciptionsPerSubject
 	}
 
+	prj := guaranteeProjection(nb.projections, projectionKey)
 	subscription := subscription{
 		deliveredOffset: istructs.Offset(0),
-		currentOffset:   guaranteeProjection(nb.projections, projectionKey),
+		currentOffset:   prj.offsetPointer,
+		prj:             prj,
 	}
 	channel.subscriptions[projectionKey] = &subscription
 	metric.numSubscriptions++
 	nb.numSubscriptions++
 
 	{
-		// Must exist because we create it in guaranteeProjection
-		prj := nb.projections[projectionKey]
 		prj.Lock()
 		defer prj.Unlock()
 		prj.toSubscribe[channelID] = channel
 	}
 
-	return err
+	return channel, &subscription, nil
+}
+
+// saveCursor must be called under nb.Lock()
+// Number of cursors per subject is limited by SubsciptionsPerSubject quota, the least recently updated cursor is evicted
+func (nb *N10nBroker) saveCursor(subject istructs.SubjectLogin, projectionKey in10n.ProjectionKey, offset istructs.Offset) {
+	cursors, ok := nb.cursorsBySubject[subject]
+	if !ok {
+		cursors = make(map[in10n.ProjectionKey]*cursorType)
+		nb.cursorsBySubject[subject] = cursors
+	}
+	cursor, ok := cursors[projectionKey]
+	if !ok {
+		if len(cursors) >= nb.quotas.SubsciptionsPerSubject {
+			var oldest in10n.ProjectionKey
+			for key, c := range cursors {
+				if cursor == nil || c.updatedAt.Before(cursor.updatedAt) {
+					oldest, cursor = key, c
+				}
+			}
+			delete(cursors, oldest)
+		}
+		cursor = new(cursorType)
+		cursors[projectionKey] = cursor
+	}
+	cursor.offset = offset
+	cursor.updatedAt = nb.now()
+}
+
+// deleteCursor must be called under nb.Lock()
+func (nb *N10nBroker) deleteCursor(subject istructs.SubjectLogin, projectionKey in10n.ProjectionKey) {
+	cursors, ok := nb.cursorsBySubject[subject]
+	if !ok {
+		return
+	}
+	delete(cursors, projectionKey)
+	if len(cursors) == 0 {
+		delete(nb.cursorsBySubject, subject)
+	}
+}
+
+// sweepCursors must be called under nb.Lock()
+// Expired cursors of all subjects are evicted at most once per cursorTTL
+func (nb *N10nBroker) sweepCursors() {
+	if nb.now().Sub(nb.cursorsSweptAt) < cursorTTL {
+		return
+	}
+	for subject, cursors := range nb.cursorsBySubject {
+		for projectionKey, cursor := range cursors {
+			if nb.cursorExpired(cursor) {
+				nb.deleteCursor(subject, projectionKey)
+			}
+		}
+	}
+	nb.cursorsSweptAt = nb.now()
 }
 
 func (nb *N10nBroker) Unsubscribe(channelID in10n.ChannelID, projectionKey in10n.ProjectionKey) (err error) {
@@ -157,6 +282,8 @@ func (nb *N10nBroker) Unsubscribe(channelID in10n.ChannelID, projectionKey in10n
 	delete(channel.subscriptions, projectionKey)
 	metric.numSubscriptions--
 	nb.numSubscriptions--
+	// explicit unsubscribe means the subject is not interested in the projection anymore
+	nb.deleteCursor(channel.subject, projectionKey)
 
 	prj := nb.projections[projectionKey]
 	if prj != nil {
@@ -172,6 +299,18 @@ func (nb *N10nBroker) Unsubscribe(channelID in10n.ChannelID, projectionKey in10n
 // Create WatchChannel for notify clients about changed projections. If channel for this demand does not exist or
 // channel already watched - exit.
 func (nb *N10nBroker) WatchChannel(ctx context.Context, channelID in10n.ChannelID, notifySubscriber func(projection in10n.ProjectionKey, offset istructs.Offset)) {
+	nb.watchChannel(ctx, channelID, false, func(projection in10n.ProjectionKey, offset istructs.Offset, _ []byte) {
+		notifySubscriber(projection, offset)
+	})
+}
+
+// WatchChannelRows @ConcurrentAccess
+// Same as WatchChannel but changed rows are delivered if the subscriber has received the previous offset of the projection
+func (nb *N10nBroker) WatchChannelRows(ctx context.Context, channelID in10n.ChannelID, notifySubscriber func(projection in10n.ProjectionKey, offset istructs.Offset, rows []byte)) {
+	nb.watchChannel(ctx, channelID, true, notifySubscriber)
+}
+
+func (nb *N10nBroker) watchChannel(ctx context.Context, channelID in10n.ChannelID, rows bool, notifySubscriber func(projection in10n.ProjectionKey, offset istructs.Offset, rows []byte)) {
 	// check that the channelID with the given ChannelID exists
 	channel, metric := func() (*channelType, *metricType) {
 		nb.RLock()
@@ -187,6 +326,12 @@ func (nb *N10nBroker) WatchChannel(ctx context.Context, channelID in10n.ChannelI
 		return channel, metric
 	}()
 
+	if rows {
+		nb.Lock()
+		channel.rows = true
+		nb.Unlock()
+	}
+
 	defer func() {
 		nb.Lock()
 		metric.numChannels--
@@ -221,18 +366,34 @@ forctx:
 			// find projection for update and collect
 			nb.Lock()
 			for projection, channelOffsets := range channel.subscriptions {
-				if *channelOffsets.currentOffset > channelOffsets.deliveredOffset {
+				switch {
+				case channelOffsets.gap:
 					updateUnits = append(updateUnits,
 						UpdateUnit{
 							Projection: projection,
-							Offset:     *channelOffsets.currentOffset,
+							Offset:     in10n.GapOffset,
 						})
+					channelOffsets.gap = false
 					channelOffsets.deliveredOffset = *channelOffsets.currentOffset
+				case *channelOffsets.currentOffset > channelOffsets.deliveredOffset:
+					unit := UpdateUnit{
+						Projection: projection,
+						Offset:     *channelOffsets.currentOffset,
+					}
+					// rows are useless if the subscriber has missed some offsets
+					if channel.rows && channelOffsets.prj.rowsFrom == channelOffsets.deliveredOffset {
+						unit.Rows = channelOffsets.prj.rows
+					}
+					updateUnits = append(updateUnits, unit)
+					channelOffsets.deliveredOffset = *channelOffsets.currentOffset
+				default:
+					continue
 				}
+				nb.saveCursor(channel.subject, projection, channelOffsets.deliveredOffset)
 			}
 			nb.Unlock()
 			for _, unit := range updateUnits {
-				notifySubscriber(unit.Projection, unit.Offset)
+				notifySubscriber(unit.Projection, unit.Offset, unit.Rows)
 			}
 			updateUnits = updateUnits[:0]
 		}
@@ -279,7 +440,7 @@ forcycle:
 	wg.Done()
 }
 
-func guaranteeProjection(projections map[in10n.ProjectionKey]*projection, projectionKey in10n.ProjectionKey) (offsetPointer *istructs.Offset) {
+func guaranteeProjection(projections map[in10n.ProjectionKey]*projection, projectionKey in10n.ProjectionKey) *projection {
 	prj := projections[projectionKey]
 	if prj == nil {
 		prj = &projection{
@@ -290,21 +451,68 @@ func guaranteeProjection(projections map[in10n.ProjectionKey]*projection, projec
 		projections[projectionKey] = prj
 
 	}
-	return prj.offsetPointer
+	return prj
 }
 
 // Update @ConcurrentAccess
 // Update projections map with new offset
 func (nb *N10nBroker) Update(projection in10n.ProjectionKey, offset istructs.Offset) {
 	nb.Lock()
-	*guaranteeProjection(nb.projections, projection) = offset
-	prj := nb.projections[projection]
+	prj := guaranteeProjection(nb.projections, projection)
+	*prj.offsetPointer = offset
+	prj.rows = nil
 	nb.Unlock()
 
 	e := event{prj: prj}
 	nb.events <- e
 }
 
+// UpdateRows @ConcurrentAccess
+// Update projections map with new offset and changed rows, rows are built only if some channel is watched with rows.
+// Rows are built outside of the broker lock
+func (nb *N10nBroker) UpdateRows(projection in10n.ProjectionKey, offset istructs.Offset, rows func(maxSize int) []byte) {
+	var data []byte
+	if nb.hasRowsWatchers(projection) {
+		if data = rows(maxRowsSize); len(data) > maxRowsSize {
+			data = nil
+		}
+	}
+
+	nb.Lock()
+	prj := guaranteeProjection(nb.projections, projection)
+	prj.rowsFrom = *prj.offsetPointer
+	prj.rows = data
+	*prj.offsetPointer = offset
+	nb.Unlock()
+
+	e := event{prj: prj}
+	nb.events <- e
+}
+
+func (nb *N10nBroker) hasRowsWatchers(projection in10n.ProjectionKey) bool {
+	nb.RLock()
+	defer nb.RUnlock()
+	prj, ok := nb.projections[projection]
+	return ok && prj.hasRowsWatchers()
+}
+
+// hasRowsWatchers must be called under N10nBroker read lock at least
+func (prj *projection) hasRowsWatchers() bool {
+	prj.Lock()
+	defer prj.Unlock()
+	for _, channel := range prj.subscribedChannels {
+		if channel.rows {
+			return true
+		}
+	}
+	for _, channel := range prj.toSubscribe {
+		if channel != nil && channel.rows {
+			return true
+		}
+	}
+	return false
+}
+
 // MetricNumChannels @ConcurrentAccess
 // return channels count
 func (nb *N10nBroker) MetricNumChannels() int {
@@ -339,12 +547,13 @@ func (nb *N10nBroker) MetricSubject(ctx context.Context, cb func(subject istruct
 
 func NewN10nBroker(quotas in10n.Quotas, now coreutils.TimeFunc) (nb *N10nBroker, cleanup func()) {
 	broker := N10nBroker{
-		projections:     make(map[in10n.ProjectionKey]*projection),
-		channels:        make(map[in10n.ChannelID]*channelType),
-		metricBySubject: make(map[istructs.SubjectLogin]*metricType),
-		quotas:          quotas,
-		now:             now,
-		events:          make(chan event, eventsChannelSize),
+		projections:      make(map[in10n.ProjectionKey]*projection),
+		channels:         make(map[in10n.ChannelID]*channelType),
+		metricBySubject:  make(map[istructs.SubjectLogin]*metricType),
+		cursorsBySubject: make(map[istructs.SubjectLogin]map[in10n.ProjectionKey]*cursorType),
+		quotas:           quotas,
+		now:              now,
+		events:           make(chan event, eventsChannelSize),
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	wg := sync.WaitGroup{}
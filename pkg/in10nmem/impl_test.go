@@ -15,6 +15,7 @@ package in10nmem
 import (
 	"context"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -250,3 +251,244 @@ func TestQuotas(t *testing.T) {
 	})
 
 }
+
+func TestResumableSubscriptions(t *testing.T) {
+	req := require.New(t)
+
+	quotasExample := in10n.Quotas{
+		Channels:               10,
+		ChannelsPerSubject:     10,
+		Subsciptions:           10,
+		SubsciptionsPerSubject: 2,
+	}
+	broker, cleanup := NewN10nBroker(quotasExample, time.Now)
+	defer cleanup()
+
+	var subject istructs.SubjectLogin = "paa"
+	projectionKey := func(name string) in10n.ProjectionKey {
+		return in10n.ProjectionKey{
+			App:        istructs.AppQName_test1_app1,
+			Projection: appdef.NewQName("test", name),
+			WS:         istructs.WSID(1),
+		}
+	}
+	prj1 := projectionKey("restaurant")
+	prj2 := projectionKey("restaurant2")
+
+	// watch creates the channel, subscribes it from the given offsets and watches it until the returned cancel is called
+	watch := func(lastSeen map[in10n.ProjectionKey]istructs.Offset) (updates chan UpdateUnit, cancel func()) {
+		channelID, err := broker.NewChannel(subject, 24*time.Hour)
+		req.NoError(err)
+		for projection, offset := range lastSeen {
+			req.NoError(broker.SubscribeFrom(channelID, projection, offset))
+		}
+		cb := &callbackMock{data: make(chan UpdateUnit, 10)}
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			broker.WatchChannel(ctx, channelID, cb.updatesMock)
+			close(done)
+		}()
+		return cb.data, func() {
+			cancelCtx()
+			<-done
+		}
+	}
+
+	broker.Update(prj1, 10)
+
+	t.Run("Should replay the latest offset to the new subscriber", func(t *testing.T) {
+		updates, cancel := watch(map[in10n.ProjectionKey]istructs.Offset{prj1: istructs.NullOffset})
+		defer cancel()
+		req.Equal(UpdateUnit{Projection: prj1, Offset: 10}, <-updates)
+		req.Eventually(func() bool { return broker.Cursor(subject, prj1) == 10 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Should resume from the subject cursor", func(t *testing.T) {
+		broker.Update(prj1, 11)
+		updates, cancel := watch(map[in10n.ProjectionKey]istructs.Offset{prj1: istructs.NullOffset})
+		defer cancel()
+		req.Equal(UpdateUnit{Projection: prj1, Offset: 11}, <-updates)
+	})
+
+	t.Run("Should resume from the last seen offset", func(t *testing.T) {
+		updates, cancel := watch(map[in10n.ProjectionKey]istructs.Offset{prj1: 11})
+		defer cancel()
+		broker.Update(prj1, 12)
+		req.Equal(UpdateUnit{Projection: prj1, Offset: 12}, <-updates)
+	})
+
+	t.Run("Should deliver the gap if updates could be missed", func(t *testing.T) {
+		// prj2 is not updated since the broker start but the client has seen offset 5
+		updates, cancel := watch(map[in10n.ProjectionKey]istructs.Offset{prj2: 5})
+		defer cancel()
+		req.Equal(UpdateUnit{Projection: prj2, Offset: in10n.GapOffset}, <-updates)
+		broker.Update(prj2, 6)
+		req.Equal(UpdateUnit{Projection: prj2, Offset: 6}, <-updates)
+	})
+
+	t.Run("Should evict the least recently updated cursor", func(t *testing.T) {
+		prj3 := projectionKey("restaurant3")
+		broker.Update(prj3, 1)
+		updates, cancel := watch(map[in10n.ProjectionKey]istructs.Offset{prj3: istructs.NullOffset})
+		defer cancel()
+		req.Equal(UpdateUnit{Projection: prj3, Offset: 1}, <-updates)
+		req.Eventually(func() bool { return broker.Cursor(subject, prj3) == 1 }, time.Second, time.Millisecond)
+		req.Equal(istructs.NullOffset, broker.Cursor(subject, prj1))
+		req.Equal(istructs.Offset(6), broker.Cursor(subject, prj2))
+	})
+}
+
+func TestCursorsEviction(t *testing.T) {
+	req := require.New(t)
+
+	quotasExample := in10n.Quotas{
+		Channels:               10,
+		ChannelsPerSubject:     10,
+		Subsciptions:           10,
+		SubsciptionsPerSubject: 10,
+	}
+	now := time.Now()
+	broker, cleanup := NewN10nBroker(quotasExample, func() time.Time { return now })
+	defer cleanup()
+
+	var subject istructs.SubjectLogin = "paa"
+	projectionKey := in10n.ProjectionKey{
+		App:        istructs.AppQName_test1_app1,
+		Projection: appdef.NewQName("test", "restaurant"),
+		WS:         istructs.WSID(1),
+	}
+
+	// deliver subscribes the new channel to the projection, waits for the offset and returns the channel
+	deliver := func(offset istructs.Offset) in10n.ChannelID {
+		channelID, err := broker.NewChannel(subject, 24*time.Hour)
+		req.NoError(err)
+		req.NoError(broker.SubscribeFrom(channelID, projectionKey, istructs.NullOffset))
+		broker.Update(projectionKey, offset)
+		cb := &callbackMock{data: make(chan UpdateUnit, 10)}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			broker.WatchChannel(ctx, channelID, cb.updatesMock)
+			close(done)
+		}()
+		req.Equal(UpdateUnit{Projection: projectionKey, Offset: offset}, <-cb.data)
+		req.Eventually(func() bool { return broker.Cursor(subject, projectionKey) == offset }, time.Second, time.Millisecond)
+		cancel()
+		<-done
+		return channelID
+	}
+
+	t.Run("Should evict the cursor on unsubscribe", func(t *testing.T) {
+		channelID, err := broker.NewChannel(subject, 24*time.Hour)
+		req.NoError(err)
+		deliver(1)
+		req.NoError(broker.SubscribeFrom(channelID, projectionKey, istructs.NullOffset))
+		req.NoError(broker.Unsubscribe(channelID, projectionKey))
+		req.Equal(istructs.NullOffset, broker.Cursor(subject, projectionKey))
+	})
+
+	t.Run("Should evict the expired cursor", func(t *testing.T) {
+		deliver(2)
+		now = now.Add(cursorTTL)
+		req.Equal(istructs.NullOffset, broker.Cursor(subject, projectionKey))
+
+		_, err := broker.NewChannel(subject, 24*time.Hour)
+		req.NoError(err)
+		broker.RLock()
+		defer broker.RUnlock()
+		req.Empty(broker.cursorsBySubject)
+	})
+}
+
+func TestRowsSubscriptions(t *testing.T) {
+	req := require.New(t)
+
+	quotasExample := in10n.Quotas{
+		Channels:               10,
+		ChannelsPerSubject:     10,
+		Subsciptions:           10,
+		SubsciptionsPerSubject: 10,
+	}
+	broker, cleanup := NewN10nBroker(quotasExample, time.Now)
+	defer cleanup()
+
+	projectionKey := in10n.ProjectionKey{
+		App:        istructs.AppQName_test1_app1,
+		Projection: appdef.NewQName("test", "restaurant"),
+		WS:         istructs.WSID(1),
+	}
+
+	// watch creates the channel subscribed to the projection and watches it until the returned cancel is called
+	watch := func(rows bool) (updates chan UpdateUnit, cancel func()) {
+		channelID, err := broker.NewChannel("paa", 24*time.Hour)
+		req.NoError(err)
+		req.NoError(broker.Subscribe(channelID, projectionKey))
+		updates = make(chan UpdateUnit, 10)
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			if rows {
+				broker.WatchChannelRows(ctx, channelID, func(projection in10n.ProjectionKey, offset istructs.Offset, rows []byte) {
+					updates <- UpdateUnit{Projection: projection, Offset: offset, Rows: rows}
+				})
+			} else {
+				cb := &callbackMock{data: updates}
+				broker.WatchChannel(ctx, channelID, cb.updatesMock)
+			}
+			close(done)
+		}()
+		if rows {
+			req.Eventually(func() bool {
+				broker.RLock()
+				defer broker.RUnlock()
+				return broker.channels[channelID].rows
+			}, time.Second, time.Millisecond)
+		}
+		return updates, func() {
+			cancelCtx()
+			<-done
+		}
+	}
+
+	rowsCalls := 0
+	rows := func(data string) func(int) []byte {
+		return func(maxSize int) []byte {
+			req.Equal(maxRowsSize, maxSize)
+			rowsCalls++
+			return []byte(data)
+		}
+	}
+
+	t.Run("Should not build rows if there are no rows watchers", func(t *testing.T) {
+		updates, cancel := watch(false)
+		defer cancel()
+		broker.UpdateRows(projectionKey, 2, rows(`[2]`))
+		req.Equal(UpdateUnit{Projection: projectionKey, Offset: 2}, <-updates)
+		req.Zero(rowsCalls)
+	})
+
+	updates, cancel := watch(true)
+	defer cancel()
+
+	t.Run("Should deliver the offset only if the previous offset is not delivered", func(t *testing.T) {
+		broker.UpdateRows(projectionKey, 3, rows(`[3]`))
+		req.Equal(UpdateUnit{Projection: projectionKey, Offset: 3}, <-updates)
+		req.Equal(1, rowsCalls)
+	})
+
+	t.Run("Should deliver rows with the offset", func(t *testing.T) {
+		broker.UpdateRows(projectionKey, 4, rows(`[4]`))
+		req.Equal(UpdateUnit{Projection: projectionKey, Offset: 4, Rows: []byte(`[4]`)}, <-updates)
+	})
+
+	t.Run("Should deliver the offset only if rows are unknown", func(t *testing.T) {
+		broker.Update(projectionKey, 5)
+		req.Equal(UpdateUnit{Projection: projectionKey, Offset: 5}, <-updates)
+	})
+
+	t.Run("Should deliver the offset only if rows are too large", func(t *testing.T) {
+		broker.UpdateRows(projectionKey, 6, rows(`[`+strings.Repeat(`6,`, maxRowsSize/2)+`6]`))
+		req.Equal(UpdateUnit{Projection: projectionKey, Offset: 6}, <-updates)
+	})
+}
@@ -16,4 +16,5 @@ import (
 type UpdateUnit struct {
 	Projection in10n.ProjectionKey
 	Offset     istructs.Offset
+	Rows       []byte
 }
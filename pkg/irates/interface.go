@@ -45,7 +45,9 @@ type BucketKey struct {
 	RateLimitName string
 	RemoteAddr    string
 	App           istructs.AppQName
+	Partition     istructs.PartitionID
 	Workspace     istructs.WSID
+	User          string
 	QName         appdef.QName
 	ID            istructs.RecordID
 }
@@ -4,7 +4,15 @@
 
 package bbolt
 
+import "time"
+
 const (
 	rwxrwxrwx = 0777
 	rw_rw_rw_ = 0666
 )
+
+// how often expired records are removed
+const sweepInterval = time.Second
+
+// size of the expiration time (unix nanoseconds) in ttlIndexBucket keys
+const expirationSize = 8
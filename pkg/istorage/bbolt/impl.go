@@ -9,18 +9,28 @@ package bbolt
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
+	"github.com/untillpro/goutils/logger"
 	bolt "go.etcd.io/bbolt"
 
 	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/internal/sweepers"
 )
 
 type appStorageFactory struct {
 	bboltParams ParamsType
+	sweepers    *sweepers.Sweepers
 }
 
+// istorage.IStoppable.StopGoroutines
+func (p *appStorageFactory) StopGoroutines() {
+	p.sweepers.Stop()
+}
 func (p *appStorageFactory) AppStorage(appName istorage.SafeAppName) (s istorage.IAppStorage, err error) {
 	dbName := filepath.Join(p.bboltParams.DBDir, appName.String()+".db")
 	_, err = os.Stat(dbName)
@@ -32,7 +42,23 @@ func (p *appStorageFactory) AppStorage(appName istorage.SafeAppName) (s istorage
 		// notest
 		return nil, err
 	}
-	return &appStorageType{db}, nil
+	storage := &appStorageType{db: db, sweepers: p.sweepers}
+	// records with TTL could be written before the restart
+	expiring := false
+	if err = db.View(func(tx *bolt.Tx) error {
+		if idx := tx.Bucket(ttlIndexBucket); idx != nil {
+			k, _ := idx.Cursor().First()
+			expiring = k != nil
+		}
+		return nil
+	}); err != nil {
+		// notest
+		return nil, err
+	}
+	if expiring {
+		storage.startSweeper()
+	}
+	return storage, nil
 }
 
 func (p *appStorageFactory) Init(appName istorage.SafeAppName) error {
@@ -78,7 +104,10 @@ func unSafeKey(value []byte) []byte {
 
 // implemetation for istorage.IAppStorage.
 type appStorageType struct {
-	db *bolt.DB
+	db       *bolt.DB
+	sweepers *sweepers.Sweepers
+	sweeping bool
+	lock     sync.Mutex
 }
 
 // istorage.IAppStorage.Put(pKey []byte, cCols []byte, value []byte) (err error)
@@ -89,18 +118,30 @@ func (s *appStorageType) Put(pKey []byte, cCols []byte, value []byte) (err error
 			// notest
 			return e
 		}
-		return b.Put(safeKey(cCols), unSafeKey(value))
+		if e = b.Put(safeKey(cCols), unSafeKey(value)); e != nil {
+			return e
+		}
+		return resetTTL(tx, pKey, cCols)
 	})
 	return err
 }
 
 // istorage.IAppStorage.PutBatch(items []BatchItem) (err error)
 func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
+	expiring := false
 	err = s.db.Update(func(tx *bolt.Tx) error {
 
 		for i := 0; i < len(items); i++ {
 
 			PKey := items[i].PKey
+			if items[i].Deleted {
+				if e := deleteRecord(tx, PKey, items[i].CCols); e != nil {
+					// notest
+					return e
+				}
+				continue
+			}
+
 			b, e := tx.CreateBucketIfNotExists(PKey)
 			if e != nil {
 				// notest
@@ -111,16 +152,37 @@ func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
 			if e != nil {
 				return e
 			}
+
+			if items[i].TTL == 0 {
+				e = resetTTL(tx, PKey, items[i].CCols)
+			} else {
+				e = setTTL(tx, PKey, items[i].CCols, time.Now().Add(items[i].TTL))
+				expiring = true
+			}
+			if e != nil {
+				// notest
+				return e
+			}
 		}
 
 		return nil
 	})
 
+	if err == nil && expiring {
+		s.startSweeper()
+	}
+
 	return err
 }
 
 // istorage.IAppStorage.Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error)
 func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	ok, _, err = s.TTLGet(pKey, cCols, data)
+	return ok, err
+}
+
+// istorage.IAppStorage.TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error)
+func (s *appStorageType) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error) {
 	*data = (*data)[0:0]
 
 	err = s.db.View(func(tx *bolt.Tx) error {
@@ -134,12 +196,14 @@ func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool,
 		if v == nil {
 			return nil
 		}
+		if ttl, ok = timeToLive(tx.Bucket(ttlBucket), pKey, cCols, time.Now()); !ok {
+			return nil
+		}
 		*data = append(*data, v...)
-		ok = true
 		return nil
 	})
 
-	return ok, err
+	return ok, ttl, err
 }
 
 // istorage.IAppStorage.Read(ctx context.Context, pKey []byte, startCCols []byte, finishCCols []byte, cb ReadCallback) (err error)
@@ -158,6 +222,8 @@ func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, fini
 		if bucket == nil {
 			return nil
 		}
+		ttls := tx.Bucket(ttlBucket)
+		now := time.Now()
 
 		var (
 			k []byte
@@ -179,7 +245,7 @@ func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, fini
 				return nil
 			}
 
-			if cb != nil {
+			if _, alive := timeToLive(ttls, pKey, unSafeKey(k), now); cb != nil && alive {
 				e = cb(unSafeKey(k), unSafeKey(v))
 				if e != nil {
 					return e
@@ -194,6 +260,34 @@ func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, fini
 	return err
 }
 
+// istorage.IAppStorageScanner.ScanPartitions(ctx context.Context, cb ScanPartitionsCallback) (err error)
+func (s *appStorageType) ScanPartitions(ctx context.Context, cb istorage.ScanPartitionsCallback) (err error) {
+	// bucket names are collected first, so cb is free to read the storage
+	pKeys := make([][]byte, 0)
+	err = s.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			if !bytes.Equal(name, ttlBucket) && !bytes.Equal(name, ttlIndexBucket) {
+				pKeys = append(pKeys, bytes.Clone(name))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		// notest
+		return err
+	}
+
+	for _, pKey := range pKeys {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err = cb(pKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // istorage.IAppStorage.GetBatch(pKey []byte, items []GetBatchItem) (err error)
 func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
 	err = s.db.View(func(tx *bolt.Tx) error {
@@ -205,9 +299,17 @@ func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (e
 			}
 			return nil
 		}
+		ttls := tx.Bucket(ttlBucket)
+		now := time.Now()
 		for i := 0; i < len(items); i++ {
 			v := bucket.Get(safeKey(items[i].CCols))
 			items[i].Ok = v != nil
+			if items[i].Ok {
+				items[i].TTL, items[i].Ok = timeToLive(ttls, pKey, items[i].CCols, now)
+			}
+			if !items[i].Ok {
+				v = nil
+			}
 			*items[i].Data = append((*items[i].Data)[0:0], v...)
 		}
 		return nil
@@ -215,3 +317,159 @@ func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (e
 
 	return err
 }
+
+// Expiration times of records written with TTL are kept in ttlBucket by ttlKey(),
+// ttlIndexBucket keeps the same records ordered by expiration time to be removed by sweeper
+var (
+	// partitions which keys start with NullQNameID are never used by istructs
+	ttlBucket      = []byte("\x00\x00ttl")
+	ttlIndexBucket = []byte("\x00\x00ttl-index")
+)
+
+// length of the partition key is the prefix, so keys of different partitions do not overlap
+func ttlKey(pKey []byte, cCols []byte) []byte {
+	key := make([]byte, 0, binary.MaxVarintLen64+len(pKey)+len(cCols))
+	key = binary.AppendUvarint(key, uint64(len(pKey)))
+	key = append(key, pKey...)
+	return append(key, cCols...)
+}
+
+func parseTTLKey(key []byte) (pKey []byte, cCols []byte) {
+	pKeyLen, n := binary.Uvarint(key)
+	return key[n : n+int(pKeyLen)], key[n+int(pKeyLen):]
+}
+
+func expirationToBytes(at time.Time) []byte {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, expirationSize), uint64(at.UnixNano()))
+}
+
+func expirationFromBytes(b []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b)))
+}
+
+// returns time left to live and false if the record has expired already, zero TTL means the record never expires
+func timeToLive(ttls *bolt.Bucket, pKey []byte, cCols []byte, now time.Time) (ttl time.Duration, alive bool) {
+	if ttls == nil {
+		return 0, true
+	}
+	at := ttls.Get(ttlKey(pKey, cCols))
+	if at == nil {
+		return 0, true
+	}
+	ttl = expirationFromBytes(at).Sub(now)
+	return ttl, ttl > 0
+}
+
+func deleteRecord(tx *bolt.Tx, pKey []byte, cCols []byte) error {
+	if b := tx.Bucket(pKey); b != nil {
+		if err := b.Delete(safeKey(cCols)); err != nil {
+			// notest
+			return err
+		}
+	}
+	return resetTTL(tx, pKey, cCols)
+}
+
+func setTTL(tx *bolt.Tx, pKey []byte, cCols []byte, expireAt time.Time) error {
+	if err := resetTTL(tx, pKey, cCols); err != nil {
+		// notest
+		return err
+	}
+	ttls, err := tx.CreateBucketIfNotExists(ttlBucket)
+	if err != nil {
+		// notest
+		return err
+	}
+	idx, err := tx.CreateBucketIfNotExists(ttlIndexBucket)
+	if err != nil {
+		// notest
+		return err
+	}
+	key := ttlKey(pKey, cCols)
+	at := expirationToBytes(expireAt)
+	if err = ttls.Put(key, at); err != nil {
+		// notest
+		return err
+	}
+	return idx.Put(append(at, key...), []byte{})
+}
+
+func resetTTL(tx *bolt.Tx, pKey []byte, cCols []byte) error {
+	ttls := tx.Bucket(ttlBucket)
+	if ttls == nil {
+		return nil
+	}
+	key := ttlKey(pKey, cCols)
+	at := ttls.Get(key)
+	if at == nil {
+		return nil
+	}
+	idxKey := append(bytes.Clone(at), key...)
+	if err := ttls.Delete(key); err != nil {
+		// notest
+		return err
+	}
+	return tx.Bucket(ttlIndexBucket).Delete(idxKey)
+}
+
+func (s *appStorageType) startSweeper() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.sweeping {
+		s.sweeping = s.sweepers.Go(sweepInterval, s.sweepOnce)
+	}
+}
+
+func (s *appStorageType) sweepOnce() (more bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	more, err := s.sweep()
+	if err != nil {
+		// records are not returned after expiration anyway, sweeper will be restarted by the next write with TTL
+		logger.Error("failed to remove expired records:", err)
+	}
+	s.sweeping = more && err == nil
+	return s.sweeping
+}
+
+// removes expired records, returns true if there are records with TTL left
+func (s *appStorageType) sweep() (more bool, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		idx := tx.Bucket(ttlIndexBucket)
+		if idx == nil {
+			return nil
+		}
+		now := time.Now()
+		expired := make([][]byte, 0)
+		cr := idx.Cursor()
+		for k, _ := cr.First(); k != nil; k, _ = cr.Next() {
+			if expirationFromBytes(k[:expirationSize]).After(now) {
+				more = true
+				break
+			}
+			// keys must not be used after deletion
+			expired = append(expired, bytes.Clone(k))
+		}
+		ttls := tx.Bucket(ttlBucket)
+		for _, k := range expired {
+			key := k[expirationSize:]
+			pKey, cCols := parseTTLKey(key)
+			if b := tx.Bucket(pKey); b != nil {
+				if e := b.Delete(safeKey(cCols)); e != nil {
+					// notest
+					return e
+				}
+			}
+			if e := ttls.Delete(key); e != nil {
+				// notest
+				return e
+			}
+			if e := idx.Delete(k); e != nil {
+				// notest
+				return e
+			}
+		}
+		return nil
+	})
+	return more, err
+}
@@ -9,8 +9,10 @@ package bbolt
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
 
 	"github.com/voedger/voedger/pkg/istorage"
 	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
@@ -96,3 +98,38 @@ func Test_PutGet(t *testing.T) {
 	require.True(ok)
 	require.Equal("Molchanovsky Dmitry Anatolyevich", string(value))
 }
+
+func Test_Sweeper(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	factory := Provide(params)
+	storageProvider := istorageimpl.Provide(factory)
+
+	appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	require.NoError(appStorage.PutBatch([]istorage.BatchItem{
+		{PKey: []byte("pk"), CCols: []byte("expiring"), Value: []byte("1"), TTL: time.Millisecond},
+		{PKey: []byte("pk"), CCols: []byte("eternal"), Value: []byte("2")},
+	}))
+
+	s := appStorage.(*appStorageType)
+	require.Eventually(func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return !s.sweeping
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(s.db.View(func(tx *bolt.Tx) error {
+		require.Nil(tx.Bucket([]byte("pk")).Get([]byte("expiring")))
+		require.NotNil(tx.Bucket([]byte("pk")).Get([]byte("eternal")))
+		k, _ := tx.Bucket(ttlBucket).Cursor().First()
+		require.Nil(k)
+		k, _ = tx.Bucket(ttlIndexBucket).Cursor().First()
+		require.Nil(k)
+		return nil
+	}))
+}
@@ -7,10 +7,12 @@ package bbolt
 
 import (
 	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/internal/sweepers"
 )
 
 func Provide(params ParamsType) istorage.IAppStorageFactory {
 	return &appStorageFactory{
 		bboltParams: params,
+		sweepers:    sweepers.New(),
 	}
 }
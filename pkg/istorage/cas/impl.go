@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/untillpro/goutils/logger"
@@ -153,6 +154,11 @@ func newStorage(cluster *gocql.ClusterConfig, keyspace string) (storage istorage
 	}, nil
 }
 
+// Cassandra TTL is in seconds, the record must not live forever if TTL is less than a second
+func ttlSeconds(ttl time.Duration) int {
+	return int((ttl + time.Second - 1) / time.Second)
+}
+
 func safeCcols(value []byte) []byte {
 	if value == nil {
 		return []byte{}
@@ -174,7 +180,17 @@ func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
 	batch := s.session.NewBatch(gocql.LoggedBatch)
 	batch.SetConsistency(gocql.Quorum)
 	stmt := fmt.Sprintf("insert into %s.values (p_key, c_col, value) values (?,?,?)", s.keyspace)
+	ttlStmt := stmt + " using ttl ?"
+	delStmt := fmt.Sprintf("delete from %s.values where p_key=? and c_col=?", s.keyspace)
 	for _, item := range items {
+		if item.Deleted {
+			batch.Query(delStmt, item.PKey, safeCcols(item.CCols))
+			continue
+		}
+		if item.TTL > 0 {
+			batch.Query(ttlStmt, item.PKey, safeCcols(item.CCols), item.Value, ttlSeconds(item.TTL))
+			continue
+		}
 		batch.Query(stmt, item.PKey, safeCcols(item.CCols), item.Value)
 	}
 	return s.session.ExecuteBatch(batch)
@@ -229,6 +245,27 @@ func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, fini
 	return scanViewQuery(ctx, q, cb)
 }
 
+// istorage.IAppStorageScanner.ScanPartitions(ctx context.Context, cb ScanPartitionsCallback) (err error)
+func (s *appStorageType) ScanPartitions(ctx context.Context, cb istorage.ScanPartitionsCallback) (err error) {
+	// distinct partition keys are read by pages in token order
+	q := s.session.Query(fmt.Sprintf("select distinct p_key from %s.values", s.keyspace)).Consistency(gocql.Quorum)
+	scanner := q.Iter().Scanner()
+	sc := scannerCloser(scanner)
+	for scanner.Next() {
+		pKey := make([]byte, 0)
+		if err = scanner.Scan(&pKey); err != nil {
+			return sc(err)
+		}
+		if err = cb(pKey); err != nil {
+			return sc(err)
+		}
+		if ctx.Err() != nil {
+			return sc(nil)
+		}
+	}
+	return sc(nil)
+}
+
 func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
 	*data = (*data)[0:0]
 	q := fmt.Sprintf("select value from %s.values where p_key=? and c_col=?", s.keyspace)
@@ -241,18 +278,32 @@ func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool,
 	return err == nil, err
 }
 
+func (s *appStorageType) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error) {
+	*data = (*data)[0:0]
+	secondsLeft := 0 // null if the record never expires
+	q := fmt.Sprintf("select value, ttl(value) from %s.values where p_key=? and c_col=?", s.keyspace)
+	err = s.session.Query(q, pKey, safeCcols(cCols)).
+		Consistency(gocql.Quorum).
+		Scan(data, &secondsLeft)
+	if errors.Is(err, gocql.ErrNotFound) {
+		return false, 0, nil
+	}
+	return err == nil, time.Duration(secondsLeft) * time.Second, err
+}
+
 func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
 	ccToIdx := make(map[string][]int)
 	values := make([]interface{}, 0, len(items)+1)
 	values = append(values, pKey)
 
 	stmt := strings.Builder{}
-	stmt.WriteString("select c_col, value from ")
+	stmt.WriteString("select c_col, value, ttl(value) from ")
 	stmt.WriteString(s.keyspace)
 	stmt.WriteString(".values where p_key=? and ")
 	stmt.WriteString("c_col in (")
 	for i, item := range items {
 		items[i].Ok = false
+		items[i].TTL = 0
 		values = append(values, item.CCols)
 		ccToIdx[string(item.CCols)] = append(ccToIdx[string(item.CCols)], i)
 		stmt.WriteRune('?')
@@ -271,7 +322,8 @@ func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (e
 	for scanner.Next() {
 		ccols := make([]byte, 0)
 		value := make([]byte, 0)
-		err = scanner.Scan(&ccols, &value)
+		secondsLeft := 0 // null if the record never expires
+		err = scanner.Scan(&ccols, &value, &secondsLeft)
 		if err != nil {
 			return sc(err)
 		}
@@ -279,6 +331,7 @@ func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (e
 		if ok {
 			for _, i := range ii {
 				items[i].Ok = true
+				items[i].TTL = time.Duration(secondsLeft) * time.Second
 				*items[i].Data = append((*items[i].Data)[0:0], value...)
 			}
 		}
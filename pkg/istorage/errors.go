@@ -10,4 +10,5 @@ var (
 	ErrStorageAlreadyExists = errors.New("storage already exists")
 	ErrStorageDoesNotExist  = errors.New("storage does not exist")
 	ErrNoSafeAppName        = errors.New("no safe app name")
+	ErrScanNotSupported     = errors.New("storage is not able to enumerate partitions")
 )
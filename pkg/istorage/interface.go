@@ -7,6 +7,7 @@ package istorage
 
 import (
 	"context"
+	"time"
 
 	"github.com/voedger/voedger/pkg/istructs"
 )
@@ -20,6 +21,14 @@ type IAppStorageProvider interface {
 	AppStorage(appName istructs.AppQName) (structs IAppStorage, err error)
 }
 
+// Implemented by IAppStorageProvider which is able to look up the existing application storage without initializing it.
+// Used by tools which read the storage, e.g. storage migration and backup
+type IAppStorageLookup interface {
+	// Returns storage of the application if it is initialized, ErrStorageDoesNotExist otherwise
+	// @ConcurrentAccess
+	ExistingAppStorage(appName istructs.AppQName) (storage IAppStorage, err error)
+}
+
 // implemented by a certain driver
 type IAppStorageFactory interface {
 	// returns IAppStorage for an existing storage
@@ -31,6 +40,12 @@ type IAppStorageFactory interface {
 	Init(appName SafeAppName) error
 }
 
+// Implemented by IAppStorageFactory of drivers which run background goroutines, e.g. sweepers of expired records
+type IStoppable interface {
+	// Stops background goroutines of all storages created by the factory and waits for them to finish
+	StopGoroutines()
+}
+
 type IAppStorage interface {
 	// cCols - clustering columns
 	// len(cCols) may be 0 (nil or empty array)
@@ -38,9 +53,13 @@ type IAppStorage interface {
 	// Example: PRIMARY KEY(wsid, qname_id, id)
 	//   Clusterting columns: qname_id, id
 	//   qname_id bytes must be written first, then id bytes
+	// Record written by Put never expires, even if it was written with TTL before
 	// @ConcurrentAccess
 	Put(pKey []byte, cCols []byte, value []byte) (err error)
 
+	// Records with zero TTL never expire, others are not returned by Get, GetBatch and Read after TTL has elapsed
+	// Expired records are physically removed by the storage itself (Cassandra TTL, background sweeper for other drivers)
+	// Records of items with Deleted flag are removed
 	PutBatch(items []BatchItem) (err error)
 
 	// len(cCols) may be 0, in this case the record which was written with zero len(cCols) will be returned
@@ -48,9 +67,13 @@ type IAppStorage interface {
 	// @ConcurrentAccess
 	Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error)
 
+	// Same as Get, also returns time left to live of the found record, zero means the record never expires
+	// @ConcurrentAccess
+	TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error)
+
 	// get and appends result to items[i].Data
 	// items[i].Ok==false means record is not found
-	// items[i].Ok & Data are undefined in case of error
+	// items[i].Ok, Data & TTL are undefined in case of error
 	GetBatch(pKey []byte, items []GetBatchItem) (err error)
 
 	// startCCols can be empty (nil or zero len), in this case reads from start of partition.
@@ -59,17 +82,38 @@ type IAppStorage interface {
 	Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb ReadCallback) (err error)
 }
 
+// Implemented by drivers which are able to enumerate partitions of the application storage.
+// istructs never enumerates partitions, this is used by tools which process the whole storage, e.g. storage migration
+type IAppStorageScanner interface {
+	// Calls cb for each partition key of the storage, order of partitions is driver specific.
+	// Partitions reserved by the driver itself (e.g. to keep records TTL) are not enumerated
+	// @ConcurrentAccess
+	ScanPartitions(ctx context.Context, cb ScanPartitionsCallback) (err error)
+}
+
 // ccols and viewRecord are temporary internal values, must NOT be changed
 type ReadCallback func(ccols []byte, viewRecord []byte) (err error)
 
+// pKey is temporary internal value, must NOT be changed
+type ScanPartitionsCallback func(pKey []byte) (err error)
+
 type BatchItem struct {
 	PKey  []byte
 	CCols []byte
 	Value []byte
+
+	// Time to live, zero means the record never expires
+	TTL time.Duration
+
+	// Record is removed, Value and TTL are ignored
+	Deleted bool
 }
 
 type GetBatchItem struct {
 	CCols []byte
 	Ok    bool
 	Data  *[]byte
+
+	// Time left to live of the found record, zero means the record never expires
+	TTL time.Duration
 }
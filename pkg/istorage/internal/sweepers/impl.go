@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sweepers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Sweepers runs goroutines which remove expired records of the storages created by the same factory
+type Sweepers struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	lock    sync.Mutex
+	wg      sync.WaitGroup
+	stopped bool
+}
+
+func New() *Sweepers {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Sweepers{ctx: ctx, cancel: cancel}
+}
+
+// Go calls sweep every interval until sweep returns false or sweepers are stopped.
+// Returns false if sweepers are stopped already, the goroutine is not started then
+func (s *Sweepers) Go(interval time.Duration, sweep func() (more bool)) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped {
+		return false
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-timer.C:
+			}
+			if !sweep() {
+				return
+			}
+			timer.Reset(interval)
+		}
+	}()
+	return true
+}
+
+// Stop stops all goroutines and waits for them to finish. Sweepers can not be started after stop
+func (s *Sweepers) Stop() {
+	s.lock.Lock()
+	s.stopped = true
+	s.lock.Unlock()
+	s.cancel()
+	s.wg.Wait()
+}
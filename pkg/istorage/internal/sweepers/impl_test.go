@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sweepers
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepers(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("Should sweep until sweep returns false", func(t *testing.T) {
+		s := New()
+		defer s.Stop()
+		calls := atomic.Int32{}
+		require.True(s.Go(time.Millisecond, func() bool { return calls.Add(1) < 3 }))
+		require.Eventually(func() bool { return calls.Load() == 3 }, time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		require.EqualValues(3, calls.Load())
+	})
+
+	t.Run("Should stop sweeping on stop", func(t *testing.T) {
+		s := New()
+		calls := atomic.Int32{}
+		require.True(s.Go(time.Millisecond, func() bool { calls.Add(1); return true }))
+		require.Eventually(func() bool { return calls.Load() > 0 }, time.Second, time.Millisecond)
+		s.Stop()
+		stoppedAt := calls.Load()
+		time.Sleep(10 * time.Millisecond)
+		require.Equal(stoppedAt, calls.Load())
+
+		require.False(s.Go(time.Millisecond, func() bool { calls.Add(1); return true }))
+	})
+}
@@ -0,0 +1,17 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package leveldb
+
+import "time"
+
+const (
+	rwxrwxrwx = 0777
+)
+
+// how often expired records are removed
+const sweepInterval = time.Second
+
+// size of the expiration time (unix nanoseconds) in ttlIndexPKey clustering columns
+const expirationSize = 8
@@ -0,0 +1,406 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package leveldb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	lvl "github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/untillpro/goutils/logger"
+
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/internal/sweepers"
+)
+
+type appStorageFactory struct {
+	params   ParamsType
+	sweepers *sweepers.Sweepers
+}
+
+// istorage.IStoppable.StopGoroutines
+func (p *appStorageFactory) StopGoroutines() {
+	p.sweepers.Stop()
+}
+func (p *appStorageFactory) AppStorage(appName istorage.SafeAppName) (s istorage.IAppStorage, err error) {
+	dbDir := filepath.Join(p.params.DBDir, appName.String())
+	// OpenFile creates the directory even if the db is missing
+	if _, err = os.Stat(dbDir); os.IsNotExist(err) {
+		return nil, istorage.ErrStorageDoesNotExist
+	}
+	db, err := lvl.OpenFile(dbDir, &opt.Options{ErrorIfMissing: true})
+	if err != nil {
+		// notest
+		return nil, err
+	}
+	storage := &appStorageType{db: db, sweepers: p.sweepers}
+	// records with TTL could be written before the restart
+	it := db.NewIterator(util.BytesPrefix(partPrefix(ttlIndexPKey)), nil)
+	defer it.Release()
+	if it.First() {
+		storage.expiring.Store(true)
+		storage.startSweeper()
+	}
+	return storage, it.Error()
+}
+
+func (p *appStorageFactory) Init(appName istorage.SafeAppName) error {
+	dbDir := filepath.Join(p.params.DBDir, appName.String())
+	_, err := os.Stat(dbDir)
+	if err == nil {
+		return istorage.ErrStorageAlreadyExists
+	}
+	if !os.IsNotExist(err) {
+		// notest
+		return err
+	}
+	if err = os.MkdirAll(p.params.DBDir, rwxrwxrwx); err != nil {
+		// notest
+		return err
+	}
+	db, err := lvl.OpenFile(dbDir, &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		// notest
+		return err
+	}
+	return db.Close()
+}
+
+// writes are synced to be as durable as other drivers
+var syncWrite = &opt.WriteOptions{Sync: true}
+
+// implemetation for istorage.IAppStorage.
+// All partitions are kept in the single sorted key space, the key is the partition prefix followed by clustering columns
+type appStorageType struct {
+	db *lvl.DB
+	// true if records with TTL were ever written, TTL is not looked up otherwise
+	expiring atomic.Bool
+	// guards writes of records with TTL against sweeper and writes without TTL against the first write with TTL
+	lock     sync.RWMutex
+	sweepers *sweepers.Sweepers
+	sweeping bool
+}
+
+// partition prefix is the length of the partition key followed by the partition key itself, so prefixes of different partitions do not overlap
+func partPrefix(pKey []byte) []byte {
+	prefix := make([]byte, 0, binary.MaxVarintLen64+len(pKey))
+	prefix = binary.AppendUvarint(prefix, uint64(len(pKey)))
+	return append(prefix, pKey...)
+}
+
+func storageKey(pKey []byte, cCols []byte) []byte {
+	return append(partPrefix(pKey), cCols...)
+}
+
+// istorage.IAppStorage.Put(pKey []byte, cCols []byte, value []byte) (err error)
+func (s *appStorageType) Put(pKey []byte, cCols []byte, value []byte) (err error) {
+	return s.PutBatch([]istorage.BatchItem{{PKey: pKey, CCols: cCols, Value: value}})
+}
+
+// istorage.IAppStorage.PutBatch(items []BatchItem) (err error)
+func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
+	batch := new(lvl.Batch)
+	expiring := s.expiring.Load()
+	for _, item := range items {
+		if item.Deleted {
+			batch.Delete(storageKey(item.PKey, item.CCols))
+		} else {
+			batch.Put(storageKey(item.PKey, item.CCols), item.Value)
+		}
+		expiring = expiring || (!item.Deleted && item.TTL > 0)
+	}
+	if !expiring {
+		// the first batch with TTL could be written concurrently, so its TTL entries could be left for the keys written here
+		s.lock.RLock()
+		if !s.expiring.Load() {
+			defer s.lock.RUnlock()
+			return s.db.Write(batch, syncWrite)
+		}
+		s.lock.RUnlock()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// later items overwrite TTL of earlier ones with the same key
+	expireAt := make(map[string][]byte, len(items))
+	for _, item := range items {
+		if !item.Deleted && item.TTL > 0 {
+			expireAt[string(storageKey(item.PKey, item.CCols))] = expirationToBytes(time.Now().Add(item.TTL))
+		} else {
+			expireAt[string(storageKey(item.PKey, item.CCols))] = nil
+		}
+	}
+	for key, at := range expireAt {
+		prevAt, err := s.db.Get(ttlEntryKey([]byte(key)), nil)
+		switch {
+		case err == nil:
+			batch.Delete(ttlIndexKey(prevAt, []byte(key)))
+			batch.Delete(ttlEntryKey([]byte(key)))
+		case !errors.Is(err, lvl.ErrNotFound):
+			// notest
+			return err
+		}
+		if at != nil {
+			batch.Put(ttlEntryKey([]byte(key)), at)
+			batch.Put(ttlIndexKey(at, []byte(key)), []byte{})
+		}
+	}
+	if err = s.db.Write(batch, syncWrite); err != nil {
+		return err
+	}
+	s.expiring.Store(true)
+	if !s.sweeping {
+		s.sweeping = s.sweepers.Go(sweepInterval, s.sweepOnce)
+	}
+	return nil
+}
+
+// istorage.IAppStorage.Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error)
+func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	ok, _, err = s.TTLGet(pKey, cCols, data)
+	return ok, err
+}
+
+// istorage.IAppStorage.TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error)
+func (s *appStorageType) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error) {
+	*data = (*data)[0:0]
+	snapshot, err := s.db.GetSnapshot()
+	if err != nil {
+		// notest
+		return false, 0, err
+	}
+	defer snapshot.Release()
+
+	key := storageKey(pKey, cCols)
+	v, err := snapshot.Get(key, nil)
+	if err != nil {
+		if errors.Is(err, lvl.ErrNotFound) {
+			return false, 0, nil
+		}
+		return false, 0, err
+	}
+	if ttl, ok, err = s.timeToLive(snapshot, key, time.Now()); !ok || err != nil {
+		return false, 0, err
+	}
+	*data = append(*data, v...)
+	return true, ttl, nil
+}
+
+// istorage.IAppStorage.Read(ctx context.Context, pKey []byte, startCCols []byte, finishCCols []byte, cb ReadCallback) (err error)
+func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+
+	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
+		return nil // absurd range
+	}
+
+	prefix := partPrefix(pKey)
+	rng := util.BytesPrefix(prefix)
+	if len(startCCols) > 0 {
+		rng.Start = storageKey(pKey, startCCols)
+	}
+	if len(finishCCols) > 0 {
+		rng.Limit = storageKey(pKey, finishCCols)
+	}
+
+	snapshot, err := s.db.GetSnapshot()
+	if err != nil {
+		// notest
+		return err
+	}
+	defer snapshot.Release()
+
+	it := snapshot.NewIterator(rng, nil)
+	defer it.Release()
+
+	now := time.Now()
+	for it.Next() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		_, alive, err := s.timeToLive(snapshot, it.Key(), now)
+		if err != nil {
+			// notest
+			return err
+		}
+		if cb != nil && alive {
+			// iterator buffers are reused on Next()
+			if err = cb(bytes.Clone(it.Key()[len(prefix):]), bytes.Clone(it.Value())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return it.Error()
+}
+
+// istorage.IAppStorageScanner.ScanPartitions(ctx context.Context, cb ScanPartitionsCallback) (err error)
+func (s *appStorageType) ScanPartitions(ctx context.Context, cb istorage.ScanPartitionsCallback) (err error) {
+	snapshot, err := s.db.GetSnapshot()
+	if err != nil {
+		// notest
+		return err
+	}
+	defer snapshot.Release()
+
+	it := snapshot.NewIterator(nil, nil)
+	defer it.Release()
+
+	for ok := it.First(); ok; {
+		if ctx.Err() != nil {
+			return nil
+		}
+		key := it.Key()
+		l, n := binary.Uvarint(key)
+		if n <= 0 || uint64(len(key)-n) < l {
+			// notest
+			return fmt.Errorf("invalid storage key %x", key)
+		}
+		pKey := bytes.Clone(key[n : n+int(l)])
+		if !bytes.Equal(pKey, ttlPKey) && !bytes.Equal(pKey, ttlIndexPKey) {
+			if err = cb(pKey); err != nil {
+				return err
+			}
+		}
+		// skip the rest of the partition
+		if limit := util.BytesPrefix(partPrefix(pKey)).Limit; limit != nil {
+			ok = it.Seek(limit)
+		} else {
+			// notest: partition prefix of 0xFF bytes only is the last one
+			ok = false
+		}
+	}
+
+	return it.Error()
+}
+
+// istorage.IAppStorage.GetBatch(pKey []byte, items []GetBatchItem) (err error)
+func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	snapshot, err := s.db.GetSnapshot()
+	if err != nil {
+		// notest
+		return err
+	}
+	defer snapshot.Release()
+
+	prefix := partPrefix(pKey)
+	key := make([]byte, 0, len(prefix))
+	now := time.Now()
+	for i := range items {
+		key = append(append(key[:0], prefix...), items[i].CCols...)
+		v, err := snapshot.Get(key, nil)
+		if err != nil && !errors.Is(err, lvl.ErrNotFound) {
+			return err
+		}
+		items[i].Ok = err == nil
+		if items[i].Ok {
+			if items[i].TTL, items[i].Ok, err = s.timeToLive(snapshot, key, now); err != nil {
+				// notest
+				return err
+			}
+		}
+		if !items[i].Ok {
+			v = nil
+		}
+		*items[i].Data = append((*items[i].Data)[0:0], v...)
+	}
+	return nil
+}
+
+// Expiration times of records written with TTL are kept in the reserved ttlPKey partition by the record storage key,
+// ttlIndexPKey partition keeps the same records ordered by expiration time to be removed by sweeper
+var (
+	// partitions which keys start with NullQNameID are never used by istructs
+	ttlPKey      = []byte("\x00\x00ttl")
+	ttlIndexPKey = []byte("\x00\x00ttl-index")
+)
+
+func ttlEntryKey(key []byte) []byte {
+	return storageKey(ttlPKey, key)
+}
+
+func ttlIndexKey(at []byte, key []byte) []byte {
+	return storageKey(ttlIndexPKey, append(bytes.Clone(at), key...))
+}
+
+func expirationToBytes(at time.Time) []byte {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, expirationSize), uint64(at.UnixNano()))
+}
+
+func expirationFromBytes(b []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b)))
+}
+
+// returns time left to live and false if the record has expired already, zero TTL means the record never expires
+func (s *appStorageType) timeToLive(snapshot *lvl.Snapshot, key []byte, now time.Time) (ttl time.Duration, alive bool, err error) {
+	if !s.expiring.Load() {
+		return 0, true, nil
+	}
+	at, err := snapshot.Get(ttlEntryKey(key), nil)
+	if err != nil {
+		if errors.Is(err, lvl.ErrNotFound) {
+			return 0, true, nil
+		}
+		// notest
+		return 0, false, err
+	}
+	ttl = expirationFromBytes(at).Sub(now)
+	return ttl, ttl > 0, nil
+}
+
+func (s *appStorageType) startSweeper() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.sweeping {
+		s.sweeping = s.sweepers.Go(sweepInterval, s.sweepOnce)
+	}
+}
+
+func (s *appStorageType) sweepOnce() (more bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	more, err := s.sweep()
+	if err != nil {
+		// records are not returned after expiration anyway, sweeper will be restarted by the next write with TTL
+		logger.Error("failed to remove expired records:", err)
+	}
+	s.sweeping = more && err == nil
+	return s.sweeping
+}
+
+// removes expired records, returns true if there are records with TTL left
+func (s *appStorageType) sweep() (more bool, err error) {
+	prefix := partPrefix(ttlIndexPKey)
+	it := s.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer it.Release()
+
+	now := time.Now()
+	batch := new(lvl.Batch)
+	for it.Next() {
+		at := it.Key()[len(prefix) : len(prefix)+expirationSize]
+		if expirationFromBytes(at).After(now) {
+			more = true
+			break
+		}
+		key := it.Key()[len(prefix)+expirationSize:]
+		batch.Delete(key)
+		batch.Delete(ttlEntryKey(key))
+		batch.Delete(it.Key())
+	}
+	if err = it.Error(); err != nil {
+		// notest
+		return false, err
+	}
+	return more, s.db.Write(batch, syncWrite)
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package leveldb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	lvl "github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/internal/sweepers"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestBasicUsage(t *testing.T) {
+	params := prepareTestData()
+	defer cleanupTestData(params)
+	factory := Provide(params)
+	istorage.TechnologyCompatibilityKit(t, factory)
+}
+
+func prepareTestData() (params ParamsType) {
+	dbDir, err := os.MkdirTemp("", "leveldb")
+	if err != nil {
+		panic(err)
+	}
+	params.DBDir = dbDir
+	return
+}
+
+func cleanupTestData(params ParamsType) {
+	if params.DBDir != "" {
+		os.RemoveAll(params.DBDir)
+	}
+}
+
+func TestPartitionsDoNotOverlap(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	storageProvider := istorageimpl.Provide(Provide(params))
+	appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	// pKey+cCols of these records are equal
+	require.NoError(appStorage.Put([]byte("ab"), []byte("c"), []byte("ab-c")))
+	require.NoError(appStorage.Put([]byte("a"), []byte("bc"), []byte("a-bc")))
+
+	value := make([]byte, 0)
+	ok, err := appStorage.Get([]byte("ab"), []byte("c"), &value)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal("ab-c", string(value))
+
+	ccols := []string{}
+	require.NoError(appStorage.Read(context.Background(), []byte("a"), nil, nil, func(cCols []byte, _ []byte) error {
+		ccols = append(ccols, string(cCols))
+		return nil
+	}))
+	require.Equal([]string{"bc"}, ccols)
+}
+
+func TestReopen(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	factory := Provide(params)
+	san, err := istorage.NewSafeAppName(istructs.AppQName_test1_app1, func(name string) (bool, error) { return true, nil })
+	require.NoError(err)
+	require.NoError(factory.Init(san))
+
+	appStorage, err := factory.AppStorage(san)
+	require.NoError(err)
+	require.NoError(appStorage.PutBatch([]istorage.BatchItem{
+		{PKey: []byte("persons"), CCols: []byte("NNV"), Value: []byte("Nikitin Nikolay Valeryevich")},
+		{PKey: []byte("persons"), CCols: []byte("MDA"), Value: []byte("Molchanovsky Dmitry Anatolyevich")},
+	}))
+	require.NoError(appStorage.(*appStorageType).db.Close())
+
+	appStorage, err = factory.AppStorage(san)
+	require.NoError(err)
+	defer appStorage.(*appStorageType).db.Close()
+
+	value := make([]byte, 0)
+	ok, err := appStorage.Get([]byte("persons"), []byte("MDA"), &value)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal("Molchanovsky Dmitry Anatolyevich", string(value))
+}
+
+func TestSweeper(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	storageProvider := istorageimpl.Provide(Provide(params))
+	appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	require.NoError(appStorage.PutBatch([]istorage.BatchItem{
+		{PKey: []byte("pk"), CCols: []byte("expiring"), Value: []byte("1"), TTL: time.Millisecond},
+		{PKey: []byte("pk"), CCols: []byte("eternal"), Value: []byte("2")},
+	}))
+
+	s := appStorage.(*appStorageType)
+	require.Eventually(func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return !s.sweeping
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// only the eternal record is left, TTL partitions are empty
+	keys := []string{}
+	it := s.db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	require.NoError(it.Error())
+	require.Equal([]string{string(storageKey([]byte("pk"), []byte("eternal")))}, keys)
+}
+
+func TestPutConcurrentWithFirstTTLWrite(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+	sw := sweepers.New()
+	dbs := []*lvl.DB{}
+	defer func() {
+		sw.Stop()
+		for _, db := range dbs {
+			db.Close()
+		}
+	}()
+
+	pKey, cCols := []byte("pk"), []byte("cc")
+	for i := 0; i < 50; i++ {
+		db, err := lvl.OpenFile(filepath.Join(params.DBDir, strconv.Itoa(i)), nil)
+		require.NoError(err)
+		dbs = append(dbs, db)
+		s := &appStorageType{db: db, sweepers: sw}
+
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			require.NoError(s.PutBatch([]istorage.BatchItem{{PKey: pKey, CCols: cCols, Value: []byte("ttl"), TTL: time.Hour}}))
+		}()
+		go func() {
+			defer wg.Done()
+			require.NoError(s.Put(pKey, cCols, []byte("eternal")))
+		}()
+		wg.Wait()
+
+		// TTL must belong to the value written last
+		value := make([]byte, 0)
+		ok, ttl, err := s.TTLGet(pKey, cCols, &value)
+		require.NoError(err)
+		require.True(ok)
+		if string(value) == "eternal" {
+			require.Zero(ttl)
+		} else {
+			require.Positive(ttl)
+		}
+
+	}
+}
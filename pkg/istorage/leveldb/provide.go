@@ -0,0 +1,18 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package leveldb
+
+import (
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/internal/sweepers"
+)
+
+// Embedded LSM storage, suitable for single-node deployments with heavy PutBatch traffic
+func Provide(params ParamsType) istorage.IAppStorageFactory {
+	return &appStorageFactory{
+		params:   params,
+		sweepers: sweepers.New(),
+	}
+}
@@ -0,0 +1,10 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package leveldb
+
+type ParamsType struct {
+	// each app storage is kept in the separate subdirectory
+	DBDir string
+}
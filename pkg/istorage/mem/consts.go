@@ -0,0 +1,10 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package mem
+
+import "time"
+
+// how often expired records are removed
+const sweepInterval = time.Second
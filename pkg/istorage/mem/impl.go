@@ -9,12 +9,20 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/internal/sweepers"
 )
 
 type appStorageFactory struct {
-	storages map[string]map[string]map[string][]byte
+	storages map[string]*appStorage
+	sweepers *sweepers.Sweepers
+}
+
+// istorage.IStoppable.StopGoroutines
+func (s *appStorageFactory) StopGoroutines() {
+	s.sweepers.Stop()
 }
 
 func (s *appStorageFactory) AppStorage(appName istorage.SafeAppName) (istorage.IAppStorage, error) {
@@ -22,50 +30,128 @@ func (s *appStorageFactory) AppStorage(appName istorage.SafeAppName) (istorage.I
 	if !ok {
 		return nil, istorage.ErrStorageDoesNotExist
 	}
-	return &appStorage{storage: storage}, nil
+	return storage, nil
 }
 
 func (s *appStorageFactory) Init(appName istorage.SafeAppName) error {
 	if _, ok := s.storages[appName.String()]; ok {
 		return istorage.ErrStorageAlreadyExists
 	}
-	s.storages[appName.String()] = map[string]map[string][]byte{}
+	s.storages[appName.String()] = &appStorage{
+		storage:  map[string]map[string][]byte{},
+		expireAt: map[string]map[string]time.Time{},
+		sweepers: s.sweepers,
+	}
 	return nil
 }
 
 type appStorage struct {
 	storage map[string]map[string][]byte
-	lock    sync.RWMutex
+	// expiration times of records written with TTL
+	expireAt map[string]map[string]time.Time
+	sweepers *sweepers.Sweepers
+	sweeping bool
+	lock     sync.RWMutex
 }
 
 func (s *appStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.put(pKey, cCols, value, 0)
+	return
+}
+
+func (s *appStorage) PutBatch(items []istorage.BatchItem) (err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, item := range items {
+		if item.Deleted {
+			s.remove(string(item.PKey), string(item.CCols))
+			continue
+		}
+		s.put(item.PKey, item.CCols, item.Value, item.TTL)
+	}
+	return nil
+}
+
+// must be called under write lock
+func (s *appStorage) remove(pKey string, cCols string) {
+	if p, ok := s.storage[pKey]; ok {
+		delete(p, cCols)
+		if len(p) == 0 {
+			delete(s.storage, pKey)
+		}
+	}
+	if e, ok := s.expireAt[pKey]; ok {
+		delete(e, cCols)
+		if len(e) == 0 {
+			delete(s.expireAt, pKey)
+		}
+	}
+}
+
+// must be called under write lock
+func (s *appStorage) put(pKey []byte, cCols []byte, value []byte, ttl time.Duration) {
 	p := s.storage[string(pKey)]
 	if p == nil {
 		p = make(map[string][]byte)
 		s.storage[string(pKey)] = p
 	}
 	p[string(cCols)] = copySlice(value)
-	return
+
+	if ttl == 0 {
+		if e, ok := s.expireAt[string(pKey)]; ok {
+			delete(e, string(cCols))
+			if len(e) == 0 {
+				delete(s.expireAt, string(pKey))
+			}
+		}
+		return
+	}
+	e := s.expireAt[string(pKey)]
+	if e == nil {
+		e = make(map[string]time.Time)
+		s.expireAt[string(pKey)] = e
+	}
+	e[string(cCols)] = time.Now().Add(ttl)
+	if !s.sweeping {
+		s.sweeping = s.sweepers.Go(sweepInterval, s.sweep)
+	}
 }
 
-func (s *appStorage) PutBatch(items []istorage.BatchItem) (err error) {
-	for _, item := range items {
-		err = s.Put(item.PKey, item.CCols, item.Value)
-		if err != nil {
-			return err
+// must be called under read lock
+func (s *appStorage) expired(pKey string, cCols string, now time.Time) bool {
+	at, ok := s.expireAt[pKey][cCols]
+	return ok && !now.Before(at)
+}
+
+// removes expired records, returns false and stops sweeping if there are no records with TTL left
+func (s *appStorage) sweep() (more bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := time.Now()
+	for pKey, e := range s.expireAt {
+		for cCols, at := range e {
+			if now.Before(at) {
+				continue
+			}
+			s.remove(pKey, cCols)
 		}
 	}
-	return nil
+	s.sweeping = len(s.expireAt) > 0
+	return s.sweeping
 }
 
-func (s *appStorage) readPartSort(ctx context.Context, part map[string][]byte, startCCols, finishCCols []byte) (sortKeys []string) {
+func (s *appStorage) readPartSort(ctx context.Context, pKey string, part map[string][]byte, startCCols, finishCCols []byte) (sortKeys []string) {
 	sortKeys = make([]string, 0)
+	now := time.Now()
 	for col := range part {
 		if ctx.Err() != nil {
 			return nil
 		}
+		if s.expired(pKey, col, now) {
+			continue
+		}
 		if len(startCCols) > 0 {
 			if bytes.Compare(startCCols, []byte(col)) > 0 {
 				continue
@@ -93,7 +179,7 @@ func (s *appStorage) readPart(ctx context.Context, pKey []byte, startCCols, fini
 		return nil, nil // no such pKey
 	}
 
-	sortKeys := s.readPartSort(ctx, v, startCCols, finishCCols)
+	sortKeys := s.readPartSort(ctx, string(pKey), v, startCCols, finishCCols)
 	if sortKeys == nil {
 		return nil, nil
 	}
@@ -132,6 +218,11 @@ func (s *appStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCC
 }
 
 func (s *appStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	ok, _, err = s.TTLGet(pKey, cCols, data)
+	return
+}
+
+func (s *appStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 	p, ok := s.storage[string(pKey)]
@@ -142,13 +233,18 @@ func (s *appStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err
 	if !ok {
 		return
 	}
+	if at, expiring := s.expireAt[string(pKey)][string(cCols)]; expiring {
+		if ttl = time.Until(at); ttl <= 0 {
+			return false, 0, nil
+		}
+	}
 	*data = append((*data)[0:0], copySlice(viewRecord)...)
 	return
 }
 
 func (s *appStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
 	for i := range items {
-		items[i].Ok, err = s.Get(pKey, items[i].CCols, items[i].Data)
+		items[i].Ok, items[i].TTL, err = s.TTLGet(pKey, items[i].CCols, items[i].Data)
 		if err != nil {
 			return
 		}
@@ -156,6 +252,26 @@ func (s *appStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err e
 	return
 }
 
+// istorage.IAppStorageScanner.ScanPartitions(ctx context.Context, cb ScanPartitionsCallback) (err error)
+func (s *appStorage) ScanPartitions(ctx context.Context, cb istorage.ScanPartitionsCallback) (err error) {
+	s.lock.RLock()
+	pKeys := make([]string, 0, len(s.storage))
+	for pKey := range s.storage {
+		pKeys = append(pKeys, pKey)
+	}
+	s.lock.RUnlock()
+
+	for _, pKey := range pKeys {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err = cb([]byte(pKey)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func copySlice(src []byte) []byte {
 	dst := make([]byte, len(src))
 	copy(dst, src)
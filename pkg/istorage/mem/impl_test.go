@@ -6,6 +6,9 @@ package mem
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
 
 	"github.com/voedger/voedger/pkg/istorage"
 )
@@ -13,3 +16,28 @@ import (
 func TestMemTCK(t *testing.T) {
 	istorage.TechnologyCompatibilityKit(t, Provide())
 }
+
+func TestSweeper(t *testing.T) {
+	require := require.New(t)
+	sf := Provide()
+	san := istorage.NewTestSafeName("sweeper")
+	require.NoError(sf.Init(san))
+	s, err := sf.AppStorage(san)
+	require.NoError(err)
+
+	require.NoError(s.PutBatch([]istorage.BatchItem{
+		{PKey: []byte("pk"), CCols: []byte("expiring"), Value: []byte("1"), TTL: time.Millisecond},
+		{PKey: []byte("pk"), CCols: []byte("eternal"), Value: []byte("2")},
+		{PKey: []byte("expiring pk"), CCols: []byte("expiring"), Value: []byte("3"), TTL: time.Millisecond},
+	}))
+
+	storage := s.(*appStorage)
+	require.Eventually(func() bool {
+		storage.lock.RLock()
+		defer storage.lock.RUnlock()
+		return !storage.sweeping
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(map[string]map[string][]byte{"pk": {"eternal": []byte("2")}}, storage.storage)
+	require.Empty(storage.expireAt)
+}
@@ -4,8 +4,11 @@
 
 package mem
 
-import "github.com/voedger/voedger/pkg/istorage"
+import (
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/internal/sweepers"
+)
 
 func Provide() istorage.IAppStorageFactory {
-	return &appStorageFactory{storages: map[string]map[string]map[string][]byte{}}
+	return &appStorageFactory{storages: map[string]*appStorage{}, sweepers: sweepers.New()}
 }
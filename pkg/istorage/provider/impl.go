@@ -58,6 +58,36 @@ func (asp *implIAppStorageProvider) AppStorage(appQName istructs.AppQName) (stor
 	return storage, err
 }
 
+// istorage.IAppStorageLookup.ExistingAppStorage
+func (asp *implIAppStorageProvider) ExistingAppStorage(appQName istructs.AppQName) (storage istorage.IAppStorage, err error) {
+	asp.lock.Lock()
+	defer asp.lock.Unlock()
+	if storage, ok := asp.cache[appQName]; ok {
+		return storage, nil
+	}
+
+	// meta storage is not initialized here, missed meta storage means there are no application storages at all
+	metaStorage := asp.metaStorage
+	if metaStorage == nil {
+		if metaStorage, err = asp.asf.AppStorage(asp.getKeyspaceName(istorage.SysMetaSafeName)); err != nil {
+			return nil, fmt.Errorf("%s: %w", appQName, err)
+		}
+		asp.metaStorage = metaStorage
+	}
+
+	exists, appStorageDesc, err := readAppStorageDesc(appQName, metaStorage)
+	if err != nil {
+		return nil, err
+	}
+	if !exists || appStorageDesc.Status != istorage.AppStorageStatus_Done {
+		return nil, fmt.Errorf("%s: %w", appQName, istorage.ErrStorageDoesNotExist)
+	}
+	if storage, err = asp.asf.AppStorage(asp.getKeyspaceName(appStorageDesc.SafeName)); err == nil {
+		asp.cache[appQName] = storage
+	}
+	return storage, err
+}
+
 func (asp *implIAppStorageProvider) getMetaStorage() (istorage.IAppStorage, error) {
 	if err := asp.asf.Init(asp.getKeyspaceName(istorage.SysMetaSafeName)); err != nil && err != istorage.ErrStorageAlreadyExists {
 		return nil, err
@@ -59,6 +59,47 @@ func TestBasicUsage(t *testing.T) {
 	})
 }
 
+func TestExistingAppStorage(t *testing.T) {
+	require := require.New(t)
+	asf := mem.Provide()
+	asp := Provide(asf)
+	lookup := asp.(istorage.IAppStorageLookup)
+
+	app1 := istructs.NewAppQName("sys", "_")
+	app2 := istructs.NewAppQName("sys", "/")
+
+	t.Run("should be error if there are no storages at all", func(t *testing.T) {
+		storage, err := lookup.ExistingAppStorage(app1)
+		require.ErrorIs(err, istorage.ErrStorageDoesNotExist)
+		require.Nil(storage)
+	})
+
+	storage, err := asp.AppStorage(app1)
+	require.NoError(err)
+	require.NoError(storage.Put([]byte{1}, []byte{1}, []byte{2}))
+
+	t.Run("should return the existing storage", func(t *testing.T) {
+		lookup := Provide(asf, asp.(*implIAppStorageProvider).suffix).(istorage.IAppStorageLookup)
+		existing, err := lookup.ExistingAppStorage(app1)
+		require.NoError(err)
+		val := []byte{}
+		ok, err := existing.Get([]byte{1}, []byte{1}, &val)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte{2}, val)
+	})
+
+	t.Run("should be error and the storage must not be initialized if it does not exist", func(t *testing.T) {
+		storage, err := lookup.ExistingAppStorage(app2)
+		require.ErrorIs(err, istorage.ErrStorageDoesNotExist)
+		require.Nil(storage)
+
+		exists, _, err := readAppStorageDesc(app2, asp.(*implIAppStorageProvider).metaStorage)
+		require.NoError(err)
+		require.False(exists)
+	})
+}
+
 func TestInitErrorPersistence(t *testing.T) {
 	require := require.New(t)
 	asf := mem.Provide()
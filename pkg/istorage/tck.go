@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -26,6 +27,11 @@ func TechnologyCompatibilityKit_Storage(t *testing.T, storage IAppStorage) {
 	t.Run("TestAppStorage_GetPutRead", func(t *testing.T) { testAppStorage_GetPutRead(t, storage) })
 	t.Run("TestAppStorage_PutBatch", func(t *testing.T) { testAppStorage_PutBatch(t, storage) })
 	t.Run("TestAppStorage_GetBatch", func(t *testing.T) { testAppStorage_GetBatch(t, storage) })
+	t.Run("TestAppStorage_TTL", func(t *testing.T) { testAppStorage_TTL(t, storage) })
+	t.Run("TestAppStorage_Delete", func(t *testing.T) { testAppStorage_Delete(t, storage) })
+	if scanner, ok := storage.(IAppStorageScanner); ok {
+		t.Run("TestAppStorage_ScanPartitions", func(t *testing.T) { testAppStorage_ScanPartitions(t, storage, scanner) })
+	}
 }
 
 func testAppStorageFactory(t *testing.T, sf IAppStorageFactory, testAppQName istructs.AppQName) IAppStorage {
@@ -608,3 +614,145 @@ func testAppStorage_GetBatch(t *testing.T, storage IAppStorage) {
 	})
 
 }
+
+// nolint
+func testAppStorage_TTL(t *testing.T, storage IAppStorage) {
+	pKey := []byte("TTL")
+	require.NoError(t, storage.PutBatch([]BatchItem{
+		{PKey: pKey, CCols: []byte("1 - expiring"), Value: []byte("milk"), TTL: time.Second},
+		{PKey: pKey, CCols: []byte("2 - eternal"), Value: []byte("salt")},
+		{PKey: pKey, CCols: []byte("3 - long living"), Value: []byte("honey"), TTL: time.Hour},
+		{PKey: pKey, CCols: []byte("4 - made eternal"), Value: []byte("cheese"), TTL: time.Second},
+	}))
+	require.NoError(t, storage.Put(pKey, []byte("4 - made eternal"), []byte("cheese")))
+
+	readAll := func(t *testing.T) (ccols []string) {
+		require.NoError(t, storage.Read(context.Background(), pKey, nil, nil, func(c []byte, _ []byte) (err error) {
+			ccols = append(ccols, string(c))
+			return nil
+		}))
+		return ccols
+	}
+
+	t.Run("Should read all records before expiration", func(t *testing.T) {
+		require := require.New(t)
+		data := []byte{}
+		ok, err := storage.Get(pKey, []byte("1 - expiring"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal("milk", string(data))
+		require.Equal([]string{"1 - expiring", "2 - eternal", "3 - long living", "4 - made eternal"}, readAll(t))
+	})
+
+	t.Run("Should not return expired records", func(t *testing.T) {
+		require := require.New(t)
+		require.Eventually(func() bool {
+			data := []byte{}
+			ok, err := storage.Get(pKey, []byte("1 - expiring"), &data)
+			require.NoError(err)
+			return !ok
+		}, 5*time.Second, 100*time.Millisecond)
+
+		require.Equal([]string{"2 - eternal", "3 - long living", "4 - made eternal"}, readAll(t))
+
+		items := []GetBatchItem{
+			{CCols: []byte("1 - expiring"), Data: new([]byte)},
+			{CCols: []byte("2 - eternal"), Data: new([]byte)},
+			{CCols: []byte("4 - made eternal"), Data: new([]byte)},
+		}
+		require.NoError(storage.GetBatch(pKey, items))
+		require.False(items[0].Ok)
+		require.True(items[1].Ok)
+		require.Equal("salt", string(*items[1].Data))
+		require.True(items[2].Ok)
+		require.Equal("cheese", string(*items[2].Data))
+	})
+
+	t.Run("Should write expired record again", func(t *testing.T) {
+		require := require.New(t)
+		require.NoError(storage.Put(pKey, []byte("1 - expiring"), []byte("fresh milk")))
+		data := []byte{}
+		ok, err := storage.Get(pKey, []byte("1 - expiring"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal("fresh milk", string(data))
+	})
+}
+
+func testAppStorage_Delete(t *testing.T, storage IAppStorage) {
+	require := require.New(t)
+	pKey := []byte("Delete")
+	require.NoError(storage.PutBatch([]BatchItem{
+		{PKey: pKey, CCols: []byte("1 - deleted"), Value: []byte("bread")},
+		{PKey: pKey, CCols: []byte("2 - kept"), Value: []byte("butter")},
+		{PKey: pKey, CCols: []byte("3 - deleted expiring"), Value: []byte("jam"), TTL: time.Hour},
+	}))
+
+	require.NoError(storage.PutBatch([]BatchItem{
+		{PKey: pKey, CCols: []byte("1 - deleted"), Deleted: true},
+		{PKey: pKey, CCols: []byte("3 - deleted expiring"), Deleted: true},
+		{PKey: pKey, CCols: []byte("4 - not existing"), Deleted: true},
+	}))
+
+	data := []byte{}
+	ok, err := storage.Get(pKey, []byte("1 - deleted"), &data)
+	require.NoError(err)
+	require.False(ok)
+
+	ccols := []string{}
+	require.NoError(storage.Read(context.Background(), pKey, nil, nil, func(c []byte, _ []byte) (err error) {
+		ccols = append(ccols, string(c))
+		return nil
+	}))
+	require.Equal([]string{"2 - kept"}, ccols)
+
+	t.Run("Should write deleted record again", func(t *testing.T) {
+		require.NoError(storage.Put(pKey, []byte("3 - deleted expiring"), []byte("fresh jam")))
+		data := []byte{}
+		ok, ttl, err := storage.TTLGet(pKey, []byte("3 - deleted expiring"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Zero(ttl)
+		require.Equal("fresh jam", string(data))
+	})
+}
+
+func testAppStorage_ScanPartitions(t *testing.T, storage IAppStorage, scanner IAppStorageScanner) {
+	require := require.New(t)
+
+	pKeys := map[string]bool{"scan-partition-1": false, "scan-partition-2": false}
+	for pKey := range pKeys {
+		require.NoError(storage.Put([]byte(pKey), []byte("cc"), []byte("value")))
+	}
+
+	t.Run("Should enumerate all partitions", func(t *testing.T) {
+		err := scanner.ScanPartitions(context.Background(), func(pKey []byte) error {
+			if _, ok := pKeys[string(pKey)]; ok {
+				pKeys[string(pKey)] = true
+			}
+			return nil
+		})
+		require.NoError(err)
+		for pKey, found := range pKeys {
+			require.True(found, pKey)
+		}
+	})
+
+	t.Run("Should handle callback error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		err := scanner.ScanPartitions(context.Background(), func([]byte) error { return testErr })
+		require.ErrorIs(err, testErr)
+	})
+
+	t.Run("Should stop on ctx error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		count := 0
+		err := scanner.ScanPartitions(ctx, func([]byte) error {
+			count++
+			return nil
+		})
+		require.NoError(err)
+		require.LessOrEqual(count, 1)
+	})
+}
@@ -98,6 +98,10 @@ func (s *cachedAppStorage) PutBatch(items []istorage.BatchItem) (err error) {
 	err = s.storage.PutBatch(items)
 	if err == nil {
 		for _, i := range items {
+			if i.Deleted || (i.TTL > 0) {
+				s.cache.Del(makeKey(i.PKey, i.CCols))
+				continue
+			}
 			s.cache.Set(makeKey(i.PKey, i.CCols), i.Value)
 		}
 	}
@@ -125,18 +129,26 @@ func (s *cachedAppStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool
 	// 	qNameID := binary.BigEndian.Uint16(pKey)
 	// 	logger.Verbose(fmt.Sprintf("missed cache by QNameID = %d", qNameID))
 	// }
-	ok, err = s.storage.Get(pKey, cCols, data)
+	ok, ttl, err := s.storage.TTLGet(pKey, cCols, data)
 	if err != nil {
 		return false, err
 	}
-	if ok {
+	switch {
+	case ttl > 0:
+		// cache has no expiration, so expiring records are always read from the storage
+	case ok:
 		s.cache.Set(key, *data)
-	} else {
+	default:
 		s.cache.Set(key, nil)
 	}
 	return ok, nil
 }
 
+// expiring records are not cached, so TTL is always read from the storage
+func (s *cachedAppStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error) {
+	return s.storage.TTLGet(pKey, cCols, data)
+}
+
 func (s *cachedAppStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
 	start := time.Now()
 	defer func() {
@@ -167,6 +179,10 @@ func (s *cachedAppStorage) getBatchFromStorage(pKey []byte, items []istorage.Get
 		return err
 	}
 	for _, item := range items {
+		if item.TTL > 0 {
+			s.cache.Del(makeKey(pKey, item.CCols))
+			continue
+		}
 		if item.Ok {
 			s.cache.Set(makeKey(pKey, item.CCols), *item.Data)
 		} else {
@@ -186,6 +202,16 @@ func (s *cachedAppStorage) Read(ctx context.Context, pKey []byte, startCCols, fi
 	return s.storage.Read(ctx, pKey, startCCols, finishCCols, cb)
 }
 
+// Partitions are enumerated by the underlying storage. Cache is not involved:
+// records removed after scan (e.g. by view truncation) are removed from cache by PutBatch
+func (s *cachedAppStorage) ScanPartitions(ctx context.Context, cb istorage.ScanPartitionsCallback) (err error) {
+	scanner, ok := s.storage.(istorage.IAppStorageScanner)
+	if !ok {
+		return istorage.ErrScanNotSupported
+	}
+	return scanner.ScanPartitions(ctx, cb)
+}
+
 func makeKey(pKey []byte, cCols []byte) (res []byte) {
 	res = make([]byte, 0, stackKeySize)
 	// res = make([]byte, 0, len(pKey)+len(cCols)) // escapes to heap
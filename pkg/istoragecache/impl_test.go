@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -385,6 +386,41 @@ func TestCacheNils(t *testing.T) {
 	})
 }
 
+func TestScanPartitions(t *testing.T) {
+	t.Run("Should enumerate partitions of the underlying storage and drop cached records of deleted partition", func(t *testing.T) {
+		require := require.New(t)
+		asp := istorageimpl.Provide(mem.Provide())
+		storage, err := Provide(testCacheSize, asp, imetrics.Provide(), "vvm").AppStorage(istructs.AppQName_test1_app1)
+		require.NoError(err)
+
+		pKey := []byte("partition")
+		require.NoError(storage.Put(pKey, []byte("cc"), []byte("value")))
+		data := make([]byte, 0)
+		ok, err := storage.Get(pKey, []byte("cc"), &data)
+		require.NoError(err)
+		require.True(ok)
+
+		pKeys := [][]byte{}
+		require.NoError(storage.(istorage.IAppStorageScanner).ScanPartitions(context.Background(), func(pKey []byte) error {
+			pKeys = append(pKeys, append([]byte{}, pKey...))
+			return nil
+		}))
+		require.Contains(pKeys, pKey)
+
+		require.NoError(storage.PutBatch([]istorage.BatchItem{{PKey: pKey, CCols: []byte("cc"), Deleted: true}}))
+		ok, err = storage.Get(pKey, []byte("cc"), &data)
+		require.NoError(err)
+		require.False(ok)
+	})
+	t.Run("Should return error if underlying storage is not able to enumerate partitions", func(t *testing.T) {
+		require := require.New(t)
+		storage, err := Provide(testCacheSize, &testStorageProvider{storage: &testStorage{}}, imetrics.Provide(), "vvm").AppStorage(istructs.AppQName_test1_app1)
+		require.NoError(err)
+		err = storage.(istorage.IAppStorageScanner).ScanPartitions(context.Background(), func([]byte) error { return nil })
+		require.ErrorIs(err, istorage.ErrScanNotSupported)
+	})
+}
+
 func TestMakeKes(t *testing.T) {
 	require := require.New(t)
 	require.Equal([]byte{1, 2, 3, 4, 5, 6}, makeKey([]byte{1, 2, 3}, []byte{4, 5, 6}))
@@ -421,6 +457,11 @@ func (s *testStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err
 	return s.get(pKey, cCols, data)
 }
 
+func (s *testStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error) {
+	ok, err = s.get(pKey, cCols, data)
+	return ok, 0, err
+}
+
 func (s *testStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
 	return s.getBatch(pKey, items)
 }
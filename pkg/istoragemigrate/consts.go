@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istoragemigrate
+
+import "errors"
+
+// max count of records copied by one batch
+const batchSize = 256
+
+// stops reading of the partition when the batch is read
+var errBatchRead = errors.New("batch is read")
+
+const progressFilePerm = 0644
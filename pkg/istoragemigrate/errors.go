@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istoragemigrate
+
+import "errors"
+
+var ErrScanNotSupported = errors.New("source storage is not able to enumerate partitions")
+
+var ErrLookupNotSupported = errors.New("source storage provider is not able to look up the existing storage")
+
+var ErrChecksumMismatch = errors.New("partition checksum mismatch")
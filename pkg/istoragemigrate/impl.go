@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istoragemigrate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func migrate(ctx context.Context, from, to istorage.IAppStorageProvider, params Params) (res Result, err error) {
+	lookup, ok := from.(istorage.IAppStorageLookup)
+	if !ok {
+		return res, ErrLookupNotSupported
+	}
+	// source storage must not be initialized if it is missed
+	src, err := lookup.ExistingAppStorage(params.App)
+	if err != nil {
+		return res, fmt.Errorf("source storage: %w", err)
+	}
+	scanner, ok := src.(istorage.IAppStorageScanner)
+	if !ok {
+		return res, ErrScanNotSupported
+	}
+	dst, err := to.AppStorage(params.App)
+	if err != nil {
+		return res, fmt.Errorf("target storage: %w", err)
+	}
+
+	prg, err := openProgress(params.ProgressFile)
+	if err != nil {
+		return res, err
+	}
+	defer prg.close()
+
+	th := newThrottle(params.RecordsPerSecond)
+
+	// partition keys are collected first, since some drivers do not allow to read the partition while scanning
+	pKeys := make([][]byte, 0)
+	if err = scanner.ScanPartitions(ctx, func(pKey []byte) error {
+		pKeys = append(pKeys, bytes.Clone(pKey))
+		return nil
+	}); err != nil {
+		return res, fmt.Errorf("scan partitions: %w", err)
+	}
+
+	for _, pKey := range pKeys {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+		if prg.isDone(pKey) {
+			res.SkippedPartitions++
+			continue
+		}
+		records, err := copyPartition(ctx, src, dst, pKey, th)
+		if err != nil {
+			return res, fmt.Errorf("partition %x: %w", pKey, err)
+		}
+		if err := prg.setDone(pKey); err != nil {
+			return res, err
+		}
+		res.Partitions++
+		res.Records += records
+		if params.OnPartition != nil {
+			params.OnPartition(pKey, records)
+		}
+	}
+
+	return res, nil
+}
+
+// copyPartition copies records of the partition by batches with their TTL and verifies every written batch by checksum
+func copyPartition(ctx context.Context, src, dst istorage.IAppStorage, pKey []byte, th *throttle) (records int, err error) {
+	var from []byte
+	for {
+		// batch clustering columns are read first, since Read does not return records TTL
+		cCols := make([][]byte, 0, batchSize)
+		if err = src.Read(ctx, pKey, from, nil, func(cc, _ []byte) error {
+			cCols = append(cCols, bytes.Clone(cc))
+			if len(cCols) == batchSize {
+				return errBatchRead
+			}
+			return nil
+		}); err != nil && !errors.Is(err, errBatchRead) {
+			return records, err
+		}
+		if len(cCols) == 0 {
+			break
+		}
+
+		items := make([]istorage.GetBatchItem, len(cCols))
+		for i := range items {
+			items[i] = istorage.GetBatchItem{CCols: cCols[i], Data: new([]byte)}
+		}
+		if err = src.GetBatch(pKey, items); err != nil {
+			return records, err
+		}
+		batch := make([]istorage.BatchItem, 0, len(items))
+		for _, item := range items {
+			if item.Ok { // record could expire after it was read
+				batch = append(batch, istorage.BatchItem{PKey: pKey, CCols: item.CCols, Value: *item.Data, TTL: item.TTL})
+			}
+		}
+		if err = th.wait(ctx, len(batch)); err != nil {
+			return records, err
+		}
+		if err = dst.PutBatch(batch); err != nil {
+			return records, err
+		}
+		if err = verifyBatch(dst, pKey, batch); err != nil {
+			return records, err
+		}
+		records += len(batch)
+
+		if len(cCols) < batchSize {
+			break
+		}
+		// the next batch starts from the nearest clustering columns after the last read ones
+		from = append(cCols[len(cCols)-1], 0)
+	}
+
+	return records, nil
+}
+
+// verifyBatch reads the written records back and compares their checksum with the checksum of the batch.
+// Records which are not in the batch (e.g. records of the target partition written before) are not verified.
+// Records with TTL could expire after they were written, so missed ones are not verified
+func verifyBatch(dst istorage.IAppStorage, pKey []byte, batch []istorage.BatchItem) error {
+	items := make([]istorage.GetBatchItem, len(batch))
+	for i := range items {
+		items[i] = istorage.GetBatchItem{CCols: batch[i].CCols, Data: new([]byte)}
+	}
+	if err := dst.GetBatch(pKey, items); err != nil {
+		return err
+	}
+	sum, dstSum := newChecksum(), newChecksum()
+	for i, item := range items {
+		if !item.Ok && batch[i].TTL > 0 {
+			continue
+		}
+		sum.add(batch[i].CCols, batch[i].Value)
+		if item.Ok {
+			dstSum.add(item.CCols, *item.Data)
+		}
+	}
+	if !bytes.Equal(sum.sum(), dstSum.sum()) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+func openProgress(fileName string) (*progress, error) {
+	prg := &progress{done: make(map[string]bool)}
+	if len(fileName) == 0 {
+		return prg, nil
+	}
+
+	data, err := os.ReadFile(fileName)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		if line := sc.Text(); len(line) > 0 {
+			prg.done[line] = true
+		}
+	}
+
+	if prg.file, err = os.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, progressFilePerm); err != nil {
+		return nil, err
+	}
+	return prg, nil
+}
+
+func (prg *progress) isDone(pKey []byte) bool {
+	return prg.done[hex.EncodeToString(pKey)]
+}
+
+func (prg *progress) setDone(pKey []byte) error {
+	if prg.file == nil {
+		return nil
+	}
+	if _, err := prg.file.WriteString(hex.EncodeToString(pKey) + "\n"); err != nil {
+		return err
+	}
+	return prg.file.Sync()
+}
+
+func (prg *progress) close() {
+	if prg.file != nil {
+		prg.file.Close()
+	}
+}
+
+func newThrottle(rps int) *throttle {
+	return &throttle{rps: rps, start: time.Now()}
+}
+
+// wait blocks until n more records can be copied without exceeding the rate
+func (th *throttle) wait(ctx context.Context, n int) error {
+	if th.rps <= 0 {
+		return nil
+	}
+	th.count += n
+	due := th.start.Add(time.Duration(th.count) * time.Second / time.Duration(th.rps))
+	if d := time.Until(due); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func newChecksum() *checksum {
+	return &checksum{h: sha256.New()}
+}
+
+func (cs *checksum) add(cCols, value []byte) {
+	cs.buf = binary.AppendUvarint(cs.buf[:0], uint64(len(cCols)))
+	cs.buf = append(cs.buf, cCols...)
+	cs.buf = binary.AppendUvarint(cs.buf, uint64(len(value)))
+	cs.buf = append(cs.buf, value...)
+	cs.h.Write(cs.buf)
+}
+
+func (cs *checksum) sum() []byte {
+	return cs.h.Sum(nil)
+}
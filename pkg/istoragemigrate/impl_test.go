@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istoragemigrate
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/bbolt"
+	"github.com/voedger/voedger/pkg/istorage/leveldb"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+const (
+	testPartitions = 5
+	testRecords    = batchSize + 10 // per partition, partition is copied by two batches
+)
+
+var testApp = istructs.AppQName_test1_app1
+
+func testPKey(p int) []byte { return []byte(fmt.Sprintf("partition-%d", p)) }
+
+func testCCols(r int) []byte { return []byte(fmt.Sprintf("ccols-%04d", r)) }
+
+func testValue(p, r int) []byte { return []byte(fmt.Sprintf("value-%d-%d", p, r)) }
+
+// fills the application storage: testPartitions partitions by testRecords records, the first record of each partition expires in an hour
+func fillStorage(t *testing.T, asp istorage.IAppStorageProvider) {
+	storage, err := asp.AppStorage(testApp)
+	require.NoError(t, err)
+	for p := 0; p < testPartitions; p++ {
+		batch := make([]istorage.BatchItem, 0, testRecords)
+		for r := 0; r < testRecords; r++ {
+			item := istorage.BatchItem{PKey: testPKey(p), CCols: testCCols(r), Value: testValue(p, r)}
+			if r == 0 {
+				item.TTL = time.Hour
+			}
+			batch = append(batch, item)
+		}
+		require.NoError(t, storage.PutBatch(batch))
+	}
+}
+
+func requireMigrated(t *testing.T, asp istorage.IAppStorageProvider) {
+	require := require.New(t)
+	storage, err := asp.AppStorage(testApp)
+	require.NoError(err)
+	for p := 0; p < testPartitions; p++ {
+		for r := 0; r < testRecords; r++ {
+			data := make([]byte, 0)
+			ok, ttl, err := storage.TTLGet(testPKey(p), testCCols(r), &data)
+			require.NoError(err)
+			require.True(ok)
+			require.Equal(testValue(p, r), data)
+			if r == 0 {
+				require.Greater(ttl, time.Duration(0))
+				require.LessOrEqual(ttl, time.Hour)
+			} else {
+				require.Zero(ttl)
+			}
+		}
+	}
+}
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	from := istorageimpl.Provide(bbolt.Provide(bbolt.ParamsType{DBDir: t.TempDir()}))
+	fillStorage(t, from)
+
+	targets := map[string]istorage.IAppStorageFactory{
+		"mem":     mem.Provide(),
+		"leveldb": leveldb.Provide(leveldb.ParamsType{DBDir: t.TempDir()}),
+	}
+	for name, factory := range targets {
+		t.Run(name, func(t *testing.T) {
+			to := istorageimpl.Provide(factory)
+			res, err := Migrate(context.Background(), from, to, Params{App: testApp})
+			require.NoError(err)
+			require.Equal(Result{Partitions: testPartitions, Records: testPartitions * testRecords}, res)
+			requireMigrated(t, to)
+		})
+	}
+}
+
+func TestResume(t *testing.T) {
+	require := require.New(t)
+
+	from := istorageimpl.Provide(mem.Provide())
+	fillStorage(t, from)
+	to := istorageimpl.Provide(mem.Provide())
+	progressFile := filepath.Join(t.TempDir(), "progress")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := Migrate(ctx, from, to, Params{
+		App:          testApp,
+		ProgressFile: progressFile,
+		OnPartition: func([]byte, int) {
+			cancel() // interrupts migration after the first partition
+		},
+	})
+	require.ErrorIs(err, context.Canceled)
+
+	res, err := Migrate(context.Background(), from, to, Params{App: testApp, ProgressFile: progressFile})
+	require.NoError(err)
+	require.Equal(Result{Partitions: testPartitions - 1, SkippedPartitions: 1, Records: (testPartitions - 1) * testRecords}, res)
+	requireMigrated(t, to)
+}
+
+func TestThrottle(t *testing.T) {
+	require := require.New(t)
+
+	from := istorageimpl.Provide(mem.Provide())
+	fillStorage(t, from)
+
+	const rps = testPartitions * testRecords * 5 // all records in 200ms
+	start := time.Now()
+	_, err := Migrate(context.Background(), from, istorageimpl.Provide(mem.Provide()), Params{App: testApp, RecordsPerSecond: rps})
+	require.NoError(err)
+	require.GreaterOrEqual(time.Since(start), 150*time.Millisecond)
+}
+
+// storage factory which storages do not implement IAppStorageScanner and lose records on PutBatch
+type testFactory struct {
+	istorage.IAppStorageFactory
+}
+
+type testStorage struct {
+	istorage.IAppStorage
+}
+
+func (f testFactory) AppStorage(appName istorage.SafeAppName) (istorage.IAppStorage, error) {
+	storage, err := f.IAppStorageFactory.AppStorage(appName)
+	return testStorage{storage}, err
+}
+
+func (s testStorage) PutBatch(items []istorage.BatchItem) error {
+	return s.IAppStorage.PutBatch(items[1:])
+}
+
+func TestNotEmptyTarget(t *testing.T) {
+	require := require.New(t)
+
+	from := istorageimpl.Provide(mem.Provide())
+	fillStorage(t, from)
+	to := istorageimpl.Provide(mem.Provide())
+	target, err := to.AppStorage(testApp)
+	require.NoError(err)
+	extraPKey, extraCCols := testPKey(0), []byte("extra")
+	require.NoError(target.Put(extraPKey, extraCCols, []byte("extra")))
+
+	res, err := Migrate(context.Background(), from, to, Params{App: testApp})
+	require.NoError(err)
+	require.Equal(testPartitions, res.Partitions)
+	requireMigrated(t, to)
+
+	// records of the target are kept
+	data := make([]byte, 0)
+	ok, err := target.Get(extraPKey, extraCCols, &data)
+	require.NoError(err)
+	require.True(ok)
+}
+
+func TestErrors(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("should be error if source storage does not exist", func(t *testing.T) {
+		from := istorageimpl.Provide(mem.Provide())
+		_, err := Migrate(context.Background(), from, istorageimpl.Provide(mem.Provide()), Params{App: testApp})
+		require.ErrorIs(err, istorage.ErrStorageDoesNotExist)
+
+		// missed source storage must not be initialized
+		_, err = from.(istorage.IAppStorageLookup).ExistingAppStorage(testApp)
+		require.ErrorIs(err, istorage.ErrStorageDoesNotExist)
+	})
+
+	t.Run("should be error if source storage is not able to scan partitions", func(t *testing.T) {
+		from := istorageimpl.Provide(testFactory{mem.Provide()})
+		fillStorage(t, from)
+		_, err := Migrate(context.Background(), from, istorageimpl.Provide(mem.Provide()), Params{App: testApp})
+		require.ErrorIs(err, ErrScanNotSupported)
+	})
+
+	t.Run("should be error if copied partition differs", func(t *testing.T) {
+		from := istorageimpl.Provide(mem.Provide())
+		fillStorage(t, from)
+		_, err := Migrate(context.Background(), from, istorageimpl.Provide(testFactory{mem.Provide()}), Params{App: testApp})
+		require.ErrorIs(err, ErrChecksumMismatch)
+	})
+}
@@ -0,0 +1,21 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istoragemigrate
+
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// Migrate copies all partitions of the application storage from one storage to another.
+//
+// Source storage provider must implement istorage.IAppStorageLookup, missed source storage is an error and is not initialized.
+// Source storage must implement istorage.IAppStorageScanner. Application must be stopped while migrating.
+// Each partition is verified by checksum after copying. Records TTL is kept.
+// Migration can be interrupted and resumed with the same Params.ProgressFile
+func Migrate(ctx context.Context, from, to istorage.IAppStorageProvider, params Params) (Result, error) {
+	return migrate(ctx, from, to, params)
+}
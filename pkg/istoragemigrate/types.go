@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istoragemigrate
+
+import (
+	"hash"
+	"os"
+	"time"
+
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+type Params struct {
+	// Application to migrate. Keyspaces are located by the application SafeAppName in the source and target storages
+	App istructs.AppQName
+
+	// Optional. File to keep migrated partitions in, the next migration with the same file skips them
+	ProgressFile string
+
+	// Optional. Max records copied per second, zero means unlimited
+	RecordsPerSecond int
+
+	// Optional. Called after each partition is copied and verified
+	OnPartition func(pKey []byte, records int)
+}
+
+type Result struct {
+	// Partitions copied by this migration
+	Partitions int
+
+	// Partitions skipped since they were migrated before, see Params.ProgressFile
+	SkippedPartitions int
+
+	// Records copied by this migration
+	Records int
+}
+
+// migrated partitions, hex encoded partition key per line
+type progress struct {
+	done map[string]bool
+	file *os.File
+}
+
+// limits records per second rate
+type throttle struct {
+	rps   int
+	start time.Time
+	count int
+}
+
+// checksum of partition records in clustering columns order
+type checksum struct {
+	h   hash.Hash
+	buf []byte
+}
@@ -7,3 +7,5 @@ package istructs
 import "errors"
 
 var ErrAppNotFound = errors.New("application not found")
+
+var ErrInvalidValue = errors.New("invalid value")
@@ -56,11 +56,19 @@ type IAppStructs interface {
 
 	IsFunctionRateLimitsExceeded(funcQName appdef.QName, wsid WSID) bool
 
+	// Returns true if any limit declared in application definition on the resource (command, query or table)
+	// is exceeded within the scopes of the operation.
+	//
+	// Otherwise takes a token from every limit bucket and returns false
+	IsLimitExceeded(resource appdef.QName, scopes LimitScopes) bool
+
 	// Describe package names
 	DescribePackageNames() []string
 
-	// Describe package content
-	DescribePackage(pkgName string) interface{}
+	// Describe package content.
+	//
+	// If tags are specified, then only types with any of these tags are described
+	DescribePackage(pkgName string, tags ...appdef.QName) interface{}
 
 	SyncProjectors() []ProjectorFactory
 	AsyncProjectors() []ProjectorFactory
@@ -133,6 +141,11 @@ type IViewRecords interface {
 	// Key & value must be from the same QName (panic)
 	Put(workspace WSID, key IKeyBuilder, value IValueBuilder) (err error)
 
+	// Same as Put, but the record expires after the specified ttl, which overrides TTL of the view.
+	// Zero ttl means TTL of the view
+	PutWithTTL(workspace WSID, key IKeyBuilder, value IValueBuilder, ttl time.Duration) (err error)
+
+	// Records of items with Deleted flag are deleted
 	PutBatch(workspace WSID, batch []ViewKV) (err error)
 
 	// All fields must be filled in in the key (panic otherwise)
@@ -144,6 +157,11 @@ type IViewRecords interface {
 	// Zero or more fields of key.ClusteringColumns can be specified
 	// If last clustering column has variable length it can be filled partially
 	Read(ctx context.Context, workspace WSID, key IKeyBuilder, cb ValuesCallback) (err error)
+
+	// Same as Read, but skips records which clustering columns are less than clustering columns of the from key.
+	// Partition key of the from key is not used.
+	// Clustering columns of the from key must begin with clustering columns specified in the key (error otherwise)
+	ReadFrom(ctx context.Context, workspace WSID, key IKeyBuilder, from IKeyBuilder, cb ValuesCallback) (err error)
 }
 
 type ViewRecordGetBatchItem struct {
@@ -155,6 +173,12 @@ type ViewRecordGetBatchItem struct {
 type ViewKV struct {
 	Key   IKeyBuilder
 	Value IValueBuilder
+
+	// Time to live of the record, overrides TTL of the view. Zero means TTL of the view
+	TTL time.Duration
+
+	// Record is deleted, Value and TTL are ignored
+	Deleted bool
 }
 
 type ValuesCallback func(key IKey, value IValue) (err error)
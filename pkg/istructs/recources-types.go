@@ -69,6 +69,18 @@ type ExecQueryCallback func(object IObject) error
 type ExecQueryArgs struct {
 	PrepareArgs
 	State IState
+	// Page is not nil if the query result is paged by sys.ID. See QueryPage
+	Page *QueryPage
+}
+
+// QueryPage is the page of the query result ordered by sys.ID.
+//
+// Query function which produces objects in sys.ID order may skip objects up to AfterID
+// and stop after Limit+1 objects: the extra object tells there are more pages
+type QueryPage struct {
+	// sys.ID of the last object of the previous page, NullRecordID for the first page
+	AfterID RecordID
+	Limit   int
 }
 
 type IState interface {
@@ -131,3 +143,13 @@ type RateLimit struct {
 	Period                time.Duration
 	MaxAllowedPerDuration uint32
 }
+
+// Scopes of the operation to check limits declared in application definition.
+//
+// See IAppStructs.IsLimitExceeded
+type LimitScopes struct {
+	Partition  PartitionID
+	Workspace  WSID
+	User       string // login of the user or device, empty if request is not authenticated
+	RemoteAddr string
+}
@@ -62,7 +62,8 @@ type IRowReader interface {
 	// Returns bytes or raw field value
 	AsBytes(name string) []byte
 
-	// Returns string or raw field value
+	// Returns string or raw field value.
+	// Decimal field value is rendered with its scale, such as "-123.45"
 	AsString(name string) string
 
 	AsQName(name string) appdef.QName
@@ -72,6 +73,12 @@ type IRowReader interface {
 	// consts.NullRecord will be returned as null-values
 	RecordIDs(includeNulls bool, cb func(name string, value RecordID))
 	FieldNames(cb func(fieldName string))
+
+	// Enumerates items of array field.
+	//
+	// Items are passed as stored: int32, int64, float32, float64, string or bool.
+	// Decimal items are passed as unscaled int64, date items as int32 days and timestamp items as int64 milliseconds
+	AsArray(name string, cb func(index int, value interface{}))
 }
 
 type IRowWriter interface {
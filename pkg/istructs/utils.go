@@ -119,6 +119,7 @@ func (*NullRowReader) AsRecordID(name string) RecordID
 func (*NullRowReader) AsQName(name string) appdef.QName                                  { return appdef.NullQName }
 func (*NullRowReader) AsBool(name string) bool                                           { return false }
 func (*NullRowReader) RecordIDs(includeNulls bool, cb func(name string, value RecordID)) {}
+func (*NullRowReader) AsArray(name string, cb func(index int, value interface{}))        {}
 
 // Implements IObject
 type NullObject struct{ NullRowReader }
@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istructs
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// *********************************************************************************************************
+//
+//				decimal
+//
+
+// Parses decimal string representation, such as "-123.45", to unscaled value using specified scale.
+//
+// Fraction digits beyond scale are not rounded, error is returned if any of them is not zero.
+func ParseDecimal(s string, scale uint8) (int64, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if (len(intPart) == 0) && (len(fracPart) == 0) {
+		return 0, fmt.Errorf("invalid decimal value «%s»: %w", s, ErrInvalidValue)
+	}
+	if len(fracPart) > int(scale) {
+		if strings.TrimRight(fracPart[scale:], "0") != "" {
+			return 0, fmt.Errorf("decimal value «%s» has more than %d fraction digits: %w", s, scale, ErrInvalidValue)
+		}
+		fracPart = fracPart[:scale]
+	}
+	fracPart += strings.Repeat("0", int(scale)-len(fracPart))
+	for _, c := range intPart + fracPart {
+		if (c < '0') || (c > '9') {
+			return 0, fmt.Errorf("invalid decimal value «%s»: %w", s, ErrInvalidValue)
+		}
+	}
+	unscaled := strings.TrimLeft(intPart+fracPart, "0")
+	if len(unscaled) == 0 {
+		return 0, nil
+	}
+	if strings.HasPrefix(s, "-") {
+		unscaled = "-" + unscaled
+	}
+	const base, bitSize = 10, 64
+	v, err := strconv.ParseInt(unscaled, base, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("decimal value «%s» is out of range: %w", s, ErrInvalidValue)
+	}
+	return v, nil
+}
+
+// Returns unscaled decimal value from float64 using specified scale. Value is rounded half away from zero to scale digits.
+//
+// Rounding is made on the shortest decimal representation of the value, so 1.005 is rounded to 1.01, as it is written in JSON.
+func DecimalFromFloat64(f float64, scale uint8) (int64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("invalid decimal value «%v»: %w", f, ErrInvalidValue)
+	}
+	const fmtShortest, bitSize = -1, 64
+	s := strconv.FormatFloat(f, 'f', fmtShortest, bitSize)
+	roundUp := false
+	if dot := strings.IndexByte(s, '.'); (dot >= 0) && (len(s)-dot-1 > int(scale)) {
+		roundUp = s[dot+1+int(scale)] >= '5'
+		s = s[:dot+1+int(scale)]
+	}
+	v, err := ParseDecimal(s, scale)
+	if err != nil {
+		return 0, err
+	}
+	if roundUp {
+		if (v == math.MinInt64) || (v == math.MaxInt64) {
+			return 0, fmt.Errorf("decimal value «%v» is out of range: %w", f, ErrInvalidValue)
+		}
+		if f < 0 {
+			v--
+		} else {
+			v++
+		}
+	}
+	return v, nil
+}
+
+// Returns float64 representation of unscaled decimal value with specified scale.
+func DecimalToFloat64(v int64, scale uint8) float64 {
+	return float64(v) / math.Pow10(int(scale))
+}
+
+// Renders unscaled decimal value with specified scale, such as "-123.45".
+func DecimalToString(v int64, scale uint8) string {
+	const base = 10
+	s := strconv.FormatUint(uint64(v), base)
+	sign := ""
+	if v < 0 {
+		s = strconv.FormatUint(-uint64(v), base)
+		sign = "-"
+	}
+	if scale == 0 {
+		return sign + s
+	}
+	if len(s) <= int(scale) {
+		s = strings.Repeat("0", int(scale)-len(s)+1) + s
+	}
+	return sign + s[:len(s)-int(scale)] + "." + s[len(s)-int(scale):]
+}
+
+// *********************************************************************************************************
+//
+//				date & timestamp
+//
+
+// Date layout, ISO 8601 calendar date
+const DateLayout = time.DateOnly
+
+// Timestamp layout, RFC 3339 with milliseconds
+const TimestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+const secondsPerDay = 24 * 60 * 60
+
+// Parses date string representation, such as "2024-01-31", to days since 1970-01-01.
+func ParseDate(s string) (int32, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date value «%s»: %w", s, ErrInvalidValue)
+	}
+	return int32(t.Unix() / secondsPerDay), nil
+}
+
+// Renders date value (days since 1970-01-01), such as "2024-01-31".
+func DateToString(days int32) string {
+	return time.Unix(int64(days)*secondsPerDay, 0).UTC().Format(DateLayout)
+}
+
+// Returns date value (days since 1970-01-01) from float64. Value must be integral and fit int32.
+func DateFromFloat64(f float64) (int32, error) {
+	if (f != math.Trunc(f)) || (f < math.MinInt32) || (f > math.MaxInt32) {
+		return 0, fmt.Errorf("invalid date value «%v»: %w", f, ErrInvalidValue)
+	}
+	return int32(f), nil
+}
+
+// Parses RFC 3339 timestamp string representation, such as "2024-01-31T12:00:00Z", to milliseconds since 1970-01-01T00:00:00Z.
+//
+// Time zone offset is required, fractions of millisecond are truncated.
+func ParseTimestamp(s string) (int64, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp value «%s»: %w", s, ErrInvalidValue)
+	}
+	return t.UnixMilli(), nil
+}
+
+// Returns timestamp value (milliseconds since 1970-01-01T00:00:00Z) from float64. Value must be integral and fit int64.
+func TimestampFromFloat64(f float64) (int64, error) {
+	// float64(math.MaxInt64) is 2^63, which is out of int64 range
+	if (f != math.Trunc(f)) || (f < math.MinInt64) || (f >= math.MaxInt64) {
+		return 0, fmt.Errorf("invalid timestamp value «%v»: %w", f, ErrInvalidValue)
+	}
+	return int64(f), nil
+}
+
+// Renders timestamp value (milliseconds since 1970-01-01T00:00:00Z) in UTC, such as "2024-01-31T12:00:00.000Z".
+func TimestampToString(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format(TimestampLayout)
+}
+
+// *********************************************************************************************************
+//
+//				UUID
+//
+
+// Length of UUID value in bytes
+const UUIDLen = 16
+
+// Parses UUID string representation, such as "f47ac10b-58cc-4372-a567-0e02b2c3d479", to bytes.
+func ParseUUID(s string) ([]byte, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid UUID value «%s»: %w", s, ErrInvalidValue)
+	}
+	return u[:], nil
+}
+
+// Renders UUID value in canonical form, such as "f47ac10b-58cc-4372-a567-0e02b2c3d479".
+//
+// Returns empty string if value is not UUID.
+func UUIDToString(b []byte) string {
+	u, err := uuid.FromBytes(b)
+	if err != nil {
+		return ""
+	}
+	return u.String()
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istructs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("should be ok to parse and render decimals", func(t *testing.T) {
+		tests := []struct {
+			s     string
+			scale uint8
+			want  int64
+			str   string
+		}{
+			{"0", 0, 0, "0"},
+			{"123", 0, 123, "123"},
+			{"123", 2, 12300, "123.00"},
+			{"-123.45", 2, -12345, "-123.45"},
+			{"+0.5", 2, 50, "0.50"},
+			{".05", 2, 5, "0.05"},
+			{"-0.05", 2, -5, "-0.05"},
+			{"1.2300", 2, 123, "1.23"},
+			{"-0", 2, 0, "0.00"},
+			{"999999999999999999", 0, 999999999999999999, "999999999999999999"},
+		}
+		for _, tt := range tests {
+			v, err := ParseDecimal(tt.s, tt.scale)
+			require.NoError(err, tt.s)
+			require.Equal(tt.want, v, tt.s)
+			require.Equal(tt.str, DecimalToString(v, tt.scale), tt.s)
+		}
+		require.Equal("-9223372036854775808", DecimalToString(math.MinInt64, 0))
+	})
+
+	t.Run("should be error to parse invalid decimals", func(t *testing.T) {
+		for _, s := range []string{"", "-", ".", "1.2.3", "abc", "1e5", "1.234", "99999999999999999999"} {
+			_, err := ParseDecimal(s, 2)
+			require.ErrorIs(err, ErrInvalidValue, s)
+		}
+	})
+
+	t.Run("should be ok to convert decimals from and to float64", func(t *testing.T) {
+		v, err := DecimalFromFloat64(12.345, 2)
+		require.NoError(err)
+		require.EqualValues(1235, v)
+		require.Equal(12.35, DecimalToFloat64(v, 2))
+
+		v, err = DecimalFromFloat64(-0.005, 2)
+		require.NoError(err)
+		require.EqualValues(-1, v)
+
+		v, err = DecimalFromFloat64(7, 0)
+		require.NoError(err)
+		require.EqualValues(7, v)
+
+		_, err = DecimalFromFloat64(math.NaN(), 2)
+		require.ErrorIs(err, ErrInvalidValue)
+		_, err = DecimalFromFloat64(math.MaxFloat64, 2)
+		require.ErrorIs(err, ErrInvalidValue)
+	})
+}
+
+func TestDateTimestamp(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("should be ok to parse and render dates", func(t *testing.T) {
+		d, err := ParseDate("1970-01-02")
+		require.NoError(err)
+		require.EqualValues(1, d)
+
+		d, err = ParseDate("1969-12-31")
+		require.NoError(err)
+		require.EqualValues(-1, d)
+		require.Equal("1969-12-31", DateToString(d))
+
+		d, err = ParseDate("2024-02-29")
+		require.NoError(err)
+		require.Equal("2024-02-29", DateToString(d))
+
+		_, err = ParseDate("2023-02-29")
+		require.ErrorIs(err, ErrInvalidValue)
+	})
+
+	t.Run("should be ok to parse and render timestamps", func(t *testing.T) {
+		ts, err := ParseTimestamp("1970-01-01T00:00:01.5Z")
+		require.NoError(err)
+		require.EqualValues(1500, ts)
+
+		ts, err = ParseTimestamp("2024-01-31T15:00:00+03:00")
+		require.NoError(err)
+		require.Equal("2024-01-31T12:00:00.000Z", TimestampToString(ts))
+
+		_, err = ParseTimestamp("2024-01-31 12:00:00")
+		require.ErrorIs(err, ErrInvalidValue)
+	})
+
+	t.Run("should be ok to get dates and timestamps from numbers", func(t *testing.T) {
+		d, err := DateFromFloat64(-1)
+		require.NoError(err)
+		require.EqualValues(-1, d)
+
+		ts, err := TimestampFromFloat64(1706702400000)
+		require.NoError(err)
+		require.Equal("2024-01-31T12:00:00.000Z", TimestampToString(ts))
+	})
+
+	t.Run("should be error to get dates and timestamps from fractional or out of range numbers", func(t *testing.T) {
+		for _, f := range []float64{1.5, math.MaxInt32 + 1, math.MinInt32 - 1, math.NaN(), math.Inf(1)} {
+			_, err := DateFromFloat64(f)
+			require.ErrorIs(err, ErrInvalidValue, f)
+		}
+		for _, f := range []float64{1.5, math.MaxInt64, math.MinInt64 * 2, math.NaN(), math.Inf(-1)} {
+			_, err := TimestampFromFloat64(f)
+			require.ErrorIs(err, ErrInvalidValue, f)
+		}
+	})
+}
+
+func TestUUID(t *testing.T) {
+	require := require.New(t)
+
+	const s = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	b, err := ParseUUID(s)
+	require.NoError(err)
+	require.Len(b, UUIDLen)
+	require.Equal(s, UUIDToString(b))
+
+	b, err = ParseUUID("F47AC10B-58CC-4372-A567-0E02B2C3D479")
+	require.NoError(err)
+	require.Equal(s, UUIDToString(b))
+
+	_, err = ParseUUID("f47ac10b")
+	require.ErrorIs(err, ErrInvalidValue)
+
+	require.Empty(UUIDToString([]byte{1, 2, 3}))
+}
@@ -61,6 +61,7 @@ type AppConfigType struct {
 	prepared                bool
 	app                     *appStructsType
 	FunctionRateLimits      functionRateLimits
+	limits                  appLimits
 	syncProjectorFactories  []istructs.ProjectorFactory
 	asyncProjectorFactories []istructs.ProjectorFactory
 	cudValidators           []istructs.CUDValidator
@@ -142,6 +143,9 @@ func (cfg *AppConfigType) prepare(buckets irates.IBuckets, appStorage istorage.I
 	// prepare functions rate limiter
 	cfg.FunctionRateLimits.prepare(buckets)
 
+	// prepare limits declared in application definition
+	cfg.limits.prepare(cfg.AppDef, buckets)
+
 	cfg.prepared = true
 	return nil
 }
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istructsmem
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem/internal/consts"
+	"github.com/voedger/voedger/pkg/istructsmem/internal/utils"
+	"github.com/voedger/voedger/pkg/istructsmem/internal/vers"
+)
+
+// system views which data is versioned by keys of versions system view
+var versionedSysViews = map[vers.VersionKey]uint16{
+	vers.SysQNamesVersion:     consts.SysView_QNames,
+	vers.SysContainersVersion: consts.SysView_Containers,
+	vers.SysSingletonsVersion: consts.SysView_SingletonIDs,
+}
+
+// Raw storage data callback. Receives partition key, clustering columns and value of the storage record
+type RawDataCallback func(pKey, cCols, value []byte) error
+
+// Raw storage data callback. Receives partition key, clustering columns, value and time left to live of the storage record.
+// Zero ttl means the record never expires
+type RawTTLDataCallback func(pKey, cCols, value []byte, ttl time.Duration) error
+
+// Raw PLog event callback. Receives event offset and raw storage data of the event
+type RawPLogCallback func(offset istructs.Offset, pKey, cCols, value []byte) error
+
+// ReadRawSysViews reads raw data of application system views: versions, QNames, container names and singleton IDs.
+//
+// Data of PLog, WLog and Records system views is not read, these views are too large to be enumerated.
+// Versions view is read first, then all partitions of the views listed in versions
+func ReadRawSysViews(ctx context.Context, storage istorage.IAppStorage, cb RawDataCallback) error {
+	versPKey := utils.ToBytes(consts.SysView_Versions)
+	views := make(map[uint16]uint16)
+	err := storage.Read(ctx, versPKey, nil, nil,
+		func(cCols, value []byte) error {
+			if view, ok := versionedSysViews[vers.VersionKey(binary.BigEndian.Uint16(cCols))]; ok {
+				views[view] = binary.BigEndian.Uint16(value)
+			}
+			return cb(versPKey, cCols, value)
+		})
+	if err != nil {
+		return err
+	}
+
+	for view, ver := range views {
+		pKey := utils.ToBytes(view, ver)
+		err := storage.Read(ctx, pKey, nil, nil,
+			func(cCols, value []byte) error {
+				return cb(pKey, cCols, value)
+			})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadRawPLog reads raw data of PLog partition events from the first offset to the end of the partition.
+//
+// PLog is append only, so events appended concurrently are read or not, but the read part is always consistent
+func ReadRawPLog(ctx context.Context, storage istorage.IAppStorage, partition istructs.PartitionID, cb RawPLogCallback) error {
+	for hi := uint64(0); ; hi++ {
+		pKey, _ := plogKey(partition, glueLogOffset(hi, 0))
+		count := 0
+		err := storage.Read(ctx, pKey, nil, nil,
+			func(cCols, value []byte) error {
+				count++
+				return cb(glueLogOffset(hi, binary.BigEndian.Uint16(cCols)), pKey, cCols, value)
+			})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ctx.Err()
+		}
+	}
+}
+
+// ReadRawData reads raw data of all application partitions except PLog and system views,
+// which are read by ReadRawPLog and ReadRawSysViews: WLog, records, views and projectors offsets.
+//
+// Storage must implement istorage.IAppStorageScanner. Partitions are read one by one by batches,
+// so data written concurrently may be read or not, application should be stopped to read the consistent data
+func ReadRawData(ctx context.Context, storage istorage.IAppStorage, cb RawTTLDataCallback) error {
+	scanner, ok := storage.(istorage.IAppStorageScanner)
+	if !ok {
+		return ErrStorageScanNotSupported
+	}
+
+	// partition keys are collected first, since some drivers do not allow to read the partition while scanning
+	pKeys := make([][]byte, 0)
+	err := scanner.ScanPartitions(ctx, func(pKey []byte) error {
+		if isRawDataPartition(pKey) {
+			pKeys = append(pKeys, bytes.Clone(pKey))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pKey := range pKeys {
+		if err := readRawPartition(ctx, storage, pKey, cb); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// Returns true if partition is not PLog or system view partition
+func isRawDataPartition(pKey []byte) bool {
+	if len(pKey) < uint16len {
+		return true
+	}
+	switch binary.BigEndian.Uint16(pKey) {
+	case consts.SysView_Versions, consts.SysView_QNames, consts.SysView_Containers, consts.SysView_SingletonIDs, consts.SysView_PLog:
+		return false
+	}
+	return true
+}
+
+// readRawPartition reads partition by batches of rawDataBatchSize records.
+// Batch clustering columns are read first, then records are read by GetBatch, since Read does not return records TTL
+func readRawPartition(ctx context.Context, storage istorage.IAppStorage, pKey []byte, cb RawTTLDataCallback) error {
+	var from []byte
+	for {
+		cCols := make([][]byte, 0, rawDataBatchSize)
+		err := storage.Read(ctx, pKey, from, nil, func(cc, _ []byte) error {
+			cCols = append(cCols, bytes.Clone(cc))
+			if len(cCols) == rawDataBatchSize {
+				return errRawBatchRead
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errRawBatchRead) {
+			return err
+		}
+		if len(cCols) == 0 {
+			return nil
+		}
+
+		items := make([]istorage.GetBatchItem, len(cCols))
+		for i := range items {
+			items[i] = istorage.GetBatchItem{CCols: cCols[i], Data: new([]byte)}
+		}
+		if err := storage.GetBatch(pKey, items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if item.Ok { // record could expire after it was read
+				if err := cb(pKey, item.CCols, *item.Data, item.TTL); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(cCols) < rawDataBatchSize {
+			return nil
+		}
+		// the next batch starts from the nearest clustering columns after the last read ones
+		from = append(cCols[len(cCols)-1], 0)
+	}
+}
@@ -8,11 +8,13 @@ package istructsmem
 import (
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"slices"
 	"sort"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
 )
 
 // Checks value by field constraints. Return error if constraints violated
@@ -30,6 +32,12 @@ func checkConstraints(fld appdef.IField, value interface{}) (err error) {
 		err = checkNumberConstraints(fld, value.(float32))
 	case appdef.DataKind_float64:
 		err = checkNumberConstraints(fld, value.(float64))
+	case appdef.DataKind_decimal:
+		err = checkDecimalConstraints(fld, value.(int64))
+	case appdef.DataKind_UUID:
+		if l := len(value.([]byte)); l != istructs.UUIDLen {
+			err = fmt.Errorf(errFieldDataConstraintViolatedFmt, fld, fmt.Sprintf("UUID length: %d", istructs.UUIDLen), ErrDataConstraintViolation)
+		}
 	}
 	return err
 }
@@ -129,3 +137,12 @@ func checkNumberConstraints[T number](fld appdef.IField, value T) (err error) {
 
 	return err
 }
+
+// Checks unscaled decimal value by decimal field constraints. Return error if constraints violated
+func checkDecimalConstraints(fld appdef.IField, value int64) (err error) {
+	precision, scale := appdef.DecimalPrecisionScale(fld.Constraints())
+	if limit := int64(math.Pow10(int(precision))); (value <= -limit) || (value >= limit) {
+		err = fmt.Errorf(errFieldDataConstraintViolatedFmt, fld, fmt.Sprintf("Precision: %d", precision), ErrDataConstraintViolation)
+	}
+	return errors.Join(err, checkNumberConstraints(fld, istructs.DecimalToFloat64(value, scale)))
+}
@@ -65,7 +65,13 @@ func Test_checkConstraints(t *testing.T) {
 				appdef.MaxExcl(9)).
 			AddField("float64_e", appdef.DataKind_float64, false,
 				appdef.MinExcl(0),
-				appdef.MaxExcl(9))
+				appdef.MaxExcl(9)).
+			// decimal field to test precision and range: [-99.99, 99.99], greater than zero
+			AddField("decimal", appdef.DataKind_decimal, false,
+				appdef.Precision(4),
+				appdef.Scale(2),
+				appdef.MinExcl(0)).
+			AddField("uuid", appdef.DataKind_UUID, false)
 
 		app, err := adb.Build()
 		require.NoError(err)
@@ -124,6 +130,13 @@ func Test_checkConstraints(t *testing.T) {
 		{"float64_i: enum", args{"float64_i", math.E}, "float64-field «float64_i» data constraint «Enum: [1 3.14159265358"},
 		{"float64_i: ok", args{"float64_i", math.Pi}, ""},
 		//-
+		{"decimal: precision", args{"decimal", int64(10000)}, "decimal-field «decimal» data constraint «Precision: 4» violated"},
+		{"decimal: min exclusive", args{"decimal", int64(-1)}, "decimal-field «decimal» data constraint «MinExcl: 0» violated"},
+		{"decimal: ok", args{"decimal", int64(9999)}, ""},
+		//-
+		{"UUID: length", args{"uuid", []byte{1, 2, 3}}, "UUID-field «uuid» data constraint «UUID length: 16» violated"},
+		{"UUID: ok", args{"uuid", make([]byte, 16)}, ""},
+		//-
 	}
 
 	for _, tt := range tests {
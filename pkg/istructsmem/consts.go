@@ -5,6 +5,8 @@
 package istructsmem
 
 import (
+	"errors"
+
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 )
@@ -29,6 +31,12 @@ const (
 	codec_LastVersion = codec_RDB_1
 )
 
+// records count read by one batch by ReadRawData
+const rawDataBatchSize = 256
+
+// stops reading of the partition when the batch is read
+var errRawBatchRead = errors.New("raw data batch is read")
+
 // maskString is character to mask values in string cell, used for obfuscate unlogged command arguments data
 const maskString = "*"
 
@@ -57,6 +65,9 @@ var funcRateLimitNameFmt = [istructs.RateLimitKind_FakeLast]string{
 	"func_%s_byID",
 }
 
+// rate limit name format for limits declared in application definition, see GetLimitRateLimitName
+const limitRateLimitNameFmt = "limit_%s"
+
 var MatchAll = func(_ appdef.QName, _ istructs.WSID, _ appdef.QName) bool {
 	return true
 }
@@ -7,6 +7,8 @@ package istructsmem
 import (
 	"errors"
 	"fmt"
+
+	"github.com/voedger/voedger/pkg/istorage"
 )
 
 var ErrorEventNotValid = errors.New("event is not valid")
@@ -27,6 +29,10 @@ var ErrMinOccursViolation = errors.New("minimum occurs violated")
 
 var ErrMaxOccursViolation = errors.New("maximum occurs violated")
 
+var ErrCheckViolation = errors.New("check violated")
+
+var ErrGeneratedFieldReadOnly = errors.New("generated field is read-only")
+
 var ErrFieldIsEmpty = errors.New("field is empty")
 
 var ErrInvalidVerificationKind = errors.New("invalid verification kind")
@@ -59,12 +65,16 @@ var ErrTypeChanged = errors.New("type has been changed")
 
 var ErrDataConstraintViolation = errors.New("data constraint violation")
 
+var ErrStorageScanNotSupported = istorage.ErrScanNotSupported
+
 const errFieldNotFoundWrap = "%s-type field «%s» is not found in type «%v»: %w" // int32-type field «myField» is not found …
 
 const errContainerNotFoundWrap = "container «%s» is not found in type «%v»: %w" // container «order_item» is not found …
 
 const errFieldValueTypeMismatchWrap = "value type «%s» is not applicable for %v: %w" // value type «float64» is not applicable for int32-field «myField»: …
 
+const errFieldIsGenerated = "%v is generated and can not be put: %w" // string-field «fullName» is generated and can not be put: …
+
 const errFieldMustBeVerified = "field «%s» must be verified, token expected, but value «%T» passed: %w"
 
 const errFieldConvertErrorWrap = "field «%s» value type «%T» can not to be converted to «%s»: %w"
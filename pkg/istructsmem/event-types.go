@@ -403,6 +403,10 @@ func (cud *cudType) build() (err error) {
 		if err = rec.build(); err != nil {
 			return err
 		}
+		rec.calcGenerated(nil)
+		if err = rec.build(); err != nil {
+			return err
+		}
 	}
 
 	for _, rec := range cud.updates {
@@ -629,10 +633,23 @@ func (upd *updateRecType) build() (err error) {
 	}
 
 	if userChanges {
-		err = upd.result.build()
+		if err = upd.result.build(); err != nil {
+			return err
+		}
 	}
 
-	return err
+	return upd.calcGenerated()
+}
+
+// Calculates generated fields of result record and puts changed values into record changes, then rebuilds both
+func (upd *updateRecType) calcGenerated() error {
+	upd.result.calcGenerated(func(name string, value interface{}) {
+		upd.changes.dyB.Set(name, value)
+	})
+	if err := upd.changes.build(); err != nil {
+		return err
+	}
+	return upd.result.build()
 }
 
 // Return dynobuffers of all recs (origin, changes and result) to pool
@@ -855,6 +872,11 @@ func (o *objectType) FillFromJSON(data map[string]any) {
 		case bool:
 			o.PutBool(n, fv)
 		case []interface{}:
+			if fld := o.fieldDef(n); (fld != nil) && fld.IsArray() {
+				// e.g. "tags": ["new", "hot"]
+				o.putArray(n, fv)
+				continue
+			}
 			// e.g. "order_item": [<2 children>]
 			cont := o.typ.(appdef.IContainers).Container(n)
 			if cont == nil {
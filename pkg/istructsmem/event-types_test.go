@@ -19,6 +19,7 @@ import (
 	"github.com/voedger/voedger/pkg/iratesce"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem/internal/singletons"
+	"github.com/voedger/voedger/pkg/parser"
 )
 
 func TestEventBuilder(t *testing.T) {
@@ -1132,6 +1133,127 @@ func Test_SingletonCDocEvent(t *testing.T) {
 	})
 }
 
+func Test_GeneratedFieldsEvent(t *testing.T) {
+	require := require.New(t)
+
+	docName := appdef.NewQName("test", "person")
+
+	appDef := appdef.New()
+	appDef.SetExpressionCompiler(parser.ExpressionCompiler())
+
+	t.Run("must ok to construct CDoc with generated fields", func(t *testing.T) {
+		doc := appDef.AddCDoc(docName)
+		doc.
+			AddField("firstName", appdef.DataKind_string, true).
+			AddField("lastName", appdef.DataKind_string, true).
+			AddField("fullName", appdef.DataKind_string, true).
+			AddField("searchKey", appdef.DataKind_string, false, appdef.MaxLen(8)).
+			SetFieldGenerated("fullName", "CONCAT(firstName, ' ', lastName)").
+			SetFieldGenerated("searchKey", "LOWER(lastName)")
+	})
+
+	cfgs := make(AppConfigsType, 1)
+	cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+
+	provider := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvider())
+
+	app, err := provider.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	eventBuilder := func(offset istructs.Offset) istructs.IRawEventBuilder {
+		return app.Events().GetNewRawEventBuilder(
+			istructs.NewRawEventBuilderParams{
+				GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+					HandlingPartition: 1,
+					PLogOffset:        offset,
+					Workspace:         1,
+					WLogOffset:        offset,
+					QName:             istructs.QNameCommandCUD, // sys.CUD
+					RegisteredAt:      1,
+				},
+			})
+	}
+
+	docID := istructs.NullRecordID
+
+	t.Run("must ok to calculate generated fields on create", func(t *testing.T) {
+		bld := eventBuilder(100500)
+		cud := bld.CUDBuilder().Create(docName)
+		cud.PutRecordID(appdef.SystemField_ID, 1)
+		cud.PutString("firstName", "John")
+		cud.PutString("lastName", "Doe")
+
+		rawEvent, err := bld.BuildRawEvent()
+		require.NoError(err)
+
+		pLogEvent, saveErr := app.Events().PutPlog(rawEvent, err, NewIDGenerator())
+		require.NoError(saveErr)
+		require.True(pLogEvent.Error().ValidEvent())
+
+		pLogEvent.CUDs(func(rec istructs.ICUDRow) {
+			require.Equal("John Doe", rec.AsString("fullName"))
+			require.Equal("doe", rec.AsString("searchKey"))
+			docID = rec.ID()
+		})
+
+		require.NoError(app.Records().Apply(pLogEvent))
+	})
+
+	t.Run("must ok to recalculate generated fields on update", func(t *testing.T) {
+		bld := eventBuilder(100501)
+		rec, err := app.Records().Get(1, true, docID)
+		require.NoError(err)
+		cud := bld.CUDBuilder().Update(rec)
+		cud.PutString("lastName", "Smith")
+
+		rawEvent, err := bld.BuildRawEvent()
+		require.NoError(err)
+
+		pLogEvent, saveErr := app.Events().PutPlog(rawEvent, err, NewIDGenerator())
+		require.NoError(saveErr)
+		require.True(pLogEvent.Error().ValidEvent())
+
+		pLogEvent.CUDs(func(rec istructs.ICUDRow) {
+			// projectors see generated values in changes
+			require.Equal("John Smith", rec.AsString("fullName"))
+			require.Equal("smith", rec.AsString("searchKey"))
+		})
+
+		require.NoError(app.Records().Apply(pLogEvent))
+
+		rec, err = app.Records().Get(1, true, docID)
+		require.NoError(err)
+		require.Equal("John", rec.AsString("firstName"))
+		require.Equal("John Smith", rec.AsString("fullName"))
+		require.Equal("smith", rec.AsString("searchKey"))
+	})
+
+	t.Run("must error if put generated field", func(t *testing.T) {
+		bld := eventBuilder(100502)
+		cud := bld.CUDBuilder().Create(docName)
+		cud.PutRecordID(appdef.SystemField_ID, 1)
+		cud.PutString("firstName", "John")
+		cud.PutString("lastName", "Doe")
+		cud.PutString("fullName", "Jane Doe") // <- error here
+
+		_, err := bld.BuildRawEvent()
+		require.ErrorIs(err, ErrGeneratedFieldReadOnly)
+		require.ErrorContains(err, "string-field «fullName» is generated")
+	})
+
+	t.Run("must error if generated value violates field constraints", func(t *testing.T) {
+		bld := eventBuilder(100502)
+		cud := bld.CUDBuilder().Create(docName)
+		cud.PutRecordID(appdef.SystemField_ID, 1)
+		cud.PutString("firstName", "John")
+		cud.PutString("lastName", "Doe-Smith") // <- error here, searchKey is too long
+
+		_, err := bld.BuildRawEvent()
+		require.ErrorIs(err, ErrDataConstraintViolation)
+		require.ErrorContains(err, "string-field «searchKey» data constraint «MaxLen: 8»")
+	})
+}
+
 func TestEventBuild_Error(t *testing.T) {
 	require := require.New(t)
 	test := test()
@@ -196,6 +196,14 @@ func (app *appStructsType) IsFunctionRateLimitsExceeded(funcQName appdef.QName,
 	return !app.buckets.TakeTokens(keys, 1)
 }
 
+func (app *appStructsType) IsLimitExceeded(resource appdef.QName, scopes istructs.LimitScopes) bool {
+	keys := app.config.limits.bucketKeys(app.config.Name, resource, scopes)
+	if len(keys) == 0 {
+		return false
+	}
+	return !app.buckets.TakeTokens(keys, 1)
+}
+
 func (app *appStructsType) describe() *descr.Application {
 	if app.descr == nil {
 		app.descr = descr.Provide(app, app.config.FunctionRateLimits.limits)
@@ -212,7 +220,10 @@ func (app *appStructsType) DescribePackageNames() (names []string) {
 }
 
 // istructs.IAppStructs.DescribePackage: Describe package content
-func (app *appStructsType) DescribePackage(name string) interface{} {
+func (app *appStructsType) DescribePackage(name string, tags ...appdef.QName) interface{} {
+	if len(tags) > 0 {
+		return descr.Provide(app, app.config.FunctionRateLimits.limits, tags...).Packages[name]
+	}
 	return app.describe().Packages[name]
 }
 
@@ -17,6 +17,14 @@ type Extension struct {
 	Type
 	Name   string
 	Engine string
+	Limits *ExtensionLimits `json:",omitempty"`
+}
+
+type ExtensionLimits struct {
+	MaxDuration    string `json:",omitempty"`
+	MaxHostCalls   uint   `json:",omitempty"`
+	MaxIntents     uint   `json:",omitempty"`
+	MaxMemoryPages uint   `json:",omitempty"`
 }
 
 type Function struct {
@@ -47,6 +47,16 @@ func (e *Extension) read(ex appdef.IExtension) {
 	e.Type.read(ex)
 	e.Name = ex.Name()
 	e.Engine = ex.Engine().TrimString()
+	if l := ex.Limits(); l != (appdef.ExtensionLimits{}) {
+		e.Limits = &ExtensionLimits{
+			MaxHostCalls:   l.MaxHostCalls,
+			MaxIntents:     l.MaxIntents,
+			MaxMemoryPages: l.MaxMemoryPages,
+		}
+		if l.MaxDuration > 0 {
+			e.Limits.MaxDuration = l.MaxDuration.String()
+		}
+	}
 }
 
 func (f *Function) read(fn appdef.IFunction) {
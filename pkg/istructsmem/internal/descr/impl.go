@@ -6,6 +6,8 @@
 package descr
 
 import (
+	"slices"
+
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 )
@@ -17,18 +19,46 @@ func newApplication() *Application {
 	return &a
 }
 
-func (a *Application) read(app istructs.IAppStructs, rateLimits map[appdef.QName]map[istructs.RateLimitKind]istructs.RateLimit) {
+func (a *Application) read(app istructs.IAppStructs, rateLimits map[appdef.QName]map[istructs.RateLimitKind]istructs.RateLimit, tags ...appdef.QName) {
 	a.Packages = make(map[string]*Package)
 
 	a.Name = app.AppQName()
 
-	app.AppDef().Types(func(typ appdef.IType) {
+	appDef := app.AppDef()
+
+	// returns true if type with specified name should be described
+	described := func(name appdef.QName) bool {
+		if len(tags) == 0 {
+			return true
+		}
+		for _, t := range appDef.TagsOf(name) {
+			if slices.Contains(tags, t) {
+				return true
+			}
+		}
+		return false
+	}
+
+	appDef.Types(func(typ appdef.IType) {
 		name := typ.QName()
 
 		if name.Pkg() == appdef.SysPackage {
 			return
 		}
 
+		if typ.Kind() == appdef.TypeKind_Tag {
+			if len(tags) == 0 || slices.Contains(tags, name) {
+				t := newTag()
+				t.read(typ.(appdef.ITag))
+				getPkg(name, a).Tags[name.String()] = t
+			}
+			return
+		}
+
+		if !described(name) {
+			return
+		}
+
 		pkg := getPkg(name, a)
 
 		if data, ok := typ.(appdef.IData); ok {
@@ -64,6 +94,9 @@ func (a *Application) read(app istructs.IAppStructs, rateLimits map[appdef.QName
 	})
 
 	for qName, qNameRateLimit := range rateLimits {
+		if !described(qName) {
+			continue
+		}
 		pkg := getPkg(qName, a)
 		for rlKind, rl := range qNameRateLimit {
 			rateLimit := newRateLimit()
@@ -88,6 +121,7 @@ func getPkg(name appdef.QName, a *Application) *Package {
 
 func newPackage() *Package {
 	return &Package{
+		Tags:       make(map[string]*Tag),
 		DataTypes:  make(map[string]*Data),
 		Structures: make(map[string]*Structure),
 		Views:      make(map[string]*View),
@@ -14,6 +14,7 @@ type Application struct {
 
 type Package struct {
 	Name       string                  `json:"-"`
+	Tags       map[string]*Tag         `json:",omitempty"`
 	DataTypes  map[string]*Data        `json:",omitempty"`
 	Structures map[string]*Structure   `json:",omitempty"`
 	Views      map[string]*View        `json:",omitempty"`
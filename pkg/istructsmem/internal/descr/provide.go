@@ -10,8 +10,11 @@ import (
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
-func Provide(app istructs.IAppStructs, rateLimits map[appdef.QName]map[istructs.RateLimitKind]istructs.RateLimit) *Application {
+// Returns application description.
+//
+// If tags are specified, then only types with any of these tags are described.
+func Provide(app istructs.IAppStructs, rateLimits map[appdef.QName]map[istructs.RateLimitKind]istructs.RateLimit, tags ...appdef.QName) *Application {
 	a := newApplication()
-	a.read(app, rateLimits)
+	a.read(app, rateLimits, tags...)
 	return a
 }
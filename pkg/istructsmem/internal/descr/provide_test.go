@@ -9,6 +9,7 @@ import (
 	_ "embed"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -51,7 +52,8 @@ func TestBasicUsage(t *testing.T) {
 		AddField("phone", appdef.DataKind_string, true, appdef.MinLen(1), appdef.MaxLen(25)).
 		SetFieldVerify("phone", appdef.VerificationKind_Any...).(appdef.ICRecordBuilder).
 		SetUniqueField("phone").
-		AddUnique(appdef.UniqueQName(rec.QName(), "uniq1"), []string{"f1"})
+		AddUnique(appdef.UniqueQName(rec.QName(), "uniq1"), []string{"f1"}).(appdef.ICRecordBuilder).
+		AddIndex(appdef.NewQName("test", "recByF2"), []string{"f2", "f1"}, "index comment")
 
 	viewName := appdef.NewQName("test", "view")
 	view := appDef.AddView(viewName)
@@ -67,11 +69,17 @@ func TestBasicUsage(t *testing.T) {
 	obj := appDef.AddObject(objName)
 	obj.AddField("f1", appdef.DataKind_string, true)
 
+	tagName := appdef.NewQName("test", "tag")
+	appDef.AddTag(tagName).SetComment("tag comment")
+
 	cmdName := appdef.NewQName("test", "cmd")
-	appDef.AddCommand(cmdName).
+	cmd := appDef.AddCommand(cmdName)
+	cmd.AddTag(tagName)
+	cmd.
 		SetUnloggedParam(objName).
 		SetParam(objName).
-		SetEngine(appdef.ExtensionEngineKind_WASM)
+		SetEngine(appdef.ExtensionEngineKind_WASM).
+		SetLimits(appdef.ExtensionLimits{MaxDuration: 100 * time.Millisecond, MaxIntents: 10})
 
 	appDef.AddQuery(appdef.NewQName("test", "query")).
 		SetParam(objName).
@@ -109,6 +117,26 @@ func TestBasicUsage(t *testing.T) {
 	//ioutil.WriteFile("C://temp//provide_test.json", json, 0644)
 
 	require.JSONEq(expectedJson, string(json))
+
+	t.Run("must be ok to describe types with tag", func(t *testing.T) {
+		app := Provide(appStr, appLimits, tagName)
+
+		pkg := app.Packages["test"]
+		require.NotNil(pkg)
+
+		require.Len(pkg.Tags, 1)
+		require.Equal("tag comment", pkg.Tags[tagName.String()].Comment)
+
+		require.Empty(pkg.DataTypes)
+		require.Empty(pkg.Structures)
+		require.Empty(pkg.Views)
+
+		require.NotNil(pkg.Extensions)
+		require.Len(pkg.Extensions.Commands, 1)
+		require.Equal(appdef.QNames{tagName}, pkg.Extensions.Commands[cmdName].Tags)
+		require.Empty(pkg.Extensions.Queries)
+		require.Empty(pkg.Extensions.Projectors)
+	})
 }
 
 type mockedAppStructs struct {
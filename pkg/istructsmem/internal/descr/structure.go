@@ -16,6 +16,7 @@ type Structure struct {
 	Containers  []*Container       `json:",omitempty"`
 	Uniques     map[string]*Unique `json:",omitempty"`
 	UniqueField string             `json:",omitempty"`
+	Indexes     []*Index           `json:",omitempty"`
 	Singleton   bool               `json:",omitempty"`
 }
 
@@ -26,6 +27,7 @@ type Field struct {
 	Data       *appdef.QName `json:",omitempty"`
 	Required   bool          `json:",omitempty"`
 	Verifiable bool          `json:",omitempty"`
+	Generated  string        `json:",omitempty"`
 	Refs       []string      `json:",omitempty"`
 }
 
@@ -42,3 +44,9 @@ type Unique struct {
 	Name    appdef.QName
 	Fields  []string
 }
+
+type Index struct {
+	Comment string `json:",omitempty"`
+	Name    appdef.QName
+	Fields  []string
+}
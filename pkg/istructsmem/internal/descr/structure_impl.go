@@ -43,6 +43,14 @@ func (s *Structure) read(str appdef.IStructure) {
 		s.UniqueField = uf.Name()
 	}
 
+	if rec, ok := str.(appdef.IRecord); ok {
+		for _, index := range rec.Indexes() {
+			i := newIndex()
+			i.read(index)
+			s.Indexes = append(s.Indexes, i)
+		}
+	}
+
 	if cDoc, ok := str.(appdef.ICDoc); ok {
 		if cDoc.Singleton() {
 			s.Singleton = true
@@ -64,6 +72,7 @@ func (f *Field) read(field appdef.IField) {
 	}
 	f.Required = field.Required()
 	f.Verifiable = field.Verifiable()
+	f.Generated = field.GeneratedAs()
 	if ref, ok := field.(appdef.IRefField); ok {
 		for _, r := range ref.Refs() {
 			f.Refs = append(f.Refs, r.String())
@@ -92,3 +101,14 @@ func (u *Unique) read(unique appdef.IUnique) {
 		u.Fields = append(u.Fields, f.Name())
 	}
 }
+
+func newIndex() *Index { return &Index{} }
+
+func (i *Index) read(index appdef.IIndex) {
+	i.Comment = readComment(index)
+
+	i.Name = index.Name()
+	for _, f := range index.Fields() {
+		i.Fields = append(i.Fields, f.Name())
+	}
+}
@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2024-present Sigma-Soft, Ltd.
+ */
+
+package descr
+
+type Tag struct {
+	Type
+}
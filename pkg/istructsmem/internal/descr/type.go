@@ -9,6 +9,7 @@ import "github.com/voedger/voedger/pkg/appdef"
 
 type Type struct {
 	Comment string          `json:",omitempty"`
+	Tags    appdef.QNames   `json:",omitempty"`
 	QName   appdef.QName    `json:"-"`
 	Kind    appdef.TypeKind `json:"-"`
 }
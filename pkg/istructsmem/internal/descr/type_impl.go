@@ -9,6 +9,7 @@ import "github.com/voedger/voedger/pkg/appdef"
 
 func (t *Type) read(typ appdef.IType) {
 	t.Comment = readComment(typ)
+	t.Tags = typ.App().TagsOf(typ.QName())
 	t.QName = typ.QName()
 	t.Kind = typ.Kind()
 }
@@ -9,6 +9,7 @@ type View struct {
 	Type
 	Key   Key
 	Value []*Field `json:",omitempty"`
+	TTL   string   `json:",omitempty"`
 }
 
 type Key struct {
@@ -24,6 +24,10 @@ func (v *View) read(view appdef.IView) {
 		f.read(fld)
 		v.Value = append(v.Value, f)
 	}
+
+	if ttl := view.TTL(); ttl > 0 {
+		v.TTL = ttl.String()
+	}
 }
 
 func (k *Key) read(key appdef.IViewKey) {
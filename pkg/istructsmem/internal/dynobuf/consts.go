@@ -21,6 +21,10 @@ var dataKindToDynoFieldType = map[appdef.DataKind]dynobuffers.FieldType{
 	appdef.DataKind_QName:    dynobuffers.FieldTypeByte, // two fixed bytes LittleEndian
 	appdef.DataKind_bool:     dynobuffers.FieldTypeBool,
 	appdef.DataKind_RecordID: dynobuffers.FieldTypeInt64,
+	appdef.DataKind_decimal:   dynobuffers.FieldTypeInt64, // unscaled value
+	appdef.DataKind_date:      dynobuffers.FieldTypeInt32, // days since 1970-01-01
+	appdef.DataKind_timestamp: dynobuffers.FieldTypeInt64, // milliseconds since 1970-01-01T00:00:00Z
+	appdef.DataKind_UUID:      dynobuffers.FieldTypeByte,  // 16 bytes
 	appdef.DataKind_Record:   dynobuffers.FieldTypeByte,
 	appdef.DataKind_Event:    dynobuffers.FieldTypeByte,
 }
@@ -27,7 +27,7 @@ func NewFieldsScheme(name string, fields appdef.IFields) *dynobuffers.Scheme {
 	for _, f := range fields.Fields() {
 		if !f.IsSys() { // #18142: extract system fields from dynobuffer
 			ft := DataKindToFieldType(f.DataKind())
-			if ft == dynobuffers.FieldTypeByte {
+			if f.IsArray() || (ft == dynobuffers.FieldTypeByte) {
 				db.AddArray(f.Name(), ft, false)
 			} else {
 				db.AddField(f.Name(), ft, false)
@@ -84,6 +84,12 @@ func (names *QNames) collectAll(appDef appdef.IAppDef, r istructs.IResources) (e
 							names.collect(u.Name()))
 					}
 				}
+				if ii, ok := t.(appdef.IIndexes); ok {
+					for _, i := range ii.Indexes() {
+						err = errors.Join(err,
+							names.collect(i.Name()))
+					}
+				}
 			})
 	}
 
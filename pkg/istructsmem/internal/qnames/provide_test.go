@@ -31,6 +31,8 @@ func TestQNamesBasicUsage(t *testing.T) {
 	d := app.AddCDoc(testName)
 	d.AddField("f1", appdef.DataKind_int64, false)
 	d.AddUnique(appdef.UniqueQName(testName, "f1"), []string{"f1"})
+	idxName := appdef.NewQName("test", "docByF1")
+	d.AddIndex(idxName, []string{"f1"})
 	appDef, err := app.Build()
 	if err != nil {
 		panic(err)
@@ -53,6 +55,12 @@ func TestQNamesBasicUsage(t *testing.T) {
 		require.NoError(err)
 		require.Equal(testName, n)
 
+		t.Run("must be able to get index name ID", func(t *testing.T) {
+			id, err := names.ID(idxName)
+			require.NoError(err)
+			require.NotEqual(NullQNameID, id)
+		})
+
 		t.Run("must be able to load early stored names", func(t *testing.T) {
 			otherVersions := vers.New()
 			if err := otherVersions.Prepare(storage); err != nil {
@@ -8,6 +8,7 @@ package teststore
 import (
 	"bytes"
 	"context"
+	"time"
 
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorage/mem"
@@ -108,25 +109,30 @@ func (s *TestMemStorage) SchedulePutError(err error, pKey, cCols []byte) {
 }
 
 func (s *TestMemStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	ok, _, err = s.TTLGet(pKey, cCols, data)
+	return ok, err
+}
+
+func (s *TestMemStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, ttl time.Duration, err error) {
 	if s.get.err != nil {
 		if s.get.match(pKey, cCols) {
 			err = s.get.err
 			s.get.err = nil
-			return false, err
+			return false, 0, err
 		}
 	}
 
-	ok, err = s.storage.Get(pKey, cCols, data)
+	ok, ttl, err = s.storage.TTLGet(pKey, cCols, data)
 
 	if ok && (s.damage.dam != nil) {
 		if s.damage.match(pKey, cCols) {
 			s.damage.dam(data)
 			s.damage.dam = nil
-			return ok, err
+			return ok, ttl, err
 		}
 	}
 
-	return ok, err
+	return ok, ttl, err
 }
 
 func (s *TestMemStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
@@ -169,6 +175,12 @@ func (s *TestMemStorage) Put(pKey []byte, cCols []byte, value []byte) (err error
 
 func (s *TestMemStorage) PutBatch(items []istorage.BatchItem) (err error) {
 	for _, p := range items {
+		if p.Deleted {
+			if err = s.storage.PutBatch([]istorage.BatchItem{p}); err != nil {
+				return err
+			}
+			continue
+		}
 		if err = s.Put(p.PKey, p.CCols, p.Value); err != nil {
 			return err
 		}
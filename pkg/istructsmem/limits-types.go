@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istructsmem
+
+import (
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Limits declared in application definition
+type appLimits struct {
+	limits map[appdef.QName][]appdef.ILimit // by limited type
+}
+
+func (al *appLimits) prepare(appDef appdef.IAppDef, buckets irates.IBuckets) {
+	al.limits = make(map[appdef.QName][]appdef.ILimit)
+	appDef.Limits(func(l appdef.ILimit) {
+		buckets.SetDefaultBucketState(GetLimitRateLimitName(l.QName()), irates.BucketState{
+			Period:             l.Rate().Period(),
+			MaxTokensPerPeriod: irates.NumTokensType(l.Rate().Count()),
+		})
+		for _, n := range l.Limited() {
+			al.limits[n] = append(al.limits[n], l)
+		}
+	})
+}
+
+// Returns bucket keys of all limits on the resource within the operation scopes
+func (al *appLimits) bucketKeys(app istructs.AppQName, resource appdef.QName, scopes istructs.LimitScopes) []irates.BucketKey {
+	limits := al.limits[resource]
+	if len(limits) == 0 {
+		return nil
+	}
+	keys := make([]irates.BucketKey, 0, len(limits))
+	for _, l := range limits {
+		key := irates.BucketKey{
+			RateLimitName: GetLimitRateLimitName(l.QName()),
+			App:           app,
+		}
+		for _, s := range l.Rate().Scopes() {
+			switch s {
+			case appdef.RateScope_AppPartition:
+				key.Partition = scopes.Partition
+			case appdef.RateScope_Workspace:
+				key.Workspace = scopes.Workspace
+			case appdef.RateScope_User:
+				key.User = scopes.User
+			case appdef.RateScope_IP:
+				key.RemoteAddr = scopes.RemoteAddr
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func GetLimitRateLimitName(limit appdef.QName) string {
+	return fmt.Sprintf(limitRateLimitNameFmt, limit)
+}
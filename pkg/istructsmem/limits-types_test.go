@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package istructsmem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func TestLimits_BasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	cmdName, docName := appdef.NewQName("test", "cmd"), appdef.NewQName("test", "doc")
+	wsRate, ipRate := appdef.NewQName("test", "wsRate"), appdef.NewQName("test", "ipRate")
+
+	appDef := appdef.New()
+	appDef.AddCommand(cmdName)
+	appDef.AddCDoc(docName).AddField("f", appdef.DataKind_int32, false)
+
+	// - per workspace and user: not often than twice per minute
+	// - per IP: not often than 3 times per hour, both for command and table
+	appDef.AddRate(wsRate, 2, time.Minute, appdef.RateScope_Workspace, appdef.RateScope_User)
+	appDef.AddRate(ipRate, 3, time.Hour, appdef.RateScope_IP)
+	appDef.AddLimit(appdef.NewQName("test", "cmdLimit"), []appdef.QName{cmdName}, wsRate)
+	appDef.AddLimit(appdef.NewQName("test", "ipLimit"), []appdef.QName{cmdName, docName}, ipRate)
+
+	cfgs := make(AppConfigsType)
+	_ = cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+	provider := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvider())
+
+	as, err := provider.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	scopes := istructs.LimitScopes{Partition: 1, Workspace: 42, User: "alice", RemoteAddr: "1.2.3.4"}
+
+	require.False(as.IsLimitExceeded(cmdName, scopes))
+	require.False(as.IsLimitExceeded(cmdName, scopes))
+	require.True(as.IsLimitExceeded(cmdName, scopes), "per workspace and user limit is exceeded")
+
+	t.Run("must be ok for other user", func(t *testing.T) {
+		s := scopes
+		s.User = "bob"
+		require.False(as.IsLimitExceeded(cmdName, s))
+	})
+
+	t.Run("must be exceeded by IP on table", func(t *testing.T) {
+		require.True(as.IsLimitExceeded(docName, scopes), "ip limit is shared between command and table")
+
+		s := scopes
+		s.RemoteAddr = "5.6.7.8"
+		require.False(as.IsLimitExceeded(docName, s))
+	})
+
+	t.Run("must be ok after period", func(t *testing.T) {
+		coreutils.TestNow = coreutils.TestNow.Add(time.Hour)
+		require.False(as.IsLimitExceeded(cmdName, scopes))
+	})
+
+	t.Run("must be false if resource is not limited", func(t *testing.T) {
+		require.False(as.IsLimitExceeded(appdef.NewQName("test", "unknown"), scopes))
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem/internal/containers"
+	"github.com/voedger/voedger/pkg/istructsmem/internal/dynobuf"
 	"github.com/voedger/voedger/pkg/istructsmem/internal/qnames"
 	"github.com/voedger/voedger/pkg/istructsmem/internal/utils"
 )
@@ -25,6 +26,8 @@ import (
 //
 //	— float64 value can be converted to all numeric kinds (int32, int64, float32, float64, RecordID)
 //	— string value can be converted to QName and []byte kinds
+//	— float64 and string values can be converted to date and timestamp kinds
+//	— string value can be converted to UUID kind
 //
 // QName values, record- and event- values returned as []byte
 func (row *rowType) dynoBufValue(value interface{}, kind appdef.DataKind) (interface{}, error) {
@@ -106,6 +109,36 @@ func (row *rowType) dynoBufValue(value interface{}, kind appdef.DataKind) (inter
 		case istructs.RecordID:
 			return int64(v), nil
 		}
+	case appdef.DataKind_decimal:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		}
+	case appdef.DataKind_date:
+		switch v := value.(type) {
+		case int32:
+			return v, nil
+		case float64:
+			return int32(v), nil
+		case string:
+			return istructs.ParseDate(v)
+		}
+	case appdef.DataKind_timestamp:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(v), nil
+		case string:
+			return istructs.ParseTimestamp(v)
+		}
+	case appdef.DataKind_UUID:
+		switch v := value.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return istructs.ParseUUID(v)
+		}
 	case appdef.DataKind_Record:
 		switch v := value.(type) {
 		case *recordType:
@@ -120,6 +153,69 @@ func (row *rowType) dynoBufValue(value interface{}, kind appdef.DataKind) (inter
 	return nil, fmt.Errorf("value has type «%T», but «%s» expected: %w", value, kind.TrimString(), ErrWrongFieldType)
 }
 
+// Converts specified items to dyno-buffer compatible array ([]int32, []int64, []float32, []float64, []string or []bool)
+// using data kind of specified array field. Every item is checked by field constraints.
+//
+// Items are converted by dynoBufValue, decimal items also can be specified as float64 or string
+func (row *rowType) dynoBufArray(fld appdef.IField, items []interface{}) (interface{}, error) {
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		v, err := row.dynoBufArrayItem(fld, item)
+		if err != nil {
+			return nil, fmt.Errorf("%v item [%d]: %w", fld, i, err)
+		}
+		if err := checkConstraints(fld, v); err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	switch dynobuf.DataKindToFieldType(fld.DataKind()) {
+	case dynobuffers.FieldTypeInt32:
+		return arrayOf[int32](values), nil
+	case dynobuffers.FieldTypeInt64:
+		return arrayOf[int64](values), nil
+	case dynobuffers.FieldTypeFloat32:
+		return arrayOf[float32](values), nil
+	case dynobuffers.FieldTypeFloat64:
+		return arrayOf[float64](values), nil
+	case dynobuffers.FieldTypeString:
+		return arrayOf[string](values), nil
+	case dynobuffers.FieldTypeBool:
+		return arrayOf[bool](values), nil
+	}
+	return nil, fmt.Errorf("%v: %s-data can not be array item: %w", fld, fld.DataKind().TrimString(), ErrWrongFieldType)
+}
+
+func (row *rowType) dynoBufArrayItem(fld appdef.IField, item interface{}) (interface{}, error) {
+	if fld.DataKind() == appdef.DataKind_decimal {
+		_, scale := appdef.DecimalPrecisionScale(fld.Constraints())
+		switch v := item.(type) {
+		case float64:
+			return istructs.DecimalFromFloat64(v, scale)
+		case string:
+			return istructs.ParseDecimal(v, scale)
+		}
+	}
+	return row.dynoBufValue(item, fld.DataKind())
+}
+
+// Returns typed array from specified values. Values must have T type
+func arrayOf[T any](values []interface{}) []T {
+	res := make([]T, len(values))
+	for i, v := range values {
+		res[i] = v.(T)
+	}
+	return res
+}
+
+// Calls callback for each array item
+func rangeArray[T any](items []T, cb func(int, interface{})) {
+	for i, v := range items {
+		cb(i, v)
+	}
+}
+
 func dynoBufGetWord(dyB *dynobuffers.Buffer, fieldName string) (value uint16, ok bool) {
 	if b := dyB.GetByteArray(fieldName); b != nil {
 		if bytes := b.Bytes(); len(bytes) == 2 {
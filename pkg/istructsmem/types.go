@@ -118,6 +118,37 @@ func (row *rowType) build() (err error) {
 	return err
 }
 
+// Calculates generated fields values and sets changed values into row. Calls changed for each changed field.
+//
+// Row should be built before calculation and should be rebuilt after if some value changed.
+// Value in changed callback is nil if field value became NULL.
+func (row *rowType) calcGenerated(changed func(name string, value interface{})) {
+	for _, fld := range row.fields.Fields() {
+		if !fld.IsGenerated() {
+			continue
+		}
+		value, err := fld.Generate(row)
+		if err != nil {
+			row.collectError(err)
+			continue
+		}
+		name := fld.Name()
+		if row.dyB.Get(name) == value {
+			continue
+		}
+		if value != nil {
+			if err := checkConstraints(fld, value); err != nil {
+				row.collectError(err)
+				continue
+			}
+		}
+		row.dyB.Set(name, value)
+		if changed != nil {
+			changed(name, value)
+		}
+	}
+}
+
 // clear clears row by set QName to NullQName value
 func (row *rowType) clear() {
 	row.typ = appdef.NullType
@@ -191,9 +222,10 @@ func (row *rowType) fieldDef(name string) appdef.IField {
 // # Panics:
 //   - if field not found
 //   - if field has different data kind
+//   - if field is array
 func (row *rowType) fieldMustExists(name string, k appdef.DataKind, otherKinds ...appdef.DataKind) appdef.IField {
 	f := row.fieldDef(name)
-	if f != nil {
+	if (f != nil) && !f.IsArray() {
 		if f.DataKind() == k {
 			return f
 		}
@@ -245,6 +277,36 @@ func (row *rowType) maskValues() {
 	}
 }
 
+// Puts specified items into array field.
+//
+// Items are converted to field data kind and checked by field constraints, collects error if conversion or check failed
+func (row *rowType) putArray(name string, items []interface{}) {
+	if a, ok := row.typ.(appdef.IWithAbstract); ok {
+		if a.Abstract() {
+			row.collectErrorf("%v: unable to put to abstract type: %w", row.QName(), ErrAbstractType)
+			return
+		}
+	}
+
+	fld := row.fieldDef(name)
+	if fld == nil {
+		row.collectErrorf(errFieldNotFoundWrap, "array", name, row.QName(), ErrNameNotFound)
+		return
+	}
+	if !fld.IsArray() {
+		row.collectErrorf(errFieldValueTypeMismatchWrap, "array", fld, ErrWrongFieldType)
+		return
+	}
+
+	value, err := row.dynoBufArray(fld, items)
+	if err != nil {
+		row.collectError(err)
+		return
+	}
+
+	row.dyB.Set(name, value)
+}
+
 // Checks is field specified name and kind exists in dynobuffers scheme.
 //
 // If exists then puts specified field value into dynoBuffer else collects error.
@@ -267,6 +329,16 @@ func (row *rowType) putValue(name string, kind dynobuffers.FieldType, value inte
 		return
 	}
 
+	if fld.IsArray() {
+		row.collectErrorf(errFieldValueTypeMismatchWrap, dynobuf.FieldTypeToString(kind), fld, ErrWrongFieldType)
+		return
+	}
+
+	if fld.IsGenerated() {
+		row.collectErrorf(errFieldIsGenerated, fld, ErrGeneratedFieldReadOnly)
+		return
+	}
+
 	if fld.Verifiable() {
 		token, ok := value.(string)
 		if !ok {
@@ -503,7 +575,7 @@ func (row *rowType) verifyToken(fld appdef.IField, token string) (value interfac
 
 // istructs.IRowReader.AsInt32
 func (row *rowType) AsInt32(name string) (value int32) {
-	_ = row.fieldMustExists(name, appdef.DataKind_int32)
+	_ = row.fieldMustExists(name, appdef.DataKind_int32, appdef.DataKind_date)
 	if value, ok := row.dyB.GetInt32(name); ok {
 		return value
 	}
@@ -512,7 +584,7 @@ func (row *rowType) AsInt32(name string) (value int32) {
 
 // istructs.IRowReader.AsInt64
 func (row *rowType) AsInt64(name string) (value int64) {
-	_ = row.fieldMustExists(name, appdef.DataKind_int64, appdef.DataKind_RecordID)
+	_ = row.fieldMustExists(name, appdef.DataKind_int64, appdef.DataKind_RecordID, appdef.DataKind_decimal, appdef.DataKind_timestamp)
 	if value, ok := row.dyB.GetInt64(name); ok {
 		return value
 	}
@@ -531,7 +603,7 @@ func (row *rowType) AsFloat32(name string) (value float32) {
 // istructs.IRowReader.AsFloat64
 func (row *rowType) AsFloat64(name string) (value float64) {
 	fld := row.fieldMustExists(name, appdef.DataKind_float64,
-		appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_float32, appdef.DataKind_RecordID)
+		appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_float32, appdef.DataKind_RecordID, appdef.DataKind_decimal)
 	switch fld.DataKind() {
 	case appdef.DataKind_int32:
 		if value, ok := row.dyB.GetInt32(name); ok {
@@ -549,13 +621,18 @@ func (row *rowType) AsFloat64(name string) (value float64) {
 		if value, ok := row.dyB.GetFloat64(name); ok {
 			return value
 		}
+	case appdef.DataKind_decimal:
+		if value, ok := row.dyB.GetInt64(name); ok {
+			_, scale := appdef.DecimalPrecisionScale(fld.Constraints())
+			return istructs.DecimalToFloat64(value, scale)
+		}
 	}
 	return 0
 }
 
 // istructs.IRowReader.AsBytes
 func (row *rowType) AsBytes(name string) (value []byte) {
-	_ = row.fieldMustExists(name, appdef.DataKind_bytes)
+	_ = row.fieldMustExists(name, appdef.DataKind_bytes, appdef.DataKind_UUID)
 	if bytes := row.dyB.GetByteArray(name); bytes != nil {
 		return bytes.Bytes()
 	}
@@ -569,7 +646,13 @@ func (row *rowType) AsString(name string) (value string) {
 		return row.container
 	}
 
-	_ = row.fieldMustExists(name, appdef.DataKind_string)
+	fld := row.fieldMustExists(name, appdef.DataKind_string, appdef.DataKind_decimal)
+
+	if fld.DataKind() == appdef.DataKind_decimal {
+		value, _ := row.dyB.GetInt64(name)
+		_, scale := appdef.DecimalPrecisionScale(fld.Constraints())
+		return istructs.DecimalToString(value, scale)
+	}
 
 	if value, ok := row.dyB.GetString(name); ok {
 		return value
@@ -632,6 +715,29 @@ func (row *rowType) AsRecordID(name string) istructs.RecordID {
 	return istructs.NullRecordID
 }
 
+// istructs.IRowReader.AsArray
+func (row *rowType) AsArray(name string, cb func(int, interface{})) {
+	fld := row.fieldDef(name)
+	if (fld == nil) || !fld.IsArray() {
+		panic(fmt.Errorf(errFieldNotFoundWrap, "array", name, row.QName(), ErrNameNotFound))
+	}
+
+	switch items := row.dyB.Get(name).(type) {
+	case []int32:
+		rangeArray(items, cb)
+	case []int64:
+		rangeArray(items, cb)
+	case []float32:
+		rangeArray(items, cb)
+	case []float64:
+		rangeArray(items, cb)
+	case []string:
+		rangeArray(items, cb)
+	case []bool:
+		rangeArray(items, cb)
+	}
+}
+
 // IValue.AsRecord
 func (row *rowType) AsRecord(name string) istructs.IRecord {
 	_ = row.fieldMustExists(name, appdef.DataKind_Record)
@@ -764,6 +870,8 @@ func (row *rowType) PutFromJSON(j map[string]any) {
 			row.PutChars(n, fv)
 		case bool:
 			row.PutBool(n, fv)
+		case []interface{}:
+			row.putArray(n, fv)
 		}
 	}
 }
@@ -787,6 +895,28 @@ func (row *rowType) PutNumber(name string, value float64) {
 		row.PutFloat64(name, value)
 	case appdef.DataKind_RecordID:
 		row.PutRecordID(name, istructs.RecordID(value))
+	case appdef.DataKind_decimal:
+		_, scale := appdef.DecimalPrecisionScale(fld.Constraints())
+		v, err := istructs.DecimalFromFloat64(value, scale)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, appdef.DataKind_decimal.TrimString(), err)
+			return
+		}
+		row.PutInt64(name, v)
+	case appdef.DataKind_date:
+		v, err := istructs.DateFromFloat64(value)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, appdef.DataKind_date.TrimString(), err)
+			return
+		}
+		row.PutInt32(name, v)
+	case appdef.DataKind_timestamp:
+		v, err := istructs.TimestampFromFloat64(value)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, appdef.DataKind_timestamp.TrimString(), err)
+			return
+		}
+		row.PutInt64(name, v)
 	default:
 		row.collectErrorf(errFieldValueTypeMismatchWrap, appdef.DataKind_float64.TrimString(), fld, ErrWrongFieldType)
 	}
@@ -854,6 +984,35 @@ func (row *rowType) PutChars(name string, value string) {
 			return
 		}
 		row.PutQName(name, qName)
+	case appdef.DataKind_decimal:
+		_, scale := appdef.DecimalPrecisionScale(fld.Constraints())
+		v, err := istructs.ParseDecimal(value, scale)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, k.TrimString(), err)
+			return
+		}
+		row.PutInt64(name, v)
+	case appdef.DataKind_date:
+		v, err := istructs.ParseDate(value)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, k.TrimString(), err)
+			return
+		}
+		row.PutInt32(name, v)
+	case appdef.DataKind_timestamp:
+		v, err := istructs.ParseTimestamp(value)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, k.TrimString(), err)
+			return
+		}
+		row.PutInt64(name, v)
+	case appdef.DataKind_UUID:
+		v, err := istructs.ParseUUID(value)
+		if err != nil {
+			row.collectErrorf(errFieldConvertErrorWrap, name, value, k.TrimString(), err)
+			return
+		}
+		row.PutBytes(name, v)
 	default:
 		row.collectErrorf(errFieldValueTypeMismatchWrap, appdef.DataKind_string.TrimString(), fld, ErrWrongFieldType)
 	}
@@ -354,6 +354,195 @@ func Test_rowType_PutFromJSON(t *testing.T) {
 	require.EqualValues(7, row.AsRecordID("RecordID"))
 }
 
+func Test_rowType_PutAs_DecimalDateTimestampUUID(t *testing.T) {
+	require := require.New(t)
+
+	name := appdef.NewQName("test", "obj")
+	adb := appdef.New()
+	adb.AddObject(name).
+		AddField("decimal", appdef.DataKind_decimal, false, appdef.Precision(10), appdef.Scale(2)).
+		AddField("date", appdef.DataKind_date, false).
+		AddField("timestamp", appdef.DataKind_timestamp, false).
+		AddField("uuid", appdef.DataKind_UUID, false)
+
+	cfgs := make(AppConfigsType, 1)
+	cfg := cfgs.AddConfig(istructs.AppQName_test1_app1, adb)
+	storage, err := simpleStorageProvider().AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	require.NoError(cfg.prepare(nil, storage))
+
+	const uuid = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+	t.Run("should be ok to put values from JSON", func(t *testing.T) {
+		row := newObject(cfg, name, nil)
+		row.PutFromJSON(map[string]any{
+			"decimal":   "12345678.9",
+			"date":      "2024-01-31",
+			"timestamp": "2024-01-31T12:00:00.5Z",
+			"uuid":      uuid,
+		})
+		o, err := row.Build()
+		require.NoError(err)
+
+		require.EqualValues(1234567890, o.AsInt64("decimal"))
+		require.Equal(12345678.9, o.AsFloat64("decimal"))
+		require.Equal("12345678.90", o.AsString("decimal"))
+		require.Equal("2024-01-31", istructs.DateToString(o.AsInt32("date")))
+		require.Equal("2024-01-31T12:00:00.500Z", istructs.TimestampToString(o.AsInt64("timestamp")))
+		require.Equal(uuid, istructs.UUIDToString(o.AsBytes("uuid")))
+	})
+
+	t.Run("should be ok to put numbers", func(t *testing.T) {
+		row := newObject(cfg, name, nil)
+		row.PutNumber("decimal", 1.005)
+		row.PutNumber("date", 1)
+		row.PutNumber("timestamp", 1000)
+		o, err := row.Build()
+		require.NoError(err)
+
+		require.EqualValues(101, o.AsInt64("decimal"))
+		require.EqualValues(1, o.AsInt32("date"))
+		require.EqualValues(1000, o.AsInt64("timestamp"))
+	})
+
+	t.Run("should be errors if invalid values", func(t *testing.T) {
+		row := newObject(cfg, name, nil)
+		row.PutFromJSON(map[string]any{
+			"decimal":   "1.234",
+			"date":      "31.01.2024",
+			"timestamp": "2024-01-31",
+			"uuid":      "f47ac10b",
+		})
+		_, err := row.Build()
+		require.ErrorIs(err, istructs.ErrInvalidValue)
+		for _, f := range []string{"decimal", "date", "timestamp", "uuid"} {
+			require.ErrorContains(err, "«"+f+"»")
+		}
+
+		row = newObject(cfg, name, nil)
+		row.PutChars("decimal", "123456789")
+		_, err = row.Build()
+		require.ErrorIs(err, ErrDataConstraintViolation)
+
+		row = newObject(cfg, name, nil)
+		row.PutNumber("date", 1.5)
+		row.PutNumber("timestamp", 1e20)
+		_, err = row.Build()
+		require.ErrorIs(err, istructs.ErrInvalidValue)
+		for _, f := range []string{"date", "timestamp"} {
+			require.ErrorContains(err, "«"+f+"»")
+		}
+	})
+}
+
+func Test_rowType_Arrays(t *testing.T) {
+	require := require.New(t)
+
+	objName := appdef.NewQName("test", "obj")
+	docName := appdef.NewQName("test", "doc")
+	adb := appdef.New()
+	adb.AddObject(objName).
+		AddArrayField("tags", appdef.DataKind_string, 0, 3, appdef.MaxLen(5)).
+		AddArrayField("nums", appdef.DataKind_int32, 1, appdef.Occurs_Unbounded).
+		AddArrayField("amounts", appdef.DataKind_decimal, 0, appdef.Occurs_Unbounded, appdef.Scale(2)).
+		AddArrayField("days", appdef.DataKind_date, 0, appdef.Occurs_Unbounded).
+		AddArrayField("flags", appdef.DataKind_bool, 0, appdef.Occurs_Unbounded).
+		AddField("int", appdef.DataKind_int32, false)
+	adb.AddCDoc(docName).
+		AddArrayField("tags", appdef.DataKind_string, 0, appdef.Occurs_Unbounded)
+
+	cfgs := make(AppConfigsType, 1)
+	cfg := cfgs.AddConfig(istructs.AppQName_test1_app1, adb)
+	storage, err := simpleStorageProvider().AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	require.NoError(cfg.prepare(nil, storage))
+
+	items := func(rr istructs.IRowReader, name string) (res []interface{}) {
+		rr.AsArray(name, func(i int, v interface{}) {
+			require.Len(res, i)
+			res = append(res, v)
+		})
+		return res
+	}
+
+	t.Run("should be ok to put arrays from JSON", func(t *testing.T) {
+		obj := newObject(cfg, objName, nil)
+		obj.FillFromJSON(map[string]any{
+			"tags":    []interface{}{"new", "hot"},
+			"nums":    []interface{}{float64(1), float64(2), float64(3)},
+			"amounts": []interface{}{12.34, "-0.05"},
+			"days":    []interface{}{"2024-01-31", float64(1)},
+			"flags":   []interface{}{true, false},
+			"int":     float64(7),
+		})
+		o, err := obj.Build()
+		require.NoError(err)
+
+		require.Equal([]interface{}{"new", "hot"}, items(o, "tags"))
+		require.Equal([]interface{}{int32(1), int32(2), int32(3)}, items(o, "nums"))
+		require.Equal([]interface{}{int64(1234), int64(-5)}, items(o, "amounts"))
+		require.Equal([]interface{}{int32(19753), int32(1)}, items(o, "days"))
+		require.Equal([]interface{}{true, false}, items(o, "flags"))
+		require.EqualValues(7, o.AsInt32("int"))
+	})
+
+	t.Run("should be ok to store and load arrays", func(t *testing.T) {
+		rec := newRecord(cfg)
+		rec.setQName(docName)
+		rec.setID(100500)
+		rec.PutFromJSON(map[string]any{"tags": []interface{}{"a", "b", "c"}})
+		require.NoError(rec.build())
+
+		rec1 := newRecord(cfg)
+		require.NoError(rec1.loadFromBytes(rec.storeToBytes()))
+		require.Equal([]interface{}{"a", "b", "c"}, items(rec1, "tags"))
+	})
+
+	t.Run("should be errors if arrays items count violated", func(t *testing.T) {
+		obj := newObject(cfg, objName, nil)
+		obj.PutFromJSON(map[string]any{
+			"tags": []interface{}{"a", "b", "c", "d"},
+		})
+		_, err := obj.Build()
+		require.ErrorIs(err, ErrMaxOccursViolation)
+		require.ErrorContains(err, "array field «tags» has too many items (4, maximum 3)")
+		require.ErrorIs(err, ErrNameNotFound)
+		require.ErrorContains(err, "misses required field «nums»")
+
+		obj = newObject(cfg, objName, nil)
+		obj.PutFromJSON(map[string]any{
+			"nums": []interface{}{},
+		})
+		_, err = obj.Build()
+		require.ErrorContains(err, "misses required field «nums»")
+	})
+
+	t.Run("should be errors if invalid array items", func(t *testing.T) {
+		obj := newObject(cfg, objName, nil)
+		obj.PutFromJSON(map[string]any{
+			"tags": []interface{}{"too long"},
+			"nums": []interface{}{"one"},
+			"days": []interface{}{"31.01.2024"},
+		})
+		_, err := obj.Build()
+		require.ErrorIs(err, ErrDataConstraintViolation)
+		require.ErrorIs(err, ErrWrongFieldType)
+		require.ErrorIs(err, istructs.ErrInvalidValue)
+	})
+
+	t.Run("should be errors if mixed scalar and array access", func(t *testing.T) {
+		obj := newObject(cfg, objName, nil)
+		obj.PutInt32("nums", 1)
+		obj.PutFromJSON(map[string]any{"int": []interface{}{float64(1)}})
+		_, err := obj.Build()
+		require.ErrorIs(err, ErrWrongFieldType)
+
+		require.Panics(func() { obj.AsInt32("nums") })
+		require.Panics(func() { obj.AsArray("int", func(int, interface{}) {}) })
+		require.Panics(func() { obj.AsArray("unknown", func(int, interface{}) {}) })
+	})
+}
+
 func Test_rowType_PutAs_ComplexTypes(t *testing.T) {
 	require := require.New(t)
 	test := test()
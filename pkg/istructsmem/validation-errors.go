@@ -25,6 +25,8 @@ const (
 	ECode_InvalidChildName
 	ECode_InvalidOccursMin
 	ECode_InvalidOccursMax
+
+	ECode_CheckViolation
 )
 
 type validateErrorType struct {
@@ -70,6 +72,8 @@ const (
 	errEventUnloggedArgUseWrongType = "%v unlogged argument uses wrong type «%v», expected «%v»: %w"
 	errContainerMinOccursViolated   = "%v container «%s» has not enough occurrences (%d, minimum %d): %w"
 	errContainerMaxOccursViolated   = "%v container «%s» has too many occurrences (%d, maximum %d): %w"
+	errArrayMinItemsViolated        = "%v array field «%s» has not enough items (%d, minimum %d): %w"
+	errArrayMaxItemsViolated        = "%v array field «%s» has too many items (%d, maximum %d): %w"
 	errUnknownContainerName         = "%v child[%d] has unknown container name «%s»: %w"
 	errWrongContainerType           = "%v child[%d] %v has wrong type name, expected «%v»: %w"
 	errWrongParentID                = "%v child[%d] %v has wrong parent id «%d», expected «%d»: %w"
@@ -77,4 +81,5 @@ const (
 	errNullInRequiredRefField       = "%v required ref field «%s» has NullRecordID value: %w"
 	errCUDsMissed                   = "%v must have not empty CUDs: %w"
 	errInvalidTypeKindInCUD         = "%v CUD.%s() [record ID «%d»] %v has invalid type kind: %w"
+	errCheckViolated                = "%v violates check «%s» (%s): %w"
 )
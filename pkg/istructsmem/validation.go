@@ -335,6 +335,7 @@ func validateObject(o *objectType) (err error) {
 //
 // Checks that all required fields are filled.
 // For required ref fields checks that they are filled with non null IDs.
+// For array fields checks items count.
 func validateRow(row *rowType) (err error) {
 	for _, f := range row.fields.Fields() {
 		if f.Required() {
@@ -355,6 +356,27 @@ func validateRow(row *rowType) (err error) {
 				}
 			}
 		}
+		if f.IsArray() {
+			err = errors.Join(err,
+				validateArrayItems(row, f))
+		}
+	}
+	return err
+}
+
+// Validates items count of specified array field
+func validateArrayItems(row *rowType, f appdef.IField) (err error) {
+	items := appdef.Occurs(0)
+	row.AsArray(f.Name(), func(int, interface{}) { items++ })
+	if items < f.MinItems() {
+		err = errors.Join(err,
+			// CDoc «test.document» array field «Tags» has not enough items (1, minimum 2)
+			validateErrorf(ECode_InvalidOccursMin, errArrayMinItemsViolated, row, f.Name(), items, f.MinItems(), ErrMinOccursViolation))
+	}
+	if items > f.MaxItems() {
+		err = errors.Join(err,
+			// CDoc «test.document» array field «Tags» has too many items (3, maximum 2)
+			validateErrorf(ECode_InvalidOccursMax, errArrayMaxItemsViolated, row, f.Name(), items, f.MaxItems(), ErrMaxOccursViolation))
 	}
 	return err
 }
@@ -391,13 +413,33 @@ func validateEventCUDs(ev *eventType) (err error) {
 func validateEventCUD(ev *eventType, rec *recordType, part string) error {
 	switch rec.typ.Kind() {
 	case appdef.TypeKind_GDoc, appdef.TypeKind_CDoc, appdef.TypeKind_WDoc, appdef.TypeKind_GRecord, appdef.TypeKind_CRecord, appdef.TypeKind_WRecord:
-		return validateRow(&rec.rowType)
+		return errors.Join(
+			validateRow(&rec.rowType),
+			validateRowChecks(&rec.rowType))
 	default:
 		// event «sys.CUD» CUD.Create() [record ID «1»] ORec «test.ORecord» has invalid type kind: %w"
 		return validateErrorf(ECode_InvalidTypeKind, errInvalidTypeKindInCUD, ev, part, rec.ID(), rec, ErrUnexpectedTypeKind)
 	}
 }
 
+// Validates row by checks of its structure.
+//
+// Checks are compiled by application definition build.
+func validateRowChecks(row *rowType) (err error) {
+	str, ok := row.typ.(appdef.IStructure)
+	if !ok {
+		return nil
+	}
+	str.Checks(func(c appdef.ICheck) {
+		if !c.Evaluate(row) {
+			err = errors.Join(err,
+				// CDoc «test.deal» violates check «positivePrice» (price > 0)
+				validateErrorf(ECode_CheckViolation, errCheckViolated, row, c.Name(), c.Expression(), ErrCheckViolation))
+		}
+	})
+	return err
+}
+
 // # Validates specified view key.
 //
 // If partialClust specified then clustering columns row may be partially filled
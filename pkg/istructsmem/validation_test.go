@@ -17,6 +17,7 @@ import (
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/parser"
 )
 
 func Test_ValidEventArgs(t *testing.T) {
@@ -313,6 +314,7 @@ func Test_ValidSysCudEvent(t *testing.T) {
 	require := require.New(t)
 
 	appDef := appdef.New()
+	appDef.SetExpressionCompiler(parser.ExpressionCompiler())
 
 	docName := appdef.NewQName("test", "document")
 	rec1Name := appdef.NewQName("test", "record1")
@@ -328,6 +330,7 @@ func Test_ValidSysCudEvent(t *testing.T) {
 		doc.
 			AddContainer("child", rec1Name, 0, appdef.Occurs_Unbounded).
 			AddContainer("child2", rec2Name, 0, appdef.Occurs_Unbounded)
+		doc.AddCheck("positive", "RequiredField > 0")
 
 		_ = appDef.AddCRecord(rec1Name)
 		_ = appDef.AddCRecord(rec2Name)
@@ -532,6 +535,41 @@ func Test_ValidSysCudEvent(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("must error if check violated", func(t *testing.T) {
+
+		t.Run("in CUD.Create", func(t *testing.T) {
+			e := cudRawEvent(false)
+			d := e.CUDBuilder().Create(docName)
+			d.PutRecordID(appdef.SystemField_ID, 1)
+			d.PutInt32("RequiredField", -1) // <- error here
+			_, err := e.BuildRawEvent()
+			require.ErrorIs(err, ErrCheckViolation)
+			require.ErrorContains(err, "violates check «positive» (RequiredField > 0)")
+
+			var vErr ValidateError
+			require.ErrorAs(err, &vErr)
+			require.Equal(ECode_CheckViolation, vErr.Code())
+		})
+
+		t.Run("in CUD.Update", func(t *testing.T) {
+			e := cudRawEvent(false)
+			u := e.CUDBuilder().Update(testDocRec(100500))
+			u.PutInt32("RequiredField", 0) // <- error here
+			_, err := e.BuildRawEvent()
+			require.ErrorIs(err, ErrCheckViolation)
+			require.ErrorContains(err, "violates check «positive»")
+		})
+
+		t.Run("no error if check passed", func(t *testing.T) {
+			e := cudRawEvent(false)
+			d := e.CUDBuilder().Create(docName)
+			d.PutRecordID(appdef.SystemField_ID, 1)
+			d.PutInt32("RequiredField", 1)
+			_, err := e.BuildRawEvent()
+			require.NoError(err)
+		})
+	})
 }
 
 func Test_ValidCommandEvent(t *testing.T) {
@@ -44,6 +44,20 @@ func (vr *appViewRecords) storeViewRecord(workspace istructs.WSID, key istructs.
 	return partKey, cCols, data, nil
 }
 
+// Returns partition key and clustering columns of view record to delete
+func (vr *appViewRecords) storeViewKey(workspace istructs.WSID, key istructs.IKeyBuilder) (partKey, cCols []byte, err error) {
+	k := key.(*keyType)
+	if err = k.build(); err != nil {
+		return nil, nil, err
+	}
+	if err = validateViewKey(k, false); err != nil {
+		return nil, nil, err
+	}
+
+	partKey, cCols = k.storeToBytes(workspace)
+	return partKey, cCols, nil
+}
+
 // Stores partition key to bytes. Must be called only if valid key
 func (key *keyType) storeViewPartKey(ws istructs.WSID) []byte {
 	/*
@@ -150,6 +164,21 @@ func loadClustFieldFromBuffer_00(key *keyType, field appdef.IField, buf *bytes.B
 		if v, err = utils.ReadInt64(buf); err == nil {
 			key.ccolsRow.PutRecordID(field.Name(), istructs.RecordID(v))
 		}
+	case appdef.DataKind_decimal, appdef.DataKind_timestamp:
+		v := int64(0)
+		if v, err = utils.ReadInt64(buf); err == nil {
+			key.ccolsRow.PutInt64(field.Name(), v)
+		}
+	case appdef.DataKind_date:
+		v := int32(0)
+		if v, err = utils.ReadInt32(buf); err == nil {
+			key.ccolsRow.PutInt32(field.Name(), v)
+		}
+	case appdef.DataKind_UUID:
+		if l := buf.Len(); l < istructs.UUIDLen {
+			return fmt.Errorf("%v: error read UUID, expected %d bytes, but only %d bytes is available: %w", key.viewName, istructs.UUIDLen, l, io.ErrUnexpectedEOF)
+		}
+		key.ccolsRow.PutBytes(field.Name(), buf.Next(istructs.UUIDLen))
 	case appdef.DataKind_bytes:
 		key.ccolsRow.PutBytes(field.Name(), buf.Bytes())
 	case appdef.DataKind_string:
@@ -7,8 +7,10 @@ package istructsmem
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	istorage "github.com/voedger/voedger/pkg/istorage"
@@ -131,8 +133,16 @@ func (vr *appViewRecords) GetBatch(workspace istructs.WSID, kv []istructs.ViewRe
 
 // istructs.IViewRecords.Put
 func (vr *appViewRecords) Put(workspace istructs.WSID, key istructs.IKeyBuilder, value istructs.IValueBuilder) (err error) {
+	return vr.PutWithTTL(workspace, key, value, 0)
+}
+
+// istructs.IViewRecords.PutWithTTL
+func (vr *appViewRecords) PutWithTTL(workspace istructs.WSID, key istructs.IKeyBuilder, value istructs.IValueBuilder, ttl time.Duration) (err error) {
 	var partKey, ccolsCols, data []byte
 	if partKey, ccolsCols, data, err = vr.storeViewRecord(workspace, key, value); err == nil {
+		if ttl = recordTTL(key, ttl); ttl > 0 {
+			return vr.app.config.storage.PutBatch([]istorage.BatchItem{{PKey: partKey, CCols: ccolsCols, Value: data, TTL: ttl}})
+		}
 		return vr.app.config.storage.Put(partKey, ccolsCols, data)
 	}
 	return err
@@ -143,13 +153,29 @@ func (vr *appViewRecords) PutBatch(workspace istructs.WSID, recs []istructs.View
 	batch := make([]istorage.BatchItem, len(recs))
 
 	for i, kv := range recs {
+		if kv.Deleted {
+			if batch[i].PKey, batch[i].CCols, err = vr.storeViewKey(workspace, kv.Key); err != nil {
+				return err
+			}
+			batch[i].Deleted = true
+			continue
+		}
 		if batch[i].PKey, batch[i].CCols, batch[i].Value, err = vr.storeViewRecord(workspace, kv.Key, kv.Value); err != nil {
 			return err
 		}
+		batch[i].TTL = recordTTL(kv.Key, kv.TTL)
 	}
 	return vr.app.config.storage.PutBatch(batch)
 }
 
+// Returns ttl if it is specified, otherwise TTL of the key view
+func recordTTL(key istructs.IKeyBuilder, ttl time.Duration) time.Duration {
+	if ttl > 0 {
+		return ttl
+	}
+	return key.(*keyType).view.TTL()
+}
+
 // istructs.IViewRecords.Read
 func (vr *appViewRecords) Read(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
 
@@ -161,22 +187,96 @@ func (vr *appViewRecords) Read(ctx context.Context, workspace istructs.WSID, key
 		return err
 	}
 
-	readRecord := func(ccols, value []byte) (err error) {
-		recKey := newKey(k.appCfg, k.viewName)
-		recKey.partRow.copyFrom(&k.partRow)
-		if err := recKey.loadFromBytes(ccols); err != nil {
-			return err
+	pKey, cKey := k.storeToBytes(workspace)
+	return vr.app.config.storage.Read(ctx, pKey, cKey, utils.IncBytes(cKey), k.readRecordFunc(cb))
+}
+
+// istructs.IViewRecords.ReadFrom
+func (vr *appViewRecords) ReadFrom(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, from istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
+
+	k := key.(*keyType)
+	if err = k.build(); err != nil {
+		return err
+	}
+	if err = validateViewKey(k, true); err != nil {
+		return err
+	}
+
+	f := from.(*keyType)
+	if f.viewName != k.viewName {
+		return fmt.Errorf("view «%v» can not be read from key of view «%v»: %w", k.viewName, f.viewName, ErrWrongType)
+	}
+	if err = f.ccolsRow.build(); err != nil {
+		return err
+	}
+
+	pKey, cKey := k.storeToBytes(workspace)
+	fromCKey := f.storeViewClustKey()
+	if !bytes.HasPrefix(fromCKey, cKey) {
+		return fmt.Errorf("view «%v» from key clustering columns must begin with read key clustering columns: %w", k.viewName, ErrWrongFieldType)
+	}
+	return vr.app.config.storage.Read(ctx, pKey, fromCKey, utils.IncBytes(cKey), k.readRecordFunc(cb))
+}
+
+// Truncates the view: removes all records of all view partitions from storage.
+//
+// Storage must implement istorage.IAppStorageScanner.
+// Concurrent writes to the view while truncating are not allowed, they could be lost or kept
+func TruncateView(ctx context.Context, appStructs istructs.IAppStructs, view appdef.QName) error {
+	app := appStructs.(*appStructsType)
+	if app.config.AppDef.View(view) == nil {
+		return fmt.Errorf(errViewNotFoundWrap, view, ErrNameNotFound)
+	}
+	viewID, err := app.config.qNames.ID(view)
+	if err != nil {
+		return err
+	}
+	scanner, ok := app.config.storage.(istorage.IAppStorageScanner)
+	if !ok {
+		return ErrStorageScanNotSupported
+	}
+
+	// partition keys are collected first, since some drivers do not allow to change the storage while scanning
+	pKeys := make([][]byte, 0)
+	err = scanner.ScanPartitions(ctx, func(pKey []byte) error {
+		if len(pKey) >= uint16len && binary.BigEndian.Uint16(pKey) == viewID {
+			pKeys = append(pKeys, bytes.Clone(pKey))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("can not truncate view «%v»: %w", view, err)
+	}
+
+	for _, pKey := range pKeys {
+		if err := deletePartition(ctx, app.config.storage, pKey); err != nil {
+			return fmt.Errorf("can not truncate view «%v»: %w", view, err)
 		}
+	}
+	return ctx.Err()
+}
 
-		valRow := newValue(k.appCfg, k.viewName)
-		if err := valRow.loadFromBytes(value); err != nil {
+// Removes all records of the partition by batches of rawDataBatchSize records
+func deletePartition(ctx context.Context, storage istorage.IAppStorage, pKey []byte) error {
+	for {
+		batch := make([]istorage.BatchItem, 0, rawDataBatchSize)
+		err := storage.Read(ctx, pKey, nil, nil, func(cCols, _ []byte) error {
+			batch = append(batch, istorage.BatchItem{PKey: pKey, CCols: bytes.Clone(cCols), Deleted: true})
+			if len(batch) == rawDataBatchSize {
+				return errRawBatchRead
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errRawBatchRead) {
+			return err
+		}
+		if len(batch) == 0 {
+			return ctx.Err()
+		}
+		if err := storage.PutBatch(batch); err != nil {
 			return err
 		}
-		return cb(recKey, valRow)
 	}
-
-	pKey, cKey := k.storeToBytes(workspace)
-	return vr.app.config.storage.Read(ctx, pKey, cKey, utils.IncBytes(cKey), readRecord)
 }
 
 // keyType is complex key from two parts (partition key and clustering key)
@@ -247,6 +347,23 @@ func (key *keyType) storeToBytes(ws istructs.WSID) (pKey, cKey []byte) {
 	return key.storeViewPartKey(ws), key.storeViewClustKey()
 }
 
+// Returns storage read callback which loads view records of the key partition and passes them to cb
+func (key *keyType) readRecordFunc(cb istructs.ValuesCallback) istorage.ReadCallback {
+	return func(ccols, value []byte) (err error) {
+		recKey := newKey(key.appCfg, key.viewName)
+		recKey.partRow.copyFrom(&key.partRow)
+		if err := recKey.loadFromBytes(ccols); err != nil {
+			return err
+		}
+
+		valRow := newValue(key.appCfg, key.viewName)
+		if err := valRow.loadFromBytes(value); err != nil {
+			return err
+		}
+		return cb(recKey, valRow)
+	}
+}
+
 // istructs.IRowReader.AsBool
 func (key *keyType) AsBool(name string) bool {
 	if key.partRow.fieldDef(name) != nil {
@@ -257,6 +374,9 @@ func (key *keyType) AsBool(name string) bool {
 
 // istructs.IRowReader.AsBytes
 func (key *keyType) AsBytes(name string) []byte {
+	if key.partRow.fieldDef(name) != nil {
+		return key.partRow.AsBytes(name)
+	}
 	return key.ccolsRow.AsBytes(name)
 }
 
@@ -312,8 +432,16 @@ func (key *keyType) AsRecordID(name string) istructs.RecordID {
 	return key.ccolsRow.AsRecordID(name)
 }
 
+// istructs.IRowReader.AsArray
+func (key *keyType) AsArray(name string, cb func(int, interface{})) {
+	key.ccolsRow.AsArray(name, cb)
+}
+
 // istructs.IRowReader.AsString
 func (key *keyType) AsString(name string) string {
+	if key.partRow.fieldDef(name) != nil {
+		return key.partRow.AsString(name)
+	}
 	return key.ccolsRow.AsString(name)
 }
 
@@ -382,12 +510,20 @@ func (key *keyType) PutBool(name string, value bool) {
 
 // istructs.IRowWriter.PutBytes
 func (key *keyType) PutBytes(name string, value []byte) {
-	key.ccolsRow.PutBytes(name, value)
+	if key.partRow.fieldDef(name) != nil {
+		key.partRow.PutBytes(name, value)
+	} else {
+		key.ccolsRow.PutBytes(name, value)
+	}
 }
 
 // istructs.IRowWriter.PutChars
 func (key *keyType) PutChars(name string, value string) {
-	key.ccolsRow.PutChars(name, value)
+	if key.partRow.fieldDef(name) != nil {
+		key.partRow.PutChars(name, value)
+	} else {
+		key.ccolsRow.PutChars(name, value)
+	}
 }
 
 // istructs.IRowWriter.PutFloat32
@@ -410,8 +546,16 @@ func (key *keyType) PutFloat64(name string, value float64) {
 
 // istructs.IRowWriter.PutFromJSON
 func (key *keyType) PutFromJSON(j map[string]any) {
-	key.partRow.PutFromJSON(j)
-	key.ccolsRow.PutFromJSON(j)
+	pk, cc := make(map[string]any), make(map[string]any)
+	for n, v := range j {
+		if key.partRow.fieldDef(n) != nil {
+			pk[n] = v
+		} else {
+			cc[n] = v
+		}
+	}
+	key.partRow.PutFromJSON(pk)
+	key.ccolsRow.PutFromJSON(cc)
 }
 
 // istructs.IRowWriter.PutInt32
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/appdef"
@@ -161,6 +162,22 @@ func Test_KeyType(t *testing.T) {
 
 		require.True(key.Equals(dupe))
 	})
+
+	t.Run("must be ok to put key from JSON", func(t *testing.T) {
+		kb := newKey(appCfg, viewName)
+		kb.PutFromJSON(map[string]any{
+			"pk_int32": float64(1),
+			"pk_bool":  true,
+			"cc_int32": float64(2),
+			"cc_qname": "test.view",
+		})
+		require.NoError(kb.build())
+
+		require.EqualValues(1, kb.AsInt32("pk_int32"))
+		require.True(kb.AsBool("pk_bool"))
+		require.EqualValues(2, kb.AsInt32("cc_int32"))
+		require.Equal(viewName, kb.AsQName("cc_qname"))
+	})
 }
 
 // TestCore_ViewRecords: test https://dev.heeus.io/launchpad/#!14470
@@ -289,6 +306,61 @@ func TestCore_ViewRecords(t *testing.T) {
 		require.Equal("Meat;Bread;Cake;", names, "wrong read order!")
 	})
 
+	t.Run("Should read records from WSID = 3 starting from the clustering key", func(t *testing.T) {
+		kb := viewRecords.KeyBuilder(appdef.NewQName("test", "viewDrinks"))
+		kb.PutInt64("partitionKey1", 3)
+
+		readFrom := func(from istructs.IKeyBuilder) (names string, err error) {
+			err = viewRecords.ReadFrom(context.Background(), 3, kb, from, func(key istructs.IKey, value istructs.IValue) (err error) {
+				names += value.AsString("name") + ";"
+				return nil
+			})
+			return names, err
+		}
+
+		t.Run("Should skip records less than from key", func(t *testing.T) {
+			from := viewRecords.KeyBuilder(appdef.NewQName("test", "viewDrinks"))
+			from.PutInt64("clusteringColumn1", 300)
+			names, err := readFrom(from)
+			require.NoError(err)
+			require.Equal("Bread;Cake;", names)
+		})
+
+		t.Run("Should skip records up to full from key", func(t *testing.T) {
+			from := viewRecords.KeyBuilder(appdef.NewQName("test", "viewDrinks"))
+			from.PutInt64("clusteringColumn1", 300)
+			from.PutBool("clusteringColumn2", true)
+			from.PutString("clusteringColumn3", "foodX")
+			names, err := readFrom(from)
+			require.NoError(err)
+			require.Equal("Cake;", names)
+		})
+
+		t.Run("Should read nothing if from key is after all records", func(t *testing.T) {
+			from := viewRecords.KeyBuilder(appdef.NewQName("test", "viewDrinks"))
+			from.PutInt64("clusteringColumn1", 500)
+			names, err := readFrom(from)
+			require.NoError(err)
+			require.Empty(names)
+		})
+
+		t.Run("Should be error if from key does not begin with read key clustering columns", func(t *testing.T) {
+			kb := viewRecords.KeyBuilder(appdef.NewQName("test", "viewDrinks"))
+			kb.PutInt64("partitionKey1", 3)
+			kb.PutInt64("clusteringColumn1", 200)
+			from := viewRecords.KeyBuilder(appdef.NewQName("test", "viewDrinks"))
+			from.PutInt64("clusteringColumn1", 300)
+			err := viewRecords.ReadFrom(context.Background(), 3, kb, from, func(istructs.IKey, istructs.IValue) error { return nil })
+			require.ErrorIs(err, ErrWrongFieldType)
+		})
+
+		t.Run("Should be error if from key is of other view", func(t *testing.T) {
+			from := viewRecords.KeyBuilder(appdef.NewQName("test", "otherView"))
+			_, err := readFrom(from)
+			require.ErrorIs(err, ErrWrongType)
+		})
+	})
+
 	t.Run("Should read two records by short clustering key and one by full", func(t *testing.T) {
 		kb := viewRecords.KeyBuilder(appdef.NewQName("test", "viewDrinks"))
 		kb.PutInt64("partitionKey1", 2)
@@ -984,6 +1056,302 @@ func Test_ViewRecords_ClustColumnsQName(t *testing.T) {
 	})
 }
 
+func Test_ViewRecords_ClustColumnsDecimalDateTimestampUUID(t *testing.T) {
+	require := require.New(t)
+	ws := istructs.WSID(1234)
+	viewName := appdef.NewQName("test", "viewPayments")
+
+	appConfigs := func() AppConfigsType {
+		appDef := appdef.New()
+		v := appDef.AddView(viewName)
+		v.KeyBuilder().PartKeyBuilder().AddField("day", appdef.DataKind_date)
+		v.KeyBuilder().ClustColsBuilder().
+			AddField("time", appdef.DataKind_timestamp).
+			AddField("amount", appdef.DataKind_decimal, appdef.Scale(2)).
+			AddField("payment", appdef.DataKind_UUID)
+		v.ValueBuilder().AddField("comment", appdef.DataKind_string, false)
+
+		cfgs := make(AppConfigsType, 1)
+		_ = cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+		return cfgs
+	}
+
+	p := Provide(appConfigs(), iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvider())
+	as, err := p.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	viewRecords := as.ViewRecords()
+
+	const payment = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+	kb := viewRecords.KeyBuilder(viewName)
+	kb.PutChars("day", "2024-01-31")
+	kb.PutChars("time", "2024-01-31T12:00:00Z")
+	kb.PutChars("amount", "-12.5")
+	kb.PutChars("payment", payment)
+	vb := viewRecords.NewValueBuilder(viewName)
+	vb.PutString("comment", "refund")
+	require.NoError(viewRecords.Put(ws, kb, vb))
+
+	kb = viewRecords.KeyBuilder(viewName)
+	kb.PutChars("day", "2024-01-31")
+	cnt := 0
+	err = viewRecords.Read(context.Background(), ws, kb, func(key istructs.IKey, value istructs.IValue) (err error) {
+		cnt++
+		require.Equal("2024-01-31T12:00:00.000Z", istructs.TimestampToString(key.AsInt64("time")))
+		require.EqualValues(-1250, key.AsInt64("amount"))
+		require.Equal(-12.5, key.AsFloat64("amount"))
+		require.Equal(payment, istructs.UUIDToString(key.AsBytes("payment")))
+		require.Equal("refund", value.AsString("comment"))
+		return nil
+	})
+	require.NoError(err)
+	require.Equal(1, cnt)
+}
+
+func Test_ViewRecords_TTL(t *testing.T) {
+	require := require.New(t)
+	ws := istructs.WSID(1234)
+	viewName := appdef.NewQName("test", "viewSessions")
+
+	appConfigs := func() AppConfigsType {
+		appDef := appdef.New()
+		v := appDef.AddView(viewName)
+		v.KeyBuilder().PartKeyBuilder().AddField("user", appdef.DataKind_int64)
+		v.KeyBuilder().ClustColsBuilder().AddField("session", appdef.DataKind_int64)
+		v.ValueBuilder().AddField("device", appdef.DataKind_string, true)
+		v.SetTTL(100 * time.Millisecond)
+
+		cfgs := make(AppConfigsType, 1)
+		_ = cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+		return cfgs
+	}
+
+	p := Provide(appConfigs(), iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvider())
+	as, err := p.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	viewRecords := as.ViewRecords()
+
+	key := func(session int64) istructs.IKeyBuilder {
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutInt64("user", 1)
+		kb.PutInt64("session", session)
+		return kb
+	}
+	value := func(device string) istructs.IValueBuilder {
+		vb := viewRecords.NewValueBuilder(viewName)
+		vb.PutString("device", device)
+		return vb
+	}
+
+	require.NoError(viewRecords.Put(ws, key(1), value("phone")))
+	require.NoError(viewRecords.PutBatch(ws, []istructs.ViewKV{{Key: key(2), Value: value("tablet")}}))
+
+	// TTL of the write overrides TTL of the view
+	require.NoError(viewRecords.PutWithTTL(ws, key(3), value("laptop"), time.Hour))
+	require.NoError(viewRecords.PutBatch(ws, []istructs.ViewKV{{Key: key(4), Value: value("watch"), TTL: time.Hour}}))
+
+	t.Run("must be ok to read records before expiration", func(t *testing.T) {
+		v, err := viewRecords.Get(ws, key(1))
+		require.NoError(err)
+		require.Equal("phone", v.AsString("device"))
+		v, err = viewRecords.Get(ws, key(2))
+		require.NoError(err)
+		require.Equal("tablet", v.AsString("device"))
+	})
+
+	t.Run("must be not found after expiration", func(t *testing.T) {
+		require.Eventually(func() bool {
+			_, err1 := viewRecords.Get(ws, key(1))
+			_, err2 := viewRecords.Get(ws, key(2))
+			return errors.Is(err1, ErrRecordNotFound) && errors.Is(err2, ErrRecordNotFound)
+		}, 5*time.Second, 10*time.Millisecond)
+
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutInt64("user", 1)
+		devices := []string{}
+		require.NoError(viewRecords.Read(context.Background(), ws, kb, func(_ istructs.IKey, v istructs.IValue) error {
+			devices = append(devices, v.AsString("device"))
+			return nil
+		}))
+		require.Equal([]string{"laptop", "watch"}, devices, "expired records must not be read")
+	})
+}
+
+func Test_ViewRecords_Delete(t *testing.T) {
+	require := require.New(t)
+	ws := istructs.WSID(1234)
+	viewName := appdef.NewQName("test", "viewSessions")
+
+	appConfigs := func() AppConfigsType {
+		appDef := appdef.New()
+		v := appDef.AddView(viewName)
+		v.KeyBuilder().PartKeyBuilder().AddField("user", appdef.DataKind_int64)
+		v.KeyBuilder().ClustColsBuilder().AddField("session", appdef.DataKind_int64)
+		v.ValueBuilder().AddField("device", appdef.DataKind_string, true)
+
+		cfgs := make(AppConfigsType, 1)
+		_ = cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+		return cfgs
+	}
+
+	p := Provide(appConfigs(), iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvider())
+	as, err := p.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	viewRecords := as.ViewRecords()
+
+	key := func(session int64) istructs.IKeyBuilder {
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutInt64("user", 1)
+		kb.PutInt64("session", session)
+		return kb
+	}
+	value := func(device string) istructs.IValueBuilder {
+		vb := viewRecords.NewValueBuilder(viewName)
+		vb.PutString("device", device)
+		return vb
+	}
+
+	require.NoError(viewRecords.PutBatch(ws, []istructs.ViewKV{
+		{Key: key(1), Value: value("phone")},
+		{Key: key(2), Value: value("tablet")},
+	}))
+
+	t.Run("must be ok to delete records by batch", func(t *testing.T) {
+		require.NoError(viewRecords.PutBatch(ws, []istructs.ViewKV{
+			{Key: key(1), Deleted: true},
+			{Key: key(3), Deleted: true},
+		}))
+
+		_, err := viewRecords.Get(ws, key(1))
+		require.ErrorIs(err, ErrRecordNotFound)
+
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutInt64("user", 1)
+		devices := []string{}
+		require.NoError(viewRecords.Read(context.Background(), ws, kb, func(_ istructs.IKey, v istructs.IValue) error {
+			devices = append(devices, v.AsString("device"))
+			return nil
+		}))
+		require.Equal([]string{"tablet"}, devices)
+	})
+
+	t.Run("must be error to delete record by incomplete key", func(t *testing.T) {
+		kb := viewRecords.KeyBuilder(viewName)
+		kb.PutInt64("user", 1)
+		err := viewRecords.PutBatch(ws, []istructs.ViewKV{{Key: kb, Deleted: true}})
+		require.ErrorIs(err, ErrFieldIsEmpty)
+	})
+}
+
+func Test_TruncateView(t *testing.T) {
+	require := require.New(t)
+	ws := istructs.WSID(1234)
+	viewName := appdef.NewQName("test", "viewTotals")
+	otherName := appdef.NewQName("test", "viewOther")
+
+	appConfigs := func() AppConfigsType {
+		appDef := appdef.New()
+		for _, n := range []appdef.QName{viewName, otherName} {
+			v := appDef.AddView(n)
+			v.KeyBuilder().PartKeyBuilder().AddField("pk", appdef.DataKind_int64)
+			v.KeyBuilder().ClustColsBuilder().AddField("cc", appdef.DataKind_int64)
+			v.ValueBuilder().AddField("total", appdef.DataKind_int64, true)
+		}
+
+		cfgs := make(AppConfigsType, 1)
+		_ = cfgs.AddConfig(istructs.AppQName_test1_app1, appDef)
+		return cfgs
+	}
+
+	storage := simpleStorageProvider()
+	p := Provide(appConfigs(), iratesce.TestBucketsFactory, testTokensFactory(), storage)
+	as, err := p.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	key := func(as istructs.IAppStructs, view appdef.QName) istructs.IKeyBuilder {
+		kb := as.ViewRecords().KeyBuilder(view)
+		kb.PutInt64("pk", 1)
+		kb.PutInt64("cc", 2)
+		return kb
+	}
+	put := func(view appdef.QName, total int64) {
+		vb := as.ViewRecords().NewValueBuilder(view)
+		vb.PutInt64("total", total)
+		require.NoError(as.ViewRecords().Put(ws, key(as, view), vb))
+	}
+
+	put(viewName, 10)
+	put(otherName, 20)
+
+	require.NoError(TruncateView(context.Background(), as, viewName))
+
+	t.Run("must be not found after truncate", func(t *testing.T) {
+		_, err := as.ViewRecords().Get(ws, key(as, viewName))
+		require.ErrorIs(err, ErrRecordNotFound)
+
+		kb := as.ViewRecords().KeyBuilder(viewName)
+		kb.PutInt64("pk", 1)
+		require.NoError(as.ViewRecords().Read(context.Background(), ws, kb, func(istructs.IKey, istructs.IValue) error {
+			require.Fail("truncated record must not be read")
+			return nil
+		}))
+	})
+
+	t.Run("must be ok to read other view", func(t *testing.T) {
+		v, err := as.ViewRecords().Get(ws, key(as, otherName))
+		require.NoError(err)
+		require.EqualValues(20, v.AsInt64("total"))
+	})
+
+	t.Run("must be ok to put and read records after truncate", func(t *testing.T) {
+		put(viewName, 30)
+		v, err := as.ViewRecords().Get(ws, key(as, viewName))
+		require.NoError(err)
+		require.EqualValues(30, v.AsInt64("total"))
+
+		t.Run("must be ok to read records after application restart", func(t *testing.T) {
+			p := Provide(appConfigs(), iratesce.TestBucketsFactory, testTokensFactory(), storage)
+			as, err := p.AppStructs(istructs.AppQName_test1_app1)
+			require.NoError(err)
+			v, err := as.ViewRecords().Get(ws, key(as, viewName))
+			require.NoError(err)
+			require.EqualValues(30, v.AsInt64("total"))
+		})
+	})
+
+	t.Run("must be error if unknown view", func(t *testing.T) {
+		require.ErrorIs(TruncateView(context.Background(), as, appdef.NewQName("test", "unknown")), ErrNameNotFound)
+	})
+
+	t.Run("must be ok to truncate view with many partitions and records", func(t *testing.T) {
+		const partitions, records = 3, rawDataBatchSize + 1
+		for pk := int64(0); pk < partitions; pk++ {
+			for cc := int64(0); cc < records; cc++ {
+				kb := as.ViewRecords().KeyBuilder(viewName)
+				kb.PutInt64("pk", pk)
+				kb.PutInt64("cc", cc)
+				vb := as.ViewRecords().NewValueBuilder(viewName)
+				vb.PutInt64("total", cc)
+				require.NoError(as.ViewRecords().Put(ws, kb, vb))
+			}
+		}
+
+		require.NoError(TruncateView(context.Background(), as, viewName))
+
+		for pk := int64(0); pk < partitions; pk++ {
+			kb := as.ViewRecords().KeyBuilder(viewName)
+			kb.PutInt64("pk", pk)
+			require.NoError(as.ViewRecords().Read(context.Background(), ws, kb, func(istructs.IKey, istructs.IValue) error {
+				require.Fail("truncated record must not be read")
+				return nil
+			}))
+		}
+
+		_, err := as.ViewRecords().Get(ws, key(as, otherName))
+		require.NoError(err, "other view must not be truncated")
+	})
+}
+
 func Test_ViewRecord_GetBatch(t *testing.T) {
 	require := require.New(t)
 
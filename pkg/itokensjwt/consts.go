@@ -12,6 +12,8 @@ const (
 	hashLength          = 32
 	errorVerifyAudience = "error verify token, this token have %s audience and was intended for principal type %s %w"
 	SecretKeyJWTName    = "secretKeyJWT"
+	rsaKeyBits          = 2048
+	kidHeader           = "kid"
 )
 
 var SecretKeyExample = SecretKeyType{
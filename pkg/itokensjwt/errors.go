@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package itokensjwt
+
+import "errors"
+
+var (
+	ErrUnsupportedSigningKey = errors.New("unsupported signing key, RSA, ECDSA P-256 or Ed25519 private key expected")
+	ErrEmptyKID              = errors.New("signing key kid must not be empty")
+	ErrSigningKeyExists      = errors.New("signing key with the same kid already exists")
+	ErrNoActiveSigningKey    = errors.New("no active signing key")
+)
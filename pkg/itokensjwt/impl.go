@@ -73,11 +73,13 @@ func (j *JWTSigner) ValidateToken(token string, pointerToPayload interface{}) (g
 	jwtToken, err = jwt.
 		Parse(token,
 			func(token *jwt.Token) (interface{}, error) {
-				_, ok := token.Method.(*jwt.SigningMethodHMAC)
-				if !ok {
-					return nil, itokens.ErrInvalidToken
+				if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+					if j.keyRing != nil && !j.acceptHS256 {
+						return nil, itokens.ErrInvalidToken
+					}
+					return j.secretKey, nil
 				}
-				return j.secretKey, nil
+				return j.verificationKey(token)
 			})
 	if jwtToken == nil {
 		if err != nil {
@@ -182,7 +184,7 @@ func setErrorDescription(err error) error {
 			// Token is expired
 			err = itokens.ErrTokenExpired
 		}
-		if ve.Errors&(jwt.ValidationErrorUnverifiable) != 0 {
+		if ve.Errors&(jwt.ValidationErrorUnverifiable|jwt.ValidationErrorSignatureInvalid) != 0 {
 			// Token malformed
 			err = itokens.ErrInvalidToken
 		}
@@ -191,6 +193,15 @@ func setErrorDescription(err error) error {
 }
 
 func (j *JWTSigner) sign(claims jwt.Claims) (token string, err error) {
+	if j.keyRing != nil {
+		key, err := j.keyRing.signingKey()
+		if err != nil {
+			return "", err
+		}
+		jwtToken := jwt.NewWithClaims(key.method, claims)
+		jwtToken.Header[kidHeader] = key.KID
+		return jwtToken.SignedString(key.PrivateKey)
+	}
 
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	if onSecretKeyMutate != nil {
@@ -199,6 +210,19 @@ func (j *JWTSigner) sign(claims jwt.Claims) (token string, err error) {
 	return jwtToken.SignedString(j.secretKey)
 }
 
+// Returns public key of non-retired key ring key the token is signed by
+func (j *JWTSigner) verificationKey(token *jwt.Token) (interface{}, error) {
+	if j.keyRing == nil {
+		return nil, itokens.ErrInvalidToken
+	}
+	kid, _ := token.Header[kidHeader].(string)
+	key, ok := j.keyRing.verificationKey(kid)
+	if !ok || key.method.Alg() != token.Method.Alg() {
+		return nil, itokens.ErrInvalidToken
+	}
+	return key.publicKey, nil
+}
+
 func getTokenPayload(token []string) string {
 	return token[1]
 }
@@ -208,7 +232,7 @@ func NewJWTSigner(secretKey SecretKeyType) *JWTSigner {
 	if len(byteSecretKey) < SecretKeyLength {
 		panic(fmt.Errorf("invalid key length: must be %d chars", SecretKeyLength))
 	}
-	return &JWTSigner{byteSecretKey}
+	return &JWTSigner{secretKey: byteSecretKey}
 }
 
 func mergeClaimsMaps(maps ...map[string]interface{}) (result map[string]interface{}) {
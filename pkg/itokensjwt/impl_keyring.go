@@ -0,0 +1,275 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package itokensjwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// NewKeyRing creates key ring with specified keys
+//
+// ErrEmptyKID, ErrSigningKeyExists or ErrUnsupportedSigningKey might be returned
+func NewKeyRing(timeFunc coreutils.TimeFunc, keys ...SigningKey) (*KeyRing, error) {
+	r := &KeyRing{timeFunc: timeFunc}
+	for _, k := range keys {
+		if err := r.Add(k); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Adds key to the ring. Key with ActivateAt in the future is published in JWKS
+// but is not used to sign tokens until activated
+func (r *KeyRing) Add(key SigningKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.add(key)
+}
+
+// Schedules rotation to the next key.
+//
+// The next key is added to the ring and signs new tokens since next.ActivateAt.
+// Keys activated before the next one are retired overlap after next.ActivateAt,
+// so tokens signed by them remain valid during overlap
+func (r *KeyRing) Rotate(next SigningKey, overlap time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if next.ActivateAt.IsZero() {
+		next.ActivateAt = r.timeFunc()
+	}
+	if err := r.add(next); err != nil {
+		return err
+	}
+
+	retireAt := next.ActivateAt.Add(overlap)
+	for _, k := range r.keys {
+		if k.KID == next.KID || !k.ActivateAt.Before(next.ActivateAt) {
+			continue
+		}
+		if k.RetireAt.IsZero() || k.RetireAt.After(retireAt) {
+			k.RetireAt = retireAt
+		}
+	}
+	return nil
+}
+
+// Returns JWKS with public keys of all non-retired keys, including not yet activated ones
+func (r *KeyRing) JWKS() (jwks JWKS) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.timeFunc()
+	jwks.Keys = make([]JWK, 0, len(r.keys))
+	for _, k := range r.keys {
+		if !k.retired(now) {
+			jwks.Keys = append(jwks.Keys, k.jwk())
+		}
+	}
+	return jwks
+}
+
+// Returns JWKS marshaled to JSON
+func (r *KeyRing) JWKSJSON() ([]byte, error) {
+	return json.Marshal(r.JWKS())
+}
+
+func (r *KeyRing) add(key SigningKey) error {
+	if len(key.KID) == 0 {
+		return ErrEmptyKID
+	}
+	for _, k := range r.keys {
+		if k.KID == key.KID {
+			return fmt.Errorf("%w: %s", ErrSigningKeyExists, key.KID)
+		}
+	}
+	method, err := signingMethod(key.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("key %s: %w", key.KID, err)
+	}
+	r.keys = append(r.keys, &ringKey{
+		SigningKey: key,
+		method:     method,
+		publicKey:  key.PrivateKey.Public(),
+	})
+	return nil
+}
+
+// Returns the most recently activated non-retired key
+func (r *KeyRing) signingKey() (res *ringKey, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.timeFunc()
+	for _, k := range r.keys {
+		if k.retired(now) || k.ActivateAt.After(now) {
+			continue
+		}
+		if res == nil || k.ActivateAt.After(res.ActivateAt) {
+			res = k
+		}
+	}
+	if res == nil {
+		return nil, ErrNoActiveSigningKey
+	}
+	return res, nil
+}
+
+// Returns non-retired key by kid
+func (r *KeyRing) verificationKey(kid string) (*ringKey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.timeFunc()
+	for _, k := range r.keys {
+		if k.KID == kid {
+			return k, !k.retired(now)
+		}
+	}
+	return nil, false
+}
+
+func (k *ringKey) retired(now time.Time) bool {
+	return !k.RetireAt.IsZero() && !now.Before(k.RetireAt)
+}
+
+func (k *ringKey) jwk() JWK {
+	res := JWK{
+		Use: "sig",
+		Alg: k.method.Alg(),
+		Kid: k.KID,
+	}
+	switch pub := k.publicKey.(type) {
+	case *rsa.PublicKey:
+		res.Kty = "RSA"
+		res.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		res.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		res.Kty = "EC"
+		res.Crv = pub.Curve.Params().Name
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		res.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size)))
+		res.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		res.Kty = "OKP"
+		res.Crv = "Ed25519"
+		res.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+	return res
+}
+
+// Returns public key from JWK. RSA, EC P-256 and OKP Ed25519 keys are supported
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+	switch {
+	case k.Kty == "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case k.Kty == "EC" && k.Crv == elliptic.P256().Params().Name:
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case k.Kty == "OKP" && k.Crv == "Ed25519":
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: wrong Ed25519 key size %d", ErrUnsupportedSigningKey, len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("%w: kty %s, crv %s", ErrUnsupportedSigningKey, k.Kty, k.Crv)
+}
+
+func signingMethod(key crypto.Signer) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve == elliptic.P256() {
+			return jwt.SigningMethodES256, nil
+		}
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA, nil
+	}
+	return nil, ErrUnsupportedSigningKey
+}
+
+// GenerateSigningKey generates new key for specified algorithm: RS256, ES256 or EdDSA
+func GenerateSigningKey(kid string, alg string) (key SigningKey, err error) {
+	key.KID = kid
+	switch alg {
+	case jwt.SigningMethodRS256.Alg():
+		key.PrivateKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case jwt.SigningMethodES256.Alg():
+		key.PrivateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case jwt.SigningMethodEdDSA.Alg():
+		_, key.PrivateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnsupportedSigningKey, alg)
+	}
+	return key, err
+}
+
+// ParsePrivateKeyPEM parses PEM encoded PKCS #8, PKCS #1 (RSA) or SEC 1 (EC) private key
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: PEM block not found", ErrUnsupportedSigningKey)
+	}
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedSigningKey
+	}
+	if _, err := signingMethod(signer); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
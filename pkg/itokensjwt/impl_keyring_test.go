@@ -0,0 +1,291 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package itokensjwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/istructs"
+	itokens "github.com/voedger/voedger/pkg/itokens"
+)
+
+func TestBasicUsage_KeyRing(t *testing.T) {
+	for _, alg := range []string{"RS256", "ES256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			require := require.New(t)
+
+			key, err := GenerateSigningKey("key1", alg)
+			require.NoError(err)
+
+			ring, err := NewKeyRing(testTimeFunc, key)
+			require.NoError(err)
+
+			signer := ProvideITokensWithKeyRing(SecretKeyExample, ring, false, testTimeFunc)
+
+			payload := TestPayload_Principal{
+				TestPayload_Login: TestPayload_Login{Login: "login", SubjectKind: istructs.SubjectKind_User},
+				ProfileWSID:       istructs.WSID(123),
+			}
+			token, err := signer.IssueToken(istructs.AppQName_test1_app1, time.Minute, &payload)
+			require.NoError(err)
+
+			header := tokenHeader(t, token)
+			require.Equal(alg, header["alg"])
+			require.Equal("key1", header["kid"])
+
+			validated := TestPayload_Principal{}
+			gp, err := signer.ValidateToken(token, &validated)
+			require.NoError(err)
+			require.Equal(payload, validated)
+			require.Equal(istructs.AppQName_test1_app1, gp.AppQName)
+
+			t.Run("token must be verifiable by JWKS only", func(t *testing.T) {
+				jwks := JWKS{}
+				b, err := ring.JWKSJSON()
+				require.NoError(err)
+				require.NoError(json.Unmarshal(b, &jwks))
+				require.Len(jwks.Keys, 1)
+				jwk := jwks.Keys[0]
+				require.Equal("key1", jwk.Kid)
+				require.Equal(alg, jwk.Alg)
+				require.Equal("sig", jwk.Use)
+
+				parsed, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return jwk.PublicKey() })
+				require.NoError(err)
+				require.True(parsed.Valid)
+			})
+		})
+	}
+}
+
+func TestKeyRingRotation(t *testing.T) {
+	require := require.New(t)
+
+	now := testTime
+	ringTimeFunc := func() time.Time { return now }
+
+	key1, err := GenerateSigningKey("key1", "ES256")
+	require.NoError(err)
+	ring, err := NewKeyRing(ringTimeFunc, key1)
+	require.NoError(err)
+
+	signer := ProvideITokensWithKeyRing(SecretKeyExample, ring, false, testTimeFunc)
+
+	issue := func() string {
+		token, err := signer.IssueToken(istructs.AppQName_test1_app1, time.Minute, &TestPayload_Login{Login: "login"})
+		require.NoError(err)
+		return token
+	}
+	validate := func(token string) error {
+		_, err := signer.ValidateToken(token, &TestPayload_Login{})
+		return err
+	}
+	kids := func() (res []string) {
+		for _, k := range ring.JWKS().Keys {
+			res = append(res, k.Kid)
+		}
+		return res
+	}
+
+	token1 := issue()
+	require.Equal("key1", tokenHeader(t, token1)["kid"])
+
+	key2, err := GenerateSigningKey("key2", "EdDSA")
+	require.NoError(err)
+	key2.ActivateAt = now.Add(time.Hour)
+	require.NoError(ring.Rotate(key2, time.Hour))
+
+	t.Run("scheduled key must be published but not used to sign", func(t *testing.T) {
+		require.Equal([]string{"key1", "key2"}, kids())
+		require.Equal("key1", tokenHeader(t, issue())["kid"])
+	})
+
+	now = now.Add(time.Hour)
+
+	t.Run("activated key must be used to sign, previous key must validate", func(t *testing.T) {
+		token2 := issue()
+		require.Equal("key2", tokenHeader(t, token2)["kid"])
+		require.NoError(validate(token2))
+		require.NoError(validate(token1))
+	})
+
+	now = now.Add(time.Hour)
+
+	t.Run("retired key must not validate and must not be published", func(t *testing.T) {
+		require.ErrorIs(validate(token1), itokens.ErrInvalidToken)
+		require.Equal([]string{"key2"}, kids())
+	})
+
+	t.Run("must be error if no active key", func(t *testing.T) {
+		key3, err := GenerateSigningKey("key3", "RS256")
+		require.NoError(err)
+		key3.ActivateAt = now.Add(time.Hour)
+		emptyRing, err := NewKeyRing(ringTimeFunc, key3)
+		require.NoError(err)
+		_, err = ProvideITokensWithKeyRing(SecretKeyExample, emptyRing, false, testTimeFunc).
+			IssueToken(istructs.AppQName_test1_app1, time.Minute, &TestPayload_Login{})
+		require.ErrorIs(err, itokens.ErrSignerError)
+	})
+}
+
+func TestKeyRingErrors(t *testing.T) {
+	require := require.New(t)
+
+	key, err := GenerateSigningKey("key", "ES256")
+	require.NoError(err)
+
+	_, err = GenerateSigningKey("key", "HS256")
+	require.ErrorIs(err, ErrUnsupportedSigningKey)
+
+	t.Run("key ring must not accept invalid keys", func(t *testing.T) {
+		_, err := NewKeyRing(testTimeFunc, key, key)
+		require.ErrorIs(err, ErrSigningKeyExists)
+
+		_, err = NewKeyRing(testTimeFunc, SigningKey{PrivateKey: key.PrivateKey})
+		require.ErrorIs(err, ErrEmptyKID)
+
+		p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(err)
+		_, err = NewKeyRing(testTimeFunc, SigningKey{KID: "p384", PrivateKey: p384})
+		require.ErrorIs(err, ErrUnsupportedSigningKey)
+	})
+
+	ring, err := NewKeyRing(testTimeFunc, key)
+	require.NoError(err)
+	signer := ProvideITokensWithKeyRing(SecretKeyExample, ring, false, testTimeFunc)
+
+	t.Run("HS256 tokens must not be accepted", func(t *testing.T) {
+		token, err := ProvideITokens(SecretKeyExample, testTimeFunc).IssueToken(istructs.AppQName_test1_app1, time.Minute, &TestPayload_Login{Login: "login"})
+		require.NoError(err)
+		_, err = signer.ValidateToken(token, &TestPayload_Login{})
+		require.ErrorIs(err, itokens.ErrInvalidToken)
+
+		t.Run("must be accepted if allowed to migrate", func(t *testing.T) {
+			payload := TestPayload_Login{}
+			_, err = ProvideITokensWithKeyRing(SecretKeyExample, ring, true, testTimeFunc).ValidateToken(token, &payload)
+			require.NoError(err)
+			require.Equal("login", payload.Login)
+		})
+	})
+
+	t.Run("tokens signed by unknown keys must not be accepted", func(t *testing.T) {
+		otherKey, err := GenerateSigningKey("key", "ES256")
+		require.NoError(err)
+		otherRing, err := NewKeyRing(testTimeFunc, otherKey)
+		require.NoError(err)
+		token, err := ProvideITokensWithKeyRing(SecretKeyExample, otherRing, false, testTimeFunc).IssueToken(istructs.AppQName_test1_app1, time.Minute, &TestPayload_Login{})
+		require.NoError(err)
+
+		_, err = signer.ValidateToken(token, &TestPayload_Login{})
+		require.ErrorIs(err, itokens.ErrInvalidToken, "same kid, other key")
+
+		_, err = ProvideITokens(SecretKeyExample, testTimeFunc).ValidateToken(token, &TestPayload_Login{})
+		require.ErrorIs(err, itokens.ErrInvalidToken, "no key ring")
+	})
+
+	t.Run("tokens with wrong kid or alg must not be accepted", func(t *testing.T) {
+		for _, header := range []map[string]interface{}{{"kid": "unknown"}, {}, {"kid": "key", "alg": "ES384"}} {
+			method := jwt.SigningMethod(jwt.SigningMethodES256)
+			if alg, ok := header["alg"]; ok {
+				method = jwt.GetSigningMethod(alg.(string))
+			}
+			jwtToken := jwt.NewWithClaims(method, jwt.MapClaims{
+				"aud":      "itokensjwt.TestPayload_Login",
+				"exp":      testTime.Add(time.Minute).Unix(),
+				"Duration": time.Minute,
+				"AppQName": istructs.AppQName_test1_app1,
+				"IssuedAt": testTime,
+			})
+			for k, v := range header {
+				jwtToken.Header[k] = v
+			}
+			p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+			require.NoError(err)
+			signKey := key.PrivateKey
+			if method != jwt.SigningMethodES256 {
+				signKey = p384
+			}
+			token, err := jwtToken.SignedString(signKey)
+			require.NoError(err)
+
+			_, err = signer.ValidateToken(token, &TestPayload_Login{})
+			require.ErrorIs(err, itokens.ErrInvalidToken, header)
+		}
+	})
+}
+
+func TestJWKPublicKey(t *testing.T) {
+	require := require.New(t)
+
+	_, err := JWK{Kty: "EC", Crv: "P-384"}.PublicKey()
+	require.ErrorIs(err, ErrUnsupportedSigningKey)
+
+	_, err = JWK{Kty: "OKP", Crv: "Ed25519", X: "AQID"}.PublicKey()
+	require.ErrorIs(err, ErrUnsupportedSigningKey)
+
+	_, err = JWK{Kty: "RSA", N: "!", E: "AQAB"}.PublicKey()
+	require.Error(err)
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	require := require.New(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	require.NoError(err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+
+	pkcs8 := func(key interface{}) []byte {
+		b, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(err)
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b})
+	}
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(err)
+
+	for name, data := range map[string][]byte{
+		"PKCS #8 RSA":     pkcs8(rsaKey),
+		"PKCS #8 ECDSA":   pkcs8(ecKey),
+		"PKCS #8 Ed25519": pkcs8(edKey),
+		"PKCS #1 RSA":     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+		"SEC 1 ECDSA":     pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, err := ParsePrivateKeyPEM(data)
+			require.NoError(err)
+			_, err = NewKeyRing(testTimeFunc, SigningKey{KID: name, PrivateKey: key})
+			require.NoError(err)
+		})
+	}
+
+	t.Run("must be error if not a PEM or unsupported key", func(t *testing.T) {
+		_, err := ParsePrivateKeyPEM([]byte("not a PEM"))
+		require.ErrorIs(err, ErrUnsupportedSigningKey)
+
+		p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(err)
+		_, err = ParsePrivateKeyPEM(pkcs8(p384))
+		require.ErrorIs(err, ErrUnsupportedSigningKey)
+	})
+}
+
+func tokenHeader(t *testing.T, token string) map[string]interface{} {
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+	return parsed.Header
+}
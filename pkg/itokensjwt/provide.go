@@ -26,3 +26,17 @@ func ProvideITokens(secretKey SecretKeyType, timeFunc coreutils.TimeFunc) (token
 	})
 	return NewJWTSigner(secretKey)
 }
+
+// ProvideITokensWithKeyRing provides implementation which signs tokens by the key ring keys (RS256, ES256 or EdDSA).
+// Tokens are validated by any non-retired key ring key. HS256 tokens signed by Secret Key are rejected,
+// unless acceptHS256 is true, which is intended to migrate previously issued tokens only.
+// Secret Key is also used by CryptoHash256. Min length - 64 byte, panic otherwise
+func ProvideITokensWithKeyRing(secretKey SecretKeyType, keyRing *KeyRing, acceptHS256 bool, timeFunc coreutils.TimeFunc) (tokenImpl itokens.ITokens) {
+	onceJWTTimeFuncSetter.Do(func() {
+		jwt.TimeFunc = timeFunc
+	})
+	signer := NewJWTSigner(secretKey)
+	signer.keyRing = keyRing
+	signer.acceptHS256 = acceptHS256
+	return signer
+}
@@ -4,8 +4,65 @@
 
 package itokensjwt
 
+import (
+	"crypto"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
 type SecretKeyType []byte
 
 type JWTSigner struct {
-	secretKey []byte
+	secretKey   []byte
+	keyRing     *KeyRing
+	acceptHS256 bool
+}
+
+// SigningKey is an asymmetric key to sign principal tokens
+type SigningKey struct {
+	// Key identifier, goes to `kid` token header
+	KID string
+	// *rsa.PrivateKey (RS256), *ecdsa.PrivateKey with P-256 curve (ES256) or ed25519.PrivateKey (EdDSA)
+	PrivateKey crypto.Signer
+	// New tokens are signed by the key since this time. Zero means immediately
+	ActivateAt time.Time
+	// Tokens signed by the key are not accepted since this time. Zero means never
+	RetireAt time.Time
+}
+
+// KeyRing holds signing keys identified by `kid`
+//
+// The most recently activated non-retired key is used to sign new tokens,
+// any non-retired key is used to validate tokens
+type KeyRing struct {
+	mu       sync.RWMutex
+	keys     []*ringKey
+	timeFunc coreutils.TimeFunc
+}
+
+type ringKey struct {
+	SigningKey
+	method    jwt.SigningMethod
+	publicKey crypto.PublicKey
+}
+
+// JWK is a public JSON Web Key, ref. RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set, ref. RFC 7517
+type JWKS struct {
+	Keys []JWK `json:"keys"`
 }
@@ -13,12 +13,14 @@ import (
 	"sync/atomic"
 	"unsafe"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
 type metric struct {
 	name string
 	app  istructs.AppQName
+	ext  appdef.QName
 	vvm  string
 }
 
@@ -34,6 +36,10 @@ func (m *metric) App() istructs.AppQName {
 	return m.app
 }
 
+func (m *metric) Ext() appdef.QName {
+	return m.ext
+}
+
 type mapMetrics struct {
 	metrics map[metric]*MetricValue
 	lock    sync.Mutex
@@ -53,6 +59,15 @@ func (m *mapMetrics) AppMetricAddr(metricName string, vvm string, app istructs.A
 	})
 }
 
+func (m *mapMetrics) ExtMetricAddr(metricName string, vvm string, app istructs.AppQName, ext appdef.QName) *MetricValue {
+	return m.get(metric{
+		name: metricName,
+		app:  app,
+		ext:  ext,
+		vvm:  vvm,
+	})
+}
+
 func (m *mapMetrics) MetricAddr(metricName string, vvmName string) *MetricValue {
 	return m.get(metric{
 		name: metricName,
@@ -69,6 +84,10 @@ func (m *mapMetrics) IncreaseApp(metricName string, vvm string, app istructs.App
 	m.AppMetricAddr(metricName, vvm, app).Increase(valueDelta)
 }
 
+func (m *mapMetrics) IncreaseExt(metricName string, vvm string, app istructs.AppQName, ext appdef.QName, valueDelta float64) {
+	m.ExtMetricAddr(metricName, vvm, app, ext).Increase(valueDelta)
+}
+
 func (m *mapMetrics) get(key metric) *MetricValue {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -96,21 +115,29 @@ func (m *mapMetrics) List(cb func(metric IMetric, metricValue float64) (err erro
 func ToPrometheus(metric IMetric, metricValue float64) []byte {
 	bb := bytes.Buffer{}
 	bb.WriteString(metric.Name())
-	if metric.App() != istructs.NullAppQName || metric.Vvm() != "" {
-		bb.WriteRune('{')
-		if metric.App() != istructs.NullAppQName {
-			bb.WriteString(`app="`)
-			bb.WriteString(metric.App().String())
-			bb.WriteRune('"')
-		}
-		if metric.Vvm() != "" {
-			if metric.App() != istructs.NullAppQName {
-				bb.WriteRune(',')
-			}
-			bb.WriteString(`vvm="`)
-			bb.WriteString(metric.Vvm())
-			bb.WriteRune('"')
+	labels := 0
+	label := func(name, value string) {
+		if labels == 0 {
+			bb.WriteRune('{')
+		} else {
+			bb.WriteRune(',')
 		}
+		bb.WriteString(name)
+		bb.WriteString(`="`)
+		bb.WriteString(value)
+		bb.WriteRune('"')
+		labels++
+	}
+	if metric.App() != istructs.NullAppQName {
+		label("app", metric.App().String())
+	}
+	if metric.Ext() != appdef.NullQName {
+		label("extension", metric.Ext().String())
+	}
+	if metric.Vvm() != "" {
+		label("vvm", metric.Vvm())
+	}
+	if labels > 0 {
 		bb.WriteRune('}')
 	}
 	bb.WriteRune(' ')
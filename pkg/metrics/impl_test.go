@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
@@ -22,6 +23,7 @@ func TestBasicUsage(t *testing.T) {
 	metrics.IncreaseApp("somecounter_total", "host1", istructs.AppQName_test1_app1, 0.00015)
 	metrics.IncreaseApp("somecounter_total", "host1", istructs.AppQName_test1_app2, 1)
 	metrics.Increase("somecounter_total", "host1", 7)
+	metrics.IncreaseExt("somecounter_total", "host1", istructs.AppQName_test1_app1, appdef.NewQName("pkg", "ext"), 2)
 
 	collection := make(map[string]bool)
 	_ = metrics.List(func(metric IMetric, metricValue float64) (err error) {
@@ -29,10 +31,11 @@ func TestBasicUsage(t *testing.T) {
 		return err
 	})
 
-	require.Len(collection, 3)
+	require.Len(collection, 4)
 	require.True(collection["somecounter_total{app=\"test1/app1\",vvm=\"host1\"} 0.00035\n"])
 	require.True(collection["somecounter_total{app=\"test1/app2\",vvm=\"host1\"} 1\n"])
 	require.True(collection["somecounter_total{vvm=\"host1\"} 7\n"])
+	require.True(collection["somecounter_total{app=\"test1/app1\",extension=\"pkg.ext\",vvm=\"host1\"} 2\n"])
 }
 
 func TestMetrics_List(t *testing.T) {
@@ -61,6 +64,7 @@ func TestToPrometheus(t *testing.T) {
 	tests := []struct {
 		name  string
 		app   istructs.AppQName
+		ext   appdef.QName
 		vvm   string
 		value float64
 		want  string
@@ -86,6 +90,25 @@ func TestToPrometheus(t *testing.T) {
 			value: 164759,
 			want:  "something_total{app=\"test1/app1\"} 164759\n",
 		},
+		{
+			name:  "With extension",
+			app:   istructs.AppQName_test1_app1,
+			ext:   appdef.NewQName("pkg", "ext"),
+			vvm:   "host",
+			value: 1,
+			want:  "something_total{app=\"test1/app1\",extension=\"pkg.ext\",vvm=\"host\"} 1\n",
+		},
+		{
+			name:  "Only extension",
+			ext:   appdef.NewQName("pkg", "ext"),
+			value: 1,
+			want:  "something_total{extension=\"pkg.ext\"} 1\n",
+		},
+		{
+			name:  "Without labels",
+			value: 1,
+			want:  "something_total 1\n",
+		},
 		{
 			name:  "Big value",
 			app:   istructs.AppQName_test2_app1,
@@ -106,6 +129,7 @@ func TestToPrometheus(t *testing.T) {
 			m := &metric{
 				name: "something_total",
 				app:  test.app,
+				ext:  test.ext,
 				vvm:  test.vvm,
 			}
 
@@ -7,6 +7,7 @@
 package imetrics
 
 import (
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
@@ -17,6 +18,9 @@ type IMetric interface {
 
 	// App returns istructs.NullAppQName when not specified
 	App() istructs.AppQName
+
+	// Ext returns appdef.NullQName when not specified
+	Ext() appdef.QName
 }
 
 type IMetrics interface {
@@ -34,6 +38,13 @@ type IMetrics interface {
 	// @ConcurrentAccess
 	IncreaseApp(metricName string, vvmName string, app istructs.AppQName, valueDelta float64)
 
+	// Increase extension metric value with "delta".
+	// The default metric value is always 0.
+	// Naming best practices: https://prometheus.io/docs/practices/naming/
+	//
+	// @ConcurrentAccess
+	IncreaseExt(metricName string, vvmName string, app istructs.AppQName, ext appdef.QName, valueDelta float64)
+
 	// Returns address of metric value.
 	// Only use atomic operations with that address!
 	//
@@ -46,6 +57,12 @@ type IMetrics interface {
 	// @ConcurrentAccess
 	AppMetricAddr(metricName string, vvmName string, app istructs.AppQName) *MetricValue
 
+	// Returns address of extension metric value.
+	// Only use atomic operations with that address!
+	//
+	// @ConcurrentAccess
+	ExtMetricAddr(metricName string, vvmName string, app istructs.AppQName, ext appdef.QName) *MetricValue
+
 	// GetAll lists current values of all metrics
 	//
 	// @ConcurrentAccess
@@ -27,8 +27,18 @@ const ExportedAppsFile = "apps.yaml"
 const ExportedPkgFolder = "pkg"
 
 const maxNestedTableContainerOccurrences = 100 // FIXME: 100 container occurrences
+
+const (
+	checkNameFmt      = "check$%02d" // name of unnamed CHECK table constraint
+	fieldCheckNameFmt = "%s$check"   // name of CHECK expression declared for field
+)
 const parserLookahead = 10
 
+const (
+	hoursPerDay = 24
+	daysPerYear = 365
+)
+
 var canNotReferenceTo = map[appdef.TypeKind][]appdef.TypeKind{
 	appdef.TypeKind_ODoc:       {},
 	appdef.TypeKind_ORecord:    {},
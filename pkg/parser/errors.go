@@ -35,9 +35,19 @@ var ErrCircularReferenceInInherits = errors.New("circular reference in INHERITS"
 var ErrRegexpCheckOnlyForVarcharField = errors.New("regexp CHECK only available for varchar field")
 var ErrMaxFieldLengthTooLarge = fmt.Errorf("maximum field length is %d", appdef.MaxFieldLength)
 var ErrOnlyInsertForOdocOrORecord = errors.New("only INSERT allowed for ODoc or ORecord")
+var ErrOnlySelectGrantForODocOrORecord = errors.New("only SELECT can be granted on ODoc or ORecord")
+var ErrLimitOnODocOrORecord = errors.New("LIMIT can not be applied to ODoc or ORecord")
 var ErrPackageWithSameNameAlreadyIncludedInApp = errors.New("package with the same name already included in application")
 var ErrStorageDeclaredOnlyInSys = errors.New("storages are only declared in sys package")
 var ErrPkgFolderNotFound = errors.New("pkg folder not found")
+var ErrExtensionLimitsOnlyForWASM = errors.New("limits are only available for WASM commands, queries and projectors")
+var ErrViewTTLMustBePositive = errors.New("view TTL must be positive")
+var ErrDecimalPrecisionOutOfRange = fmt.Errorf("decimal precision must be from 1 to %d", appdef.MaxDecimalPrecision)
+var ErrDecimalScaleExceedsPrecision = errors.New("decimal scale exceeds precision")
+var ErrArrayMaxItemsOutOfRange = fmt.Errorf("array max items must be from 1 to %d", appdef.Occurs_Unbounded-1)
+var ErrCheckExpressionNotBoolean = errors.New("CHECK expression must be boolean")
+var ErrGeneratedFieldNotInTable = errors.New("generated fields are only allowed in tables")
+var ErrGeneratedValueOutOfRange = errors.New("generated value is out of range")
 
 func ErrAppDoesNotDefineUseOfPackage(name string) error {
 	return fmt.Errorf("application does not define use of package %s", name)
@@ -132,10 +142,46 @@ func ErrUndefined(name string) error {
 	return fmt.Errorf("%s undefined", name)
 }
 
+func ErrIndexNotSupportedForTable(tblName string) error {
+	return fmt.Errorf("index is not supported for table %s, only CDoc, CRecord, WDoc and WRecord tables can be indexed", tblName)
+}
+
+func ErrIndexedFieldType(name string) error {
+	return fmt.Errorf("field %s can not be indexed, arrays and bytes fields are not supported", name)
+}
+
 func ErrUndefinedField(name string) error {
 	return fmt.Errorf("undefined field %s", name)
 }
 
+func ErrUndefinedFunction(name string) error {
+	return fmt.Errorf("undefined function %s", name)
+}
+
+func ErrInvalidFunctionArguments(name string) error {
+	return fmt.Errorf("invalid arguments of function %s", name)
+}
+
+func ErrIncompatibleOperands(op string) error {
+	return fmt.Errorf("incompatible operands of %s", op)
+}
+
+func ErrFieldTypeNotSupportedInCheck(name string) error {
+	return fmt.Errorf("type of field %s not supported in CHECK expression", name)
+}
+
+func ErrGeneratedFieldIncompatibleWith(what string) error {
+	return fmt.Errorf("generated field incompatible with %s", what)
+}
+
+func ErrGeneratedFieldReference(name string) error {
+	return fmt.Errorf("generated field can not refer to generated field %s", name)
+}
+
+func ErrIncompatibleGeneratedType(name string) error {
+	return fmt.Errorf("expression type incompatible with generated field %s", name)
+}
+
 func ErrFieldAlreadyInUnique(name string) error {
 	return fmt.Errorf("field %s already in unique constraint", name)
 }
@@ -172,6 +218,14 @@ func ErrViewFieldBytes(name string) error {
 	return fmt.Errorf("bytes field %s not supported in partition key", name)
 }
 
+func ErrViewFieldArray(name string) error {
+	return fmt.Errorf("array field %s not supported in primary key", name)
+}
+
+func ErrArrayItemTypeNotSupported(name string) error {
+	return fmt.Errorf("%s not supported as array item", name)
+}
+
 func ErrVarcharFieldInCC(name string) error {
 	return fmt.Errorf("varchar field %s can only be the last one in clustering key", name)
 }
@@ -17,35 +17,35 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 )
 
-func parseImpl(fileName string, content string) (*SchemaAST, error) {
-	var basicLexer = lexer.MustSimple([]lexer.SimpleRule{
-		{Name: "PreStmtComment", Pattern: `(?:(?:[\n\r]+\s*--[^\n]*)+)|(?:[\n\r]\s*\/\*[\s\S]*?\*\/)`},
-		{Name: "MultilineComment", Pattern: `\/\*[\s\S]*?\*\/`},
-		{Name: "Comment", Pattern: `\s*--[^\r\n]*`},
-		{Name: "Array", Pattern: `\[\]`},
-		{Name: "Float", Pattern: `[-+]?\d+\.\d+`},
-		{Name: "Int", Pattern: `[-+]?\d+`},
-		{Name: "Operators", Pattern: `<>|!=|<=|>=|[-+*/%,()=<>]`}, //( '<>' | '<=' | '>=' | '=' | '<' | '>' | '!=' )"
-		{Name: "Punct", Pattern: `[;\[\].]`},
-		{Name: "DEFAULTNEXTVAL", Pattern: `DEFAULT[ \r\n\t]+NEXTVAL`},
-		{Name: "NOTNULL", Pattern: `NOT[ \r\n\t]+NULL`},
-		{Name: "UNLOGGED", Pattern: `UNLOGGED`},
-		{Name: "EXTENSIONENGINE", Pattern: `EXTENSION[ \r\n\t]+ENGINE`},
-		{Name: "INSERTONCOMMAND", Pattern: `INSERT[ \r\n\t]+ON[ \r\n\t]+COMMAND`},
-		{Name: "INSERTONALLCOMMANDSWITHTAG", Pattern: `INSERT[ \r\n\t]+ON[ \r\n\t]+ALL[ \r\n\t]+COMMANDS[ \r\n\t]+WITH[ \r\n\t]+TAG`},
-		{Name: "SELECTONQUERY", Pattern: `SELECT[ \r\n\t]+ON[ \r\n\t]+QUERY`},
-		{Name: "SELECTONALLQUERIESWITHTAG", Pattern: `SELECT[ \r\n\t]+ON[ \r\n\t]+ALL[ \r\n\t]+QUERIES[ \r\n\t]+WITH[ \r\n\t]+TAG`},
-		{Name: "INSERTONWORKSPACE", Pattern: `INSERT[ \r\n\t]+ON[ \r\n\t]+WORKSPACE`},
-		{Name: "INSERTONALLWORKSPACESWITHTAG", Pattern: `INSERT[ \r\n\t]+ON[ \r\n\t]+ALL[ \r\n\t]+WORKSPACES[ \r\n\t]+WITH[ \r\n\t]+TAG`},
-		{Name: "ONALLTABLESWITHTAG", Pattern: `ON[ \r\n\t]+ALL[ \r\n\t]+TABLES[ \r\n\t]+WITH[ \r\n\t]+TAG`},
-		{Name: "ONTABLE", Pattern: `ON[ \r\n\t]+TABLE`},
-		{Name: "TABLE", Pattern: `TABLE`},
-		{Name: "PRIMARYKEY", Pattern: `PRIMARY[ \r\n\t]+KEY`},
-		{Name: "String", Pattern: `('(\\'|[^'])*')`},
-		{Name: "Ident", Pattern: `([a-zA-Z_]\w*)|("[a-zA-Z_]\w*")`},
-		{Name: "Whitespace", Pattern: `[ \r\n\t]+`},
-	})
+var basicLexer = lexer.MustSimple([]lexer.SimpleRule{
+	{Name: "PreStmtComment", Pattern: `(?:(?:[\n\r]+\s*--[^\n]*)+)|(?:[\n\r]\s*\/\*[\s\S]*?\*\/)`},
+	{Name: "MultilineComment", Pattern: `\/\*[\s\S]*?\*\/`},
+	{Name: "Comment", Pattern: `\s*--[^\r\n]*`},
+	{Name: "Array", Pattern: `\[\]`},
+	{Name: "Float", Pattern: `[-+]?\d+\.\d+`},
+	{Name: "Int", Pattern: `[-+]?\d+`},
+	{Name: "Operators", Pattern: `<>|!=|<=|>=|[-+*/%,()=<>]`}, //( '<>' | '<=' | '>=' | '=' | '<' | '>' | '!=' )"
+	{Name: "Punct", Pattern: `[;\[\].]`},
+	{Name: "DEFAULTNEXTVAL", Pattern: `DEFAULT[ \r\n\t]+NEXTVAL`},
+	{Name: "NOTNULL", Pattern: `NOT[ \r\n\t]+NULL`},
+	{Name: "UNLOGGED", Pattern: `UNLOGGED`},
+	{Name: "EXTENSIONENGINE", Pattern: `EXTENSION[ \r\n\t]+ENGINE`},
+	{Name: "INSERTONCOMMAND", Pattern: `INSERT[ \r\n\t]+ON[ \r\n\t]+COMMAND`},
+	{Name: "INSERTONALLCOMMANDSWITHTAG", Pattern: `INSERT[ \r\n\t]+ON[ \r\n\t]+ALL[ \r\n\t]+COMMANDS[ \r\n\t]+WITH[ \r\n\t]+TAG`},
+	{Name: "SELECTONQUERY", Pattern: `SELECT[ \r\n\t]+ON[ \r\n\t]+QUERY`},
+	{Name: "SELECTONALLQUERIESWITHTAG", Pattern: `SELECT[ \r\n\t]+ON[ \r\n\t]+ALL[ \r\n\t]+QUERIES[ \r\n\t]+WITH[ \r\n\t]+TAG`},
+	{Name: "INSERTONWORKSPACE", Pattern: `INSERT[ \r\n\t]+ON[ \r\n\t]+WORKSPACE`},
+	{Name: "INSERTONALLWORKSPACESWITHTAG", Pattern: `INSERT[ \r\n\t]+ON[ \r\n\t]+ALL[ \r\n\t]+WORKSPACES[ \r\n\t]+WITH[ \r\n\t]+TAG`},
+	{Name: "ONALLTABLESWITHTAG", Pattern: `ON[ \r\n\t]+ALL[ \r\n\t]+TABLES[ \r\n\t]+WITH[ \r\n\t]+TAG`},
+	{Name: "ONTABLE", Pattern: `ON[ \r\n\t]+TABLE`},
+	{Name: "TABLE", Pattern: `TABLE`},
+	{Name: "PRIMARYKEY", Pattern: `PRIMARY[ \r\n\t]+KEY`},
+	{Name: "String", Pattern: `('(\\'|[^'])*')`},
+	{Name: "Ident", Pattern: `([a-zA-Z_]\w*)|("[a-zA-Z_]\w*")`},
+	{Name: "Whitespace", Pattern: `[ \r\n\t]+`},
+})
 
+func parseImpl(fileName string, content string) (*SchemaAST, error) {
 	parser := participle.MustBuild[SchemaAST](
 		participle.Lexer(basicLexer),
 		participle.Elide("Whitespace", "Comment", "MultilineComment", "PreStmtComment"),
@@ -55,6 +55,16 @@ func parseImpl(fileName string, content string) (*SchemaAST, error) {
 	return parser.ParseString(fileName, content)
 }
 
+func parseExpressionImpl(expression string) (*Expression, error) {
+	parser := participle.MustBuild[Expression](
+		participle.Lexer(basicLexer),
+		participle.Elide("Whitespace", "Comment", "MultilineComment", "PreStmtComment"),
+		participle.Unquote("String"),
+		participle.UseLookahead(parserLookahead),
+	)
+	return parser.ParseString("", expression)
+}
+
 func mergeSchemas(mergeFrom, mergeTo *SchemaAST) {
 	// imports
 	mergeTo.Imports = append(mergeTo.Imports, mergeFrom.Imports...)
@@ -326,6 +336,7 @@ func (c *basicContext) err(err error) {
 }
 
 func buildAppDefs(appSchema *AppSchemaAST, builder appdef.IAppDefBuilder, opts ...BuildAppDefsOption) error {
+	builder.SetExpressionCompiler(expressionCompiler{})
 	ctx := newBuildContext(appSchema, builder)
 	for _, opt := range opts {
 		opt(ctx)
@@ -72,6 +72,8 @@ func analyse(c *basicContext, p *PackageSchemaAST) {
 			analyseLimit(v, ictx)
 		case *GrantStmt:
 			analyseGrant(v, ictx)
+		case *IndexStmt:
+			analyseIndex(v, ictx)
 		}
 	})
 }
@@ -79,35 +81,50 @@ func analyse(c *basicContext, p *PackageSchemaAST) {
 func analyseGrant(grant *GrantStmt, c *iterateCtx) {
 
 	// To
-	err := resolveInCtx(grant.To, c, func(f *RoleStmt, _ *PackageSchemaAST) error { return nil })
+	err := resolveInCtx(grant.To, c, func(f *RoleStmt, pkg *PackageSchemaAST) error {
+		grant.role = pkg.NewQName(f.Name)
+		return nil
+	})
 	if err != nil {
 		c.stmtErr(&grant.To.Pos, err)
 	}
 
 	// On
 	if grant.Command {
-		err := resolveInCtx(grant.On, c, func(f *CommandStmt, _ *PackageSchemaAST) error { return nil })
+		err := resolveInCtx(grant.On, c, func(f *CommandStmt, pkg *PackageSchemaAST) error {
+			grant.on = pkg.NewQName(f.Name)
+			return nil
+		})
 		if err != nil {
 			c.stmtErr(&grant.On.Pos, err)
 		}
 	}
 
 	if grant.Query {
-		err := resolveInCtx(grant.On, c, func(f *QueryStmt, _ *PackageSchemaAST) error { return nil })
+		err := resolveInCtx(grant.On, c, func(f *QueryStmt, pkg *PackageSchemaAST) error {
+			grant.on = pkg.NewQName(f.Name)
+			return nil
+		})
 		if err != nil {
 			c.stmtErr(&grant.On.Pos, err)
 		}
 	}
 
 	if grant.Workspace {
-		err := resolveInCtx(grant.On, c, func(f *WorkspaceStmt, _ *PackageSchemaAST) error { return nil })
+		err := resolveInCtx(grant.On, c, func(f *WorkspaceStmt, pkg *PackageSchemaAST) error {
+			grant.on = pkg.NewQName(f.Name)
+			return nil
+		})
 		if err != nil {
 			c.stmtErr(&grant.On.Pos, err)
 		}
 	}
 
 	if grant.AllCommandsWithTag || grant.AllQueriesWithTag || grant.AllWorkspacesWithTag || (grant.AllTablesWithTag != nil) {
-		err := resolveInCtx(grant.On, c, func(f *TagStmt, _ *PackageSchemaAST) error { return nil })
+		err := resolveInCtx(grant.On, c, func(f *TagStmt, pkg *PackageSchemaAST) error {
+			grant.on = pkg.NewQName(f.Name)
+			return nil
+		})
 		if err != nil {
 			c.stmtErr(&grant.On.Pos, err)
 		}
@@ -116,7 +133,11 @@ func analyseGrant(grant *GrantStmt, c *iterateCtx) {
 	var table *TableStmt
 
 	if grant.Table != nil {
-		err := resolveInCtx(grant.On, c, func(f *TableStmt, _ *PackageSchemaAST) error { table = f; return nil })
+		err := resolveInCtx(grant.On, c, func(f *TableStmt, pkg *PackageSchemaAST) error {
+			table = f
+			grant.on = pkg.NewQName(f.Name)
+			return nil
+		})
 		if err != nil {
 			c.stmtErr(&grant.On.Pos, err)
 		}
@@ -148,7 +169,11 @@ func analyseGrant(grant *GrantStmt, c *iterateCtx) {
 			}
 		}
 
+		oDoc := table.tableTypeKind == appdef.TypeKind_ODoc || table.tableTypeKind == appdef.TypeKind_ORecord
 		for _, i := range grant.Table.Items {
+			if oDoc && !i.Select {
+				c.stmtErr(&i.Pos, ErrOnlySelectGrantForODocOrORecord)
+			}
 			for _, column := range i.Columns {
 				if err := checkColumn(column.Value); err != nil {
 					c.stmtErr(&column.Pos, err)
@@ -245,30 +270,78 @@ func analyseRate(r *RateStmt, c *iterateCtx) {
 }
 
 func analyseLimit(u *LimitStmt, c *iterateCtx) {
-	err := resolveInCtx(u.RateName, c, func(l *RateStmt, schema *PackageSchemaAST) error { return nil })
+	err := resolveInCtx(u.RateName, c, func(l *RateStmt, schema *PackageSchemaAST) error {
+		u.rate = schema.NewQName(l.Name)
+		return nil
+	})
 	if err != nil {
 		c.stmtErr(&u.RateName.Pos, err)
 	}
 	if u.Action.Tag != nil {
-		if err = resolveInCtx(*u.Action.Tag, c, func(t *TagStmt, schema *PackageSchemaAST) error { return nil }); err != nil {
+		if err = resolveInCtx(*u.Action.Tag, c, func(t *TagStmt, schema *PackageSchemaAST) error {
+			u.on = schema.NewQName(t.Name)
+			return nil
+		}); err != nil {
 			c.stmtErr(&u.Action.Tag.Pos, err)
 		}
 	} else if u.Action.Command != nil {
-		if err = resolveInCtx(*u.Action.Command, c, func(t *CommandStmt, schema *PackageSchemaAST) error { return nil }); err != nil {
+		if err = resolveInCtx(*u.Action.Command, c, func(t *CommandStmt, schema *PackageSchemaAST) error {
+			u.on = schema.NewQName(t.Name)
+			return nil
+		}); err != nil {
 			c.stmtErr(&u.Action.Command.Pos, err)
 		}
 
 	} else if u.Action.Query != nil {
-		if err = resolveInCtx(*u.Action.Query, c, func(t *QueryStmt, schema *PackageSchemaAST) error { return nil }); err != nil {
+		if err = resolveInCtx(*u.Action.Query, c, func(t *QueryStmt, schema *PackageSchemaAST) error {
+			u.on = schema.NewQName(t.Name)
+			return nil
+		}); err != nil {
 			c.stmtErr(&u.Action.Query.Pos, err)
 		}
 	} else if u.Action.Table != nil {
-		if err = resolveInCtx(*u.Action.Table, c, func(t *TableStmt, schema *PackageSchemaAST) error { return nil }); err != nil {
+		if err = resolveInCtx(*u.Action.Table, c, func(t *TableStmt, schema *PackageSchemaAST) error {
+			if t.Abstract {
+				return ErrUseOfAbstractTable(u.Action.Table.String())
+			}
+			if t.tableTypeKind == appdef.TypeKind_ODoc || t.tableTypeKind == appdef.TypeKind_ORecord {
+				return ErrLimitOnODocOrORecord
+			}
+			u.on = schema.NewQName(t.Name)
+			return nil
+		}); err != nil {
 			c.stmtErr(&u.Action.Table.Pos, err)
 		}
 	}
 }
 
+func analyseIndex(idx *IndexStmt, c *iterateCtx) {
+	err := resolveInCtx(idx.Table, c, func(tbl *TableStmt, pkg *PackageSchemaAST) error {
+		if tbl.Abstract {
+			return ErrUseOfAbstractTable(idx.Table.String())
+		}
+		switch tbl.tableTypeKind {
+		case appdef.TypeKind_CDoc, appdef.TypeKind_CRecord, appdef.TypeKind_WDoc, appdef.TypeKind_WRecord:
+		default:
+			return ErrIndexNotSupportedForTable(idx.Table.String())
+		}
+		idx.table = pkg.NewQName(tbl.Name)
+		return nil
+	})
+	if err != nil {
+		c.stmtErr(&idx.Table.Pos, err)
+	}
+
+	fields := make(map[Ident]bool)
+	for i := range idx.Fields {
+		f := &idx.Fields[i]
+		if fields[f.Value] {
+			c.stmtErr(&f.Pos, ErrRedefined(string(f.Value)))
+		}
+		fields[f.Value] = true
+	}
+}
+
 func analyseView(view *ViewStmt, c *iterateCtx) {
 	view.pkRef = nil
 	fields := make(map[string]int)
@@ -288,6 +361,8 @@ func analyseView(view *ViewStmt, c *iterateCtx) {
 			} else {
 				fields[string(f.Name.Value)] = i
 			}
+			analyseDecimal(f.Type.Decimal, c)
+			analyseArray(f.Array, &f.Type, c)
 		} else if fe.RefField != nil {
 			rf := fe.RefField
 			if _, ok := fields[string(rf.Name.Value)]; ok {
@@ -329,6 +404,9 @@ func analyseView(view *ViewStmt, c *iterateCtx) {
 			if fld.Type.Bytes != nil {
 				c.stmtErr(&pkf.Pos, ErrViewFieldBytes(string(pkf.Value)))
 			}
+			if fld.Array != nil {
+				c.stmtErr(&pkf.Pos, ErrViewFieldArray(string(pkf.Value)))
+			}
 		}
 	}
 
@@ -346,9 +424,16 @@ func analyseView(view *ViewStmt, c *iterateCtx) {
 			if fld.Type.Bytes != nil && !last {
 				c.stmtErr(&ccf.Pos, ErrBytesFieldInCC(string(ccf.Value)))
 			}
+			if fld.Array != nil {
+				c.stmtErr(&ccf.Pos, ErrViewFieldArray(string(ccf.Value)))
+			}
 		}
 	}
 
+	if view.TTL != nil && view.TTL.Amount <= 0 {
+		c.stmtErr(&view.TTL.Pos, ErrViewTTLMustBePositive)
+	}
+
 	// ResultOf
 	var projector *ProjectorStmt
 	err := resolveInCtx(view.ResultOf, c, func(f *ProjectorStmt, _ *PackageSchemaAST) error {
@@ -598,20 +683,28 @@ func analyseProjector(v *ProjectorStmt, c *iterateCtx) {
 		}
 	}
 
+	analyseWith(&v.With, v, c)
 }
 
 // Note: function may update with argument
 func analyseWith(with *[]WithItem, statement IStatement, c *iterateCtx) {
 	var comment *WithItem
+	limits := false
 
 	for i := range *with {
 		item := &(*with)[i]
 		if item.Comment != nil {
 			comment = item
 		}
+		limits = limits || item.limit()
+		item.tags = nil
 		for j := range item.Tags {
 			tag := item.Tags[j]
-			if err := resolveInCtx(tag, c, func(*TagStmt, *PackageSchemaAST) error { return nil }); err != nil {
+			err := resolveInCtx(tag, c, func(t *TagStmt, pkg *PackageSchemaAST) error {
+				item.tags = append(item.tags, pkg.NewQName(t.Name))
+				return nil
+			})
+			if err != nil {
 				c.stmtErr(&tag.Pos, err)
 			}
 		}
@@ -620,6 +713,21 @@ func analyseWith(with *[]WithItem, statement IStatement, c *iterateCtx) {
 	if comment != nil {
 		statement.SetComments(strings.Split(*comment.Comment, "\n"))
 	}
+
+	if limits {
+		wasm := false
+		switch s := statement.(type) {
+		case *CommandStmt:
+			wasm = s.Engine.WASM
+		case *QueryStmt:
+			wasm = s.Engine.WASM
+		case *ProjectorStmt:
+			wasm = s.Engine.WASM
+		}
+		if !wasm {
+			c.stmtErr(statement.GetPos(), ErrExtensionLimitsOnlyForWASM)
+		}
+	}
 }
 
 func preAnalyseTable(v *TableStmt, c *iterateCtx) {
@@ -774,6 +882,48 @@ func lookupField(items []TableItemExpr, name Ident) bool {
 	return false
 }
 
+func analyseDecimal(d *TypeDecimal, c *iterateCtx) {
+	if d == nil || d.Precision == nil {
+		return
+	}
+	if *d.Precision == 0 || *d.Precision > uint64(appdef.MaxDecimalPrecision) {
+		c.stmtErr(&d.Pos, ErrDecimalPrecisionOutOfRange)
+		return
+	}
+	if d.Scale != nil && *d.Scale > *d.Precision {
+		c.stmtErr(&d.Pos, ErrDecimalScaleExceedsPrecision)
+	}
+}
+
+func analyseArray(a *DataTypeArray, dt *DataType, c *iterateCtx) {
+	if a == nil {
+		return
+	}
+	if a.MaxItems != nil && (*a.MaxItems == 0 || *a.MaxItems >= uint64(appdef.Occurs_Unbounded)) {
+		c.stmtErr(&a.Pos, ErrArrayMaxItemsOutOfRange)
+	}
+	if dt != nil {
+		if k := dataTypeToDataKind(*dt); !k.IsSupportedArrayItem() {
+			c.stmtErr(&a.Pos, ErrArrayItemTypeNotSupported(k.TrimString()))
+		}
+	}
+}
+
+func analyseGenerated(field *FieldExpr, c *iterateCtx, isTable bool) {
+	switch {
+	case !isTable:
+		c.stmtErr(&field.Pos, ErrGeneratedFieldNotInTable)
+	case field.Type.DataType == nil:
+		c.stmtErr(&field.Pos, ErrGeneratedFieldIncompatibleWith("nested table"))
+	case field.Array != nil:
+		c.stmtErr(&field.Pos, ErrGeneratedFieldIncompatibleWith("array"))
+	case (field.DefaultIntValue != nil) || (field.DefaultStringValue != nil):
+		c.stmtErr(&field.Pos, ErrGeneratedFieldIncompatibleWith("DEFAULT"))
+	case field.Verifiable:
+		c.stmtErr(&field.Pos, ErrGeneratedFieldIncompatibleWith("VERIFIABLE"))
+	}
+}
+
 func analyseFields(items []TableItemExpr, c *iterateCtx, isTable bool) {
 	fieldsInUniques := make([]Ident, 0)
 	constraintNames := make(map[string]bool)
@@ -781,6 +931,9 @@ func analyseFields(items []TableItemExpr, c *iterateCtx, isTable bool) {
 		item := items[i]
 		if item.Field != nil {
 			field := item.Field
+			if field.Generated != nil {
+				analyseGenerated(field, c, isTable)
+			}
 			if field.CheckRegexp != nil {
 				if field.Type.DataType != nil && field.Type.DataType.Varchar != nil {
 					_, err := regexp.Compile(field.CheckRegexp.Regexp)
@@ -804,7 +957,10 @@ func analyseFields(items []TableItemExpr, c *iterateCtx, isTable bool) {
 						c.stmtErr(&bb.Pos, ErrMaxFieldLengthTooLarge)
 					}
 				}
+				analyseDecimal(field.Type.DataType.Decimal, c)
+				analyseArray(field.Array, field.Type.DataType, c)
 			} else {
+				analyseArray(field.Array, nil, c)
 				if !isTable { // analysing a TYPE
 					err := resolveInCtx(*field.Type.Def, c, func(f *TypeStmt, pkg *PackageSchemaAST) error {
 						field.Type.qName = pkg.NewQName(f.Name)
@@ -7,6 +7,8 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/alecthomas/participle/v2/lexer"
 
@@ -37,16 +39,21 @@ type buildFunc func() error
 
 func (c *buildContext) build() error {
 	var steps = []buildFunc{
+		c.tags,
 		c.types,
 		c.rates,
 		c.tables,
+		c.indexes,
 		c.views,
 		c.commands,
 		c.projectors,
 		c.queries,
+		c.limits,
+		c.roles,
 		c.workspaces,
 		c.alterWorkspaces,
 		c.inheritedWorkspaces,
+		c.grants,
 	}
 	for _, step := range steps {
 		if err := step(); err != nil {
@@ -66,16 +73,7 @@ type wsBuildCtx struct {
 
 func supported(stmt interface{}) bool {
 	// FIXME: this must be empty in the end
-	if _, ok := stmt.(*TagStmt); ok {
-		return false
-	}
-	if _, ok := stmt.(*RoleStmt); ok {
-		return false
-	}
-	if _, ok := stmt.(*RateStmt); ok {
-		return false
-	}
-	if _, ok := stmt.(*LimitStmt); ok {
+	if _, ok := stmt.(*IndexStmt); ok {
 		return false
 	}
 	return true
@@ -97,20 +95,189 @@ func (c *buildContext) useStmtInWs(wsctx *wsBuildCtx, stmtPackage string, stmt i
 	}
 }
 
+func (c *buildContext) tags() error {
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(tag *TagStmt, ictx *iterateCtx) {
+			b := c.builder.AddTag(schema.NewQName(tag.Name))
+			c.addComments(tag, b)
+		})
+	}
+	return nil
+}
+
 func (c *buildContext) rates() error {
 	for _, schema := range c.app.Packages {
 		iteratePackageStmt(schema, &c.basicContext, func(rate *RateStmt, ictx *iterateCtx) {
-			if rate.Value.Variable != nil {
+			count := 0
+			if rate.Value.Count != nil {
+				count = *rate.Value.Count
+			} else if rate.Value.declare != nil {
+				count = *rate.Value.declare.DefaultValue
 				if c.variableResolver != nil {
-					c.variableResolver.AsInt32(rate.Value.variable)
-					// TODO: use in appdef builder
+					if v, ok := c.variableResolver.AsInt32(rate.Value.variable); ok {
+						count = int(v)
+					}
+				}
+			}
+			period := rate.Value.TimeUnit.Duration()
+			if rate.Value.TimeUnitAmounts != nil {
+				period *= time.Duration(*rate.Value.TimeUnitAmounts)
+			}
+			scopes := []appdef.RateScope{}
+			if rate.ObjectScope != nil {
+				if rate.ObjectScope.PerAppPartition {
+					scopes = append(scopes, appdef.RateScope_AppPartition)
+				}
+				if rate.ObjectScope.PerWorkspace {
+					scopes = append(scopes, appdef.RateScope_Workspace)
 				}
 			}
+			if rate.SubjectScope != nil {
+				if rate.SubjectScope.PerUser {
+					scopes = append(scopes, appdef.RateScope_User)
+				}
+				if rate.SubjectScope.PerIp {
+					scopes = append(scopes, appdef.RateScope_IP)
+				}
+			}
+			b := c.builder.AddRate(schema.NewQName(rate.Name), appdef.RateCount(count), period, scopes...)
+			c.addComments(rate, b)
+		})
+	}
+	return nil
+}
+
+func (c *buildContext) limits() error {
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(limit *LimitStmt, ictx *iterateCtx) {
+			var on []appdef.QName
+			if limit.Action.Everything {
+				on = c.typesOfKind(appdef.NullQName,
+					appdef.TypeKind_Command, appdef.TypeKind_Query,
+					appdef.TypeKind_GDoc, appdef.TypeKind_GRecord,
+					appdef.TypeKind_CDoc, appdef.TypeKind_CRecord,
+					appdef.TypeKind_WDoc, appdef.TypeKind_WRecord)
+			} else {
+				on = []appdef.QName{limit.on} // table, command, query or tag
+			}
+			b := c.builder.AddLimit(schema.NewQName(limit.Name), on, limit.rate)
+			c.addComments(limit, b)
 		})
 	}
 	return nil
 }
 
+func (c *buildContext) roles() error {
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(role *RoleStmt, ictx *iterateCtx) {
+			b := c.builder.AddRole(schema.NewQName(role.Name))
+			c.addComments(role, b)
+		})
+	}
+	return nil
+}
+
+func (c *buildContext) grants() error {
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(grant *GrantStmt, ictx *iterateCtx) {
+			c.grant(grant)
+		})
+	}
+	return nil
+}
+
+func (c *buildContext) grant(grant *GrantStmt) {
+	role, ok := c.builder.Role(grant.role).(appdef.IRoleBuilder)
+	if !ok {
+		return // error is reported on the analysis stage
+	}
+	comments := grant.GetComments()
+
+	switch {
+	case grant.Command, grant.Query:
+		role.Grant([]appdef.PrivilegeKind{appdef.PrivilegeKind_Execute}, []appdef.QName{grant.on}, nil, comments...)
+	case grant.Workspace:
+		role.Grant([]appdef.PrivilegeKind{appdef.PrivilegeKind_Insert}, []appdef.QName{grant.on}, nil, comments...)
+	case grant.AllCommandsWithTag:
+		if names := c.typesOfKind(grant.on, appdef.TypeKind_Command); len(names) > 0 {
+			role.Grant([]appdef.PrivilegeKind{appdef.PrivilegeKind_Execute}, names, nil, comments...)
+		}
+	case grant.AllQueriesWithTag:
+		if names := c.typesOfKind(grant.on, appdef.TypeKind_Query); len(names) > 0 {
+			role.Grant([]appdef.PrivilegeKind{appdef.PrivilegeKind_Execute}, names, nil, comments...)
+		}
+	case grant.AllWorkspacesWithTag:
+		// workspaces can not be tagged yet
+	case grant.AllTablesWithTag != nil:
+		tables := c.typesOfKind(grant.on,
+			appdef.TypeKind_GDoc, appdef.TypeKind_CDoc, appdef.TypeKind_WDoc, appdef.TypeKind_ODoc,
+			appdef.TypeKind_GRecord, appdef.TypeKind_CRecord, appdef.TypeKind_WRecord, appdef.TypeKind_ORecord)
+		for _, table := range tables {
+			if grant.AllTablesWithTag.GrantAll {
+				role.GrantAll([]appdef.QName{table}, comments...)
+				continue
+			}
+			for _, item := range grant.AllTablesWithTag.Items {
+				c.grantOnTable(role, table, item.Insert, item.Update, item.Select, nil, comments)
+			}
+		}
+	case grant.Table != nil:
+		if grant.Table.GrantAll != nil {
+			if len(grant.Table.GrantAll.Columns) == 0 {
+				role.GrantAll([]appdef.QName{grant.on}, comments...)
+			} else {
+				c.grantOnTable(role, grant.on, true, true, true, grant.Table.GrantAll.Columns, comments)
+			}
+		}
+		for _, item := range grant.Table.Items {
+			c.grantOnTable(role, grant.on, item.Insert, item.Update, item.Select, item.Columns, comments)
+		}
+	}
+}
+
+// Grants table privileges to the role. Insert and update privileges are skipped for ODocs and ORecords
+func (c *buildContext) grantOnTable(role appdef.IRoleBuilder, table appdef.QName, insert, update, sel bool, columns []Identifier, comments []string) {
+	kinds := []appdef.PrivilegeKind{}
+	switch c.builder.Type(table).Kind() {
+	case appdef.TypeKind_ODoc, appdef.TypeKind_ORecord:
+	default:
+		if insert {
+			kinds = append(kinds, appdef.PrivilegeKind_Insert)
+		}
+		if update {
+			kinds = append(kinds, appdef.PrivilegeKind_Update)
+		}
+	}
+	if sel {
+		kinds = append(kinds, appdef.PrivilegeKind_Select)
+	}
+	if len(kinds) == 0 {
+		return
+	}
+	fields := make([]string, 0, len(columns))
+	for _, column := range columns {
+		fields = append(fields, string(column.Value))
+	}
+	role.Grant(kinds, []appdef.QName{table}, fields, comments...)
+}
+
+// Returns names of non-abstract types of specified kinds with the tag. If tag is NullQName, then types are not filtered by tag
+func (c *buildContext) typesOfKind(tag appdef.QName, kinds ...appdef.TypeKind) (names []appdef.QName) {
+	c.builder.Types(func(t appdef.IType) {
+		if !slices.Contains(kinds, t.Kind()) {
+			return
+		}
+		if a, ok := t.(appdef.IWithAbstract); ok && a.Abstract() {
+			return
+		}
+		if tag != appdef.NullQName && !c.builder.TagsOf(t.QName()).Contains(tag) {
+			return
+		}
+		names = append(names, t.QName())
+	})
+	return names
+}
+
 func (c *buildContext) workspaces() error {
 
 	var iter func(ws *WorkspaceStmt, wsctx *wsBuildCtx, coll IStatementCollection)
@@ -220,6 +387,33 @@ func (c *buildContext) inheritedWorkspaces() error {
 	return nil
 }
 
+func (c *buildContext) setLimits(with []WithItem, builder appdef.IExtensionBuilder) {
+	limits := appdef.ExtensionLimits{}
+	for _, item := range with {
+		if item.MaxDurationMs != nil {
+			limits.MaxDuration = time.Duration(*item.MaxDurationMs) * time.Millisecond
+		}
+		if item.MaxHostCalls != nil {
+			limits.MaxHostCalls = uint(*item.MaxHostCalls)
+		}
+		if item.MaxIntents != nil {
+			limits.MaxIntents = uint(*item.MaxIntents)
+		}
+		if item.MaxMemoryPages != nil {
+			limits.MaxMemoryPages = uint(*item.MaxMemoryPages)
+		}
+	}
+	builder.SetLimits(limits)
+}
+
+func (c *buildContext) addTags(with []WithItem, builder appdef.ITypeBuilder) {
+	for _, item := range with {
+		if len(item.tags) > 0 {
+			builder.AddTag(item.tags...)
+		}
+	}
+}
+
 func (c *buildContext) addComments(s IStatement, builder appdef.ICommentBuilder) {
 	comments := s.GetComments()
 	if len(comments) > 0 {
@@ -283,9 +477,11 @@ func (c *buildContext) projectors() error {
 			}
 
 			c.addComments(proj, builder)
+			c.addTags(proj.With, builder)
 			builder.SetName(proj.GetName())
 			if proj.Engine.WASM {
 				builder.SetEngine(appdef.ExtensionEngineKind_WASM)
+				c.setLimits(proj.With, builder)
 			} else {
 				builder.SetEngine(appdef.ExtensionEngineKind_BuiltIn)
 			}
@@ -303,6 +499,9 @@ func (c *buildContext) views() error {
 				return c.defCtx().defBuilder.(appdef.IViewBuilder)
 			}
 			c.addComments(view, vb())
+			if view.TTL != nil {
+				vb().SetTTL(view.TTL.Duration())
+			}
 
 			resolveConstraints := func(f *ViewField) []appdef.IConstraint {
 				cc := []appdef.IConstraint{}
@@ -315,6 +514,8 @@ func (c *buildContext) views() error {
 					if (f.Type.Varchar != nil) && (f.Type.Varchar.MaxLen != nil) {
 						cc = append(cc, appdef.MaxLen(uint16(*f.Type.Varchar.MaxLen)))
 					}
+				case appdef.DataKind_decimal:
+					cc = append(cc, decimalConstraints(f.Type.Decimal)...)
 				}
 				return cc
 			}
@@ -326,7 +527,7 @@ func (c *buildContext) views() error {
 					}
 				}
 				if f.Field != nil {
-					vb().KeyBuilder().PartKeyBuilder().AddField(string(f.Field.Name.Value), dataTypeToDataKind(f.Field.Type))
+					vb().KeyBuilder().PartKeyBuilder().AddField(string(f.Field.Name.Value), dataTypeToDataKind(f.Field.Type), resolveConstraints(f.Field)...)
 					comment(f.Field.Name.Value, f.Field.Statement)
 					return
 				}
@@ -362,7 +563,12 @@ func (c *buildContext) views() error {
 				}
 				if f.Field != nil {
 					k := dataTypeToDataKind(f.Field.Type)
-					vb().ValueBuilder().AddDataField(string(f.Field.Name.Value), appdef.SysDataName(k), f.Field.NotNull, resolveConstraints(f.Field)...)
+					if f.Field.Array != nil {
+						minItems, maxItems := arrayItems(f.Field.Array, f.Field.NotNull)
+						vb().ValueBuilder().AddArrayField(string(f.Field.Name.Value), k, minItems, maxItems, resolveConstraints(f.Field)...)
+					} else {
+						vb().ValueBuilder().AddDataField(string(f.Field.Name.Value), appdef.SysDataName(k), f.Field.NotNull, resolveConstraints(f.Field)...)
+					}
 					comment(f.Field.Name.Value, f.Field.Statement)
 					return
 				}
@@ -392,6 +598,7 @@ func (c *buildContext) commands() error {
 			qname := schema.NewQName(cmd.Name)
 			b := c.builder.AddCommand(qname)
 			c.addComments(cmd, b)
+			c.addTags(cmd.With, b)
 			if cmd.Param != nil {
 				setParam(ictx, cmd.Param, func(qn appdef.QName) { b.SetParam(qn) })
 			}
@@ -404,6 +611,7 @@ func (c *buildContext) commands() error {
 			b.SetName(cmd.GetName())
 			if cmd.Engine.WASM {
 				b.SetEngine(appdef.ExtensionEngineKind_WASM)
+				c.setLimits(cmd.With, b)
 			} else {
 				b.SetEngine(appdef.ExtensionEngineKind_BuiltIn)
 			}
@@ -418,6 +626,7 @@ func (c *buildContext) queries() error {
 			qname := schema.NewQName(q.Name)
 			b := c.builder.AddQuery(qname)
 			c.addComments(q, b)
+			c.addTags(q.With, b)
 			if q.Param != nil {
 				setParam(ictx, q.Param, func(qn appdef.QName) { b.SetParam(qn) })
 			}
@@ -427,6 +636,7 @@ func (c *buildContext) queries() error {
 			b.SetName(q.GetName())
 			if q.Engine.WASM {
 				b.SetEngine(appdef.ExtensionEngineKind_WASM)
+				c.setLimits(q.With, b)
 			} else {
 				b.SetEngine(appdef.ExtensionEngineKind_BuiltIn)
 			}
@@ -447,6 +657,36 @@ func (c *buildContext) tables() error {
 	return errors.Join(c.errs...)
 }
 
+func (c *buildContext) indexes() error {
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(idx *IndexStmt, ictx *iterateCtx) {
+			c.index(schema, idx)
+		})
+	}
+	return errors.Join(c.errs...)
+}
+
+func (c *buildContext) index(schema *PackageSchemaAST, idx *IndexStmt) {
+	rec, ok := c.builder.TypeByName(idx.table).(appdef.IRecordBuilder)
+	if !ok {
+		return
+	}
+	fields := make([]string, 0, len(idx.Fields))
+	for _, f := range idx.Fields {
+		fld := rec.Field(string(f.Value))
+		if fld == nil {
+			c.stmtErr(&f.Pos, ErrUndefinedField(string(f.Value)))
+			return
+		}
+		if fld.IsArray() || (fld.DataKind() == appdef.DataKind_bytes) {
+			c.stmtErr(&f.Pos, ErrIndexedFieldType(string(f.Value)))
+			return
+		}
+		fields = append(fields, fld.Name())
+	}
+	rec.AddIndex(schema.NewQName(idx.Name), fields, idx.GetComments()...)
+}
+
 func (c *buildContext) fillTable(table *TableStmt, ictx *iterateCtx) {
 	if table.Inherits != nil {
 		if err := resolveInCtx(*table.Inherits, ictx, func(t *TableStmt, schema *PackageSchemaAST) error {
@@ -480,6 +720,7 @@ func (c *buildContext) table(schema *PackageSchemaAST, table *TableStmt, ictx *i
 	}
 	c.pushDef(qname, table.tableTypeKind)
 	c.addComments(table, c.defCtx().defBuilder.(appdef.ICommentBuilder))
+	c.addTags(table.With, c.defCtx().defBuilder.(appdef.ITypeBuilder))
 	c.fillTable(table, ictx)
 	if table.singletone {
 		c.defCtx().defBuilder.(appdef.ICDocBuilder).SetSingleton()
@@ -526,23 +767,27 @@ func (c *buildContext) addDataTypeField(field *FieldExpr) {
 	fieldName := string(field.Name)
 	sysDataKind := dataTypeToDataKind(*field.Type.DataType)
 
+	constraints := make([]appdef.IConstraint, 0)
 	if field.Type.DataType.Bytes != nil {
 		if field.Type.DataType.Bytes.MaxLen != nil {
-			bld.AddField(fieldName, appdef.DataKind_bytes, field.NotNull, appdef.MaxLen(uint16(*field.Type.DataType.Bytes.MaxLen)))
-		} else {
-			bld.AddField(fieldName, appdef.DataKind_bytes, field.NotNull)
+			constraints = append(constraints, appdef.MaxLen(uint16(*field.Type.DataType.Bytes.MaxLen)))
 		}
 	} else if field.Type.DataType.Varchar != nil {
-		constraints := make([]appdef.IConstraint, 0)
 		if field.Type.DataType.Varchar.MaxLen != nil {
 			constraints = append(constraints, appdef.MaxLen(uint16(*field.Type.DataType.Varchar.MaxLen)))
 		}
 		if field.CheckRegexp != nil {
 			constraints = append(constraints, appdef.Pattern(field.CheckRegexp.Regexp))
 		}
-		bld.AddField(fieldName, appdef.DataKind_string, field.NotNull, constraints...)
+	} else if field.Type.DataType.Decimal != nil {
+		constraints = append(constraints, decimalConstraints(field.Type.DataType.Decimal)...)
+	}
+
+	if field.Array != nil {
+		minItems, maxItems := arrayItems(field.Array, field.NotNull)
+		bld.AddArrayField(fieldName, sysDataKind, minItems, maxItems, constraints...)
 	} else {
-		bld.AddField(fieldName, sysDataKind, field.NotNull)
+		bld.AddField(fieldName, sysDataKind, field.NotNull, constraints...)
 	}
 
 	if field.Verifiable {
@@ -564,14 +809,13 @@ func (c *buildContext) addObjectFieldToType(field *FieldExpr) {
 	}
 
 	maxOccur := 1
-	// not supported by kernel yet
-	// if field.Type.Array != nil {
-	// 	if field.Type.Array.Unbounded {
-	// 		maxOccur = maxNestedTableContainerOccurrences
-	// 	} else {
-	// 		maxOccur = field.Type.Array.MaxOccurs
-	// 	}
-	// }
+	if field.Array != nil {
+		if field.Array.Unbounded {
+			maxOccur = maxNestedTableContainerOccurrences
+		} else {
+			maxOccur = int(*field.Array.MaxItems)
+		}
+	}
 	c.defCtx().defBuilder.(appdef.IObjectBuilder).AddContainer(string(field.Name), field.Type.qName, appdef.Occurs(minOccur), appdef.Occurs(maxOccur))
 }
 
@@ -689,6 +933,50 @@ func (c *buildContext) addTableItems(items []TableItemExpr, ictx *iterateCtx) {
 			c.addFieldsOf(&item.FieldSet.Pos, item.FieldSet.Type, ictx)
 		}
 	}
+
+	// generated fields and checks are set after all fields, because expression may refer to any field of table
+	for _, item := range items {
+		if (item.Field != nil) && (item.Field.Generated != nil) {
+			c.defCtx().defBuilder.(appdef.IFieldsBuilder).SetFieldGenerated(string(item.Field.Name), item.Field.Generated.String())
+		}
+	}
+	for _, item := range items {
+		if (item.Field != nil) && (item.Field.Generated != nil) {
+			c.compileGeneratedField(item.Field)
+		}
+	}
+	for _, item := range items {
+		if (item.Field != nil) && (item.Field.CheckExpression != nil) {
+			c.addCheckToDef(&item.Field.Pos, fmt.Sprintf(fieldCheckNameFmt, item.Field.Name), item.Field.CheckExpression, nil)
+		} else if (item.Constraint != nil) && (item.Constraint.Check != nil) {
+			c.addCheckToDef(&item.Constraint.Pos, string(item.Constraint.ConstraintName), &item.Constraint.Check.Expression, item.Constraint.GetComments())
+		}
+	}
+}
+
+func (c *buildContext) compileGeneratedField(field *FieldExpr) {
+	fields := c.defCtx().defBuilder.(appdef.IFields)
+	name := string(field.Name)
+	if _, err := compileGenerated(fields.Field(name).GeneratedAs(), fields, name); err != nil {
+		c.stmtErr(&field.Pos, err)
+	}
+}
+
+func (c *buildContext) addCheckToDef(pos *lexer.Position, name string, expr *Expression, comments []string) {
+	checks := c.defCtx().defBuilder.(appdef.IChecks)
+	if name == "" {
+		name = fmt.Sprintf(checkNameFmt, checks.CheckCount()+1)
+	}
+	if checks.Check(name) != nil {
+		c.stmtErr(pos, ErrRedefined(name))
+		return
+	}
+	e := expr.String()
+	if _, err := compileCheck(e, c.defCtx().defBuilder.(appdef.IFields)); err != nil {
+		c.stmtErr(pos, err)
+		return
+	}
+	c.defCtx().defBuilder.(appdef.IChecksBuilder).AddCheck(name, e, comments...)
 }
 
 func (c *buildContext) addFieldsOf(pos *lexer.Position, of DefQName, ictx *iterateCtx) {
@@ -0,0 +1,609 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package parser
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Kind of CHECK expression value
+type checkKind uint8
+
+const (
+	checkKind_null checkKind = iota // NULL literal, compatible with any kind
+	checkKind_int
+	checkKind_float
+	checkKind_string
+	checkKind_bool
+)
+
+func (k checkKind) numeric() bool {
+	return (k == checkKind_null) || (k == checkKind_int) || (k == checkKind_float)
+}
+
+func (k checkKind) boolean() bool {
+	return (k == checkKind_null) || (k == checkKind_bool)
+}
+
+func (k checkKind) comparable(other checkKind) bool {
+	return (k == checkKind_null) || (other == checkKind_null) || (k.numeric() && other.numeric()) || (k == other)
+}
+
+// Evaluates compiled expression for row. Returns int64, float64, string, bool or nil if value is NULL (UNKNOWN)
+type checkEvaluator func(row appdef.ICheckRow) interface{}
+
+type checkExpr struct {
+	kind checkKind
+	eval checkEvaluator
+}
+
+func checkConstant(kind checkKind, v interface{}) checkExpr {
+	return checkExpr{kind, func(appdef.ICheckRow) interface{} { return v }}
+}
+
+type checkCompiler struct {
+	fields      appdef.IFields
+	noGenerated bool // references to generated fields are not allowed
+}
+
+// # Implements:
+//   - appdef.IExpressionCompiler
+type expressionCompiler struct{}
+
+func (expressionCompiler) CompileCheck(expression string, fields appdef.IFields) (appdef.CheckFunc, error) {
+	return compileCheck(expression, fields)
+}
+
+func (expressionCompiler) CompileGenerated(expression string, fields appdef.IFields, field string) (appdef.GeneratedFunc, error) {
+	return compileGenerated(expression, fields, field)
+}
+
+func compileCheck(expression string, fields appdef.IFields) (appdef.CheckFunc, error) {
+	e, err := parseExpressionImpl(expression)
+	if err != nil {
+		return nil, err
+	}
+	x, err := checkCompiler{fields: fields}.expression(e)
+	if err != nil {
+		return nil, err
+	}
+	if !x.kind.boolean() {
+		return nil, ErrCheckExpressionNotBoolean
+	}
+	return func(row appdef.ICheckRow) bool {
+		return x.eval(row) != false
+	}, nil
+}
+
+func compileGenerated(expression string, fields appdef.IFields, field string) (appdef.GeneratedFunc, error) {
+	f := fields.Field(field)
+	if f == nil {
+		return nil, ErrUndefinedField(field)
+	}
+	e, err := parseExpressionImpl(expression)
+	if err != nil {
+		return nil, err
+	}
+	x, err := checkCompiler{fields: fields, noGenerated: true}.expression(e)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := func(kinds []checkKind, conv func(v interface{}) (interface{}, error)) (appdef.GeneratedFunc, error) {
+		if (x.kind != checkKind_null) && !slices.Contains(kinds, x.kind) {
+			return nil, ErrIncompatibleGeneratedType(field)
+		}
+		return func(row appdef.ICheckRow) (interface{}, error) {
+			if v := x.eval(row); v != nil {
+				return conv(v)
+			}
+			return nil, nil
+		}, nil
+	}
+	same := func(v interface{}) (interface{}, error) { return v, nil }
+	numeric := []checkKind{checkKind_int, checkKind_float}
+
+	switch f.DataKind() {
+	case appdef.DataKind_int32, appdef.DataKind_date:
+		return conv([]checkKind{checkKind_int}, func(v interface{}) (interface{}, error) {
+			i := v.(int64)
+			if (i < math.MinInt32) || (i > math.MaxInt32) {
+				return nil, fmt.Errorf("value %d of generated field %s: %w", i, field, ErrGeneratedValueOutOfRange)
+			}
+			return int32(i), nil
+		})
+	case appdef.DataKind_int64, appdef.DataKind_timestamp:
+		return conv([]checkKind{checkKind_int}, same)
+	case appdef.DataKind_float32:
+		return conv(numeric, func(v interface{}) (interface{}, error) { return float32(checkFloat(v)), nil })
+	case appdef.DataKind_float64:
+		return conv(numeric, func(v interface{}) (interface{}, error) { return checkFloat(v), nil })
+	case appdef.DataKind_decimal:
+		_, scale := appdef.DecimalPrecisionScale(f.Constraints())
+		return conv(numeric, func(v interface{}) (interface{}, error) {
+			d, err := istructs.DecimalFromFloat64(checkFloat(v), scale)
+			if err != nil {
+				return nil, fmt.Errorf("value of generated field %s: %w", field, errors.Join(ErrGeneratedValueOutOfRange, err))
+			}
+			return d, nil
+		})
+	case appdef.DataKind_string:
+		return conv([]checkKind{checkKind_string}, same)
+	case appdef.DataKind_bool:
+		return conv([]checkKind{checkKind_bool}, same)
+	}
+	return nil, ErrIncompatibleGeneratedType(field)
+}
+
+func (c checkCompiler) expression(e *Expression) (checkExpr, error) {
+	xx := make([]checkExpr, len(e.Or))
+	for i, o := range e.Or {
+		x, err := c.and(o)
+		if err != nil {
+			return checkExpr{}, err
+		}
+		xx[i] = x
+	}
+	return checkLogical("OR", xx, true)
+}
+
+func (c checkCompiler) and(o *OrCondition) (checkExpr, error) {
+	xx := make([]checkExpr, len(o.And))
+	for i, cond := range o.And {
+		x, err := c.condition(cond)
+		if err != nil {
+			return checkExpr{}, err
+		}
+		xx[i] = x
+	}
+	return checkLogical("AND", xx, false)
+}
+
+func (c checkCompiler) condition(cond *Condition) (checkExpr, error) {
+	if cond.Not == nil {
+		return c.conditionOperand(cond.Operand)
+	}
+	x, err := c.condition(cond.Not)
+	if err != nil {
+		return checkExpr{}, err
+	}
+	if !x.kind.boolean() {
+		return checkExpr{}, ErrIncompatibleOperands("NOT")
+	}
+	return checkExpr{checkKind_bool, func(row appdef.ICheckRow) interface{} {
+		if v := x.eval(row); v != nil {
+			return !v.(bool)
+		}
+		return nil
+	}}, nil
+}
+
+func (c checkCompiler) conditionOperand(o *ConditionOperand) (checkExpr, error) {
+	x, err := c.operand(o.Operand)
+	if err != nil {
+		return checkExpr{}, err
+	}
+	rhs := o.ConditionRHS
+	switch {
+	case rhs == nil:
+		return x, nil
+	case rhs.Compare != nil:
+		y, err := c.operand(rhs.Compare.Operand)
+		if err != nil {
+			return checkExpr{}, err
+		}
+		return checkCompare(rhs.Compare.Operator, x, y)
+	case rhs.Is != nil:
+		not := rhs.Is.Not
+		return checkExpr{checkKind_bool, func(row appdef.ICheckRow) interface{} {
+			return (x.eval(row) == nil) != not
+		}}, nil
+	case rhs.Between != nil:
+		start, err := c.operand(rhs.Between.Start)
+		if err != nil {
+			return checkExpr{}, err
+		}
+		end, err := c.operand(rhs.Between.End)
+		if err != nil {
+			return checkExpr{}, err
+		}
+		ge, err := checkCompare(">=", x, start)
+		if err != nil {
+			return checkExpr{}, ErrIncompatibleOperands("BETWEEN")
+		}
+		le, err := checkCompare("<=", x, end)
+		if err != nil {
+			return checkExpr{}, ErrIncompatibleOperands("BETWEEN")
+		}
+		return checkLogical("BETWEEN", []checkExpr{ge, le}, false)
+	case rhs.In != nil:
+		items := make([]checkExpr, len(rhs.In.Expressions))
+		for i, e := range rhs.In.Expressions {
+			y, err := c.expression(e)
+			if err != nil {
+				return checkExpr{}, err
+			}
+			if !x.kind.comparable(y.kind) {
+				return checkExpr{}, ErrIncompatibleOperands("IN")
+			}
+			items[i] = y
+		}
+		return checkExpr{checkKind_bool, func(row appdef.ICheckRow) interface{} {
+			v := x.eval(row)
+			if v == nil {
+				return nil
+			}
+			unknown := false
+			for _, y := range items {
+				w := y.eval(row)
+				if w == nil {
+					unknown = true
+					continue
+				}
+				if compareCheckValues(v, w) == 0 {
+					return true
+				}
+			}
+			if unknown {
+				return nil
+			}
+			return false
+		}}, nil
+	}
+	return checkExpr{}, ErrIncompatibleOperands(o.String())
+}
+
+func (c checkCompiler) operand(o *Operand) (checkExpr, error) {
+	x, err := c.factor(o.LHS)
+	if (err != nil) || (o.RHS == nil) {
+		return x, err
+	}
+	y, err := c.factor(o.RHS)
+	if err != nil {
+		return checkExpr{}, err
+	}
+	return checkArithmetic(o.Op, x, y)
+}
+
+func (c checkCompiler) factor(f *Factor) (checkExpr, error) {
+	x, err := c.term(f.LHS)
+	if (err != nil) || (f.RHS == nil) {
+		return x, err
+	}
+	y, err := c.term(f.RHS)
+	if err != nil {
+		return checkExpr{}, err
+	}
+	return checkArithmetic(f.Op, x, y)
+}
+
+func (c checkCompiler) term(t *Term) (checkExpr, error) {
+	switch {
+	case t.Value != nil:
+		return checkLiteral(t.Value), nil
+	case t.SymbolRef != nil:
+		if t.SymbolRef.Parameters == nil {
+			return c.field(t.SymbolRef.Name)
+		}
+		return c.function(t.SymbolRef)
+	}
+	return c.expression(t.SubExpression)
+}
+
+func (c checkCompiler) field(name DefQName) (checkExpr, error) {
+	if name.Package != "" {
+		return checkExpr{}, ErrUndefinedField(name.String())
+	}
+	f := c.fields.Field(string(name.Name))
+	if f == nil {
+		return checkExpr{}, ErrUndefinedField(name.String())
+	}
+	if f.IsArray() {
+		return checkExpr{}, ErrFieldTypeNotSupportedInCheck(f.Name())
+	}
+	if c.noGenerated && f.IsGenerated() {
+		return checkExpr{}, ErrGeneratedFieldReference(f.Name())
+	}
+
+	n := f.Name()
+	value := func(kind checkKind, get func(row appdef.ICheckRow) interface{}) (checkExpr, error) {
+		return checkExpr{kind, func(row appdef.ICheckRow) interface{} {
+			if !row.HasValue(n) {
+				return nil
+			}
+			return get(row)
+		}}, nil
+	}
+
+	switch f.DataKind() {
+	case appdef.DataKind_int32, appdef.DataKind_date:
+		return value(checkKind_int, func(row appdef.ICheckRow) interface{} { return int64(row.AsInt32(n)) })
+	case appdef.DataKind_int64, appdef.DataKind_timestamp:
+		return value(checkKind_int, func(row appdef.ICheckRow) interface{} { return row.AsInt64(n) })
+	case appdef.DataKind_RecordID:
+		return value(checkKind_int, func(row appdef.ICheckRow) interface{} { return row.AsInt64(n) })
+	case appdef.DataKind_float32:
+		return value(checkKind_float, func(row appdef.ICheckRow) interface{} { return float64(row.AsFloat32(n)) })
+	case appdef.DataKind_float64:
+		return value(checkKind_float, func(row appdef.ICheckRow) interface{} { return row.AsFloat64(n) })
+	case appdef.DataKind_decimal:
+		_, scale := appdef.DecimalPrecisionScale(f.Constraints())
+		return value(checkKind_float, func(row appdef.ICheckRow) interface{} { return istructs.DecimalToFloat64(row.AsInt64(n), scale) })
+	case appdef.DataKind_string:
+		return value(checkKind_string, func(row appdef.ICheckRow) interface{} { return row.AsString(n) })
+	case appdef.DataKind_QName:
+		return value(checkKind_string, func(row appdef.ICheckRow) interface{} { return row.AsQName(n).String() })
+	case appdef.DataKind_UUID:
+		return value(checkKind_string, func(row appdef.ICheckRow) interface{} { return istructs.UUIDToString(row.AsBytes(n)) })
+	case appdef.DataKind_bool:
+		return value(checkKind_bool, func(row appdef.ICheckRow) interface{} { return row.AsBool(n) })
+	}
+	return checkExpr{}, ErrFieldTypeNotSupportedInCheck(f.Name())
+}
+
+func (c checkCompiler) function(s *SymbolRef) (checkExpr, error) {
+	fn, ok := checkFunctions[strings.ToUpper(string(s.Name.Name))]
+	if !ok || (s.Name.Package != "") {
+		return checkExpr{}, ErrUndefinedFunction(s.Name.String())
+	}
+	args := make([]checkExpr, len(s.Parameters))
+	for i, p := range s.Parameters {
+		x, err := c.expression(p)
+		if err != nil {
+			return checkExpr{}, err
+		}
+		args[i] = x
+	}
+	x, ok := fn(args)
+	if !ok {
+		return checkExpr{}, ErrInvalidFunctionArguments(s.Name.String())
+	}
+	return x, nil
+}
+
+// Combines operands with OR (if or is true) or AND using three-valued logic
+func checkLogical(op string, xx []checkExpr, or bool) (checkExpr, error) {
+	if len(xx) == 1 {
+		return xx[0], nil
+	}
+	for _, x := range xx {
+		if !x.kind.boolean() {
+			return checkExpr{}, ErrIncompatibleOperands(op)
+		}
+	}
+	return checkExpr{checkKind_bool, func(row appdef.ICheckRow) interface{} {
+		unknown := false
+		for _, x := range xx {
+			v := x.eval(row)
+			if v == nil {
+				unknown = true
+				continue
+			}
+			if v.(bool) == or {
+				return or
+			}
+		}
+		if unknown {
+			return nil
+		}
+		return !or
+	}}, nil
+}
+
+func checkCompare(op string, x, y checkExpr) (checkExpr, error) {
+	if !x.kind.comparable(y.kind) {
+		return checkExpr{}, ErrIncompatibleOperands(op)
+	}
+	var match func(int) bool
+	switch op {
+	case "=":
+		match = func(c int) bool { return c == 0 }
+	case "<>", "!=":
+		match = func(c int) bool { return c != 0 }
+	case "<":
+		match = func(c int) bool { return c < 0 }
+	case "<=":
+		match = func(c int) bool { return c <= 0 }
+	case ">":
+		match = func(c int) bool { return c > 0 }
+	case ">=":
+		match = func(c int) bool { return c >= 0 }
+	}
+	return checkExpr{checkKind_bool, func(row appdef.ICheckRow) interface{} {
+		a := x.eval(row)
+		if a == nil {
+			return nil
+		}
+		b := y.eval(row)
+		if b == nil {
+			return nil
+		}
+		return match(compareCheckValues(a, b))
+	}}, nil
+}
+
+func checkArithmetic(op string, x, y checkExpr) (checkExpr, error) {
+	if !x.kind.numeric() || !y.kind.numeric() {
+		return checkExpr{}, ErrIncompatibleOperands(op)
+	}
+	kind := checkKind_int
+	if (x.kind == checkKind_float) || (y.kind == checkKind_float) {
+		kind = checkKind_float
+	}
+	return checkExpr{kind, func(row appdef.ICheckRow) interface{} {
+		a := x.eval(row)
+		if a == nil {
+			return nil
+		}
+		b := y.eval(row)
+		if b == nil {
+			return nil
+		}
+		if kind == checkKind_int {
+			return checkIntOp(op, a.(int64), b.(int64))
+		}
+		return checkFloatOp(op, checkFloat(a), checkFloat(b))
+	}}, nil
+}
+
+// Returns result of integer operation. Division by zero is NULL
+func checkIntOp(op string, a, b int64) interface{} {
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	}
+	if b == 0 {
+		return nil
+	}
+	if op == "/" {
+		return a / b
+	}
+	return a % b
+}
+
+// Returns result of float operation. Division by zero is NULL
+func checkFloatOp(op string, a, b float64) interface{} {
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	}
+	if b == 0 {
+		return nil
+	}
+	if op == "/" {
+		return a / b
+	}
+	return math.Mod(a, b)
+}
+
+func checkFloat(v interface{}) float64 {
+	if i, ok := v.(int64); ok {
+		return float64(i)
+	}
+	return v.(float64)
+}
+
+func checkLiteral(v *Value) checkExpr {
+	switch {
+	case v.Int != nil:
+		return checkConstant(checkKind_int, *v.Int)
+	case v.Float != nil:
+		return checkConstant(checkKind_float, *v.Float)
+	case v.String != nil:
+		return checkConstant(checkKind_string, *v.String)
+	case v.Boolean != nil:
+		return checkConstant(checkKind_bool, bool(*v.Boolean))
+	}
+	return checkConstant(checkKind_null, nil)
+}
+
+// Returns -1, 0 or 1 if a is less, equal or greater than b. Values kinds should be comparable
+func compareCheckValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		if b, ok := b.(int64); ok {
+			return cmp.Compare(a, b)
+		}
+		return cmp.Compare(float64(a), b.(float64))
+	case float64:
+		return cmp.Compare(a, checkFloat(b))
+	case string:
+		return strings.Compare(a, b.(string))
+	case bool:
+		switch b := b.(bool); {
+		case a == b:
+			return 0
+		case b:
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// CHECK expression functions. Returns false if arguments are invalid
+var checkFunctions = map[string]func(args []checkExpr) (checkExpr, bool){
+	"LENGTH": checkStringFunc(checkKind_int, func(s string) interface{} { return int64(utf8.RuneCountInString(s)) }),
+	"LOWER":  checkStringFunc(checkKind_string, func(s string) interface{} { return strings.ToLower(s) }),
+	"UPPER":  checkStringFunc(checkKind_string, func(s string) interface{} { return strings.ToUpper(s) }),
+	"TRIM":   checkStringFunc(checkKind_string, func(s string) interface{} { return strings.TrimSpace(s) }),
+	"SUBSTRING": func(args []checkExpr) (checkExpr, bool) {
+		if (len(args) < 2) || (len(args) > 3) || (args[0].kind != checkKind_string && args[0].kind != checkKind_null) {
+			return checkExpr{}, false
+		}
+		for _, a := range args[1:] {
+			if (a.kind != checkKind_int) && (a.kind != checkKind_null) {
+				return checkExpr{}, false
+			}
+		}
+		return checkExpr{checkKind_string, func(row appdef.ICheckRow) interface{} {
+			vv := make([]interface{}, len(args))
+			for i, a := range args {
+				if vv[i] = a.eval(row); vv[i] == nil {
+					return nil
+				}
+			}
+			runes := []rune(vv[0].(string))
+			start := vv[1].(int64) - 1 // SQL strings positions are one-based
+			end := int64(len(runes))
+			if len(vv) > 2 {
+				end = min(end, start+vv[2].(int64))
+			}
+			start = max(start, 0)
+			if end <= start {
+				return ""
+			}
+			return string(runes[start:end])
+		}}, true
+	},
+	"CONCAT": func(args []checkExpr) (checkExpr, bool) {
+		for _, a := range args {
+			if (a.kind != checkKind_string) && (a.kind != checkKind_null) {
+				return checkExpr{}, false
+			}
+		}
+		return checkExpr{checkKind_string, func(row appdef.ICheckRow) interface{} {
+			s := strings.Builder{}
+			for _, a := range args {
+				if v := a.eval(row); v != nil { // NULL arguments are ignored
+					s.WriteString(v.(string))
+				}
+			}
+			return s.String()
+		}}, true
+	},
+}
+
+// Returns function with single string argument
+func checkStringFunc(kind checkKind, f func(string) interface{}) func([]checkExpr) (checkExpr, bool) {
+	return func(args []checkExpr) (checkExpr, bool) {
+		if (len(args) != 1) || (args[0].kind != checkKind_string && args[0].kind != checkKind_null) {
+			return checkExpr{}, false
+		}
+		return checkExpr{kind, func(row appdef.ICheckRow) interface{} {
+			if v := args[0].eval(row); v != nil {
+				return f(v.(string))
+			}
+			return nil
+		}}, true
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -247,6 +248,15 @@ func Test_BasicUsage(t *testing.T) {
 	})
 	require.Equal(1, intentsCount)
 
+	// roles
+	locationUser := builder.Role(appdef.NewQName("main", "LocationUser"))
+	require.NotNil(locationUser)
+	require.True(locationUser.IsGranted(appdef.PrivilegeKind_Select, appdef.NewQName("untill", "Prices"), "Price"))
+	require.True(locationUser.IsGranted(appdef.PrivilegeKind_Execute, appdef.NewQName("main", "NewOrder")))
+	require.True(locationUser.IsGranted(appdef.PrivilegeKind_Execute, appdef.NewQName("main", "Query1")))
+	require.True(locationUser.IsGranted(appdef.PrivilegeKind_Insert, appdef.NewQName("main", "MyWorkspace")))
+	require.False(locationUser.IsGranted(appdef.PrivilegeKind_Execute, appdef.NewQName("main", "NewOrder2")))
+
 	_, err = builder.Build()
 	require.NoError(err)
 
@@ -916,6 +926,64 @@ func Test_Views(t *testing.T) {
 		);
 	)
 	`, "file.sql:2:3: primary key not defined")
+
+	require.AppSchemaError(`APPLICATION test(); WORKSPACE Workspace (
+		VIEW test(
+			field1 int,
+			PRIMARY KEY((field1))
+		) AS RESULT OF Proj1 WITH TTL 0 DAYS;
+		EXTENSION ENGINE BUILTIN (
+			PROJECTOR Proj1 AFTER EXECUTE ON (Orders) INTENTS (View(test));
+			COMMAND Orders()
+		);
+	)
+	`, "file.sql:5:33: view TTL must be positive")
+}
+
+func Test_ViewTTL(t *testing.T) {
+	require := require.New(t)
+
+	ast, err := ParseFile("file2.sql", `APPLICATION test(); WORKSPACE Workspace (
+		VIEW Sessions(
+			UserID int64,
+			SessionID int64,
+			PRIMARY KEY((UserID), SessionID)
+		) AS RESULT OF Proj1 WITH TTL 30 DAYS;
+		VIEW Minutes(
+			UserID int64,
+			SessionID int64,
+			PRIMARY KEY((UserID), SessionID)
+		) AS RESULT OF Proj1 WITH TTL 90 MINUTES;
+		VIEW Eternal(
+			UserID int64,
+			SessionID int64,
+			PRIMARY KEY((UserID), SessionID)
+		) AS RESULT OF Proj1;
+		EXTENSION ENGINE BUILTIN (
+			PROJECTOR Proj1 AFTER EXECUTE ON (Orders) INTENTS (View(Sessions, Minutes, Eternal));
+			COMMAND Orders()
+		);
+	)
+	`)
+	require.NoError(err)
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{ast})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{
+		getSysPackageAST(),
+		pkg,
+	})
+	require.NoError(err)
+
+	appBld := appdef.New()
+	require.NoError(BuildAppDefs(packages, appBld))
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	require.Equal(30*24*time.Hour, app.View(appdef.NewQName("test", "Sessions")).TTL())
+	require.Equal(90*time.Minute, app.View(appdef.NewQName("test", "Minutes")).TTL())
+	require.Zero(app.View(appdef.NewQName("test", "Eternal")).TTL())
 }
 
 func Test_Views2(t *testing.T) {
@@ -1347,6 +1415,598 @@ func Test_1KStringField(t *testing.T) {
 
 }
 
+func Test_DecimalDateTimestampUUIDFields(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("example.sql", `APPLICATION test();
+	TABLE MyTable INHERITS CDoc (
+		Price decimal(10, 2),
+		Amount numeric(12),
+		Total decimal,
+		Day date,
+		Created timestamp,
+		Ref uuid
+	);
+	WORKSPACE Workspace (
+		VIEW Sales(
+			Day date,
+			Created timestamp,
+			Ref uuid,
+			Amount decimal(12, 2),
+			PRIMARY KEY((Day), Created, Ref)
+		) AS RESULT OF Proj1;
+		EXTENSION ENGINE BUILTIN (
+			PROJECTOR Proj1 AFTER EXECUTE ON (Orders) INTENTS (View(Sales));
+			COMMAND Orders()
+		);
+	)
+	`)
+	require.NoError(err)
+
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{
+		getSysPackageAST(),
+		pkg,
+	})
+	require.NoError(err)
+
+	appBld := appdef.New()
+	require.NoError(BuildAppDefs(packages, appBld))
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	cdoc := app.CDoc(appdef.NewQName("test", "MyTable"))
+	require.NotNil(cdoc)
+
+	precisionScale := func(f appdef.IField) [2]uint8 {
+		p, s := appdef.DecimalPrecisionScale(f.Constraints())
+		return [2]uint8{p, s}
+	}
+
+	require.Equal(appdef.DataKind_decimal, cdoc.Field("Price").DataKind())
+	require.Equal([2]uint8{10, 2}, precisionScale(cdoc.Field("Price")))
+	require.Equal([2]uint8{12, 0}, precisionScale(cdoc.Field("Amount")))
+	require.Equal([2]uint8{appdef.DefaultDecimalPrecision, 0}, precisionScale(cdoc.Field("Total")))
+	require.Equal(appdef.DataKind_date, cdoc.Field("Day").DataKind())
+	require.Equal(appdef.DataKind_timestamp, cdoc.Field("Created").DataKind())
+	require.Equal(appdef.DataKind_UUID, cdoc.Field("Ref").DataKind())
+
+	view := app.View(appdef.NewQName("test", "Sales"))
+	require.NotNil(view)
+	require.Equal(appdef.DataKind_date, view.Key().PartKey().Field("Day").DataKind())
+	require.Equal(appdef.DataKind_timestamp, view.Key().ClustCols().Field("Created").DataKind())
+	require.Equal(appdef.DataKind_UUID, view.Key().ClustCols().Field("Ref").DataKind())
+	require.Equal([2]uint8{12, 2}, precisionScale(view.Value().Field("Amount")))
+
+	t.Run("should be errors if decimal precision or scale is out of range", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+	TABLE MyTable INHERITS CDoc (
+		Price decimal(19, 2),
+		Total decimal(0),
+		Amount decimal(5, 6)
+	)`, "file.sql:3:9: decimal precision must be from 1 to 18",
+			"file.sql:4:9: decimal precision must be from 1 to 18",
+			"file.sql:5:10: decimal scale exceeds precision")
+	})
+}
+
+func Test_ArrayFields(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("example.sql", `APPLICATION test();
+	TABLE MyTable INHERITS CDoc (
+		Tags varchar(20)[],
+		Phones varchar[3] NOT NULL,
+		Prices decimal(10, 2)[]
+	);
+	WORKSPACE Workspace (
+		VIEW Tagged(
+			Tag int32,
+			Day int32,
+			Docs int64[] NOT NULL,
+			PRIMARY KEY((Tag), Day)
+		) AS RESULT OF Proj1;
+		EXTENSION ENGINE BUILTIN (
+			PROJECTOR Proj1 AFTER EXECUTE ON (Orders) INTENTS (View(Tagged));
+			COMMAND Orders()
+		);
+	)
+	`)
+	require.NoError(err)
+
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{
+		getSysPackageAST(),
+		pkg,
+	})
+	require.NoError(err)
+
+	appBld := appdef.New()
+	require.NoError(BuildAppDefs(packages, appBld))
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	cdoc := app.CDoc(appdef.NewQName("test", "MyTable"))
+	require.NotNil(cdoc)
+
+	tags := cdoc.Field("Tags")
+	require.True(tags.IsArray())
+	require.Equal(appdef.DataKind_string, tags.DataKind())
+	require.False(tags.Required())
+	require.Equal(appdef.Occurs(0), tags.MinItems())
+	require.Equal(appdef.Occurs_Unbounded, tags.MaxItems())
+	require.EqualValues(20, tags.Constraints()[appdef.ConstraintKind_MaxLen].Value())
+
+	phones := cdoc.Field("Phones")
+	require.True(phones.IsArray())
+	require.True(phones.Required())
+	require.Equal(appdef.Occurs(1), phones.MinItems())
+	require.Equal(appdef.Occurs(3), phones.MaxItems())
+
+	prices := cdoc.Field("Prices")
+	require.True(prices.IsArray())
+	require.Equal(appdef.DataKind_decimal, prices.DataKind())
+
+	view := app.View(appdef.NewQName("test", "Tagged"))
+	require.NotNil(view)
+	docs := view.Value().Field("Docs")
+	require.True(docs.IsArray())
+	require.Equal(appdef.DataKind_int64, docs.DataKind())
+	require.Equal(appdef.Occurs(1), docs.MinItems())
+
+	t.Run("should be errors if array field is not supported", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+	TABLE MyTable INHERITS CDoc (
+		Photos bytes[],
+		Refs uuid[],
+		Empty int32[0]
+	);
+	WORKSPACE Workspace (
+		VIEW Tagged(
+			Tags varchar[],
+			Day int32[],
+			PRIMARY KEY((Day), Tags)
+		) AS RESULT OF Proj1;
+		EXTENSION ENGINE BUILTIN (
+			PROJECTOR Proj1 AFTER EXECUTE ON (Orders) INTENTS (View(Tagged));
+			COMMAND Orders()
+		);
+	)`, "file.sql:3:15: bytes not supported as array item",
+			"file.sql:4:12: UUID not supported as array item",
+			"file.sql:5:14: array max items must be from 1 to 65534",
+			"file.sql:11:17: array field Day not supported in primary key",
+			"file.sql:11:23: array field Tags not supported in primary key")
+	})
+}
+
+type testCheckRow struct {
+	istructs.NullObject
+	values map[string]interface{}
+}
+
+func (r *testCheckRow) HasValue(name string) bool     { _, ok := r.values[name]; return ok }
+func (r *testCheckRow) AsInt32(name string) int32     { return r.values[name].(int32) }
+func (r *testCheckRow) AsInt64(name string) int64     { return r.values[name].(int64) }
+func (r *testCheckRow) AsFloat64(name string) float64 { return r.values[name].(float64) }
+func (r *testCheckRow) AsString(name string) string   { return r.values[name].(string) }
+func (r *testCheckRow) AsBool(name string) bool       { return r.values[name].(bool) }
+
+func Test_Checks(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("example.sql", `APPLICATION test();
+	TABLE Deal INHERITS CDoc (
+		Name varchar NOT NULL,
+		Qty int32 CHECK (Qty > 0),
+		Price decimal(10, 2),
+		Discount float64,
+		Closed bool,
+		CHECK (Discount <= Price * Qty),
+		-- Deal name should be code or title
+		CONSTRAINT NameChecker CHECK (LENGTH(TRIM(Name)) BETWEEN 1 AND 10 OR UPPER(Name) = 'N/A'),
+		CHECK (NOT Closed OR Qty IS NOT NULL)
+	);
+	`)
+	require.NoError(err)
+
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{getSysPackageAST(), pkg})
+	require.NoError(err)
+
+	appBld := appdef.New()
+	require.NoError(BuildAppDefs(packages, appBld))
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	doc := app.CDoc(appdef.NewQName("test", "Deal"))
+	require.NotNil(doc)
+	require.Equal(4, doc.CheckCount())
+
+	require.Equal("Qty > 0", doc.Check("Qty$check").Expression())
+	require.Equal("Discount <= Price * Qty", doc.Check("check$02").Expression())
+	require.Equal("LENGTH(TRIM(Name)) BETWEEN 1 AND 10 OR UPPER(Name) = 'N/A'", doc.Check("NameChecker").Expression())
+	require.Equal("Deal name should be code or title", doc.Check("NameChecker").Comment())
+	require.Equal("NOT Closed OR Qty IS NOT NULL", doc.Check("check$04").Expression())
+
+	t.Run("should be ok to evaluate checks compiled by build", func(t *testing.T) {
+		require.True(doc.Check("Qty$check").Evaluate(&testCheckRow{values: map[string]interface{}{"Qty": int32(1)}}))
+		require.False(doc.Check("Qty$check").Evaluate(&testCheckRow{values: map[string]interface{}{"Qty": int32(0)}}))
+	})
+
+	t.Run("should be ok to evaluate compiled checks", func(t *testing.T) {
+		tests := []struct {
+			expr   string
+			values map[string]interface{}
+			want   bool
+		}{
+			{"Qty > 0", map[string]interface{}{"Qty": int32(1)}, true},
+			{"Qty > 0", map[string]interface{}{"Qty": int32(0)}, false},
+			{"Qty > 0", map[string]interface{}{}, true}, // UNKNOWN succeeds
+			{"Qty IS NULL", map[string]interface{}{}, true},
+			{"Qty IS NOT NULL", map[string]interface{}{}, false},
+			{"Discount <= Price * Qty", map[string]interface{}{"Discount": 10.0, "Price": int64(550), "Qty": int32(2)}, true},
+			{"Discount <= Price * Qty", map[string]interface{}{"Discount": 11.01, "Price": int64(550), "Qty": int32(2)}, false},
+			{"Qty % 2 = 1 AND Qty / 0 > 1", map[string]interface{}{"Qty": int32(3)}, true}, // division by zero is NULL
+			{"Qty IN (1, 2, 3)", map[string]interface{}{"Qty": int32(4)}, false},
+			{"Qty IN (1, 2, NULL)", map[string]interface{}{"Qty": int32(4)}, true},
+			{"NOT (Qty BETWEEN 1 AND 3)", map[string]interface{}{"Qty": int32(2)}, false},
+			{"Closed = TRUE OR Qty > 0", map[string]interface{}{"Closed": false, "Qty": int32(0)}, false},
+			{"Closed OR Qty > 0", map[string]interface{}{"Closed": false}, true},
+			{"Name = 'a\\'b'", map[string]interface{}{"Name": "a'b"}, true},
+			{"LOWER(Name) = 'abc' AND UPPER(Name) = 'ABC'", map[string]interface{}{"Name": "aBc"}, true},
+			{"SUBSTRING(Name, 2, 2) = 'bc' AND SUBSTRING(Name, 3) = 'cd' AND SUBSTRING(Name, 0, 2) = 'a'", map[string]interface{}{"Name": "abcd"}, true},
+			{"CONCAT(Name, '-', NULL, 'x') = 'ab-x'", map[string]interface{}{"Name": "ab"}, true},
+			{"LENGTH(Name) = 3", map[string]interface{}{"Name": "абв"}, true},
+		}
+		for _, tt := range tests {
+			check, err := CompileCheck(tt.expr, doc)
+			require.NoError(err, tt.expr)
+			require.Equal(tt.want, check(&testCheckRow{values: tt.values}), tt.expr)
+		}
+	})
+
+	t.Run("should be errors if check is invalid", func(t *testing.T) {
+		tests := []struct {
+			expr string
+			err  string
+		}{
+			{"Qty", ErrCheckExpressionNotBoolean.Error()},
+			{"Unknown > 0", "undefined field Unknown"},
+			{"Valid(Name)", "undefined function Valid"},
+			{"LENGTH(Qty) > 0", "invalid arguments of function LENGTH"},
+			{"Name > 0", "incompatible operands of >"},
+			{"Name + 1 > 0", "incompatible operands of +"},
+			{"Qty > 0 AND Name", "incompatible operands of AND"},
+		}
+		for _, tt := range tests {
+			_, err := CompileCheck(tt.expr, doc)
+			require.EqualError(err, tt.err, tt.expr)
+		}
+
+		fs, err := ParseFile("file.sql", `APPLICATION test();
+	TABLE Deal INHERITS CDoc (
+		Qty int32 CHECK (Qty > Count)
+	);
+	ABSTRACT TABLE Base INHERITS CDoc (
+		Qty int32,
+		CONSTRAINT C1 CHECK (Qty > 0)
+	);
+	TABLE Derived INHERITS Base (
+		CONSTRAINT C1 CHECK (Qty < 10)
+	);
+	`)
+		require.NoError(err)
+		pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+		require.NoError(err)
+		packages, err := BuildAppSchema([]*PackageSchemaAST{getSysPackageAST(), pkg})
+		require.NoError(err)
+		err = BuildAppDefs(packages, appdef.New())
+		require.EqualError(err, strings.Join([]string{
+			"file.sql:3:3: undefined field Count",
+			"file.sql:10:3: redefinition of C1",
+		}, "\n"))
+	})
+}
+
+func Test_GeneratedFields(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("example.sql", `APPLICATION test();
+	TABLE Person INHERITS CDoc (
+		FirstName varchar NOT NULL,
+		LastName varchar NOT NULL,
+		FullName varchar GENERATED ALWAYS AS (CONCAT(FirstName, ' ', LastName)),
+		SearchKey varchar GENERATED ALWAYS AS (LOWER(TRIM(LastName))) NOT NULL,
+		Qty int32,
+		Price decimal(10, 2),
+		Total decimal(12, 2) GENERATED ALWAYS AS (Price * Qty),
+		HasQty bool GENERATED ALWAYS AS (Qty IS NOT NULL)
+	);
+	`)
+	require.NoError(err)
+
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{getSysPackageAST(), pkg})
+	require.NoError(err)
+
+	appBld := appdef.New()
+	require.NoError(BuildAppDefs(packages, appBld))
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	doc := app.CDoc(appdef.NewQName("test", "Person"))
+	require.NotNil(doc)
+
+	require.False(doc.Field("FirstName").IsGenerated())
+	require.Equal("CONCAT(FirstName, ' ', LastName)", doc.Field("FullName").GeneratedAs())
+	require.Equal("LOWER(TRIM(LastName))", doc.Field("SearchKey").GeneratedAs())
+	require.True(doc.Field("SearchKey").Required())
+	require.Equal("Price * Qty", doc.Field("Total").GeneratedAs())
+	require.Equal("Qty IS NOT NULL", doc.Field("HasQty").GeneratedAs())
+
+	t.Run("should be ok to evaluate compiled generated fields", func(t *testing.T) {
+		tests := []struct {
+			field  string
+			values map[string]interface{}
+			want   interface{}
+		}{
+			{"FullName", map[string]interface{}{"FirstName": "John", "LastName": "Doe"}, "John Doe"},
+			{"SearchKey", map[string]interface{}{"LastName": " Doe "}, "doe"},
+			{"Total", map[string]interface{}{"Price": int64(1050), "Qty": int32(3)}, int64(3150)},
+			{"Total", map[string]interface{}{"Price": int64(1050)}, nil},
+			{"HasQty", map[string]interface{}{}, false},
+		}
+		for _, tt := range tests {
+			f := doc.Field(tt.field)
+			v, err := f.Generate(&testCheckRow{values: tt.values})
+			require.NoError(err, tt.field)
+			require.Equal(tt.want, v, tt.field)
+		}
+	})
+
+	t.Run("should be error if generated value is out of field range", func(t *testing.T) {
+		gen, err := CompileGenerated("Qty * 100000", doc, "Qty")
+		require.NoError(err)
+
+		v, err := gen(&testCheckRow{values: map[string]interface{}{"Qty": int32(100)}})
+		require.NoError(err)
+		require.Equal(int32(10000000), v)
+
+		_, err = gen(&testCheckRow{values: map[string]interface{}{"Qty": int32(100000)}})
+		require.ErrorIs(err, ErrGeneratedValueOutOfRange)
+		require.ErrorContains(err, "value 10000000000 of generated field Qty")
+
+		gen, err = CompileGenerated("Qty * 1000000000000000.0", doc, "Price")
+		require.NoError(err)
+		_, err = gen(&testCheckRow{values: map[string]interface{}{"Qty": int32(100000)}})
+		require.ErrorIs(err, ErrGeneratedValueOutOfRange)
+	})
+
+	t.Run("should be errors if generated field is invalid", func(t *testing.T) {
+		tests := []struct {
+			expr  string
+			field string
+			err   string
+		}{
+			{"Qty", "Unknown", "undefined field Unknown"},
+			{"Unknown + 1", "Qty", "undefined field Unknown"},
+			{"FullName", "LastName", "generated field can not refer to generated field FullName"},
+			{"FirstName", "Qty", "expression type incompatible with generated field Qty"},
+			{"Price * 2", "Qty", "expression type incompatible with generated field Qty"},
+		}
+		for _, tt := range tests {
+			_, err := CompileGenerated(tt.expr, doc, tt.field)
+			require.EqualError(err, tt.err, tt.expr)
+		}
+
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+	TYPE Params (
+		A int32,
+		B int32 GENERATED ALWAYS AS (A + 1)
+	);
+	TABLE Deal INHERITS CDoc (
+		A int32,
+		B int32[] GENERATED ALWAYS AS (A + 1),
+		C int32 GENERATED ALWAYS AS (A + 1) DEFAULT 1,
+		D varchar GENERATED ALWAYS AS ('a') VERIFIABLE
+	);
+	`,
+			"file.sql:4:3: generated fields are only allowed in tables",
+			"file.sql:8:3: generated field incompatible with array",
+			"file.sql:9:3: generated field incompatible with DEFAULT",
+			"file.sql:10:3: generated field incompatible with VERIFIABLE")
+
+		fs, err := ParseFile("file.sql", `APPLICATION test();
+	TABLE Deal INHERITS CDoc (
+		A int32 GENERATED ALWAYS AS (B * 2),
+		B int32 GENERATED ALWAYS AS (A + 1),
+		C int32,
+		D varchar GENERATED ALWAYS AS (C + 1)
+	);
+	`)
+		require.NoError(err)
+		pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+		require.NoError(err)
+		packages, err := BuildAppSchema([]*PackageSchemaAST{getSysPackageAST(), pkg})
+		require.NoError(err)
+		err = BuildAppDefs(packages, appdef.New())
+		require.EqualError(err, strings.Join([]string{
+			"file.sql:3:3: generated field can not refer to generated field B",
+			"file.sql:4:3: generated field can not refer to generated field A",
+			"file.sql:6:3: expression type incompatible with generated field D",
+		}, "\n"))
+	})
+}
+
+func Test_Indexes(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("example.sql", `APPLICATION test();
+	TABLE Client INHERITS CDoc (
+		Name varchar NOT NULL,
+		Phone varchar,
+		Country int32
+	);
+	WORKSPACE MyWS (
+		TABLE Visit INHERITS WDoc (
+			Client ref(Client) NOT NULL,
+			Date date
+		);
+		-- visits by client and date
+		INDEX VisitsByClient ON Visit(Client, Date);
+	);
+	INDEX ClientsByPhone ON Client(Phone);
+	INDEX ClientsByCountry ON test.Client(Country, Name);
+	`)
+	require.NoError(err)
+
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{getSysPackageAST(), pkg})
+	require.NoError(err)
+
+	appBld := appdef.New()
+	require.NoError(BuildAppDefs(packages, appBld))
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	client := app.CDoc(appdef.NewQName("test", "Client"))
+	require.Equal(2, client.IndexCount())
+	idx := client.Index(appdef.NewQName("test", "ClientsByCountry"))
+	require.NotNil(idx)
+	require.Len(idx.Fields(), 2)
+	require.Equal("Country", idx.Fields()[0].Name())
+	require.Equal("Name", idx.Fields()[1].Name())
+
+	visit := app.WDoc(appdef.NewQName("test", "Visit"))
+	idx = visit.Index(appdef.NewQName("test", "VisitsByClient"))
+	require.NotNil(idx)
+	require.Equal("visits by client and date", idx.Comment())
+	require.Len(idx.Fields(), 2)
+	require.Nil(app.TypeByName(appdef.NewQName("test", "VisitsByClient")))
+
+	t.Run("should be errors if index is invalid", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+	ABSTRACT TABLE Base INHERITS CDoc (
+		A int32
+	);
+	TABLE Doc INHERITS ODoc (
+		A int32
+	);
+	TABLE Rec INHERITS CRecord (
+		A int32
+	);
+	INDEX Idx1 ON Unknown(A);
+	INDEX Idx2 ON Base(A);
+	INDEX Idx3 ON Doc(A);
+	INDEX Idx4 ON Rec(A, A);
+	`,
+			"file.sql:11:16: undefined table: Unknown",
+			"file.sql:12:16: use of abstract table Base",
+			"file.sql:13:16: index is not supported for table Doc, only CDoc, CRecord, WDoc and WRecord tables can be indexed",
+			"file.sql:14:23: redefinition of A")
+
+		fs, err := ParseFile("file.sql", `APPLICATION test();
+	TABLE Rec INHERITS CRecord (
+		A int32,
+		B bytes,
+		C int32[]
+	);
+	INDEX Idx1 ON Rec(Unknown);
+	INDEX Idx2 ON Rec(A, B);
+	INDEX Idx3 ON Rec(C);
+	`)
+		require.NoError(err)
+		pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+		require.NoError(err)
+		packages, err := BuildAppSchema([]*PackageSchemaAST{getSysPackageAST(), pkg})
+		require.NoError(err)
+		err = BuildAppDefs(packages, appdef.New())
+		require.EqualError(err, strings.Join([]string{
+			"file.sql:7:20: undefined field Unknown",
+			"file.sql:8:23: field B can not be indexed, arrays and bytes fields are not supported",
+			"file.sql:9:20: field C can not be indexed, arrays and bytes fields are not supported",
+		}, "\n"))
+	})
+}
+
+func Test_ExtensionLimits(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("example.sql", `APPLICATION test();
+	WORKSPACE MyWS (
+		TABLE Doc INHERITS CDoc (
+			A int32
+		);
+		EXTENSION ENGINE WASM (
+			COMMAND Cmd() WITH MaxDurationMs=100, MaxHostCalls=1000, Comment='cmd', MaxIntents=10, MaxMemoryPages=64;
+			QUERY Qry() RETURNS void WITH MaxHostCalls=50;
+			PROJECTOR Prj AFTER INSERT ON Doc WITH MaxIntents=1;
+		);
+	);
+	`)
+	require.NoError(err)
+
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{getSysPackageAST(), pkg})
+	require.NoError(err)
+
+	appBld := appdef.New()
+	require.NoError(BuildAppDefs(packages, appBld))
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	cmd := app.Command(appdef.NewQName("test", "Cmd"))
+	require.Equal("cmd", cmd.Comment())
+	require.Equal(appdef.ExtensionLimits{
+		MaxDuration:    100 * time.Millisecond,
+		MaxHostCalls:   1000,
+		MaxIntents:     10,
+		MaxMemoryPages: 64,
+	}, cmd.Limits())
+	require.Equal(appdef.ExtensionLimits{MaxHostCalls: 50}, app.Query(appdef.NewQName("test", "Qry")).Limits())
+	require.Equal(appdef.ExtensionLimits{MaxIntents: 1}, app.Projector(appdef.NewQName("test", "Prj")).Limits())
+
+	t.Run("should be error if limits are declared for not WASM extension", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+	WORKSPACE MyWS (
+		TABLE Doc INHERITS CDoc (
+			A int32
+		) WITH MaxIntents=1;
+		EXTENSION ENGINE BUILTIN (
+			COMMAND Cmd() WITH MaxDurationMs=100;
+			PROJECTOR Prj AFTER INSERT ON Doc WITH MaxIntents=1;
+		);
+	);
+	`,
+			"file.sql:3:3: limits are only available for WASM commands, queries and projectors",
+			"file.sql:7:4: limits are only available for WASM commands, queries and projectors",
+			"file.sql:8:4: limits are only available for WASM commands, queries and projectors")
+	})
+}
+
 func Test_ReferenceToNoTable(t *testing.T) {
 	require := require.New(t)
 
@@ -1916,9 +2576,9 @@ TYPE Item (
 
 TYPE Deal (
 	side1 		Person NOT NULL,	-- collection 1..1
-	side2 		Person				-- collection 0..1
---	items 		Item[] NOT NULL,	-- (not yet supported by kernel) collection 1..* (up to maxNestedTableContainerOccurrences = 100)
---	discounts 	Item[3]				-- (not yet supported by kernel) collection 0..3 (one-based numbering convention for arrays, similarly to PostgreSQL)
+	side2 		Person,				-- collection 0..1
+	items 		Item[] NOT NULL,	-- collection 1..* (up to maxNestedTableContainerOccurrences = 100)
+	discounts 	Item[3]				-- collection 0..3 (one-based numbering convention for arrays, similarly to PostgreSQL)
 );
 
 WORKSPACE Workspace1 (
@@ -1938,18 +2598,15 @@ WORKSPACE Workspace1 (
 	validate := func(par appdef.IType) {
 		o, ok := par.(appdef.IObject)
 		require.True(ok, "expected %v supports IObject", par)
-		require.Equal(2, o.ContainerCount())
+		require.Equal(4, o.ContainerCount())
 		require.Equal(appdef.Occurs(1), o.Container("side1").MinOccurs())
 		require.Equal(appdef.Occurs(1), o.Container("side1").MaxOccurs())
 		require.Equal(appdef.Occurs(0), o.Container("side2").MinOccurs())
 		require.Equal(appdef.Occurs(1), o.Container("side2").MaxOccurs())
-
-		/* TODO: uncomment when kernel supports it
 		require.Equal(appdef.Occurs(1), o.Container("items").MinOccurs())
 		require.Equal(appdef.Occurs(100), o.Container("items").MaxOccurs())
 		require.Equal(appdef.Occurs(0), o.Container("discounts").MinOccurs())
 		require.Equal(appdef.Occurs(3), o.Container("discounts").MaxOccurs())
-		*/
 	}
 
 	cmd := builder.Command(appdef.NewQName("app1", "CmdDeal"))
@@ -2100,6 +2757,95 @@ func Test_Grants(t *testing.T) {
 	)
 }
 
+func Test_GrantsBuild(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("example.sql", `APPLICATION test();
+	-- Writer role comment
+	ROLE Writer;
+	TAG Backoffice;
+	WORKSPACE MyWS (
+		ROLE Reader;
+		TABLE Doc INHERITS CDoc (
+			A int32,
+			B varchar
+		) WITH Tags=(Backoffice);
+		TABLE Bill INHERITS ODoc (
+			Total int32
+		) WITH Tags=(Backoffice);
+		TABLE Log INHERITS CDoc (
+			Msg varchar
+		);
+		EXTENSION ENGINE BUILTIN (
+			COMMAND Cmd() WITH Tags=(Backoffice);
+			COMMAND Cmd2();
+			QUERY Qry() RETURNS void WITH Tags=(Backoffice);
+		);
+		GRANT SELECT(A) ON TABLE Doc TO Reader;
+		GRANT SELECT ON TABLE Bill TO Reader;
+		GRANT SELECT ON QUERY Qry TO Reader;
+		GRANT INSERT,UPDATE ON ALL TABLES WITH TAG Backoffice TO Writer;
+		GRANT INSERT ON ALL COMMANDS WITH TAG Backoffice TO Writer;
+		GRANT ALL ON TABLE Log TO Writer;
+		GRANT INSERT ON WORKSPACE MyWS TO Writer;
+	);
+	`)
+	require.NoError(err)
+
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{getSysPackageAST(), pkg})
+	require.NoError(err)
+
+	appBld := appdef.New()
+	require.NoError(BuildAppDefs(packages, appBld))
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	doc, bill, log := appdef.NewQName("test", "Doc"), appdef.NewQName("test", "Bill"), appdef.NewQName("test", "Log")
+	cmd, cmd2, qry := appdef.NewQName("test", "Cmd"), appdef.NewQName("test", "Cmd2"), appdef.NewQName("test", "Qry")
+	ws := appdef.NewQName("test", "MyWS")
+
+	reader := app.Role(appdef.NewQName("test", "Reader"))
+	require.NotNil(reader)
+	require.True(reader.IsGranted(appdef.PrivilegeKind_Select, doc, "A"))
+	require.False(reader.IsGranted(appdef.PrivilegeKind_Select, doc, "A", "B"))
+	require.True(reader.IsGranted(appdef.PrivilegeKind_Select, bill))
+	require.True(reader.IsGranted(appdef.PrivilegeKind_Execute, qry))
+	require.False(reader.IsGranted(appdef.PrivilegeKind_Execute, cmd))
+
+	writer := app.Role(appdef.NewQName("test", "Writer"))
+	require.NotNil(writer)
+	require.Equal("Writer role comment", writer.Comment())
+	require.True(writer.IsGranted(appdef.PrivilegeKind_Insert, doc, "A", "B"))
+	require.True(writer.IsGranted(appdef.PrivilegeKind_Update, doc))
+	require.False(writer.IsGranted(appdef.PrivilegeKind_Select, doc))
+	require.False(writer.IsGranted(appdef.PrivilegeKind_Insert, bill), "insert is not granted on ODoc")
+	require.True(writer.IsGranted(appdef.PrivilegeKind_Execute, cmd))
+	require.False(writer.IsGranted(appdef.PrivilegeKind_Execute, cmd2))
+	require.False(writer.IsGranted(appdef.PrivilegeKind_Execute, qry))
+	require.True(writer.IsGranted(appdef.PrivilegeKind_Insert, log))
+	require.True(writer.IsGranted(appdef.PrivilegeKind_Update, log))
+	require.True(writer.IsGranted(appdef.PrivilegeKind_Select, log))
+	require.True(writer.IsGranted(appdef.PrivilegeKind_Insert, ws))
+
+	require.Equal(appdef.TypeKind_Role, app.Workspace(ws).Type(reader.QName()).Kind(), "role declared in workspace must be available in workspace")
+
+	t.Run("should be error if insert or update granted on ODoc", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+	ROLE Writer;
+	WORKSPACE MyWS (
+		TABLE Bill INHERITS ODoc ();
+		GRANT SELECT, UPDATE ON TABLE Bill TO Writer;
+	);
+	`,
+			"file.sql:5:17: only SELECT can be granted on ODoc or ORecord")
+	})
+}
+
 func Test_UndefinedType(t *testing.T) {
 	require := assertions(t)
 
@@ -2159,6 +2905,46 @@ func Test_Variables(t *testing.T) {
 	require.True(resolver.resolved[appdef.NewQName("pkg", "variable")])
 }
 
+func Test_Tags(t *testing.T) {
+	require := assertions(t)
+
+	schema, err := require.AppSchema(`APPLICATION app1();
+	-- backoffice tag comment
+	TAG Backoffice;
+	TAG Sales;
+	WORKSPACE w (
+		TABLE Doc INHERITS CDoc (A int32) WITH Tags=(Backoffice, Sales);
+		TABLE Bill INHERITS ODoc (Total int32) WITH Tags=(Sales);
+		EXTENSION ENGINE BUILTIN (
+			COMMAND Cmd() WITH Tags=(Backoffice);
+			QUERY Qry() RETURNS void WITH Tags=(Sales);
+		);
+	);`)
+	require.NoError(err)
+
+	appBld := appdef.New()
+	require.NoError(BuildAppDefs(schema, appBld))
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	backoffice, sales := appdef.NewQName("pkg", "Backoffice"), appdef.NewQName("pkg", "Sales")
+	doc, bill := appdef.NewQName("pkg", "Doc"), appdef.NewQName("pkg", "Bill")
+	cmd, qry := appdef.NewQName("pkg", "Cmd"), appdef.NewQName("pkg", "Qry")
+
+	tag := app.Tag(backoffice)
+	require.NotNil(tag)
+	require.Equal(appdef.TypeKind_Tag, tag.Kind())
+	require.Equal("backoffice tag comment", tag.Comment())
+
+	require.Equal(appdef.QNamesFrom(backoffice, sales), app.TagsOf(doc))
+	require.Equal(appdef.QNamesFrom(sales), app.TagsOf(bill))
+	require.Equal(appdef.QNamesFrom(backoffice), app.TagsOf(cmd))
+	require.Empty(app.TagsOf(appdef.NewQName("pkg", "w")))
+
+	require.Equal(appdef.QNamesFrom(cmd, doc), app.TypesWithTag(backoffice))
+	require.Equal(appdef.QNamesFrom(bill, doc, qry), app.TypesWithTag(sales))
+}
+
 func Test_RatesAndLimits(t *testing.T) {
 	require := assertions(t)
 
@@ -2176,4 +2962,78 @@ func Test_RatesAndLimits(t *testing.T) {
 		"file.sql:6:21: undefined query: y",
 		"file.sql:7:19: undefined tag: z",
 		"file.sql:8:21: undefined table: t")
+
+	require.AppSchemaError(`APPLICATION app1();
+	WORKSPACE w (
+		RATE r 1 PER HOUR;
+		TABLE Bill INHERITS ODoc ();
+		LIMIT l ON TABLE Bill WITH RATE r;
+	);`,
+		"file.sql:5:20: LIMIT can not be applied to ODoc or ORecord")
+
+	t.Run("must be ok to build rates and limits", func(t *testing.T) {
+		schema, err := require.AppSchema(`APPLICATION app1();
+	DECLARE nPerSec int32 DEFAULT 100;
+	TAG Backoffice;
+	TAG Unused;
+	WORKSPACE w (
+		-- default rate comment
+		RATE DefaultRate 10 PER HOUR;
+		RATE VarRate nPerSec PER SECOND PER WORKSPACE PER USER;
+		RATE IpRate 3 PER 5 MINUTES PER APP PARTITION PER IP;
+		TABLE Doc INHERITS CDoc (A int32) WITH Tags=(Backoffice);
+		TABLE Bill INHERITS ODoc (Total int32) WITH Tags=(Backoffice);
+		EXTENSION ENGINE BUILTIN (
+			COMMAND Cmd() WITH Tags=(Backoffice);
+			QUERY Qry() RETURNS void;
+		);
+		LIMIT CmdLimit ON COMMAND Cmd WITH RATE IpRate;
+		LIMIT TableLimit ON TABLE Doc WITH RATE VarRate;
+		LIMIT TagLimit ON TAG Backoffice WITH RATE DefaultRate;
+		LIMIT AllLimit ON EVERYTHING WITH RATE DefaultRate;
+		LIMIT UnusedLimit ON TAG Unused WITH RATE DefaultRate;
+	);`)
+		require.NoError(err)
+
+		appBld := appdef.New()
+		require.NoError(BuildAppDefs(schema, appBld))
+		app, err := appBld.Build()
+		require.NoError(err)
+
+		doc, bill := appdef.NewQName("pkg", "Doc"), appdef.NewQName("pkg", "Bill")
+		cmd, qry := appdef.NewQName("pkg", "Cmd"), appdef.NewQName("pkg", "Qry")
+
+		r := app.Rate(appdef.NewQName("pkg", "DefaultRate"))
+		require.EqualValues(10, r.Count())
+		require.Equal(time.Hour, r.Period())
+		require.Equal(appdef.DefaultRateScopes, r.Scopes())
+		require.Equal("default rate comment", r.Comment())
+
+		r = app.Rate(appdef.NewQName("pkg", "VarRate"))
+		require.EqualValues(100, r.Count(), "default value of variable")
+		require.Equal(time.Second, r.Period())
+		require.Equal([]appdef.RateScope{appdef.RateScope_Workspace, appdef.RateScope_User}, r.Scopes())
+
+		r = app.Rate(appdef.NewQName("pkg", "IpRate"))
+		require.EqualValues(3, r.Count())
+		require.Equal(5*time.Minute, r.Period())
+		require.Equal([]appdef.RateScope{appdef.RateScope_AppPartition, appdef.RateScope_IP}, r.Scopes())
+
+		require.Equal(appdef.QNamesFrom(cmd), app.Limit(appdef.NewQName("pkg", "CmdLimit")).On())
+		require.Equal(appdef.QNamesFrom(doc), app.Limit(appdef.NewQName("pkg", "TableLimit")).On())
+		backoffice := appdef.NewQName("pkg", "Backoffice")
+		tagLimit := app.Limit(appdef.NewQName("pkg", "TagLimit"))
+		require.Equal(appdef.QNamesFrom(backoffice), tagLimit.On())
+		require.Equal(appdef.QNamesFrom(doc, cmd), tagLimit.Limited(), "ODoc is not limited")
+
+		all := app.Limit(appdef.NewQName("pkg", "AllLimit")).On()
+		require.True(all.Contains(doc))
+		require.True(all.Contains(cmd))
+		require.True(all.Contains(qry))
+		require.False(all.Contains(bill))
+
+		require.Empty(app.Limit(appdef.NewQName("pkg", "UnusedLimit")).Limited(), "no types with unused tag")
+
+		require.Equal(appdef.TypeKind_Limit, app.Workspace(appdef.NewQName("pkg", "w")).Type(appdef.NewQName("pkg", "CmdLimit")).Kind())
+	})
 }
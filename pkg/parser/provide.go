@@ -59,3 +59,29 @@ func BuildAppSchema(packages []*PackageSchemaAST) (*AppSchemaAST, error) {
 func BuildAppDefs(appSchema *AppSchemaAST, builder appdef.IAppDefBuilder, opts ...BuildAppDefsOption) error {
 	return buildAppDefs(appSchema, builder, opts...)
 }
+
+// Returns compiler for expressions of structures checks and generated fields.
+//
+// BuildAppDefs sets this compiler for the builder, use it for application definitions built without parser
+func ExpressionCompiler() appdef.IExpressionCompiler {
+	return expressionCompiler{}
+}
+
+// CompileCheck compiles CHECK expression over specified fields.
+//
+// Expressions may use comparisons, arithmetic, boolean logic, IS [NOT] NULL, BETWEEN, IN
+// and string functions LENGTH, LOWER, UPPER, TRIM, SUBSTRING and CONCAT.
+//
+// Returns error if expression has syntax errors, refers to unknown fields or functions or has operands of incompatible types
+func CompileCheck(expression string, fields appdef.IFields) (appdef.CheckFunc, error) {
+	return compileCheck(expression, fields)
+}
+
+// CompileGenerated compiles GENERATED ALWAYS AS expression of specified field.
+//
+// Expressions may use the same constructs as CHECK expressions, but can not refer to generated fields.
+//
+// Returns error if expression can not be compiled or expression type is incompatible with field data kind
+func CompileGenerated(expression string, fields appdef.IFields, field string) (appdef.GeneratedFunc, error) {
+	return compileGenerated(expression, fields, field)
+}
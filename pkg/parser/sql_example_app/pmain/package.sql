@@ -72,15 +72,16 @@ TABLE TablePlan INHERITS CDoc (
     Rate currency NOT NULL,
     Expiration timestamp,
     VerifiableField varchar NOT NULL VERIFIABLE, -- Verifiable field
-    Int1 int DEFAULT 1 CHECK(Int1 >= 1 AND Int2 < 10000),  -- Expressions evaluating to TRUE or UNKNOWN succeed.
+    Int1 int DEFAULT 1 CHECK(Int1 >= 1 AND Int1 < 10000),  -- Expressions evaluating to TRUE or UNKNOWN succeed.
     Text1 varchar DEFAULT 'a',
     "bytes" bytes, -- optional quotes
     ScreenGroupRef ref(ScreenGroup),
     AnyTableRef ref,
     FewTablesRef ref(ScreenGroup, TablePlan) NOT NULL,
     CheckedField varchar(8) CHECK '^[0-9]{8}$', -- Field validated by regexp
-    CHECK (ValidateRow(this)), -- Unnamed CHECK table constraint. Expressions evaluating to TRUE or UNKNOWN succeed.
-    CONSTRAINT StateChecker CHECK (ValidateFState(FState)), -- Named CHECK table constraint
+    SearchName varchar GENERATED ALWAYS AS (LOWER(TRIM(Name))), -- Read-only field, calculated by core when record is created or updated
+    CHECK (LENGTH(TRIM(Name)) > 0 AND TableNumber <> FState), -- Unnamed CHECK table constraint. Expressions evaluating to TRUE or UNKNOWN succeed.
+    CONSTRAINT StateChecker CHECK (FState BETWEEN 0 AND 2), -- Named CHECK table constraint
     UNIQUE (FState, Name), -- unnamed UNIQUE table constraint, core generates `main.TablePlan$uniques$01` automatically
     CONSTRAINT UniqueTable UNIQUE (TableNumber), -- named UNIQUE table constraint
     UNIQUEFIELD Name, -- deprecated. For Air backward compatibility only
@@ -316,11 +317,12 @@ WORKSPACE MyWorkspace (
         Dummy2 int,
         PRIMARY KEY ((Dummy), Dummy2)
     ) AS RESULT OF UpdateDashboard;
+    -- Records of the view expire in 90 days after they are written
     VIEW NotificationsHistory(
         Dummy int,
         Dummy2 int,
         PRIMARY KEY ((Dummy), Dummy2)
-    ) AS RESULT OF UpdateDashboard;
+    ) AS RESULT OF UpdateDashboard WITH TTL 90 DAYS;
     VIEW ActiveTablePlansView(
         Dummy int,
         Dummy2 int,
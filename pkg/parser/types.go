@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/fs"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/participle/v2/lexer"
 
@@ -115,6 +116,7 @@ type RootStatement struct {
 	Type           *TypeStmt           `parser:"| @@"`
 	Application    *ApplicationStmt    `parser:"| @@"`
 	Declare        *DeclareStmt        `parser:"| @@"`
+	Index          *IndexStmt          `parser:"| @@"`
 	// Sequence  *sequenceStmt  `parser:"| @@"`
 
 	stmt interface{}
@@ -135,6 +137,7 @@ type WorkspaceStatement struct {
 	Table     *TableStmt              `parser:"| @@"`
 	Type      *TypeStmt               `parser:"| @@"`
 	Limit     *LimitStmt              `parser:"| @@"`
+	Index     *IndexStmt              `parser:"| @@"`
 	//Sequence  *sequenceStmt  `parser:"| @@"`
 	Grant *GrantStmt `parser:"| @@"`
 
@@ -296,6 +299,12 @@ type TypeBytes struct {
 	MaxLen *uint64 `parser:"'bytes' ( '(' @Int ')' )?"`
 }
 
+type TypeDecimal struct {
+	Pos       lexer.Position
+	Precision *uint64 `parser:"('decimal' | 'numeric') ( '(' @Int"`
+	Scale     *uint64 `parser:"( ',' @Int )? ')' )?"`
+}
+
 type VoidOrDataType struct {
 	Void     bool           `parser:"( @'void'"`
 	DataType *DataTypeOrDef `parser:"| @@)"`
@@ -309,6 +318,7 @@ type VoidOrDef struct {
 type DataType struct {
 	Varchar   *TypeVarchar `parser:"( @@"`
 	Bytes     *TypeBytes   `parser:"| @@"`
+	Decimal   *TypeDecimal `parser:"| @@"`
 	Int32     bool         `parser:"| @('int' | 'int32')"`
 	Int64     bool         `parser:"| @'int64'"`
 	Float32   bool         `parser:"| @('float' | 'float32')"`
@@ -317,6 +327,8 @@ type DataType struct {
 	Bool      bool         `parser:"| @'bool'"`
 	Blob      bool         `parser:"| @'blob'"`
 	Timestamp bool         `parser:"| @'timestamp'"`
+	Date      bool         `parser:"| @'date'"`
+	UUID      bool         `parser:"| @'uuid'"`
 	Record    bool         `parser:"| @'record'"`
 	Currency  bool         `parser:"| @'currency' )"`
 }
@@ -346,8 +358,21 @@ func (q DataType) String() (s string) {
 		return fmt.Sprintf("bytes[%d]", appdef.DefaultFieldMaxLength)
 	} else if q.Blob {
 		return "blob"
+	} else if q.Decimal != nil {
+		precision, scale := uint64(appdef.DefaultDecimalPrecision), uint64(0)
+		if q.Decimal.Precision != nil {
+			precision = *q.Decimal.Precision
+		}
+		if q.Decimal.Scale != nil {
+			scale = *q.Decimal.Scale
+		}
+		return fmt.Sprintf("decimal(%d,%d)", precision, scale)
 	} else if q.Timestamp {
 		return "timestamp"
+	} else if q.Date {
+		return "date"
+	} else if q.UUID {
+		return "uuid"
 	} else if q.Currency {
 		return "currency"
 	}
@@ -355,16 +380,23 @@ func (q DataType) String() (s string) {
 	return "?"
 }
 
-// not suppored by kernel yet:
-// type DataTypeOrDefArray struct {
-// 	Unbounded bool `parser:"@'[]' |"`
-// 	MaxOccurs int  `parser:"'[' @Int ']'"`
-// }
+// Array suffix of field type: `[]` for unbounded array or `[N]` for array of up to N items
+type DataTypeArray struct {
+	Pos       lexer.Position
+	Unbounded bool    `parser:"( @Array"`
+	MaxItems  *uint64 `parser:"| '[' @Int ']' )"`
+}
+
+func (a DataTypeArray) String() string {
+	if a.MaxItems != nil {
+		return fmt.Sprintf("[%d]", *a.MaxItems)
+	}
+	return "[]"
+}
 
 type DataTypeOrDef struct {
 	DataType *DataType `parser:"( @@"`
 	Def      *DefQName `parser:"| @@ )"`
-	// Array    *DataTypeOrDefArray `parser:"@@?"` not suppored by kernel yet
 
 	// filled on the analysis stage
 	qName     appdef.QName
@@ -438,6 +470,7 @@ type ProjectorStmt struct {
 	State           []ProjectorStorage `parser:"('STATE'   '(' @@ (',' @@)* ')' )?"`
 	Intents         []ProjectorStorage `parser:"('INTENTS' '(' @@ (',' @@)* ')' )?"`
 	IncludingErrors bool               `parser:"@('INCLUDING' 'ERRORS')?"`
+	With            []WithItem         `parser:"('WITH' @@ (',' @@)* )?"`
 	Engine          EngineType         // Initialized with 1st pass
 }
 
@@ -513,6 +546,17 @@ type UseTableStmt struct {
 	qNames []appdef.QName // filled on the analysis stage
 }
 
+type IndexStmt struct {
+	Statement
+	Name   Ident        `parser:"'INDEX' @Ident"`
+	Table  DefQName     `parser:"'ON' @@"`
+	Fields []Identifier `parser:"'(' @@ (',' @@)* ')'"`
+
+	table appdef.QName // filled on the analysis stage
+}
+
+func (s IndexStmt) GetName() string { return string(s.Name) }
+
 type UseWorkspaceStmt struct {
 	Statement
 	Workspace Identifier `parser:"'USE' 'WORKSPACE' @@"`
@@ -537,6 +581,20 @@ type RateValueTimeUnit struct {
 	Year   bool `parser:"| @('YEAR' | 'YEARS')"`
 }
 
+func (u RateValueTimeUnit) Duration() time.Duration {
+	switch {
+	case u.Second:
+		return time.Second
+	case u.Minute:
+		return time.Minute
+	case u.Hour:
+		return time.Hour
+	case u.Day:
+		return hoursPerDay * time.Hour
+	}
+	return daysPerYear * hoursPerDay * time.Hour
+}
+
 type RateValue struct {
 	Count           *int              `parser:"(@Int"`
 	Variable        *DefQName         `parser:"| @@) 'PER'"`
@@ -577,9 +635,11 @@ type LimitAction struct {
 
 type LimitStmt struct {
 	Statement
-	Name     Ident       `parser:"'LIMIT' @Ident"`
-	Action   LimitAction `parser:"@@"`
-	RateName DefQName    `parser:"'WITH' 'RATE' @@"`
+	Name     Ident        `parser:"'LIMIT' @Ident"`
+	Action   LimitAction  `parser:"@@"`
+	RateName DefQName     `parser:"'WITH' 'RATE' @@"`
+	on       appdef.QName // filled on the analysis stage, table, command, query or tag
+	rate     appdef.QName // filled on the analysis stage
 }
 
 func (s LimitStmt) GetName() string { return string(s.Name) }
@@ -630,6 +690,9 @@ type GrantStmt struct {
 
 	On DefQName `parser:"@@"`
 	To DefQName `parser:"'TO' @@"`
+
+	role appdef.QName // filled on the analysis stage
+	on   appdef.QName // filled on the analysis stage, type or tag
 }
 
 type StorageStmt struct {
@@ -682,8 +745,18 @@ func (s *CommandStmt) GetName() string            { return string(s.Name) }
 func (s *CommandStmt) SetEngineType(e EngineType) { s.Engine = e }
 
 type WithItem struct {
-	Comment *string    `parser:"('Comment' '=' @String)"`
-	Tags    []DefQName `parser:"| ('Tags' '=' '(' @@ (',' @@)* ')')"`
+	Comment        *string    `parser:"('Comment' '=' @String)"`
+	Tags           []DefQName `parser:"| ('Tags' '=' '(' @@ (',' @@)* ')')"`
+	MaxDurationMs  *uint64    `parser:"| ('MaxDurationMs' '=' @Int)"`
+	MaxHostCalls   *uint64    `parser:"| ('MaxHostCalls' '=' @Int)"`
+	MaxIntents     *uint64    `parser:"| ('MaxIntents' '=' @Int)"`
+	MaxMemoryPages *uint64    `parser:"| ('MaxMemoryPages' '=' @Int)"`
+
+	tags []appdef.QName // filled on the analysis stage
+}
+
+func (i WithItem) limit() bool {
+	return (i.MaxDurationMs != nil) || (i.MaxHostCalls != nil) || (i.MaxIntents != nil) || (i.MaxMemoryPages != nil)
 }
 
 type AnyOrVoidOrDef struct {
@@ -737,6 +810,9 @@ func (s *TableStmt) Iterate(callback func(stmt interface{})) {
 		if item.Field != nil {
 			callback(item.Field)
 		}
+		if item.Constraint != nil {
+			callback(item.Constraint)
+		}
 	}
 }
 
@@ -793,12 +869,14 @@ type CheckRegExp struct {
 
 type FieldExpr struct {
 	Statement
-	Name               Ident         `parser:"@Ident"`
-	Type               DataTypeOrDef `parser:"@@"`
-	NotNull            bool          `parser:"@(NOTNULL)?"`
-	Verifiable         bool          `parser:"@('VERIFIABLE')?"`
-	DefaultIntValue    *int          `parser:"('DEFAULT' @Int)?"`
-	DefaultStringValue *string       `parser:"('DEFAULT' @String)?"`
+	Name               Ident          `parser:"@Ident"`
+	Type               DataTypeOrDef  `parser:"@@"`
+	Array              *DataTypeArray `parser:"@@?"`
+	Generated          *Expression    `parser:"('GENERATED' 'ALWAYS' 'AS' '(' @@ ')')?"`
+	NotNull            bool           `parser:"@(NOTNULL)?"`
+	Verifiable         bool           `parser:"@('VERIFIABLE')?"`
+	DefaultIntValue    *int           `parser:"('DEFAULT' @Int)?"`
+	DefaultStringValue *string        `parser:"('DEFAULT' @String)?"`
 	//	DefaultNextVal     *string       `parser:"(DEFAULTNEXTVAL  '(' @String ')')?"`
 	CheckRegexp     *CheckRegExp `parser:"('CHECK' @@ )?"`
 	CheckExpression *Expression  `parser:"('CHECK' '(' @@ ')')? "`
@@ -809,9 +887,21 @@ type ViewStmt struct {
 	Name     Ident          `parser:"'VIEW' @Ident"`
 	Items    []ViewItemExpr `parser:"'(' @@? (',' @@)* ')'"`
 	ResultOf DefQName       `parser:"'AS' 'RESULT' 'OF' @@"`
+	TTL      *ViewTTL       `parser:"('WITH' 'TTL' @@)?"`
 	pkRef    *PrimaryKeyExpr
 }
 
+// Time to live of view records, e.g. "WITH TTL 30 DAYS"
+type ViewTTL struct {
+	Pos      lexer.Position
+	Amount   int               `parser:"@Int"`
+	TimeUnit RateValueTimeUnit `parser:"@@"`
+}
+
+func (t ViewTTL) Duration() time.Duration {
+	return time.Duration(t.Amount) * t.TimeUnit.Duration()
+}
+
 func (s *ViewStmt) Iterate(callback func(stmt interface{})) {
 	for i := 0; i < len(s.Items); i++ {
 		item := &s.Items[i]
@@ -902,9 +992,10 @@ type ViewRefField struct {
 
 type ViewField struct {
 	Statement
-	Name    Identifier `parser:"@@"`
-	Type    DataType   `parser:"@@"`
-	NotNull bool       `parser:"@(NOTNULL)?"`
+	Name    Identifier     `parser:"@@"`
+	Type    DataType       `parser:"@@"`
+	Array   *DataTypeArray `parser:"@@?"`
+	NotNull bool           `parser:"@(NOTNULL)?"`
 }
 
 type IVariableResolver interface {
@@ -4,6 +4,11 @@
  */
 package parser
 
+import (
+	"strconv"
+	"strings"
+)
+
 type Boolean bool
 
 func (b *Boolean) Capture(values []string) error {
@@ -20,8 +25,8 @@ type OrCondition struct {
 }
 
 type Condition struct {
-	Operand *ConditionOperand `parser:"  @@"`
-	Not     *Condition        `parser:"| 'NOT' @@"`
+	Not     *Condition        `parser:"  'NOT' @@"`
+	Operand *ConditionOperand `parser:"| @@"`
 }
 
 type ConditionOperand struct {
@@ -47,8 +52,8 @@ type Like struct {
 }
 
 type Is struct {
-	Not  bool `parser:"[ @'NOT' ]"`
-	Null bool `parser:"@'NULL'"`
+	Not  bool `parser:"( @NOTNULL"`
+	Null bool `parser:"| @'NULL' )"`
 }
 
 type Between struct {
@@ -94,3 +99,113 @@ type Value struct {
 type Array struct {
 	Expressions []*Expression `parser:"'(' @@ ( ',' @@ )* ')'"`
 }
+
+func (e Expression) String() string {
+	ss := make([]string, len(e.Or))
+	for i, o := range e.Or {
+		ss[i] = o.String()
+	}
+	return strings.Join(ss, " OR ")
+}
+
+func (o OrCondition) String() string {
+	ss := make([]string, len(o.And))
+	for i, a := range o.And {
+		ss[i] = a.String()
+	}
+	return strings.Join(ss, " AND ")
+}
+
+func (c Condition) String() string {
+	if c.Not != nil {
+		return "NOT " + c.Not.String()
+	}
+	return c.Operand.String()
+}
+
+func (o ConditionOperand) String() string {
+	if o.ConditionRHS == nil {
+		return o.Operand.String()
+	}
+	return o.Operand.String() + " " + o.ConditionRHS.String()
+}
+
+func (r ConditionRHS) String() string {
+	switch {
+	case r.Compare != nil:
+		return r.Compare.Operator + " " + r.Compare.Operand.String()
+	case r.Is != nil:
+		if r.Is.Not {
+			return "IS NOT NULL"
+		}
+		return "IS NULL"
+	case r.Between != nil:
+		return "BETWEEN " + r.Between.Start.String() + " AND " + r.Between.End.String()
+	case r.In != nil:
+		ss := make([]string, len(r.In.Expressions))
+		for i, e := range r.In.Expressions {
+			ss[i] = e.String()
+		}
+		return "IN (" + strings.Join(ss, ", ") + ")"
+	}
+	return ""
+}
+
+func (o Operand) String() string {
+	if o.RHS == nil {
+		return o.LHS.String()
+	}
+	return o.LHS.String() + " " + o.Op + " " + o.RHS.String()
+}
+
+func (f Factor) String() string {
+	if f.RHS == nil {
+		return f.LHS.String()
+	}
+	return f.LHS.String() + " " + f.Op + " " + f.RHS.String()
+}
+
+func (t Term) String() string {
+	switch {
+	case t.Value != nil:
+		return t.Value.literal()
+	case t.SymbolRef != nil:
+		return t.SymbolRef.String()
+	case t.SubExpression != nil:
+		return "(" + t.SubExpression.String() + ")"
+	}
+	return ""
+}
+
+func (s SymbolRef) String() string {
+	if s.Parameters == nil {
+		return s.Name.String()
+	}
+	ss := make([]string, len(s.Parameters))
+	for i, p := range s.Parameters {
+		ss[i] = p.String()
+	}
+	return s.Name.String() + "(" + strings.Join(ss, ", ") + ")"
+}
+
+// Returns value literal as it should be written in VSQL
+func (v Value) literal() string {
+	switch {
+	case v.Int != nil:
+		return strconv.FormatInt(*v.Int, 10)
+	case v.Float != nil:
+		s := strconv.FormatFloat(*v.Float, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0" // keeps value float, see Float token in lexer
+		}
+		return s
+	case v.String != nil:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(*v.String) + "'"
+	case v.Boolean != nil:
+		if *v.Boolean {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	return "NULL"
+}
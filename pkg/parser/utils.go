@@ -192,7 +192,7 @@ func iteratePackage(pkg *PackageSchemaAST, ctx *basicContext, callback func(stmt
 }
 
 func iteratePackageStmt[stmtType *TableStmt | *TypeStmt | *ViewStmt | *CommandStmt | *QueryStmt |
-	*WorkspaceStmt | *AlterWorkspaceStmt | *ProjectorStmt | *RateStmt](pkg *PackageSchemaAST, ctx *basicContext, callback func(stmt stmtType, ctx *iterateCtx)) {
+	*WorkspaceStmt | *AlterWorkspaceStmt | *ProjectorStmt | *RateStmt | *LimitStmt | *TagStmt | *IndexStmt | *RoleStmt | *GrantStmt](pkg *PackageSchemaAST, ctx *basicContext, callback func(stmt stmtType, ctx *iterateCtx)) {
 	iteratePackage(pkg, ctx, func(stmt interface{}, ctx *iterateCtx) {
 		if s, ok := stmt.(stmtType); ok {
 			callback(s, ctx)
@@ -323,8 +323,17 @@ func dataTypeToDataKind(t DataType) appdef.DataKind {
 	if t.Varchar != nil {
 		return appdef.DataKind_string
 	}
+	if t.Decimal != nil {
+		return appdef.DataKind_decimal
+	}
 	if t.Timestamp {
-		return appdef.DataKind_int64
+		return appdef.DataKind_timestamp
+	}
+	if t.Date {
+		return appdef.DataKind_date
+	}
+	if t.UUID {
+		return appdef.DataKind_UUID
 	}
 	if t.Record {
 		return appdef.DataKind_Record
@@ -347,3 +356,25 @@ func contains(s []Identifier, e Ident) bool {
 	}
 	return false
 }
+
+func decimalConstraints(d *TypeDecimal) (cc []appdef.IConstraint) {
+	if d.Precision != nil {
+		cc = append(cc, appdef.Precision(uint8(*d.Precision)))
+	}
+	if d.Scale != nil {
+		cc = append(cc, appdef.Scale(uint8(*d.Scale)))
+	}
+	return cc
+}
+
+// Returns min and max items of array field. NOT NULL array requires at least one item
+func arrayItems(a *DataTypeArray, notNull bool) (minItems, maxItems appdef.Occurs) {
+	if notNull {
+		minItems = 1
+	}
+	maxItems = appdef.Occurs_Unbounded
+	if a.MaxItems != nil {
+		maxItems = appdef.Occurs(*a.MaxItems)
+	}
+	return minItems, maxItems
+}
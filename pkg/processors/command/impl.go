@@ -85,6 +85,11 @@ func (c *cmdWorkpiece) WSID() istructs.WSID {
 	return c.cmdMes.WSID()
 }
 
+// returns scopes of the command to check limits
+func (c *cmdWorkpiece) limitScopes() istructs.LimitScopes {
+	return processors.LimitScopes(c.cmdMes.PartitionID(), c.cmdMes.WSID(), c.principals, c.cmdMes.Host())
+}
+
 // borrows app partition for command
 func (c *cmdWorkpiece) borrow() (err error) {
 	if c.appPart, err = c.appParts.Borrow(c.cmdMes.AppQName(), c.cmdMes.PartitionID(), cluster.ProcessorKind_Command); err != nil {
@@ -149,7 +154,8 @@ func (cmdProc *cmdProc) getCmdResultBuilder(_ context.Context, work interface{})
 
 func (cmdProc *cmdProc) buildCommandArgs(_ context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
-	hs := cmd.hostStateProvider.get(cmd.appStructs, cmd.cmdMes.WSID(), cmd.reb.CUDBuilder(), cmd.principals, cmd.cmdMes.Token(), cmd.cmdResultBuilder)
+	hs := cmd.hostStateProvider.get(cmd.appStructs, cmd.cmdMes.WSID(), cmd.reb.CUDBuilder(), cmd.principals, cmd.cmdMes.Token(), cmd.cmdResultBuilder,
+		cmd.argsObject, cmd.unloggedArgsObject)
 	hs.ClearIntents()
 	cmd.eca = istructs.ExecCommandArgs{
 		CommandPrepareArgs: istructs.CommandPrepareArgs{
@@ -312,6 +318,25 @@ func limitCallRate(_ context.Context, work interface{}) (err error) {
 	return nil
 }
 
+func checkLimits(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	if cmd.appStructs.IsLimitExceeded(cmd.cmdMes.Command().QName(), cmd.limitScopes()) {
+		return coreutils.NewHTTPErrorf(http.StatusTooManyRequests)
+	}
+	return nil
+}
+
+// Checks limits over all CUDs of the event: both from request and from command intents
+func limitCUDs(_ context.Context, work interface{}) (err error) {
+	cmd := work.(*cmdWorkpiece)
+	cmd.rawEvent.CUDs(func(rec istructs.ICUDRow) {
+		if err == nil && cmd.appStructs.IsLimitExceeded(rec.QName(), cmd.limitScopes()) {
+			err = coreutils.NewHTTPError(http.StatusTooManyRequests, fmt.Errorf("%v: operation rate limit exceeded", rec.QName()))
+		}
+	})
+	return err
+}
+
 func (cmdProc *cmdProc) authenticate(_ context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
 	req := iauthnz.AuthnRequest{
@@ -350,6 +375,10 @@ func getResources(_ context.Context, work interface{}) (err error) {
 
 func getFunction(_ context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
+	if cmd.cmdMes.Command().Engine() == appdef.ExtensionEngineKind_WASM {
+		// WASM command is invoked by extension engine of borrowed application partition
+		return nil
+	}
 	cmd.cmdFunc = cmd.resources.QueryResource(cmd.cmdMes.Command().QName()).(istructs.ICommandFunction) // existence is checked already
 	return nil
 }
@@ -447,10 +476,14 @@ func (xp xPath) Error(err error) error {
 	return xp.Errorf("%w", err)
 }
 
-func execCommand(_ context.Context, work interface{}) (err error) {
+func execCommand(ctx context.Context, work interface{}) (err error) {
 	cmd := work.(*cmdWorkpiece)
 	begin := time.Now()
-	err = cmd.cmdFunc.Exec(cmd.eca)
+	if cmd.cmdFunc == nil {
+		err = cmd.appPart.Invoke(ctx, cmd.cmdMes.Command().QName(), cmd.hostStateProvider.state)
+	} else {
+		err = cmd.cmdFunc.Exec(cmd.eca)
+	}
 	work.(*cmdWorkpiece).metrics.increase(ExecSeconds, time.Since(begin).Seconds())
 	return err
 }
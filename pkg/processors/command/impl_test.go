@@ -12,6 +12,7 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/iauthnzimpl"
+	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/in10nmem"
 	"github.com/voedger/voedger/pkg/iratesce"
@@ -35,6 +37,7 @@ import (
 	"github.com/voedger/voedger/pkg/pipeline"
 	"github.com/voedger/voedger/pkg/processors"
 	"github.com/voedger/voedger/pkg/projectors"
+	"github.com/voedger/voedger/pkg/state"
 	coreutils "github.com/voedger/voedger/pkg/utils"
 	ibus "github.com/voedger/voedger/staging/src/github.com/untillpro/airs-ibus"
 	"github.com/voedger/voedger/staging/src/github.com/untillpro/ibusmem"
@@ -285,6 +288,111 @@ func TestRecovery(t *testing.T) {
 	<-app.done
 }
 
+func TestDecimalDateTimestampUUID_JSON(t *testing.T) {
+	require := require.New(t)
+
+	cmdQName := appdef.NewQName(appdef.SysPackage, "Values")
+	paramsQName := appdef.NewQName("test", "ValuesParams")
+	resultQName := appdef.NewQName("test", "ValuesResult")
+	docQName := appdef.NewQName("test", "ValuesDoc")
+	projQName := appdef.NewQName(appdef.SysPackage, "ValuesCUDs")
+	const uuid = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+	cudValues := make(chan map[string]interface{}, 1)
+	app := setUp(t, func(appDef appdef.IAppDefBuilder, cfg *istructsmem.AppConfigType) {
+		for _, f := range []appdef.IFieldsBuilder{appDef.AddObject(paramsQName), appDef.AddObject(resultQName), appDef.AddCDoc(docQName)} {
+			f.AddField("decimal", appdef.DataKind_decimal, false, appdef.Precision(18), appdef.Scale(2)).
+				AddField("date", appdef.DataKind_date, false).
+				AddField("timestamp", appdef.DataKind_timestamp, false).
+				AddField("uuid", appdef.DataKind_UUID, false)
+		}
+		appDef.AddCommand(cmdQName).SetParam(paramsQName).SetResult(resultQName)
+
+		cfg.AddSyncProjectors(func(partition istructs.PartitionID) istructs.Projector {
+			return istructs.Projector{
+				Name: projQName,
+				Func: func(event istructs.IPLogEvent, _ istructs.IState, _ istructs.IIntents) (err error) {
+					event.CUDs(func(rec istructs.ICUDRow) {
+						cudValues <- map[string]interface{}{
+							"decimal":   rec.AsString("decimal"),
+							"date":      istructs.DateToString(rec.AsInt32("date")),
+							"timestamp": istructs.TimestampToString(rec.AsInt64("timestamp")),
+							"uuid":      istructs.UUIDToString(rec.AsBytes("uuid")),
+						}
+					})
+					return nil
+				},
+			}
+		})
+		appDef.AddProjector(projQName).AddEvent(cmdQName, appdef.ProjectorEventKind_Execute)
+	})
+	defer tearDown(app)
+
+	// command returns its arguments as the result
+	cmd := istructsmem.NewCommandFunction(cmdQName, func(args istructs.ExecCommandArgs) (err error) {
+		res := args.Workpiece.(*cmdWorkpiece).cmdResultBuilder
+		res.PutInt64("decimal", args.ArgumentObject.AsInt64("decimal"))
+		res.PutInt32("date", args.ArgumentObject.AsInt32("date"))
+		res.PutInt64("timestamp", args.ArgumentObject.AsInt64("timestamp"))
+		res.PutBytes("uuid", args.ArgumentObject.AsBytes("uuid"))
+		return nil
+	})
+	app.cfg.Resources.Add(cmd)
+
+	send := func(body string) ibus.Response {
+		req := ibus.Request{
+			WSID:     1,
+			AppQName: istructs.AppQName_untill_airs_bp.String(),
+			Resource: "c.sys.Values",
+			Body:     []byte(body),
+			Header:   app.sysAuthHeader,
+		}
+		resp, _, _, err := app.bus.SendRequest2(app.ctx, req, coreutils.GetTestBustTimeout())
+		require.NoError(err)
+		return resp
+	}
+
+	t.Run("should be ok to unmarshal strings and numbers and to marshal strings", func(t *testing.T) {
+		resp := send(fmt.Sprintf(`{
+			"args":{"decimal":"1234567890123456.78","date":19753,"timestamp":"2024-01-31T15:00:00.5+03:00","uuid":"%[1]s"},
+			"cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.ValuesDoc","decimal":12.3,"date":"2024-01-31","timestamp":1706702400500,"uuid":"%[1]s"}}]
+		}`, strings.ToUpper(uuid)))
+		require.Equal(http.StatusOK, resp.StatusCode, string(resp.Data))
+
+		m := map[string]interface{}{}
+		require.NoError(json.Unmarshal(resp.Data, &m))
+		require.Equal(map[string]interface{}{
+			appdef.SystemField_QName:     resultQName.String(),
+			appdef.SystemField_Container: "",
+			"decimal":                    "1234567890123456.78",
+			"date":                       "2024-01-31",
+			"timestamp":                  "2024-01-31T12:00:00.500Z",
+			"uuid":                       uuid,
+		}, m["Result"])
+
+		require.Equal(map[string]interface{}{
+			"decimal":   "12.30",
+			"date":      "2024-01-31",
+			"timestamp": "2024-01-31T12:00:00.500Z",
+			"uuid":      uuid,
+		}, <-cudValues)
+	})
+
+	t.Run("should be bad request if invalid values", func(t *testing.T) {
+		for _, args := range []string{
+			`{"decimal":"1.234"}`,
+			`{"decimal":12345678901234567}`,
+			`{"date":1.5}`,
+			`{"date":"31.01.2024"}`,
+			`{"timestamp":1e20}`,
+			`{"uuid":"f47ac10b"}`,
+		} {
+			resp := send(`{"args":` + args + `}`)
+			require.Equal(http.StatusBadRequest, resp.StatusCode, args)
+		}
+	})
+}
+
 func restartCmdProc(app *testApp) {
 	app.cancel()
 	<-app.done
@@ -594,6 +702,85 @@ func TestBasicUsage_FuncWithRawArg(t *testing.T) {
 	<-ch
 }
 
+// Extension engine, which WASM command greets the name from argument
+type testWASMEngine struct {
+	mx      sync.Mutex
+	invoked []iextengine.ExtQName
+}
+
+func (e *testWASMEngine) SetLimits(iextengine.ExtensionLimits) {}
+
+func (e *testWASMEngine) Invoke(_ context.Context, extName iextengine.ExtQName, io iextengine.IExtensionIO) error {
+	e.mx.Lock()
+	e.invoked = append(e.invoked, extName)
+	e.mx.Unlock()
+
+	argKey, err := io.KeyBuilder(state.ArgumentObject, appdef.NullQName)
+	if err != nil {
+		return err
+	}
+	arg, err := io.MustExist(argKey)
+	if err != nil {
+		return err
+	}
+	resKey, err := io.KeyBuilder(state.Result, appdef.NullQName)
+	if err != nil {
+		return err
+	}
+	res, err := io.NewValue(resKey)
+	if err != nil {
+		return err
+	}
+	res.PutString("greeting", "Hello, "+arg.AsString("name"))
+	return nil
+}
+
+func (e *testWASMEngine) Close(context.Context) {}
+
+type testWASMEngineFactory struct {
+	engine *testWASMEngine
+}
+
+func (f testWASMEngineFactory) New(_ context.Context, _ []iextengine.ExtensionPackage, _ *iextengine.ExtEngineConfig, numEngines int) ([]iextengine.IExtensionEngine, error) {
+	ee := make([]iextengine.IExtensionEngine, numEngines)
+	for i := range ee {
+		ee[i] = f.engine
+	}
+	return ee, nil
+}
+
+func TestWASMCommand(t *testing.T) {
+	require := require.New(t)
+
+	cmdQName := appdef.NewQName("test", "Greet")
+	paramsQName := appdef.NewQName("test", "GreetParams")
+	resultQName := appdef.NewQName("test", "GreetResult")
+	engine := &testWASMEngine{}
+	app := setUpWithExtEngines(t, func(appDef appdef.IAppDefBuilder, _ *istructsmem.AppConfigType) {
+		appDef.AddObject(paramsQName).AddField("name", appdef.DataKind_string, true)
+		appDef.AddObject(resultQName).AddField("greeting", appdef.DataKind_string, true)
+		appDef.AddCommand(cmdQName).SetParam(paramsQName).SetResult(resultQName).SetEngine(appdef.ExtensionEngineKind_WASM)
+	}, iextengine.IExtensionEngineFactories{appdef.ExtensionEngineKind_WASM: testWASMEngineFactory{engine}})
+	defer tearDown(app)
+
+	request := ibus.Request{
+		Body:     []byte(`{"args":{"name":"World"}}`),
+		AppQName: istructs.AppQName_untill_airs_bp.String(),
+		WSID:     1,
+		Resource: "c." + cmdQName.String(),
+		Header:   app.sysAuthHeader,
+	}
+	resp, _, _, err := app.bus.SendRequest2(app.ctx, request, coreutils.GetTestBustTimeout())
+	require.NoError(err)
+	require.Equal(http.StatusOK, resp.StatusCode, string(resp.Data))
+
+	require.Equal([]iextengine.ExtQName{iextengine.NewExtQName("test", "Greet")}, engine.invoked)
+
+	m := map[string]interface{}{}
+	require.NoError(json.Unmarshal(resp.Data, &m))
+	require.Equal("Hello, World", m["Result"].(map[string]interface{})["greeting"])
+}
+
 func TestRateLimit(t *testing.T) {
 	require := require.New(t)
 
@@ -638,6 +825,81 @@ func TestRateLimit(t *testing.T) {
 	require.Equal(http.StatusTooManyRequests, resp.StatusCode)
 }
 
+func TestLimits(t *testing.T) {
+	require := require.New(t)
+
+	cmdQName := appdef.NewQName(appdef.SysPackage, "MyCmd")
+	cudQName := appdef.NewQName(appdef.SysPackage, "CUD")
+	intentsCmdQName := appdef.NewQName(appdef.SysPackage, "IntentsCmd")
+	docQName := appdef.NewQName("test", "doc")
+	intentDocQName := appdef.NewQName("test", "intentDoc")
+	rateQName := appdef.NewQName("test", "rate")
+
+	app := setUp(t,
+		func(appDef appdef.IAppDefBuilder, cfg *istructsmem.AppConfigType) {
+			appDef.AddCDoc(docQName).AddField("IntFld", appdef.DataKind_int32, false)
+			appDef.AddCommand(cmdQName)
+			appDef.AddCommand(cudQName)
+			cfg.Resources.Add(istructsmem.NewCommandFunction(cmdQName, istructsmem.NullCommandExec))
+			cfg.Resources.Add(istructsmem.NewCommandFunction(cudQName, istructsmem.NullCommandExec))
+			appDef.AddCDoc(intentDocQName).AddField("IntFld", appdef.DataKind_int32, false)
+			appDef.AddCommand(intentsCmdQName)
+			cfg.Resources.Add(istructsmem.NewCommandFunction(intentsCmdQName, func(args istructs.ExecCommandArgs) error {
+				kb, err := args.State.KeyBuilder(state.Record, intentDocQName)
+				if err != nil {
+					return err
+				}
+				vb, err := args.Intents.NewValue(kb)
+				if err != nil {
+					return err
+				}
+				vb.PutRecordID(appdef.SystemField_ID, 1)
+				return nil
+			}))
+
+			appDef.AddRate(rateQName, 2, time.Minute, appdef.RateScope_Workspace)
+			appDef.AddLimit(appdef.NewQName("test", "cmdLimit"), []appdef.QName{cmdQName}, rateQName)
+			appDef.AddLimit(appdef.NewQName("test", "docLimit"), []appdef.QName{docQName}, rateQName)
+			appDef.AddLimit(appdef.NewQName("test", "intentDocLimit"), []appdef.QName{intentDocQName}, rateQName)
+		})
+	defer tearDown(app)
+
+	send := func(resource, body string) int {
+		request := ibus.Request{
+			Body:     []byte(body),
+			AppQName: istructs.AppQName_untill_airs_bp.String(),
+			WSID:     1,
+			Resource: resource,
+			Header:   app.sysAuthHeader,
+		}
+		resp, _, _, err := app.bus.SendRequest2(app.ctx, request, coreutils.GetTestBustTimeout())
+		require.NoError(err)
+		return resp.StatusCode
+	}
+
+	t.Run("command limit", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			require.Equal(http.StatusOK, send("c.sys.MyCmd", `{}`))
+		}
+		require.Equal(http.StatusTooManyRequests, send("c.sys.MyCmd", `{}`))
+	})
+
+	t.Run("table limit", func(t *testing.T) {
+		const cud = `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"test.doc"}}]}`
+		for i := 0; i < 2; i++ {
+			require.Equal(http.StatusOK, send("c.sys.CUD", cud))
+		}
+		require.Equal(http.StatusTooManyRequests, send("c.sys.CUD", cud))
+	})
+
+	t.Run("table limit on command intents", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			require.Equal(http.StatusOK, send("c.sys.IntentsCmd", `{}`))
+		}
+		require.Equal(http.StatusTooManyRequests, send("c.sys.IntentsCmd", `{}`))
+	})
+}
+
 type testApp struct {
 	ctx               context.Context
 	cfg               *istructsmem.AppConfigType
@@ -679,6 +941,10 @@ var (
 )
 
 func setUp(t *testing.T, prepare func(appDef appdef.IAppDefBuilder, cfg *istructsmem.AppConfigType)) testApp {
+	return setUpWithExtEngines(t, prepare, nil)
+}
+
+func setUpWithExtEngines(t *testing.T, prepare func(appDef appdef.IAppDefBuilder, cfg *istructsmem.AppConfigType), extEngines iextengine.IExtensionEngineFactories) testApp {
 	require := require.New(t)
 	// command processor - это IService, работающий через CommandChannel(iprocbus.ServiceChannel). Подготовим этот channel
 	serviceChannel := make(CommandChannel)
@@ -705,11 +971,11 @@ func setUp(t *testing.T, prepare func(appDef appdef.IAppDefBuilder, cfg *istruct
 		payloads.ProvideIAppTokensFactory(itokensjwt.TestTokensJWT()), appStorageProvider)
 
 	// prepare the AppParts to borrow AppStructs
-	appParts, appPartsClean, err := appparts.New(appStructsProvider)
+	appParts, appPartsClean, err := appparts.NewWithExtEngines(context.Background(), appStructsProvider, extEngines, nil)
 	require.NoError(err)
 	defer appPartsClean()
 
-	appParts.DeployApp(testAppName, appDef, testAppPartsCount, testAppEngines)
+	require.NoError(appParts.DeployApp(testAppName, nil, appDef, testAppPartsCount, testAppEngines))
 	appParts.DeployAppPartitions(testAppName, []istructs.PartitionID{testAppPartID})
 
 	// command processor работает через ibus.SendResponse -> нам нужна реализация ibus
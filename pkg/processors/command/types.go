@@ -122,11 +122,13 @@ type hostStateProvider struct {
 	state            state.IHostState
 	token            string
 	cmdResultBuilder istructs.IObjectBuilder
+	arg              istructs.IObject
+	unloggedArg      istructs.IObject
 }
 
 func newHostStateProvider(ctx context.Context, pid istructs.PartitionID, secretReader isecrets.ISecretReader) *hostStateProvider {
 	p := &hostStateProvider{}
-	p.state = state.ProvideCommandProcessorStateFactory()(ctx, p.getAppStructs, state.SimplePartitionIDFunc(pid), p.getWSID, secretReader, p.getCUD, p.getPrincipals, p.getToken, builtin.MaxCUDs, p.getCmdResultBuilder)
+	p.state = state.ProvideCommandProcessorStateFactory()(ctx, p.getAppStructs, state.SimplePartitionIDFunc(pid), p.getWSID, secretReader, p.getCUD, p.getPrincipals, p.getToken, builtin.MaxCUDs, p.getCmdResultBuilder, p.getArg, p.getUnloggedArg)
 	return p
 }
 
@@ -138,12 +140,17 @@ func (p *hostStateProvider) getPrincipals() []iauthnz.Principal {
 }
 func (p *hostStateProvider) getToken() string                             { return p.token }
 func (p *hostStateProvider) getCmdResultBuilder() istructs.IObjectBuilder { return p.cmdResultBuilder }
-func (p *hostStateProvider) get(appStructs istructs.IAppStructs, wsid istructs.WSID, cud istructs.ICUD, principals []iauthnz.Principal, token string, cmdResultBuilder istructs.IObjectBuilder) state.IHostState {
+func (p *hostStateProvider) getArg() istructs.IObject                     { return p.arg }
+func (p *hostStateProvider) getUnloggedArg() istructs.IObject             { return p.unloggedArg }
+func (p *hostStateProvider) get(appStructs istructs.IAppStructs, wsid istructs.WSID, cud istructs.ICUD, principals []iauthnz.Principal, token string,
+	cmdResultBuilder istructs.IObjectBuilder, arg, unloggedArg istructs.IObject) state.IHostState {
 	p.as = appStructs
 	p.wsid = wsid
 	p.cud = cud
 	p.principals = principals
 	p.token = token
 	p.cmdResultBuilder = cmdResultBuilder
+	p.arg = arg
+	p.unloggedArg = unloggedArg
 	return p.state
 }
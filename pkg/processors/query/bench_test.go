@@ -57,7 +57,7 @@ func Benchmark_pipelineIService_Sequential(b *testing.B) {
 	appParts, cleanAppParts, err := appparts.New(appStructsProvider)
 	require.NoError(err)
 	defer cleanAppParts()
-	appParts.DeployApp(appName, appDef, appPartsCount, appEngines)
+	require.NoError(appParts.DeployApp(appName, nil, appDef, appPartsCount, appEngines))
 	appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
 
 	queryProcessor := ProvideServiceFactory()(
@@ -127,7 +127,7 @@ func Benchmark_pipelineIService_Parallel(b *testing.B) {
 		appParts, cleanAppParts, err := appparts.New(appStructsProvider)
 		require.NoError(err)
 		defer cleanAppParts()
-		appParts.DeployApp(appName, appDef, appPartsCount, appEngines)
+		require.NoError(appParts.DeployApp(appName, nil, appDef, appPartsCount, appEngines))
 		appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
 
 		queryProcessor := ProvideServiceFactory()(
@@ -4,7 +4,11 @@
 
 package queryprocessor
 
-import "github.com/voedger/voedger/pkg/appdef"
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
 
 const (
 	filterKind_Eq    = "eq"
@@ -13,6 +17,16 @@ const (
 	filterKind_Lt    = "lt"
 	filterKind_And   = "and"
 	filterKind_Or    = "or"
+
+	filterKind_Contains   = "contains"
+	filterKind_StartsWith = "startsWith"
+	filterKind_In         = "in"
+)
+
+// sectionType_Cursor is the type of the result section with the continuation token of the next page
+const (
+	sectionType_Cursor  = "cursor"
+	cursorTokenDuration = 24 * time.Hour
 )
 
 const (
@@ -12,3 +12,4 @@ var ErrWrongType = errors.New("wrong type")
 var ErrWrongLength = errors.New("wrong length")
 var ErrNotFound = errors.New("not found")
 var ErrUnexpected = errors.New("unexpected")
+var ErrInvalidCursor = errors.New("invalid cursor")
@@ -5,9 +5,18 @@
 package queryprocessor
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 
+	"golang.org/x/text/language"
+	"golang.org/x/text/search"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
 	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
@@ -33,6 +42,12 @@ func NewFilter(data coreutils.MapObject) (IFilter, error) {
 		return newAndFilter(args)
 	case filterKind_Or:
 		return newOrFilter(args)
+	case filterKind_Contains:
+		return newContainsFilter(args)
+	case filterKind_StartsWith:
+		return newStartsWithFilter(args)
+	case filterKind_In:
+		return newInFilter(args)
 	default:
 		return nil, fmt.Errorf("filter: expr: filter '%s' is unknown: %w", expr, ErrWrongType)
 	}
@@ -92,6 +107,77 @@ func newLessFilter(args interface{}) (IFilter, error) {
 	}, nil
 }
 
+func newContainsFilter(args interface{}) (IFilter, error) {
+	field, value, err := textArgs(args)
+	if err != nil {
+		return nil, filterErr(filterKind_Contains, err)
+	}
+	matcher, err := textMatcher(args)
+	if err != nil {
+		return nil, filterErr(filterKind_Contains, err)
+	}
+	return &ContainsFilter{
+		field:   field,
+		value:   value,
+		pattern: matcher.CompileString(value),
+	}, nil
+}
+
+func newStartsWithFilter(args interface{}) (IFilter, error) {
+	field, value, err := textArgs(args)
+	if err != nil {
+		return nil, filterErr(filterKind_StartsWith, err)
+	}
+	matcher, err := textMatcher(args)
+	if err != nil {
+		return nil, filterErr(filterKind_StartsWith, err)
+	}
+	return &StartsWithFilter{
+		field:   field,
+		value:   value,
+		pattern: matcher.CompileString(value),
+	}, nil
+}
+
+func newInFilter(args interface{}) (IFilter, error) {
+	data, ok := args.(map[string]interface{})
+	if !ok {
+		return nil, filterErr(filterKind_In, fmt.Errorf("field 'args' must be an object: %w", ErrWrongType))
+	}
+	mapObject := coreutils.MapObject(data)
+	field, err := mapObject.AsStringRequired("field")
+	if err != nil {
+		return nil, filterErr(filterKind_In, err)
+	}
+	values, ok, err := mapObject.AsObjects("values")
+	if err != nil {
+		return nil, filterErr(filterKind_In, err)
+	}
+	if !ok {
+		return nil, filterErr(filterKind_In, fmt.Errorf("field 'values' must be present: %w", ErrNotFound))
+	}
+	matcher, err := textMatcher(args)
+	if err != nil {
+		return nil, filterErr(filterKind_In, err)
+	}
+	epsilon, err := epsilon(args)
+	if err != nil {
+		return nil, filterErr(filterKind_In, err)
+	}
+	inFilter := &InFilter{
+		field:    field,
+		values:   values,
+		patterns: make([]*search.Pattern, len(values)),
+		epsilon:  epsilon,
+	}
+	for i, value := range values {
+		if s, ok := value.(string); ok {
+			inFilter.patterns[i] = matcher.CompileString(s)
+		}
+	}
+	return inFilter, nil
+}
+
 func newAndFilter(args interface{}) (IFilter, error) {
 	operands, ok := args.([]interface{})
 	if !ok {
@@ -153,6 +239,59 @@ func generalArgs(args interface{}) (string, interface{}, error) {
 	return field, value, nil
 }
 
+func textArgs(args interface{}) (string, string, error) {
+	field, value, err := generalArgs(args)
+	if err != nil {
+		return "", "", err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", "", fmt.Errorf("field 'value' must be a string: %w", ErrWrongType)
+	}
+	return field, s, nil
+}
+
+// textMatcher returns collation-aware matcher for string comparison.
+//
+// Options:
+//   - "caseSensitive": compare letter case, false by default
+//   - "ignoreDiacritics": ignore diacritics ("ö" == "o"), false by default
+//   - "locale": BCP 47 language tag of collation rules, root collation by default
+func textMatcher(args interface{}) (*search.Matcher, error) {
+	data := args.(map[string]interface{}) // type is already checked
+	mapObject := coreutils.MapObject(data)
+	options, _, err := mapObject.AsObject("options")
+	if err != nil {
+		return nil, err
+	}
+	caseSensitive, _, err := options.AsBoolean("caseSensitive")
+	if err != nil {
+		return nil, err
+	}
+	ignoreDiacritics, _, err := options.AsBoolean("ignoreDiacritics")
+	if err != nil {
+		return nil, err
+	}
+	locale, _, err := options.AsString("locale")
+	if err != nil {
+		return nil, err
+	}
+	tag := language.Und
+	if locale != "" {
+		if tag, err = language.Parse(locale); err != nil {
+			return nil, fmt.Errorf("field 'locale' must be a BCP 47 language tag: %w", err)
+		}
+	}
+	opts := []search.Option{}
+	if !caseSensitive {
+		opts = append(opts, search.IgnoreCase)
+	}
+	if ignoreDiacritics {
+		opts = append(opts, search.IgnoreDiacritics)
+	}
+	return search.New(tag, opts...), nil
+}
+
 func epsilon(args interface{}) (float64, error) {
 	data := args.(map[string]interface{}) // type is already checked by generalArgs()
 	mapObject := coreutils.MapObject(data)
@@ -184,6 +323,76 @@ func nearlyEqual(a, b, epsilon float64) bool {
 	return diff/(absA+absB) < epsilon
 }
 
+// Returns filter value for date, timestamp or UUID field in the same form as field value is rendered by coreutils.ReadByKind.
+//
+// Timestamps can be specified as RFC 3339 string in any time zone or as milliseconds number
+func canonicalValue(kind appdef.DataKind, value interface{}) (res string, err error) {
+	switch v := value.(type) {
+	case string:
+		switch kind {
+		case appdef.DataKind_date:
+			var d int32
+			if d, err = istructs.ParseDate(v); err == nil {
+				return istructs.DateToString(d), nil
+			}
+		case appdef.DataKind_timestamp:
+			var ts int64
+			if ts, err = istructs.ParseTimestamp(v); err == nil {
+				return istructs.TimestampToString(ts), nil
+			}
+		case appdef.DataKind_UUID:
+			var u []byte
+			if u, err = istructs.ParseUUID(v); err == nil {
+				return istructs.UUIDToString(u), nil
+			}
+		}
+	case float64:
+		if kind == appdef.DataKind_timestamp {
+			return istructs.TimestampToString(int64(v)), nil
+		}
+	}
+	return "", errors.Join(err, fmt.Errorf("value %v is not applicable to %s: %w", value, kind.TrimString(), ErrWrongType))
+}
+
+// Returns unscaled values of decimal field value, rendered by coreutils.ReadByKind, and of filter value, specified as number or string.
+//
+// Both values are scaled to the greatest scale of them, so they can be compared exactly
+func decimalValues(fieldValue string, value interface{}) (fv, v int64, err error) {
+	var s string
+	switch value := value.(type) {
+	case string:
+		s = value
+	case float64:
+		const fmtShortest, bitSize = -1, 64
+		s = strconv.FormatFloat(value, 'f', fmtShortest, bitSize)
+	default:
+		return 0, 0, fmt.Errorf("value %v is not applicable to %s: %w", value, appdef.DataKind_decimal.TrimString(), ErrWrongType)
+	}
+	scale := max(decimalScale(fieldValue), decimalScale(s))
+	if fv, err = istructs.ParseDecimal(fieldValue, scale); err == nil {
+		v, err = istructs.ParseDecimal(s, scale)
+	}
+	if err != nil {
+		return 0, 0, errors.Join(err, fmt.Errorf("value %v is not applicable to %s: %w", value, appdef.DataKind_decimal.TrimString(), ErrWrongType))
+	}
+	return fv, v, nil
+}
+
+// Returns count of significant fraction digits of decimal string
+func decimalScale(s string) uint8 {
+	_, frac, _ := strings.Cut(s, ".")
+	return uint8(min(len(strings.TrimRight(frac, "0")), math.MaxUint8))
+}
+
+// Compares decimal values rendered by coreutils.ReadByKind
+func compareDecimals(s1, s2 string) (int, error) {
+	v1, v2, err := decimalValues(s1, s2)
+	if err != nil {
+		return 0, err
+	}
+	return cmp.Compare(v1, v2), nil
+}
+
 //TODO (FILTER0002) dynamic prepare and validation?
 //type baseFilter struct {
 //	field       string
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"fmt"
+
+	"golang.org/x/text/search"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// ContainsFilter matches string fields which contain the value.
+//
+// Comparison is collation-aware, see textMatcher() for options
+type ContainsFilter struct {
+	field   string
+	value   string
+	pattern *search.Pattern
+}
+
+func (f ContainsFilter) IsMatch(fk FieldsKinds, outputRow IOutputRow) (bool, error) {
+	switch fk[f.field] {
+	case appdef.DataKind_string:
+		start, _ := f.pattern.IndexString(outputRow.Value(f.field).(string))
+		return start >= 0, nil
+	case appdef.DataKind_null:
+		return false, nil
+	default:
+		return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_Contains, f.field, ErrWrongType)
+	}
+}
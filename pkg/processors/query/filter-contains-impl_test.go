@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+func TestContainsFilter_IsMatch(t *testing.T) {
+	row := func(name string) IOutputRow {
+		r := &testOutputRow{fields: []string{"name"}}
+		r.Set("name", name)
+		return r
+	}
+	fk := FieldsKinds{"name": appdef.DataKind_string}
+	nameFilter := func(args map[string]interface{}) IFilter {
+		args["field"] = "name"
+		f, err := NewFilter(map[string]interface{}{"expr": filterKind_Contains, "args": args})
+		require.NoError(t, err)
+		return f
+	}
+	match := func(match bool, err error) bool {
+		require.NoError(t, err)
+		return match
+	}
+	t.Run("Should match case insensitive by default", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "COLA"})
+		require.True(t, match(f.IsMatch(fk, row("Coca-Cola zero"))))
+		require.True(t, match(f.IsMatch(fk, row("cola"))))
+		require.False(t, match(f.IsMatch(fk, row("Fanta"))))
+	})
+	t.Run("Should match case sensitive", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "Cola", "options": map[string]interface{}{"caseSensitive": true}})
+		require.True(t, match(f.IsMatch(fk, row("Coca-Cola"))))
+		require.False(t, match(f.IsMatch(fk, row("coca-cola"))))
+	})
+	t.Run("Should match UTF-8 text", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "ПИВО"})
+		require.True(t, match(f.IsMatch(fk, row("Светлое пиво"))))
+
+		f = nameFilter(map[string]interface{}{"value": "cafe", "options": map[string]interface{}{"ignoreDiacritics": true}})
+		require.True(t, match(f.IsMatch(fk, row("Le Café"))))
+
+		f = nameFilter(map[string]interface{}{"value": "cafe"})
+		require.False(t, match(f.IsMatch(fk, row("Le Café"))))
+	})
+	t.Run("Should return false on null data type", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "cola"})
+		require.False(t, match(f.IsMatch(nil, nil)))
+	})
+	t.Run("Should return error on wrong data type", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "cola"})
+
+		match, err := f.IsMatch(FieldsKinds{"name": appdef.DataKind_int32}, nil)
+
+		require.ErrorIs(t, err, ErrWrongType)
+		require.False(t, match)
+	})
+}
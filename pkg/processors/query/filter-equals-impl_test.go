@@ -139,6 +139,55 @@ func TestEqualsFilter_IsMatch(t *testing.T) {
 			require.False(t, match(activeFilter(true).IsMatch(fk, row(false))))
 		})
 	})
+	t.Run("Compare decimal", func(t *testing.T) {
+		row := func(price string) IOutputRow {
+			r := &testOutputRow{fields: []string{"price"}}
+			r.Set("price", price)
+			return r
+		}
+		fk := FieldsKinds{"price": appdef.DataKind_decimal}
+		priceFilter := func(price interface{}) IFilter {
+			return &EqualsFilter{
+				field: "price",
+				value: price,
+			}
+		}
+		t.Run("Should match", func(t *testing.T) {
+			require.True(t, match(priceFilter(12.34).IsMatch(fk, row("12.34"))))
+			require.True(t, match(priceFilter(12.3).IsMatch(fk, row("12.30"))))
+			require.True(t, match(priceFilter("12.340").IsMatch(fk, row("12.34"))))
+			require.True(t, match(priceFilter("1234567890123456.78").IsMatch(fk, row("1234567890123456.78"))))
+		})
+		t.Run("Should not match", func(t *testing.T) {
+			require.False(t, match(priceFilter(12.35).IsMatch(fk, row("12.34"))))
+			require.False(t, match(priceFilter(12.341).IsMatch(fk, row("12.34"))))
+			require.False(t, match(priceFilter("1234567890123456.77").IsMatch(fk, row("1234567890123456.78"))))
+		})
+		t.Run("Should return error on invalid value", func(t *testing.T) {
+			_, err := priceFilter("12,34").IsMatch(fk, row("12.34"))
+			require.ErrorIs(t, err, ErrWrongType)
+			_, err = priceFilter(true).IsMatch(fk, row("12.34"))
+			require.ErrorIs(t, err, ErrWrongType)
+		})
+	})
+	t.Run("Compare date", func(t *testing.T) {
+		row := func(date string) IOutputRow {
+			r := &testOutputRow{fields: []string{"birthday"}}
+			r.Set("birthday", date)
+			return r
+		}
+		fk := FieldsKinds{"birthday": appdef.DataKind_date}
+		t.Run("Should match", func(t *testing.T) {
+			require.True(t, match((&EqualsFilter{field: "birthday", value: "2024-01-31"}).IsMatch(fk, row("2024-01-31"))))
+		})
+		t.Run("Should not match", func(t *testing.T) {
+			require.False(t, match((&EqualsFilter{field: "birthday", value: "2024-02-01"}).IsMatch(fk, row("2024-01-31"))))
+		})
+		t.Run("Should return error on invalid value", func(t *testing.T) {
+			_, err := (&EqualsFilter{field: "birthday", value: "31.01.2024"}).IsMatch(fk, row("2024-01-31"))
+			require.ErrorIs(t, err, ErrWrongType)
+		})
+	})
 	t.Run("Should return false on null data type", func(t *testing.T) {
 		filter := &EqualsFilter{}
 
@@ -25,6 +25,18 @@ func (f GreaterFilter) IsMatch(fk FieldsKinds, outputRow IOutputRow) (bool, erro
 		return outputRow.Value(f.field).(float32) > float32(f.value.(float64)), nil
 	case appdef.DataKind_float64:
 		return outputRow.Value(f.field).(float64) > f.value.(float64), nil
+	case appdef.DataKind_decimal:
+		fv, v, err := decimalValues(outputRow.Value(f.field).(string), f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_Gt, f.field, err)
+		}
+		return fv > v, nil
+	case appdef.DataKind_date, appdef.DataKind_timestamp:
+		v, err := canonicalValue(fk[f.field], f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_Gt, f.field, err)
+		}
+		return outputRow.Value(f.field).(string) > v, nil
 	case appdef.DataKind_string:
 		return outputRow.Value(f.field).(string) > f.value.(string), nil
 	case appdef.DataKind_null:
@@ -116,6 +116,49 @@ func TestGreaterFilter_IsMatch(t *testing.T) {
 			require.False(t, match(nameFilter("Xenta").IsMatch(fk, row("Cola"))))
 		})
 	})
+	t.Run("Compare decimal", func(t *testing.T) {
+		row := func(price string) IOutputRow {
+			r := &testOutputRow{fields: []string{"price"}}
+			r.Set("price", price)
+			return r
+		}
+		fk := FieldsKinds{"price": appdef.DataKind_decimal}
+		priceFilter := func(price interface{}) IFilter {
+			return &GreaterFilter{
+				field: "price",
+				value: price,
+			}
+		}
+		t.Run("Should match", func(t *testing.T) {
+			require.True(t, match(priceFilter(9.99).IsMatch(fk, row("10.00"))))
+			require.True(t, match(priceFilter("1234567890123456.77").IsMatch(fk, row("1234567890123456.78"))))
+		})
+		t.Run("Should not match", func(t *testing.T) {
+			require.False(t, match(priceFilter(10.0).IsMatch(fk, row("10.00"))))
+			require.False(t, match(priceFilter("10.001").IsMatch(fk, row("10.00"))))
+		})
+	})
+	t.Run("Compare timestamp", func(t *testing.T) {
+		row := func(ts string) IOutputRow {
+			r := &testOutputRow{fields: []string{"created"}}
+			r.Set("created", ts)
+			return r
+		}
+		fk := FieldsKinds{"created": appdef.DataKind_timestamp}
+		createdFilter := func(ts interface{}) IFilter {
+			return &GreaterFilter{
+				field: "created",
+				value: ts,
+			}
+		}
+		t.Run("Should match", func(t *testing.T) {
+			require.True(t, match(createdFilter("2024-01-31T14:00:00+03:00").IsMatch(fk, row("2024-01-31T12:00:00.000Z"))))
+			require.True(t, match(createdFilter(float64(0)).IsMatch(fk, row("2024-01-31T12:00:00.000Z"))))
+		})
+		t.Run("Should not match", func(t *testing.T) {
+			require.False(t, match(createdFilter("2024-01-31T12:00:00Z").IsMatch(fk, row("2024-01-31T12:00:00.000Z"))))
+		})
+	})
 	t.Run("Should return false on null data type", func(t *testing.T) {
 		filter := &GreaterFilter{}
 
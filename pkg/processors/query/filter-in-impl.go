@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"fmt"
+
+	"golang.org/x/text/search"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// InFilter matches fields which are equal to one of the values.
+//
+// Strings are compared collation-aware, see textMatcher() for options
+type InFilter struct {
+	field    string
+	values   []interface{}
+	patterns []*search.Pattern // nil for not string values
+	epsilon  float64
+}
+
+func (f InFilter) IsMatch(fk FieldsKinds, outputRow IOutputRow) (bool, error) {
+	kind := fk[f.field]
+	if kind == appdef.DataKind_null {
+		return false, nil
+	}
+	for i, value := range f.values {
+		match, err := f.isEqual(kind, outputRow.Value(f.field), value, f.patterns[i])
+		if err != nil {
+			return false, err
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f InFilter) isEqual(kind appdef.DataKind, fieldValue, value interface{}, pattern *search.Pattern) (bool, error) {
+	wrongValue := func() (bool, error) {
+		return false, fmt.Errorf("'%s' filter: field %s: value %v: %w", filterKind_In, f.field, value, ErrWrongType)
+	}
+	switch kind {
+	case appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_float32, appdef.DataKind_float64, appdef.DataKind_RecordID:
+		v, ok := value.(float64)
+		if !ok {
+			return wrongValue()
+		}
+		switch kind {
+		case appdef.DataKind_int32:
+			return fieldValue.(int32) == int32(v), nil
+		case appdef.DataKind_int64:
+			return fieldValue.(int64) == int64(v), nil
+		case appdef.DataKind_float32:
+			return nearlyEqual(v, float64(fieldValue.(float32)), f.epsilon), nil
+		case appdef.DataKind_float64:
+			return nearlyEqual(v, fieldValue.(float64), f.epsilon), nil
+		}
+		return fieldValue.(istructs.RecordID) == istructs.RecordID(int64(v)), nil
+	case appdef.DataKind_string:
+		if pattern == nil {
+			return wrongValue()
+		}
+		s := fieldValue.(string)
+		_, end := pattern.IndexString(s, search.Anchor)
+		return end == len(s), nil
+	case appdef.DataKind_decimal:
+		fv, v, err := decimalValues(fieldValue.(string), value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_In, f.field, err)
+		}
+		return fv == v, nil
+	case appdef.DataKind_date, appdef.DataKind_timestamp, appdef.DataKind_UUID:
+		v, err := canonicalValue(kind, value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_In, f.field, err)
+		}
+		return fieldValue.(string) == v, nil
+	case appdef.DataKind_bool:
+		v, ok := value.(bool)
+		if !ok {
+			return wrongValue()
+		}
+		return fieldValue.(bool) == v, nil
+	default:
+		return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_In, f.field, ErrWrongType)
+	}
+}
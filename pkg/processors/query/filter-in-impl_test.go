@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestInFilter_IsMatch(t *testing.T) {
+	row := func(value interface{}) IOutputRow {
+		r := &testOutputRow{fields: []string{"fld"}}
+		r.Set("fld", value)
+		return r
+	}
+	inFilter := func(args map[string]interface{}) IFilter {
+		args["field"] = "fld"
+		f, err := NewFilter(map[string]interface{}{"expr": filterKind_In, "args": args})
+		require.NoError(t, err)
+		return f
+	}
+	match := func(match bool, err error) bool {
+		require.NoError(t, err)
+		return match
+	}
+	tests := []struct {
+		name     string
+		kind     appdef.DataKind
+		args     map[string]interface{}
+		match    interface{}
+		notMatch interface{}
+	}{
+		{"int32", appdef.DataKind_int32, map[string]interface{}{"values": []interface{}{1.0, 42.0}}, int32(42), int32(43)},
+		{"int64", appdef.DataKind_int64, map[string]interface{}{"values": []interface{}{1.0, 42.0}}, int64(1), int64(2)},
+		{"float32", appdef.DataKind_float32, map[string]interface{}{"values": []interface{}{42.7}, "options": map[string]interface{}{"epsilon": 0.0000001}}, float32(42.7), float32(42.71)},
+		{"float64", appdef.DataKind_float64, map[string]interface{}{"values": []interface{}{42.7}, "options": map[string]interface{}{"epsilon": 0.0000001}}, 42.7, 42.71},
+		{"RecordID", appdef.DataKind_RecordID, map[string]interface{}{"values": []interface{}{42.0}}, istructs.RecordID(42), istructs.RecordID(1)},
+		{"bool", appdef.DataKind_bool, map[string]interface{}{"values": []interface{}{true}}, true, false},
+		{"string", appdef.DataKind_string, map[string]interface{}{"values": []interface{}{"beer", "cola"}}, "Cola", "Coca-Cola"},
+		{"case sensitive string", appdef.DataKind_string, map[string]interface{}{"values": []interface{}{"Cola"}, "options": map[string]interface{}{"caseSensitive": true}}, "Cola", "cola"},
+		{"decimal", appdef.DataKind_decimal, map[string]interface{}{"values": []interface{}{12.34, "99.9"}}, "99.90", "12.35"},
+		{"date", appdef.DataKind_date, map[string]interface{}{"values": []interface{}{"2024-01-31"}}, "2024-01-31", "2024-02-01"},
+		{"timestamp", appdef.DataKind_timestamp, map[string]interface{}{"values": []interface{}{"2024-01-31T15:00:00+03:00"}}, "2024-01-31T12:00:00.000Z", "2024-01-31T15:00:00.000Z"},
+		{"UUID", appdef.DataKind_UUID, map[string]interface{}{"values": []interface{}{"F47AC10B-58CC-4372-A567-0E02B2C3D479"}}, "f47ac10b-58cc-4372-a567-0e02b2c3d479", "f47ac10b-58cc-4372-a567-0e02b2c3d470"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fk := FieldsKinds{"fld": test.kind}
+			f := inFilter(test.args)
+			require.True(t, match(f.IsMatch(fk, row(test.match))))
+			require.False(t, match(f.IsMatch(fk, row(test.notMatch))))
+		})
+	}
+	t.Run("Should not match empty values", func(t *testing.T) {
+		f := inFilter(map[string]interface{}{"values": []interface{}{}})
+		require.False(t, match(f.IsMatch(FieldsKinds{"fld": appdef.DataKind_int32}, row(int32(1)))))
+	})
+	t.Run("Should return false on null data type", func(t *testing.T) {
+		f := inFilter(map[string]interface{}{"values": []interface{}{1.0}})
+		require.False(t, match(f.IsMatch(nil, nil)))
+	})
+	t.Run("Should return error on wrong value type", func(t *testing.T) {
+		f := inFilter(map[string]interface{}{"values": []interface{}{"42"}})
+
+		match, err := f.IsMatch(FieldsKinds{"fld": appdef.DataKind_int32}, row(int32(42)))
+
+		require.ErrorIs(t, err, ErrWrongType)
+		require.False(t, match)
+	})
+	t.Run("Should return error on invalid date value", func(t *testing.T) {
+		f := inFilter(map[string]interface{}{"values": []interface{}{"31.01.2024"}})
+
+		match, err := f.IsMatch(FieldsKinds{"fld": appdef.DataKind_date}, row("2024-01-31"))
+
+		require.ErrorIs(t, err, ErrWrongType)
+		require.False(t, match)
+	})
+	t.Run("Should return error on wrong data type", func(t *testing.T) {
+		f := inFilter(map[string]interface{}{"values": []interface{}{"42"}})
+
+		match, err := f.IsMatch(FieldsKinds{"fld": appdef.DataKind_bytes}, row([]byte("42")))
+
+		require.ErrorIs(t, err, ErrWrongType)
+		require.False(t, match)
+	})
+}
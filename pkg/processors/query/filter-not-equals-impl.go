@@ -26,6 +26,18 @@ func (f NotEqualsFilter) IsMatch(fk FieldsKinds, outputRow IOutputRow) (bool, er
 		return !nearlyEqual(f.value.(float64), float64(outputRow.Value(f.field).(float32)), f.epsilon), nil
 	case appdef.DataKind_float64:
 		return !nearlyEqual(f.value.(float64), outputRow.Value(f.field).(float64), f.epsilon), nil
+	case appdef.DataKind_decimal:
+		fv, v, err := decimalValues(outputRow.Value(f.field).(string), f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_NotEq, f.field, err)
+		}
+		return fv != v, nil
+	case appdef.DataKind_date, appdef.DataKind_timestamp, appdef.DataKind_UUID:
+		v, err := canonicalValue(fk[f.field], f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_NotEq, f.field, err)
+		}
+		return outputRow.Value(f.field).(string) != v, nil
 	case appdef.DataKind_string:
 		return outputRow.Value(f.field).(string) != f.value.(string), nil
 	case appdef.DataKind_bool:
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"fmt"
+
+	"golang.org/x/text/search"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// StartsWithFilter matches string fields which start with the value.
+//
+// Comparison is collation-aware, see textMatcher() for options
+type StartsWithFilter struct {
+	field   string
+	value   string
+	pattern *search.Pattern
+}
+
+func (f StartsWithFilter) IsMatch(fk FieldsKinds, outputRow IOutputRow) (bool, error) {
+	switch fk[f.field] {
+	case appdef.DataKind_string:
+		start, _ := f.pattern.IndexString(outputRow.Value(f.field).(string), search.Anchor)
+		return start == 0, nil
+	case appdef.DataKind_null:
+		return false, nil
+	default:
+		return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_StartsWith, f.field, ErrWrongType)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+func TestStartsWithFilter_IsMatch(t *testing.T) {
+	row := func(name string) IOutputRow {
+		r := &testOutputRow{fields: []string{"name"}}
+		r.Set("name", name)
+		return r
+	}
+	fk := FieldsKinds{"name": appdef.DataKind_string}
+	nameFilter := func(args map[string]interface{}) IFilter {
+		args["field"] = "name"
+		f, err := NewFilter(map[string]interface{}{"expr": filterKind_StartsWith, "args": args})
+		require.NoError(t, err)
+		return f
+	}
+	match := func(match bool, err error) bool {
+		require.NoError(t, err)
+		return match
+	}
+	t.Run("Should match case insensitive by default", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "coca"})
+		require.True(t, match(f.IsMatch(fk, row("Coca-Cola"))))
+		require.False(t, match(f.IsMatch(fk, row("Zero Coca-Cola"))))
+	})
+	t.Run("Should match case sensitive", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "Coca", "options": map[string]interface{}{"caseSensitive": true}})
+		require.True(t, match(f.IsMatch(fk, row("Coca-Cola"))))
+		require.False(t, match(f.IsMatch(fk, row("coca-cola"))))
+	})
+	t.Run("Should match UTF-8 text", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "öl", "options": map[string]interface{}{"locale": "de"}})
+		require.True(t, match(f.IsMatch(fk, row("Öl extra"))))
+		require.False(t, match(f.IsMatch(fk, row("Ol extra"))))
+	})
+	t.Run("Should return false on null data type", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "coca"})
+		require.False(t, match(f.IsMatch(nil, nil)))
+	})
+	t.Run("Should return error on wrong data type", func(t *testing.T) {
+		f := nameFilter(map[string]interface{}{"value": "coca"})
+
+		match, err := f.IsMatch(FieldsKinds{"name": appdef.DataKind_bool}, nil)
+
+		require.ErrorIs(t, err, ErrWrongType)
+		require.False(t, match)
+	})
+}
@@ -71,10 +71,20 @@ func implRowsProcessorFactory(ctx context.Context, appDef appdef.IAppDef, state
 				metrics:    metrics,
 			}))
 		}
-		if len(params.OrderBy()) != 0 {
-			operators = append(operators, pipeline.WireAsyncOperator("Order", newOrderOperator(params.OrderBy(), metrics)))
+		orderBy := params.OrderBy()
+		if params.Pager() != nil {
+			orderBy = params.Pager().OrderBy()
 		}
-		if params.StartFrom() != 0 || params.Count() != 0 {
+		if len(orderBy) != 0 {
+			operators = append(operators, pipeline.WireAsyncOperator("Order", newOrderOperator(orderBy, rootFields, metrics)))
+		}
+		if params.Pager() != nil {
+			operators = append(operators, pipeline.WireAsyncOperator("Pager", newPagerOperator(
+				params.Pager(),
+				rootFields,
+				params.Count(),
+				metrics)))
+		} else if params.StartFrom() != 0 || params.Count() != 0 {
 			operators = append(operators, pipeline.WireAsyncOperator("Counter", newCounterOperator(
 				params.StartFrom(),
 				params.Count(),
@@ -220,6 +230,13 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 			}
 			return nil
 		}),
+		operator("check limits", func(ctx context.Context, qw *queryWork) (err error) {
+			scopes := processors.LimitScopes(qw.msg.Partition(), qw.msg.WSID(), qw.principals, qw.msg.Host())
+			if qw.appStructs.IsLimitExceeded(qw.msg.Query().QName(), scopes) {
+				return coreutils.NewSysError(http.StatusTooManyRequests)
+			}
+			return nil
+		}),
 		operator("unmarshal request", func(ctx context.Context, qw *queryWork) (err error) {
 			parsType := qw.msg.Query().Param()
 			if parsType != nil && parsType.QName() == istructs.QNameRaw {
@@ -243,11 +260,18 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 				state.SimpleWSIDFunc(qw.msg.WSID()),
 				qw.secretReader,
 				func() []iauthnz.Principal { return qw.principals },
-				func() string { return qw.msg.Token() })
+				func() string { return qw.msg.Token() },
+				func() istructs.IObject { return qw.execQueryArgs.ArgumentObject },
+				func() istructs.IObjectBuilder { return qw.appStructs.ObjectBuilder(qw.resultType.QName()) },
+				func() istructs.ExecQueryCallback { return qw.callbackFunc })
 			qw.execQueryArgs.State = qw.state
 			return
 		}),
 		operator("get queryFunc", func(ctx context.Context, qw *queryWork) (err error) {
+			if qw.msg.Query().Engine() == appdef.ExtensionEngineKind_WASM {
+				// WASM query is invoked by extension engine of borrowed application partition
+				return nil
+			}
 			qw.queryFunc = qw.appStructs.Resources().QueryResource(qw.msg.Query().QName()).(istructs.IQueryFunction)
 			return nil
 		}),
@@ -257,6 +281,9 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 				return nil
 			}
 			if qw.resultType.QName() == appdef.QNameANY {
+				if qw.queryFunc == nil {
+					return coreutils.NewHTTPErrorf(http.StatusBadRequest, "result type of WASM query ", qw.msg.Query().QName(), " must be declared")
+				}
 				qNameResultType := qw.queryFunc.ResultType(qw.execQueryArgs.PrepareArgs)
 				qw.resultType = qw.appStructs.AppDef().Type(qNameResultType)
 			}
@@ -266,7 +293,8 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 			return coreutils.WrapSysError(err, http.StatusBadRequest)
 		}),
 		operator("validate: get query params", func(ctx context.Context, qw *queryWork) (err error) {
-			qw.queryParams, err = newQueryParams(qw.requestData, NewElement, NewFilter, NewOrderBy, newFieldsKinds(qw.resultType))
+			qw.queryParams, err = newQueryParams(qw.requestData, NewElement, NewFilter, NewOrderBy,
+				newPagerFactory(qw.appStructs.AppTokens(), qw.msg.Query().QName(), qw.msg.WSID()), newFieldsKinds(qw.resultType))
 			return coreutils.WrapSysError(err, http.StatusBadRequest)
 		}),
 		operator("authorize result", func(ctx context.Context, qw *queryWork) (err error) {
@@ -301,7 +329,20 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 			return nil
 		}),
 		operator("get func exec", func(ctx context.Context, qw *queryWork) (err error) {
-			qw.queryExec = qw.appStructs.Resources().QueryResource(qw.msg.Query().QName()).(istructs.IQueryFunction).Exec
+			if qw.queryFunc == nil {
+				qw.queryExec = qw.invokeWASM
+				return nil
+			}
+			qw.queryExec = qw.queryFunc.Exec
+			// rows paged by sys.ID and not filtered can be read by the function from the cursor position
+			if pager := qw.queryParams.Pager(); pager != nil && len(qw.queryParams.Filters()) == 0 {
+				if afterID, ok := pager.AfterID(); ok {
+					qw.execQueryArgs.Page = &istructs.QueryPage{
+						AfterID: afterID,
+						Limit:   int(qw.queryParams.Count()),
+					}
+				}
+			}
 			return nil
 		}),
 	}
@@ -315,7 +356,7 @@ type queryWork struct {
 	appParts appparts.IAppPartitions
 	// work
 	requestData       map[string]interface{}
-	state             istructs.IState
+	state             state.IHostState
 	queryParams       IQueryParams
 	appPart           appparts.IAppPartition
 	appStructs        istructs.IAppStructs
@@ -329,6 +370,7 @@ type queryWork struct {
 	secretReader      isecrets.ISecretReader
 	queryFunc         istructs.IQueryFunction
 	queryExec         func(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) error
+	callbackFunc      istructs.ExecQueryCallback
 }
 
 func newQueryWork(msg IQueryMessage, rs IResultSenderClosable, appParts appparts.IAppPartitions,
@@ -358,6 +400,16 @@ func (qw *queryWork) borrow() (err error) {
 	return nil
 }
 
+// Invokes WASM query by extension engine of borrowed application partition.
+// Result objects are created by query as values of Result storage and streamed to callback, the last one is sent when intents are applied
+func (qw *queryWork) invokeWASM(ctx context.Context, _ istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) error {
+	qw.callbackFunc = callback
+	if err := qw.appPart.Invoke(ctx, qw.msg.Query().QName(), qw.state); err != nil {
+		return err
+	}
+	return qw.state.ApplyIntents()
+}
+
 // releases borrowed app partition
 func (qw *queryWork) release() {
 	if ap := qw.appPart; ap != nil {
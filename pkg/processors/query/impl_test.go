@@ -20,6 +20,7 @@ import (
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/iauthnzimpl"
+	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/iprocbus"
 	"github.com/voedger/voedger/pkg/iratesce"
 	"github.com/voedger/voedger/pkg/istorage/mem"
@@ -292,7 +293,7 @@ func TestBasicUsage_ServiceFactory(t *testing.T) {
 	appParts, cleanAppParts, err := appparts.New(appStructsProvider)
 	require.NoError(err)
 	defer cleanAppParts()
-	appParts.DeployApp(appName, appDef, appPartsCount, appEngines)
+	require.NoError(appParts.DeployApp(appName, nil, appDef, appPartsCount, appEngines))
 	appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
 
 	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter)
@@ -1041,7 +1042,7 @@ func TestRateLimiter(t *testing.T) {
 	appParts, cleanAppParts, err := appparts.New(appStructsProvider)
 	require.NoError(err)
 	defer cleanAppParts()
-	appParts.DeployApp(appName, appDef, appPartsCount, appEngines)
+	require.NoError(appParts.DeployApp(appName, nil, appDef, appPartsCount, appEngines))
 	appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
 
 	// create aquery processor
@@ -1075,6 +1076,79 @@ func TestRateLimiter(t *testing.T) {
 	require.Error(<-errs)
 }
 
+func TestLimits(t *testing.T) {
+	require := require.New(t)
+	errs := make(chan error)
+	serviceChannel := make(iprocbus.ServiceChannel)
+	rs := testResultSenderClosable{
+		startArraySection: func(sectionType string, path []string) {},
+		sendElement:       func(name string, element interface{}) (err error) { return nil },
+		close: func(err error) {
+			errs <- err
+		},
+	}
+
+	qNameMyQryParams := appdef.NewQName("test", "myQryParams")
+	qNameMyQryResults := appdef.NewQName("test", "myQryResults")
+	qName := appdef.NewQName("test", "myQry")
+	rateQName := appdef.NewQName("test", "rate")
+	appDef, appStructsProvider, appTokens := getTestCfg(require,
+		func(appDef appdef.IAppDefBuilder) {
+			appDef.AddObject(qNameMyQryParams)
+			appDef.AddObject(qNameMyQryResults).
+				AddField("fld", appdef.DataKind_string, false)
+			appDef.AddQuery(qName).SetParam(qNameMyQryParams).SetResult(qNameMyQryResults)
+			appDef.AddRate(rateQName, 2, time.Minute, appdef.RateScope_Workspace)
+			appDef.AddLimit(appdef.NewQName("test", "qryLimit"), []appdef.QName{qName}, rateQName)
+		},
+		func(cfg *istructsmem.AppConfigType) {
+			cfg.Resources.Add(istructsmem.NewQueryFunction(qName, istructsmem.NullQueryExec))
+		})
+
+	appParts, cleanAppParts, err := appparts.New(appStructsProvider)
+	require.NoError(err)
+	defer cleanAppParts()
+	require.NoError(appParts.DeployApp(appName, nil, appDef, appPartsCount, appEngines))
+	appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
+
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter)
+	authz := iauthnzimpl.NewDefaultAuthorizer()
+	queryProcessor := ProvideServiceFactory()(
+		serviceChannel,
+		func(ctx context.Context, sender ibus.ISender) IResultSenderClosable { return rs },
+		appParts,
+		3, // max concurrent queries
+		imetrics.Provide(), "vvm", authn, authz)
+	go queryProcessor.Run(context.Background())
+
+	body := []byte(`{
+		"args":{},
+		"elements":[{"path":"","fields":["fld"]}]
+	}`)
+	query := appDef.Query(qName)
+	status := func(token string) int {
+		serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, nil, body, query, "127.0.0.1", token)
+		err := <-errs
+		if err == nil {
+			return http.StatusOK
+		}
+		var se coreutils.SysError
+		require.ErrorAs(err, &se)
+		return se.HTTPStatus
+	}
+
+	// forbidden queries must not be counted by the limit
+	for i := 0; i < 3; i++ {
+		require.Equal(http.StatusForbidden, status(""))
+	}
+
+	systemToken := getSystemToken(appTokens)
+	for i := 0; i < 2; i++ {
+		require.Equal(http.StatusOK, status(systemToken))
+	}
+	require.Equal(http.StatusTooManyRequests, status(systemToken))
+}
+
 func TestAuthnz(t *testing.T) {
 	require := require.New(t)
 	errs := make(chan error)
@@ -1099,7 +1173,7 @@ func TestAuthnz(t *testing.T) {
 	require.NoError(err)
 	defer cleanAppParts()
 
-	appParts.DeployApp(appName, appDef, appPartsCount, appEngines)
+	require.NoError(appParts.DeployApp(appName, nil, appDef, appPartsCount, appEngines))
 	appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
 
 	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter)
@@ -1190,6 +1264,100 @@ func (w testWorkpiece) Release() {
 	}
 }
 
+// Extension engine, which WASM query creates result objects by Result storage
+type testWASMEngine struct {
+	mx      sync.Mutex
+	invoked []iextengine.ExtQName
+	results []string
+}
+
+func (e *testWASMEngine) SetLimits(iextengine.ExtensionLimits) {}
+
+func (e *testWASMEngine) Invoke(_ context.Context, extName iextengine.ExtQName, io iextengine.IExtensionIO) error {
+	e.mx.Lock()
+	e.invoked = append(e.invoked, extName)
+	e.mx.Unlock()
+	for _, name := range e.results {
+		kb, err := io.KeyBuilder(state.Result, appdef.NullQName)
+		if err != nil {
+			return err
+		}
+		vb, err := io.NewValue(kb)
+		if err != nil {
+			return err
+		}
+		vb.PutString("name", name)
+	}
+	return nil
+}
+
+func (e *testWASMEngine) Close(context.Context) {}
+
+type testWASMEngineFactory struct {
+	engine *testWASMEngine
+}
+
+func (f testWASMEngineFactory) New(_ context.Context, _ []iextengine.ExtensionPackage, _ *iextengine.ExtEngineConfig, numEngines int) ([]iextengine.IExtensionEngine, error) {
+	ee := make([]iextengine.IExtensionEngine, numEngines)
+	for i := range ee {
+		ee[i] = f.engine
+	}
+	return ee, nil
+}
+
+func TestWASMQuery(t *testing.T) {
+	require := require.New(t)
+
+	qNameWASMQuery := appdef.NewQName("bo", "WASMQuery")
+	qNameWASMResult := appdef.NewQName("bo", "WASMResult")
+	appDef, appStructsProvider, appTokens := getTestCfg(require, func(adb appdef.IAppDefBuilder) {
+		adb.AddObject(qNameWASMResult).AddField("name", appdef.DataKind_string, true)
+		adb.AddQuery(qNameWASMQuery).SetResult(qNameWASMResult).SetEngine(appdef.ExtensionEngineKind_WASM)
+	})
+
+	engine := &testWASMEngine{results: []string{"Cola", "Cake", "Amaretto"}}
+	appParts, cleanAppParts, err := appparts.NewWithExtEngines(context.Background(), appStructsProvider,
+		iextengine.IExtensionEngineFactories{appdef.ExtensionEngineKind_WASM: testWASMEngineFactory{engine}}, nil)
+	require.NoError(err)
+	defer cleanAppParts()
+	require.NoError(appParts.DeployApp(appName, nil, appDef, appPartsCount, appEngines))
+	appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
+
+	results := []string{}
+	done := make(chan error)
+	serviceChannel := make(iprocbus.ServiceChannel)
+	rs := testResultSenderClosable{
+		startArraySection: func(sectionType string, path []string) {},
+		sendElement: func(name string, element interface{}) (err error) {
+			bb, err := json.Marshal(element)
+			require.NoError(err)
+			results = append(results, string(bb))
+			return nil
+		},
+		close: func(err error) { done <- err },
+	}
+
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter)
+	authz := iauthnzimpl.NewDefaultAuthorizer()
+	queryProcessor := ProvideServiceFactory()(
+		serviceChannel,
+		func(ctx context.Context, sender ibus.ISender) IResultSenderClosable { return rs },
+		appParts,
+		3, // max concurrent queries
+		imetrics.Provide(), "vvm", authn, authz)
+	processorCtx, processorCtxCancel := context.WithCancel(context.Background())
+	defer processorCtxCancel()
+	go queryProcessor.Run(processorCtx)
+
+	body := []byte(`{"args":{},"elements":[{"path":"","fields":["name"]}]}`)
+	query := appDef.Query(qNameWASMQuery)
+	serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, nil, body, query, "127.0.0.1", getSystemToken(appTokens))
+	require.NoError(<-done)
+
+	require.Equal([]iextengine.ExtQName{iextengine.NewExtQName("bo", "WASMQuery")}, engine.invoked)
+	require.Equal([]string{`[[["Cola"]]]`, `[[["Cake"]]]`, `[[["Amaretto"]]]`}, results)
+}
+
 type testResultSenderClosable struct {
 	startArraySection func(sectionType string, path []string)
 	objectSection     func(sectionType string, path []string, element interface{}) (err error)
@@ -15,5 +15,6 @@ const (
 	execFilterSeconds = "voedger_qp_exec_filter_seconds"
 	execOrderSeconds  = "voedger_qp_exec_order_seconds"
 	execCountSeconds  = "voedger_qp_exec_count_seconds"
+	execPagerSeconds  = "voedger_qp_exec_pager_seconds"
 	execSendSeconds   = "voedger_qp_exec_send_seconds"
 )
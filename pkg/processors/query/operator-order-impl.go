@@ -10,19 +10,23 @@ import (
 	"sort"
 	"time"
 
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/pipeline"
 )
 
 type OrderOperator struct {
 	pipeline.AsyncNOOP
 	orderBys []IOrderBy
+	fields   FieldsKinds // root fields kinds, decimals are rendered as strings and must be compared as numbers
 	rows     []IOutputRow
 	metrics  IMetrics
 }
 
-func newOrderOperator(orderBys []IOrderBy, metrics IMetrics) pipeline.IAsyncOperator {
+func newOrderOperator(orderBys []IOrderBy, fields FieldsKinds, metrics IMetrics) pipeline.IAsyncOperator {
 	return &OrderOperator{
 		orderBys: orderBys,
+		fields:   fields,
 		rows:     make([]IOutputRow, 0),
 		metrics:  metrics,
 	}
@@ -43,13 +47,25 @@ func (o OrderOperator) Flush(callback pipeline.OpFuncFlush) (err error) {
 	defer func() {
 		o.metrics.Increase(execOrderSeconds, time.Since(begin).Seconds())
 	}()
-	sort.Slice(o.rows, func(i, j int) bool {
+	// stable sort keeps the order of the query function for equal rows, e.g. clustering order of a view
+	sort.SliceStable(o.rows, func(i, j int) bool {
 		for _, orderBy := range o.orderBys {
 			o1 := o.value(i, orderBy.Field())
 			o2 := o.value(j, orderBy.Field())
 			if o1 == o2 {
 				continue
 			}
+			if o.fields[orderBy.Field()] == appdef.DataKind_decimal {
+				c, e := compareDecimals(o1.(string), o2.(string))
+				if e != nil {
+					err = fmt.Errorf("order by '%s' is impossible: %w", orderBy.Field(), e)
+					return false
+				}
+				if orderBy.IsDesc() {
+					return c > 0
+				}
+				return c < 0
+			}
 			switch o1.(type) {
 			case int32:
 				return compareInt32(o1.(int32), o2.(int32), orderBy.IsDesc())
@@ -61,6 +77,8 @@ func (o OrderOperator) Flush(callback pipeline.OpFuncFlush) (err error) {
 				return compareFloat64(o1.(float64), o2.(float64), orderBy.IsDesc())
 			case string:
 				return compareString(o1.(string), o2.(string), orderBy.IsDesc())
+			case istructs.RecordID:
+				return compareRecordID(o1.(istructs.RecordID), o2.(istructs.RecordID), orderBy.IsDesc())
 			default:
 				err = fmt.Errorf("order by '%s' is impossible: %w", orderBy.Field(), ErrWrongType)
 			}
@@ -113,3 +131,10 @@ func compareString(o1, o2 string, desc bool) bool {
 	}
 	return o1 < o2
 }
+
+func compareRecordID(o1, o2 istructs.RecordID, desc bool) bool {
+	if desc {
+		return o1 > o2
+	}
+	return o1 < o2
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/pipeline"
 )
 
@@ -52,7 +54,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "id",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -77,7 +79,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "id",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -102,7 +104,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -127,7 +129,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -152,7 +154,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "weight",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -177,7 +179,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "weight",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Cola", 100, 1.15))
@@ -206,7 +208,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Xenta", 100, 1.45))
@@ -238,7 +240,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Xenta", 100, 1.45))
@@ -270,7 +272,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  true,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Xenta", 100, 1.45))
@@ -302,7 +304,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				field: "name",
 				desc:  false,
 			}}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 		works := make([]pipeline.IWorkpiece, 0)
 
 		_, _ = operator.DoAsync(context.Background(), work(1, "Xenta", 100, 1.45))
@@ -346,7 +348,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 				desc:  false,
 			},
 		}
-		operator := newOrderOperator(orders, &testMetrics{})
+		operator := newOrderOperator(orders, nil, &testMetrics{})
 
 		_, _ = operator.DoAsync(context.Background(), work(true))
 		_, _ = operator.DoAsync(context.Background(), work(false))
@@ -385,7 +387,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 					field: "x",
 					desc:  false,
 				}}
-			operator := newOrderOperator(orders, &testMetrics{})
+			operator := newOrderOperator(orders, nil, &testMetrics{})
 			works := make([]pipeline.IWorkpiece, 0)
 
 			_, _ = operator.DoAsync(context.Background(), work(0, 1))
@@ -407,7 +409,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 					field: "x",
 					desc:  true,
 				}}
-			operator := newOrderOperator(orders, &testMetrics{})
+			operator := newOrderOperator(orders, nil, &testMetrics{})
 			works := make([]pipeline.IWorkpiece, 0)
 
 			_, _ = operator.DoAsync(context.Background(), work(0, 1))
@@ -445,7 +447,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 					field: "x",
 					desc:  false,
 				}}
-			operator := newOrderOperator(orders, &testMetrics{})
+			operator := newOrderOperator(orders, nil, &testMetrics{})
 			works := make([]pipeline.IWorkpiece, 0)
 
 			_, _ = operator.DoAsync(context.Background(), work(22.5))
@@ -467,7 +469,7 @@ func TestOrderOperator_Flush(t *testing.T) {
 					field: "x",
 					desc:  true,
 				}}
-			operator := newOrderOperator(orders, &testMetrics{})
+			operator := newOrderOperator(orders, nil, &testMetrics{})
 			works := make([]pipeline.IWorkpiece, 0)
 
 			_, _ = operator.DoAsync(context.Background(), work(22.5))
@@ -483,6 +485,44 @@ func TestOrderOperator_Flush(t *testing.T) {
 			require.Equal(float32(-7.2), temperature(works[2]))
 		})
 	})
+	t.Run("Should order by decimal field as numbers", func(t *testing.T) {
+		work := func(price string) pipeline.IWorkpiece {
+			return rowsWorkpiece{outputRow: &outputRow{
+				keyToIdx: map[string]int{rootDocument: 0},
+				values: []interface{}{
+					[]IOutputRow{&outputRow{
+						keyToIdx: map[string]int{"price": 0},
+						values:   []interface{}{price},
+					}},
+				},
+			}}
+		}
+		price := func(work pipeline.IWorkpiece) string {
+			return work.(rowsWorkpiece).OutputRow().Value(rootDocument).([]IOutputRow)[0].Values()[0].(string)
+		}
+		fields := FieldsKinds{"price": appdef.DataKind_decimal}
+		for _, desc := range []bool{false, true} {
+			require := require.New(t)
+			operator := newOrderOperator([]IOrderBy{orderBy{field: "price", desc: desc}}, fields, &testMetrics{})
+			works := make([]pipeline.IWorkpiece, 0)
+
+			_, _ = operator.DoAsync(context.Background(), work("10.00"))
+			_, _ = operator.DoAsync(context.Background(), work("-2.50"))
+			_, _ = operator.DoAsync(context.Background(), work("9.99"))
+
+			require.NoError(operator.Flush(func(work pipeline.IWorkpiece) {
+				works = append(works, work)
+			}))
+
+			expected := []string{"-2.50", "9.99", "10.00"}
+			if desc {
+				expected = []string{"10.00", "9.99", "-2.50"}
+			}
+			for i, w := range works {
+				require.Equal(expected[i], price(w))
+			}
+		}
+	})
 }
 
 func TestOrderOperator_DoAsync(t *testing.T) {
@@ -495,7 +535,7 @@ func TestOrderOperator_DoAsync(t *testing.T) {
 			release = true
 		},
 	}
-	operator := newOrderOperator(nil, &testMetrics{})
+	operator := newOrderOperator(nil, nil, &testMetrics{})
 
 	_, _ = operator.DoAsync(context.Background(), work)
 
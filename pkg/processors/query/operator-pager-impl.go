@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/pipeline"
+)
+
+// PagerOperator passes the page of rows that follows the cursor position.
+// Rows are expected to be ordered by the pager keyset, which is unique.
+// If there are more rows after the page then the continuation token is sent on flush
+type PagerOperator struct {
+	pipeline.AsyncNOOP
+	pager     IPager
+	fields    FieldsKinds // root fields kinds, decimals are rendered as strings and must be compared as numbers
+	count     int64
+	after     []string      // JSON encoded keys of the last row of the previous page
+	afterKeys []interface{} // after keys decoded to types of row values
+	sent      int64
+	last      []interface{}
+	hasMore   bool
+	metrics   IMetrics
+}
+
+func newPagerOperator(pager IPager, fields FieldsKinds, count int64, metrics IMetrics) pipeline.IAsyncOperator {
+	after := pager.After()
+	return &PagerOperator{
+		pager:     pager,
+		fields:    fields,
+		count:     count,
+		after:     after,
+		afterKeys: make([]interface{}, len(after)),
+		metrics:   metrics,
+	}
+}
+
+func (o *PagerOperator) DoAsync(_ context.Context, work pipeline.IWorkpiece) (outWork pipeline.IWorkpiece, err error) {
+	begin := time.Now()
+	defer func() {
+		o.metrics.Increase(execPagerSeconds, time.Since(begin).Seconds())
+		if outWork == nil {
+			work.Release()
+		}
+	}()
+	if o.hasMore {
+		return nil, nil
+	}
+	keys := o.keys(work.(IWorkpiece).OutputRow())
+	if o.after != nil {
+		c, err := o.compareAfter(keys)
+		if err != nil {
+			return nil, err
+		}
+		if c <= 0 {
+			return nil, nil
+		}
+	}
+	if o.sent == o.count {
+		o.hasMore = true
+		return nil, nil
+	}
+	o.last = keys
+	o.sent++
+	return work, nil
+}
+
+func (o *PagerOperator) Flush(callback pipeline.OpFuncFlush) (err error) {
+	if !o.hasMore {
+		return nil
+	}
+	token, err := o.pager.Next(o.last)
+	if err != nil {
+		return err
+	}
+	callback(cursorWorkpiece{token: token})
+	return nil
+}
+
+func (o *PagerOperator) keys(row IOutputRow) []interface{} {
+	keys := make([]interface{}, len(o.pager.OrderBy()))
+	root := row.Value(rootDocument).([]IOutputRow)[0]
+	for i, orderBy := range o.pager.OrderBy() {
+		keys[i] = root.Value(orderBy.Field())
+	}
+	return keys
+}
+
+// compareAfter compares row keys with the after keys decoding them to the types of the row values
+func (o *PagerOperator) compareAfter(keys []interface{}) (int, error) {
+	for i, key := range keys {
+		if o.afterKeys[i] != nil || key == nil || o.after[i] == "null" {
+			continue
+		}
+		v := reflect.New(reflect.TypeOf(key))
+		if err := json.Unmarshal([]byte(o.after[i]), v.Interface()); err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+		}
+		o.afterKeys[i] = v.Elem().Interface()
+	}
+	return o.compare(keys, o.afterKeys)
+}
+
+// compare returns -1, 0 or 1 if row with keys1 goes before, together or after the row with keys2 in the keyset order
+func (o *PagerOperator) compare(keys1, keys2 []interface{}) (int, error) {
+	for i, orderBy := range o.pager.OrderBy() {
+		c, err := compareKeys(o.fields[orderBy.Field()], keys1[i], keys2[i])
+		if err != nil {
+			return 0, fmt.Errorf("order by '%s' is impossible: %w", orderBy.Field(), err)
+		}
+		if orderBy.IsDesc() {
+			c = -c
+		}
+		if c != 0 {
+			return c, nil
+		}
+	}
+	return 0, nil
+}
+
+// compareKeys compares values of the same type. Nil is less than any other value
+func compareKeys(kind appdef.DataKind, k1, k2 interface{}) (int, error) {
+	switch {
+	case k1 == nil && k2 == nil:
+		return 0, nil
+	case k1 == nil:
+		return -1, nil
+	case k2 == nil:
+		return 1, nil
+	}
+	ok := true
+	c := 0
+	switch v1 := k1.(type) {
+	case int32:
+		var v2 int32
+		v2, ok = k2.(int32)
+		c = cmp.Compare(v1, v2)
+	case int64:
+		var v2 int64
+		v2, ok = k2.(int64)
+		c = cmp.Compare(v1, v2)
+	case float32:
+		var v2 float32
+		v2, ok = k2.(float32)
+		c = cmp.Compare(v1, v2)
+	case float64:
+		var v2 float64
+		v2, ok = k2.(float64)
+		c = cmp.Compare(v1, v2)
+	case string:
+		var v2 string
+		if v2, ok = k2.(string); ok {
+			if kind == appdef.DataKind_decimal {
+				return compareDecimals(v1, v2)
+			}
+			c = cmp.Compare(v1, v2)
+		}
+	case istructs.RecordID:
+		var v2 istructs.RecordID
+		v2, ok = k2.(istructs.RecordID)
+		c = cmp.Compare(v1, v2)
+	default:
+		ok = false
+	}
+	if !ok {
+		return 0, ErrWrongType
+	}
+	return c, nil
+}
+
+type cursorWorkpiece struct {
+	pipeline.IWorkpiece
+	token string
+}
+
+// Values returns the continuation token as the single field of the root element row
+func (w cursorWorkpiece) Values() []interface{} {
+	return []interface{}{[]interface{}{[]interface{}{w.token}}}
+}
+
+func (w cursorWorkpiece) Release() {}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/pipeline"
+)
+
+func TestPagerOperator(t *testing.T) {
+	appTokens := payloads.ProvideIAppTokensFactory(itokensjwt.TestTokensJWT()).New(istructs.AppQName_test1_app1)
+	query := appdef.NewQName("test", "query")
+	pagerFactory := newPagerFactory(appTokens, query, istructs.WSID(1))
+
+	type product struct {
+		id    istructs.RecordID
+		name  string
+		price int32
+	}
+	work := func(p product) pipeline.IWorkpiece {
+		return rowsWorkpiece{
+			outputRow: &outputRow{
+				keyToIdx: map[string]int{rootDocument: 0},
+				values: []interface{}{
+					[]IOutputRow{
+						&outputRow{
+							keyToIdx: map[string]int{appdef.SystemField_ID: 0, "name": 1, "price": 2},
+							values:   []interface{}{p.id, p.name, p.price},
+						},
+					},
+				},
+			},
+		}
+	}
+	// page returns IDs of the page rows and the continuation token
+	page := func(t *testing.T, keyset []IOrderBy, after string, count int64, products ...product) (ids []istructs.RecordID, token string) {
+		require := require.New(t)
+		pager, err := pagerFactory(after, keyset)
+		require.NoError(err)
+		operator := newPagerOperator(pager, nil, count, &testMetrics{})
+		for _, p := range products {
+			outWork, err := operator.DoAsync(context.Background(), work(p))
+			require.NoError(err)
+			if outWork != nil {
+				ids = append(ids, outWork.(IWorkpiece).OutputRow().Value(rootDocument).([]IOutputRow)[0].Value(appdef.SystemField_ID).(istructs.RecordID))
+			}
+		}
+		require.NoError(operator.Flush(func(work pipeline.IWorkpiece) {
+			token = work.(cursorWorkpiece).token
+		}))
+		return ids, token
+	}
+
+	t.Run("Should page by sys.ID", func(t *testing.T) {
+		require := require.New(t)
+		keyset := []IOrderBy{orderBy{field: appdef.SystemField_ID}}
+		products := []product{{id: 1}, {id: 2}, {id: 3}, {id: 4}, {id: 5}}
+
+		ids, token := page(t, keyset, "", 2, products...)
+		require.Equal([]istructs.RecordID{1, 2}, ids)
+		require.NotEmpty(token)
+
+		// row 2 is deleted and row 6 is added between pages
+		products = []product{{id: 1}, {id: 3}, {id: 4}, {id: 5}, {id: 6}}
+		ids, token = page(t, keyset, token, 2, products...)
+		require.Equal([]istructs.RecordID{3, 4}, ids)
+
+		ids, token = page(t, keyset, token, 2, products...)
+		require.Equal([]istructs.RecordID{5, 6}, ids)
+		require.Empty(token)
+	})
+	t.Run("Should page through equal keys", func(t *testing.T) {
+		require := require.New(t)
+		keyset := []IOrderBy{orderBy{field: "price", desc: true}, orderBy{field: appdef.SystemField_ID}}
+		products := []product{
+			{id: 1, price: 30},
+			{id: 2, price: 20},
+			{id: 3, price: 20},
+			{id: 4, price: 20},
+			{id: 5, price: 10},
+		}
+
+		ids, token := page(t, keyset, "", 2, products...)
+		require.Equal([]istructs.RecordID{1, 2}, ids)
+
+		ids, token = page(t, keyset, token, 1, products...)
+		require.Equal([]istructs.RecordID{3}, ids)
+
+		ids, token = page(t, keyset, token, 5, products...)
+		require.Equal([]istructs.RecordID{4, 5}, ids)
+		require.Empty(token)
+	})
+	t.Run("Should page by several fields", func(t *testing.T) {
+		require := require.New(t)
+		keyset := []IOrderBy{orderBy{field: "name"}, orderBy{field: appdef.SystemField_ID, desc: true}}
+		products := []product{
+			{id: 3, name: "Cola"},
+			{id: 1, name: "Cola"},
+			{id: 4, name: "Pepsi"},
+			{id: 2, name: "Sprite"},
+		}
+
+		ids, token := page(t, keyset, "", 2, products...)
+		require.Equal([]istructs.RecordID{3, 1}, ids)
+
+		ids, token = page(t, keyset, token, 2, products...)
+		require.Equal([]istructs.RecordID{4, 2}, ids)
+		require.Empty(token)
+	})
+	t.Run("Should return sys.ID of the last row if paged by sys.ID", func(t *testing.T) {
+		require := require.New(t)
+		keyset := []IOrderBy{orderBy{field: appdef.SystemField_ID}}
+
+		pager, err := pagerFactory("", keyset)
+		require.NoError(err)
+		afterID, ok := pager.AfterID()
+		require.True(ok)
+		require.Equal(istructs.NullRecordID, afterID)
+
+		_, token := page(t, keyset, "", 2, product{id: 1}, product{id: 2}, product{id: 3})
+		pager, err = pagerFactory(token, keyset)
+		require.NoError(err)
+		afterID, ok = pager.AfterID()
+		require.True(ok)
+		require.Equal(istructs.RecordID(2), afterID)
+
+		t.Run("Should not return sys.ID if paged by other keys", func(t *testing.T) {
+			pager, err := pagerFactory("", []IOrderBy{orderBy{field: "name"}, orderBy{field: appdef.SystemField_ID}})
+			require.NoError(err)
+			_, ok := pager.AfterID()
+			require.False(ok)
+
+			pager, err = pagerFactory("", []IOrderBy{orderBy{field: appdef.SystemField_ID, desc: true}})
+			require.NoError(err)
+			_, ok = pager.AfterID()
+			require.False(ok)
+		})
+	})
+	t.Run("Should return error if cursor is invalid", func(t *testing.T) {
+		keyset := []IOrderBy{orderBy{field: appdef.SystemField_ID}}
+		_, token := page(t, keyset, "", 1, product{id: 1}, product{id: 2})
+
+		t.Run("forged", func(t *testing.T) {
+			_, err := pagerFactory(token+"x", keyset)
+			require.ErrorIs(t, err, ErrInvalidCursor)
+		})
+		t.Run("another order", func(t *testing.T) {
+			_, err := pagerFactory(token, []IOrderBy{orderBy{field: appdef.SystemField_ID, desc: true}})
+			require.ErrorIs(t, err, ErrInvalidCursor)
+		})
+		t.Run("another query", func(t *testing.T) {
+			_, err := newPagerFactory(appTokens, appdef.NewQName("test", "another"), istructs.WSID(1))(token, keyset)
+			require.ErrorIs(t, err, ErrInvalidCursor)
+		})
+		t.Run("another workspace", func(t *testing.T) {
+			_, err := newPagerFactory(appTokens, query, istructs.WSID(2))(token, keyset)
+			require.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	})
+}
@@ -23,6 +23,10 @@ func (o *SendToBusOperator) DoAsync(_ context.Context, work pipeline.IWorkpiece)
 	defer func() {
 		o.metrics.Increase(execSendSeconds, time.Since(begin).Seconds())
 	}()
+	if cursor, ok := work.(cursorWorkpiece); ok {
+		o.rs.StartArraySection(sectionType_Cursor, nil)
+		return work, o.rs.SendElement("", cursor.Values())
+	}
 	if !o.initialized {
 		//TODO what to set into sectionType, path?
 		o.rs.StartArraySection("", nil)
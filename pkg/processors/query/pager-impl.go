@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package queryprocessor
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// cursorPayload is signed by application tokens, so client can not forge the position.
+// Keys are JSON encoded to keep int64 values precise
+type cursorPayload struct {
+	Query   string
+	WSID    istructs.WSID
+	OrderBy []string
+	Keys    []string
+}
+
+type pager struct {
+	tokens  istructs.IAppTokens
+	query   appdef.QName
+	wsid    istructs.WSID
+	orderBy []IOrderBy
+	after   cursorPayload
+}
+
+func newPagerFactory(tokens istructs.IAppTokens, query appdef.QName, wsid istructs.WSID) PagerFactory {
+	return func(after string, orderBy []IOrderBy) (IPager, error) {
+		p := &pager{
+			tokens:  tokens,
+			query:   query,
+			wsid:    wsid,
+			orderBy: orderBy,
+		}
+		if after == "" {
+			return p, nil
+		}
+		payload := cursorPayload{}
+		if _, err := tokens.ValidateToken(after, &payload); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+		}
+		if payload.Query != query.String() || payload.WSID != wsid || !slices.Equal(payload.OrderBy, p.orderByFields()) {
+			return nil, fmt.Errorf("cursor is issued for another query, workspace or order: %w", ErrInvalidCursor)
+		}
+		if len(payload.Keys) != len(orderBy) {
+			return nil, fmt.Errorf("cursor keys length must be %d but got %d: %w", len(orderBy), len(payload.Keys), ErrInvalidCursor)
+		}
+		p.after = payload
+		return p, nil
+	}
+}
+
+func (p *pager) OrderBy() []IOrderBy { return p.orderBy }
+
+func (p *pager) After() []string { return p.after.Keys }
+
+func (p *pager) AfterID() (id istructs.RecordID, ok bool) {
+	if len(p.orderBy) != 1 || p.orderBy[0].Field() != appdef.SystemField_ID || p.orderBy[0].IsDesc() {
+		return istructs.NullRecordID, false
+	}
+	if p.after.Keys == nil {
+		return istructs.NullRecordID, true
+	}
+	if err := json.Unmarshal([]byte(p.after.Keys[0]), &id); err != nil {
+		return istructs.NullRecordID, false
+	}
+	return id, true
+}
+
+func (p *pager) Next(keys []interface{}) (token string, err error) {
+	payload := cursorPayload{
+		Query:   p.query.String(),
+		WSID:    p.wsid,
+		OrderBy: p.orderByFields(),
+		Keys:    make([]string, len(keys)),
+	}
+	for i, key := range keys {
+		bb, err := json.Marshal(key)
+		if err != nil {
+			return "", err
+		}
+		payload.Keys[i] = string(bb)
+	}
+	return p.tokens.IssueToken(cursorTokenDuration, &payload)
+}
+
+// orderByFields returns keyset fields, descending ones are prefixed with '-'
+func (p *pager) orderByFields() []string {
+	res := make([]string, len(p.orderBy))
+	for i, o := range p.orderBy {
+		res[i] = o.Field()
+		if o.IsDesc() {
+			res[i] = "-" + res[i]
+		}
+	}
+	return res
+}
@@ -7,6 +7,7 @@ package queryprocessor
 import (
 	"fmt"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
@@ -16,6 +17,7 @@ type queryParams struct {
 	orderBy   []IOrderBy
 	startFrom int64
 	count     int64
+	pager     IPager
 }
 
 func (p queryParams) Elements() []IElement { return p.elements }
@@ -23,8 +25,10 @@ func (p queryParams) Filters() []IFilter   { return p.filters }
 func (p queryParams) OrderBy() []IOrderBy  { return p.orderBy }
 func (p queryParams) StartFrom() int64     { return p.startFrom }
 func (p queryParams) Count() int64         { return p.count }
+func (p queryParams) Pager() IPager        { return p.pager }
 
-func newQueryParams(data coreutils.MapObject, elementFactory ElementFactory, filterFactory FilterFactory, orderByFactory OrderByFactory, rootFieldsKinds FieldsKinds) (res IQueryParams, err error) {
+func newQueryParams(data coreutils.MapObject, elementFactory ElementFactory, filterFactory FilterFactory, orderByFactory OrderByFactory,
+	pagerFactory PagerFactory, rootFieldsKinds FieldsKinds) (res IQueryParams, err error) {
 	qp := queryParams{}
 	if err = qp.fillArray(data, "elements", func(elem coreutils.MapObject) error {
 		element, err := elementFactory(elem)
@@ -59,7 +63,47 @@ func newQueryParams(data coreutils.MapObject, elementFactory ElementFactory, fil
 	if qp.startFrom, _, err = data.AsInt64("startFrom"); err != nil {
 		return nil, err
 	}
-	return qp, qp.validate(rootFieldsKinds)
+	if err = qp.validate(rootFieldsKinds); err != nil {
+		return qp, err
+	}
+	after, ok, err := data.AsString("after")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if qp.pager, err = qp.newPager(after, pagerFactory); err != nil {
+			return nil, fmt.Errorf("after: %w", err)
+		}
+	}
+	return qp, nil
+}
+
+// newPager returns the pager with the keyset made of orderBy fields and sys.ID of the root element.
+// sys.ID makes the keyset unique, so it must be requested
+func (p queryParams) newPager(after string, pagerFactory PagerFactory) (IPager, error) {
+	if p.startFrom != 0 {
+		return nil, fmt.Errorf("'startFrom' can not be used with cursor: %w", ErrUnexpected)
+	}
+	if p.count <= 0 {
+		return nil, fmt.Errorf("'count' must be specified to page by cursor: %w", coreutils.ErrFieldsMissed)
+	}
+	keyset := append([]IOrderBy{}, p.orderBy...)
+	for _, o := range p.orderBy {
+		if o.Field() == appdef.SystemField_ID {
+			return pagerFactory(after, keyset)
+		}
+	}
+	for _, e := range p.elements {
+		if !e.Path().IsRoot() {
+			continue
+		}
+		for _, field := range e.ResultFields() {
+			if field.Field() == appdef.SystemField_ID {
+				return pagerFactory(after, append(keyset, orderBy{field: appdef.SystemField_ID}))
+			}
+		}
+	}
+	return nil, fmt.Errorf("root element field '%s' must be requested to page by cursor: %w", appdef.SystemField_ID, coreutils.ErrFieldsMissed)
 }
 
 func (p *queryParams) fillArray(data coreutils.MapObject, fieldName string, cb func(elem coreutils.MapObject) error) error {
@@ -126,6 +170,12 @@ func validateFilters(filters []IFilter, validateFilter func(filter, field string
 			err = validateFilter(filterKind_Gt, filter.field)
 		case *LessFilter:
 			err = validateFilter(filterKind_Lt, filter.field)
+		case *ContainsFilter:
+			err = validateFilter(filterKind_Contains, filter.field)
+		case *StartsWithFilter:
+			err = validateFilter(filterKind_StartsWith, filter.field)
+		case *InFilter:
+			err = validateFilter(filterKind_In, filter.field)
 		case *AndFilter:
 			err = validateFilters(filter.filters, validateFilter)
 			if err != nil {
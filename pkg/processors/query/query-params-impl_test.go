@@ -39,7 +39,7 @@ func TestWrongTypes(t *testing.T) {
 	appParts, cleanAppParts, err := appparts.New(appStructsProvider)
 	require.NoError(err)
 	defer cleanAppParts()
-	appParts.DeployApp(appName, appDef, appPartsCount, appEngines)
+	require.NoError(appParts.DeployApp(appName, nil, appDef, appPartsCount, appEngines))
 	appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
 
 	queryProcessor := ProvideServiceFactory()(
@@ -169,6 +169,36 @@ func TestWrongTypes(t *testing.T) {
 			body: `{"filters":[{"expr":"lt","args":""}]}`,
 			err:  "filters: 'lt' filter: field 'args' must be an object: wrong type",
 		},
+		{
+			name: "Contains filter value must be a string",
+			body: `{"filters":[{"expr":"contains","args":{"field":"name","value":1}}]}`,
+			err:  "filters: 'contains' filter: field 'value' must be a string: wrong type",
+		},
+		{
+			name: "StartsWith filter caseSensitive must be a boolean",
+			body: `{"filters":[{"expr":"startsWith","args":{"field":"name","value":"a","options":{"caseSensitive":"wrong"}}}]}`,
+			err:  "filters: 'startsWith' filter: field 'caseSensitive' must be a boolean: field type mismatch",
+		},
+		{
+			name: "StartsWith filter locale must be a language tag",
+			body: `{"filters":[{"expr":"startsWith","args":{"field":"name","value":"a","options":{"locale":"$$$"}}}]}`,
+			err:  "filters: 'startsWith' filter: field 'locale' must be a BCP 47 language tag: language: tag is not well-formed",
+		},
+		{
+			name: "In filter wrong args",
+			body: `{"filters":[{"expr":"in","args":""}]}`,
+			err:  "filters: 'in' filter: field 'args' must be an object: wrong type",
+		},
+		{
+			name: "In filter values must be present",
+			body: `{"filters":[{"expr":"in","args":{"field":"id"}}]}`,
+			err:  "filters: 'in' filter: field 'values' must be present: not found",
+		},
+		{
+			name: "In filter values must be an array",
+			body: `{"filters":[{"expr":"in","args":{"field":"id","values":{}}}]}`,
+			err:  "filters: 'in' filter: field 'values' must be an array of objects: field type mismatch",
+		},
 		{
 			name: "And filter wrong args",
 			body: `{"filters":[{"expr":"and","args":""}]}`,
@@ -249,6 +279,11 @@ func TestWrongTypes(t *testing.T) {
 			body: `{"elements":[{"fields":["sys.ID"]}],"filters":[{"expr":"eq","args":{"field":"wrong","value":1}}]}`,
 			err:  "filters: 'eq' filter has field 'wrong' that is absent in root element fields/refs, please add or change it: unexpected",
 		},
+		{
+			name: "In filter field must be present in root element fields/refs",
+			body: `{"elements":[{"fields":["sys.ID"]}],"filters":[{"expr":"in","args":{"field":"wrong","values":[1]}}]}`,
+			err:  "filters: 'in' filter has field 'wrong' that is absent in root element fields/refs, please add or change it: unexpected",
+		},
 		{
 			name: "Not equals filter field must be present in root element fields/refs",
 			body: `{"elements":[{"fields":["sys.ID"]}],"filters":[{"expr":"notEq","args":{"field":"wrong","value":1}}]}`,
@@ -274,6 +309,31 @@ func TestWrongTypes(t *testing.T) {
 			body: `{"elements":[{"fields":["sys.ID"],"path":"article"},{"fields":["sys.ID"],"path":"article"}]}`,
 			err:  "elements: path 'article' must be unique",
 		},
+		{
+			name: "After must be a string",
+			body: `{"after":1,"count":1}`,
+			err:  "field 'after' must be a string: field type mismatch",
+		},
+		{
+			name: "After can not be used with startFrom",
+			body: `{"after":"","count":1,"startFrom":1}`,
+			err:  "after: 'startFrom' can not be used with cursor: unexpected",
+		},
+		{
+			name: "After requires count",
+			body: `{"after":""}`,
+			err:  "after: 'count' must be specified to page by cursor: fields are missed",
+		},
+		{
+			name: "After requires sys.ID",
+			body: `{"elements":[{"fields":["name"]}],"after":"","count":1}`,
+			err:  "after: root element field 'sys.ID' must be requested to page by cursor: fields are missed",
+		},
+		{
+			name: "After must be a valid cursor",
+			body: `{"elements":[{"fields":["sys.ID"]}],"after":"wrong","count":1}`,
+			err:  "after: invalid cursor",
+		},
 	}
 
 	query := appDef.Query(qNameFunction)
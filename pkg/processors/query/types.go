@@ -56,6 +56,25 @@ type IQueryParams interface {
 	OrderBy() []IOrderBy
 	StartFrom() int64
 	Count() int64
+	// Pager returns nil if the result is not paged by cursor
+	Pager() IPager
+}
+
+// PagerFactory creates IPager from the continuation token and the requested ordering
+type PagerFactory func(after string, orderBy []IOrderBy) (IPager, error)
+
+// IPager is the keyset pagination of the query result.
+// Rows are ordered by the keyset and each page starts right after the last row of the previous page
+type IPager interface {
+	// OrderBy returns the keyset: requested ordering followed by the sys.ID tie-breaker, so the keyset is unique
+	OrderBy() []IOrderBy
+	// After returns JSON encoded keys of the last row of the previous page. Keys are nil for the first page
+	After() []string
+	// AfterID returns sys.ID of the last row of the previous page if rows are paged by sys.ID ascending.
+	// Then the query function can seek to the page instead of producing all rows
+	AfterID() (id istructs.RecordID, ok bool)
+	// Next returns the continuation token for the page that starts after the row with the given keys
+	Next(keys []interface{}) (token string, err error)
 }
 
 // ElementFactory creates IElement from data
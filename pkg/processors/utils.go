@@ -0,0 +1,26 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package processors
+
+import (
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Returns scopes of the request to check limits declared in application definition
+func LimitScopes(partition istructs.PartitionID, wsid istructs.WSID, principals []iauthnz.Principal, remoteAddr string) istructs.LimitScopes {
+	scopes := istructs.LimitScopes{
+		Partition:  partition,
+		Workspace:  wsid,
+		RemoteAddr: remoteAddr,
+	}
+	for _, prn := range principals {
+		if prn.Kind == iauthnz.PrincipalKind_User || prn.Kind == iauthnz.PrincipalKind_Device {
+			scopes.User = prn.Name
+			break
+		}
+	}
+	return scopes
+}
@@ -8,7 +8,9 @@ package projectors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -26,10 +28,13 @@ import (
 type workpiece struct {
 	event      istructs.IPLogEvent
 	pLogOffset istructs.Offset
+	rebuilt    *partitionRebuild // not nil -> PLog is replayed by the rebuilt projector till pLogOffset, event is nil
 }
 
 func (w *workpiece) Release() {
-	w.event.Release()
+	if w.event != nil {
+		w.event.Release()
+	}
 }
 
 // implements ServiceOperator
@@ -42,6 +47,12 @@ type asyncActualizer struct {
 	name         string
 	readCtx      *asyncActualizerContextState
 	projErrState int32 // 0 - no error, 1 - error
+
+	projectorName appdef.QName
+	pauseLock     sync.Mutex
+	paused        *actualizerPause // not nil while the actualizer is stopped by the rebuilder
+	pauseNotify   chan struct{}    // wakes the actualizer up which is waiting after error
+	rebuild       atomic.Pointer[partitionRebuild]
 }
 
 func (a *asyncActualizer) Prepare(interface{}) error {
@@ -71,22 +82,85 @@ func (a *asyncActualizer) Prepare(interface{}) error {
 func (a *asyncActualizer) Run(ctx context.Context) {
 	var err error
 	for ctx.Err() == nil {
+		if a.waitResume(ctx) {
+			continue
+		}
 		if err = a.init(ctx); err == nil {
 			logger.Trace(a.name, "started")
 			err = a.keepReading()
-			a.conf.LogError(a.name, err)
+			if !errors.Is(err, errActualizerPaused) {
+				a.conf.LogError(a.name, err)
+			}
 		}
 		a.finit() // even execute if a.init has failed
-		if ctx.Err() == nil && err != nil {
+		if ctx.Err() == nil && err != nil && !errors.Is(err, errActualizerPaused) {
 			a.conf.LogError(a.name, err)
 			select {
 			case <-ctx.Done():
 			case <-a.conf.AfterError(actualizerErrorDelay):
+			case <-a.pauseNotify:
 			}
 		}
 	}
+	if r, ok := a.conf.Rebuilder.(*rebuilder); ok && a.projectorName != appdef.NullQName {
+		r.unregister(a.conf.AppQName, a.projectorName, a)
+	}
 }
 func (a *asyncActualizer) Stop() {}
+
+// Stops the actualizer to rebuild the projector. Returned channel is closed when the actualizer is stopped
+func (a *asyncActualizer) pause() <-chan struct{} {
+	a.pauseLock.Lock()
+	defer a.pauseLock.Unlock()
+	a.paused = &actualizerPause{
+		stopped: make(chan struct{}),
+		resume:  make(chan struct{}),
+	}
+	if a.readCtx != nil {
+		a.readCtx.cancelWithError(errActualizerPaused)
+	}
+	select {
+	case a.pauseNotify <- struct{}{}:
+	default:
+	}
+	return a.paused.stopped
+}
+
+// Resumes the actualizer stopped by pause
+func (a *asyncActualizer) resume() {
+	a.pauseLock.Lock()
+	defer a.pauseLock.Unlock()
+	if p := a.paused; p != nil {
+		select {
+		case <-p.resume:
+		default:
+			close(p.resume)
+		}
+	}
+}
+
+// Waits until the paused actualizer is resumed. Returns false if the actualizer is not paused
+func (a *asyncActualizer) waitResume(ctx context.Context) bool {
+	a.pauseLock.Lock()
+	p := a.paused
+	a.pauseLock.Unlock()
+	if p == nil {
+		return false
+	}
+
+	close(p.stopped)
+	select {
+	case <-p.resume:
+	case <-ctx.Done():
+	}
+
+	a.pauseLock.Lock()
+	if a.paused == p {
+		a.paused = nil
+	}
+	a.pauseLock.Unlock()
+	return true
+}
 func (a *asyncActualizer) cancelChannel(e error) {
 	a.readCtx.cancelWithError(e)
 	a.conf.Broker.WatchChannel(a.readCtx.ctx, a.conf.channel, func(projection in10n.ProjectionKey, offset istructs.Offset) {})
@@ -94,11 +168,22 @@ func (a *asyncActualizer) cancelChannel(e error) {
 
 func (a *asyncActualizer) init(ctx context.Context) (err error) {
 	a.structs = a.conf.AppStructs()
-	a.readCtx = &asyncActualizerContextState{}
 
+	a.pauseLock.Lock()
+	a.readCtx = &asyncActualizerContextState{}
 	a.readCtx.ctx, a.readCtx.cancel = context.WithCancel(ctx)
+	paused := a.paused != nil
+	a.pauseLock.Unlock()
+	if paused {
+		a.readCtx.cancel()
+		return errActualizerPaused
+	}
 
 	projector := a.factory(a.conf.Partition)
+	if r, ok := a.conf.Rebuilder.(*rebuilder); ok {
+		a.projectorName = projector.Name
+		r.register(a.conf.AppQName, projector.Name, a)
+	}
 	iProjector := a.structs.AppDef().Projector(projector.Name)
 
 	// https://github.com/voedger/voedger/issues/1048
@@ -139,13 +224,7 @@ func (a *asyncActualizer) init(ctx context.Context) (err error) {
 		a.structs,
 		state.SimplePartitionIDFunc(a.conf.Partition),
 		p.WSIDProvider,
-		func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset) {
-			a.conf.Broker.Update(in10n.ProjectionKey{
-				App:        a.conf.AppQName,
-				Projection: view,
-				WS:         wsid,
-			}, offset)
-		},
+		n10nFunc(a.conf.Broker, a.conf.AppQName),
 		a.conf.SecretReader,
 		a.conf.IntentsLimit,
 		a.conf.BundlesLimit,
@@ -191,6 +270,13 @@ func (a *asyncActualizer) keepReading() (err error) {
 		a.cancelChannel(err)
 		return
 	}
+	if pr := a.rebuild.Swap(nil); pr != nil {
+		// rebuild is done when the projector flushes the replayed events
+		if err = a.pipeline.SendAsync(&workpiece{pLogOffset: a.offset, rebuilt: pr}); err != nil {
+			a.cancelChannel(err)
+			return
+		}
+	}
 	a.conf.Broker.WatchChannel(a.readCtx.ctx, a.conf.channel, func(projection in10n.ProjectionKey, offset istructs.Offset) {
 		if logger.IsTrace() {
 			logger.Trace(fmt.Sprintf("%s received n10n: offset %d, last handled: %d", a.name, offset, a.offset))
@@ -219,6 +305,9 @@ func (a *asyncActualizer) handleEvent(pLogOffset istructs.Offset, event istructs
 	}
 
 	a.offset = pLogOffset
+	if pr := a.rebuild.Load(); pr != nil {
+		pr.set(RebuildStatus_Replaying, pLogOffset)
+	}
 
 	if logger.IsTrace() {
 		logger.Trace(fmt.Sprintf("offset %d for %s", a.offset, a.name))
@@ -269,6 +358,15 @@ func (p *asyncProjector) DoAsync(_ context.Context, work pipeline.IWorkpiece) (o
 	defer work.Release()
 	w := work.(*workpiece)
 
+	if w.rebuilt != nil {
+		if err = p.flush(); err != nil {
+			return nil, err
+		}
+		w.rebuilt.set(RebuildStatus_Done, w.pLogOffset)
+		logger.Info(fmt.Sprintf("%s [%d] rebuilt, offset %d", p.projector.Name, p.partition, w.pLogOffset))
+		return nil, nil
+	}
+
 	p.wsid = w.event.Workspace()
 	p.pLogOffset = w.pLogOffset
 	if p.aametrics != nil {
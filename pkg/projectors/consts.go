@@ -39,3 +39,12 @@ const (
 )
 
 var PLogUpdatesQName = appdef.NewQName(appdef.SysPackage, "PLogUpdates")
+
+const (
+	// projector is being stopped on the partition
+	RebuildStatus_Stopping RebuildStatus = "stopping"
+	// PLog of the partition is being replayed
+	RebuildStatus_Replaying RebuildStatus = "replaying"
+	// all events of PLog of the partition are handed to the projector
+	RebuildStatus_Done RebuildStatus = "done"
+)
@@ -3,3 +3,14 @@
  */
 
 package projectors
+
+import "errors"
+
+var ErrProjectorNotFound = errors.New("projector not found")
+
+var ErrRebuildInProgress = errors.New("projector rebuild is in progress")
+
+var ErrRebuildNotFound = errors.New("projector rebuild not found")
+
+// returned by the actualizer which is stopped to be rebuilt
+var errActualizerPaused = errors.New("actualizer is paused to be rebuilt")
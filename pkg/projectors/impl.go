@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/pipeline"
 	"github.com/voedger/voedger/pkg/state"
@@ -18,8 +19,9 @@ import (
 
 func asyncActualizerFactory(conf AsyncActualizerConf, factory istructs.ProjectorFactory) (pipeline.ISyncOperator, error) {
 	return pipeline.ServiceOperator(&asyncActualizer{
-		factory: factory,
-		conf:    conf,
+		factory:     factory,
+		conf:        conf,
+		pauseNotify: make(chan struct{}, 1),
 	}), nil
 }
 
@@ -122,6 +124,22 @@ type eventService struct {
 
 func (s *eventService) getWSID() istructs.WSID { return s.event.Workspace() }
 
+func n10nFunc(broker in10n.IN10nBroker, app istructs.AppQName) state.N10nFunc {
+	rowsBroker, withRows := broker.(in10n.IN10nRowsBroker)
+	return func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset, rows func(maxSize int) []byte) {
+		projection := in10n.ProjectionKey{
+			App:        app,
+			Projection: view,
+			WS:         wsid,
+		}
+		if withRows {
+			rowsBroker.UpdateRows(projection, offset, rows)
+			return
+		}
+		broker.Update(projection, offset)
+	}
+}
+
 func provideViewDefImpl(appDef appdef.IAppDefBuilder, qname appdef.QName, buildFunc ViewTypeBuilder) {
 	builder := appDef.AddView(qname)
 	if buildFunc != nil {
@@ -50,10 +50,56 @@ type AsyncActualizerConf struct {
 	Broker  in10n.IN10nBroker
 	channel in10n.ChannelID
 	Opts    []state.ActualizerStateOptFunc
+
+	// Optional. Actualizer is registered to be rebuilt by the rebuilder
+	Rebuilder IRebuilder
 }
 
 type AppStructsFunc func() istructs.IAppStructs
 
+// IRebuilder rebuilds async projectors.
+//
+// Rebuild stops the projector on all partitions, truncates views from the projector intents,
+// resets the projector offsets and replays PLog from the beginning
+type IRebuilder interface {
+	// Starts rebuild of the projector. Returns when the projector is stopped on all partitions, views are truncated and offsets are reset.
+	// PLog is replayed in background, use Progress to watch it.
+	//
+	// Returns ErrProjectorNotFound if no actualizer of the projector is running,
+	// ErrRebuildInProgress if the previous rebuild of the projector is not finished
+	Rebuild(ctx context.Context, app istructs.AppQName, projector appdef.QName) error
+
+	// Returns progress of the last rebuild of the projector.
+	//
+	// Returns ErrRebuildNotFound if the projector was not rebuilt
+	Progress(app istructs.AppQName, projector appdef.QName) (RebuildProgress, error)
+}
+
+type RebuildProgress struct {
+	App        istructs.AppQName
+	Projector  appdef.QName
+	Partitions []PartitionRebuildProgress // ordered by partition ID
+}
+
+// Done returns true if PLog is replayed on all partitions
+func (p RebuildProgress) Done() bool {
+	for _, pp := range p.Partitions {
+		if pp.Status != RebuildStatus_Done {
+			return false
+		}
+	}
+	return true
+}
+
+type PartitionRebuildProgress struct {
+	Partition istructs.PartitionID
+	Status    RebuildStatus
+	// PLog offset of the last event handed to the projector
+	Offset istructs.Offset
+}
+
+type RebuildStatus string
+
 type AsyncActualizerMetrics interface {
 	Increase(metricName string, partition istructs.PartitionID, projection appdef.QName, valueDelta float64)
 	Set(metricName string, partition istructs.PartitionID, projection appdef.QName, value float64)
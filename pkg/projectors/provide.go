@@ -6,16 +6,31 @@
 
 package projectors
 
-import "github.com/voedger/voedger/pkg/appdef"
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/state"
+)
 
 func ProvideAsyncActualizerFactory() AsyncActualizerFactory {
 	return asyncActualizerFactory
 }
 
+// Rebuilder must be set to AsyncActualizerConf.Rebuilder of the actualizers to rebuild
+func ProvideRebuilder() IRebuilder {
+	return newRebuilder()
+}
+
 func ProvideSyncActualizerFactory() SyncActualizerFactory {
 	return syncActualizerFactory
 }
 
+// Changed view rows are attached to the offset if the broker is in10n.IN10nRowsBroker
+func ProvideN10nFunc(broker in10n.IN10nBroker, app istructs.AppQName) state.N10nFunc {
+	return n10nFunc(broker, app)
+}
+
 func ProvideOffsetsDef(appDef appdef.IAppDefBuilder) {
 	provideOffsetsDefImpl(appDef)
 }
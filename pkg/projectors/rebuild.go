@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package projectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/untillpro/goutils/logger"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/state"
+)
+
+type rebuildKey struct {
+	app       istructs.AppQName
+	projector appdef.QName
+}
+
+// implements IRebuilder
+type rebuilder struct {
+	lock        sync.Mutex
+	actualizers map[rebuildKey]map[*asyncActualizer]struct{}
+	rebuilds    map[rebuildKey]*rebuild
+}
+
+type rebuild struct {
+	partitions []*partitionRebuild
+}
+
+type partitionRebuild struct {
+	lock      sync.Mutex
+	partition istructs.PartitionID
+	status    RebuildStatus
+	offset    istructs.Offset
+}
+
+// stops the actualizer while the projector is being rebuilt
+type actualizerPause struct {
+	stopped chan struct{} // closed by the actualizer when it is stopped
+	resume  chan struct{} // closed by the rebuilder to resume the actualizer
+}
+
+func newRebuilder() *rebuilder {
+	return &rebuilder{
+		actualizers: make(map[rebuildKey]map[*asyncActualizer]struct{}),
+		rebuilds:    make(map[rebuildKey]*rebuild),
+	}
+}
+
+func (r *rebuilder) Rebuild(ctx context.Context, app istructs.AppQName, projector appdef.QName) (err error) {
+	key := rebuildKey{app, projector}
+	aa, rb, err := r.start(key)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.lock.Lock()
+			delete(r.rebuilds, key)
+			r.lock.Unlock()
+		}
+		for _, a := range aa {
+			a.resume()
+		}
+	}()
+
+	stopped := make([]<-chan struct{}, len(aa))
+	for i, a := range aa {
+		stopped[i] = a.pause()
+	}
+	for _, s := range stopped {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		select {
+		case <-s:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	logger.Info(fmt.Sprintf("%v: projector %v is stopped on %d partition(s) to be rebuilt", app, projector, len(aa)))
+
+	appStructs := aa[0].conf.AppStructs()
+	if err = truncateViews(ctx, appStructs, projector); err != nil {
+		return err
+	}
+	for i, a := range aa {
+		if err = storeOffset(appStructs, a.conf.Partition, projector, istructs.NullOffset); err != nil {
+			return err
+		}
+		rb.partitions[i].set(RebuildStatus_Replaying, istructs.NullOffset)
+		a.rebuild.Store(rb.partitions[i])
+	}
+	return nil
+}
+
+func (r *rebuilder) Progress(app istructs.AppQName, projector appdef.QName) (RebuildProgress, error) {
+	r.lock.Lock()
+	rb, ok := r.rebuilds[rebuildKey{app, projector}]
+	r.lock.Unlock()
+	if !ok {
+		return RebuildProgress{}, fmt.Errorf("%v projector %v: %w", app, projector, ErrRebuildNotFound)
+	}
+	return rb.progress(app, projector), nil
+}
+
+// returns actualizers of the projector ordered by partition and registers new rebuild
+func (r *rebuilder) start(key rebuildKey) ([]*asyncActualizer, *rebuild, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.actualizers[key]) == 0 {
+		return nil, nil, fmt.Errorf("%v projector %v: %w", key.app, key.projector, ErrProjectorNotFound)
+	}
+	if rb, ok := r.rebuilds[key]; ok && !rb.progress(key.app, key.projector).Done() {
+		return nil, nil, fmt.Errorf("%v projector %v: %w", key.app, key.projector, ErrRebuildInProgress)
+	}
+
+	aa := make([]*asyncActualizer, 0, len(r.actualizers[key]))
+	for a := range r.actualizers[key] {
+		aa = append(aa, a)
+	}
+	sort.Slice(aa, func(i, j int) bool { return aa[i].conf.Partition < aa[j].conf.Partition })
+
+	rb := &rebuild{partitions: make([]*partitionRebuild, len(aa))}
+	for i, a := range aa {
+		rb.partitions[i] = &partitionRebuild{partition: a.conf.Partition, status: RebuildStatus_Stopping}
+	}
+	r.rebuilds[key] = rb
+
+	return aa, rb, nil
+}
+
+func (r *rebuilder) register(app istructs.AppQName, projector appdef.QName, a *asyncActualizer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	key := rebuildKey{app, projector}
+	if r.actualizers[key] == nil {
+		r.actualizers[key] = make(map[*asyncActualizer]struct{})
+	}
+	r.actualizers[key][a] = struct{}{}
+}
+
+func (r *rebuilder) unregister(app istructs.AppQName, projector appdef.QName, a *asyncActualizer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.actualizers[rebuildKey{app, projector}], a)
+}
+
+func (rb *rebuild) progress(app istructs.AppQName, projector appdef.QName) RebuildProgress {
+	p := RebuildProgress{
+		App:        app,
+		Projector:  projector,
+		Partitions: make([]PartitionRebuildProgress, len(rb.partitions)),
+	}
+	for i, pr := range rb.partitions {
+		pr.lock.Lock()
+		p.Partitions[i] = PartitionRebuildProgress{Partition: pr.partition, Status: pr.status, Offset: pr.offset}
+		pr.lock.Unlock()
+	}
+	return p
+}
+
+func (pr *partitionRebuild) set(status RebuildStatus, offset istructs.Offset) {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+	pr.status = status
+	pr.offset = offset
+}
+
+// Truncates views which are declared in the projector intents
+func truncateViews(ctx context.Context, appStructs istructs.IAppStructs, projector appdef.QName) (err error) {
+	prj := appStructs.AppDef().Projector(projector)
+	if prj == nil {
+		return fmt.Errorf("projector %v: %w", projector, ErrProjectorNotFound)
+	}
+	prj.Intents(func(storage appdef.QName, names appdef.QNames) {
+		if storage != state.View {
+			return
+		}
+		for _, view := range names {
+			err = errors.Join(err, istructsmem.TruncateView(ctx, appStructs, view))
+		}
+	})
+	return err
+}
+
+func storeOffset(appStructs istructs.IAppStructs, partition istructs.PartitionID, projector appdef.QName, offset istructs.Offset) error {
+	kb := appStructs.ViewRecords().KeyBuilder(qnameProjectionOffsets)
+	kb.PutInt32(partitionFld, int32(partition))
+	kb.PutQName(projectorNameFld, projector)
+	vb := appStructs.ViewRecords().NewValueBuilder(qnameProjectionOffsets)
+	vb.PutInt64(offsetFld, int64(offset))
+	return appStructs.ViewRecords().Put(istructs.NullWSID, kb, vb)
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package projectors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/in10nmem"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/pipeline"
+	"github.com/voedger/voedger/pkg/state"
+)
+
+func TestBasicUsage_Rebuild(t *testing.T) {
+	require := require.New(t)
+
+	app := appStructs(
+		func(appDef appdef.IAppDefBuilder) {
+			ProvideViewDef(appDef, incProjectionView, buildProjectionView)
+			appDef.AddCommand(testQName)
+			appDef.AddProjector(incrementorName).
+				AddEvent(testQName, appdef.ProjectorEventKind_Execute).
+				AddIntent(state.View, incProjectionView)
+		},
+		func(cfg *istructsmem.AppConfigType) {
+			cfg.Resources.Add(istructsmem.NewCommandFunction(testQName, istructsmem.NullCommandExec))
+		})
+
+	partitions := []istructs.PartitionID{1, 2}
+	topOffsets := make(map[istructs.PartitionID]istructs.Offset)
+	for _, partition := range partitions {
+		f := pLogFiller{
+			app:       app,
+			partition: partition,
+			offset:    istructs.FirstOffset,
+			cmdQName:  testQName,
+		}
+		ws := istructs.WSID(partition) * 1000
+		f.fill(ws + 1)
+		f.fill(ws + 2)
+		topOffsets[partition] = f.fill(ws + 1)
+	}
+
+	broker, cleanup := in10nmem.ProvideEx2(in10n.Quotas{
+		Channels:               10,
+		ChannelsPerSubject:     10,
+		Subsciptions:           10,
+		SubsciptionsPerSubject: 10,
+	}, time.Now)
+	defer cleanup()
+
+	withCancel, cancelCtx := context.WithCancel(context.Background())
+	rebuilder := ProvideRebuilder()
+
+	actualizers := make([]pipeline.ISyncOperator, 0, len(partitions))
+	for _, partition := range partitions {
+		conf := AsyncActualizerConf{
+			Ctx:        withCancel,
+			AppQName:   istructs.AppQName_test1_app1,
+			Partition:  partition,
+			AppStructs: func() istructs.IAppStructs { return app },
+			Broker:     broker,
+			Rebuilder:  rebuilder,
+		}
+		actualizer, err := ProvideAsyncActualizerFactory()(conf, incrementorFactory)
+		require.NoError(err)
+		require.NoError(actualizer.DoSync(conf.Ctx, struct{}{})) // Start service
+		actualizers = append(actualizers, actualizer)
+	}
+	defer func() {
+		cancelCtx()
+		for _, a := range actualizers {
+			a.Close()
+		}
+	}()
+
+	waitOffsets := func() {
+		for _, partition := range partitions {
+			for getActualizerOffset(require, app, partition, incrementorName) < topOffsets[partition] {
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}
+	checkValues := func(v1001 int32) {
+		require.Equal(v1001, getProjectionValue(require, app, incProjectionView, istructs.WSID(1001)))
+		require.Equal(int32(1), getProjectionValue(require, app, incProjectionView, istructs.WSID(1002)))
+		require.Equal(int32(2), getProjectionValue(require, app, incProjectionView, istructs.WSID(2001)))
+		require.Equal(int32(1), getProjectionValue(require, app, incProjectionView, istructs.WSID(2002)))
+	}
+	waitOffsets()
+	checkValues(2)
+
+	// spoil the view as buggy projector does
+	kb := app.ViewRecords().KeyBuilder(incProjectionView)
+	kb.PutInt32("pk", 0)
+	kb.PutInt32("cc", 0)
+	vb := app.ViewRecords().NewValueBuilder(incProjectionView)
+	vb.PutInt32(colValue, 100)
+	require.NoError(app.ViewRecords().Put(istructs.WSID(1001), kb, vb))
+
+	t.Run("must be ok to rebuild projector", func(t *testing.T) {
+		require.NoError(rebuilder.Rebuild(context.Background(), istructs.AppQName_test1_app1, incrementorName))
+
+		var progress RebuildProgress
+		require.Eventually(func() bool {
+			p, err := rebuilder.Progress(istructs.AppQName_test1_app1, incrementorName)
+			require.NoError(err)
+			progress = p
+			return p.Done()
+		}, 5*time.Second, time.Millisecond)
+
+		require.Len(progress.Partitions, len(partitions))
+		for i, partition := range partitions {
+			require.Equal(partition, progress.Partitions[i].Partition)
+			require.Equal(RebuildStatus_Done, progress.Partitions[i].Status)
+			require.Equal(topOffsets[partition], progress.Partitions[i].Offset)
+		}
+
+		waitOffsets()
+		checkValues(2)
+	})
+
+	t.Run("must be ok to rebuild projector again", func(t *testing.T) {
+		require.NoError(rebuilder.Rebuild(context.Background(), istructs.AppQName_test1_app1, incrementorName))
+		require.Eventually(func() bool {
+			p, err := rebuilder.Progress(istructs.AppQName_test1_app1, incrementorName)
+			require.NoError(err)
+			return p.Done()
+		}, 5*time.Second, time.Millisecond)
+		waitOffsets()
+		checkValues(2)
+	})
+
+	t.Run("must be error if unknown projector", func(t *testing.T) {
+		unknown := appdef.NewQName("test", "unknown")
+		require.ErrorIs(rebuilder.Rebuild(context.Background(), istructs.AppQName_test1_app1, unknown), ErrProjectorNotFound)
+
+		_, err := rebuilder.Progress(istructs.AppQName_test1_app1, unknown)
+		require.ErrorIs(err, ErrRebuildNotFound)
+	})
+
+	t.Run("must be error if context is done while stopping", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(rebuilder.Rebuild(ctx, istructs.AppQName_test1_app1, incrementorName), context.Canceled)
+
+		// actualizers are resumed
+		f := pLogFiller{
+			app:       app,
+			partition: partitions[0],
+			offset:    topOffsets[partitions[0]] + 1,
+			cmdQName:  testQName,
+		}
+		topOffsets[partitions[0]] = f.fill(1001)
+		broker.Update(in10n.ProjectionKey{
+			App:        istructs.AppQName_test1_app1,
+			Projection: PLogUpdatesQName,
+			WS:         istructs.WSID(partitions[0]),
+		}, topOffsets[partitions[0]])
+		waitOffsets()
+		checkValues(3)
+	})
+}
@@ -16,6 +16,15 @@ ALTER WORKSPACE AppWorkspaceWS (
 		WSKindInitializationData varchar(1024) NOT NULL
 	);
 
+	-- external identity (e.g. OpenID Connect provider account) linked to the login
+	-- stored in the app workspace of the external identity login, the linked login may live in another app workspace
+	TABLE ExternalIdentity INHERITS CDoc (
+		Login varchar NOT NULL,
+		AppName varchar NOT NULL,
+		Provider varchar NOT NULL,
+		SubjectHash varchar NOT NULL
+	);
+
 	TYPE CreateLoginParams (
 		Login text NOT NULL,
 		AppName text NOT NULL,
@@ -81,6 +90,44 @@ ALTER WORKSPACE AppWorkspaceWS (
 		NewPwd text NOT NULL
 	);
 
+	TYPE IssueExternalIdentityTokenParams (
+		AppName text NOT NULL,
+		Provider text NOT NULL,
+		AuthorizationCode varchar(32768) NOT NULL,
+		RedirectURI varchar(1024) NOT NULL,
+		CodeVerifier text,
+		Nonce text NOT NULL
+	);
+
+	TYPE IssueExternalIdentityTokenResult (
+		ExternalIdentityToken varchar(32768) NOT NULL,
+		Login text NOT NULL
+	);
+
+	TYPE CreateLoginByExternalIdentityParams (
+		AppName text NOT NULL,
+		WSKindInitializationData text(1024) NOT NULL,
+		ProfileCluster int32 NOT NULL
+	);
+
+	TYPE ExternalIdentityTokenParams (
+		ExternalIdentityToken varchar(32768) NOT NULL
+	);
+
+	TYPE LinkExternalIdentityParams (
+		AppName text NOT NULL
+	);
+
+	TYPE LinkExternalIdentityUnloggedParams (
+		ExternalIdentityToken varchar(32768) NOT NULL,
+		PrincipalToken varchar(32768) NOT NULL
+	);
+
+	TYPE IssuePrincipalTokenByExternalIdentityParams (
+		AppName text NOT NULL,
+		ExternalIdentityToken varchar(32768) NOT NULL
+	);
+
 	VIEW LoginIdx (
 		AppWSID int64 NOT NULL,
 		AppIDLoginHash text NOT NULL,
@@ -88,6 +135,13 @@ ALTER WORKSPACE AppWorkspaceWS (
 		PRIMARY KEY((AppWSID), AppIDLoginHash)
 	) AS RESULT OF ProjectorLoginIdx;
 
+	VIEW ExternalIdentityIdx (
+		AppWSID int64 NOT NULL,
+		AppIDProviderSubjectHash text NOT NULL,
+		Login text NOT NULL,
+		PRIMARY KEY((AppWSID), AppIDProviderSubjectHash)
+	) AS RESULT OF ProjectorExternalIdentityIdx;
+
 	EXTENSION ENGINE BUILTIN (
 		COMMAND CreateLogin (CreateLoginParams, UNLOGGED CreateLoginUnloggedParams);
 		COMMAND ChangePassword (ChangePasswordParams, UNLOGGED ChangePasswordUnloggedParams);
@@ -95,7 +149,12 @@ ALTER WORKSPACE AppWorkspaceWS (
 		QUERY IssuePrincipalToken (IssuePrincipalTokenParams) RETURNS IssuePrincipalTokenResult;
 		QUERY InitiateResetPasswordByEmail (InitiateResetPasswordByEmailParams) RETURNS InitiateResetPasswordByEmailResult;
 		QUERY IssueVerifiedValueTokenForResetPassword (IssueVerifiedValueTokenForResetPasswordParams) RETURNS IssueVerifiedValueTokenForResetPasswordResult;
+		QUERY IssueExternalIdentityToken (IssueExternalIdentityTokenParams) RETURNS IssueExternalIdentityTokenResult;
+		COMMAND CreateLoginByExternalIdentity (CreateLoginByExternalIdentityParams, UNLOGGED ExternalIdentityTokenParams);
+		COMMAND LinkExternalIdentity (LinkExternalIdentityParams, UNLOGGED LinkExternalIdentityUnloggedParams);
+		QUERY IssuePrincipalTokenByExternalIdentity (IssuePrincipalTokenByExternalIdentityParams) RETURNS IssuePrincipalTokenResult;
 		SYNC PROJECTOR ProjectorLoginIdx AFTER INSERT ON Login INTENTS(View(LoginIdx));
+		SYNC PROJECTOR ProjectorExternalIdentityIdx AFTER INSERT ON ExternalIdentity INTENTS(View(ExternalIdentityIdx));
 		PROJECTOR InvokeCreateWorkspaceID_registry AFTER INSERT ON(Login);
 	);
 );
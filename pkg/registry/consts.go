@@ -9,6 +9,7 @@ import (
 	"embed"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	coreutils "github.com/voedger/voedger/pkg/utils"
@@ -32,6 +33,25 @@ const (
 	field_NewPwd            = "NewPwd"
 	field_AppName           = "AppName"
 	field_Login             = "Login"
+
+	field_Provider                 = "Provider"
+	field_AuthorizationCode        = "AuthorizationCode"
+	field_RedirectURI              = "RedirectURI"
+	field_CodeVerifier             = "CodeVerifier"
+	field_Nonce                    = "Nonce"
+	field_ExternalIdentityToken    = "ExternalIdentityToken"
+	field_SubjectHash              = "SubjectHash"
+	field_AppIDProviderSubjectHash = "AppIDProviderSubjectHash"
+	field_PrincipalToken           = "PrincipalToken"
+
+	// external identity token is short-lived: it is only to create login and to issue principal token right after sign in
+	externalIdentityTokenExpiration = 10 * time.Minute
+	oidcDiscoveryPath               = "/.well-known/openid-configuration"
+	oidcRequestTimeout              = 10 * time.Second
+	// provider keys are fetched again on unknown kid not more often than this
+	oidcKeysRefreshInterval = 10 * time.Second
+	randomPasswordLen       = 32
+	externalLoginHashLen    = 32
 )
 
 var (
@@ -45,9 +65,18 @@ var (
 	QNameQueryInitiateResetPasswordByEmail            = appdef.NewQName(RegistryPackage, "InitiateResetPasswordByEmail")
 	QNameQueryIssueVerifiedValueTokenForResetPassword = appdef.NewQName(RegistryPackage, "IssueVerifiedValueTokenForResetPassword")
 	QNameCDocLogin                                    = appdef.NewQName(RegistryPackage, "Login")
+	QNameCDocExternalIdentity                         = appdef.NewQName(RegistryPackage, "ExternalIdentity")
+	QNameViewExternalIdentityIdx                      = appdef.NewQName(RegistryPackage, "ExternalIdentityIdx")
+	QNameProjectorExternalIdentityIdx                 = appdef.NewQName(RegistryPackage, "ProjectorExternalIdentityIdx")
+	QNameQueryIssueExternalIdentityToken              = appdef.NewQName(RegistryPackage, "IssueExternalIdentityToken")
+	QNameCommandCreateLoginByExternalIdentity         = appdef.NewQName(RegistryPackage, "CreateLoginByExternalIdentity")
+	QNameQueryIssuePrincipalTokenByExternalIdentity   = appdef.NewQName(RegistryPackage, "IssuePrincipalTokenByExternalIdentity")
+	QNameCommandLinkExternalIdentity                  = appdef.NewQName(RegistryPackage, "LinkExternalIdentity")
 	qNameProjectorInvokeCreateWorkspaceID_registry    = appdef.NewQName(RegistryPackage, "InvokeCreateWorkspaceID_registry")
 	errPasswordIsIncorrect                            = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "password is incorrect")
 	errLoginOrPasswordIsIncorrect                     = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "login or password is incorrect")
+	errExternalIdentityNotLinked                      = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "external identity is not linked to a login")
+	errLoginExistsLinkExternalIdentity                = coreutils.NewHTTPErrorf(http.StatusConflict, "login already exists, sign in by the login and link the external identity by c.registry.LinkExternalIdentity")
 
 	//go:embed appws.sql
 	schemasFS embed.FS
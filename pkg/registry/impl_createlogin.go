@@ -8,7 +8,6 @@ package registry
 import (
 	"errors"
 	"net/http"
-	"strings"
 
 	"github.com/untillpro/goutils/iterate"
 	"github.com/voedger/voedger/pkg/appdef"
@@ -49,9 +48,7 @@ func execCmdCreateLogin(asp istructs.IAppStructsProvider) istructsmem.ExecComman
 		}
 
 		// see https://dev.untill.com/projects/#!537026
-		if strings.HasPrefix(loginStr, "-") || strings.HasPrefix(loginStr, ".") || strings.HasPrefix(loginStr, " ") ||
-			strings.HasSuffix(loginStr, "-") || strings.HasSuffix(loginStr, ".") || strings.HasSuffix(loginStr, " ") ||
-			strings.Contains(loginStr, "..") || !validLoginRegexp.MatchString(loginStr) {
+		if !isLoginFormatValid(loginStr) {
 			return coreutils.NewHTTPErrorf(http.StatusBadRequest, "incorrect login format: ", loginStr)
 		}
 
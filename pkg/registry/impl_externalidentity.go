@@ -0,0 +1,346 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package registry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/untillpro/goutils/iterate"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/state"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func (r *externalIdentityTokenResult) AsString(name string) string {
+	if name == field_Login {
+		return r.login
+	}
+	return r.token
+}
+
+// q.registry.IssueExternalIdentityToken
+// sys/registry, any app workspace: login is not known yet
+// exchanges authorization code for ID token at the provider, verifies ID token and issues external identity token
+func provideIssueExternalIdentityTokenExec(providers oidcProviders, itokens itokens.ITokens, timeFunc coreutils.TimeFunc) istructsmem.ExecQueryClosure {
+	return func(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+		appQName, err := parseAppName(args.ArgumentObject.AsString(authnz.Field_AppName))
+		if err != nil {
+			return err
+		}
+
+		providerName := args.ArgumentObject.AsString(field_Provider)
+		provider, ok := providers[providerName]
+		if !ok {
+			return coreutils.NewHTTPErrorf(http.StatusBadRequest, "unknown identity provider ", providerName)
+		}
+
+		claims, err := provider.exchangeCode(ctx,
+			args.ArgumentObject.AsString(field_AuthorizationCode),
+			args.ArgumentObject.AsString(field_RedirectURI),
+			args.ArgumentObject.AsString(field_CodeVerifier),
+			args.ArgumentObject.AsString(field_Nonce),
+			timeFunc())
+		if err != nil {
+			return err
+		}
+
+		payload := ExternalIdentityPayload{
+			Login:    externalIdentityLogin(provider, claims),
+			Provider: providerName,
+			Subject:  claims["sub"].(string),
+		}
+		result := &externalIdentityTokenResult{login: payload.Login}
+		if result.token, err = itokens.IssueToken(appQName, externalIdentityTokenExpiration, &payload); err != nil {
+			return fmt.Errorf("external identity token issue failed: %w", err)
+		}
+		return callback(result)
+	}
+}
+
+// c.registry.CreateLoginByExternalIdentity
+// sys/registry, pseudoProfileWSID of the external identity login translated to appWSID
+// creates new login and links external identity to it. Existing login is never linked here, see c.registry.LinkExternalIdentity
+func provideExecCmdCreateLoginByExternalIdentity(asp istructs.IAppStructsProvider, itokens itokens.ITokens) istructsmem.ExecCommandClosure {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		appName := args.ArgumentObject.AsString(authnz.Field_AppName)
+		payload, err := validateExternalIdentityToken(itokens, args.ArgumentUnloggedObject.AsString(field_ExternalIdentityToken), appName)
+		if err != nil {
+			return err
+		}
+
+		if err := checkExternalIdentityAppWSID(asp, payload.Login, args.Workspace, appName); err != nil {
+			return err
+		}
+
+		if err := checkExternalIdentityNotLinked(args.State, args.Workspace, appName, payload); err != nil {
+			return err
+		}
+
+		cdocLoginID, err := GetCDocLoginID(args.State, args.Workspace, appName, payload.Login)
+		if err != nil {
+			return err
+		}
+		if cdocLoginID != istructs.NullRecordID {
+			return errLoginExistsLinkExternalIdentity
+		}
+
+		// new login, password is unknown to anyone, may be set later by ResetPasswordByEmail
+		if err := newCDocLoginByExternalIdentity(args, appName, payload.Login); err != nil {
+			return err
+		}
+		return newCDocExternalIdentity(args, appName, payload.Login, payload)
+	}
+}
+
+// c.registry.LinkExternalIdentity
+// sys/registry, pseudoProfileWSID of the external identity login translated to appWSID
+// links external identity to the existing login. Login owner proves the ownership by the principal token of the login
+func provideExecCmdLinkExternalIdentity(asp istructs.IAppStructsProvider, itokens itokens.ITokens) istructsmem.ExecCommandClosure {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		appName := args.ArgumentObject.AsString(authnz.Field_AppName)
+		payload, err := validateExternalIdentityToken(itokens, args.ArgumentUnloggedObject.AsString(field_ExternalIdentityToken), appName)
+		if err != nil {
+			return err
+		}
+
+		if err := checkExternalIdentityAppWSID(asp, payload.Login, args.Workspace, appName); err != nil {
+			return err
+		}
+
+		principal, err := validateLinkingPrincipalToken(itokens, args.ArgumentUnloggedObject.AsString(field_PrincipalToken), appName)
+		if err != nil {
+			return err
+		}
+
+		if err := checkExternalIdentityNotLinked(args.State, args.Workspace, appName, payload); err != nil {
+			return err
+		}
+
+		return newCDocExternalIdentity(args, appName, principal.Login, payload)
+	}
+}
+
+func newCDocExternalIdentity(args istructs.ExecCommandArgs, appName string, login string, payload ExternalIdentityPayload) error {
+	kb, err := args.State.KeyBuilder(state.Record, QNameCDocExternalIdentity)
+	if err != nil {
+		return err
+	}
+	cdocExternalIdentity, err := args.Intents.NewValue(kb)
+	if err != nil {
+		return err
+	}
+	cdocExternalIdentity.PutRecordID(appdef.SystemField_ID, 2)
+	cdocExternalIdentity.PutString(field_Login, login)
+	cdocExternalIdentity.PutString(authnz.Field_AppName, appName)
+	cdocExternalIdentity.PutString(field_Provider, payload.Provider)
+	cdocExternalIdentity.PutString(field_SubjectHash, GetLoginHash(payload.Subject))
+	return nil
+}
+
+func checkExternalIdentityNotLinked(st istructs.IState, appWSID istructs.WSID, appName string, payload ExternalIdentityPayload) error {
+	linkedLogin, err := getExternalIdentityLogin(st, appWSID, appName, payload)
+	if err != nil {
+		return err
+	}
+	if len(linkedLogin) > 0 {
+		return coreutils.NewHTTPErrorf(http.StatusConflict, "external identity is already linked to a login")
+	}
+	return nil
+}
+
+// returns the principal of the login the external identity is linked to
+func validateLinkingPrincipalToken(itokens itokens.ITokens, token string, appName string) (principal payloads.PrincipalPayload, err error) {
+	gp, err := itokens.ValidateToken(token, &principal)
+	if err != nil {
+		return principal, coreutils.NewHTTPError(http.StatusUnauthorized, err)
+	}
+	if gp.AppQName.String() != appName {
+		return principal, coreutils.NewHTTPErrorf(http.StatusUnauthorized, "principal token is issued for ", gp.AppQName, " but ", appName, " is requested")
+	}
+	if principal.ProfileWSID == istructs.NullWSID || principal.IsAPIToken || principal.SubjectKind != istructs.SubjectKind_User {
+		return principal, coreutils.NewHTTPErrorf(http.StatusForbidden, "principal token of the user login is required")
+	}
+	return principal, nil
+}
+
+func newCDocLoginByExternalIdentity(args istructs.ExecCommandArgs, appName string, login string) error {
+	randomPwd := make([]byte, randomPasswordLen)
+	if _, err := rand.Read(randomPwd); err != nil {
+		// notest
+		return err
+	}
+	pwdSaltedHash, err := GetPasswordSaltedHash(hex.EncodeToString(randomPwd))
+	if err != nil {
+		return err
+	}
+
+	kb, err := args.State.KeyBuilder(state.Record, QNameCDocLogin)
+	if err != nil {
+		return err
+	}
+	cdocLogin, err := args.Intents.NewValue(kb)
+	if err != nil {
+		return err
+	}
+	cdocLogin.PutRecordID(appdef.SystemField_ID, 1)
+	cdocLogin.PutInt32(authnz.Field_ProfileCluster, args.ArgumentObject.AsInt32(authnz.Field_ProfileCluster))
+	cdocLogin.PutBytes(field_PwdHash, pwdSaltedHash)
+	cdocLogin.PutString(authnz.Field_AppName, appName)
+	cdocLogin.PutInt32(authnz.Field_SubjectKind, int32(istructs.SubjectKind_User))
+	cdocLogin.PutString(authnz.Field_LoginHash, GetLoginHash(login))
+	cdocLogin.PutString(authnz.Field_WSKindInitializationData, args.ArgumentObject.AsString(authnz.Field_WSKindInitializationData))
+	return nil
+}
+
+// q.registry.IssuePrincipalTokenByExternalIdentity
+// sys/registry, pseudoProfileWSID of the external identity login translated to appWSID
+func provideIssuePrincipalTokenByExternalIdentityExec(asp istructs.IAppStructsProvider, itokens itokens.ITokens) istructsmem.ExecQueryClosure {
+	return func(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+		appName := args.ArgumentObject.AsString(authnz.Field_AppName)
+		payload, err := validateExternalIdentityToken(itokens, args.ArgumentObject.AsString(field_ExternalIdentityToken), appName)
+		if err != nil {
+			return err
+		}
+
+		appWSAmount, err := externalIdentityAppWSAmount(asp, appName)
+		if err != nil {
+			return err
+		}
+		if err := CheckAppWSID(payload.Login, args.Workspace, appWSAmount); err != nil {
+			return err
+		}
+
+		linkedLogin, err := getExternalIdentityLogin(args.State, args.Workspace, appName, payload)
+		if err != nil {
+			return err
+		}
+		if len(linkedLogin) == 0 {
+			return errExternalIdentityNotLinked
+		}
+
+		// linked login may live in another app workspace than the external identity login
+		linkedLoginAppWSID := getLoginAppWSID(linkedLogin, args.Workspace.ClusterID(), appWSAmount)
+		cdocLogin, doesLoginExist, err := GetCDocLogin(linkedLogin, args.State, linkedLoginAppWSID, appName)
+		if err != nil {
+			return err
+		}
+		if !doesLoginExist {
+			return errLoginDoesNotExist(linkedLogin)
+		}
+
+		appQName, err := parseAppName(appName)
+		if err != nil {
+			// notest: checked already by validateExternalIdentityToken
+			return err
+		}
+		return issuePrincipalToken(cdocLogin, linkedLogin, appQName, itokens, callback)
+	}
+}
+
+// sys/registry, appWorkspace, triggered by CDoc<ExternalIdentity>
+var projectorExternalIdentityIdx = func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) (err error) {
+	return iterate.ForEachError(event.CUDs, func(rec istructs.ICUDRow) error {
+		if rec.QName() != QNameCDocExternalIdentity {
+			return nil
+		}
+		kb, err := s.KeyBuilder(state.View, QNameViewExternalIdentityIdx)
+		if err != nil {
+			return err
+		}
+		kb.PutInt64(field_AppWSID, int64(event.Workspace()))
+		kb.PutString(field_AppIDProviderSubjectHash, externalIdentityIdxKey(rec.AsString(authnz.Field_AppName), rec.AsString(field_Provider), rec.AsString(field_SubjectHash)))
+
+		vb, err := intents.NewValue(kb)
+		if err != nil {
+			return err
+		}
+		vb.PutString(field_Login, rec.AsString(field_Login))
+		return nil
+	})
+}
+
+// empty login means external identity is not linked to a login
+func getExternalIdentityLogin(st istructs.IState, appWSID istructs.WSID, appName string, payload ExternalIdentityPayload) (login string, err error) {
+	kb, err := st.KeyBuilder(state.View, QNameViewExternalIdentityIdx)
+	if err != nil {
+		return "", err
+	}
+	kb.PutInt64(field_AppWSID, int64(appWSID))
+	kb.PutString(field_AppIDProviderSubjectHash, externalIdentityIdxKey(appName, payload.Provider, GetLoginHash(payload.Subject)))
+	idx, ok, err := st.CanExist(kb)
+	if err != nil || !ok {
+		return "", err
+	}
+	return idx.AsString(field_Login), nil
+}
+
+func externalIdentityIdxKey(appName, provider, subjectHash string) string {
+	return appName + "/" + provider + "/" + subjectHash
+}
+
+func validateExternalIdentityToken(itokens itokens.ITokens, token string, appName string) (payload ExternalIdentityPayload, err error) {
+	gp, err := itokens.ValidateToken(token, &payload)
+	if err != nil {
+		return payload, coreutils.NewHTTPError(http.StatusUnauthorized, err)
+	}
+	if gp.AppQName.String() != appName {
+		return payload, coreutils.NewHTTPErrorf(http.StatusUnauthorized, "external identity token is issued for ", gp.AppQName, " but ", appName, " is requested")
+	}
+	return payload, nil
+}
+
+func checkExternalIdentityAppWSID(asp istructs.IAppStructsProvider, login string, appWSID istructs.WSID, appName string) error {
+	appWSAmount, err := externalIdentityAppWSAmount(asp, appName)
+	if err != nil {
+		return err
+	}
+	return CheckAppWSID(login, appWSID, appWSAmount)
+}
+
+func externalIdentityAppWSAmount(asp istructs.IAppStructsProvider, appName string) (istructs.AppWSAmount, error) {
+	appQName, err := parseAppName(appName)
+	if err != nil {
+		return 0, err
+	}
+	as, err := asp.AppStructs(appQName)
+	if err != nil {
+		if errors.Is(err, istructs.ErrAppNotFound) {
+			return 0, coreutils.NewHTTPErrorf(http.StatusBadRequest, "unknown application ", appName)
+		}
+		return 0, err
+	}
+	return as.WSAmount(), nil
+}
+
+// Returns login for external identity: provider name and subject hash.
+// Verified email is used instead only if the provider is trusted to verify emails
+func externalIdentityLogin(provider *oidcProvider, claims jwt.MapClaims) string {
+	if verified, _ := claims["email_verified"].(bool); verified && provider.TrustEmail {
+		if email, _ := claims["email"].(string); isLoginFormatValid(strings.ToLower(email)) {
+			return strings.ToLower(email)
+		}
+	}
+	sub := claims["sub"].(string)
+	return provider.name + "-" + GetLoginHash(claims["iss"].(string) + "/" + sub)[:externalLoginHashLen]
+}
+
+func parseAppName(appName string) (appQName istructs.AppQName, err error) {
+	if appQName, err = istructs.ParseAppQName(appName); err != nil {
+		return appQName, coreutils.NewHTTPErrorf(http.StatusBadRequest, "failed to parse app qualified name ", appName, ": ", err)
+	}
+	return appQName, nil
+}
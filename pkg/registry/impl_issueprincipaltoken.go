@@ -79,24 +79,28 @@ func provideIssuePrincipalTokenExec(asp istructs.IAppStructsProvider, itokens it
 			return errLoginOrPasswordIsIncorrect
 		}
 
-		result := &iptRR{
-			profileWSID:          cdocLogin.AsInt64(authnz.Field_WSID),
-			profileCreationError: cdocLogin.AsString(authnz.Field_WSError),
-		}
-		if result.profileWSID == 0 || len(result.profileCreationError) > 0 {
-			return callback(result)
-		}
-
-		// issue principal token
-		principalPayload := payloads.PrincipalPayload{
-			Login:       args.ArgumentObject.AsString(authnz.Field_Login),
-			SubjectKind: istructs.SubjectKindType(cdocLogin.AsInt32(authnz.Field_SubjectKind)),
-			ProfileWSID: istructs.WSID(result.profileWSID),
-		}
-		if result.principalToken, err = itokens.IssueToken(appQName, authnz.DefaultPrincipalTokenExpiration, &principalPayload); err != nil {
-			return fmt.Errorf("principal token issue failed: %w", err)
-		}
+		return issuePrincipalToken(cdocLogin, login, appQName, itokens, callback)
+	}
+}
 
+func issuePrincipalToken(cdocLogin istructs.IStateValue, login string, appQName istructs.AppQName, itokens itokens.ITokens, callback istructs.ExecQueryCallback) (err error) {
+	result := &iptRR{
+		profileWSID:          cdocLogin.AsInt64(authnz.Field_WSID),
+		profileCreationError: cdocLogin.AsString(authnz.Field_WSError),
+	}
+	if result.profileWSID == 0 || len(result.profileCreationError) > 0 {
 		return callback(result)
 	}
+
+	// issue principal token
+	principalPayload := payloads.PrincipalPayload{
+		Login:       login,
+		SubjectKind: istructs.SubjectKindType(cdocLogin.AsInt32(authnz.Field_SubjectKind)),
+		ProfileWSID: istructs.WSID(result.profileWSID),
+	}
+	if result.principalToken, err = itokens.IssueToken(appQName, authnz.DefaultPrincipalTokenExpiration, &principalPayload); err != nil {
+		return fmt.Errorf("principal token issue failed: %w", err)
+	}
+
+	return callback(result)
 }
@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func newOIDCProviders(cfg OIDCProviders) oidcProviders {
+	res := oidcProviders{}
+	for name, p := range cfg {
+		res[name] = &oidcProvider{
+			OIDCProvider: p,
+			name:         name,
+			httpClient:   &http.Client{Timeout: oidcRequestTimeout},
+			keys:         map[string]crypto.PublicKey{},
+		}
+	}
+	return res
+}
+
+// Exchanges authorization code for ID token and returns verified ID token claims
+func (p *oidcProvider) exchangeCode(ctx context.Context, code, redirectURI, codeVerifier, nonce string, now time.Time) (claims jwt.MapClaims, err error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURI},
+	}
+	if len(codeVerifier) > 0 {
+		form.Set("code_verifier", codeVerifier)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(coreutils.ContentType, "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+
+	tokenResp := struct {
+		IDToken string `json:"id_token"`
+	}{}
+	if err := p.doJSON(req, &tokenResp); err != nil {
+		return nil, coreutils.NewHTTPErrorf(http.StatusUnauthorized, "failed to exchange authorization code: ", err)
+	}
+	if len(tokenResp.IDToken) == 0 {
+		return nil, coreutils.NewHTTPErrorf(http.StatusUnauthorized, "identity provider returned no id_token")
+	}
+
+	if claims, err = p.verifyIDToken(ctx, tokenResp.IDToken, nonce, now); err != nil {
+		return nil, coreutils.NewHTTPErrorf(http.StatusUnauthorized, "invalid id_token: ", err)
+	}
+	return claims, nil
+}
+
+func (p *oidcProvider) verifyIDToken(ctx context.Context, idToken, nonce string, now time.Time) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	// claims are validated below by the registry time, not by jwt.TimeFunc
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	if _, err := parser.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA, *jwt.SigningMethodEd25519:
+		default:
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, kid, now)
+	}); err != nil {
+		return nil, err
+	}
+
+	if !claims.VerifyIssuer(p.Issuer, true) {
+		return nil, fmt.Errorf("unexpected issuer %v", claims["iss"])
+	}
+	if !claims.VerifyAudience(p.ClientID, true) {
+		return nil, fmt.Errorf("unexpected audience %v", claims["aud"])
+	}
+	if !claims.VerifyExpiresAt(now.Unix(), true) {
+		return nil, errors.New("token is expired")
+	}
+	if len(nonce) == 0 || claims["nonce"] != nonce {
+		return nil, errors.New("nonce mismatch")
+	}
+	if sub, _ := claims["sub"].(string); len(sub) == 0 {
+		return nil, errors.New("sub claim is empty")
+	}
+	return claims, nil
+}
+
+// Returns provider key by kid. Provider JWKS is fetched again if the key is unknown, e.g. after provider key rotation.
+// JWKS is fetched not more often than once per oidcKeysRefreshInterval, so that tokens with random kids do not flood the provider
+func (p *oidcProvider) publicKey(ctx context.Context, kid string, now time.Time) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	refresh := !ok && !p.keysRefreshing && now.Sub(p.keysRefreshedAt) >= oidcKeysRefreshInterval
+	if refresh {
+		p.keysRefreshing = true
+		p.keysRefreshedAt = now
+	}
+	jwksURI := p.JWKSURI
+	p.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+	if !refresh {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+
+	keys, err := p.fetchKeys(ctx, jwksURI)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keysRefreshing = false
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch provider keys: %w", err)
+	}
+	p.keys = keys
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+func (p *oidcProvider) fetchKeys(ctx context.Context, jwksURI string) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	jwks := itokensjwt.JWKS{}
+	if err := p.doJSON(req, &jwks); err != nil {
+		return nil, err
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if key, err := jwk.PublicKey(); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+// Discovers provider endpoints if not configured
+func (p *oidcProvider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovered || len(p.TokenEndpoint) > 0 && len(p.JWKSURI) > 0 {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.Issuer, "/")+oidcDiscoveryPath, nil)
+	if err != nil {
+		return err
+	}
+	cfg := struct {
+		Issuer        string `json:"issuer"`
+		TokenEndpoint string `json:"token_endpoint"`
+		JWKSURI       string `json:"jwks_uri"`
+	}{}
+	if err := p.doJSON(req, &cfg); err != nil {
+		return coreutils.NewHTTPErrorf(http.StatusServiceUnavailable, "failed to discover identity provider ", p.name, ": ", err)
+	}
+	if cfg.Issuer != p.Issuer {
+		return coreutils.NewHTTPErrorf(http.StatusServiceUnavailable, "identity provider ", p.name, " discovered issuer ", cfg.Issuer, " does not match ", p.Issuer)
+	}
+	if len(p.TokenEndpoint) == 0 {
+		p.TokenEndpoint = cfg.TokenEndpoint
+	}
+	if len(p.JWKSURI) == 0 {
+		p.JWKSURI = cfg.JWKSURI
+	}
+	p.discovered = true
+	return nil
+}
+
+func (p *oidcProvider) doJSON(req *http.Request, result interface{}) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %d %s", req.Method, req.URL, resp.StatusCode, body)
+	}
+	return json.Unmarshal(body, result)
+}
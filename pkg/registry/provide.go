@@ -15,7 +15,7 @@ import (
 )
 
 func Provide(cfg *istructsmem.AppConfigType, asp istructs.IAppStructsProvider, itokens itokens.ITokens,
-	federation coreutils.IFederation) parser.PackageFS {
+	federation coreutils.IFederation, oidc OIDCProviders, timeFunc coreutils.TimeFunc) parser.PackageFS {
 	cfg.Resources.Add(istructsmem.NewCommandFunction(
 		QNameCommandCreateLogin,
 		execCmdCreateLogin(asp),
@@ -24,6 +24,7 @@ func Provide(cfg *istructsmem.AppConfigType, asp istructs.IAppStructsProvider, i
 	cfg.Resources.Add(istructsmem.NewQueryFunction(
 		appdef.NewQName(RegistryPackage, "IssuePrincipalToken"),
 		provideIssuePrincipalTokenExec(asp, itokens)))
+	provideExternalIdentity(cfg, asp, itokens, oidc, timeFunc)
 	provideChangePassword(cfg)
 	provideResetPassword(cfg, asp, itokens, federation)
 	cfg.AddAsyncProjectors(provideAsyncProjectorFactoryInvokeCreateWorkspaceID(federation, cfg.Name, itokens))
@@ -46,6 +47,30 @@ func provideAsyncProjectorFactoryInvokeCreateWorkspaceID(federation coreutils.IF
 	}
 }
 
+func provideExternalIdentity(cfg *istructsmem.AppConfigType, asp istructs.IAppStructsProvider, itokens itokens.ITokens, oidc OIDCProviders, timeFunc coreutils.TimeFunc) {
+	cfg.Resources.Add(istructsmem.NewQueryFunction(
+		QNameQueryIssueExternalIdentityToken,
+		provideIssueExternalIdentityTokenExec(newOIDCProviders(oidc), itokens, timeFunc)))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCommandCreateLoginByExternalIdentity,
+		provideExecCmdCreateLoginByExternalIdentity(asp, itokens)))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCommandLinkExternalIdentity,
+		provideExecCmdLinkExternalIdentity(asp, itokens)))
+	cfg.Resources.Add(istructsmem.NewQueryFunction(
+		QNameQueryIssuePrincipalTokenByExternalIdentity,
+		provideIssuePrincipalTokenByExternalIdentityExec(asp, itokens)))
+}
+
+func ProvideSyncProjectorExternalIdentityIdxFactory() istructs.ProjectorFactory {
+	return func(partition istructs.PartitionID) istructs.Projector {
+		return istructs.Projector{
+			Name: QNameProjectorExternalIdentityIdx,
+			Func: projectorExternalIdentityIdx,
+		}
+	}
+}
+
 func ProvideSyncProjectorLoginIdxFactory() istructs.ProjectorFactory {
 	return func(partition istructs.PartitionID) istructs.Projector {
 		return istructs.Projector{
@@ -4,7 +4,14 @@
 
 package registry
 
-import "github.com/voedger/voedger/pkg/istructs"
+import (
+	"crypto"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/voedger/voedger/pkg/istructs"
+)
 
 // for both Initiate*ResetPassword and Issue*ForResetPassword
 type result struct {
@@ -12,3 +19,49 @@ type result struct {
 	token       string
 	profileWSID int64
 }
+
+// OIDCProvider is an OpenID Connect identity provider the users can sign in by
+type OIDCProvider struct {
+	// `iss` claim of ID tokens. Also used to discover endpoints if TokenEndpoint or JWKSURI is empty
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// Authorization code is exchanged for ID token here
+	TokenEndpoint string
+	// ID tokens are verified by keys from here
+	JWKSURI string
+	// true -> verified email claim is used as the login of new logins
+	// false (default) -> login is built from the provider name and subject only
+	TrustEmail bool
+}
+
+// OIDCProviders are identity providers by name. Name goes to `Provider` param of registry functions
+type OIDCProviders map[string]OIDCProvider
+
+// ExternalIdentityPayload is issued by q.registry.IssueExternalIdentityToken when the user is authenticated by the identity provider
+type ExternalIdentityPayload struct {
+	Login    string
+	Provider string
+	Subject  string
+}
+
+type oidcProvider struct {
+	OIDCProvider
+	name       string
+	httpClient *http.Client
+	mu         sync.Mutex
+	discovered bool
+	keys       map[string]crypto.PublicKey // by kid
+	// last JWKS fetch start, used to rate-limit fetches on unknown kids
+	keysRefreshedAt time.Time
+	keysRefreshing  bool
+}
+
+type oidcProviders map[string]*oidcProvider
+
+// q.registry.IssueExternalIdentityToken
+type externalIdentityTokenResult struct {
+	istructs.NullObject
+	token string
+	login string
+}
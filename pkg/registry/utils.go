@@ -9,6 +9,7 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -18,15 +19,20 @@ import (
 )
 
 func CheckAppWSID(login string, urlWSID istructs.WSID, appWSAmount istructs.AppWSAmount) error {
-	crc16 := coreutils.CRC16([]byte(login))
-	appWSID := istructs.WSID(crc16%uint16(appWSAmount)) + istructs.FirstBaseAppWSID
-	expectedAppWSID := istructs.NewWSID(urlWSID.ClusterID(), appWSID)
+	expectedAppWSID := getLoginAppWSID(login, urlWSID.ClusterID(), appWSAmount)
 	if expectedAppWSID != urlWSID {
 		return coreutils.NewHTTPErrorf(http.StatusForbidden, "wrong url WSID: ", expectedAppWSID, " expected, ", urlWSID, " got")
 	}
 	return nil
 }
 
+// Returns the app workspace the login lives in
+func getLoginAppWSID(login string, clusterID istructs.ClusterID, appWSAmount istructs.AppWSAmount) istructs.WSID {
+	crc16 := coreutils.CRC16([]byte(login))
+	appWSID := istructs.WSID(crc16%uint16(appWSAmount)) + istructs.FirstBaseAppWSID
+	return istructs.NewWSID(clusterID, appWSID)
+}
+
 // istructs.NullRecordID means not found
 func GetCDocLoginID(st istructs.IState, appWSID istructs.WSID, appName string, login string) (cdocLoginID istructs.RecordID, err error) {
 	kb, err := st.KeyBuilder(state.View, QNameViewLoginIdx)
@@ -34,6 +40,7 @@ func GetCDocLoginID(st istructs.IState, appWSID istructs.WSID, appName string, l
 		return istructs.NullRecordID, err
 	}
 	loginHash := GetLoginHash(login)
+	kb.PutInt64(state.Field_WSID, int64(appWSID))
 	kb.PutInt64(field_AppWSID, int64(appWSID))
 	kb.PutString(field_AppIDLoginHash, appName+"/"+loginHash)
 	loginIdx, ok, err := st.CanExist(kb)
@@ -62,11 +69,19 @@ func GetCDocLogin(login string, st istructs.IState, appWSID istructs.WSID, appNa
 	if err != nil {
 		return nil, doesLoginExist, err
 	}
+	kb.PutInt64(state.Field_WSID, int64(appWSID))
 	kb.PutRecordID(state.Field_ID, cdocLoginID)
 	cdocLogin, err = st.MustExist(kb)
 	return
 }
 
+// see https://dev.untill.com/projects/#!537026
+func isLoginFormatValid(login string) bool {
+	return !strings.HasPrefix(login, "-") && !strings.HasPrefix(login, ".") && !strings.HasPrefix(login, " ") &&
+		!strings.HasSuffix(login, "-") && !strings.HasSuffix(login, ".") && !strings.HasSuffix(login, " ") &&
+		!strings.Contains(login, "..") && validLoginRegexp.MatchString(login)
+}
+
 func GetLoginHash(login string) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(login)))
 }
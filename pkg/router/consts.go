@@ -31,10 +31,21 @@ const (
 	DefaultConnectionsLimit       = 10000
 	DefaultRouterPort             = 8822
 	DefaultRouterConnectionsLimit = 10000
+	DefaultWSCallsLimit           = 100
 	//Timeouts should be greater than NATS timeouts to proper use in browser(multiply responses)
 	DefaultRouterReadTimeout  = 15
 	DefaultRouterWriteTimeout = 15
 	hours24                   = 24 * time.Hour
+	JWKSPath                  = "/.well-known/jwks.json"
+)
+
+// websocket message types
+const (
+	wsMessageType_Call        = "call"
+	wsMessageType_Subscribe   = "subscribe"
+	wsMessageType_Unsubscribe = "unsubscribe"
+	wsMessageType_Response    = "response"
+	wsMessageType_N10n        = "n10n"
 )
 
 var (
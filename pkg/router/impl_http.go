@@ -124,6 +124,12 @@ func (s *httpService) registerHandlers(busTimeout time.Duration, appsWSAmount ma
 			Methods("POST", "GET", "OPTIONS").
 			Name("blob read")
 	}
+	if s.JWKS != nil {
+		s.router.HandleFunc(JWKSPath, corsHandler(jwksHandler(s.JWKS))).Methods("GET", "OPTIONS").Name("jwks")
+	}
+	for path, handler := range s.AdminHandlers {
+		s.router.Handle(path, handler).Name("admin " + path)
+	}
 	s.router.HandleFunc(fmt.Sprintf("/api/{%s}/{%s}/{%s:[0-9]+}/{%s:[a-zA-Z0-9_/.]+}", AppOwner, AppName,
 		WSID, ResourceName), corsHandler(RequestHandler(s.bus, busTimeout, appsWSAmount))).
 		Methods("POST", "PATCH", "OPTIONS").Name("api")
@@ -132,6 +138,7 @@ func (s *httpService) registerHandlers(busTimeout time.Duration, appsWSAmount ma
 	s.router.Handle("/n10n/subscribe", corsHandler(s.subscribeHandler())).Methods("GET")
 	s.router.Handle("/n10n/unsubscribe", corsHandler(s.unSubscribeHandler())).Methods("GET")
 	s.router.Handle("/n10n/update/{offset:[0-9]{1,10}}", corsHandler(s.updateHandler()))
+	s.router.Handle("/ws", s.wsHandler()).Methods("GET")
 
 	// pprof profile
 	s.router.Handle("/debug/pprof", http.HandlerFunc(pprof.Index))
@@ -197,6 +204,20 @@ func corsHandler(h http.Handler) http.HandlerFunc {
 	}
 }
 
+func jwksHandler(jwks func() ([]byte, error)) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		b, err := jwks()
+		if err != nil {
+			WriteTextResponse(resp, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.Header().Set(coreutils.ContentType, coreutils.ApplicationJSON)
+		if _, err := resp.Write(b); err != nil {
+			log.Println("failed to write jwks response:", err)
+		}
+	}
+}
+
 func checkHandler() http.HandlerFunc {
 	return func(resp http.ResponseWriter, req *http.Request) {
 		if _, err := resp.Write([]byte("ok")); err != nil {
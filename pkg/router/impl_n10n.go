@@ -22,6 +22,15 @@ import (
 )
 
 /*
+Resumable subscription: "Resume": true and optional "Offset" last seen by the client in each ProjectionKey.
+The latest offset is sent if it is greater than the last seen one, offset 0 means that updates could be missed.
+
+curl -G --data-urlencode "payload={\"SubjectLogin\": \"paa\", \"Resume\": true, \"ProjectionKey\":[{\"App\":\"Application\",\"Projection\":\"paa.price\",\"WS\":1,\"Offset\":12}]}" https://alpha2.dev.untill.ru/n10n/channel -H "Content-Type: application/json"
+
+Changed rows: "Rows": true, data of each event is {"Offset":13,"Rows":[{"Key":{...},"Value":{...}}]}, "Rows" is omitted if they are unknown.
+
+curl -G --data-urlencode "payload={\"SubjectLogin\": \"paa\", \"Rows\": true, \"ProjectionKey\":[{\"App\":\"Application\",\"Projection\":\"paa.price\",\"WS\":1}]}" https://alpha2.dev.untill.ru/n10n/channel -H "Content-Type: application/json"
+
 curl -G --data-urlencode "payload={\"SubjectLogin\": \"paa\", \"ProjectionKey\":[{\"App\":\"Application\",\"Projection\":\"paa.price\",\"WS\":1}, {\"App\":\"Application\",\"Projection\":\"paa.wine_price\",\"WS\":1}]}" https://alpha2.dev.untill.ru/n10n/channel -H "Content-Type: application/json"
 */
 func (s *httpService) subscribeAndWatchHandler() http.HandlerFunc {
@@ -55,6 +64,12 @@ func (s *httpService) subscribeAndWatchHandler() http.HandlerFunc {
 			http.Error(rw, "Streaming unsupported!", http.StatusInternalServerError)
 			return
 		}
+		rowsBroker, ok := s.n10n.(in10n.IN10nRowsBroker)
+		if urlParams.Rows && !ok {
+			logger.Error(in10n.ErrRowsNotSupported)
+			http.Error(rw, in10n.ErrRowsNotSupported.Error(), n10nErrorToStatusCode(in10n.ErrRowsNotSupported))
+			return
+		}
 		channel, err = s.n10n.NewChannel(urlParams.SubjectLogin, hours24)
 		if err != nil {
 			logger.Error(err)
@@ -66,7 +81,7 @@ func (s *httpService) subscribeAndWatchHandler() http.HandlerFunc {
 			return
 		}
 		for _, projection := range urlParams.ProjectionKey {
-			err = s.n10n.Subscribe(channel, projection)
+			err = s.subscribe(channel, projection, urlParams.Resume)
 			if err != nil {
 				logger.Error(err)
 				http.Error(rw, "subscribe failed: "+err.Error(), n10nErrorToStatusCode(err))
@@ -77,6 +92,16 @@ func (s *httpService) subscribeAndWatchHandler() http.HandlerFunc {
 		ch := make(chan in10nmem.UpdateUnit)
 		go func() {
 			defer close(ch)
+			if urlParams.Rows {
+				rowsBroker.WatchChannelRows(req.Context(), channel, func(projection in10n.ProjectionKey, offset istructs.Offset, rows []byte) {
+					ch <- in10nmem.UpdateUnit{
+						Projection: projection,
+						Offset:     offset,
+						Rows:       rows,
+					}
+				})
+				return
+			}
 			s.n10n.WatchChannel(req.Context(), channel, func(projection in10n.ProjectionKey, offset istructs.Offset) {
 				var unit = in10nmem.UpdateUnit{
 					Projection: projection,
@@ -100,7 +125,15 @@ func (s *httpService) subscribeAndWatchHandler() http.HandlerFunc {
 					logger.Error("failed to write projection key event to client:", err)
 				}
 			}
-			offset, _ = json.Marshal(&result.Offset) // error impossible
+			if urlParams.Rows {
+				offset, err = json.Marshal(&rowsDataType{Offset: result.Offset, Rows: result.Rows})
+				if err != nil {
+					// rows are not valid JSON
+					offset, _ = json.Marshal(&rowsDataType{Offset: result.Offset})
+				}
+			} else {
+				offset, _ = json.Marshal(&result.Offset) // error impossible
+			}
 			if _, err = fmt.Fprintf(rw, "data: %s\n\n", offset); err != nil {
 				logger.Error("failed to write projection key offset to client:", err)
 			}
@@ -109,10 +142,23 @@ func (s *httpService) subscribeAndWatchHandler() http.HandlerFunc {
 	}
 }
 
+// subscribe resumes the subscription after the offset last seen by the client if resume is true
+func (s *httpService) subscribe(channel in10n.ChannelID, projection projectionKeyParamType, resume bool) error {
+	if !resume {
+		return s.n10n.Subscribe(channel, projection.ProjectionKey)
+	}
+	broker, ok := s.n10n.(in10n.IN10nResumableBroker)
+	if !ok {
+		return in10n.ErrResumeNotSupported
+	}
+	return broker.SubscribeFrom(channel, projection.ProjectionKey, projection.Offset)
+}
+
 func n10nErrorToStatusCode(err error) int {
 	switch {
 	case errors.Is(err, in10n.ErrChannelDoesNotExist), errors.Is(err, in10nmemv1.ErrMetricDoesNotExists),
-		errors.Is(err, in10n.ErrChannelDoesNotExist):
+		errors.Is(err, in10n.ErrChannelDoesNotExist), errors.Is(err, in10n.ErrResumeNotSupported),
+		errors.Is(err, in10n.ErrRowsNotSupported):
 		return http.StatusBadRequest
 	case errors.Is(err, in10n.ErrQuotaExceeded_Subsciptions), errors.Is(err, in10n.ErrQuotaExceeded_SubsciptionsPerSubject),
 		errors.Is(err, in10n.ErrQuotaExceeded_Channels), errors.Is(err, in10n.ErrQuotaExceeded_ChannelsPerSubject):
@@ -133,7 +179,7 @@ func (s *httpService) subscribeHandler() http.HandlerFunc {
 		}
 		logger.Info("n10n subscribe: ", parameters)
 		for _, projection := range parameters.ProjectionKey {
-			err = s.n10n.Subscribe(parameters.Channel, projection)
+			err = s.subscribe(parameters.Channel, projection, parameters.Resume)
 			if err != nil {
 				logger.Error(err)
 				http.Error(rw, "subscribe failed: "+err.Error(), n10nErrorToStatusCode(err))
@@ -155,7 +201,7 @@ func (s *httpService) unSubscribeHandler() http.HandlerFunc {
 		}
 		logger.Info("n10n unsubscribe: ", parameters)
 		for _, projection := range parameters.ProjectionKey {
-			err = s.n10n.Unsubscribe(parameters.Channel, projection)
+			err = s.n10n.Unsubscribe(parameters.Channel, projection.ProjectionKey)
 			if err != nil {
 				logger.Error(err)
 				http.Error(rw, err.Error(), n10nErrorToStatusCode(err))
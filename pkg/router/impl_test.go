@@ -13,11 +13,14 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
 
 	"github.com/voedger/voedger/staging/src/github.com/untillpro/ibusmem"
 
@@ -25,10 +28,12 @@ import (
 
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/pipeline"
+	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
 const (
-	testWSID = istructs.MaxPseudoBaseWSID + 1
+	testWSID         = istructs.MaxPseudoBaseWSID + 1
+	testWSCallsLimit = 2
 )
 
 var (
@@ -271,6 +276,21 @@ func TestCheck(t *testing.T) {
 	expectOKRespPlainText(t, resp)
 }
 
+func TestJWKS(t *testing.T) {
+	setUp(t, func(requestCtx context.Context, sender ibus.ISender, request ibus.Request) {
+	}, 1*time.Second)
+	defer tearDown()
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d%s", router.port(), JWKSPath))
+	require.Nil(t, err, err)
+	defer resp.Body.Close()
+	respBodyBytes, err := io.ReadAll(resp.Body)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, coreutils.ApplicationJSON, resp.Header.Get(coreutils.ContentType))
+	require.JSONEq(t, `{"keys":[]}`, string(respBodyBytes))
+}
+
 func Test404(t *testing.T) {
 	setUp(t, func(requestCtx context.Context, sender ibus.ISender, request ibus.Request) {
 	}, 1*time.Second)
@@ -383,6 +403,8 @@ func setUp(t *testing.T, handlerFunc func(requestCtx context.Context, sender ibu
 		WriteTimeout:     DefaultWriteTimeout,
 		ReadTimeout:      DefaultReadTimeout,
 		ConnectionsLimit: DefaultConnectionsLimit,
+		WSCallsLimit:     testWSCallsLimit,
+		JWKS:             func() ([]byte, error) { return []byte(`{"keys":[]}`), nil },
 	}
 	bus := ibusmem.Provide(func(requestCtx context.Context, sender ibus.ISender, request ibus.Request) {
 		router.handler(requestCtx, sender, request)
@@ -456,3 +478,110 @@ func expectResp(t *testing.T, resp *http.Response, contentType string, statusCod
 	require.Equal(t, []string{"*"}, resp.Header["Access-Control-Allow-Origin"])
 	require.Equal(t, []string{"Accept, Content-Type, Content-Length, Accept-Encoding, Authorization"}, resp.Header["Access-Control-Allow-Headers"])
 }
+
+func TestWebSocket(t *testing.T) {
+	require := require.New(t)
+	release := make(chan struct{})
+	setUp(t, func(requestCtx context.Context, sender ibus.ISender, request ibus.Request) {
+		require.Equal(testWSID, istructs.WSID(request.WSID))
+		require.Equal("test1/app1", request.AppQName)
+		switch request.Resource {
+		case "c.sys.CUD":
+			sender.SendResponse(ibus.Response{
+				ContentType: coreutils.ApplicationJSON,
+				StatusCode:  http.StatusOK,
+				Data:        []byte(fmt.Sprintf(`{"auth":%q,"body":%s}`, strings.Join(request.Header[coreutils.Authorization], ""), request.Body)),
+			})
+		case "q.sys.Collection":
+			go func() {
+				rs := sender.SendParallelResponse()
+				rs.StartArraySection("", nil)
+				require.NoError(rs.SendElement("", elem1))
+				rs.Close(nil)
+			}()
+		case "c.sys.Wait":
+			<-release
+			sender.SendResponse(ibus.Response{ContentType: coreutils.ApplicationJSON, StatusCode: http.StatusOK})
+		}
+	}, ibus.DefaultTimeout)
+	defer tearDown()
+
+	config, err := websocket.NewConfig(fmt.Sprintf("ws://127.0.0.1:%d/ws", router.port()), "http://127.0.0.1")
+	require.NoError(err)
+	config.Header.Set(coreutils.Authorization, coreutils.BearerPrefix+"connToken")
+	conn, err := websocket.DialConfig(config)
+	require.NoError(err)
+	defer conn.Close()
+
+	call := func(msg string) (resp wsResponseType) {
+		require.NoError(websocket.Message.Send(conn, msg))
+		require.NoError(websocket.JSON.Receive(conn, &resp))
+		return resp
+	}
+
+	t.Run("command with the connection token", func(t *testing.T) {
+		resp := call(fmt.Sprintf(`{"ID":"1","Type":"call","App":"test1/app1","WSID":%d,"Resource":"c.sys.CUD","Body":{"cuds":[]}}`, testWSID))
+		require.Equal("1", resp.ID)
+		require.Equal(http.StatusOK, resp.Status)
+		require.JSONEq(`{"auth":"Bearer connToken","body":{"cuds":[]}}`, string(resp.Body))
+	})
+
+	t.Run("command with the call token", func(t *testing.T) {
+		resp := call(fmt.Sprintf(`{"ID":"2","Type":"call","App":"test1/app1","WSID":%d,"Resource":"c.sys.CUD","Body":{},"Token":"callToken"}`, testWSID))
+		require.Equal("2", resp.ID)
+		require.JSONEq(`{"auth":"Bearer callToken","body":{}}`, string(resp.Body))
+	})
+
+	t.Run("query with sectioned response", func(t *testing.T) {
+		resp := call(fmt.Sprintf(`{"ID":"3","Type":"call","App":"test1/app1","WSID":%d,"Resource":"q.sys.Collection","Body":{}}`, testWSID))
+		require.Equal("3", resp.ID)
+		require.Equal(http.StatusOK, resp.Status)
+		require.JSONEq(`{"sections":[{"type":"","elements":[{"fld1":"fld1Val"}]}]}`, string(resp.Body))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		resp := call(`{"ID":"4","Type":"unknown"}`)
+		require.Equal(wsResponseType{ID: "4", Type: wsMessageType_Response, Status: http.StatusBadRequest, Body: []byte(`"unknown message type \"unknown\""`)}, resp)
+
+		resp = call(`{"ID":"5","Type":"call","App":"wrong","Resource":"c.sys.CUD"}`)
+		require.Equal("5", resp.ID)
+		require.Equal(http.StatusBadRequest, resp.Status)
+
+		resp = call(`{"ID":"6","Type":"call",`)
+		require.Equal(http.StatusBadRequest, resp.Status)
+	})
+
+	t.Run("calls in progress are limited", func(t *testing.T) {
+		for i := 0; i < testWSCallsLimit; i++ {
+			require.NoError(websocket.Message.Send(conn, fmt.Sprintf(`{"ID":"wait%d","Type":"call","App":"test1/app1","WSID":%d,"Resource":"c.sys.Wait","Body":{}}`, i, testWSID)))
+		}
+		resp := call(fmt.Sprintf(`{"ID":"over","Type":"call","App":"test1/app1","WSID":%d,"Resource":"c.sys.Wait","Body":{}}`, testWSID))
+		require.Equal("over", resp.ID)
+		require.Equal(http.StatusTooManyRequests, resp.Status)
+
+		close(release)
+		for i := 0; i < testWSCallsLimit; i++ {
+			resp := wsResponseType{}
+			require.NoError(websocket.JSON.Receive(conn, &resp))
+			require.Equal(http.StatusOK, resp.Status)
+		}
+
+		resp = call(fmt.Sprintf(`{"ID":"next","Type":"call","App":"test1/app1","WSID":%d,"Resource":"c.sys.CUD","Body":{}}`, testWSID))
+		require.Equal("next", resp.ID)
+		require.Equal(http.StatusOK, resp.Status)
+	})
+
+	t.Run("cookie is not the connection token", func(t *testing.T) {
+		config, err := websocket.NewConfig(fmt.Sprintf("ws://127.0.0.1:%d/ws", router.port()), "http://evil.example.com")
+		require.NoError(err)
+		config.Header.Set("Cookie", coreutils.Authorization+"="+url.QueryEscape(coreutils.BearerPrefix+"cookieToken"))
+		conn, err := websocket.DialConfig(config)
+		require.NoError(err)
+		defer conn.Close()
+
+		require.NoError(websocket.Message.Send(conn, fmt.Sprintf(`{"ID":"7","Type":"call","App":"test1/app1","WSID":%d,"Resource":"c.sys.CUD","Body":{}}`, testWSID)))
+		resp := wsResponseType{}
+		require.NoError(websocket.JSON.Receive(conn, &resp))
+		require.JSONEq(`{"auth":"","body":{}}`, string(resp.Body))
+	})
+}
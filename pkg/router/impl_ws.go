@@ -0,0 +1,267 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/untillpro/goutils/logger"
+	"golang.org/x/net/websocket"
+
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+/*
+Single long-lived connection which multiplexes command/query calls and n10n subscriptions.
+Each client message is a JSON object, responses have the same ID, n10n events have no ID:
+
+	-> {"ID":"1","Type":"call","App":"test1/app1","WSID":1,"Resource":"c.sys.CUD","Body":{"cuds":[...]}}
+	<- {"ID":"1","Type":"response","Status":200,"Body":{"CurrentWLogOffset":13,...}}
+	-> {"ID":"2","Type":"subscribe","SubjectLogin":"paa","ProjectionKey":[{"App":"test1/app1","Projection":"paa.price","WS":1}]}
+	<- {"ID":"2","Type":"response","Status":200}
+	<- {"Type":"n10n","ProjectionKey":{"App":"test1/app1","Projection":"paa.price","WS":1},"Offset":13}
+	-> {"ID":"3","Type":"unsubscribe","ProjectionKey":[{"App":"test1/app1","Projection":"paa.price","WS":1}]}
+
+Calls are handled concurrently, calls in progress over RouterParams.WSCallsLimit are rejected with 429 Too Many Requests.
+Principal token is taken from the Authorization header of the connection request, "Token" of the call overrides it.
+Cookies are not used: browsers send them with cross-site connection requests, so browser clients must pass "Token" in calls.
+All subscriptions of the connection belong to the SubjectLogin of the first subscribe.
+
+wscat -c ws://localhost:8822/ws -H "Authorization: Bearer ${TOKEN}"
+*/
+func (s *httpService) wsHandler() http.Handler {
+	return websocket.Server{
+		// Origin is not checked: non-browser clients (e.g. POS terminals) do not send it,
+		// and the connection is not authorized by ambient credentials (cookies), see wsAuthToken()
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			s.serveWS(conn)
+		},
+	}
+}
+
+func (s *httpService) serveWS(conn *websocket.Conn) {
+	req := conn.Request()
+
+	// read and write timeouts of the server are not applicable for the long-lived connection
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		logger.Error("failed to reset websocket deadline:", err)
+		return
+	}
+
+	// req's BaseContext is router service's context. See service.Start()
+	ctx, cancel := context.WithCancel(req.Context())
+	callsLimit := s.WSCallsLimit
+	if callsLimit <= 0 {
+		callsLimit = DefaultWSCallsLimit
+	}
+	wsConn := &wsConnType{
+		conn:      conn,
+		ctx:       ctx,
+		authToken: wsAuthToken(req),
+		calls:     make(chan struct{}, callsLimit),
+	}
+	defer func() {
+		cancel()
+		wsConn.wg.Wait()
+	}()
+	go func() {
+		// unblocks Receive() on router shutdown
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	for ctx.Err() == nil {
+		var msg wsRequestType
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				wsConn.respondError(msg.ID, http.StatusBadRequest, err.Error())
+				continue
+			}
+			if ctx.Err() == nil {
+				logger.Verbose("websocket closed:", err)
+			}
+			return
+		}
+		switch msg.Type {
+		case wsMessageType_Call:
+			// reading is not blocked by calls in progress: responses, subscribe and unsubscribe are still served
+			select {
+			case wsConn.calls <- struct{}{}:
+			default:
+				wsConn.respondError(msg.ID, http.StatusTooManyRequests, fmt.Sprintf("too many calls in progress, limit is %d", cap(wsConn.calls)))
+				continue
+			}
+			wsConn.wg.Add(1)
+			go func() {
+				defer func() {
+					<-wsConn.calls
+					wsConn.wg.Done()
+				}()
+				s.wsCall(wsConn, msg)
+			}()
+		case wsMessageType_Subscribe:
+			s.wsSubscribe(wsConn, msg)
+		case wsMessageType_Unsubscribe:
+			s.wsUnsubscribe(wsConn, msg)
+		default:
+			wsConn.respondError(msg.ID, http.StatusBadRequest, fmt.Sprintf("unknown message type %q", msg.Type))
+		}
+	}
+}
+
+// wsCall dispatches command or query call to the bus in the same way as api/ HTTP requests are dispatched
+func (s *httpService) wsCall(wsConn *wsConnType, msg wsRequestType) {
+	appQName, err := istructs.ParseAppQName(msg.App)
+	if err != nil {
+		wsConn.respondError(msg.ID, http.StatusBadRequest, err.Error())
+		return
+	}
+	req, err := http.NewRequestWithContext(wsConn.ctx, http.MethodPost, "/api/"+msg.App+"/"+strconv.FormatUint(uint64(msg.WSID), parseInt64Base)+"/"+msg.Resource,
+		bytes.NewReader(msg.Body))
+	if err != nil {
+		wsConn.respondError(msg.ID, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Header.Set(coreutils.ContentType, coreutils.ApplicationJSON)
+	switch {
+	case len(msg.Token) > 0:
+		req.Header.Set(coreutils.Authorization, coreutils.BearerPrefix+msg.Token)
+	case len(wsConn.authToken) > 0:
+		req.Header.Set(coreutils.Authorization, wsConn.authToken)
+	}
+	req = mux.SetURLVars(req, map[string]string{
+		AppOwner:     appQName.Owner(),
+		AppName:      appQName.Name(),
+		WSID:         strconv.FormatUint(uint64(msg.WSID), parseInt64Base),
+		ResourceName: msg.Resource,
+	})
+	resp := newWSResponseWriter()
+	RequestHandler(s.bus, s.busTimeout, s.appsWSAmount)(resp, req)
+	wsConn.respond(msg.ID, resp.status, resp.body.Bytes())
+}
+
+func (s *httpService) wsSubscribe(wsConn *wsConnType, msg wsRequestType) {
+	if len(wsConn.channel) > 0 && msg.SubjectLogin != wsConn.subject {
+		wsConn.respondError(msg.ID, http.StatusBadRequest, fmt.Sprintf("connection is subscribed by subject %q, can not subscribe by %q", wsConn.subject, msg.SubjectLogin))
+		return
+	}
+	channel, err := s.wsChannel(wsConn, msg.SubjectLogin)
+	if err != nil {
+		logger.Error(err)
+		wsConn.respondError(msg.ID, n10nErrorToStatusCode(err), err.Error())
+		return
+	}
+	for _, projection := range msg.ProjectionKey {
+		if err := s.subscribe(channel, projection, msg.Resume); err != nil {
+			logger.Error(err)
+			wsConn.respondError(msg.ID, n10nErrorToStatusCode(err), "subscribe failed: "+err.Error())
+			return
+		}
+	}
+	wsConn.respond(msg.ID, http.StatusOK, nil)
+}
+
+func (s *httpService) wsUnsubscribe(wsConn *wsConnType, msg wsRequestType) {
+	if len(wsConn.channel) == 0 {
+		wsConn.respondError(msg.ID, http.StatusBadRequest, in10n.ErrChannelDoesNotExist.Error())
+		return
+	}
+	for _, projection := range msg.ProjectionKey {
+		if err := s.n10n.Unsubscribe(wsConn.channel, projection.ProjectionKey); err != nil {
+			logger.Error(err)
+			wsConn.respondError(msg.ID, n10nErrorToStatusCode(err), err.Error())
+			return
+		}
+	}
+	wsConn.respond(msg.ID, http.StatusOK, nil)
+}
+
+// wsChannel returns n10n channel of the connection, the channel is created and watched on the first subscribe
+func (s *httpService) wsChannel(wsConn *wsConnType, subject istructs.SubjectLogin) (in10n.ChannelID, error) {
+	if len(wsConn.channel) > 0 {
+		return wsConn.channel, nil
+	}
+	if s.n10n == nil {
+		return "", errors.New("n10n is not supported")
+	}
+	channel, err := s.n10n.NewChannel(subject, hours24)
+	if err != nil {
+		return "", fmt.Errorf("create new channel failed: %w", err)
+	}
+	wsConn.channel = channel
+	wsConn.subject = subject
+	wsConn.wg.Add(1)
+	go func() {
+		defer wsConn.wg.Done()
+		s.n10n.WatchChannel(wsConn.ctx, channel, func(projection in10n.ProjectionKey, offset istructs.Offset) {
+			wsConn.send(wsN10nType{
+				Type:          wsMessageType_N10n,
+				ProjectionKey: projection,
+				Offset:        offset,
+			})
+		})
+	}()
+	return channel, nil
+}
+
+// respond sends the response to the client message, non-JSON body (e.g. text/plain error) is sent as JSON string
+func (c *wsConnType) respond(id string, status int, body []byte) {
+	if len(body) > 0 && !json.Valid(body) {
+		body, _ = json.Marshal(string(body)) // error impossible
+	}
+	c.send(wsResponseType{
+		ID:     id,
+		Type:   wsMessageType_Response,
+		Status: status,
+		Body:   body,
+	})
+}
+
+func (c *wsConnType) respondError(id string, status int, msg string) {
+	body, _ := json.Marshal(msg) // error impossible
+	c.respond(id, status, body)
+}
+
+func (c *wsConnType) send(msg interface{}) {
+	c.Lock()
+	defer c.Unlock()
+	if err := websocket.JSON.Send(c.conn, msg); err != nil && c.ctx.Err() == nil {
+		logger.Error("failed to write to websocket:", err)
+	}
+}
+
+// wsAuthToken returns the Authorization header of the connection request.
+// Authorization cookie must not be used, otherwise any site opened in the browser could connect on behalf of the user
+func wsAuthToken(req *http.Request) string {
+	return req.Header.Get(coreutils.Authorization)
+}
+
+func newWSResponseWriter() *wsResponseWriter {
+	return &wsResponseWriter{
+		header: http.Header{},
+		status: http.StatusOK,
+	}
+}
+
+func (w *wsResponseWriter) Header() http.Header { return w.header }
+
+func (w *wsResponseWriter) Write(data []byte) (int, error) { return w.body.Write(data) }
+
+func (w *wsResponseWriter) WriteHeader(status int) { w.status = status }
+
+func (w *wsResponseWriter) Flush() {}
@@ -6,6 +6,9 @@
 package router
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"net/url"
@@ -14,6 +17,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/net/websocket"
 
 	ibus "github.com/voedger/voedger/staging/src/github.com/untillpro/airs-ibus"
 
@@ -29,12 +33,17 @@ type RouterParams struct {
 	WriteTimeout         int
 	ReadTimeout          int
 	ConnectionsLimit     int
+	WSCallsLimit         int // max calls in progress per websocket connection, DefaultWSCallsLimit if 0
 	HTTP01ChallengeHosts []string
 	CertDir              string
 	RouteDefault         string            // http://10.0.0.3:3000/not-found : https://alpha.dev.untill.ru/unknown/foo -> http://10.0.0.3:3000/not-found/unknown/foo
 	Routes               map[string]string // /grafana=http://10.0.0.3:3000 : https://alpha.dev.untill.ru/grafana/foo -> http://10.0.0.3:3000/grafana/foo
 	RoutesRewrite        map[string]string // /grafana-rewrite=http://10.0.0.3:3000/rewritten : https://alpha.dev.untill.ru/grafana-rewrite/foo -> http://10.0.0.3:3000/rewritten/foo
 	RouteDomains         map[string]string // resellerportal.dev.untill.ru=http://resellerportal : https://resellerportal.dev.untill.ru/foo -> http://resellerportal/foo
+	// returns JSON Web Key Set to verify principal tokens, nil -> JWKS endpoint is not served
+	JWKS func() ([]byte, error)
+	// admin API handlers by paths, handlers authorize requests by themselves
+	AdminHandlers map[string]http.Handler
 }
 
 type httpService struct {
@@ -81,10 +90,80 @@ type route struct {
 
 type createChannelParamsType struct {
 	SubjectLogin  istructs.SubjectLogin
-	ProjectionKey []in10n.ProjectionKey
+	ProjectionKey []projectionKeyParamType
+	// Resume subscriptions from offsets last seen by the client
+	Resume bool
+	// Send changed view rows with offsets
+	Rows bool
 }
 
 type subscriberParamsType struct {
 	Channel       in10n.ChannelID
-	ProjectionKey []in10n.ProjectionKey
+	ProjectionKey []projectionKeyParamType
+	Resume        bool
+}
+
+type projectionKeyParamType struct {
+	in10n.ProjectionKey
+	// Offset last seen by the client, the subject cursor is used if omitted
+	Offset istructs.Offset
+}
+
+// data of the projection event if the client is subscribed with rows
+type rowsDataType struct {
+	Offset istructs.Offset
+	// omitted if rows are unknown or too large, the client must re-read the projection
+	Rows json.RawMessage `json:",omitempty"`
+}
+
+// message from the websocket client
+type wsRequestType struct {
+	// correlation ID, the response has the same ID
+	ID   string
+	Type string
+
+	// call
+	App      string
+	WSID     istructs.WSID
+	Resource string
+	Body     json.RawMessage
+	// principal token, the token of the connection is used if omitted
+	Token string
+
+	// subscribe, unsubscribe
+	SubjectLogin  istructs.SubjectLogin
+	ProjectionKey []projectionKeyParamType
+	Resume        bool
+}
+
+type wsResponseType struct {
+	ID     string
+	Type   string
+	Status int
+	Body   json.RawMessage `json:",omitempty"`
+}
+
+type wsN10nType struct {
+	Type          string
+	ProjectionKey in10n.ProjectionKey
+	Offset        istructs.Offset
+}
+
+type wsConnType struct {
+	sync.Mutex // guards writes to conn
+	conn       *websocket.Conn
+	ctx        context.Context
+	wg         sync.WaitGroup
+	authToken  string
+	calls      chan struct{} // semaphore of calls in progress
+	// accessed by the reading goroutine only
+	channel in10n.ChannelID
+	subject istructs.SubjectLogin
+}
+
+// wsResponseWriter collects the response of RequestHandler to send it as a single websocket message
+type wsResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
 }
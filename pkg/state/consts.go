@@ -20,6 +20,7 @@ var (
 	AppSecret      = appdef.NewQName(appdef.SysPackage, "AppSecret")
 	RequestSubject = appdef.NewQName(appdef.SysPackage, "RequestSubject")
 	Result         = appdef.NewQName(appdef.SysPackage, "Result")
+	ArgumentObject = appdef.NewQName(appdef.SysPackage, "ArgumentObject")
 )
 
 const (
@@ -47,6 +48,7 @@ const (
 	Field_WSID                          = "WSID"
 	Field_HTTPClientTimeoutMilliseconds = "HTTPClientTimeoutMilliseconds"
 	Field_Singleton                     = "Singleton"
+	Field_Index                         = "Index"
 	Field_Secret                        = "Secret"
 	Field_RegisteredAt                  = "RegisteredAt"
 	Field_DeviceID                      = "DeviceID"
@@ -64,6 +66,7 @@ const (
 	Field_IsNew                         = "IsNew"
 	Field_Name                          = "Name"
 	Field_Token                         = "Token"
+	Field_Unlogged                      = "Unlogged"
 )
 
 const (
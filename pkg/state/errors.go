@@ -18,6 +18,7 @@ var ErrGetNotSupportedByStorage = errors.New("get not supported by storage")
 var ErrReadNotSupportedByStorage = errors.New("read not supported by storage")
 var ErrUpdateNotSupportedByStorage = errors.New("update not supported by storage")
 var ErrInsertNotSupportedByStorage = errors.New("insert not supported by storage")
+var ErrIndexValuesMissed = errors.New("index values missed")
 var errTest = errors.New("test")
 var errCurrentValueIsNotAnArray = errors.New("current value is not an array")
 var errFieldByNameIsNotAnObjectOrArray = errors.New("field by name is not an object or array")
@@ -37,6 +37,12 @@ func put(fieldName string, kind appdef.DataKind, rr istructs.IRowReader, rw istr
 		rw.PutBool(fieldName, rr.AsBool(fieldName))
 	case appdef.DataKind_RecordID:
 		rw.PutRecordID(fieldName, rr.AsRecordID(fieldName))
+	case appdef.DataKind_decimal, appdef.DataKind_timestamp:
+		rw.PutInt64(fieldName, rr.AsInt64(fieldName))
+	case appdef.DataKind_date:
+		rw.PutInt32(fieldName, rr.AsInt32(fieldName))
+	case appdef.DataKind_UUID:
+		rw.PutBytes(fieldName, rr.AsBytes(fieldName))
 	default:
 		panic(fmt.Errorf("illegal state: field - '%s', kind - '%d': %w", fieldName, kind, ErrNotSupported))
 	}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package state
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+type argumentObjectStorage struct {
+	argFunc         ArgFunc
+	unloggedArgFunc UnloggedArgFunc
+}
+
+func (s *argumentObjectStorage) NewKeyBuilder(_ appdef.QName, _ istructs.IStateKeyBuilder) istructs.IStateKeyBuilder {
+	return newKeyBuilder(ArgumentObject, appdef.NullQName)
+}
+
+// Returns argument object. Unlogged argument object is returned if key has true Unlogged field
+func (s *argumentObjectStorage) Get(key istructs.IStateKeyBuilder) (istructs.IStateValue, error) {
+	argFunc := s.argFunc
+	if unlogged, ok := key.(*keyBuilder).data[Field_Unlogged]; ok && unlogged.(bool) {
+		argFunc = ArgFunc(s.unloggedArgFunc)
+	}
+	if argFunc == nil {
+		return nil, nil
+	}
+	arg := argFunc()
+	if arg == nil {
+		return nil, nil
+	}
+	return &objectValue{object: arg}, nil
+}
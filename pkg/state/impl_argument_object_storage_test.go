@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+type testArgObject struct {
+	istructs.NullObject
+	name  string
+	items []istructs.IObject
+}
+
+func (o *testArgObject) AsString(string) string { return o.name }
+func (o *testArgObject) Containers(cb func(string)) {
+	if len(o.items) > 0 {
+		cb("items")
+	}
+}
+func (o *testArgObject) Children(_ string, cb func(istructs.IObject)) {
+	for _, item := range o.items {
+		cb(item)
+	}
+}
+
+func TestArgumentObjectStorage_BasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	arg := &testArgObject{
+		name:  "arg",
+		items: []istructs.IObject{&testArgObject{name: "item1"}, &testArgObject{name: "item2"}},
+	}
+	unloggedArg := &testArgObject{name: "unlogged"}
+
+	t.Run("Should get command argument and unlogged argument", func(t *testing.T) {
+		s := ProvideCommandProcessorStateFactory()(context.Background(), nil, nil, SimpleWSIDFunc(istructs.NullWSID), nil, nil, nil, nil, 1, nil,
+			func() istructs.IObject { return arg }, func() istructs.IObject { return unloggedArg })
+
+		kb, err := s.KeyBuilder(ArgumentObject, appdef.NullQName)
+		require.NoError(err)
+		v, err := s.MustExist(kb)
+		require.NoError(err)
+		require.Equal("arg", v.AsString("name"))
+
+		items := v.AsValue("items")
+		require.Equal(2, items.Length())
+		require.Equal("item1", items.GetAsValue(0).AsString("name"))
+		require.Equal("item2", items.GetAsValue(1).AsString("name"))
+
+		kb.PutBool(Field_Unlogged, true)
+		v, err = s.MustExist(kb)
+		require.NoError(err)
+		require.Equal("unlogged", v.AsString("name"))
+	})
+
+	t.Run("Should not exist unlogged argument for query", func(t *testing.T) {
+		s := ProvideQueryProcessorStateFactory()(context.Background(), &nilAppStructs{}, nil, SimpleWSIDFunc(istructs.NullWSID), nil, nil, nil,
+			func() istructs.IObject { return arg }, nil, nil)
+
+		kb, err := s.KeyBuilder(ArgumentObject, appdef.NullQName)
+		require.NoError(err)
+		v, err := s.MustExist(kb)
+		require.NoError(err)
+		require.Equal("arg", v.AsString("name"))
+
+		kb.PutBool(Field_Unlogged, true)
+		_, ok, err := s.CanExist(kb)
+		require.NoError(err)
+		require.False(ok)
+	})
+}
@@ -47,10 +47,12 @@ func implProvideAsyncActualizerState(ctx context.Context, appStructs istructs.IA
 	}, S_GET|S_GET_BATCH|S_READ|S_INSERT|S_UPDATE)
 
 	state.addStorage(Record, &recordsStorage{
-		recordsFunc: func() istructs.IRecords { return appStructs.Records() },
-		appDefFunc:  func() appdef.IAppDef { return appStructs.AppDef() },
-		wsidFunc:    wsidFunc,
-	}, S_GET|S_GET_BATCH)
+		ctx:             ctx,
+		recordsFunc:     func() istructs.IRecords { return appStructs.Records() },
+		viewRecordsFunc: func() istructs.IViewRecords { return appStructs.ViewRecords() },
+		appDefFunc:      func() appdef.IAppDef { return appStructs.AppDef() },
+		wsidFunc:        wsidFunc,
+	}, S_GET|S_GET_BATCH|S_READ)
 
 	state.addStorage(WLog, &wLogStorage{
 		ctx:        ctx,
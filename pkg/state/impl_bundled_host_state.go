@@ -22,6 +22,9 @@ func (s *bundledHostState) CanExist(key istructs.IStateKeyBuilder) (stateValue i
 	if ok {
 		// can be already in a bundles
 		if value, ok := bundledStorage.get(key); ok {
+			if vb, ok := value.value.(*viewValueBuilder); ok && vb.deleted {
+				return nil, false, nil
+			}
 			// TODO later: For the optimization purposes, maybe would be wise to use e.g. AsValue()
 			// instead of BuildValue()
 			return value.value.BuildValue(), true, nil
@@ -17,7 +17,7 @@ import (
 func TestBundledHostState_BasicUsage(t *testing.T) {
 	require := require.New(t)
 	factory := ProvideAsyncActualizerStateFactory()
-	n10nFn := func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset) {}
+	n10nFn := func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset, _ func(int) []byte) {}
 	appStructs := mockedAppStructs()
 
 	// Create instance of async actualizer state
@@ -95,7 +95,7 @@ func mockedAppStructs() istructs.IAppStructs {
 func TestAsyncActualizerState_BasicUsage_Old(t *testing.T) {
 	require := require.New(t)
 	touched := false
-	n10nFn := func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset) {
+	n10nFn := func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset, _ func(int) []byte) {
 		touched = true
 		require.Equal(testViewRecordQName1, view)
 		require.Equal(istructs.WSID(1), wsid)
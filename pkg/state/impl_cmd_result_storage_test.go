@@ -16,7 +16,7 @@ import (
 
 func TestCmdResultStorage_InsertInValue(t *testing.T) {
 	cmdResBuilder := istructs.NewNullObjectBuilder()
-	s := ProvideCommandProcessorStateFactory()(context.Background(), nil, nil, SimpleWSIDFunc(istructs.NullWSID), nil, nil, nil, nil, 1, func() istructs.IObjectBuilder { return cmdResBuilder })
+	s := ProvideCommandProcessorStateFactory()(context.Background(), nil, nil, SimpleWSIDFunc(istructs.NullWSID), nil, nil, nil, nil, 1, func() istructs.IObjectBuilder { return cmdResBuilder }, nil, nil)
 
 	kb, err := s.KeyBuilder(Result, testRecordQName1)
 	require.NoError(t, err)
@@ -37,7 +37,7 @@ func TestCmdResultStorage_InsertInKey(t *testing.T) {
 	}()
 
 	cmdResBuilder := istructs.NewNullObjectBuilder()
-	s := ProvideCommandProcessorStateFactory()(context.Background(), nil, nil, SimpleWSIDFunc(istructs.NullWSID), nil, nil, nil, nil, 1, func() istructs.IObjectBuilder { return cmdResBuilder })
+	s := ProvideCommandProcessorStateFactory()(context.Background(), nil, nil, SimpleWSIDFunc(istructs.NullWSID), nil, nil, nil, nil, 1, func() istructs.IObjectBuilder { return cmdResBuilder }, nil, nil)
 
 	kb, err := s.KeyBuilder(Result, testRecordQName1)
 	require.NoError(t, err)
@@ -14,7 +14,7 @@ import (
 
 func implProvideCommandProcessorState(ctx context.Context, appStructsFunc AppStructsFunc, partitionIDFunc PartitionIDFunc,
 	wsidFunc WSIDFunc, secretReader isecrets.ISecretReader, cudFunc CUDFunc, principalsFunc PrincipalsFunc,
-	tokenFunc TokenFunc, intentsLimit int, cmdResultBuilderFunc CmdResultBuilderFunc) IHostState {
+	tokenFunc TokenFunc, intentsLimit int, cmdResultBuilderFunc CmdResultBuilderFunc, argFunc ArgFunc, unloggedArgFunc UnloggedArgFunc) IHostState {
 	bs := newHostState("CommandProcessor", intentsLimit)
 
 	bs.addStorage(View, &viewRecordsStorage{
@@ -56,5 +56,10 @@ func implProvideCommandProcessorState(ctx context.Context, appStructsFunc AppStr
 		cmdResultBuilderFunc: cmdResultBuilderFunc,
 	}, S_INSERT)
 
+	bs.addStorage(ArgumentObject, &argumentObjectStorage{
+		argFunc:         argFunc,
+		unloggedArgFunc: unloggedArgFunc,
+	}, S_GET)
+
 	return bs
 }
@@ -30,7 +30,7 @@ func TestPLogStorage_Read(t *testing.T) {
 		appStructs.On("Events").Return(events)
 		appStructs.On("Records").Return(&nilRecords{})
 		appStructs.On("ViewRecords").Return(&nilViewRecords{})
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, SimplePartitionIDFunc(istructs.PartitionID(1)), nil, nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, SimplePartitionIDFunc(istructs.PartitionID(1)), nil, nil, nil, nil, nil, nil, nil)
 		kb, err := s.KeyBuilder(PLog, appdef.NullQName)
 		require.NoError(err)
 		kb.PutInt64(Field_Offset, 1)
@@ -54,7 +54,7 @@ func TestPLogStorage_Read(t *testing.T) {
 		appStructs.On("Events").Return(events)
 		appStructs.On("Records").Return(&nilRecords{})
 		appStructs.On("ViewRecords").Return(&nilViewRecords{})
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, SimplePartitionIDFunc(istructs.PartitionID(1)), nil, nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, SimplePartitionIDFunc(istructs.PartitionID(1)), nil, nil, nil, nil, nil, nil, nil)
 		k, err := s.KeyBuilder(PLog, appdef.NullQName)
 		require.NoError(err)
 		k.PutInt64(Field_Offset, 1)
@@ -80,7 +80,7 @@ func TestPLogStorage_Get(t *testing.T) {
 		appStructs.On("Events").Return(events)
 		appStructs.On("Records").Return(&nilRecords{})
 		appStructs.On("ViewRecords").Return(&nilViewRecords{})
-		s := ProvideCommandProcessorStateFactory()(context.Background(), func() istructs.IAppStructs { return appStructs }, SimplePartitionIDFunc(istructs.PartitionID(1)), nil, nil, nil, nil, nil, 0, nil)
+		s := ProvideCommandProcessorStateFactory()(context.Background(), func() istructs.IAppStructs { return appStructs }, SimplePartitionIDFunc(istructs.PartitionID(1)), nil, nil, nil, nil, nil, 0, nil, nil, nil)
 		kb, err := s.KeyBuilder(PLog, appdef.NullQName)
 		require.NoError(err)
 		kb.PutInt64(Field_Offset, 1)
@@ -108,7 +108,7 @@ func TestPLogStorage_Get(t *testing.T) {
 		appStructs.On("Events").Return(events)
 		appStructs.On("Records").Return(&nilRecords{})
 		appStructs.On("ViewRecords").Return(&nilViewRecords{})
-		s := ProvideCommandProcessorStateFactory()(context.Background(), func() istructs.IAppStructs { return appStructs }, SimplePartitionIDFunc(istructs.PartitionID(1)), nil, nil, nil, nil, nil, 0, nil)
+		s := ProvideCommandProcessorStateFactory()(context.Background(), func() istructs.IAppStructs { return appStructs }, SimplePartitionIDFunc(istructs.PartitionID(1)), nil, nil, nil, nil, nil, 0, nil, nil, nil)
 		kb1, err := s.KeyBuilder(PLog, appdef.NullQName)
 		require.NoError(err)
 		kb1.PutInt64(Field_Offset, 1)
@@ -12,9 +12,26 @@ import (
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
+// Query processor state. Result objects are streamed: pending result object is sent to query callback
+// when next result object is created or intents are applied, so results are not buffered
+type queryProcessorState struct {
+	*hostState
+}
+
+func (s *queryProcessorState) NewValue(key istructs.IStateKeyBuilder) (istructs.IStateValueBuilder, error) {
+	if key.Storage() == Result {
+		if err := s.ApplyIntents(); err != nil {
+			return nil, err
+		}
+	}
+	return s.hostState.NewValue(key)
+}
+
 func implProvideQueryProcessorState(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc,
-	secretReader isecrets.ISecretReader, principalsFunc PrincipalsFunc, tokenFunc TokenFunc) IHostState {
-	bs := newHostState("QueryProcessor", 0)
+	secretReader isecrets.ISecretReader, principalsFunc PrincipalsFunc, tokenFunc TokenFunc,
+	argFunc ArgFunc, resultBuilderFunc QueryResultBuilderFunc, resultFunc ExecQueryCallbackFunc) IHostState {
+	// Result is the only storage with intents, at most one result object is pending
+	bs := newHostState("QueryProcessor", 1)
 
 	bs.addStorage(View, &viewRecordsStorage{
 		ctx:             ctx,
@@ -24,10 +41,12 @@ func implProvideQueryProcessorState(ctx context.Context, appStructs istructs.IAp
 	}, S_GET|S_GET_BATCH|S_READ)
 
 	bs.addStorage(Record, &recordsStorage{
-		recordsFunc: func() istructs.IRecords { return appStructs.Records() },
-		appDefFunc:  func() appdef.IAppDef { return appStructs.AppDef() },
-		wsidFunc:    wsidFunc,
-	}, S_GET|S_GET_BATCH)
+		ctx:             ctx,
+		recordsFunc:     func() istructs.IRecords { return appStructs.Records() },
+		viewRecordsFunc: func() istructs.IViewRecords { return appStructs.ViewRecords() },
+		appDefFunc:      func() appdef.IAppDef { return appStructs.AppDef() },
+		wsidFunc:        wsidFunc,
+	}, S_GET|S_GET_BATCH|S_READ)
 
 	bs.addStorage(WLog, &wLogStorage{
 		ctx:        ctx,
@@ -52,5 +71,14 @@ func implProvideQueryProcessorState(ctx context.Context, appStructs istructs.IAp
 		tokenFunc:      tokenFunc,
 	}, S_GET)
 
-	return bs
+	bs.addStorage(ArgumentObject, &argumentObjectStorage{
+		argFunc: argFunc,
+	}, S_GET)
+
+	bs.addStorage(Result, &queryResultStorage{
+		resultBuilderFunc: resultBuilderFunc,
+		resultFunc:        resultFunc,
+	}, S_INSERT)
+
+	return &queryProcessorState{hostState: bs}
 }
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package state
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Each new value is a query result object. Object is sent to query callback when next object is created or intents are applied
+type queryResultStorage struct {
+	resultBuilderFunc QueryResultBuilderFunc
+	resultFunc        ExecQueryCallbackFunc
+}
+
+func (s *queryResultStorage) NewKeyBuilder(_ appdef.QName, _ istructs.IStateKeyBuilder) istructs.IStateKeyBuilder {
+	return newResultKeyBuilder()
+}
+
+func (s *queryResultStorage) Validate([]ApplyBatchItem) (err error) {
+	return nil
+}
+
+func (s *queryResultStorage) ApplyBatch(items []ApplyBatchItem) (err error) {
+	callback := s.resultFunc()
+	for _, item := range items {
+		obj, err := item.value.(*resultValueBuilder).resultBuilder.Build()
+		if err != nil {
+			return err
+		}
+		if err = callback(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *queryResultStorage) ProvideValueBuilder(istructs.IStateKeyBuilder, istructs.IStateValueBuilder) istructs.IStateValueBuilder {
+	return &resultValueBuilder{resultBuilder: s.resultBuilderFunc()}
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestQueryResultStorage_BasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	results := []istructs.IObject{}
+	newState := func(callback istructs.ExecQueryCallback) IHostState {
+		return ProvideQueryProcessorStateFactory()(context.Background(), &nilAppStructs{}, nil, SimpleWSIDFunc(istructs.NullWSID), nil, nil, nil, nil,
+			func() istructs.IObjectBuilder { return istructs.NewNullObjectBuilder() },
+			func() istructs.ExecQueryCallback { return callback })
+	}
+
+	t.Run("Should stream results to callback", func(t *testing.T) {
+		const count = 3
+		s := newState(func(object istructs.IObject) error {
+			results = append(results, object)
+			return nil
+		})
+		for i := 0; i < count; i++ {
+			kb, err := s.KeyBuilder(Result, appdef.NullQName)
+			require.NoError(err)
+			vb, err := s.NewValue(kb)
+			require.NoError(err)
+			vb.PutString("name", "value")
+			require.Len(results, i, "previous result should be sent when next one is created")
+		}
+
+		require.NoError(s.ValidateIntents())
+		require.NoError(s.ApplyIntents())
+		require.Len(results, count)
+	})
+
+	t.Run("Should return callback error on next result", func(t *testing.T) {
+		testErr := errors.New("test error")
+		s := newState(func(istructs.IObject) error { return testErr })
+		kb, err := s.KeyBuilder(Result, appdef.NullQName)
+		require.NoError(err)
+		_, err = s.NewValue(kb)
+		require.NoError(err)
+
+		_, err = s.NewValue(kb)
+		require.ErrorIs(err, testErr)
+	})
+
+	t.Run("Should return callback error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		s := newState(func(istructs.IObject) error { return testErr })
+		kb, err := s.KeyBuilder(Result, appdef.NullQName)
+		require.NoError(err)
+		_, err = s.NewValue(kb)
+		require.NoError(err)
+
+		require.ErrorIs(s.ApplyIntents(), testErr)
+	})
+}
@@ -5,6 +5,7 @@
 package state
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -14,10 +15,12 @@ import (
 )
 
 type recordsStorage struct {
-	recordsFunc recordsFunc
-	cudFunc     CUDFunc
-	appDefFunc  appDefFunc
-	wsidFunc    WSIDFunc
+	ctx             context.Context
+	recordsFunc     recordsFunc
+	viewRecordsFunc viewRecordsFunc
+	cudFunc         CUDFunc
+	appDefFunc      appDefFunc
+	wsidFunc        WSIDFunc
 }
 
 func (s *recordsStorage) NewKeyBuilder(entity appdef.QName, _ istructs.IStateKeyBuilder) istructs.IStateKeyBuilder {
@@ -105,6 +108,40 @@ func (s *recordsStorage) GetBatch(items []GetBatchItem) (err error) {
 	}
 	return err
 }
+
+// Reads records by values of leading fields of index
+func (s *recordsStorage) Read(kb istructs.IStateKeyBuilder, callback istructs.ValueCallback) (err error) {
+	k := kb.(*recordsKeyBuilder)
+	rec, ok := s.appDefFunc().Type(k.entity).(appdef.IRecord)
+	if !ok {
+		return fmt.Errorf("record «%v»: %w", k.entity, ErrNotFound)
+	}
+	index := rec.Index(k.index)
+	if index == nil {
+		return fmt.Errorf("index «%v» of record «%v»: %w", k.index, k.entity, ErrNotFound)
+	}
+	values := make([]interface{}, 0, len(k.values))
+	for _, f := range index.Fields() {
+		v, ok := k.values[f.Name()]
+		if !ok {
+			break
+		}
+		values = append(values, v)
+	}
+	if len(values) != len(k.values) {
+		return fmt.Errorf("index «%v» fields %v: values must be specified for leading fields only: %w", k.index, k.values, ErrIndexValuesMissed)
+	}
+	return coreutils.ReadByIndex(s.ctx, s.viewRecordsFunc(), k.wsid, index, values, func(id istructs.RecordID) error {
+		record, err := s.recordsFunc().Get(k.wsid, true, id)
+		if err != nil {
+			return err
+		}
+		if record.QName() == appdef.NullQName {
+			return nil
+		}
+		return callback(&key{data: map[string]interface{}{Field_ID: int64(id)}}, &recordsValue{record: record})
+	})
+}
 func (s *recordsStorage) Validate([]ApplyBatchItem) (err error)   { return }
 func (s *recordsStorage) ApplyBatch([]ApplyBatchItem) (err error) { return }
 func (s *recordsStorage) ProvideValueBuilder(key istructs.IStateKeyBuilder, _ istructs.IStateValueBuilder) istructs.IStateValueBuilder {
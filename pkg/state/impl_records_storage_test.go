@@ -62,7 +62,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 			On("Records").Return(records).
 			On("ViewRecords").Return(&nilViewRecords{}).
 			On("Events").Return(&nilEvents{})
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
 		k1, err := s.KeyBuilder(Record, appdef.NullQName)
 		require.NoError(err)
 		k1.PutRecordID(Field_ID, 1)
@@ -140,7 +140,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 			On("Records").Return(records).
 			On("ViewRecords").Return(&nilViewRecords{}).
 			On("Events").Return(&nilEvents{})
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
 		k1, err := s.KeyBuilder(Record, appdef.NullQName)
 		require.NoError(err)
 		k1.PutQName(Field_Singleton, testRecordQName1)
@@ -176,7 +176,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 	})
 	t.Run("Should return error when 'id' not found", func(t *testing.T) {
 		require := require.New(t)
-		s := ProvideQueryProcessorStateFactory()(context.Background(), &nilAppStructs{}, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), &nilAppStructs{}, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
 		k, err := s.KeyBuilder(Record, appdef.NullQName)
 		require.NoError(err)
 
@@ -195,7 +195,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 			On("Records").Return(records).
 			On("ViewRecords").Return(&nilViewRecords{}).
 			On("Events").Return(&nilEvents{})
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
 		k, err := s.KeyBuilder(Record, appdef.NullQName)
 		require.NoError(err)
 		k.PutRecordID(Field_ID, istructs.RecordID(1))
@@ -215,7 +215,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 			On("Records").Return(records).
 			On("ViewRecords").Return(&nilViewRecords{}).
 			On("Events").Return(&nilEvents{})
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
 		k, err := s.KeyBuilder(Record, appdef.NullQName)
 		require.NoError(err)
 		k.PutQName(Field_Singleton, testRecordQName1)
@@ -226,6 +226,82 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 		require.ErrorIs(err, errTest)
 	})
 }
+func TestRecordsStorage_Read(t *testing.T) {
+	idxName := appdef.NewQName("test", "idx")
+	appDef := appdef.New()
+	appDef.AddCDoc(testRecordQName1).
+		AddField("name", appdef.DataKind_string, false).
+		AddField("age", appdef.DataKind_int32, false).(appdef.ICDocBuilder).
+		AddIndex(idxName, []string{"name", "age"})
+	app, err := appDef.Build()
+	require.NoError(t, err)
+
+	newState := func(viewRecords istructs.IViewRecords, records istructs.IRecords) IHostState {
+		appStructs := &mockAppStructs{}
+		appStructs.
+			On("AppDef").Return(app).
+			On("Records").Return(records).
+			On("ViewRecords").Return(viewRecords).
+			On("Events").Return(&nilEvents{})
+		return ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
+	}
+
+	t.Run("Should read records by index", func(t *testing.T) {
+		require := require.New(t)
+		kb := &mockKeyBuilder{}
+		kb.
+			On("PutQName", "IndexQName", idxName).
+			On("PutBytes", "Values", mock.Anything)
+		viewRecords := &mockViewRecords{}
+		viewRecords.
+			On("KeyBuilder", mock.Anything).Return(kb).
+			On("Read", context.Background(), istructs.WSID(1), kb, mock.Anything).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				cb := args.Get(3).(istructs.ValuesCallback)
+				actual := &mockValue{}
+				actual.On("AsRecordID", "ID").Return(istructs.RecordID(7))
+				require.NoError(cb(nil, actual))
+			})
+		record := &mockRecord{}
+		record.On("QName").Return(testRecordQName1)
+		records := &mockRecords{}
+		records.On("Get", istructs.WSID(1), true, istructs.RecordID(7)).Return(record, nil)
+
+		s := newState(viewRecords, records)
+		k, err := s.KeyBuilder(Record, testRecordQName1)
+		require.NoError(err)
+		k.PutQName(Field_Index, idxName)
+		k.PutString("name", "John")
+
+		ids := make([]int64, 0)
+		err = s.Read(k, func(key istructs.IKey, value istructs.IStateValue) error {
+			ids = append(ids, key.AsInt64(Field_ID))
+			require.Equal(record, value.AsRecord(""))
+			return nil
+		})
+		require.NoError(err)
+		require.Equal([]int64{7}, ids)
+		kb.AssertExpectations(t)
+	})
+
+	t.Run("Should return error", func(t *testing.T) {
+		require := require.New(t)
+		s := newState(&nilViewRecords{}, &nilRecords{})
+
+		k, err := s.KeyBuilder(Record, testRecordQName1)
+		require.NoError(err)
+		k.PutQName(Field_Index, appdef.NewQName("test", "unknown"))
+		require.ErrorIs(s.Read(k, func(istructs.IKey, istructs.IStateValue) error { return nil }), ErrNotFound)
+
+		k, err = s.KeyBuilder(Record, testRecordQName1)
+		require.NoError(err)
+		k.PutQName(Field_Index, idxName)
+		k.PutInt32("age", 42)
+		require.ErrorIs(s.Read(k, func(istructs.IKey, istructs.IStateValue) error { return nil }), ErrIndexValuesMissed)
+	})
+}
+
 func TestRecordsStorage_Insert(t *testing.T) {
 	require := require.New(t)
 	fieldName := "name"
@@ -235,7 +311,7 @@ func TestRecordsStorage_Insert(t *testing.T) {
 		On("PutString", fieldName, value)
 	cud := &mockCUD{}
 	cud.On("Create").Return(rw)
-	s := ProvideCommandProcessorStateFactory()(context.Background(), nil, nil, SimpleWSIDFunc(istructs.NullWSID), nil, func() istructs.ICUD { return cud }, nil, nil, 1, nil)
+	s := ProvideCommandProcessorStateFactory()(context.Background(), nil, nil, SimpleWSIDFunc(istructs.NullWSID), nil, func() istructs.ICUD { return cud }, nil, nil, 1, nil, nil, nil)
 	kb, err := s.KeyBuilder(Record, testRecordQName1)
 	require.NoError(err)
 
@@ -257,7 +333,7 @@ func TestRecordsStorage_Update(t *testing.T) {
 	sv := &recordsValue{record: r}
 	cud := &mockCUD{}
 	cud.On("Update", mock.Anything).Return(rw)
-	s := ProvideCommandProcessorStateFactory()(context.Background(), nil, nil, SimpleWSIDFunc(istructs.NullWSID), nil, func() istructs.ICUD { return cud }, nil, nil, 1, nil)
+	s := ProvideCommandProcessorStateFactory()(context.Background(), nil, nil, SimpleWSIDFunc(istructs.NullWSID), nil, func() istructs.ICUD { return cud }, nil, nil, 1, nil, nil, nil)
 	kb, err := s.KeyBuilder(Record, testRecordQName1)
 	require.NoError(err)
 
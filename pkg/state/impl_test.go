@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -206,12 +207,18 @@ func (r *mockViewRecords) Get(workspace istructs.WSID, key istructs.IKeyBuilder)
 func (r *mockViewRecords) GetBatch(workspace istructs.WSID, kv []istructs.ViewRecordGetBatchItem) (err error) {
 	return r.Called(workspace, kv).Error(0)
 }
+func (r *mockViewRecords) PutWithTTL(workspace istructs.WSID, key istructs.IKeyBuilder, value istructs.IValueBuilder, ttl time.Duration) (err error) {
+	return r.Called(workspace, key, value, ttl).Error(0)
+}
 func (r *mockViewRecords) PutBatch(workspace istructs.WSID, batch []istructs.ViewKV) (err error) {
 	return r.Called(workspace, batch).Error(0)
 }
 func (r *mockViewRecords) Read(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
 	return r.Called(ctx, workspace, key, cb).Error(0)
 }
+func (r *mockViewRecords) ReadFrom(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, from istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
+	return r.Called(ctx, workspace, key, from, cb).Error(0)
+}
 
 type mockRecord struct {
 	istructs.IRecord
@@ -299,6 +306,7 @@ func (b *mockKeyBuilder) PutInt64(name string, value int64)                { b.C
 func (b *mockKeyBuilder) PutString(name, value string)                     { b.Called(name, value) }
 func (b *mockKeyBuilder) PutRecordID(name string, value istructs.RecordID) { b.Called(name, value) }
 func (b *mockKeyBuilder) PutQName(name string, value appdef.QName)         { b.Called(name, value) }
+func (b *mockKeyBuilder) PutBytes(name string, value []byte)               { b.Called(name, value) }
 func (b *mockKeyBuilder) Equals(src istructs.IKeyBuilder) bool             { return b.Called(src).Bool(0) }
 
 type nilKeyBuilder struct {
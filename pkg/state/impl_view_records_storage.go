@@ -5,11 +5,14 @@
 package state
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
+	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
 type viewRecordsStorage struct {
@@ -25,6 +28,7 @@ func (s *viewRecordsStorage) NewKeyBuilder(entity appdef.QName, _ istructs.IStat
 		IKeyBuilder: s.viewRecordsFunc().KeyBuilder(entity),
 		view:        entity,
 		wsid:        s.wsidFunc(),
+		viewRecords: s.viewRecordsFunc,
 	}
 }
 func (s *viewRecordsStorage) Get(key istructs.IStateKeyBuilder) (value istructs.IStateValue, err error) {
@@ -76,18 +80,25 @@ func (s *viewRecordsStorage) Read(kb istructs.IStateKeyBuilder, callback istruct
 		})
 	}
 	vrkb := kb.(*viewKeyBuilder)
+	if vrkb.from != nil {
+		return s.viewRecordsFunc().ReadFrom(s.ctx, vrkb.wsid, vrkb.IKeyBuilder, vrkb.from, cb)
+	}
 	return s.viewRecordsFunc().Read(s.ctx, vrkb.wsid, vrkb.IKeyBuilder, cb)
 }
 func (s *viewRecordsStorage) Validate([]ApplyBatchItem) (err error) { return err }
 func (s *viewRecordsStorage) ApplyBatch(items []ApplyBatchItem) (err error) {
 	batches := make(map[istructs.WSID][]istructs.ViewKV)
 	nn := make(map[n10n]istructs.Offset)
+	changes := make(map[n10n][]istructs.ViewKV)
 	for _, item := range items {
 		k := item.key.(*viewKeyBuilder)
 		v := item.value.(*viewValueBuilder)
-		batches[k.wsid] = append(batches[k.wsid], istructs.ViewKV{Key: k.IKeyBuilder, Value: v.IValueBuilder})
-		if nn[n10n{wsid: k.wsid, view: k.view}] < v.offset {
-			nn[n10n{wsid: k.wsid, view: k.view}] = v.offset
+		kv := istructs.ViewKV{Key: k.IKeyBuilder, Value: v.IValueBuilder, Deleted: v.deleted}
+		batches[k.wsid] = append(batches[k.wsid], kv)
+		n := n10n{wsid: k.wsid, view: k.view}
+		changes[n] = append(changes[n], kv)
+		if nn[n] < v.offset {
+			nn[n] = v.offset
 		}
 	}
 	for wsid, batch := range batches {
@@ -97,10 +108,43 @@ func (s *viewRecordsStorage) ApplyBatch(items []ApplyBatchItem) (err error) {
 		}
 	}
 	for n, newOffset := range nn {
-		s.n10nFunc(n.view, n.wsid, newOffset)
+		s.n10nFunc(n.view, n.wsid, newOffset, s.rowsFunc(changes[n]))
 	}
 	return err
 }
+
+// Returns func to build JSON array of the changed rows, e.g. [{"Key":{"pk":1,"cc":"a"},"Value":{"total":10}}].
+// Building stops as soon as the array exceeds maxSize, nil is returned then
+func (s *viewRecordsStorage) rowsFunc(changes []istructs.ViewKV) func(maxSize int) []byte {
+	return func(maxSize int) []byte {
+		appDef := s.appDefFunc()
+		buf := bytes.NewBufferString("[")
+		for i, kv := range changes {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			row := map[string]interface{}{
+				"Key": coreutils.FieldsToMap(kv.Key.(istructs.IRowReader), appDef, coreutils.WithNonNilsOnly()),
+			}
+			if kv.Deleted {
+				row["Deleted"] = true
+			} else {
+				row["Value"] = coreutils.FieldsToMap(kv.Value.Build(), appDef, coreutils.WithNonNilsOnly())
+			}
+			bb, err := json.Marshal(row)
+			if err != nil {
+				// subscribers will receive the offset only
+				return nil
+			}
+			if buf.Len()+len(bb)+1 > maxSize {
+				return nil
+			}
+			buf.Write(bb)
+		}
+		buf.WriteByte(']')
+		return buf.Bytes()
+	}
+}
 func (s *viewRecordsStorage) ProvideValueBuilder(kb istructs.IStateKeyBuilder, _ istructs.IStateValueBuilder) istructs.IStateValueBuilder {
 	return &viewValueBuilder{
 		IValueBuilder: s.viewRecordsFunc().NewValueBuilder(kb.(*viewKeyBuilder).view),
@@ -37,7 +37,7 @@ func TestViewRecordsStorage_GetBatch(t *testing.T) {
 			On("Records").Return(&nilRecords{}).
 			On("Events").Return(&nilEvents{}).
 			On("ViewRecords").Return(viewRecords)
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
 		k, e := s.KeyBuilder(View, testViewRecordQName1)
 		require.Nil(e)
 		k.PutInt64("pkk", 64)
@@ -69,7 +69,7 @@ func TestViewRecordsStorage_GetBatch(t *testing.T) {
 			On("Records").Return(&nilRecords{}).
 			On("Events").Return(&nilEvents{}).
 			On("ViewRecords").Return(viewRecords)
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
 		k, err := s.KeyBuilder(View, testViewRecordQName1)
 		require.NoError(err)
 		k.PutInt64("pkk", 64)
@@ -99,7 +99,7 @@ func TestViewRecordsStorage_Read(t *testing.T) {
 			On("Records").Return(&nilRecords{}).
 			On("Events").Return(&nilEvents{}).
 			On("ViewRecords").Return(viewRecords)
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
 		k, err := s.KeyBuilder(View, testViewRecordQName1)
 		require.NoError(err)
 
@@ -111,6 +111,34 @@ func TestViewRecordsStorage_Read(t *testing.T) {
 
 		require.True(touched)
 	})
+	t.Run("Should read from clustering columns", func(t *testing.T) {
+		require := require.New(t)
+		viewRecords := &mockViewRecords{}
+		viewRecords.
+			On("KeyBuilder", testViewRecordQName1).Return(newKeyBuilder(View, testViewRecordQName1)).
+			On("ReadFrom", context.Background(), istructs.WSID(1), mock.Anything, mock.Anything, mock.AnythingOfType("istructs.ValuesCallback")).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				from := args.Get(3).(*keyBuilder)
+				require.Equal(int64(42), from.data["id"])
+			})
+		appStructs := &mockAppStructs{}
+		appStructs.
+			On("AppDef").Return(&nilAppDef{}).
+			On("Records").Return(&nilRecords{}).
+			On("Events").Return(&nilEvents{}).
+			On("ViewRecords").Return(viewRecords)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
+		k, err := s.KeyBuilder(View, testViewRecordQName1)
+		require.NoError(err)
+		k.(IViewKeyBuilder).ReadFrom().PutInt64("id", 42)
+
+		err = s.Read(k, func(istructs.IKey, istructs.IStateValue) error { return nil })
+		require.NoError(err)
+
+		viewRecords.AssertCalled(t, "ReadFrom", context.Background(), istructs.WSID(1), mock.Anything, mock.Anything, mock.Anything)
+		viewRecords.AssertNotCalled(t, "Read", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 	t.Run("Should return error on read", func(t *testing.T) {
 		require := require.New(t)
 		viewRecords := &mockViewRecords{}
@@ -124,7 +152,7 @@ func TestViewRecordsStorage_Read(t *testing.T) {
 			On("Records").Return(&nilRecords{}).
 			On("Events").Return(&nilEvents{}).
 			On("ViewRecords").Return(viewRecords)
-		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil)
+		s := ProvideQueryProcessorStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, nil, nil, nil, nil)
 		k, err := s.KeyBuilder(View, testViewRecordQName1)
 		require.NoError(err)
 
@@ -168,6 +196,55 @@ func TestViewRecordsStorage_ApplyBatch_should_return_error_on_put_batch(t *testi
 	require.ErrorIs(err, errTest)
 }
 
+type sameKeyBuilder struct{ nilKeyBuilder }
+
+func (b *sameKeyBuilder) Equals(istructs.IKeyBuilder) bool { return true }
+
+func TestViewRecordsStorage_DeleteViewRecord(t *testing.T) {
+	require := require.New(t)
+
+	appDef := appdef.New()
+
+	view := appDef.AddView(testViewRecordQName1)
+	view.KeyBuilder().PartKeyBuilder().AddField("pkk", appdef.DataKind_int64)
+	view.KeyBuilder().ClustColsBuilder().AddField("cck", appdef.DataKind_string)
+	view.ValueBuilder().AddField("vk", appdef.DataKind_string, false)
+
+	viewRecords := &mockViewRecords{}
+	viewRecords.
+		On("KeyBuilder", testViewRecordQName1).Return(&sameKeyBuilder{}).
+		On("NewValueBuilder", testViewRecordQName1).Return(&nilValueBuilder{}).
+		On("PutBatch", istructs.WSID(1), mock.MatchedBy(func(batch []istructs.ViewKV) bool {
+			return len(batch) == 1 && batch[0].Deleted
+		})).Return(nil)
+	appStructs := &mockAppStructs{}
+	appStructs.
+		On("AppDef").Return(appDef).
+		On("ViewRecords").Return(viewRecords).
+		On("Records").Return(&nilRecords{}).
+		On("Events").Return(&nilEvents{})
+	s := ProvideAsyncActualizerStateFactory()(context.Background(), appStructs, nil, SimpleWSIDFunc(istructs.WSID(1)), nil, nil, 10, 10)
+	kb, err := s.KeyBuilder(View, testViewRecordQName1)
+	require.NoError(err)
+	require.NoError(DeleteViewRecord(s, kb))
+
+	_, err = s.ApplyIntents()
+	require.NoError(err)
+
+	_, ok, err := s.CanExist(kb)
+	require.NoError(err)
+	require.False(ok, "deleted record must not be read from bundle")
+
+	require.NoError(s.FlushBundles())
+	viewRecords.AssertExpectations(t)
+
+	t.Run("Should be error to delete record of not a view storage", func(t *testing.T) {
+		kb, err := s.KeyBuilder(Record, testRecordQName1)
+		require.NoError(err)
+		require.ErrorIs(DeleteViewRecord(s, kb), ErrNotSupported)
+	})
+}
+
 func TestViewRecordsStorage_toJSON(t *testing.T) {
 
 	appDef := appdef.New()
@@ -188,3 +265,33 @@ func TestViewRecordsStorage_toJSON(t *testing.T) {
 		On("AsQName", mock.Anything).Return(testViewRecordQName1)
 
 }
+
+func TestViewRecordsStorage_rowsFunc(t *testing.T) {
+	require := require.New(t)
+
+	type rowKey struct {
+		nilKeyBuilder
+		mockRowReader
+	}
+	key := &rowKey{}
+	key.mockRowReader.On("AsQName", appdef.SystemField_QName).Return(appdef.NullQName)
+	value := &mockValue{}
+	value.On("AsQName", appdef.SystemField_QName).Return(appdef.NullQName)
+	valueBuilder := &mockValueBuilder{}
+	valueBuilder.On("Build").Return(value)
+
+	s := &viewRecordsStorage{appDefFunc: func() appdef.IAppDef { return &nilAppDef{} }}
+	rows := s.rowsFunc([]istructs.ViewKV{{Key: key, Value: valueBuilder}, {Key: key, Value: valueBuilder}})
+
+	t.Run("Should build JSON array of rows", func(t *testing.T) {
+		require.JSONEq(`[{"Key":{},"Value":{}},{"Key":{},"Value":{}}]`, string(rows(1024)))
+	})
+	t.Run("Should mark deleted rows", func(t *testing.T) {
+		rows := s.rowsFunc([]istructs.ViewKV{{Key: key, Deleted: true}})
+		require.JSONEq(`[{"Key":{},"Deleted":true}]`, string(rows(1024)))
+	})
+	t.Run("Should stop building if rows are too large", func(t *testing.T) {
+		require.Nil(rows(len(`[{"Key":{},"Value":{}}]`) - 1))
+		valueBuilder.AssertNumberOfCalls(t, "Build", 2+1)
+	})
+}
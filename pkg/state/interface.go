@@ -49,6 +49,16 @@ type IWithUpdate interface {
 	ProvideValueBuilderForUpdate(key istructs.IStateKeyBuilder, existingValue istructs.IStateValue, existingBuilder istructs.IStateValueBuilder) istructs.IStateValueBuilder
 }
 
+// IViewKeyBuilder is the key builder of the View storage
+type IViewKeyBuilder interface {
+	istructs.IStateKeyBuilder
+
+	// ReadFrom returns writer of the clustering columns of the key to start reading from.
+	// Read skips records which clustering columns are less than ReadFrom ones.
+	// ReadFrom clustering columns must begin with the key clustering columns
+	ReadFrom() istructs.IRowWriter
+}
+
 type IHostState interface {
 	istructs.IState
 	istructs.IIntents
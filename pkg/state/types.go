@@ -23,15 +23,21 @@ import (
 
 type PartitionIDFunc func() istructs.PartitionID
 type WSIDFunc func() istructs.WSID
-type N10nFunc func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset)
+
+// rows returns JSON array of the changed view rows, it is evaluated lazily
+type N10nFunc func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset, rows func(maxSize int) []byte)
 type AppStructsFunc func() istructs.IAppStructs
 type CUDFunc func() istructs.ICUD
 type CmdResultBuilderFunc func() istructs.IObjectBuilder
+type QueryResultBuilderFunc func() istructs.IObjectBuilder
+type ExecQueryCallbackFunc func() istructs.ExecQueryCallback
+type ArgFunc func() istructs.IObject
+type UnloggedArgFunc func() istructs.IObject
 type PrincipalsFunc func() []iauthnz.Principal
 type TokenFunc func() string
-type CommandProcessorStateFactory func(ctx context.Context, appStructsFunc AppStructsFunc, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, secretReader isecrets.ISecretReader, cudFunc CUDFunc, principalPayloadFunc PrincipalsFunc, tokenFunc TokenFunc, intentsLimit int, cmdResultBuilderFunc CmdResultBuilderFunc) IHostState
+type CommandProcessorStateFactory func(ctx context.Context, appStructsFunc AppStructsFunc, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, secretReader isecrets.ISecretReader, cudFunc CUDFunc, principalPayloadFunc PrincipalsFunc, tokenFunc TokenFunc, intentsLimit int, cmdResultBuilderFunc CmdResultBuilderFunc, argFunc ArgFunc, unloggedArgFunc UnloggedArgFunc) IHostState
 type SyncActualizerStateFactory func(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, n10nFunc N10nFunc, secretReader isecrets.ISecretReader, intentsLimit int) IHostState
-type QueryProcessorStateFactory func(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, secretReader isecrets.ISecretReader, principalPayloadFunc PrincipalsFunc, tokenFunc TokenFunc) IHostState
+type QueryProcessorStateFactory func(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, secretReader isecrets.ISecretReader, principalPayloadFunc PrincipalsFunc, tokenFunc TokenFunc, argFunc ArgFunc, resultBuilderFunc QueryResultBuilderFunc, resultFunc ExecQueryCallbackFunc) IHostState
 type AsyncActualizerStateFactory func(ctx context.Context, appStructs istructs.IAppStructs, partitionIDFunc PartitionIDFunc, wsidFunc WSIDFunc, n10nFunc N10nFunc, secretReader isecrets.ISecretReader, intentsLimit, bundlesLimit int,
 	opts ...ActualizerStateOptFunc) IBundledHostState
 
@@ -157,6 +163,8 @@ type recordsKeyBuilder struct {
 	singleton appdef.QName
 	wsid      istructs.WSID
 	entity    appdef.QName
+	index     appdef.QName
+	values    map[string]interface{} // values of index fields
 }
 
 func (b *recordsKeyBuilder) Storage() appdef.QName {
@@ -172,6 +180,9 @@ func (b *recordsKeyBuilder) String() string {
 	if b.singleton != appdef.NullQName {
 		_, _ = sb.WriteString(fmt.Sprintf(", singleton - %s", b.singleton))
 	}
+	if b.index != appdef.NullQName {
+		_, _ = sb.WriteString(fmt.Sprintf(", index - %s, values - %v", b.index, b.values))
+	}
 	_, _ = sb.WriteString(fmt.Sprintf(", WSID - %d", b.wsid))
 	return sb.String()
 }
@@ -181,8 +192,7 @@ func (b *recordsKeyBuilder) PutInt64(name string, value int64) {
 		b.wsid = istructs.WSID(value)
 		return
 	}
-	//TODO ???
-	panic(name)
+	b.putValue(name, value)
 }
 
 func (b *recordsKeyBuilder) PutRecordID(name string, value istructs.RecordID) {
@@ -190,17 +200,33 @@ func (b *recordsKeyBuilder) PutRecordID(name string, value istructs.RecordID) {
 		b.id = value
 		return
 	}
-	//TODO ???
-	panic(name)
+	b.putValue(name, value)
 }
 
 func (b *recordsKeyBuilder) PutQName(name string, value appdef.QName) {
-	if name == Field_Singleton {
+	switch name {
+	case Field_Singleton:
 		b.singleton = value
-		return
+	case Field_Index:
+		b.index = value
+	default:
+		b.putValue(name, value)
+	}
+}
+
+func (b *recordsKeyBuilder) PutInt32(name string, value int32)     { b.putValue(name, value) }
+func (b *recordsKeyBuilder) PutFloat32(name string, value float32) { b.putValue(name, value) }
+func (b *recordsKeyBuilder) PutFloat64(name string, value float64) { b.putValue(name, value) }
+func (b *recordsKeyBuilder) PutBytes(name string, value []byte)    { b.putValue(name, value) }
+func (b *recordsKeyBuilder) PutString(name string, value string)   { b.putValue(name, value) }
+func (b *recordsKeyBuilder) PutBool(name string, value bool)       { b.putValue(name, value) }
+
+// Puts value of index field to read records by index
+func (b *recordsKeyBuilder) putValue(name string, value interface{}) {
+	if b.values == nil {
+		b.values = make(map[string]interface{})
 	}
-	//TODO ???
-	panic(name)
+	b.values[name] = value
 }
 
 type recordsValueBuilder struct {
@@ -224,8 +250,18 @@ func (b *recordsValueBuilder) PutRecordID(name string, value istructs.RecordID)
 
 type viewKeyBuilder struct {
 	istructs.IKeyBuilder
-	wsid istructs.WSID
-	view appdef.QName
+	wsid        istructs.WSID
+	view        appdef.QName
+	viewRecords viewRecordsFunc
+	from        istructs.IKeyBuilder
+}
+
+// IViewKeyBuilder.ReadFrom
+func (b *viewKeyBuilder) ReadFrom() istructs.IRowWriter {
+	if b.from == nil {
+		b.from = b.viewRecords().KeyBuilder(b.view)
+	}
+	return b.from
 }
 
 func (b *viewKeyBuilder) PutInt64(name string, value int64) {
@@ -264,8 +300,9 @@ func (b *viewKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
 
 type viewValueBuilder struct {
 	istructs.IValueBuilder
-	offset istructs.Offset
-	entity appdef.QName
+	offset  istructs.Offset
+	entity  appdef.QName
+	deleted bool
 }
 
 func (b *viewValueBuilder) PutInt64(name string, value int64) {
@@ -310,6 +347,10 @@ func (v *recordsValue) AsRecord(string) (record istructs.IRecord) { return v.rec
 func (v *recordsValue) FieldNames(cb func(fieldName string)) {
 	v.record.FieldNames(cb)
 }
+func (v *recordsValue) AsArray(name string, cb func(int, interface{})) { v.record.AsArray(name, cb) }
+func (v *recordsValue) AsValue(name string) istructs.IStateValue {
+	return newArrayValue(v.record, name)
+}
 
 type pLogValue struct {
 	baseStateValue
@@ -604,6 +645,54 @@ func (v *requestSubjectValue) AsString(name string) string {
 	}
 }
 
+// Argument object value. Containers are read by AsValue(container) as arrays of objects
+type objectValue struct {
+	baseStateValue
+	object istructs.IObject
+}
+
+func (v *objectValue) AsInt32(name string) int32        { return v.object.AsInt32(name) }
+func (v *objectValue) AsInt64(name string) int64        { return v.object.AsInt64(name) }
+func (v *objectValue) AsFloat32(name string) float32    { return v.object.AsFloat32(name) }
+func (v *objectValue) AsFloat64(name string) float64    { return v.object.AsFloat64(name) }
+func (v *objectValue) AsBytes(name string) []byte       { return v.object.AsBytes(name) }
+func (v *objectValue) AsString(name string) string      { return v.object.AsString(name) }
+func (v *objectValue) AsQName(name string) appdef.QName { return v.object.AsQName(name) }
+func (v *objectValue) AsBool(name string) bool          { return v.object.AsBool(name) }
+func (v *objectValue) AsRecordID(name string) istructs.RecordID {
+	return v.object.AsRecordID(name)
+}
+func (v *objectValue) AsRecord(string) istructs.IRecord { return v.object.AsRecord() }
+func (v *objectValue) FieldNames(cb func(fieldName string)) {
+	v.object.FieldNames(cb)
+}
+func (v *objectValue) AsArray(name string, cb func(int, interface{})) { v.object.AsArray(name, cb) }
+func (v *objectValue) AsValue(name string) istructs.IStateValue {
+	isContainer := false
+	v.object.Containers(func(container string) {
+		isContainer = isContainer || (container == name)
+	})
+	if !isContainer {
+		return newArrayValue(v.object, name)
+	}
+	children := &objectsValue{}
+	v.object.Children(name, func(child istructs.IObject) {
+		children.objects = append(children.objects, child)
+	})
+	return children
+}
+
+// Children objects of container. Objects are read by GetAsValue(index)
+type objectsValue struct {
+	baseStateValue
+	objects []istructs.IObject
+}
+
+func (v *objectsValue) Length() int { return len(v.objects) }
+func (v *objectsValue) GetAsValue(index int) istructs.IStateValue {
+	return &objectValue{object: v.objects[index]}
+}
+
 type viewValue struct {
 	baseStateValue
 	value istructs.IValue
@@ -623,6 +712,10 @@ func (v *viewValue) AsRecordID(name string) istructs.RecordID {
 func (v *viewValue) AsRecord(name string) istructs.IRecord {
 	return v.value.AsRecord(name)
 }
+func (v *viewValue) AsArray(name string, cb func(int, interface{})) { v.value.AsArray(name, cb) }
+func (v *viewValue) AsValue(name string) istructs.IStateValue {
+	return newArrayValue(v.value, name)
+}
 
 type cudsValue struct {
 	istructs.IStateValue
@@ -655,6 +748,29 @@ func (v *cudRowValue) AsBool(name string) bool {
 func (v *cudRowValue) AsRecordID(name string) istructs.RecordID {
 	return v.value.AsRecordID(name)
 }
+func (v *cudRowValue) AsArray(name string, cb func(int, interface{})) { v.value.AsArray(name, cb) }
+
+// Array field value. Items are read by GetAs×××(index)
+type arrayValue struct {
+	baseStateValue
+	items []interface{}
+}
+
+func newArrayValue(rr istructs.IRowReader, name string) *arrayValue {
+	v := &arrayValue{}
+	rr.AsArray(name, func(_ int, item interface{}) {
+		v.items = append(v.items, item)
+	})
+	return v
+}
+
+func (v *arrayValue) Length() int                    { return len(v.items) }
+func (v *arrayValue) GetAsString(index int) string   { return v.items[index].(string) }
+func (v *arrayValue) GetAsInt32(index int) int32     { return v.items[index].(int32) }
+func (v *arrayValue) GetAsInt64(index int) int64     { return v.items[index].(int64) }
+func (v *arrayValue) GetAsFloat32(index int) float32 { return v.items[index].(float32) }
+func (v *arrayValue) GetAsFloat64(index int) float64 { return v.items[index].(float64) }
+func (v *arrayValue) GetAsBool(index int) bool       { return v.items[index].(bool) }
 
 type baseStateValue struct{}
 
@@ -669,6 +785,7 @@ func (v *baseStateValue) AsBool(string) bool                              { pani
 func (v *baseStateValue) AsRecordID(string) istructs.RecordID             { panic(errNotImplemented) }
 func (v *baseStateValue) RecordIDs(bool, func(string, istructs.RecordID)) { panic(errNotImplemented) }
 func (v *baseStateValue) FieldNames(func(string))                         { panic(errNotImplemented) }
+func (v *baseStateValue) AsArray(string, func(int, interface{}))          { panic(errNotImplemented) }
 func (v *baseStateValue) AsRecord(string) istructs.IRecord                { panic(errNotImplemented) }
 func (v *baseStateValue) AsEvent(string) istructs.IDbEvent                { panic(errNotImplemented) }
 func (v *baseStateValue) Length() int                                     { panic(errCurrentValueIsNotAnArray) }
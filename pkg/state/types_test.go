@@ -121,3 +121,27 @@ func TestPLogKeyBuilder(t *testing.T) {
 		require.Equal(t, "plog partitionID - 30, offset - 20, count - 10", kb.(fmt.Stringer).String())
 	})
 }
+
+type testArrayReader struct {
+	istructs.NullObject
+}
+
+func (*testArrayReader) AsArray(name string, cb func(int, interface{})) {
+	if name == "tags" {
+		for i, tag := range []string{"red", "green"} {
+			cb(i, tag)
+		}
+	}
+}
+
+func TestArrayValue(t *testing.T) {
+	require := require.New(t)
+
+	v := newArrayValue(&testArrayReader{}, "tags")
+	require.Equal(2, v.Length())
+	require.Equal("red", v.GetAsString(0))
+	require.Equal("green", v.GetAsString(1))
+	require.Panics(func() { v.GetAsBytes(0) })
+
+	require.Zero(newArrayValue(&testArrayReader{}, "unknown").Length())
+}
@@ -6,10 +6,27 @@
 package state
 
 import (
+	"fmt"
+
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
+// Deletes view record with the specified key by intents.
+//
+// Returns error if key is not a view record key
+func DeleteViewRecord(intents istructs.IIntents, key istructs.IStateKeyBuilder) error {
+	if key.Storage() != View {
+		return fmt.Errorf("unable to delete record of storage %v: %w", key.Storage(), ErrNotSupported)
+	}
+	vb, err := intents.NewValue(key)
+	if err != nil {
+		return err
+	}
+	vb.(*viewValueBuilder).deleted = true
+	return nil
+}
+
 func GetPrincipalTokenFromState(st istructs.IState) (token string, err error) {
 	kb, err := st.KeyBuilder(RequestSubject, appdef.NullQName)
 	if err != nil {
@@ -8,10 +8,13 @@ package collection
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/state"
 )
 
@@ -27,28 +30,115 @@ func collectionResultQName(args istructs.PrepareArgs) appdef.QName {
 	return qname
 }
 
-func collectionFuncExec(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
-	if args.ArgumentObject == nil {
-		return errors.New("ArgumentObject is not defined in PrepareArgs")
+func provideCollectionFuncExec(appDef appdef.IAppDef) istructsmem.ExecQueryClosure {
+	return func(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+		if args.ArgumentObject == nil {
+			return errors.New("ArgumentObject is not defined in PrepareArgs")
+		}
+		qnameStr := args.ArgumentObject.AsString(field_Schema)
+		resultsQName, err := appdef.ParseQName(qnameStr)
+		if err != nil {
+			return err
+		}
+
+		if indexStr := args.ArgumentObject.AsString(field_Index); indexStr != "" {
+			ids, err := readIDsByIndex(appDef, args, resultsQName, indexStr)
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := readCollection(args, resultsQName, id, nil, callback); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		return readCollection(args, resultsQName, args.ArgumentObject.AsRecordID(field_ID), args.Page, callback)
 	}
-	qnameStr := args.ArgumentObject.AsString(field_Schema)
-	resultsQName, err := appdef.ParseQName(qnameStr)
+}
+
+// Returns IDs of documents, which index fields values are equal to IndexValues argument
+func readIDsByIndex(appDef appdef.IAppDef, args istructs.ExecQueryArgs, docQName appdef.QName, indexStr string) ([]istructs.RecordID, error) {
+	indexQName, err := appdef.ParseQName(indexStr)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	doc, ok := appDef.Type(docQName).(appdef.IRecord)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a record", docQName)
+	}
+	if doc.Kind() != appdef.TypeKind_CDoc {
+		// IDs of records are not IDs of collection documents, use index of the owning document instead
+		return nil, fmt.Errorf("index %v of %v: %w", indexQName, doc, errNotDocumentIndex)
+	}
+	index := doc.Index(indexQName)
+	if index == nil {
+		return nil, fmt.Errorf("index %v of %v: %w", indexQName, docQName, state.ErrNotFound)
+	}
+	values := []interface{}{}
+	if valuesStr := args.ArgumentObject.AsString(field_IndexValues); valuesStr != "" {
+		if err := json.Unmarshal([]byte(valuesStr), &values); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal index values: %w", err)
+		}
 	}
+	if len(values) > len(index.Fields()) {
+		return nil, fmt.Errorf("%d index values specified, but index %v has only %d fields", len(values), indexQName, len(index.Fields()))
+	}
+
+	kb, err := args.State.KeyBuilder(state.Record, docQName)
+	if err != nil {
+		return nil, err
+	}
+	kb.PutQName(state.Field_Index, indexQName)
+	for i, value := range values {
+		name := index.Fields()[i].Name()
+		switch v := value.(type) {
+		case float64:
+			kb.PutFloat64(name, v)
+		case string:
+			kb.PutString(name, v)
+		case bool:
+			kb.PutBool(name, v)
+		default:
+			return nil, fmt.Errorf("unsupported value %v of index field %s", value, name)
+		}
+	}
+
+	ids := []istructs.RecordID{}
+	err = args.State.Read(kb, func(key istructs.IKey, _ istructs.IStateValue) error {
+		ids = append(ids, istructs.RecordID(key.AsInt64(state.Field_ID)))
+		return nil
+	})
+	return ids, err
+}
 
+// Reads documents of the collection. If page is not nil then documents up to page.AfterID are skipped
+// and reading stops after page.Limit+1 documents
+func readCollection(args istructs.ExecQueryArgs, resultsQName appdef.QName, id istructs.RecordID, page *istructs.QueryPage, callback istructs.ExecQueryCallback) (err error) {
 	kb, err := args.State.KeyBuilder(state.View, QNameCollectionView)
 	if err != nil {
 		return err
 	}
 	kb.PutInt32(Field_PartKey, PartitionKeyCollection)
 	kb.PutQName(Field_DocQName, resultsQName)
-	id := args.ArgumentObject.AsRecordID(field_ID)
 	if id != istructs.NullRecordID {
 		kb.PutRecordID(field_DocID, id)
+		page = nil
+	}
+	if page != nil && page.AfterID != istructs.NullRecordID {
+		from := kb.(state.IViewKeyBuilder).ReadFrom()
+		from.PutQName(Field_DocQName, resultsQName)
+		from.PutRecordID(field_DocID, page.AfterID+1)
 	}
 
 	var lastDoc *collectionObject
+	sent := 0
+	send := func() error {
+		lastDoc.handleRawRecords()
+		sent++
+		return callback(lastDoc)
+	}
 
 	err = args.State.Read(kb, func(key istructs.IKey, value istructs.IStateValue) (err error) {
 		rec := value.AsRecord(Field_Record)
@@ -56,19 +146,25 @@ func collectionFuncExec(ctx context.Context, args istructs.ExecQueryArgs, callba
 
 		if lastDoc != nil && lastDoc.ID() == docId {
 			lastDoc.addRawRecord(rec)
-		} else {
-			if lastDoc != nil {
-				lastDoc.handleRawRecords()
-				err = callback(lastDoc)
+			return nil
+		}
+		if lastDoc != nil {
+			if err = send(); err != nil {
+				return err
+			}
+			if page != nil && sent > page.Limit {
+				lastDoc = nil
+				return errPageRead
 			}
-			obj := newCollectionObject(rec)
-			lastDoc = obj
 		}
-		return
+		lastDoc = newCollectionObject(rec)
+		return nil
 	})
+	if errors.Is(err, errPageRead) {
+		return nil
+	}
 	if lastDoc != nil && err == nil {
-		lastDoc.handleRawRecords()
-		err = callback(lastDoc)
+		err = send()
 	}
 	return err
 }
@@ -73,7 +73,9 @@ func buildAppParts(t *testing.T) (appParts appparts.IAppPartitions, cleanup func
 		adb.AddQuery(qNameQueryCollection).
 			SetParam(adb.AddObject(qNameCollectionParams).
 				AddField(field_Schema, appdef.DataKind_string, true).
-				AddField(field_ID, appdef.DataKind_RecordID, false).(appdef.IType).QName()).
+				AddField(field_ID, appdef.DataKind_RecordID, false).
+				AddField(field_Index, appdef.DataKind_string, false).
+				AddField(field_IndexValues, appdef.DataKind_string, false).(appdef.IType).QName()).
 			SetResult(appdef.QNameANY)
 
 		adb.AddQuery(qNameQueryGetCDoc).
@@ -161,7 +163,7 @@ func buildAppParts(t *testing.T) (appParts appparts.IAppPartitions, cleanup func
 
 	appParts, cleanup, err = appparts.New(provider)
 	require.NoError(err)
-	appParts.DeployApp(test.appQName, appDef, test.appPartsCount, test.appEngines)
+	require.NoError(appParts.DeployApp(test.appQName, nil, appDef, test.appPartsCount, test.appEngines))
 	appParts.DeployAppPartitions(test.appQName, []istructs.PartitionID{test.partition})
 
 	return appParts, cleanup
@@ -1112,8 +1114,26 @@ func provideSyncActualizer(ctx context.Context, as istructs.IAppStructs, partiti
 		Ctx:        ctx,
 		AppStructs: func() istructs.IAppStructs { return as },
 		Partition:  partitionID,
-		N10nFunc:   func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset) {},
+		N10nFunc:   func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset, rows func(maxSize int) []byte) {},
 	}
 	actualizerFactory := projectors.ProvideSyncActualizerFactory()
 	return actualizerFactory(actualizerConfig, collectionProjectorFactory(as.AppDef()))
 }
+
+func Test_readIDsByIndex_recordIndex(t *testing.T) {
+	require := require.New(t)
+
+	recName := appdef.NewQName("test", "rec")
+	idxName := appdef.NewQName("test", "recByName")
+
+	adb := appdef.New()
+	rec := adb.AddCRecord(recName)
+	rec.AddField("name", appdef.DataKind_string, false)
+	rec.AddIndex(idxName, []string{"name"})
+	appDef, err := adb.Build()
+	require.NoError(err)
+
+	_, err = readIDsByIndex(appDef, istructs.ExecQueryArgs{}, recName, idxName.String())
+	require.ErrorIs(err, errNotDocumentIndex)
+	require.ErrorContains(err, "CRecord «test.rec»")
+}
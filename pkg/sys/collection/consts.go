@@ -7,6 +7,8 @@
 package collection
 
 import (
+	"errors"
+
 	"github.com/voedger/voedger/pkg/appdef"
 )
 
@@ -31,12 +33,19 @@ var (
 //
 //	FUNC: sys.Collection
 const (
-	field_Schema = "Schema"
-	field_ID     = "ID"
+	field_Schema      = "Schema"
+	field_ID          = "ID"
+	field_Index       = "Index"
+	field_IndexValues = "IndexValues"
 )
 
 var qNameQueryCollection = appdef.NewQName(appdef.SysPackage, "Collection")
 
+// stops reading of the collection view when the page is read
+var errPageRead = errors.New("page is read")
+
+var errNotDocumentIndex = errors.New("collection can be read by index of document only")
+
 // ///////////////////////////////////
 //
 //	FUNC: air.state
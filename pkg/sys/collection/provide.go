@@ -13,7 +13,7 @@ func Provide(cfg *istructsmem.AppConfigType, appDefBuilder appdef.IAppDefBuilder
 	cfg.Resources.Add(istructsmem.NewQueryFunctionCustomResult(
 		qNameQueryCollection,
 		collectionResultQName,
-		collectionFuncExec,
+		provideCollectionFuncExec(appDefBuilder),
 	))
 
 	provideQryCDoc(cfg, appDefBuilder)
@@ -4,4 +4,7 @@
 
 package describe
 
-const field_PackageName = "PackageName"
+const (
+	field_PackageName = "PackageName"
+	field_Tags        = "Tags"
+)
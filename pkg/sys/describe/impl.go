@@ -7,9 +7,12 @@ package describe
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"strings"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
 func provideQryDescribePackageNames(asp istructs.IAppStructsProvider, appQName istructs.AppQName) func(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
@@ -32,7 +35,13 @@ func provideQryDescribePackage(asp istructs.IAppStructsProvider, appQName istruc
 		}
 
 		packageName := args.ArgumentObject.AsString(field_PackageName)
-		packageDescription := as.DescribePackage(packageName)
+
+		tags, err := parseTags(args.ArgumentObject.AsString(field_Tags))
+		if err != nil {
+			return coreutils.NewHTTPError(http.StatusBadRequest, err)
+		}
+
+		packageDescription := as.DescribePackage(packageName, tags...)
 
 		b, err := json.Marshal(packageDescription)
 		if err != nil {
@@ -43,6 +52,21 @@ func provideQryDescribePackage(asp istructs.IAppStructsProvider, appQName istruc
 	}
 }
 
+// Parses comma separated tag names, e.g. "app1pkg.Backoffice,app1pkg.Reports"
+func parseTags(s string) (tags []appdef.QName, err error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	for _, str := range strings.Split(s, ",") {
+		tag, err := appdef.ParseQName(strings.TrimSpace(str))
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
 func (r *result) AsString(string) string {
 	return r.res
 }
@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package indexes
+
+import "github.com/voedger/voedger/pkg/appdef"
+
+// length of record ID, which is appended to index values
+const idLen = 8
+
+var (
+	qNameApplyIndexes = appdef.NewQName(appdef.SysPackage, "ApplyIndexes")
+)
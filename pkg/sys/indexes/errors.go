@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package indexes
+
+import "errors"
+
+var ErrIndexValuesTooLong = errors.New("index values are too long")
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package indexes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/untillpro/goutils/iterate"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/state"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+func provideApplyIndexes(appDef appdef.IAppDef) func(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) (err error) {
+	return func(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) (err error) {
+		return iterate.ForEachError(event.CUDs, func(rec istructs.ICUDRow) error {
+			iIndexes, ok := appDef.Type(rec.QName()).(appdef.IIndexes)
+			if !ok {
+				return nil
+			}
+			for _, index := range iIndexes.Indexes() {
+				if err := applyIndex(rec, st, intents, index); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// Updates index rows of the record.
+//
+// Records are already stored when sync projectors are applied, so last indexed values are taken
+// from the IndexedRecords view. Stale index row is deleted.
+func applyIndex(cud istructs.ICUDRow, st istructs.IState, intents istructs.IIntents, index appdef.IIndex) error {
+	var row istructs.IRowReader = cud
+	if !cud.IsNew() {
+		kb, err := st.KeyBuilder(state.Record, cud.QName())
+		if err != nil {
+			// notest
+			return err
+		}
+		kb.PutRecordID(state.Field_ID, cud.ID())
+		rec, err := st.MustExist(kb)
+		if err != nil {
+			return err
+		}
+		row = rec
+	}
+	values := coreutils.IndexValues(index, row)
+	if maxLen := int(appdef.MaxFieldLength) - idLen; len(values) > maxLen {
+		return fmt.Errorf("%v: length of index values of record %d is %d, max %d is allowed: %w",
+			index.Name(), cud.ID(), len(values), maxLen, ErrIndexValuesTooLong)
+	}
+
+	recKB, err := st.KeyBuilder(state.View, coreutils.QNameViewIndexedRecords)
+	if err != nil {
+		// notest
+		return err
+	}
+	recKB.PutRecordID(coreutils.Field_IndexID, cud.ID())
+	recKB.PutQName(coreutils.Field_IndexQName, index.Name())
+
+	if !cud.IsNew() {
+		indexed, ok, err := st.CanExist(recKB)
+		if err != nil {
+			return err
+		}
+		if ok {
+			oldValues := indexed.AsBytes(coreutils.Field_Values)
+			if bytes.Equal(oldValues, values) {
+				return nil
+			}
+			if err := deleteIndexRow(st, intents, index, oldValues, cud.ID()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := putIndexRow(st, intents, index, values, cud.ID()); err != nil {
+		return err
+	}
+	recVB, err := intents.NewValue(recKB)
+	if err != nil {
+		return err
+	}
+	recVB.PutBytes(coreutils.Field_Values, values)
+	return nil
+}
+
+func putIndexRow(st istructs.IState, intents istructs.IIntents, index appdef.IIndex, values []byte, id istructs.RecordID) error {
+	kb, err := indexRowKey(st, index, values, id)
+	if err != nil {
+		// notest
+		return err
+	}
+	vb, err := intents.NewValue(kb)
+	if err != nil {
+		return err
+	}
+	vb.PutRecordID(coreutils.Field_IndexID, id)
+	return nil
+}
+
+func deleteIndexRow(st istructs.IState, intents istructs.IIntents, index appdef.IIndex, values []byte, id istructs.RecordID) error {
+	kb, err := indexRowKey(st, index, values, id)
+	if err != nil {
+		// notest
+		return err
+	}
+	return state.DeleteViewRecord(intents, kb)
+}
+
+// Returns key of index row: record ID is appended to index values to make key unique
+func indexRowKey(st istructs.IState, index appdef.IIndex, values []byte, id istructs.RecordID) (istructs.IStateKeyBuilder, error) {
+	kb, err := st.KeyBuilder(state.View, coreutils.QNameViewIndexes)
+	if err != nil {
+		// notest
+		return nil, err
+	}
+	kb.PutQName(coreutils.Field_IndexQName, index.Name())
+	kb.PutBytes(coreutils.Field_Values, binary.BigEndian.AppendUint64(bytes.Clone(values), uint64(id)))
+	return kb, nil
+}
@@ -0,0 +1,23 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package indexes
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+// Provides sync projector which maintains rows of secondary indexes of records.
+//
+// Records are looked up by index with coreutils.ReadByIndex
+func Provide(cfg *istructsmem.AppConfigType, appDef appdef.IAppDef) {
+	cfg.AddSyncProjectors(func(partition istructs.PartitionID) istructs.Projector {
+		return istructs.Projector{
+			Name: qNameApplyIndexes,
+			Func: provideApplyIndexes(appDef),
+		}
+	})
+}
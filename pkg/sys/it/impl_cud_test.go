@@ -222,6 +222,81 @@ func TestBasicUsage_Singletons(t *testing.T) {
 	require.True(istructs.RecordID(singletonID) >= istructs.FirstSingletonID && istructs.RecordID(singletonID) <= istructs.MaxSingletonID)
 }
 
+func TestCollection_Cursor(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.CreateWorkspace(it.DummyWSParams("testws"+vit.NextName()), vit.WS(istructs.AppQName_test1_app1, "test_ws").Owner)
+
+	newArticle := func(name string, controlActive int) {
+		body := fmt.Sprintf(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.articles","name":"%s","article_manual":1,"article_hash":2,"hideonhold":3,"time_active":4,"control_active":%d}}]}`, name, controlActive)
+		vit.PostWS(ws, "c.sys.CUD", body)
+	}
+	newArticle("cola", 1)
+	newArticle("pepsi", 2)
+	newArticle("sprite", 2)
+	newArticle("fanta", 2)
+	newArticle("water", 3)
+
+	// readPage returns names of the page articles and the continuation token
+	readPage := func(orderBy string, after string) (names []string, cursor string) {
+		body := fmt.Sprintf(`{
+			"args":{"Schema":"app1pkg.articles"},
+			"elements":[{"fields":["name","sys.ID"]}],
+			"orderBy":[%s],
+			"count":2,
+			"after":%q
+		}`, orderBy, after)
+		resp := vit.PostWS(ws, "q.sys.Collection", body)
+		for _, row := range resp.Sections[0].Elements {
+			names = append(names, row[0][0][0].(string))
+		}
+		return names, resp.Cursor()
+	}
+
+	t.Run("natural order", func(t *testing.T) {
+		names, cursor := readPage("", "")
+		require.Equal([]string{"cola", "pepsi"}, names)
+
+		// new article is added to the end of the collection, previous pages are not affected
+		newArticle("juice", 1)
+		names, cursor = readPage("", cursor)
+		require.Equal([]string{"sprite", "fanta"}, names)
+		names, cursor = readPage("", cursor)
+		require.Equal([]string{"water", "juice"}, names)
+		require.Empty(cursor)
+	})
+
+	t.Run("order by field", func(t *testing.T) {
+		orderBy := `{"field":"control_active","desc":true}`
+		body := `{"args":{"Schema":"app1pkg.articles"},"elements":[{"fields":["name","control_active","sys.ID"]}],"orderBy":[%s],"count":2,"after":%q}`
+		names := []string{}
+		cursor := ""
+		for {
+			resp := vit.PostWS(ws, "q.sys.Collection", fmt.Sprintf(body, orderBy, cursor))
+			for _, row := range resp.Sections[0].Elements {
+				names = append(names, row[0][0][0].(string))
+			}
+			if cursor = resp.Cursor(); cursor == "" {
+				break
+			}
+		}
+		require.Equal([]string{"water", "pepsi", "sprite", "fanta", "cola", "juice"}, names)
+	})
+
+	t.Run("400 bad request on cursor of another order", func(t *testing.T) {
+		_, cursor := readPage("", "")
+		body := fmt.Sprintf(`{"args":{"Schema":"app1pkg.articles"},"elements":[{"fields":["name","sys.ID"]}],"orderBy":[{"field":"name"}],"count":2,"after":%q}`, cursor)
+		vit.PostWS(ws, "q.sys.Collection", body, coreutils.Expect400())
+	})
+
+	t.Run("400 bad request if sys.ID is not requested", func(t *testing.T) {
+		body := `{"args":{"Schema":"app1pkg.articles"},"elements":[{"fields":["name"]}],"count":2,"after":""}`
+		vit.PostWS(ws, "q.sys.Collection", body, coreutils.Expect400("sys.ID"))
+	})
+}
+
 func TestUnlinkReference(t *testing.T) {
 	require := require.New(t)
 	vit := it.NewVIT(t, &it.SharedConfig_App1)
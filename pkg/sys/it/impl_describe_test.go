@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
 	it "github.com/voedger/voedger/pkg/vit"
 )
 
@@ -39,6 +40,9 @@ func TestBasicUsage_DescribeSchema(t *testing.T) {
 		require.NoError(json.Unmarshal([]byte(desc), &actual))
 
 		expected := map[string]interface{}{
+			"Tags": map[string]interface{}{
+				"app2pkg.Backoffice": map[string]interface{}{},
+			},
 			"Structures": map[string]interface{}{
 				"app2pkg.test_ws": map[string]interface{}{
 					"Fields": []interface{}{
@@ -86,6 +90,31 @@ func TestBasicUsage_DescribeSchema(t *testing.T) {
 			"Extensions": map[string]interface{}{
 				"Commands": map[string]interface{}{
 					"app2pkg.testCmd": map[string]interface{}{
+						"Tags":   []interface{}{"app2pkg.Backoffice"},
+						"Engine": "BuiltIn",
+						"Name":   "testCmd",
+					},
+				},
+			},
+		}
+		require.EqualValues(expected, actual)
+	})
+
+	t.Run("describe package types with tag", func(t *testing.T) {
+		body := `{"args":{"PackageName":"app2pkg","Tags":"app2pkg.Backoffice"},"elements":[{"fields":["PackageDesc"]}]}`
+		desc := vit.PostProfile(prnApp2, "q.sys.DescribePackage", body).SectionRow()[0].(string)
+
+		actual := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(desc), &actual))
+
+		expected := map[string]interface{}{
+			"Tags": map[string]interface{}{
+				"app2pkg.Backoffice": map[string]interface{}{},
+			},
+			"Extensions": map[string]interface{}{
+				"Commands": map[string]interface{}{
+					"app2pkg.testCmd": map[string]interface{}{
+						"Tags":   []interface{}{"app2pkg.Backoffice"},
 						"Engine": "BuiltIn",
 						"Name":   "testCmd",
 					},
@@ -94,4 +123,9 @@ func TestBasicUsage_DescribeSchema(t *testing.T) {
 		}
 		require.EqualValues(expected, actual)
 	})
+
+	t.Run("400 bad request on invalid tag name", func(t *testing.T) {
+		body := `{"args":{"PackageName":"app2pkg","Tags":"wrong tag"},"elements":[{"fields":["PackageDesc"]}]}`
+		vit.PostProfile(prnApp2, "q.sys.DescribePackage", body, coreutils.Expect400())
+	})
 }
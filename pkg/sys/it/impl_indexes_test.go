@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestBasicUsage_Indexes(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	country := vit.NextNumber()
+
+	body := fmt.Sprintf(`{"cuds":[
+		{"fields":{"sys.ID":1,"sys.QName":"app1pkg.DocIndexed","Name":"Bob","Country":%[1]d}},
+		{"fields":{"sys.ID":2,"sys.QName":"app1pkg.DocIndexed","Name":"Alice","Country":%[1]d}},
+		{"fields":{"sys.ID":3,"sys.QName":"app1pkg.DocIndexed","Name":"Carol","Country":%[2]d}}]}`, country, country+1)
+	resp := vit.PostWS(ws, "c.sys.CUD", body)
+	bobID := resp.NewIDs["1"]
+	aliceID := resp.NewIDs["2"]
+	carolID := resp.NewIDs["3"]
+
+	collection := func(values string) []string {
+		body := fmt.Sprintf(`{"args":{"Schema":"app1pkg.DocIndexed","Index":"app1pkg.DocIndexedByCountry","IndexValues":%q},
+			"elements":[{"fields":["Name"]}]}`, values)
+		resp := vit.PostWS(ws, "q.sys.Collection", body)
+		names := []string{}
+		for i := 0; (len(resp.Sections) > 0) && (i < len(resp.Sections[0].Elements)); i++ {
+			names = append(names, resp.SectionRow(i)[0].(string))
+		}
+		return names
+	}
+
+	sqlQuery := func(where string) []int64 {
+		body := fmt.Sprintf(`{"args":{"Query":"select * from app1pkg.DocIndexed where %s"},"elements":[{"fields":["Result"]}]}`, where)
+		resp := vit.PostWS(ws, "q.sys.SqlQuery", body)
+		ids := []int64{}
+		for i := 0; (len(resp.Sections) > 0) && (i < len(resp.Sections[0].Elements)); i++ {
+			m := map[string]interface{}{}
+			require.NoError(json.Unmarshal([]byte(resp.SectionRow(i)[0].(string)), &m))
+			ids = append(ids, int64(m["sys.ID"].(float64)))
+		}
+		return ids
+	}
+
+	t.Run("should read by leading index fields in index order", func(t *testing.T) {
+		require.Equal([]string{"Alice", "Bob"}, collection(fmt.Sprintf(`[%d]`, country)))
+		require.Equal([]string{"Bob"}, collection(fmt.Sprintf(`[%d,"Bob"]`, country)))
+		require.Equal([]string{"Carol"}, collection(fmt.Sprintf(`[%d]`, country+1)))
+
+		require.Equal([]int64{aliceID, bobID}, sqlQuery(fmt.Sprintf("Country = %d", country)))
+		require.Equal([]int64{carolID}, sqlQuery(fmt.Sprintf("Name = 'Carol' and Country = %d", country+1)))
+		require.Empty(sqlQuery(fmt.Sprintf("Country = %d and Name = 'Dave'", country)))
+	})
+
+	t.Run("should maintain index on update", func(t *testing.T) {
+		body := fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"Country":%d}}]}`, bobID, country+1)
+		vit.PostWS(ws, "c.sys.CUD", body)
+
+		require.Equal([]string{"Alice"}, collection(fmt.Sprintf(`[%d]`, country)))
+		require.Equal([]string{"Bob", "Carol"}, collection(fmt.Sprintf(`[%d]`, country+1)))
+
+		body = fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"Rating":4.5}}]}`, bobID)
+		vit.PostWS(ws, "c.sys.CUD", body)
+		require.Equal([]int64{bobID}, sqlQuery(fmt.Sprintf("Country = %d and Name = 'Bob'", country+1)))
+	})
+
+	t.Run("should be error if no index by columns", func(t *testing.T) {
+		body := `{"args":{"Query":"select * from app1pkg.DocIndexed where Name = 'Bob'"},"elements":[{"fields":["Result"]}]}`
+		resp := vit.PostWS(ws, "q.sys.SqlQuery", body, coreutils.Expect500())
+		resp.RequireError(t, "'app1pkg.DocIndexed' has no index by columns: Name")
+	})
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/router"
+	it "github.com/voedger/voedger/pkg/vit"
+	"github.com/voedger/voedger/pkg/vvm"
+)
+
+func TestBasicUsage_JWKS(t *testing.T) {
+	require := require.New(t)
+
+	key, err := itokensjwt.GenerateSigningKey("key1", "ES256")
+	require.NoError(err)
+
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app2, it.ProvideApp2, it.WithUserLogin("login", "1")),
+		it.WithVVMConfig(func(cfg *vvm.VVMConfig) {
+			keyRing, err := itokensjwt.NewKeyRing(cfg.TimeFunc, key)
+			require.NoError(err)
+			cfg.JWTKeyRing = keyRing
+		}),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	prn := vit.GetPrincipal(istructs.AppQName_test1_app2, "login")
+
+	t.Run("principal token must be signed by the key ring key", func(t *testing.T) {
+		token, _, err := new(jwt.Parser).ParseUnverified(prn.Token, jwt.MapClaims{})
+		require.NoError(err)
+		require.Equal("ES256", token.Header["alg"])
+		require.Equal("key1", token.Header["kid"])
+
+		body := `{"args":{},"elements":[{"fields":["Names"]}]}`
+		vit.PostProfile(prn, "q.sys.DescribePackageNames", body)
+	})
+
+	t.Run("JWKS must be served by the router", func(t *testing.T) {
+		resp := vit.Get(strings.TrimPrefix(router.JWKSPath, "/"))
+
+		jwks := itokensjwt.JWKS{}
+		require.NoError(json.Unmarshal([]byte(resp.Body), &jwks))
+		require.Len(jwks.Keys, 1)
+		require.Equal("key1", jwks.Keys[0].Kid)
+		require.Equal("EC", jwks.Keys[0].Kty)
+		require.Equal("P-256", jwks.Keys[0].Crv)
+	})
+}
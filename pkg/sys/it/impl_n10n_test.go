@@ -6,6 +6,7 @@ package sys_it
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
@@ -13,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/istructs"
 	coreutils "github.com/voedger/voedger/pkg/utils"
 	it "github.com/voedger/voedger/pkg/vit"
 )
@@ -115,3 +117,120 @@ func TestBasicUsage_n10n(t *testing.T) {
 	resp.HTTPResp.Body.Close()
 	<-done // подождем завершения
 }
+
+func TestN10n_Resume(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	projection := fmt.Sprintf(`{"App":"untill/Application","Projection":"paa.%s","WS":1}`, vit.NextName())
+	vit.Post("n10n/update/13", projection)
+
+	// watch opens the resumable channel, calls onChannel when it is created and returns data of the first projection event
+	watch := func(projectionKey string, onChannel func()) (data string) {
+		query := fmt.Sprintf(`{"SubjectLogin":"paa_resume","Resume":true,"ProjectionKey":[%s]}`, projectionKey)
+		params := url.Values{}
+		params.Add("payload", query)
+		resp := vit.Get(fmt.Sprintf("n10n/channel?%s", params.Encode()), coreutils.WithLongPolling())
+		defer resp.HTTPResp.Body.Close()
+		scanner := bufio.NewScanner(resp.HTTPResp.Body)
+		scanner.Split(it.ScanSSE)
+		for scanner.Scan() {
+			var event string
+			for _, str := range strings.Split(scanner.Text(), "\n") {
+				if strings.HasPrefix(str, "event: ") {
+					event = strings.TrimPrefix(str, "event: ")
+				}
+				if !strings.HasPrefix(str, "data: ") {
+					continue
+				}
+				if event != "channelId" {
+					return strings.TrimPrefix(str, "data: ")
+				}
+				if onChannel != nil {
+					onChannel()
+				}
+			}
+		}
+		return ""
+	}
+
+	t.Run("replay the latest offset after the last seen one", func(t *testing.T) {
+		require.Equal("13", watch(strings.TrimSuffix(projection, "}")+`,"Offset":10}`, nil))
+	})
+
+	t.Run("resume from the subject cursor", func(t *testing.T) {
+		// offset 13 is already delivered to the subject, so the next update is expected
+		require.Equal("14", watch(projection, func() {
+			vit.Post("n10n/update/14", projection)
+		}))
+	})
+
+	t.Run("gap if updates could be missed", func(t *testing.T) {
+		unknownProjection := fmt.Sprintf(`{"App":"untill/Application","Projection":"paa.%s","WS":1,"Offset":5}`, vit.NextName())
+		require.Equal("0", watch(unknownProjection, nil))
+	})
+}
+
+func TestN10n_Rows(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.CreateWorkspace(it.DummyWSParams("testws"+vit.NextName()), vit.WS(istructs.AppQName_test1_app1, "test_ws").Owner)
+
+	query := fmt.Sprintf(`{"SubjectLogin":"paa_rows","Rows":true,"ProjectionKey":[{"App":"test1/app1","Projection":"sys.CollectionView","WS":%d}]}`, ws.WSID)
+	params := url.Values{}
+	params.Add("payload", query)
+	resp := vit.Get(fmt.Sprintf("n10n/channel?%s", params.Encode()), coreutils.WithLongPolling())
+	defer resp.HTTPResp.Body.Close()
+
+	// data of the projection events
+	events := make(chan string)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.HTTPResp.Body)
+		scanner.Split(it.ScanSSE)
+		for scanner.Scan() {
+			var event string
+			for _, str := range strings.Split(scanner.Text(), "\n") {
+				if strings.HasPrefix(str, "event: ") {
+					event = strings.TrimPrefix(str, "event: ")
+				}
+				if strings.HasPrefix(str, "data: ") && event != "channelId" {
+					events <- strings.TrimPrefix(str, "data: ")
+				}
+			}
+		}
+	}()
+
+	type rowsData struct {
+		Offset int64
+		Rows   []struct {
+			Key   map[string]interface{}
+			Value map[string]interface{}
+		}
+	}
+	newArticle := func(name string) (data rowsData) {
+		body := fmt.Sprintf(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.articles","name":"%s","article_manual":1,"article_hash":2,"hideonhold":3,"time_active":4,"control_active":1}}]}`, name)
+		offset := vit.PostWS(ws, "c.sys.CUD", body).CurrentWLogOffset
+		for data.Offset < offset {
+			require.NoError(json.Unmarshal([]byte(<-events), &data))
+		}
+		return data
+	}
+
+	// the first event could be delivered without rows since the subscriber has not received the previous offset
+	newArticle("cola")
+
+	data := newArticle("pepsi")
+	require.Len(data.Rows, 1)
+	require.Equal("app1pkg.articles", data.Rows[0].Key["DocQName"])
+	require.Equal("pepsi", data.Rows[0].Value["Record"].(map[string]interface{})["name"])
+}
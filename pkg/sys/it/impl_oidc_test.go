@@ -0,0 +1,334 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sys_it
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/registry"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+const (
+	testOIDCProvider        = "mock"
+	testOIDCTrustedProvider = "trusted"
+	testOIDCRedirectURI     = "https://example.com/callback"
+)
+
+func TestBasicUsage_SignInByExternalIdentity(t *testing.T) {
+	require := require.New(t)
+
+	idp := it.NewMockIdP("client1", "secret1")
+	defer idp.Close()
+
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app2, it.ProvideApp2),
+		it.WithOIDCProvider(testOIDCProvider, idp.Provider()),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	email := vit.NextName() + "@example.com"
+	auth := it.MockIdPAuth{
+		Subject:       "subject1",
+		Email:         email,
+		EmailVerified: true,
+		Nonce:         "nonce1",
+		RedirectURI:   testOIDCRedirectURI,
+		CodeChallenge: it.CodeChallengeS256("verifier1"),
+	}
+
+	// exchange the authorization code for the external identity token
+	// login is built from the provider and subject since the provider is not trusted to verify emails
+	code := idp.IssueCode(auth)
+	externalIdentityToken, login := issueExternalIdentityToken(vit, testOIDCProvider, code, "verifier1", auth.Nonce)
+	require.NotEqual(email, login)
+	require.True(strings.HasPrefix(login, testOIDCProvider+"-"))
+
+	// link the external identity to the new login
+	loginPseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, login, istructs.MainClusterID)
+	createLoginByExternalIdentity(vit, loginPseudoWSID, externalIdentityToken)
+
+	// sign in by the external identity
+	token, profileWSID := signInByExternalIdentity(vit, loginPseudoWSID, externalIdentityToken)
+	require.NotEmpty(token)
+	require.True(profileWSID.BaseWSID() >= istructs.FirstBaseUserWSID)
+
+	t.Run("principal token must work", func(t *testing.T) {
+		body := `{"args":{"Schema":"sys.UserProfile"},"elements":[{"fields":["DisplayName"]}]}`
+		resp := vit.PostApp(istructs.AppQName_test1_app2, profileWSID, "q.sys.Collection", body, coreutils.WithAuthorizeBy(token))
+		require.Equal("User Name", resp.SectionRow()[0])
+	})
+
+	t.Run("sign in again by the new authorization code", func(t *testing.T) {
+		auth.Nonce = "nonce2"
+		auth.CodeChallenge = ""
+		code := idp.IssueCode(auth)
+		externalIdentityToken, newLogin := issueExternalIdentityToken(vit, testOIDCProvider, code, "", auth.Nonce)
+		require.Equal(login, newLogin)
+		_, wsid := signInByExternalIdentity(vit, loginPseudoWSID, externalIdentityToken)
+		require.Equal(profileWSID, wsid)
+
+		t.Run("409 on link again", func(t *testing.T) {
+			createLoginByExternalIdentity(vit, loginPseudoWSID, externalIdentityToken, coreutils.Expect409())
+		})
+	})
+}
+
+func TestSignInByExternalIdentity_TrustEmail(t *testing.T) {
+	require := require.New(t)
+
+	idp := it.NewMockIdP("client1", "secret1")
+	defer idp.Close()
+
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app2, it.ProvideApp2),
+		it.WithOIDCProvider(testOIDCTrustedProvider, func() registry.OIDCProvider {
+			p := idp.Provider()
+			p.TrustEmail = true
+			return p
+		}()),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	email := vit.NextName() + "@example.com"
+	auth := it.MockIdPAuth{
+		Subject:       "subject1",
+		Email:         strings.ToUpper(email),
+		EmailVerified: true,
+		Nonce:         "nonce1",
+		RedirectURI:   testOIDCRedirectURI,
+	}
+
+	externalIdentityToken, login := issueExternalIdentityToken(vit, testOIDCTrustedProvider, idp.IssueCode(auth), "", auth.Nonce)
+	require.Equal(email, login)
+
+	loginPseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, login, istructs.MainClusterID)
+	createLoginByExternalIdentity(vit, loginPseudoWSID, externalIdentityToken)
+	_, profileWSID := signInByExternalIdentity(vit, loginPseudoWSID, externalIdentityToken)
+	require.True(profileWSID.BaseWSID() >= istructs.FirstBaseUserWSID)
+
+	t.Run("unverified email must not be used as login", func(t *testing.T) {
+		auth := auth
+		auth.Subject = "subject2"
+		auth.EmailVerified = false
+		_, login := issueExternalIdentityToken(vit, testOIDCTrustedProvider, idp.IssueCode(auth), "", auth.Nonce)
+		require.NotEqual(email, login)
+		require.True(strings.HasPrefix(login, testOIDCTrustedProvider+"-"))
+	})
+}
+
+func TestSignInByExternalIdentity_LinkToExistingLogin(t *testing.T) {
+	require := require.New(t)
+
+	idp := it.NewMockIdP("client1", "secret1")
+	defer idp.Close()
+
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1),
+		it.WithApp(istructs.AppQName_test1_app2, it.ProvideApp2),
+		it.WithOIDCProvider(testOIDCProvider, idp.Provider()),
+		it.WithOIDCProvider(testOIDCTrustedProvider, func() registry.OIDCProvider {
+			p := idp.Provider()
+			p.TrustEmail = true
+			return p
+		}()),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	email := vit.NextName() + "@example.com"
+	prn := vit.SignIn(vit.SignUp(email, "1", istructs.AppQName_test1_app2))
+
+	auth := it.MockIdPAuth{
+		Subject:       "subject1",
+		Email:         email,
+		EmailVerified: true,
+		Nonce:         "nonce1",
+		RedirectURI:   testOIDCRedirectURI,
+	}
+
+	t.Run("existing login must not be linked on login creation", func(t *testing.T) {
+		externalIdentityToken, login := issueExternalIdentityToken(vit, testOIDCTrustedProvider, idp.IssueCode(auth), "", auth.Nonce)
+		require.Equal(email, login)
+		createLoginByExternalIdentity(vit, prn.PseudoProfileWSID, externalIdentityToken, coreutils.Expect409("login already exists"))
+		body := fmt.Sprintf(`{"args":{"AppName":"%s","ExternalIdentityToken":"%s"},"elements":[{"fields":["PrincipalToken"]}]}`,
+			istructs.AppQName_test1_app2, externalIdentityToken)
+		vit.PostApp(istructs.AppQName_sys_registry, prn.PseudoProfileWSID, "q.registry.IssuePrincipalTokenByExternalIdentity", body, coreutils.Expect401())
+	})
+
+	externalIdentityToken, login := issueExternalIdentityToken(vit, testOIDCProvider, idp.IssueCode(auth), "", auth.Nonce)
+	loginPseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, login, istructs.MainClusterID)
+
+	t.Run("401 on link without the principal token of the login", func(t *testing.T) {
+		linkExternalIdentity(vit, loginPseudoWSID, externalIdentityToken, "wrong", coreutils.Expect401())
+	})
+
+	t.Run("401 on link by the principal token of another app", func(t *testing.T) {
+		app1Prn := vit.SignIn(vit.SignUp(vit.NextName(), "1", istructs.AppQName_test1_app1))
+		linkExternalIdentity(vit, loginPseudoWSID, externalIdentityToken, app1Prn.Token, coreutils.Expect401())
+	})
+
+	t.Run("403 on link by the system principal token", func(t *testing.T) {
+		sysPrn := vit.GetSystemPrincipal(istructs.AppQName_test1_app2)
+		linkExternalIdentity(vit, loginPseudoWSID, externalIdentityToken, sysPrn.Token, coreutils.Expect403())
+	})
+
+	// the login owner links the external identity explicitly
+	linkExternalIdentity(vit, loginPseudoWSID, externalIdentityToken, prn.Token)
+	_, profileWSID := signInByExternalIdentity(vit, loginPseudoWSID, externalIdentityToken)
+	require.Equal(prn.ProfileWSID, profileWSID)
+
+	t.Run("409 on link again", func(t *testing.T) {
+		linkExternalIdentity(vit, loginPseudoWSID, externalIdentityToken, prn.Token, coreutils.Expect409())
+		createLoginByExternalIdentity(vit, loginPseudoWSID, externalIdentityToken, coreutils.Expect409())
+	})
+}
+
+func TestSignInByExternalIdentityErrors(t *testing.T) {
+	idp := it.NewMockIdP("client1", "secret1")
+	defer idp.Close()
+
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app2, it.ProvideApp2),
+		it.WithOIDCProvider(testOIDCProvider, idp.Provider()),
+		it.WithOIDCProvider("wrongSecret", func() registry.OIDCProvider {
+			p := idp.Provider()
+			p.ClientSecret = "wrong"
+			return p
+		}()),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	auth := it.MockIdPAuth{
+		Subject:       "subject1",
+		Email:         vit.NextName() + "@example.com",
+		EmailVerified: true,
+		Nonce:         "nonce1",
+		RedirectURI:   testOIDCRedirectURI,
+		CodeChallenge: it.CodeChallengeS256("verifier1"),
+	}
+
+	t.Run("400 on unknown provider", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"AppName":"%s","Provider":"unknown","AuthorizationCode":"%s","RedirectURI":"%s","Nonce":"nonce1"},"elements":[{"fields":["ExternalIdentityToken"]}]}`,
+			istructs.AppQName_test1_app2, idp.IssueCode(auth), testOIDCRedirectURI)
+		resp := vit.PostApp(istructs.AppQName_sys_registry, oidcPseudoWSID(), "q.registry.IssueExternalIdentityToken", body, coreutils.Expect400())
+		resp.RequireError(t, "unknown identity provider unknown")
+	})
+
+	t.Run("401 on wrong client secret", func(t *testing.T) {
+		issueExternalIdentityTokenErr(t, vit, "wrongSecret", idp.IssueCode(auth), "verifier1", auth.Nonce, "failed to exchange authorization code")
+	})
+
+	t.Run("401 on wrong code verifier", func(t *testing.T) {
+		issueExternalIdentityTokenErr(t, vit, testOIDCProvider, idp.IssueCode(auth), "wrong", auth.Nonce, "failed to exchange authorization code")
+	})
+
+	t.Run("401 on nonce mismatch", func(t *testing.T) {
+		issueExternalIdentityTokenErr(t, vit, testOIDCProvider, idp.IssueCode(auth), "verifier1", "wrong", "nonce mismatch")
+	})
+
+	t.Run("400 on no nonce", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"AppName":"%s","Provider":"%s","AuthorizationCode":"%s","RedirectURI":"%s","CodeVerifier":"verifier1"},"elements":[{"fields":["ExternalIdentityToken"]}]}`,
+			istructs.AppQName_test1_app2, testOIDCProvider, idp.IssueCode(auth), testOIDCRedirectURI)
+		vit.PostApp(istructs.AppQName_sys_registry, oidcPseudoWSID(), "q.registry.IssueExternalIdentityToken", body, coreutils.Expect400())
+	})
+
+	t.Run("401 on authorization code reuse", func(t *testing.T) {
+		code := idp.IssueCode(auth)
+		issueExternalIdentityToken(vit, testOIDCProvider, code, "verifier1", auth.Nonce)
+		issueExternalIdentityTokenErr(t, vit, testOIDCProvider, code, "verifier1", auth.Nonce, "failed to exchange authorization code")
+	})
+
+	externalIdentityToken, login := issueExternalIdentityToken(vit, testOIDCProvider, idp.IssueCode(auth), "verifier1", auth.Nonce)
+	loginPseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, login, istructs.MainClusterID)
+
+	t.Run("401 on sign in by not linked external identity", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"AppName":"%s","ExternalIdentityToken":"%s"},"elements":[{"fields":["PrincipalToken"]}]}`,
+			istructs.AppQName_test1_app2, externalIdentityToken)
+		vit.PostApp(istructs.AppQName_sys_registry, loginPseudoWSID, "q.registry.IssuePrincipalTokenByExternalIdentity", body, coreutils.Expect401())
+	})
+
+	t.Run("401 on wrong external identity token", func(t *testing.T) {
+		createLoginByExternalIdentity(vit, loginPseudoWSID, "wrong", coreutils.Expect401())
+	})
+
+	t.Run("403 on wrong url WSID", func(t *testing.T) {
+		// pseudo WSIDs are mapped to app workspaces, so the wrong one must be mapped to another app workspace than the login one
+		as, err := vit.AppStructs(istructs.AppQName_sys_registry)
+		require.NoError(t, err)
+		loginAppWSID := coreutils.GetAppWSID(loginPseudoWSID, as.WSAmount())
+		wrongPseudoWSID := oidcPseudoWSID()
+		for i := 0; coreutils.GetAppWSID(wrongPseudoWSID, as.WSAmount()) == loginAppWSID; i++ {
+			wrongPseudoWSID = coreutils.GetPseudoWSID(istructs.NullWSID, fmt.Sprint("wrong", i), istructs.MainClusterID)
+		}
+		createLoginByExternalIdentity(vit, wrongPseudoWSID, externalIdentityToken, coreutils.Expect403())
+	})
+
+	t.Run("401 on expired external identity token", func(t *testing.T) {
+		vit.TimeAdd(time.Hour)
+		createLoginByExternalIdentity(vit, loginPseudoWSID, externalIdentityToken, coreutils.Expect401())
+	})
+}
+
+// login is not known before the external identity token is issued, so any pseudo WSID could be used
+func oidcPseudoWSID() istructs.WSID {
+	return coreutils.GetPseudoWSID(istructs.NullWSID, testOIDCProvider, istructs.MainClusterID)
+}
+
+func issueExternalIdentityToken(vit *it.VIT, provider, code, codeVerifier, nonce string) (externalIdentityToken string, login string) {
+	body := fmt.Sprintf(`{"args":{"AppName":"%s","Provider":"%s","AuthorizationCode":"%s","RedirectURI":"%s","CodeVerifier":"%s","Nonce":"%s"},
+		"elements":[{"fields":["ExternalIdentityToken","Login"]}]}`,
+		istructs.AppQName_test1_app2, provider, code, testOIDCRedirectURI, codeVerifier, nonce)
+	resp := vit.PostApp(istructs.AppQName_sys_registry, oidcPseudoWSID(), "q.registry.IssueExternalIdentityToken", body)
+	return resp.SectionRow()[0].(string), resp.SectionRow()[1].(string)
+}
+
+func issueExternalIdentityTokenErr(t *testing.T, vit *it.VIT, provider, code, codeVerifier, nonce string, expectedErr string) {
+	body := fmt.Sprintf(`{"args":{"AppName":"%s","Provider":"%s","AuthorizationCode":"%s","RedirectURI":"%s","CodeVerifier":"%s","Nonce":"%s"},
+		"elements":[{"fields":["ExternalIdentityToken"]}]}`,
+		istructs.AppQName_test1_app2, provider, code, testOIDCRedirectURI, codeVerifier, nonce)
+	resp := vit.PostApp(istructs.AppQName_sys_registry, oidcPseudoWSID(), "q.registry.IssueExternalIdentityToken", body, coreutils.Expect401())
+	resp.RequireContainsError(t, expectedErr)
+}
+
+func createLoginByExternalIdentity(vit *it.VIT, loginPseudoWSID istructs.WSID, externalIdentityToken string, opts ...coreutils.ReqOptFunc) {
+	body := fmt.Sprintf(`{"args":{"AppName":"%s","WSKindInitializationData":"{\"DisplayName\":\"User Name\"}","ProfileCluster":%d},"unloggedArgs":{"ExternalIdentityToken":"%s"}}`,
+		istructs.AppQName_test1_app2, istructs.MainClusterID, externalIdentityToken)
+	vit.PostApp(istructs.AppQName_sys_registry, loginPseudoWSID, "c.registry.CreateLoginByExternalIdentity", body, opts...)
+}
+
+func linkExternalIdentity(vit *it.VIT, loginPseudoWSID istructs.WSID, externalIdentityToken, principalToken string, opts ...coreutils.ReqOptFunc) {
+	body := fmt.Sprintf(`{"args":{"AppName":"%s"},"unloggedArgs":{"ExternalIdentityToken":"%s","PrincipalToken":"%s"}}`,
+		istructs.AppQName_test1_app2, externalIdentityToken, principalToken)
+	vit.PostApp(istructs.AppQName_sys_registry, loginPseudoWSID, "c.registry.LinkExternalIdentity", body, opts...)
+}
+
+// waits for the profile initialization like vit.SignIn does
+func signInByExternalIdentity(vit *it.VIT, loginPseudoWSID istructs.WSID, externalIdentityToken string) (token string, profileWSID istructs.WSID) {
+	body := fmt.Sprintf(`{"args":{"AppName":"%s","ExternalIdentityToken":"%s"},"elements":[{"fields":["PrincipalToken","WSID","WSError"]}]}`,
+		istructs.AppQName_test1_app2, externalIdentityToken)
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		resp := vit.PostApp(istructs.AppQName_sys_registry, loginPseudoWSID, "q.registry.IssuePrincipalTokenByExternalIdentity", body)
+		profileWSID = istructs.WSID(resp.SectionRow()[1].(float64))
+		require.Empty(vit.T, resp.SectionRow()[2].(string))
+		if profileWSID != istructs.NullWSID {
+			return resp.SectionRow()[0].(string), profileWSID
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	vit.T.Fatal("user profile is not initialized in an acceptable time")
+	return "", istructs.NullWSID
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/projectors"
+	"github.com/voedger/voedger/pkg/sys/journal"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+	it "github.com/voedger/voedger/pkg/vit"
+	"github.com/voedger/voedger/pkg/vvm"
+)
+
+func TestBasicUsage_RebuildProjector(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	body := fmt.Sprintf(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.bill","tableno":%d,"id_untill_users":%d,"table_part":"a","proforma":3,"working_day":"20230228"}}]}`,
+		vit.NextNumber(), vit.GetAny("app1pkg.untill_users", ws))
+	offset := vit.PostWS(ws, "c.sys.CUD", body).CurrentWLogOffset
+	WaitForIndexOffset(vit, ws, journal.QNameViewWLogDates, offset)
+
+	as, err := vit.AppStructs(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	wLogDatesKey := func(year, dayOfYear int32) istructs.IKeyBuilder {
+		kb := as.ViewRecords().KeyBuilder(journal.QNameViewWLogDates)
+		kb.PutInt32("Year", year)
+		kb.PutInt32("DayOfYear", dayOfYear)
+		return kb
+	}
+
+	// the record is not produced by the projector, so it must not survive the rebuild
+	staleKey := wLogDatesKey(1999, 1)
+	vb := as.ViewRecords().NewValueBuilder(journal.QNameViewWLogDates)
+	vb.PutInt64("FirstOffset", 1)
+	vb.PutInt64("LastOffset", 1)
+	require.NoError(as.ViewRecords().Put(ws.WSID, staleKey, vb))
+	_, err = as.ViewRecords().Get(ws.WSID, staleKey)
+	require.NoError(err)
+
+	rebuildURL := fmt.Sprintf("%s?app=%s&projector=%s", vvm.RebuildProjectorPath[1:], istructs.AppQName_test1_app1, journal.QNameProjectorWLogDates)
+	sysPrn := vit.GetSystemPrincipal(istructs.AppQName_test1_app1)
+
+	t.Run("401 on no system token", func(t *testing.T) {
+		vit.Post(rebuildURL, "", coreutils.Expect401())
+	})
+
+	t.Run("403 on user token", func(t *testing.T) {
+		vit.Post(rebuildURL, "", coreutils.WithAuthorizeBy(ws.Owner.Token), coreutils.Expect403())
+	})
+
+	vit.Post(rebuildURL, "", coreutils.WithAuthorizeBy(sysPrn.Token), coreutils.WithExpectedCode(http.StatusAccepted))
+
+	deadline := time.Now().Add(time.Minute)
+	for {
+		resp := vit.Get(rebuildURL, coreutils.WithAuthorizeBy(sysPrn.Token))
+		progress := struct {
+			Partitions []struct{ Status projectors.RebuildStatus }
+		}{}
+		require.NoError(json.Unmarshal([]byte(resp.Body), &progress))
+		done := len(progress.Partitions) > 0
+		for _, p := range progress.Partitions {
+			done = done && p.Status == projectors.RebuildStatus_Done
+		}
+		if done {
+			break
+		}
+		require.True(time.Now().Before(deadline), "projector is not rebuilt in an acceptable time")
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Run("view must be truncated", func(t *testing.T) {
+		_, err := as.ViewRecords().Get(ws.WSID, staleKey)
+		require.ErrorIs(err, istructsmem.ErrRecordNotFound)
+	})
+
+	t.Run("view must be rebuilt", func(t *testing.T) {
+		value, err := as.ViewRecords().Get(ws.WSID, wLogDatesKey(int32(vit.Now().Year()), int32(vit.Now().YearDay())))
+		require.NoError(err)
+		require.GreaterOrEqual(value.AsInt64("LastOffset"), int64(offset))
+	})
+}
@@ -474,3 +474,92 @@ func TestSqlQuery(t *testing.T) {
 		require.NotEqual(t, len(wsOne.Sections[0].Elements), len(wsTwo.Sections[0].Elements))
 	})
 }
+
+func TestSqlQuery_select(t *testing.T) {
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.CreateWorkspace(it.DummyWSParams("testws"+vit.NextName()), vit.WS(istructs.AppQName_test1_app1, "test_ws").Owner)
+	body := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.untill_users","name":"Alice","user_void":0}},
+					   {"fields":{"sys.ID":2,"sys.QName":"app1pkg.untill_users","name":"Bob","user_void":1}}]}`
+	resp := vit.PostWS(ws, "c.sys.CUD", body)
+	aliceID := resp.NewIDs["1"]
+	bobID := resp.NewIDs["2"]
+
+	for i, userID := range []int64{aliceID, bobID, bobID} {
+		body = fmt.Sprintf(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.bill","tableno":%d,"id_untill_users":%d,"table_part":"a","proforma":%d,"working_day":"20230227"}}]}`,
+			i+1, userID, (i+1)*10)
+		vit.PostWS(ws, "c.sys.CUD", body)
+	}
+
+	query := func(sql string) (rows []map[string]interface{}) {
+		body := fmt.Sprintf(`{"args":{"Query":%q},"elements":[{"fields":["Result"]}]}`, sql)
+		resp := vit.PostWS(ws, "q.sys.SqlQuery", body)
+		for _, element := range resp.Sections[0].Elements {
+			m := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal([]byte(element[0][0][0].(string)), &m))
+			rows = append(rows, m)
+		}
+		return rows
+	}
+
+	t.Run("Should count CDocs per type", func(t *testing.T) {
+		require := require.New(t)
+		rows := query("select DocQName, count(*) as cnt from sys.CollectionView where PartKey = 1 and DocQName in ('app1pkg.bill', 'app1pkg.untill_users') group by DocQName order by DocQName")
+
+		require.Equal([]map[string]interface{}{
+			{"DocQName": "app1pkg.bill", "cnt": float64(3)},
+			{"DocQName": "app1pkg.untill_users", "cnt": float64(2)},
+		}, rows)
+	})
+	t.Run("Should aggregate records without GROUP BY", func(t *testing.T) {
+		require := require.New(t)
+		rows := query("select count(*), sum(proforma), min(tableno), max(tableno), avg(proforma) from app1pkg.bill")
+
+		require.Equal([]map[string]interface{}{
+			{"count(*)": float64(3), "sum(proforma)": float64(60), "min(tableno)": float64(1), "max(tableno)": float64(3), "avg(proforma)": float64(20)},
+		}, rows)
+	})
+	t.Run("Should join records by ref field", func(t *testing.T) {
+		require := require.New(t)
+		rows := query("select u.name, count(*) as bills, sum(b.proforma) as total from app1pkg.bill b join app1pkg.untill_users u on b.id_untill_users = u.id group by u.name order by total desc")
+
+		require.Equal([]map[string]interface{}{
+			{"u.name": "Bob", "bills": float64(2), "total": float64(50)},
+			{"u.name": "Alice", "bills": float64(1), "total": float64(10)},
+		}, rows)
+	})
+	t.Run("Should filter, order and limit records", func(t *testing.T) {
+		require := require.New(t)
+		rows := query("select tableno, proforma from app1pkg.bill where proforma >= 20 order by tableno desc limit 1")
+
+		require.Equal([]map[string]interface{}{{"tableno": float64(3), "proforma": float64(30)}}, rows)
+
+		rows = query("select name from app1pkg.untill_users where name like 'b%' or user_void = 0 order by name")
+		require.Equal([]map[string]interface{}{{"name": "Alice"}, {"name": "Bob"}}, rows)
+	})
+	t.Run("Should return error when column is not grouped", func(t *testing.T) {
+		body := `{"args":{"Query":"select name, count(*) from app1pkg.untill_users"}}`
+		resp := vit.PostWS(ws, "q.sys.SqlQuery", body, coreutils.Expect500())
+
+		resp.RequireError(t, "column 'name' must appear in the GROUP BY clause or be used in an aggregate function")
+	})
+	t.Run("Should return error when join condition not supported", func(t *testing.T) {
+		body := `{"args":{"Query":"select * from app1pkg.bill b join app1pkg.untill_users u on b.tableno = u.user_void"}}`
+		resp := vit.PostWS(ws, "q.sys.SqlQuery", body, coreutils.Expect500())
+
+		resp.RequireError(t, "unsupported join condition: b.tableno = u.user_void")
+	})
+	t.Run("Should return error when left join used", func(t *testing.T) {
+		body := `{"args":{"Query":"select * from app1pkg.bill b left join app1pkg.untill_users u on b.id_untill_users = u.id"}}`
+		resp := vit.PostWS(ws, "q.sys.SqlQuery", body, coreutils.Expect500())
+
+		resp.RequireError(t, "unsupported join: left join")
+	})
+	t.Run("Should return error when offset is not a literal", func(t *testing.T) {
+		body := `{"args":{"Query":"select tableno from app1pkg.bill order by tableno limit 1 offset tableno"}}`
+		resp := vit.PostWS(ws, "q.sys.SqlQuery", body, coreutils.Expect500())
+
+		resp.RequireError(t, "integer literal expected: tableno")
+	})
+}
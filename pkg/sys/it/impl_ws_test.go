@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/voedger/voedger/pkg/istructs"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestWebSocket(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.CreateWorkspace(it.DummyWSParams("testws"+vit.NextName()), vit.WS(istructs.AppQName_test1_app1, "test_ws").Owner)
+
+	wsURL := *vit.IFederation.URL()
+	wsURL.Scheme = "ws"
+	wsURL.Path = "/ws"
+	config, err := websocket.NewConfig(wsURL.String(), vit.IFederation.URL().String())
+	require.NoError(err)
+	config.Header.Set(coreutils.Authorization, coreutils.BearerPrefix+ws.Owner.Token)
+	conn, err := websocket.DialConfig(config)
+	require.NoError(err)
+	defer conn.Close()
+
+	type message struct {
+		ID            string
+		Type          string
+		Status        int
+		Body          json.RawMessage
+		ProjectionKey map[string]interface{}
+		Offset        int64
+	}
+	receive := func() (msg message) {
+		require.NoError(websocket.JSON.Receive(conn, &msg))
+		return msg
+	}
+
+	// subscribe and call the command over the same connection
+	require.NoError(websocket.Message.Send(conn, fmt.Sprintf(`{"ID":"1","Type":"subscribe","SubjectLogin":"paa_ws","ProjectionKey":[{"App":"test1/app1","Projection":"sys.CollectionView","WS":%d}]}`, ws.WSID)))
+	msg := receive()
+	require.Equal("1", msg.ID)
+	require.Equal(http.StatusOK, msg.Status)
+
+	body := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.articles","name":"cola","article_manual":1,"article_hash":2,"hideonhold":3,"time_active":4,"control_active":1}}]}`
+	require.NoError(websocket.Message.Send(conn, fmt.Sprintf(`{"ID":"2","Type":"call","App":"test1/app1","WSID":%d,"Resource":"c.sys.CUD","Body":%s}`, ws.WSID, body)))
+
+	// response and the notification could come in any order
+	var cmdResp coreutils.FuncResponse
+	var n10nOffset int64
+	for cmdResp.CurrentWLogOffset == 0 || n10nOffset < cmdResp.CurrentWLogOffset {
+		msg = receive()
+		switch msg.Type {
+		case "response":
+			require.Equal("2", msg.ID)
+			require.Equal(http.StatusOK, msg.Status)
+			require.NoError(json.Unmarshal(msg.Body, &cmdResp))
+		case "n10n":
+			require.Equal("sys.CollectionView", msg.ProjectionKey["Projection"])
+			n10nOffset = msg.Offset
+		}
+	}
+
+	t.Run("query", func(t *testing.T) {
+		require.NoError(websocket.Message.Send(conn, fmt.Sprintf(`{"ID":"3","Type":"call","App":"test1/app1","WSID":%d,"Resource":"q.sys.Collection","Body":{"args":{"Schema":"app1pkg.articles"},"elements":[{"fields":["name"]}]}}`, ws.WSID)))
+		msg := receive()
+		require.Equal("3", msg.ID)
+		require.Equal(http.StatusOK, msg.Status)
+		require.JSONEq(`{"sections":[{"type":"","elements":[[[["cola"]]]]}]}`, string(msg.Body))
+	})
+
+	t.Run("subscribe by another subject", func(t *testing.T) {
+		require.NoError(websocket.Message.Send(conn, fmt.Sprintf(`{"ID":"5","Type":"subscribe","SubjectLogin":"another","ProjectionKey":[{"App":"test1/app1","Projection":"sys.CollectionView","WS":%d}]}`, ws.WSID)))
+		msg := receive()
+		require.Equal("5", msg.ID)
+		require.Equal(http.StatusBadRequest, msg.Status)
+	})
+
+	t.Run("wrong token of the call", func(t *testing.T) {
+		require.NoError(websocket.Message.Send(conn, fmt.Sprintf(`{"ID":"4","Type":"call","App":"test1/app1","WSID":%d,"Resource":"c.sys.CUD","Body":%s,"Token":"wrong"}`, ws.WSID, body)))
+		msg := receive()
+		require.Equal("4", msg.ID)
+		require.Equal(http.StatusUnauthorized, msg.Status)
+	})
+}
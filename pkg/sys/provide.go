@@ -22,6 +22,7 @@ import (
 	"github.com/voedger/voedger/pkg/sys/builtin"
 	"github.com/voedger/voedger/pkg/sys/collection"
 	"github.com/voedger/voedger/pkg/sys/describe"
+	"github.com/voedger/voedger/pkg/sys/indexes"
 	"github.com/voedger/voedger/pkg/sys/invite"
 	"github.com/voedger/voedger/pkg/sys/journal"
 	"github.com/voedger/voedger/pkg/sys/smtp"
@@ -50,6 +51,7 @@ func Provide(cfg *istructsmem.AppConfigType, appDefBuilder appdef.IAppDefBuilder
 	authnz.Provide(cfg, itokens, atf)
 	invite.Provide(cfg, timeFunc, federation, itokens, smtpCfg)
 	uniques.Provide(cfg, appDefBuilder)
+	indexes.Provide(cfg, appDefBuilder)
 	describe.Provide(cfg, asp)
 	return ProvidePackageFS()
 }
@@ -15,9 +15,25 @@ const (
 	DefaultLimit  = 100
 	DefaultOffset = istructs.FirstOffset
 	field_Query   = "Query"
+	field_ID      = "id"
 	flag_WSID     = "--wsid="
 )
 
+const (
+	aggr_Count = "count"
+	aggr_Sum   = "sum"
+	aggr_Min   = "min"
+	aggr_Max   = "max"
+	aggr_Avg   = "avg"
+)
+
+const (
+	// max groups in GROUP BY results
+	maxGroups = 10000
+	// max rows to sort by ORDER BY without LIMIT
+	maxSortedRows = 10000
+)
+
 var (
 	plog    = appdef.NewQName(appdef.SysPackage, "plog")
 	plogDef = map[string]bool{
@@ -10,4 +10,7 @@ import "errors"
 
 var (
 	errUnsupportedDataKind = errors.New("unsupported data kind")
+	errTooManyRows         = errors.New("too many rows")
+	errDivisionByZero      = errors.New("division by zero")
+	errLimitReached        = errors.New("limit reached")
 )
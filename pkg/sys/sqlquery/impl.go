@@ -32,7 +32,19 @@ func execQrySqlQuery(asp istructs.IAppStructsProvider, appQName istructs.AppQNam
 		if err != nil {
 			return err
 		}
-		s := stmt.(*sqlparser.Select)
+		s, ok := stmt.(*sqlparser.Select)
+		if !ok {
+			return fmt.Errorf("unsupported statement: %s", sqlparser.String(stmt))
+		}
+
+		appStructs, err := asp.AppStructs(appQName)
+		if err != nil {
+			return err
+		}
+
+		if isSelect(s) {
+			return execSelect(ctx, wsid, s, appStructs, callback)
+		}
 
 		f := &filter{fields: make(map[string]bool)}
 		for _, intf := range s.SelectExprs {
@@ -49,11 +61,6 @@ func execQrySqlQuery(asp istructs.IAppStructsProvider, appQName istructs.AppQNam
 			}
 		}
 
-		appStructs, err := asp.AppStructs(appQName)
-		if err != nil {
-			return err
-		}
-
 		var whereExpr sqlparser.Expr
 		if s.Where == nil {
 			whereExpr = nil
@@ -72,7 +79,9 @@ func execQrySqlQuery(asp istructs.IAppStructsProvider, appQName istructs.AppQNam
 		case appdef.TypeKind_CRecord:
 			fallthrough
 		case appdef.TypeKind_WDoc:
-			return readRecords(wsid, source, whereExpr, appStructs, f, callback)
+			fallthrough
+		case appdef.TypeKind_WRecord:
+			return readRecords(ctx, wsid, source, whereExpr, appStructs, f, callback)
 		default:
 			if source != plog && source != wlog {
 				break
@@ -110,7 +119,7 @@ func lim(limit *sqlparser.Limit) (int, error) {
 	if limit == nil {
 		return DefaultLimit, nil
 	}
-	v, err := parseInt64(limit.Rowcount.(*sqlparser.SQLVal).Val)
+	v, err := intVal(limit.Rowcount)
 	if err != nil {
 		return 0, err
 	}
@@ -123,6 +132,15 @@ func lim(limit *sqlparser.Limit) (int, error) {
 	return int(v), err
 }
 
+// intVal returns value of integer literal
+func intVal(expr sqlparser.Expr) (int64, error) {
+	v, ok := expr.(*sqlparser.SQLVal)
+	if !ok {
+		return 0, fmt.Errorf("integer literal expected: %s", sqlparser.String(expr))
+	}
+	return parseInt64(v.Val)
+}
+
 func offs(expr sqlparser.Expr) (istructs.Offset, bool, error) {
 	o := DefaultOffset
 	eq := false
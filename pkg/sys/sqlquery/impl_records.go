@@ -5,9 +5,13 @@
 package sqlquery
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/blastrain/vitess-sqlparser/sqlparser"
 	"github.com/voedger/voedger/pkg/appdef"
@@ -16,9 +20,27 @@ import (
 	coreutils "github.com/voedger/voedger/pkg/utils"
 )
 
-func readRecords(WSID istructs.WSID, qName appdef.QName, expr sqlparser.Expr, appStructs istructs.IAppStructs, f *filter, callback istructs.ExecQueryCallback) error {
+func readRecords(ctx context.Context, WSID istructs.WSID, qName appdef.QName, expr sqlparser.Expr, appStructs istructs.IAppStructs, f *filter, callback istructs.ExecQueryCallback) error {
 	rr := make([]istructs.RecordGetBatchItem, 0)
 
+	if conds := indexConditions(expr); len(conds) > 0 {
+		index, values, err := findIndex(appStructs.AppDef().Type(qName), conds)
+		if err != nil {
+			return err
+		}
+		err = coreutils.ReadByIndex(ctx, appStructs.ViewRecords(), WSID, index, values, func(id istructs.RecordID) error {
+			rr = append(rr, istructs.RecordGetBatchItem{ID: id})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(rr) == 0 {
+			return nil
+		}
+		return sendRecords(WSID, qName, rr, appStructs, f, callback)
+	}
+
 	findIDs := func(expr sqlparser.Expr) error {
 		switch r := expr.(type) {
 		case *sqlparser.ComparisonExpr:
@@ -69,7 +91,11 @@ func readRecords(WSID istructs.WSID, qName appdef.QName, expr sqlparser.Expr, ap
 		return errors.New("you have to provide at least one record ID")
 	}
 
-	err = appStructs.Records().GetBatch(WSID, true, rr)
+	return sendRecords(WSID, qName, rr, appStructs, f, callback)
+}
+
+func sendRecords(WSID istructs.WSID, qName appdef.QName, rr []istructs.RecordGetBatchItem, appStructs istructs.IAppStructs, f *filter, callback istructs.ExecQueryCallback) error {
+	err := appStructs.Records().GetBatch(WSID, true, rr)
 	if err != nil {
 		return err
 	}
@@ -106,3 +132,88 @@ func readRecords(WSID istructs.WSID, qName appdef.QName, expr sqlparser.Expr, ap
 
 	return nil
 }
+
+// Returns equality conditions on non-ID columns joined by AND, column name -> value.
+//
+// Returns nil if expression is not such conditions
+func indexConditions(expr sqlparser.Expr) map[string]sqlparser.Expr {
+	conds := make(map[string]sqlparser.Expr)
+	var walk func(expr sqlparser.Expr) bool
+	walk = func(expr sqlparser.Expr) bool {
+		switch r := expr.(type) {
+		case *sqlparser.AndExpr:
+			return walk(r.Left) && walk(r.Right)
+		case *sqlparser.ComparisonExpr:
+			col, ok := r.Left.(*sqlparser.ColName)
+			if !ok || (r.Operator != sqlparser.EqualStr) || (col.Name.Lowered() == "id") {
+				return false
+			}
+			conds[col.Name.String()] = r.Right
+			return true
+		}
+		return false
+	}
+	if !walk(expr) {
+		return nil
+	}
+	return conds
+}
+
+// Returns index, which leading fields are exactly the columns of conditions, and values of conditions in index fields order
+func findIndex(t appdef.IType, conds map[string]sqlparser.Expr) (appdef.IIndex, []interface{}, error) {
+	columns := make([]string, 0, len(conds))
+	for c := range conds {
+		columns = append(columns, c)
+	}
+	slices.Sort(columns)
+	for _, c := range columns {
+		if fields, ok := t.(appdef.IFields); !ok || (fields.Field(c) == nil) {
+			return nil, nil, fmt.Errorf("unsupported column name: %s", c)
+		}
+	}
+
+	if rec, ok := t.(appdef.IRecord); ok {
+		for _, index := range rec.Indexes() {
+			if len(index.Fields()) < len(conds) {
+				continue
+			}
+			values := make([]interface{}, 0, len(conds))
+			for _, f := range index.Fields()[:len(conds)] {
+				expr, ok := conds[f.Name()]
+				if !ok {
+					break
+				}
+				v, err := indexValue(f, expr)
+				if err != nil {
+					return nil, nil, err
+				}
+				values = append(values, v)
+			}
+			if len(values) == len(conds) {
+				return index, values, nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("'%s' has no index by columns: %s", t.QName(), strings.Join(columns, ", "))
+}
+
+func indexValue(f appdef.IField, expr sqlparser.Expr) (interface{}, error) {
+	switch v := expr.(type) {
+	case sqlparser.BoolVal:
+		return bool(v), nil
+	case *sqlparser.SQLVal:
+		switch v.Type {
+		case sqlparser.StrVal:
+			return string(v.Val), nil
+		case sqlparser.IntVal:
+			switch f.DataKind() {
+			case appdef.DataKind_float32, appdef.DataKind_float64, appdef.DataKind_decimal:
+				return strconv.ParseFloat(string(v.Val), bitSize64)
+			}
+			return parseInt64(v.Val)
+		case sqlparser.FloatVal:
+			return strconv.ParseFloat(string(v.Val), bitSize64)
+		}
+	}
+	return nil, fmt.Errorf("unsupported value of column '%s': %s", f.Name(), sqlparser.String(expr))
+}
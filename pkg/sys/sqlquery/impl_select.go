@@ -0,0 +1,692 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sqlquery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blastrain/vitess-sqlparser/sqlparser"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys/collection"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// isSelect returns true if the statement uses joins, aggregate functions, expressions, GROUP BY or ORDER BY
+// and must be executed by the select executor
+func isSelect(s *sqlparser.Select) bool {
+	if len(s.From) != 1 || len(s.GroupBy) > 0 || len(s.OrderBy) > 0 || s.Having != nil {
+		return true
+	}
+	if _, ok := s.From[0].(*sqlparser.AliasedTableExpr); !ok {
+		return true
+	}
+	for _, intf := range s.SelectExprs {
+		if expr, ok := intf.(*sqlparser.AliasedExpr); ok {
+			if _, ok := expr.Expr.(*sqlparser.ColName); !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func execSelect(ctx context.Context, WSID istructs.WSID, s *sqlparser.Select, appStructs istructs.IAppStructs, callback istructs.ExecQueryCallback) error {
+	q, err := newSelectQuery(WSID, s, appStructs)
+	if err != nil {
+		return err
+	}
+	return q.exec(ctx, callback)
+}
+
+func newSelectQuery(WSID istructs.WSID, s *sqlparser.Select, appStructs istructs.IAppStructs) (q *selectQuery, err error) {
+	q = &selectQuery{
+		wsid:       WSID,
+		appStructs: appStructs,
+		limit:      DefaultLimit,
+	}
+
+	if s.Distinct != "" {
+		return nil, errors.New("DISTINCT is not supported")
+	}
+	if s.Having != nil {
+		return nil, errors.New("HAVING is not supported")
+	}
+	if len(s.From) != 1 {
+		return nil, fmt.Errorf("unsupported FROM clause: %s", sqlparser.String(s.From))
+	}
+	if err = q.addTable(s.From[0]); err != nil {
+		return nil, err
+	}
+
+	if s.Where != nil {
+		q.whereExpr = s.Where.Expr
+		if q.where, err = q.compileExpr(s.Where.Expr); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, intf := range s.SelectExprs {
+		if err = q.addSelectExpr(intf); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, expr := range s.GroupBy {
+		eval, e := q.compileExpr(expr)
+		if e != nil {
+			return nil, e
+		}
+		q.groupBy = append(q.groupBy, eval)
+		q.aggregated = true
+	}
+	if q.aggregated {
+		if err = q.checkGroupBy(s.GroupBy); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, order := range s.OrderBy {
+		if err = q.addOrder(order); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.Limit != nil {
+		if q.limit, err = lim(s.Limit); err != nil {
+			return nil, err
+		}
+		if s.Limit.Offset != nil {
+			v, e := intVal(s.Limit.Offset)
+			if e != nil {
+				return nil, e
+			}
+			if v < 0 {
+				return nil, errors.New("offset must not be negative")
+			}
+			q.offset = int(v)
+		}
+	}
+
+	return q, nil
+}
+
+func (q *selectQuery) addTable(expr sqlparser.TableExpr) error {
+	switch t := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		return q.addSource(t)
+	case *sqlparser.ParenTableExpr:
+		if len(t.Exprs) == 1 {
+			return q.addTable(t.Exprs[0])
+		}
+	case *sqlparser.JoinTableExpr:
+		if t.Join != sqlparser.JoinStr && t.Join != sqlparser.StraightJoinStr {
+			return fmt.Errorf("unsupported join: %s", t.Join)
+		}
+		if err := q.addTable(t.LeftExpr); err != nil {
+			return err
+		}
+		right, ok := t.RightExpr.(*sqlparser.AliasedTableExpr)
+		if !ok {
+			return fmt.Errorf("unsupported join table expression: %T", t.RightExpr)
+		}
+		if err := q.addSource(right); err != nil {
+			return err
+		}
+		return q.addJoin(t.On)
+	}
+	return fmt.Errorf("unsupported table expression: %T", expr)
+}
+
+func (q *selectQuery) addSource(expr *sqlparser.AliasedTableExpr) error {
+	table, ok := expr.Expr.(sqlparser.TableName)
+	if !ok {
+		return fmt.Errorf("unsupported table expression: %T", expr.Expr)
+	}
+	src := &source{
+		qName: appdef.NewQName(table.Qualifier.String(), table.Name.String()),
+		alias: table.Name.String(),
+	}
+	if !expr.As.IsEmpty() {
+		src.alias = expr.As.String()
+	}
+	if q.sourceByAlias(src.alias) >= 0 {
+		return fmt.Errorf("not unique table alias: %s", src.alias)
+	}
+
+	t := q.appStructs.AppDef().Type(src.qName)
+	src.kind = t.Kind()
+	switch {
+	case src.kind == appdef.TypeKind_ViewRecord:
+		src.fields = q.appStructs.AppDef().View(src.qName)
+	case isRecordKind(src.kind):
+		src.fields = t.(appdef.IFields)
+	default:
+		return fmt.Errorf("unsupported source: %s", src.qName)
+	}
+
+	q.sources = append(q.sources, src)
+	return nil
+}
+
+// addJoin binds the last added source by the ON condition.
+//
+// Condition must equal the ID of the joined record to the reference field of already bound source
+func (q *selectQuery) addJoin(on sqlparser.Expr) error {
+	target := len(q.sources) - 1
+	if !isRecordKind(q.sources[target].kind) {
+		return fmt.Errorf("unable to join '%s': only records can be joined", q.sources[target].qName)
+	}
+
+	errCondition := fmt.Errorf("unsupported join condition: %s", sqlparser.String(on))
+	cmp, ok := on.(*sqlparser.ComparisonExpr)
+	if !ok || cmp.Operator != sqlparser.EqualStr {
+		return errCondition
+	}
+	left, ok := cmp.Left.(*sqlparser.ColName)
+	if !ok {
+		return errCondition
+	}
+	right, ok := cmp.Right.(*sqlparser.ColName)
+	if !ok {
+		return errCondition
+	}
+	l, err := q.column(left)
+	if err != nil {
+		return err
+	}
+	r, err := q.column(right)
+	if err != nil {
+		return err
+	}
+
+	var ref column
+	switch {
+	case r.src == target && r.field == appdef.SystemField_ID && l.src < target:
+		ref = l
+	case l.src == target && l.field == appdef.SystemField_ID && r.src < target:
+		ref = r
+	default:
+		return errCondition
+	}
+	if q.sources[ref.src].fields.Field(ref.field).DataKind() != appdef.DataKind_RecordID {
+		return fmt.Errorf("field '%s' of '%s' is not a reference", ref.field, q.sources[ref.src].qName)
+	}
+
+	q.joins = append(q.joins, &join{target: target, ref: ref})
+	return nil
+}
+
+func (q *selectQuery) addSelectExpr(intf sqlparser.SelectExpr) error {
+	switch expr := intf.(type) {
+	case *sqlparser.StarExpr:
+		for i, src := range q.sources {
+			if !expr.TableName.Name.IsEmpty() && src.alias != expr.TableName.Name.String() {
+				continue
+			}
+			for _, f := range src.fields.Fields() {
+				name := f.Name()
+				if len(q.sources) > 1 {
+					name = fmt.Sprintf("%s.%s", src.alias, name)
+				}
+				c := column{src: i, field: f.Name()}
+				q.items = append(q.items, &selectItem{name: name, eval: c.eval})
+			}
+		}
+		return nil
+	case *sqlparser.AliasedExpr:
+		item := &selectItem{
+			name: sqlparser.String(expr.Expr),
+			expr: expr.Expr,
+		}
+		if !expr.As.IsEmpty() {
+			item.name = expr.As.String()
+		}
+		if f, ok := expr.Expr.(*sqlparser.FuncExpr); ok && isAggregateFunc(f) {
+			aggr, err := q.compileAggregate(f)
+			if err != nil {
+				return err
+			}
+			item.aggr = aggr
+			q.aggregated = true
+		} else {
+			eval, err := q.compileExpr(expr.Expr)
+			if err != nil {
+				return err
+			}
+			item.eval = eval
+		}
+		q.items = append(q.items, item)
+		return nil
+	}
+	return fmt.Errorf("unsupported select expression: %T", intf)
+}
+
+// checkGroupBy checks that each not aggregated select item is the GROUP BY expression
+func (q *selectQuery) checkGroupBy(groupBy sqlparser.GroupBy) error {
+	for _, item := range q.items {
+		if item.aggr != nil {
+			continue
+		}
+		if item.expr == nil {
+			return errors.New("* is not allowed with GROUP BY or aggregate functions")
+		}
+		grouped := false
+		for _, expr := range groupBy {
+			if q.sameExpr(item.expr, expr) {
+				grouped = true
+				break
+			}
+		}
+		if !grouped {
+			return fmt.Errorf("column '%s' must appear in the GROUP BY clause or be used in an aggregate function", item.name)
+		}
+	}
+	return nil
+}
+
+func (q *selectQuery) addOrder(order *sqlparser.Order) error {
+	o := &orderItem{
+		item: -1,
+		desc: order.Direction == sqlparser.DescScr,
+	}
+	if cn, ok := order.Expr.(*sqlparser.ColName); ok && cn.Qualifier.IsEmpty() {
+		for i, item := range q.items {
+			if item.name == cn.Name.String() {
+				o.item = i
+				break
+			}
+		}
+	}
+	if o.item < 0 {
+		for i, item := range q.items {
+			if item.expr != nil && q.sameExpr(item.expr, order.Expr) {
+				o.item = i
+				break
+			}
+		}
+	}
+	if o.item < 0 {
+		if q.aggregated {
+			return fmt.Errorf("ORDER BY expression '%s' must be one of the selected columns", sqlparser.String(order.Expr))
+		}
+		eval, err := q.compileExpr(order.Expr)
+		if err != nil {
+			return err
+		}
+		o.eval = eval
+	}
+	q.orderBy = append(q.orderBy, o)
+	return nil
+}
+
+// column resolves the column name to the source and the field of the source.
+//
+// Column can be qualified by the table alias. Unqualified names must be unique across sources.
+// Name «id» refers to the «sys.ID» system field of records
+func (q *selectQuery) column(cn *sqlparser.ColName) (c column, err error) {
+	name := cn.Name.String()
+	c.src = -1
+	switch {
+	case !cn.Qualifier.Qualifier.IsEmpty():
+		c.src = q.sourceByAlias(cn.Qualifier.Qualifier.String())
+		if c.src < 0 {
+			return c, fmt.Errorf("unknown table alias: %s", cn.Qualifier.Qualifier.String())
+		}
+		name = fmt.Sprintf("%s.%s", cn.Qualifier.Name, name)
+	case !cn.Qualifier.Name.IsEmpty():
+		c.src = q.sourceByAlias(cn.Qualifier.Name.String())
+		if c.src < 0 {
+			name = fmt.Sprintf("%s.%s", cn.Qualifier.Name, name)
+		}
+	}
+
+	if c.src >= 0 {
+		if c.field = q.sources[c.src].field(name); c.field == "" {
+			return c, fmt.Errorf("field '%s' not found in def '%s'", name, q.sources[c.src].qName)
+		}
+		return c, nil
+	}
+
+	for i, src := range q.sources {
+		if field := src.field(name); field != "" {
+			if c.src >= 0 {
+				return c, fmt.Errorf("ambiguous field '%s'", name)
+			}
+			c.src = i
+			c.field = field
+		}
+	}
+	if c.src < 0 {
+		return c, fmt.Errorf("field '%s' not found in def", name)
+	}
+	return c, nil
+}
+
+func (q *selectQuery) sourceByAlias(alias string) int {
+	for i, src := range q.sources {
+		if src.alias == alias {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameExpr returns true if expressions are the same columns or have the same text
+func (q *selectQuery) sameExpr(e1, e2 sqlparser.Expr) bool {
+	cn1, ok1 := e1.(*sqlparser.ColName)
+	cn2, ok2 := e2.(*sqlparser.ColName)
+	if ok1 && ok2 {
+		c1, err1 := q.column(cn1)
+		c2, err2 := q.column(cn2)
+		return err1 == nil && err2 == nil && c1 == c2
+	}
+	return sqlparser.String(e1) == sqlparser.String(e2)
+}
+
+func (q *selectQuery) exec(ctx context.Context, callback istructs.ExecQueryCallback) error {
+	var sink rowsSink = &limiter{
+		offset: q.offset,
+		limit:  q.limit,
+		emit:   q.emitter(callback),
+	}
+	if len(q.orderBy) > 0 {
+		sink = newSorter(q.orderBy, q.offset, q.limit, sink)
+	}
+
+	var put func(r row) error
+	var flush func() error
+	if q.aggregated {
+		g := newGrouper(q, sink)
+		put, flush = g.put, g.flush
+	} else {
+		put = func(r row) error {
+			out, err := q.project(r)
+			if err != nil {
+				return err
+			}
+			return sink.put(out)
+		}
+		flush = sink.flush
+	}
+
+	err := q.scan(ctx, func(r row) error {
+		if q.where != nil {
+			v, err := q.where(r)
+			if err != nil {
+				return err
+			}
+			if ok, _ := v.(bool); !ok {
+				return nil
+			}
+		}
+		return put(r)
+	})
+	if err == nil {
+		err = flush()
+	}
+	if errors.Is(err, errLimitReached) {
+		return nil
+	}
+	return err
+}
+
+// project calculates select items and order keys of the not aggregated row
+func (q *selectQuery) project(r row) (out *outRow, err error) {
+	out = &outRow{values: make([]interface{}, len(q.items))}
+	for i, item := range q.items {
+		if out.values[i], err = item.eval(r); err != nil {
+			return nil, err
+		}
+	}
+	if out.keys, err = q.orderKeys(out, r); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (q *selectQuery) orderKeys(out *outRow, r row) (keys []interface{}, err error) {
+	if len(q.orderBy) == 0 {
+		return nil, nil
+	}
+	keys = make([]interface{}, len(q.orderBy))
+	for i, o := range q.orderBy {
+		if o.item >= 0 {
+			keys[i] = out.values[o.item]
+			continue
+		}
+		if keys[i], err = o.eval(r); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func (q *selectQuery) emitter(callback istructs.ExecQueryCallback) func(out *outRow) error {
+	return func(out *outRow) error {
+		data := make(map[string]interface{}, len(q.items))
+		for i, item := range q.items {
+			data[item.name] = out.values[i]
+		}
+		bb, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		return callback(&result{value: string(bb)})
+	}
+}
+
+// scan reads the first source and joins other sources to each read row
+func (q *selectQuery) scan(ctx context.Context, cb func(r row) error) error {
+	bind := func(fields map[string]interface{}) error {
+		r := make(row, len(q.sources))
+		r[0] = fields
+		for _, j := range q.joins {
+			ok, err := q.bind(j, r)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+		}
+		return cb(r)
+	}
+	if q.sources[0].kind == appdef.TypeKind_ViewRecord {
+		return q.scanView(ctx, bind)
+	}
+	return q.scanRecords(ctx, bind)
+}
+
+// bind reads the joined record by reference. Returns false if record not found
+func (q *selectQuery) bind(j *join, r row) (bool, error) {
+	id, _ := r[j.ref.src][j.ref.field].(istructs.RecordID)
+	if id == istructs.NullRecordID {
+		return false, nil
+	}
+	rec, err := q.appStructs.Records().Get(q.wsid, true, id)
+	if err != nil {
+		return false, err
+	}
+	if rec.QName() != q.sources[j.target].qName {
+		return false, nil
+	}
+	r[j.target] = coreutils.FieldsToMap(rec, q.appStructs.AppDef())
+	return true, nil
+}
+
+func (q *selectQuery) scanView(ctx context.Context, cb func(fields map[string]interface{}) error) error {
+	src := q.sources[0]
+	view := q.appStructs.AppDef().View(src.qName)
+	kb := q.appStructs.ViewRecords().KeyBuilder(src.qName)
+	for _, expr := range conjuncts(q.whereExpr) {
+		c, val, ok := q.equality(expr)
+		if !ok || view.Key().Field(c.field) == nil {
+			continue
+		}
+		if err := putKeyField(kb, c.field, view.Key().Field(c.field).DataKind(), val.Val); err != nil {
+			return err
+		}
+	}
+	return q.appStructs.ViewRecords().Read(ctx, q.wsid, kb, func(key istructs.IKey, value istructs.IValue) (err error) {
+		data := coreutils.FieldsToMap(key, q.appStructs.AppDef(), coreutils.WithNonNilsOnly())
+		for k, v := range coreutils.FieldsToMap(value, q.appStructs.AppDef(), coreutils.WithNonNilsOnly()) {
+			data[k] = v
+		}
+		return cb(data)
+	})
+}
+
+// scanRecords reads records by IDs from WHERE clause, reads singleton or scans sys.CollectionView for CDocs and CRecords
+func (q *selectQuery) scanRecords(ctx context.Context, cb func(fields map[string]interface{}) error) error {
+	src := q.sources[0]
+
+	ids, err := q.recordIDs()
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		if err = q.appStructs.Records().GetBatch(q.wsid, true, ids); err != nil {
+			return err
+		}
+		for _, item := range ids {
+			if item.Record.QName() != src.qName {
+				continue
+			}
+			if err = cb(coreutils.FieldsToMap(item.Record, q.appStructs.AppDef())); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if doc := q.appStructs.AppDef().CDoc(src.qName); doc != nil && doc.Singleton() {
+		rec, e := q.appStructs.Records().GetSingleton(q.wsid, src.qName)
+		if e != nil {
+			return e
+		}
+		if rec.QName() == appdef.NullQName {
+			return nil
+		}
+		return cb(coreutils.FieldsToMap(rec, q.appStructs.AppDef()))
+	}
+
+	if src.kind != appdef.TypeKind_CDoc && src.kind != appdef.TypeKind_CRecord {
+		return fmt.Errorf("unable to scan '%s': only CDocs and CRecords can be scanned, please specify record IDs", src.qName)
+	}
+
+	kb := q.appStructs.ViewRecords().KeyBuilder(collection.QNameCollectionView)
+	kb.PutInt32(collection.Field_PartKey, collection.PartitionKeyCollection)
+	if src.kind == appdef.TypeKind_CDoc {
+		kb.PutQName(collection.Field_DocQName, src.qName)
+	}
+	return q.appStructs.ViewRecords().Read(ctx, q.wsid, kb, func(_ istructs.IKey, value istructs.IValue) (err error) {
+		rec := value.AsRecord(collection.Field_Record)
+		if rec.QName() != src.qName {
+			return nil
+		}
+		return cb(coreutils.FieldsToMap(rec, q.appStructs.AppDef()))
+	})
+}
+
+// recordIDs returns IDs of the first source from «id = N» and «id IN (...)» conditions of WHERE clause
+func (q *selectQuery) recordIDs() (ids []istructs.RecordGetBatchItem, err error) {
+	for _, expr := range conjuncts(q.whereExpr) {
+		cmp, ok := expr.(*sqlparser.ComparisonExpr)
+		if !ok || (cmp.Operator != sqlparser.EqualStr && cmp.Operator != sqlparser.InStr) {
+			continue
+		}
+		cn, ok := cmp.Left.(*sqlparser.ColName)
+		if !ok {
+			continue
+		}
+		c, err := q.column(cn)
+		if err != nil || c.src != 0 || c.field != appdef.SystemField_ID {
+			continue
+		}
+		vals := sqlparser.ValTuple{cmp.Right}
+		if cmp.Operator == sqlparser.InStr {
+			if vals, ok = cmp.Right.(sqlparser.ValTuple); !ok {
+				continue
+			}
+		}
+		for _, v := range vals {
+			val, ok := v.(*sqlparser.SQLVal)
+			if !ok {
+				return nil, fmt.Errorf("unsupported ID value: %s", sqlparser.String(v))
+			}
+			id, err := parseInt64(val.Val)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, istructs.RecordGetBatchItem{ID: istructs.RecordID(id)})
+		}
+		return ids, nil
+	}
+	return nil, nil
+}
+
+// equality returns the column of the first source and the value from «column = value» expression
+func (q *selectQuery) equality(expr sqlparser.Expr) (c column, val *sqlparser.SQLVal, ok bool) {
+	cmp, ok := expr.(*sqlparser.ComparisonExpr)
+	if !ok || cmp.Operator != sqlparser.EqualStr {
+		return c, nil, false
+	}
+	cn, ok := cmp.Left.(*sqlparser.ColName)
+	if !ok {
+		return c, nil, false
+	}
+	if val, ok = cmp.Right.(*sqlparser.SQLVal); !ok {
+		return c, nil, false
+	}
+	c, err := q.column(cn)
+	if err != nil || c.src != 0 {
+		return c, nil, false
+	}
+	return c, val, true
+}
+
+// conjuncts returns the top level AND operands of the expression
+func conjuncts(expr sqlparser.Expr) []sqlparser.Expr {
+	switch e := expr.(type) {
+	case nil:
+		return nil
+	case *sqlparser.AndExpr:
+		return append(conjuncts(e.Left), conjuncts(e.Right)...)
+	case *sqlparser.ParenExpr:
+		return conjuncts(e.Expr)
+	}
+	return []sqlparser.Expr{expr}
+}
+
+func (s *source) field(name string) string {
+	if s.fields.Field(name) != nil {
+		return name
+	}
+	if isRecordKind(s.kind) && strings.EqualFold(name, field_ID) {
+		return appdef.SystemField_ID
+	}
+	return ""
+}
+
+func (c column) eval(r row) (interface{}, error) {
+	return r[c.src][c.field], nil
+}
+
+func isRecordKind(kind appdef.TypeKind) bool {
+	switch kind {
+	case appdef.TypeKind_GDoc, appdef.TypeKind_GRecord,
+		appdef.TypeKind_CDoc, appdef.TypeKind_CRecord,
+		appdef.TypeKind_WDoc, appdef.TypeKind_WRecord,
+		appdef.TypeKind_ODoc, appdef.TypeKind_ORecord:
+		return true
+	}
+	return false
+}
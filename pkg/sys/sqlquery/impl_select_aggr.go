@@ -0,0 +1,365 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sqlquery
+
+import (
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blastrain/vitess-sqlparser/sqlparser"
+)
+
+func isAggregateFunc(f *sqlparser.FuncExpr) bool {
+	switch f.Name.Lowered() {
+	case aggr_Count, aggr_Sum, aggr_Min, aggr_Max, aggr_Avg:
+		return f.Qualifier.IsEmpty()
+	}
+	return false
+}
+
+func (q *selectQuery) compileAggregate(f *sqlparser.FuncExpr) (*aggregate, error) {
+	if f.Distinct {
+		return nil, fmt.Errorf("DISTINCT is not supported in aggregate functions: %s", sqlparser.String(f))
+	}
+	if len(f.Exprs) != 1 {
+		return nil, fmt.Errorf("aggregate function '%s' must have exactly one argument", sqlparser.String(f))
+	}
+	aggr := &aggregate{fn: f.Name.Lowered()}
+	switch arg := f.Exprs[0].(type) {
+	case *sqlparser.StarExpr:
+		if aggr.fn != aggr_Count {
+			return nil, fmt.Errorf("* is allowed in COUNT only: %s", sqlparser.String(f))
+		}
+	case *sqlparser.AliasedExpr:
+		eval, err := q.compileExpr(arg.Expr)
+		if err != nil {
+			return nil, err
+		}
+		aggr.arg = eval
+	default:
+		return nil, fmt.Errorf("unsupported aggregate function argument: %s", sqlparser.String(arg))
+	}
+	return aggr, nil
+}
+
+// newAggregator returns the new accumulator for the aggregate function
+func (a *aggregate) newAggregator() aggregator {
+	switch a.fn {
+	case aggr_Count:
+		return &countAggregator{}
+	case aggr_Sum:
+		return &sumAggregator{}
+	case aggr_Avg:
+		return &avgAggregator{}
+	case aggr_Min:
+		return &extremeAggregator{less: true}
+	}
+	return &extremeAggregator{}
+}
+
+type countAggregator struct {
+	count int64
+}
+
+func (a *countAggregator) add(v interface{}) error {
+	if v != nil {
+		a.count++
+	}
+	return nil
+}
+
+func (a *countAggregator) result() interface{} { return a.count }
+
+type sumAggregator struct {
+	intSum   int64
+	floatSum float64
+	isFloat  bool
+	count    int64
+}
+
+func (a *sumAggregator) add(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	n, isInt, ok := number(v)
+	if !ok {
+		return fmt.Errorf("unable to sum %T", v)
+	}
+	a.count++
+	if isInt && !a.isFloat {
+		a.intSum += n.(int64)
+		return nil
+	}
+	if !a.isFloat {
+		a.floatSum = float64(a.intSum)
+		a.isFloat = true
+	}
+	a.floatSum += toFloat64(n)
+	return nil
+}
+
+func (a *sumAggregator) result() interface{} {
+	if a.count == 0 {
+		return nil
+	}
+	if a.isFloat {
+		return a.floatSum
+	}
+	return a.intSum
+}
+
+type avgAggregator struct {
+	sum   float64
+	count int64
+}
+
+func (a *avgAggregator) add(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	n, _, ok := number(v)
+	if !ok {
+		return fmt.Errorf("unable to average %T", v)
+	}
+	a.sum += toFloat64(n)
+	a.count++
+	return nil
+}
+
+func (a *avgAggregator) result() interface{} {
+	if a.count == 0 {
+		return nil
+	}
+	return a.sum / float64(a.count)
+}
+
+// extremeAggregator calculates MIN if less is true and MAX otherwise
+type extremeAggregator struct {
+	less  bool
+	value interface{}
+}
+
+func (a *extremeAggregator) add(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	if a.value == nil {
+		a.value = v
+		return nil
+	}
+	c, err := compare(v, a.value)
+	if err != nil {
+		return err
+	}
+	if (c < 0) == a.less && c != 0 {
+		a.value = v
+	}
+	return nil
+}
+
+func (a *extremeAggregator) result() interface{} { return a.value }
+
+func newGrouper(q *selectQuery, next rowsSink) *grouper {
+	return &grouper{
+		q:      q,
+		groups: make(map[string]*group),
+		next:   next,
+	}
+}
+
+// put adds the row to the group accumulators
+func (g *grouper) put(r row) error {
+	key := ""
+	if len(g.q.groupBy) > 0 {
+		vals := make([]interface{}, len(g.q.groupBy))
+		for i, eval := range g.q.groupBy {
+			v, err := eval(r)
+			if err != nil {
+				return err
+			}
+			vals[i] = normalize(v)
+		}
+		bb, err := json.Marshal(vals)
+		if err != nil {
+			return err
+		}
+		key = string(bb)
+	}
+
+	grp, ok := g.groups[key]
+	if !ok {
+		if len(g.groups) >= maxGroups {
+			return fmt.Errorf("%w: more than %d groups", errTooManyRows, maxGroups)
+		}
+		var err error
+		if grp, err = g.newGroup(r); err != nil {
+			return err
+		}
+		g.groups[key] = grp
+		g.keys = append(g.keys, key)
+	}
+
+	for i, item := range g.q.items {
+		if item.aggr == nil {
+			continue
+		}
+		var v interface{} = true // COUNT(*) counts rows
+		if item.aggr.arg != nil {
+			var err error
+			if v, err = item.aggr.arg(r); err != nil {
+				return err
+			}
+		}
+		if err := grp.aggrs[i].add(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newGroup creates accumulators and calculates GROUP BY items from the first row of the group
+func (g *grouper) newGroup(r row) (grp *group, err error) {
+	grp = &group{
+		values: make([]interface{}, len(g.q.items)),
+		aggrs:  make([]aggregator, len(g.q.items)),
+	}
+	for i, item := range g.q.items {
+		if item.aggr != nil {
+			grp.aggrs[i] = item.aggr.newAggregator()
+			continue
+		}
+		if r != nil {
+			if grp.values[i], err = item.eval(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return grp, nil
+}
+
+func (g *grouper) flush() error {
+	if len(g.groups) == 0 && len(g.q.groupBy) == 0 {
+		// aggregates over empty set, e.g. COUNT(*) returns 0
+		grp, err := g.newGroup(nil)
+		if err != nil {
+			return err
+		}
+		g.groups[""] = grp
+		g.keys = append(g.keys, "")
+	}
+	for _, key := range g.keys {
+		grp := g.groups[key]
+		out := &outRow{values: grp.values}
+		for i, aggr := range grp.aggrs {
+			if aggr != nil {
+				out.values[i] = aggr.result()
+			}
+		}
+		keys, err := g.q.orderKeys(out, nil)
+		if err != nil {
+			return err
+		}
+		out.keys = keys
+		if err := g.next.put(out); err != nil {
+			return err
+		}
+	}
+	return g.next.flush()
+}
+
+// newSorter returns sink that sorts rows.
+//
+// If limit is specified then only offset+limit rows are kept in memory, else maxSortedRows rows can be sorted
+func newSorter(orderBy []*orderItem, offset, limit int, next rowsSink) *sorter {
+	s := &sorter{
+		orderBy:  orderBy,
+		capacity: maxSortedRows,
+		next:     next,
+	}
+	if limit < maxSortedRows && offset < maxSortedRows-limit {
+		s.capacity = offset + limit
+		s.bounded = true
+	}
+	return s
+}
+
+func (s *sorter) put(out *outRow) error {
+	if !s.bounded {
+		if len(s.rows) >= s.capacity {
+			return fmt.Errorf("%w: more than %d rows to sort, please use LIMIT", errTooManyRows, maxSortedRows)
+		}
+		s.rows = append(s.rows, out)
+		return nil
+	}
+	if s.capacity == 0 {
+		return nil
+	}
+	// s.rows is the heap with the last row in order on the top
+	heap.Push(s, out)
+	if len(s.rows) > s.capacity {
+		heap.Pop(s)
+	}
+	return nil
+}
+
+func (s *sorter) flush() error {
+	sort.SliceStable(s.rows, func(i, j int) bool { return s.compare(s.rows[i], s.rows[j]) < 0 })
+	for _, out := range s.rows {
+		if err := s.next.put(out); err != nil {
+			return err
+		}
+	}
+	return s.next.flush()
+}
+
+func (s *sorter) compare(r1, r2 *outRow) int {
+	for i, o := range s.orderBy {
+		c, err := compare(r1.keys[i], r2.keys[i])
+		if err != nil {
+			c = strings.Compare(fmt.Sprint(r1.keys[i]), fmt.Sprint(r2.keys[i]))
+		}
+		if o.desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func (s *sorter) Len() int           { return len(s.rows) }
+func (s *sorter) Less(i, j int) bool { return s.compare(s.rows[i], s.rows[j]) > 0 }
+func (s *sorter) Swap(i, j int)      { s.rows[i], s.rows[j] = s.rows[j], s.rows[i] }
+func (s *sorter) Push(x any)         { s.rows = append(s.rows, x.(*outRow)) }
+func (s *sorter) Pop() any {
+	last := s.rows[len(s.rows)-1]
+	s.rows = s.rows[:len(s.rows)-1]
+	return last
+}
+
+// put skips first offset rows and passes next limit rows to emit. Returns errLimitReached when limit is reached
+func (l *limiter) put(out *outRow) error {
+	if l.skipped < l.offset {
+		l.skipped++
+		return nil
+	}
+	if l.emitted >= l.limit {
+		return errLimitReached
+	}
+	if err := l.emit(out); err != nil {
+		return err
+	}
+	l.emitted++
+	if l.emitted >= l.limit {
+		return errLimitReached
+	}
+	return nil
+}
+
+func (l *limiter) flush() error { return nil }
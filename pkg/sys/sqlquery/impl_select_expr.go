@@ -0,0 +1,404 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package sqlquery
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blastrain/vitess-sqlparser/sqlparser"
+
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// compileExpr compiles the scalar expression to the evaluator
+func (q *selectQuery) compileExpr(expr sqlparser.Expr) (evaluator, error) {
+	switch e := expr.(type) {
+	case *sqlparser.ColName:
+		c, err := q.column(e)
+		if err != nil {
+			return nil, err
+		}
+		return c.eval, nil
+	case *sqlparser.SQLVal:
+		v, err := literal(e)
+		if err != nil {
+			return nil, err
+		}
+		return constant(v), nil
+	case *sqlparser.NullVal:
+		return constant(nil), nil
+	case sqlparser.BoolVal:
+		return constant(bool(e)), nil
+	case *sqlparser.ParenExpr:
+		return q.compileExpr(e.Expr)
+	case *sqlparser.AndExpr:
+		return q.compileLogical(e.Left, e.Right, false)
+	case *sqlparser.OrExpr:
+		return q.compileLogical(e.Left, e.Right, true)
+	case *sqlparser.NotExpr:
+		eval, err := q.compileExpr(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return func(r row) (interface{}, error) {
+			v, err := eval(r)
+			if err != nil || v == nil {
+				return nil, err
+			}
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("boolean expected, got %T", v)
+			}
+			return !b, nil
+		}, nil
+	case *sqlparser.ComparisonExpr:
+		return q.compileComparison(e)
+	case *sqlparser.IsExpr:
+		return q.compileIs(e)
+	case *sqlparser.BinaryExpr:
+		return q.compileArithmetic(e)
+	case *sqlparser.UnaryExpr:
+		if e.Operator != sqlparser.UMinusStr && e.Operator != sqlparser.UPlusStr {
+			return nil, fmt.Errorf("unsupported operator: %s", e.Operator)
+		}
+		return q.compileArithmetic(&sqlparser.BinaryExpr{
+			Operator: e.Operator,
+			Left:     sqlparser.NewIntVal([]byte("0")),
+			Right:    e.Expr,
+		})
+	case *sqlparser.FuncExpr:
+		if isAggregateFunc(e) {
+			return nil, fmt.Errorf("aggregate function '%s' is not allowed here", sqlparser.String(e))
+		}
+		return nil, fmt.Errorf("unsupported function: %s", e.Name.String())
+	}
+	return nil, fmt.Errorf("unsupported expression: %T", expr)
+}
+
+func (q *selectQuery) compileLogical(left, right sqlparser.Expr, or bool) (evaluator, error) {
+	l, err := q.compileExpr(left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := q.compileExpr(right)
+	if err != nil {
+		return nil, err
+	}
+	return func(rw row) (interface{}, error) {
+		lv, err := l(rw)
+		if err != nil {
+			return nil, err
+		}
+		if b, _ := lv.(bool); b == or {
+			return or, nil
+		}
+		rv, err := r(rw)
+		if err != nil {
+			return nil, err
+		}
+		b, _ := rv.(bool)
+		return b, nil
+	}, nil
+}
+
+func (q *selectQuery) compileComparison(e *sqlparser.ComparisonExpr) (evaluator, error) {
+	if e.Escape != nil {
+		return nil, fmt.Errorf("unsupported expression: %s", sqlparser.String(e))
+	}
+	l, err := q.compileExpr(e.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Operator {
+	case sqlparser.InStr, sqlparser.NotInStr:
+		tuple, ok := e.Right.(sqlparser.ValTuple)
+		if !ok {
+			return nil, fmt.Errorf("unsupported expression: %s", sqlparser.String(e.Right))
+		}
+		vv := make([]evaluator, len(tuple))
+		for i, expr := range tuple {
+			if vv[i], err = q.compileExpr(expr); err != nil {
+				return nil, err
+			}
+		}
+		in := e.Operator == sqlparser.InStr
+		return func(r row) (interface{}, error) {
+			lv, err := l(r)
+			if err != nil || lv == nil {
+				return false, err
+			}
+			for _, eval := range vv {
+				v, err := eval(r)
+				if err != nil {
+					return nil, err
+				}
+				if c, err := compare(lv, v); err == nil && c == 0 {
+					return in, nil
+				}
+			}
+			return !in, nil
+		}, nil
+	case sqlparser.LikeStr, sqlparser.NotLikeStr:
+		pattern, ok := e.Right.(*sqlparser.SQLVal)
+		if !ok || pattern.Type != sqlparser.StrVal {
+			return nil, fmt.Errorf("string literal expected as LIKE pattern: %s", sqlparser.String(e.Right))
+		}
+		re, err := likeRegexp(string(pattern.Val))
+		if err != nil {
+			return nil, err
+		}
+		like := e.Operator == sqlparser.LikeStr
+		return func(r row) (interface{}, error) {
+			lv, err := l(r)
+			if err != nil || lv == nil {
+				return false, err
+			}
+			return re.MatchString(fmt.Sprint(normalize(lv))) == like, nil
+		}, nil
+	}
+
+	var match func(int) bool
+	switch e.Operator {
+	case sqlparser.EqualStr:
+		match = func(c int) bool { return c == 0 }
+	case sqlparser.NotEqualStr:
+		match = func(c int) bool { return c != 0 }
+	case sqlparser.LessThanStr:
+		match = func(c int) bool { return c < 0 }
+	case sqlparser.LessEqualStr:
+		match = func(c int) bool { return c <= 0 }
+	case sqlparser.GreaterThanStr:
+		match = func(c int) bool { return c > 0 }
+	case sqlparser.GreaterEqualStr:
+		match = func(c int) bool { return c >= 0 }
+	default:
+		return nil, fmt.Errorf("unsupported operation: %s", e.Operator)
+	}
+	r, err := q.compileExpr(e.Right)
+	if err != nil {
+		return nil, err
+	}
+	return func(rw row) (interface{}, error) {
+		lv, err := l(rw)
+		if err != nil {
+			return nil, err
+		}
+		rv, err := r(rw)
+		if err != nil {
+			return nil, err
+		}
+		if lv == nil || rv == nil {
+			return false, nil
+		}
+		c, err := compare(lv, rv)
+		if err != nil {
+			return nil, err
+		}
+		return match(c), nil
+	}, nil
+}
+
+func (q *selectQuery) compileIs(e *sqlparser.IsExpr) (evaluator, error) {
+	eval, err := q.compileExpr(e.Expr)
+	if err != nil {
+		return nil, err
+	}
+	var is func(v interface{}) bool
+	switch e.Operator {
+	case sqlparser.IsNullStr:
+		is = func(v interface{}) bool { return v == nil }
+	case sqlparser.IsNotNullStr:
+		is = func(v interface{}) bool { return v != nil }
+	case sqlparser.IsTrueStr:
+		is = func(v interface{}) bool { return v == true }
+	case sqlparser.IsNotTrueStr:
+		is = func(v interface{}) bool { return v != true }
+	case sqlparser.IsFalseStr:
+		is = func(v interface{}) bool { return v == false }
+	case sqlparser.IsNotFalseStr:
+		is = func(v interface{}) bool { return v != false }
+	default:
+		return nil, fmt.Errorf("unsupported operation: %s", e.Operator)
+	}
+	return func(r row) (interface{}, error) {
+		v, err := eval(r)
+		if err != nil {
+			return nil, err
+		}
+		return is(v), nil
+	}, nil
+}
+
+func (q *selectQuery) compileArithmetic(e *sqlparser.BinaryExpr) (evaluator, error) {
+	var intOp func(a, b int64) (int64, error)
+	var floatOp func(a, b float64) float64
+	switch e.Operator {
+	case sqlparser.PlusStr:
+		intOp = func(a, b int64) (int64, error) { return a + b, nil }
+		floatOp = func(a, b float64) float64 { return a + b }
+	case sqlparser.MinusStr:
+		intOp = func(a, b int64) (int64, error) { return a - b, nil }
+		floatOp = func(a, b float64) float64 { return a - b }
+	case sqlparser.MultStr:
+		intOp = func(a, b int64) (int64, error) { return a * b, nil }
+		floatOp = func(a, b float64) float64 { return a * b }
+	case sqlparser.DivStr:
+		floatOp = func(a, b float64) float64 { return a / b }
+	case sqlparser.ModStr:
+		intOp = func(a, b int64) (int64, error) {
+			if b == 0 {
+				return 0, errDivisionByZero
+			}
+			return a % b, nil
+		}
+		floatOp = math.Mod
+	default:
+		return nil, fmt.Errorf("unsupported operator: %s", e.Operator)
+	}
+	l, err := q.compileExpr(e.Left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := q.compileExpr(e.Right)
+	if err != nil {
+		return nil, err
+	}
+	return func(rw row) (interface{}, error) {
+		lv, err := l(rw)
+		if err != nil {
+			return nil, err
+		}
+		rv, err := r(rw)
+		if err != nil {
+			return nil, err
+		}
+		if lv == nil || rv == nil {
+			return nil, nil
+		}
+		li, lIsInt, lok := number(lv)
+		ri, rIsInt, rok := number(rv)
+		if !lok || !rok {
+			return nil, fmt.Errorf("unable to apply '%s' to %T and %T", e.Operator, lv, rv)
+		}
+		if lIsInt && rIsInt && intOp != nil {
+			return intOp(li.(int64), ri.(int64))
+		}
+		return floatOp(toFloat64(li), toFloat64(ri)), nil
+	}, nil
+}
+
+func constant(v interface{}) evaluator {
+	return func(row) (interface{}, error) { return v, nil }
+}
+
+func literal(val *sqlparser.SQLVal) (interface{}, error) {
+	switch val.Type {
+	case sqlparser.StrVal:
+		return string(val.Val), nil
+	case sqlparser.IntVal:
+		return parseInt64(val.Val)
+	case sqlparser.FloatVal:
+		return strconv.ParseFloat(string(val.Val), bitSize64)
+	}
+	return nil, fmt.Errorf("unsupported value: %s", sqlparser.String(val))
+}
+
+// likeRegexp converts LIKE pattern to the case insensitive regular expression
+func likeRegexp(pattern string) (*regexp.Regexp, error) {
+	b := strings.Builder{}
+	b.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// normalize converts numeric values to int64 or float64 and bytes to string
+func normalize(v interface{}) interface{} {
+	switch val := v.(type) {
+	case int32:
+		return int64(val)
+	case istructs.RecordID:
+		return int64(val)
+	case istructs.Offset:
+		return int64(val)
+	case float32:
+		return float64(val)
+	case []byte:
+		return string(val)
+	}
+	return v
+}
+
+// number returns normalized numeric value, true if value is integer and false if value is not a number
+func number(v interface{}) (n interface{}, isInt bool, ok bool) {
+	switch val := normalize(v).(type) {
+	case int64:
+		return val, true, true
+	case float64:
+		return val, false, true
+	}
+	return nil, false, false
+}
+
+func toFloat64(n interface{}) float64 {
+	if i, ok := n.(int64); ok {
+		return float64(i)
+	}
+	return n.(float64)
+}
+
+// compare returns -1, 0 or 1 if a is less, equal or greater than b. Nil is less than any other value
+func compare(a, b interface{}) (int, error) {
+	switch {
+	case a == nil && b == nil:
+		return 0, nil
+	case a == nil:
+		return -1, nil
+	case b == nil:
+		return 1, nil
+	}
+	if an, aIsInt, ok := number(a); ok {
+		bn, bIsInt, ok := number(b)
+		if !ok {
+			return 0, fmt.Errorf("unable to compare %T and %T", a, b)
+		}
+		if aIsInt && bIsInt {
+			return cmp.Compare(an.(int64), bn.(int64)), nil
+		}
+		return cmp.Compare(toFloat64(an), toFloat64(bn)), nil
+	}
+	switch av := normalize(a).(type) {
+	case string:
+		if bv, ok := normalize(b).(string); ok {
+			return strings.Compare(av, bv), nil
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			switch {
+			case av == bv:
+				return 0, nil
+			case bv:
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unable to compare %T and %T", a, b)
+}
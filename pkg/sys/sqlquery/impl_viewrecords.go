@@ -83,27 +83,8 @@ func readViewRecords(ctx context.Context, WSID istructs.WSID, viewRecordQName ap
 		if f == nil {
 			return fmt.Errorf("field '%s' does not exist in '%s' key def", k.name, viewRecordQName)
 		}
-		switch f.DataKind() {
-		case appdef.DataKind_int32:
-			fallthrough
-		case appdef.DataKind_int64:
-			fallthrough
-		case appdef.DataKind_float32:
-			fallthrough
-		case appdef.DataKind_float64:
-			fallthrough
-		case appdef.DataKind_RecordID:
-			v, e := strconv.ParseFloat(string(k.value), bitSize64)
-			if e != nil {
-				return e
-			}
-			kb.PutNumber(k.name, v)
-		case appdef.DataKind_bytes, appdef.DataKind_string:
-			fallthrough
-		case appdef.DataKind_QName:
-			kb.PutChars(k.name, string(k.value))
-		default:
-			return errUnsupportedDataKind
+		if e := putKeyField(kb, k.name, f.DataKind(), k.value); e != nil {
+			return e
 		}
 	}
 
@@ -120,3 +101,31 @@ func readViewRecords(ctx context.Context, WSID istructs.WSID, viewRecordQName ap
 		return callback(&result{value: string(bb)})
 	})
 }
+
+func putKeyField(kb istructs.IKeyBuilder, name string, kind appdef.DataKind, value []byte) error {
+	switch kind {
+	case appdef.DataKind_int32:
+		fallthrough
+	case appdef.DataKind_int64:
+		fallthrough
+	case appdef.DataKind_float32:
+		fallthrough
+	case appdef.DataKind_float64:
+		fallthrough
+	case appdef.DataKind_RecordID:
+		v, e := strconv.ParseFloat(string(value), bitSize64)
+		if e != nil {
+			return e
+		}
+		kb.PutNumber(name, v)
+	case appdef.DataKind_bytes, appdef.DataKind_string:
+		fallthrough
+	case appdef.DataKind_decimal, appdef.DataKind_date, appdef.DataKind_timestamp, appdef.DataKind_UUID:
+		fallthrough
+	case appdef.DataKind_QName:
+		kb.PutChars(name, string(value))
+	default:
+		return errUnsupportedDataKind
+	}
+	return nil
+}
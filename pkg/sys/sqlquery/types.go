@@ -5,6 +5,9 @@
 package sqlquery
 
 import (
+	"github.com/blastrain/vitess-sqlparser/sqlparser"
+
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
@@ -32,3 +35,104 @@ type result struct {
 }
 
 func (o *result) AsString(string) string { return o.value }
+
+type selectQuery struct {
+	wsid       istructs.WSID
+	appStructs istructs.IAppStructs
+	sources    []*source
+	joins      []*join
+	whereExpr  sqlparser.Expr
+	where      evaluator
+	items      []*selectItem
+	groupBy    []evaluator
+	aggregated bool
+	orderBy    []*orderItem
+	offset     int
+	limit      int
+}
+
+// source is the table from FROM or JOIN clause
+type source struct {
+	alias  string
+	qName  appdef.QName
+	kind   appdef.TypeKind
+	fields appdef.IFields
+}
+
+// join binds target source by ID from the ref field of already bound source
+type join struct {
+	target int
+	ref    column
+}
+
+type column struct {
+	src   int
+	field string
+}
+
+// row contains field values of each source
+type row []map[string]interface{}
+
+type evaluator func(r row) (interface{}, error)
+
+type selectItem struct {
+	name string
+	expr sqlparser.Expr
+	eval evaluator
+	aggr *aggregate
+}
+
+type aggregate struct {
+	fn  string
+	arg evaluator // nil for COUNT(*)
+}
+
+type aggregator interface {
+	add(v interface{}) error
+	result() interface{}
+}
+
+type orderItem struct {
+	item int // index of select item or -1
+	eval evaluator
+	desc bool
+}
+
+// outRow contains select items values and ORDER BY keys
+type outRow struct {
+	values []interface{}
+	keys   []interface{}
+}
+
+type rowsSink interface {
+	put(out *outRow) error
+	flush() error
+}
+
+type group struct {
+	values []interface{}
+	aggrs  []aggregator
+}
+
+type grouper struct {
+	q      *selectQuery
+	groups map[string]*group
+	keys   []string
+	next   rowsSink
+}
+
+type sorter struct {
+	orderBy  []*orderItem
+	rows     []*outRow
+	capacity int
+	bounded  bool
+	next     rowsSink
+}
+
+type limiter struct {
+	offset  int
+	limit   int
+	skipped int
+	emitted int
+	emit    func(out *outRow) error
+}
@@ -176,7 +176,9 @@ ABSTRACT WORKSPACE Workspace (
 
 	TYPE CollectionParams (
 		Schema text NOT NULL,
-		ID int64
+		ID int64,
+		Index text, -- QName of the Schema document index to look up documents
+		IndexValues text -- JSON array of values of the leading index fields
 	);
 
 	TYPE GetCDocParams (
@@ -200,7 +202,8 @@ ABSTRACT WORKSPACE Workspace (
 	);
 
 	TYPE DescribePackageParams (
-		PackageName text NOT NULL
+		PackageName text NOT NULL,
+		Tags text -- comma separated qualified names of tags to filter types by
 	);
 
 	TYPE DescribePackageResult (
@@ -404,6 +407,20 @@ ABSTRACT WORKSPACE Workspace (
 		PRIMARY KEY ((QName, ValuesHash), Values) -- partitioning is not optimal, no better solution
 	) AS RESULT OF ApplyUniques;
 
+	VIEW Indexes (
+		IndexQName qname NOT NULL,
+		Values bytes(65535) NOT NULL, -- ordered index fields values + ID of the record
+		ID ref, -- NullRecordID if index fields values of the record are changed
+		PRIMARY KEY ((IndexQName), Values)
+	) AS RESULT OF ApplyIndexes;
+
+	VIEW IndexedRecords (
+		ID ref NOT NULL,
+		IndexQName qname NOT NULL,
+		Values bytes(65535) NOT NULL, -- last indexed fields values of the record
+		PRIMARY KEY ((ID), IndexQName)
+	) AS RESULT OF ApplyIndexes;
+
 	VIEW WorkspaceIDIdx (
 		OwnerWSID int64 NOT NULL,
 		WSName text NOT NULL,
@@ -497,6 +514,12 @@ ABSTRACT WORKSPACE Workspace (
 			AFTER EXECUTE WITH PARAM ON ODoc
 			INTENTS(View(Uniques));
 
+		-- indexes
+
+		SYNC PROJECTOR ApplyIndexes
+			AFTER INSERT OR UPDATE ON (CRecord, WRecord)
+			INTENTS(View(Indexes, IndexedRecords));
+
 		-- verifier
 
 		QUERY InitiateEmailVerification(InitiateEmailVerificationParams) RETURNS InitialEmailVerificationResult;
@@ -563,6 +586,10 @@ EXTENSION ENGINE BUILTIN (
 	);
 
 	STORAGE Result(
-		INSERT SCOPE(COMMANDS)
+		INSERT SCOPE(COMMANDS, QUERIES)
+	);
+
+	STORAGE ArgumentObject(
+		GET SCOPE(COMMANDS, QUERIES)
 	);
 );
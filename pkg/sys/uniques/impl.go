@@ -145,6 +145,18 @@ func buildUniqueViewKeyByValues(kb istructs.IKeyBuilder, qName appdef.QName, uni
 func getUniqueKeyValues(rec istructs.IRowReader, uniqueFields []appdef.IField, uniqueQName appdef.QName) (res []byte, err error) {
 	buf := bytes.NewBuffer(nil)
 	for _, uniqueField := range uniqueFields {
+		switch uniqueField.DataKind() {
+		case appdef.DataKind_decimal, appdef.DataKind_timestamp:
+			// stored values are written, rendered ones depend on field constraints
+			binary.Write(buf, binary.BigEndian, rec.AsInt64(uniqueField.Name())) // nolint
+			continue
+		case appdef.DataKind_date:
+			binary.Write(buf, binary.BigEndian, rec.AsInt32(uniqueField.Name())) // nolint
+			continue
+		case appdef.DataKind_UUID:
+			buf.Write(rec.AsBytes(uniqueField.Name()))
+			continue
+		}
 		val := coreutils.ReadByKind(uniqueField.Name(), uniqueField.DataKind(), rec)
 		switch uniqueField.DataKind() {
 		case appdef.DataKind_string:
@@ -495,6 +495,16 @@ func (resp *FuncResponse) NewID() int64 {
 	return resp.NewIDs["1"]
 }
 
+// returns the continuation token of the next page or empty string if the page is the last one
+func (resp *FuncResponse) Cursor() string {
+	for _, section := range resp.Sections {
+		if section.Type == "cursor" && len(section.Elements) > 0 {
+			return section.Elements[0][0][0][0].(string)
+		}
+	}
+	return ""
+}
+
 func (resp *FuncResponse) IsEmpty() bool {
 	return len(resp.Sections) == 0
 }
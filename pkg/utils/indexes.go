@@ -0,0 +1,302 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package coreutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+var (
+	// View which stores index rows: IndexQName -> Values + RecordID -> ID
+	QNameViewIndexes = appdef.NewQName(appdef.SysPackage, "Indexes")
+
+	// View which stores last indexed values of records: RecordID, IndexQName -> Values
+	QNameViewIndexedRecords = appdef.NewQName(appdef.SysPackage, "IndexedRecords")
+)
+
+const (
+	Field_IndexQName = "IndexQName"
+	Field_IndexID    = "ID"
+	Field_Values     = "Values"
+
+	indexStringTerminator = byte(0x01)
+	indexStringEscape     = byte(0xFF)
+)
+
+// Returns bytes of index fields values of specified row.
+//
+// Encoded values are ordered bytewise in the same way as values themselves,
+// so the bytes of the leading fields values are the prefix of the bytes of all fields values.
+func IndexValues(index appdef.IIndex, rr istructs.IRowReader) []byte {
+	buf := bytes.NewBuffer(nil)
+	for _, f := range index.Fields() {
+		n := f.Name()
+		switch f.DataKind() {
+		case appdef.DataKind_int32, appdef.DataKind_date:
+			writeIndexInt32(buf, rr.AsInt32(n))
+		case appdef.DataKind_int64, appdef.DataKind_decimal, appdef.DataKind_timestamp:
+			writeIndexInt64(buf, rr.AsInt64(n))
+		case appdef.DataKind_float32:
+			writeIndexFloat32(buf, rr.AsFloat32(n))
+		case appdef.DataKind_float64:
+			writeIndexFloat64(buf, rr.AsFloat64(n))
+		case appdef.DataKind_RecordID:
+			writeIndexUint64(buf, uint64(rr.AsRecordID(n)))
+		case appdef.DataKind_bool:
+			writeIndexBool(buf, rr.AsBool(n))
+		case appdef.DataKind_string:
+			writeIndexString(buf, rr.AsString(n))
+		case appdef.DataKind_QName:
+			writeIndexString(buf, rr.AsQName(n).String())
+		case appdef.DataKind_UUID:
+			buf.Write(rr.AsBytes(n))
+		}
+	}
+	return buf.Bytes()
+}
+
+// Returns bytes of specified values of leading index fields.
+//
+// Values are converted to fields data kinds, values are accepted as they are received from JSON:
+// numbers as float64, decimals as numbers or strings, dates, timestamps, UUIDs and QNames as strings.
+// Returns error if there are more values than index fields or some value can not be converted.
+func IndexValuesOf(index appdef.IIndex, values []interface{}) ([]byte, error) {
+	if len(values) > len(index.Fields()) {
+		return nil, fmt.Errorf("%v: %d values specified, but index has only %d fields: %w", index.Name(), len(values), len(index.Fields()), ErrFieldTypeMismatch)
+	}
+	buf := bytes.NewBuffer(nil)
+	for i, value := range values {
+		if err := writeIndexValue(buf, index.Fields()[i], value); err != nil {
+			return nil, fmt.Errorf("%v: %w", index.Name(), err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Reads IDs of records, which leading index fields values are equal to specified values, in index order.
+//
+// If no values specified, then all indexed records are read.
+func ReadByIndex(ctx context.Context, views istructs.IViewRecords, wsid istructs.WSID, index appdef.IIndex, values []interface{}, cb func(istructs.RecordID) error) error {
+	prefix, err := IndexValuesOf(index, values)
+	if err != nil {
+		return err
+	}
+	kb := views.KeyBuilder(QNameViewIndexes)
+	kb.PutQName(Field_IndexQName, index.Name())
+	if len(prefix) > 0 {
+		kb.PutBytes(Field_Values, prefix)
+	}
+	return views.Read(ctx, wsid, kb, func(_ istructs.IKey, value istructs.IValue) error {
+		return cb(value.AsRecordID(Field_IndexID))
+	})
+}
+
+func writeIndexValue(buf *bytes.Buffer, f appdef.IField, value interface{}) error {
+	mismatch := func() error {
+		return fmt.Errorf("value %v (%T) of field «%s» can not be converted to %s: %w", value, value, f.Name(), f.DataKind().TrimString(), ErrFieldTypeMismatch)
+	}
+	switch f.DataKind() {
+	case appdef.DataKind_int32, appdef.DataKind_date:
+		if s, ok := value.(string); ok && (f.DataKind() == appdef.DataKind_date) {
+			d, err := istructs.ParseDate(s)
+			if err != nil {
+				return err
+			}
+			writeIndexInt32(buf, d)
+			return nil
+		}
+		n, ok := indexInteger(value)
+		if !ok || (n < math.MinInt32) || (n > math.MaxInt32) {
+			return mismatch()
+		}
+		writeIndexInt32(buf, int32(n))
+	case appdef.DataKind_int64, appdef.DataKind_timestamp:
+		if s, ok := value.(string); ok && (f.DataKind() == appdef.DataKind_timestamp) {
+			ts, err := istructs.ParseTimestamp(s)
+			if err != nil {
+				return err
+			}
+			writeIndexInt64(buf, ts)
+			return nil
+		}
+		n, ok := indexInteger(value)
+		if !ok {
+			return mismatch()
+		}
+		writeIndexInt64(buf, n)
+	case appdef.DataKind_decimal:
+		_, scale := appdef.DecimalPrecisionScale(f.Constraints())
+		var (
+			v   int64
+			err error
+		)
+		switch val := value.(type) {
+		case string:
+			v, err = istructs.ParseDecimal(val, scale)
+		case float64:
+			v, err = istructs.DecimalFromFloat64(val, scale)
+		default:
+			n, ok := indexInteger(value)
+			if !ok {
+				return mismatch()
+			}
+			v, err = istructs.DecimalFromFloat64(float64(n), scale)
+		}
+		if err != nil {
+			return err
+		}
+		writeIndexInt64(buf, v)
+	case appdef.DataKind_float32:
+		switch val := value.(type) {
+		case float32:
+			writeIndexFloat32(buf, val)
+		case float64:
+			writeIndexFloat32(buf, float32(val))
+		default:
+			return mismatch()
+		}
+	case appdef.DataKind_float64:
+		switch val := value.(type) {
+		case float32:
+			writeIndexFloat64(buf, float64(val))
+		case float64:
+			writeIndexFloat64(buf, val)
+		default:
+			return mismatch()
+		}
+	case appdef.DataKind_RecordID:
+		n, ok := indexInteger(value)
+		if !ok || (n < 0) {
+			return mismatch()
+		}
+		writeIndexUint64(buf, uint64(n))
+	case appdef.DataKind_bool:
+		b, ok := value.(bool)
+		if !ok {
+			return mismatch()
+		}
+		writeIndexBool(buf, b)
+	case appdef.DataKind_string:
+		s, ok := value.(string)
+		if !ok {
+			return mismatch()
+		}
+		writeIndexString(buf, s)
+	case appdef.DataKind_QName:
+		switch val := value.(type) {
+		case string:
+			q, err := appdef.ParseQName(val)
+			if err != nil {
+				return err
+			}
+			writeIndexString(buf, q.String())
+		case appdef.QName:
+			writeIndexString(buf, val.String())
+		default:
+			return mismatch()
+		}
+	case appdef.DataKind_UUID:
+		switch val := value.(type) {
+		case string:
+			u, err := istructs.ParseUUID(val)
+			if err != nil {
+				return err
+			}
+			buf.Write(u)
+		case []byte:
+			if len(val) != istructs.UUIDLen {
+				return mismatch()
+			}
+			buf.Write(val)
+		default:
+			return mismatch()
+		}
+	default:
+		return mismatch()
+	}
+	return nil
+}
+
+// Returns integer from JSON number or Go integer value
+func indexInteger(value interface{}) (int64, bool) {
+	switch val := value.(type) {
+	case int32:
+		return int64(val), true
+	case int64:
+		return val, true
+	case int:
+		return int64(val), true
+	case istructs.RecordID:
+		if val > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(val), true
+	case float64:
+		if (val != math.Trunc(val)) || (val < math.MinInt64) || (val >= math.MaxInt64) {
+			return 0, false
+		}
+		return int64(val), true
+	}
+	return 0, false
+}
+
+func writeIndexInt32(buf *bytes.Buffer, v int32) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(v)^(1<<31))
+}
+
+func writeIndexInt64(buf *bytes.Buffer, v int64) {
+	writeIndexUint64(buf, uint64(v)^(1<<63))
+}
+
+func writeIndexUint64(buf *bytes.Buffer, v uint64) {
+	_ = binary.Write(buf, binary.BigEndian, v)
+}
+
+func writeIndexFloat32(buf *bytes.Buffer, v float32) {
+	b := math.Float32bits(v)
+	if b&(1<<31) != 0 {
+		b = ^b
+	} else {
+		b |= 1 << 31
+	}
+	_ = binary.Write(buf, binary.BigEndian, b)
+}
+
+func writeIndexFloat64(buf *bytes.Buffer, v float64) {
+	b := math.Float64bits(v)
+	if b&(1<<63) != 0 {
+		b = ^b
+	} else {
+		b |= 1 << 63
+	}
+	writeIndexUint64(buf, b)
+}
+
+func writeIndexBool(buf *bytes.Buffer, v bool) {
+	if v {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+}
+
+// Writes string bytes, zero bytes are escaped by 0x00 0xFF, string is terminated by 0x00 0x01
+func writeIndexString(buf *bytes.Buffer, s string) {
+	for i := 0; i < len(s); i++ {
+		buf.WriteByte(s[i])
+		if s[i] == 0 {
+			buf.WriteByte(indexStringEscape)
+		}
+	}
+	buf.WriteByte(0)
+	buf.WriteByte(indexStringTerminator)
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package coreutils
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestIndexValues(t *testing.T) {
+	require := require.New(t)
+
+	docName := appdef.NewQName("test", "doc")
+	idxName := appdef.NewQName("test", "idx")
+
+	appDef := appdef.New()
+	appDef.AddCDoc(docName).
+		AddField("int32", appdef.DataKind_int32, false).
+		AddField("float64", appdef.DataKind_float64, false).
+		AddField("string", appdef.DataKind_string, false).
+		AddField("amount", appdef.DataKind_decimal, false, appdef.Scale(2)).
+		AddField("date", appdef.DataKind_date, false).
+		AddField("uuid", appdef.DataKind_UUID, false).(appdef.ICDocBuilder).
+		AddIndex(idxName, []string{"int32", "float64", "string"}).
+		AddIndex(appdef.NewQName("test", "idx2"), []string{"amount", "date", "uuid"})
+	app, err := appDef.Build()
+	require.NoError(err)
+	idx := app.CDoc(docName).Index(idxName)
+
+	row := func(i int32, f float64, s string) istructs.IRowReader {
+		return &TestObject{Name: docName, Data: map[string]interface{}{"int32": i, "float64": f, "string": s}}
+	}
+
+	t.Run("should be ordered as values", func(t *testing.T) {
+		rows := []istructs.IRowReader{
+			row(math.MinInt32, 0, ""),
+			row(-1, math.Inf(-1), ""),
+			row(-1, -1.5, ""),
+			row(-1, -1, ""),
+			row(-1, 0, ""),
+			row(-1, 0.5, "a"),
+			row(-1, 0.5, "a\x00"),
+			row(-1, 0.5, "a\x00b"),
+			row(-1, 0.5, "ab"),
+			row(-1, 0.5, "b"),
+			row(0, 0, ""),
+			row(math.MaxInt32, 0, ""),
+		}
+		for i := 1; i < len(rows); i++ {
+			require.Negative(bytes.Compare(IndexValues(idx, rows[i-1]), IndexValues(idx, rows[i])), i)
+		}
+	})
+
+	t.Run("should be prefix if leading values specified", func(t *testing.T) {
+		all := IndexValues(idx, row(42, 3.14, "str"))
+
+		b, err := IndexValuesOf(idx, []interface{}{float64(42), 3.14, "str"})
+		require.NoError(err)
+		require.Equal(all, b)
+
+		b, err = IndexValuesOf(idx, []interface{}{int32(42)})
+		require.NoError(err)
+		require.True(bytes.HasPrefix(all, b))
+		require.Len(b, 4)
+
+		b, err = IndexValuesOf(idx, nil)
+		require.NoError(err)
+		require.Empty(b)
+	})
+
+	t.Run("should be ok to convert values from JSON", func(t *testing.T) {
+		idx2 := app.CDoc(docName).Index(appdef.NewQName("test", "idx2"))
+		uuid := []byte{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72, 0xa5, 0x67, 0x0e, 0x02, 0xb2, 0xc3, 0xd4, 0x79}
+		all := IndexValues(idx2, &TestObject{Name: docName, Data: map[string]interface{}{"amount": int64(1234), "date": int32(19753), "uuid": uuid}})
+
+		b, err := IndexValuesOf(idx2, []interface{}{12.34, "2024-01-31", "f47ac10b-58cc-4372-a567-0e02b2c3d479"})
+		require.NoError(err)
+		require.Equal(all, b)
+
+		b, err = IndexValuesOf(idx2, []interface{}{"12.34", float64(19753), uuid})
+		require.NoError(err)
+		require.Equal(all, b)
+	})
+
+	t.Run("should be error if values are invalid", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			values []interface{}
+		}{
+			{"too many values", []interface{}{1, 2.0, "3", 4}},
+			{"fractional integer", []interface{}{1.5}},
+			{"integer out of range", []interface{}{float64(math.MaxInt32 + 1)}},
+			{"string for integer", []interface{}{"1"}},
+			{"number for string", []interface{}{1, 2.0, 3}},
+		}
+		for _, tt := range tests {
+			_, err := IndexValuesOf(idx, tt.values)
+			require.ErrorIs(err, ErrFieldTypeMismatch, tt.name)
+		}
+	})
+}
@@ -34,6 +34,7 @@ func (m *MockCUDRow) ModifiedFields(cb func(fieldName string, newValue interface
 func (m *MockCUDRow) AsRecordID(name string) istructs.RecordID {
 	return m.Called(name).Get(0).(istructs.RecordID)
 }
+func (m *MockCUDRow) AsArray(name string, cb func(int, interface{})) { m.Called(name, cb) }
 func (m *MockCUDRow) RecordIDs(includeNulls bool, cb func(name string, value istructs.RecordID)) {
 	m.Called(includeNulls, cb)
 }
@@ -81,6 +82,7 @@ func (m *MockObject) FieldNames(cb func(fieldName string)) { m.Called(cb) }
 func (m *MockObject) AsRecordID(name string) istructs.RecordID {
 	return m.Called(name).Get(0).(istructs.RecordID)
 }
+func (m *MockObject) AsArray(name string, cb func(int, interface{})) { m.Called(name, cb) }
 func (m *MockObject) RecordIDs(includeNulls bool, cb func(name string, value istructs.RecordID)) {
 	m.Called(includeNulls, cb)
 }
@@ -229,6 +231,9 @@ func (m *MockStateValue) AsRecordID(name string) istructs.RecordID {
 	args := m.Called(name)
 	return args.Get(0).(istructs.RecordID)
 }
+func (m *MockStateValue) AsArray(name string, cb func(int, interface{})) {
+	m.Called(name, cb)
+}
 func (m *MockStateValue) RecordIDs(includeNulls bool, cb func(name string, value istructs.RecordID)) {
 	m.Called(includeNulls, cb)
 }
@@ -384,6 +389,7 @@ func (m *MockKey) FieldNames(cb func(fieldName string)) { m.Called(cb) }
 func (m *MockKey) AsRecordID(name string) istructs.RecordID {
 	return m.Called(name).Get(0).(istructs.RecordID)
 }
+func (m *MockKey) AsArray(name string, cb func(int, interface{})) { m.Called(name, cb) }
 func (m *MockKey) RecordIDs(includeNulls bool, cb func(name string, value istructs.RecordID)) {
 	m.Called(includeNulls, cb)
 }
@@ -403,6 +409,7 @@ func (m *MockValue) AsBool(name string) bool          { return m.Called(name).Ge
 func (m *MockValue) AsRecordID(name string) istructs.RecordID {
 	return m.Called(name).Get(0).(istructs.RecordID)
 }
+func (m *MockValue) AsArray(name string, cb func(int, interface{})) { m.Called(name, cb) }
 func (m *MockValue) RecordIDs(includeNulls bool, cb func(name string, value istructs.RecordID)) {
 	m.Called(includeNulls, cb)
 }
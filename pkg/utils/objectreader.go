@@ -32,11 +32,37 @@ func ReadByKind(name string, kind appdef.DataKind, rr istructs.IRowReader) inter
 		return rr.AsQName(name).String()
 	case appdef.DataKind_bool:
 		return rr.AsBool(name)
+	case appdef.DataKind_decimal:
+		return rr.AsString(name)
+	case appdef.DataKind_date:
+		return istructs.DateToString(rr.AsInt32(name))
+	case appdef.DataKind_timestamp:
+		return istructs.TimestampToString(rr.AsInt64(name))
+	case appdef.DataKind_UUID:
+		return istructs.UUIDToString(rr.AsBytes(name))
 	default:
 		panic("unsupported kind " + fmt.Sprint(kind) + " for field " + name)
 	}
 }
 
+// Returns items of array field. Items are converted like ReadByKind does: decimals, dates and timestamps to strings
+func ReadArray(fld appdef.IField, rr istructs.IRowReader) []interface{} {
+	res := []interface{}{}
+	_, scale := appdef.DecimalPrecisionScale(fld.Constraints())
+	rr.AsArray(fld.Name(), func(_ int, value interface{}) {
+		switch fld.DataKind() {
+		case appdef.DataKind_decimal:
+			value = istructs.DecimalToString(value.(int64), scale)
+		case appdef.DataKind_date:
+			value = istructs.DateToString(value.(int32))
+		case appdef.DataKind_timestamp:
+			value = istructs.TimestampToString(value.(int64))
+		}
+		res = append(res, value)
+	})
+	return res
+}
+
 type mapperOpts struct {
 	filter      func(name string, kind appdef.DataKind) bool
 	nonNilsOnly bool
@@ -70,13 +96,16 @@ func FieldsToMap(obj istructs.IRowReader, appDef appdef.IAppDef, optFuncs ...Map
 		optFunc(opts)
 	}
 
-	proceedField := func(fieldName string, kind appdef.DataKind) {
+	proceedField := func(fld appdef.IField) {
+		fieldName, kind := fld.Name(), fld.DataKind()
 		if opts.filter != nil {
 			if !opts.filter(fieldName, kind) {
 				return
 			}
 		}
-		if kind == appdef.DataKind_Record {
+		if fld.IsArray() {
+			res[fieldName] = ReadArray(fld, obj)
+		} else if kind == appdef.DataKind_Record {
 			if v, ok := obj.(istructs.IValue); ok {
 				res[fieldName] = FieldsToMap(v.AsRecord(fieldName), appDef, optFuncs...)
 			} else {
@@ -90,11 +119,11 @@ func FieldsToMap(obj istructs.IRowReader, appDef appdef.IAppDef, optFuncs ...Map
 	if fields, ok := t.(appdef.IFields); ok {
 		if opts.nonNilsOnly {
 			obj.FieldNames(func(fieldName string) {
-				proceedField(fieldName, fields.Field(fieldName).DataKind())
+				proceedField(fields.Field(fieldName))
 			})
 		} else {
 			for _, f := range fields.Fields() {
-				proceedField(f.Name(), f.DataKind())
+				proceedField(f)
 			}
 		}
 	}
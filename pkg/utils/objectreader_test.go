@@ -272,6 +272,49 @@ func TestReadValue(t *testing.T) {
 	})
 }
 
+func TestReadByKind_DecimalDateTimestampUUID(t *testing.T) {
+	require := require.New(t)
+	obj := &TestObject{
+		Name: testQName,
+		Data: map[string]interface{}{
+			"decimal":   "12.34",
+			"date":      int32(19753),
+			"timestamp": int64(1706702400500),
+			"uuid":      []byte{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72, 0xa5, 0x67, 0x0e, 0x02, 0xb2, 0xc3, 0xd4, 0x79},
+		},
+	}
+	require.Equal("12.34", ReadByKind("decimal", appdef.DataKind_decimal, obj))
+	require.Equal("2024-01-31", ReadByKind("date", appdef.DataKind_date, obj))
+	require.Equal("2024-01-31T12:00:00.500Z", ReadByKind("timestamp", appdef.DataKind_timestamp, obj))
+	require.Equal("f47ac10b-58cc-4372-a567-0e02b2c3d479", ReadByKind("uuid", appdef.DataKind_UUID, obj))
+}
+
+func TestFieldsToMap_Arrays(t *testing.T) {
+	require := require.New(t)
+
+	appDef := appdef.New()
+	appDef.AddObject(testQName).
+		AddArrayField("tags", appdef.DataKind_string, 0, appdef.Occurs_Unbounded).
+		AddArrayField("amounts", appdef.DataKind_decimal, 0, appdef.Occurs_Unbounded, appdef.Scale(2)).
+		AddArrayField("days", appdef.DataKind_date, 0, appdef.Occurs_Unbounded)
+	app, err := appDef.Build()
+	require.NoError(err)
+
+	obj := &TestObject{
+		Name: testQName,
+		Data: map[string]interface{}{
+			"tags":                   []interface{}{"new", "hot"},
+			"amounts":                []interface{}{int64(1234), int64(-5)},
+			"days":                   []interface{}{int32(19753)},
+			appdef.SystemField_QName: testQName,
+		},
+	}
+	m := FieldsToMap(obj, app, WithNonNilsOnly())
+	require.Equal([]interface{}{"new", "hot"}, m["tags"])
+	require.Equal([]interface{}{"12.34", "-0.05"}, m["amounts"])
+	require.Equal([]interface{}{"2024-01-31"}, m["days"])
+}
+
 func TestObjectReaderErrors(t *testing.T) {
 	require := require.New(t)
 	require.Panics(func() { ReadByKind("", appdef.DataKind_FakeLast, nil) })
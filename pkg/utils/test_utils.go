@@ -118,6 +118,13 @@ func (o *TestObject) AsRecordID(name string) istructs.RecordID {
 	}
 	return istructs.NullRecordID
 }
+func (o *TestObject) AsArray(name string, cb func(int, interface{})) {
+	if items, ok := o.Data[name].([]interface{}); ok {
+		for i, item := range items {
+			cb(i, item)
+		}
+	}
+}
 func (o *TestObject) Children(container string, cb func(istructs.IObject)) {
 	iterate := func(cc []*TestObject) {
 		for _, c := range cc {
@@ -34,6 +34,7 @@ type ReqOptFunc func(opts *reqOpts)
 type FuncResponse struct {
 	*HTTPResponse
 	Sections []struct {
+		Type     string              `json:"type"`
 		Elements [][][][]interface{} `json:"elements"`
 	} `json:"sections"`
 	NewIDs            map[string]int64
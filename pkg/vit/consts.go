@@ -21,6 +21,12 @@ const (
 	allowedGoroutinesNumDiff     = 200
 	field_Input                  = "Input"
 	testEmailsAwaitingTimeout    = 5 * time.Second
+	mockIdPKID                   = "mockidp"
+	mockIdPAlg                   = "RS256"
+	mockIdPIDTokenExpiration     = 10 * time.Minute
+	mockIdPDiscoveryPath         = "/.well-known/openid-configuration"
+	mockIdPTokenPath             = "/token"
+	mockIdPJWKSPath              = "/jwks"
 )
 
 var (
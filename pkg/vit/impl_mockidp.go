@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package vit
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/registry"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// Starts OpenID Connect identity provider which issues ID tokens at VIT time
+//
+// Authorization endpoint is not implemented: authorization codes are issued by IssueCode
+func NewMockIdP(clientID, clientSecret string) *MockIdP {
+	key, err := itokensjwt.GenerateSigningKey(mockIdPKID, mockIdPAlg)
+	if err != nil {
+		panic(err)
+	}
+	keyRing, err := itokensjwt.NewKeyRing(func() time.Time { return ts.now() }, key)
+	if err != nil {
+		panic(err)
+	}
+	jwks, err := keyRing.JWKSJSON()
+	if err != nil {
+		panic(err)
+	}
+	idp := &MockIdP{
+		key:          key,
+		method:       jwt.GetSigningMethod(mockIdPAlg),
+		jwks:         jwks,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		codes:        map[string]mockIdPCode{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(mockIdPDiscoveryPath, idp.handleDiscovery)
+	mux.HandleFunc(mockIdPTokenPath, idp.handleToken)
+	mux.HandleFunc(mockIdPJWKSPath, idp.handleJWKS)
+	idp.server = httptest.NewServer(mux)
+	return idp
+}
+
+// Returns registry provider config. Endpoints are discovered by the issuer
+func (idp *MockIdP) Provider() registry.OIDCProvider {
+	return registry.OIDCProvider{
+		Issuer:       idp.server.URL,
+		ClientID:     idp.clientID,
+		ClientSecret: idp.clientSecret,
+	}
+}
+
+// Authenticates the user and returns authorization code to be exchanged for ID token once
+func (idp *MockIdP) IssueCode(auth MockIdPAuth) string {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	code := "code" + strconv.Itoa(len(idp.codes)+1)
+	idp.codes[code] = mockIdPCode{MockIdPAuth: auth}
+	return code
+}
+
+func (idp *MockIdP) Close() {
+	idp.server.Close()
+}
+
+// Returns S256 PKCE code challenge for the code verifier
+func CodeChallengeS256(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func (idp *MockIdP) handleDiscovery(rw http.ResponseWriter, _ *http.Request) {
+	writeMockIdPJSON(rw, http.StatusOK, map[string]interface{}{
+		"issuer":         idp.server.URL,
+		"token_endpoint": idp.server.URL + mockIdPTokenPath,
+		"jwks_uri":       idp.server.URL + mockIdPJWKSPath,
+	})
+}
+
+func (idp *MockIdP) handleJWKS(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set(coreutils.ContentType, coreutils.ApplicationJSON)
+	_, _ = rw.Write(idp.jwks)
+}
+
+func (idp *MockIdP) handleToken(rw http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		writeMockIdPError(rw, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	clientID, clientSecret, _ := req.BasicAuth()
+	clientID, _ = url.QueryUnescape(clientID)
+	clientSecret, _ = url.QueryUnescape(clientSecret)
+	if clientID != idp.clientID || clientSecret != idp.clientSecret {
+		writeMockIdPError(rw, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+	if req.PostForm.Get("grant_type") != "authorization_code" {
+		writeMockIdPError(rw, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	idp.mu.Lock()
+	code, ok := idp.codes[req.PostForm.Get("code")]
+	if ok && !code.used {
+		code.used = true
+		idp.codes[req.PostForm.Get("code")] = code
+	} else {
+		ok = false
+	}
+	idp.mu.Unlock()
+
+	if !ok || code.RedirectURI != req.PostForm.Get("redirect_uri") ||
+		len(code.CodeChallenge) > 0 && code.CodeChallenge != CodeChallengeS256(req.PostForm.Get("code_verifier")) {
+		writeMockIdPError(rw, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	now := ts.now()
+	claims := jwt.MapClaims{
+		"iss":            idp.server.URL,
+		"sub":            code.Subject,
+		"aud":            idp.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(mockIdPIDTokenExpiration).Unix(),
+		"email":          code.Email,
+		"email_verified": code.EmailVerified,
+	}
+	if len(code.Nonce) > 0 {
+		claims["nonce"] = code.Nonce
+	}
+	token := jwt.NewWithClaims(idp.method, claims)
+	token.Header["kid"] = idp.key.KID
+	idToken, err := token.SignedString(idp.key.PrivateKey)
+	if err != nil {
+		writeMockIdPError(rw, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeMockIdPJSON(rw, http.StatusOK, map[string]interface{}{
+		"access_token": "access" + req.PostForm.Get("code"),
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func writeMockIdPError(rw http.ResponseWriter, statusCode int, oauthErr string) {
+	writeMockIdPJSON(rw, statusCode, map[string]interface{}{"error": oauthErr})
+}
+
+func writeMockIdPJSON(rw http.ResponseWriter, statusCode int, data map[string]interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		// notest
+		panic(fmt.Sprint("failed to marshal mock IdP response: ", err))
+	}
+	rw.Header().Set(coreutils.ContentType, coreutils.ApplicationJSON)
+	rw.WriteHeader(statusCode)
+	_, _ = rw.Write(body)
+}
@@ -7,6 +7,7 @@ package vit
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/apps"
@@ -17,6 +18,7 @@ import (
 	"github.com/voedger/voedger/pkg/extensionpoints"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/registry"
 	"github.com/voedger/voedger/pkg/sys/smtp"
 	"github.com/voedger/voedger/pkg/sys/workspace"
 	coreutils "github.com/voedger/voedger/pkg/utils"
@@ -26,7 +28,10 @@ import (
 func NewOwnVITConfig(opts ...vitConfigOptFunc) VITConfig {
 	// helper: implicitly append sys apps
 	opts = append(opts,
-		WithApp(istructs.AppQName_sys_registry, registryapp.Provide(smtp.Cfg{})),
+		// last to use OpenID Connect providers declared by previous options
+		func(vpc *vitPreConfig) {
+			WithApp(istructs.AppQName_sys_registry, registryapp.Provide(smtp.Cfg{}, vpc.oidcProviders))(vpc)
+		},
 		WithApp(istructs.AppQName_sys_blobber, blobberapp.Provide(smtp.Cfg{})),
 		WithApp(istructs.AppQName_sys_router, routerapp.Provide(smtp.Cfg{})),
 	)
@@ -45,6 +50,16 @@ func WithBuilder(builder apps.AppBuilder) AppOptFunc {
 	}
 }
 
+// Deploys application with WASM module of specified package
+func WithExtModule(pkgName string, moduleURL *url.URL) AppOptFunc {
+	return func(app *app, _ *vvm.VVMConfig) {
+		if app.deployment.ExtModuleURLs == nil {
+			app.deployment.ExtModuleURLs = map[string]*url.URL{}
+		}
+		app.deployment.ExtModuleURLs[pkgName] = moduleURL
+	}
+}
+
 // at MainCluster
 func WithUserLogin(name, pwd string, opts ...PostConstructFunc) AppOptFunc {
 	return func(app *app, _ *vvm.VVMConfig) {
@@ -160,6 +175,16 @@ func WithInit(initFunc func()) vitConfigOptFunc {
 	}
 }
 
+// Allows sign in to registry by the OpenID Connect provider
+func WithOIDCProvider(name string, provider registry.OIDCProvider) vitConfigOptFunc {
+	return func(vpc *vitPreConfig) {
+		if vpc.oidcProviders == nil {
+			vpc.oidcProviders = registry.OIDCProviders{}
+		}
+		vpc.oidcProviders[name] = provider
+	}
+}
+
 func WithApp(appQName istructs.AppQName, updater apps.AppBuilder, appOpts ...AppOptFunc) vitConfigOptFunc {
 	return func(vpc *vitPreConfig) {
 		_, ok := vpc.vitApps[appQName]
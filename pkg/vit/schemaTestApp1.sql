@@ -429,6 +429,14 @@ ALTERABLE WORKSPACE test_wsWS (
 		UNIQUEFIELD Int
 	);
 
+	TABLE DocIndexed INHERITS CDoc (
+		Name varchar NOT NULL,
+		Country int32,
+		Rating float64
+	);
+
+	INDEX DocIndexedByCountry ON DocIndexed(Country, Name);
+
 	TABLE Config INHERITS Singleton (
 		Fld1 varchar NOT NULL
 	);
@@ -3,6 +3,8 @@
 
 APPLICATION app2();
 
+TAG Backoffice;
+
 ALTERABLE WORKSPACE test_wsWS (
 	DESCRIPTOR test_ws (
 		IntFld int32 NOT NULL,
@@ -12,6 +14,6 @@ ALTERABLE WORKSPACE test_wsWS (
 	TABLE doc1 INHERITS CDoc();
 
 	EXTENSION ENGINE BUILTIN (
-		COMMAND testCmd();
+		COMMAND testCmd() WITH Tags=(Backoffice);
 	);
 );
@@ -5,14 +5,18 @@
 package vit
 
 import (
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/extensionpoints"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/registry"
 	"github.com/voedger/voedger/pkg/state/smtptest"
 	coreutils "github.com/voedger/voedger/pkg/utils"
 	"github.com/voedger/voedger/pkg/vvm"
@@ -48,10 +52,11 @@ type VITConfig struct {
 type vitApps map[istructs.AppQName]*app // указатель потому, что к app потом будут опции применяться ([]logins, например)
 
 type vitPreConfig struct {
-	vvmCfg    *vvm.VVMConfig
-	vitApps   vitApps
-	cleanups  []func(vit *VIT)
-	initFuncs []func()
+	vvmCfg        *vvm.VVMConfig
+	vitApps       vitApps
+	cleanups      []func(vit *VIT)
+	initFuncs     []func()
+	oidcProviders registry.OIDCProviders
 }
 
 type vitConfigOptFunc func(*vitPreConfig)
@@ -144,6 +149,33 @@ type signUpOpts struct {
 
 type emailCaptor chan smtptest.Message
 
+// OpenID Connect identity provider for tests
+type MockIdP struct {
+	server       *httptest.Server
+	key          itokensjwt.SigningKey
+	method       jwt.SigningMethod
+	jwks         []byte
+	clientID     string
+	clientSecret string
+	mu           sync.Mutex
+	codes        map[string]mockIdPCode
+}
+
+// User authentication at MockIdP
+type MockIdPAuth struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Nonce         string
+	RedirectURI   string
+	CodeChallenge string // S256 PKCE code challenge, optional
+}
+
+type mockIdPCode struct {
+	MockIdPAuth
+	used bool
+}
+
 type SubscriptionParameters interface {
 	GetWSID() istructs.WSID
 	GetAppQName() istructs.AppQName
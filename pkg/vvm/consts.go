@@ -44,3 +44,10 @@ var (
 		KeyspaceWithReplication: istoragecas2.SimpleWithReplication,
 	}
 )
+
+// Path of the projector rebuild admin API served by the router:
+//   - POST starts rebuild of the async projector
+//   - GET returns progress of the rebuild
+//
+// Query parameters are `app` (e.g. `untill/airsbp`) and `projector` (e.g. `air.UpdateStats`)
+const RebuildProjectorPath = "/admin/projectors/rebuild"
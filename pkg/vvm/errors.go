@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package vvm
+
+import "errors"
+
+var errSystemPrincipalTokenRequired = errors.New("system principal token required")
@@ -36,6 +36,7 @@ func NewVVMDefaultConfig() VVMConfig {
 		RouterWriteTimeout:     router.DefaultRouterWriteTimeout, // same
 		RouterReadTimeout:      router.DefaultRouterWriteTimeout, // same
 		RouterConnectionsLimit: router.DefaultRouterConnectionsLimit,
+		RouterWSCallsLimit:     router.DefaultWSCallsLimit,
 		BLOBMaxSize:            DefaultBLOBMaxSize,
 		TimeFunc:               DefaultTimeFunc,
 		Name:                   commandprocessor.VVMName(hostname),
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package vvm
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/untillpro/goutils/logger"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/projectors"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+// Request must be authorized by the system principal token of the application
+func provideRebuildHandler(rebuilder projectors.IRebuilder, appTokensFactory payloads.IAppTokensFactory) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		app, err := istructs.ParseAppQName(r.URL.Query().Get("app"))
+		if err != nil {
+			http.Error(rw, "app: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if status, err := authorizeSystemPrincipal(r, appTokensFactory.New(app)); err != nil {
+			http.Error(rw, err.Error(), status)
+			return
+		}
+		projector, err := appdef.ParseQName(r.URL.Query().Get("projector"))
+		if err != nil {
+			http.Error(rw, "projector: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		status := http.StatusOK
+		switch r.Method {
+		case http.MethodPost:
+			if err = rebuilder.Rebuild(r.Context(), app, projector); err != nil {
+				replyRebuildError(rw, err)
+				return
+			}
+			logger.Info("rebuild of projector", projector, "of app", app, "is started")
+			status = http.StatusAccepted
+		case http.MethodGet:
+		default:
+			http.Error(rw, "405 method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		progress, err := rebuilder.Progress(app, projector)
+		if err != nil {
+			replyRebuildError(rw, err)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(status)
+		if err := json.NewEncoder(rw).Encode(progress); err != nil {
+			logger.Error("admin API: failed to reply projector rebuild progress: ", err)
+		}
+	}
+}
+
+func replyRebuildError(rw http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, projectors.ErrProjectorNotFound), errors.Is(err, projectors.ErrRebuildNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, projectors.ErrRebuildInProgress):
+		status = http.StatusConflict
+	}
+	http.Error(rw, err.Error(), status)
+}
+
+func authorizeSystemPrincipal(r *http.Request, appTokens istructs.IAppTokens) (status int, err error) {
+	authHeader := r.Header.Get(coreutils.Authorization)
+	if !strings.HasPrefix(authHeader, coreutils.BearerPrefix) {
+		return http.StatusUnauthorized, errSystemPrincipalTokenRequired
+	}
+	principal, err := payloads.GetPrincipalPayload(appTokens, strings.TrimPrefix(authHeader, coreutils.BearerPrefix))
+	if err != nil {
+		return http.StatusUnauthorized, err
+	}
+	if principal.ProfileWSID != istructs.NullWSID {
+		return http.StatusForbidden, errSystemPrincipalTokenRequired
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package vvm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/projectors"
+	coreutils "github.com/voedger/voedger/pkg/utils"
+)
+
+type testRebuilder struct {
+	rebuilt []appdef.QName
+}
+
+func (r *testRebuilder) Rebuild(_ context.Context, _ istructs.AppQName, projector appdef.QName) error {
+	if projector.Entity() == "Busy" {
+		return projectors.ErrRebuildInProgress
+	}
+	r.rebuilt = append(r.rebuilt, projector)
+	return nil
+}
+
+func (r *testRebuilder) Progress(app istructs.AppQName, projector appdef.QName) (projectors.RebuildProgress, error) {
+	for _, p := range r.rebuilt {
+		if p == projector {
+			return projectors.RebuildProgress{
+				App:        app,
+				Projector:  projector,
+				Partitions: []projectors.PartitionRebuildProgress{{Partition: 1, Status: projectors.RebuildStatus_Done, Offset: 42}},
+			}, nil
+		}
+	}
+	return projectors.RebuildProgress{}, projectors.ErrRebuildNotFound
+}
+
+func TestRebuildHandler(t *testing.T) {
+	require := require.New(t)
+
+	rebuilder := &testRebuilder{}
+	appTokensFactory := payloads.ProvideIAppTokensFactory(itokensjwt.ProvideITokens(itokensjwt.SecretKeyExample, time.Now))
+	handler := provideRebuildHandler(rebuilder, appTokensFactory)
+
+	systemToken, err := payloads.GetSystemPrincipalTokenApp(appTokensFactory.New(istructs.AppQName_test1_app1))
+	require.NoError(err)
+
+	requestWithToken := func(method, query, token string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(method, RebuildProjectorPath+"?"+query, nil)
+		if len(token) > 0 {
+			req.Header.Set(coreutils.Authorization, coreutils.BearerPrefix+token)
+		}
+		handler(rw, req)
+		return rw
+	}
+	request := func(method, query string) *httptest.ResponseRecorder {
+		return requestWithToken(method, query, systemToken)
+	}
+
+	t.Run("must be ok to start rebuild and get progress", func(t *testing.T) {
+		rw := request(http.MethodPost, "app=test1/app1&projector=test.Proj")
+		require.Equal(http.StatusAccepted, rw.Code)
+
+		rw = request(http.MethodGet, "app=test1/app1&projector=test.Proj")
+		require.Equal(http.StatusOK, rw.Code)
+
+		progress := projectors.RebuildProgress{}
+		require.NoError(json.Unmarshal(rw.Body.Bytes(), &progress))
+		require.Equal(istructs.AppQName_test1_app1, progress.App)
+		require.Equal(appdef.NewQName("test", "Proj"), progress.Projector)
+		require.True(progress.Done())
+		require.EqualValues(42, progress.Partitions[0].Offset)
+	})
+
+	t.Run("must be error", func(t *testing.T) {
+		require.Equal(http.StatusBadRequest, request(http.MethodGet, "app=wrong&projector=test.Proj").Code)
+		require.Equal(http.StatusBadRequest, request(http.MethodGet, "app=test1/app1&projector=wrong").Code)
+		require.Equal(http.StatusNotFound, request(http.MethodGet, "app=test1/app1&projector=test.Unknown").Code)
+		require.Equal(http.StatusConflict, request(http.MethodPost, "app=test1/app1&projector=test.Busy").Code)
+		require.Equal(http.StatusMethodNotAllowed, request(http.MethodDelete, "app=test1/app1&projector=test.Proj").Code)
+	})
+
+	t.Run("must be authorized by system principal token of the application", func(t *testing.T) {
+		require.Equal(http.StatusUnauthorized, requestWithToken(http.MethodPost, "app=test1/app1&projector=test.Proj", "").Code)
+		require.Equal(http.StatusUnauthorized, requestWithToken(http.MethodPost, "app=test1/app1&projector=test.Proj", "wrong").Code)
+		require.Equal(http.StatusUnauthorized, requestWithToken(http.MethodPost, "app=test1/app2&projector=test.Proj", systemToken).Code)
+
+		userToken, err := appTokensFactory.New(istructs.AppQName_test1_app1).IssueToken(time.Minute, &payloads.PrincipalPayload{
+			Login:       "user",
+			SubjectKind: istructs.SubjectKind_User,
+			ProfileWSID: 42,
+		})
+		require.NoError(err)
+		require.Equal(http.StatusForbidden, requestWithToken(http.MethodPost, "app=test1/app1&projector=test.Proj", userToken).Code)
+	})
+}
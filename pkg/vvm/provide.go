@@ -10,6 +10,7 @@ package vvm
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -23,6 +24,8 @@ import (
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/apppartsctl"
 	builtinapps "github.com/voedger/voedger/pkg/cluster/builtin"
+	"github.com/voedger/voedger/pkg/iextengine"
+	iextenginewasm "github.com/voedger/voedger/pkg/iextenginewazero"
 	"github.com/voedger/voedger/pkg/router"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -43,6 +46,7 @@ import (
 	"github.com/voedger/voedger/pkg/istoragecache"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
 	"github.com/voedger/voedger/pkg/itokensjwt"
 	imetrics "github.com/voedger/voedger/pkg/metrics"
@@ -136,7 +140,7 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 		provideRouterAppStorage,
 		provideIFederation,
 		provideCachingAppStorageProvider,  // IAppStorageProvider
-		itokensjwt.ProvideITokens,         // ITokens
+		provideITokens,                    // ITokens
 		istructsmem.Provide,               // IAppStructsProvider
 		payloads.ProvideIAppTokensFactory, // IAppTokensFactory
 		in10nmem.ProvideEx2,
@@ -147,6 +151,7 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 		imetrics.Provide,
 		projectors.ProvideSyncActualizerFactory,
 		projectors.ProvideAsyncActualizerFactory,
+		projectors.ProvideRebuilder,
 		iprocbusmem.Provide,
 		provideRouterServices,
 		provideMetricsServiceOperator,
@@ -164,8 +169,8 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 		provideIAppStorageUncachingProviderFactory,
 		provideAppPartsCtlPipelineService,
 		apppartsctl.New,
-		appparts.New,
-		builtinapps.Apps,
+		provideAppPartitions,
+		provideBuiltInApps,
 		// wire.Value(vvmConfig.NumCommandProcessors) -> (wire bug?) value github.com/untillpro/airs-bp3/vvm.CommandProcessorsCount can't be used: vvmConfig is not declared in package scope
 		wire.FieldsOf(&vvmConfig,
 			"NumCommandProcessors",
@@ -186,6 +191,14 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 	))
 }
 
+func provideBuiltInApps(vvmCfg *VVMConfig) []apppartsctl.BuiltInApp {
+	apps := builtinapps.Apps()
+	for i, app := range apps {
+		apps[i].ExtModuleURLs = vvmCfg.ExtModuleURLs[app.Name]
+	}
+	return apps
+}
+
 func provideAppPartsCtlPipelineService(ctl apppartsctl.IAppPartitionsController) IAppPartsCtlPipelineService {
 	return &AppPartsCtlPipelineService{IAppPartitionsController: ctl}
 }
@@ -196,8 +209,16 @@ func provideIAppStorageUncachingProviderFactory(factory istorage.IAppStorageFact
 	}
 }
 
-func provideStorageFactory(vvmConfig *VVMConfig) (provider istorage.IAppStorageFactory, err error) {
-	return vvmConfig.StorageFactory()
+func provideStorageFactory(vvmConfig *VVMConfig) (provider istorage.IAppStorageFactory, cleanup func(), err error) {
+	if provider, err = vvmConfig.StorageFactory(); err != nil {
+		return nil, nil, err
+	}
+	cleanup = func() {
+		if stoppable, ok := provider.(istorage.IStoppable); ok {
+			stoppable.StopGoroutines()
+		}
+	}
+	return provider, cleanup, nil
 }
 
 func provideSubjectGetterFunc() iauthnzimpl.SubjectGetterFunc {
@@ -244,6 +265,13 @@ func provideSecretKeyJWT(sr isecrets.ISecretReader) (itokensjwt.SecretKeyType, e
 	return sr.ReadSecret(itokensjwt.SecretKeyJWTName)
 }
 
+func provideITokens(secretKey itokensjwt.SecretKeyType, cfg *VVMConfig, timeFunc coreutils.TimeFunc) itokens.ITokens {
+	if cfg.JWTKeyRing != nil {
+		return itokensjwt.ProvideITokensWithKeyRing(secretKey, cfg.JWTKeyRing, cfg.JWTAcceptHS256, timeFunc)
+	}
+	return itokensjwt.ProvideITokens(secretKey, timeFunc)
+}
+
 func provideAppsWSAmounts(vvmApps VVMApps, asp istructs.IAppStructsProvider) map[istructs.AppQName]istructs.AppWSAmount {
 	res := map[istructs.AppQName]istructs.AppWSAmount{}
 	for _, appQName := range vvmApps {
@@ -297,17 +325,24 @@ func provideMetricsServicePortGetter(ms metrics.MetricsService) func() metrics.M
 	}
 }
 
-func provideRouterParams(cfg *VVMConfig, port VVMPortType, vvmIdx VVMIdxType) router.RouterParams {
+func provideRouterParams(cfg *VVMConfig, port VVMPortType, vvmIdx VVMIdxType, rebuilder projectors.IRebuilder, appTokensFactory payloads.IAppTokensFactory) router.RouterParams {
 	res := router.RouterParams{
+		AdminHandlers: map[string]http.Handler{
+			RebuildProjectorPath: provideRebuildHandler(rebuilder, appTokensFactory),
+		},
 		WriteTimeout:         cfg.RouterWriteTimeout,
 		ReadTimeout:          cfg.RouterReadTimeout,
 		ConnectionsLimit:     cfg.RouterConnectionsLimit,
+		WSCallsLimit:         cfg.RouterWSCallsLimit,
 		HTTP01ChallengeHosts: cfg.RouterHTTP01ChallengeHosts,
 		RouteDefault:         cfg.RouteDefault,
 		Routes:               cfg.Routes,
 		RoutesRewrite:        cfg.RoutesRewrite,
 		RouteDomains:         cfg.RouteDomains,
 	}
+	if cfg.JWTKeyRing != nil {
+		res.JWKS = cfg.JWTKeyRing.JWKSJSON
+	}
 	if port != 0 {
 		res.Port = int(port) + int(vvmIdx)
 	}
@@ -361,6 +396,29 @@ func provideChannelGroups(cfg *VVMConfig) (res []iprocbusmem.ChannelGroup) {
 	return
 }
 
+func provideAppPartitions(vvmCtx context.Context, vvmCfg *VVMConfig, asp istructs.IAppStructsProvider, metrics imetrics.IMetrics,
+	vvmName commandprocessor.VVMName) (ap appparts.IAppPartitions, cleanup func(), err error) {
+	wasmFactory := iextenginewasm.ProvideExtensionEngineFactory(true)
+	extEngineFactories := iextengine.IExtensionEngineFactories{
+		appdef.ExtensionEngineKind_WASM: wasmFactory,
+	}
+	extConfig := &iextengine.ExtEngineConfig{
+		InstancesPerPackage: vvmCfg.ExtEngineInstancesPerPackage,
+		CompilationCacheDir: vvmCfg.ExtEngineCompilationCacheDir,
+		Metrics:             metrics,
+		VvmName:             string(vvmName),
+	}
+	ap, appPartsCleanup, err := appparts.NewWithExtEngines(vvmCtx, asp, extEngineFactories, extConfig)
+	if err != nil {
+		wasmFactory.Close(vvmCtx)
+		return nil, nil, err
+	}
+	return ap, func() {
+		appPartsCleanup()
+		wasmFactory.Close(vvmCtx)
+	}, nil
+}
+
 func provideCachingAppStorageProvider(vvmCfg *VVMConfig, storageCacheSize StorageCacheSizeType, metrics imetrics.IMetrics,
 	vvmName commandprocessor.VVMName, uncachingProivder IAppStorageUncachingProviderFactory) (istorage.IAppStorageProvider, error) {
 	aspNonCaching := uncachingProivder()
@@ -398,13 +456,7 @@ func provideSyncActualizerFactory(vvmApps VVMApps, structsProvider istructs.IApp
 				WorkToEvent: func(work interface{}) istructs.IPLogEvent {
 					return work.(interface{ Event() istructs.IPLogEvent }).Event()
 				},
-				N10nFunc: func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset) {
-					n10nBroker.Update(in10n.ProjectionKey{
-						App:        appStructs.AppQName(),
-						Projection: view,
-						WS:         wsid,
-					}, offset)
-				},
+				N10nFunc:     projectors.ProvideN10nFunc(n10nBroker, appStructs.AppQName()),
 				IntentsLimit: builtin.MaxCUDs,
 			}
 			actualizer := actualizerFactory(conf, appStructs.SyncProjectors()[0], appStructs.SyncProjectors()[1:]...)
@@ -498,7 +550,7 @@ func provideCommandProcessors(cpCount coreutils.CommandProcessorsCount, ccf Comm
 	return pipeline.ForkOperator(pipeline.ForkSame, forks[0], forks[1:]...)
 }
 
-func provideAsyncActualizersFactory(appStructsProvider istructs.IAppStructsProvider, n10nBroker in10n.IN10nBroker, asyncActualizerFactory projectors.AsyncActualizerFactory, secretReader isecrets.ISecretReader, metrics imetrics.IMetrics) AsyncActualizersFactory {
+func provideAsyncActualizersFactory(appStructsProvider istructs.IAppStructsProvider, n10nBroker in10n.IN10nBroker, asyncActualizerFactory projectors.AsyncActualizerFactory, secretReader isecrets.ISecretReader, metrics imetrics.IMetrics, rebuilder projectors.IRebuilder) AsyncActualizersFactory {
 	return func(vvmCtx context.Context, appQName istructs.AppQName, asyncProjectorFactories AsyncProjectorFactories, partitionID istructs.PartitionID, opts []state.ActualizerStateOptFunc) pipeline.ISyncOperator {
 		var asyncProjectors []pipeline.ForkOperatorOptionFunc
 		appStructs, err := appStructsProvider.AppStructs(appQName)
@@ -518,6 +570,7 @@ func provideAsyncActualizersFactory(appStructsProvider istructs.IAppStructsProvi
 			IntentsLimit:  builtin.MaxCUDs,
 			FlushInterval: actualizerFlushInterval,
 			Metrics:       metrics,
+			Rebuilder:     rebuilder,
 		}
 
 		asyncProjectors = make([]pipeline.ForkOperatorOptionFunc, len(asyncProjectorFactories))
@@ -22,6 +22,7 @@ import (
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/itokensjwt"
 	"github.com/voedger/voedger/pkg/pipeline"
 	commandprocessor "github.com/voedger/voedger/pkg/processors/command"
 	"github.com/voedger/voedger/pkg/router"
@@ -114,6 +115,7 @@ type VVMConfig struct {
 	RouterWriteTimeout         int
 	RouterReadTimeout          int
 	RouterConnectionsLimit     int
+	RouterWSCallsLimit         int
 	RouterHTTP01ChallengeHosts []string
 	RouteDefault               string
 	Routes                     map[string]string
@@ -138,6 +140,17 @@ type VVMConfig struct {
 	FederationURL       *url.URL
 	ActualizerStateOpts []state.ActualizerStateOptFunc
 	SecretsReader       isecrets.ISecretReader
+	// nil -> principal tokens are signed by HS256 with secretKeyJWT
+	// not nil -> principal tokens are signed by the key ring keys, JWKS is served by the router
+	JWTKeyRing *itokensjwt.KeyRing
+	// true -> HS256 tokens signed with secretKeyJWT are still accepted if JWTKeyRing is set, to migrate previously issued tokens
+	JWTAcceptHS256 bool
+	// 0 -> one warm instance of each WASM package per extension engine
+	ExtEngineInstancesPerPackage uint
+	// empty -> compiled WASM modules are cached in memory only
+	ExtEngineCompilationCacheDir string
+	// URLs of WASM modules of built-in applications by application and package names
+	ExtModuleURLs map[istructs.AppQName]map[string]*url.URL
 }
 
 type resultSenderErrorFirst struct {
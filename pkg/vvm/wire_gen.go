@@ -8,6 +8,7 @@ package vvm
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -22,6 +23,8 @@ import (
 	"github.com/voedger/voedger/pkg/iauthnz"
 	"github.com/voedger/voedger/pkg/iauthnzimpl"
 	"github.com/voedger/voedger/pkg/iblobstoragestg"
+	"github.com/voedger/voedger/pkg/iextengine"
+	iextenginewasm "github.com/voedger/voedger/pkg/iextenginewazero"
 	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/in10nmem"
 	"github.com/voedger/voedger/pkg/iprocbus"
@@ -34,6 +37,7 @@ import (
 	"github.com/voedger/voedger/pkg/istoragecache"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
 	"github.com/voedger/voedger/pkg/itokens-payloads"
 	"github.com/voedger/voedger/pkg/itokensjwt"
 	"github.com/voedger/voedger/pkg/metrics"
@@ -69,23 +73,25 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 	if err != nil {
 		return nil, nil, err
 	}
-	iTokens := itokensjwt.ProvideITokens(secretKeyType, timeFunc)
+	iTokens := provideITokens(secretKeyType, vvmConfig, timeFunc)
 	iAppTokensFactory := payloads.ProvideIAppTokensFactory(iTokens)
 	storageCacheSizeType := vvmConfig.StorageCacheSize
 	iMetrics := imetrics.Provide()
 	vvmName := vvmConfig.Name
-	iAppStorageFactory, err := provideStorageFactory(vvmConfig)
+	iAppStorageFactory, cleanup, err := provideStorageFactory(vvmConfig)
 	if err != nil {
 		return nil, nil, err
 	}
 	iAppStorageUncachingProviderFactory := provideIAppStorageUncachingProviderFactory(iAppStorageFactory)
 	iAppStorageProvider, err := provideCachingAppStorageProvider(vvmConfig, storageCacheSizeType, iMetrics, vvmName, iAppStorageUncachingProviderFactory)
 	if err != nil {
+		cleanup()
 		return nil, nil, err
 	}
 	iAppStructsProvider := istructsmem.Provide(appConfigsType, bucketsFactoryType, iAppTokensFactory, iAppStorageProvider)
-	iAppPartitions, cleanup, err := appparts.New(iAppStructsProvider)
+	iAppPartitions, cleanup2, err := provideAppPartitions(vvmCtx, vvmConfig, iAppStructsProvider, iMetrics, vvmName)
 	if err != nil {
+		cleanup()
 		return nil, nil, err
 	}
 	vvmPortSource := provideVVMPortSource()
@@ -104,12 +110,13 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 	v2 := provideAppsExtensionPoints(vvmConfig)
 	v3, err := provideAppsPackages(vvmConfig, appConfigsType, apIs, v2)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	vvmApps := provideVVMApps(v3)
 	quotas := vvmConfig.Quotas
-	in10nBroker, cleanup2 := in10nmem.ProvideEx2(quotas, timeFunc)
+	in10nBroker, cleanup3 := in10nmem.ProvideEx2(quotas, timeFunc)
 	maxPrepareQueriesType := vvmConfig.MaxPrepareQueries
 	syncActualizerFactory := projectors.ProvideSyncActualizerFactory()
 	commandprocessorSyncActualizerFactory := provideSyncActualizerFactory(vvmApps, iAppStructsProvider, in10nBroker, maxPrepareQueriesType, syncActualizerFactory, iSecretReader)
@@ -123,18 +130,20 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 	queryprocessorServiceFactory := queryprocessor.ProvideServiceFactory()
 	operatorQueryProcessors := provideQueryProcessors(queryProcessorsCount, queryChannel, iAppPartitions, queryprocessorServiceFactory, iMetrics, vvmName, maxPrepareQueriesType, iAuthenticator, iAuthorizer)
 	asyncActualizerFactory := projectors.ProvideAsyncActualizerFactory()
-	asyncActualizersFactory := provideAsyncActualizersFactory(iAppStructsProvider, in10nBroker, asyncActualizerFactory, iSecretReader, iMetrics)
+	iRebuilder := projectors.ProvideRebuilder()
+	asyncActualizersFactory := provideAsyncActualizersFactory(iAppStructsProvider, in10nBroker, asyncActualizerFactory, iSecretReader, iMetrics, iRebuilder)
 	v5 := vvmConfig.ActualizerStateOpts
 	appPartitionFactory := provideAppPartitionFactory(asyncActualizersFactory, v5)
 	appServiceFactory := provideAppServiceFactory(appPartitionFactory, commandProcessorsCount)
 	operatorAppServicesFactory := provideOperatorAppServices(appServiceFactory, vvmApps, iAppStructsProvider)
 	vvmPortType := vvmConfig.VVMPort
-	routerParams := provideRouterParams(vvmConfig, vvmPortType, vvmIdx)
+	routerParams := provideRouterParams(vvmConfig, vvmPortType, vvmIdx, iRebuilder, iAppTokensFactory)
 	busTimeout := vvmConfig.BusTimeout
 	blobberServiceChannels := vvmConfig.BlobberServiceChannels
 	blobMaxSizeType := vvmConfig.BLOBMaxSize
 	blobberAppStruct, err := provideBlobberAppStruct(iAppStructsProvider)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
@@ -142,6 +151,7 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 	blobberAppClusterID := provideBlobberClusterAppID(blobberAppStruct)
 	blobAppStorage, err := provideBlobAppStorage(iAppStorageProvider)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
@@ -149,6 +159,7 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 	blobStorage := provideBlobStorage(blobAppStorage, timeFunc)
 	routerAppStorage, err := provideRouterAppStorage(iAppStorageProvider)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
@@ -164,9 +175,10 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 	metricsServicePort := provideMetricsServicePort(metricsServicePortInitial, vvmIdx)
 	metricsService := metrics.ProvideMetricsService(vvmCtx, metricsServicePort, iMetrics)
 	metricsServiceOperator := provideMetricsServiceOperator(metricsService)
-	v7 := builtin.Apps()
-	iAppPartitionsController, cleanup3, err := apppartsctl.New(iAppPartitions, v7)
+	v7 := provideBuiltInApps(vvmConfig)
+	iAppPartitionsController, cleanup4, err := apppartsctl.New(iAppPartitions, v7)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
@@ -182,6 +194,7 @@ func ProvideCluster(vvmCtx context.Context, vvmConfig *VVMConfig, vvmIdx VVMIdxT
 		AppsPackages:        v3,
 	}
 	return vvm, func() {
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
@@ -228,6 +241,14 @@ func (vvm *VoedgerVM) Launch() error {
 	return vvm.ServicePipeline.SendSync(ignition)
 }
 
+func provideBuiltInApps(vvmCfg *VVMConfig) []apppartsctl.BuiltInApp {
+	apps := builtin.Apps()
+	for i, app := range apps {
+		apps[i].ExtModuleURLs = vvmCfg.ExtModuleURLs[app.Name]
+	}
+	return apps
+}
+
 func provideAppPartsCtlPipelineService(ctl apppartsctl.IAppPartitionsController) IAppPartsCtlPipelineService {
 	return &AppPartsCtlPipelineService{IAppPartitionsController: ctl}
 }
@@ -238,8 +259,16 @@ func provideIAppStorageUncachingProviderFactory(factory istorage.IAppStorageFact
 	}
 }
 
-func provideStorageFactory(vvmConfig *VVMConfig) (provider istorage.IAppStorageFactory, err error) {
-	return vvmConfig.StorageFactory()
+func provideStorageFactory(vvmConfig *VVMConfig) (provider istorage.IAppStorageFactory, cleanup func(), err error) {
+	if provider, err = vvmConfig.StorageFactory(); err != nil {
+		return nil, nil, err
+	}
+	cleanup = func() {
+		if stoppable, ok := provider.(istorage.IStoppable); ok {
+			stoppable.StopGoroutines()
+		}
+	}
+	return provider, cleanup, nil
 }
 
 func provideSubjectGetterFunc() iauthnzimpl.SubjectGetterFunc {
@@ -285,6 +314,13 @@ func provideSecretKeyJWT(sr isecrets.ISecretReader) (itokensjwt.SecretKeyType, e
 	return sr.ReadSecret(itokensjwt.SecretKeyJWTName)
 }
 
+func provideITokens(secretKey itokensjwt.SecretKeyType, cfg *VVMConfig, timeFunc coreutils.TimeFunc) itokens.ITokens {
+	if cfg.JWTKeyRing != nil {
+		return itokensjwt.ProvideITokensWithKeyRing(secretKey, cfg.JWTKeyRing, cfg.JWTAcceptHS256, timeFunc)
+	}
+	return itokensjwt.ProvideITokens(secretKey, timeFunc)
+}
+
 func provideAppsWSAmounts(vvmApps VVMApps, asp istructs.IAppStructsProvider) map[istructs.AppQName]istructs.AppWSAmount {
 	res := map[istructs.AppQName]istructs.AppWSAmount{}
 	for _, appQName := range vvmApps {
@@ -338,17 +374,24 @@ func provideMetricsServicePortGetter(ms metrics.MetricsService) func() metrics.M
 	}
 }
 
-func provideRouterParams(cfg *VVMConfig, port VVMPortType, vvmIdx VVMIdxType) router.RouterParams {
+func provideRouterParams(cfg *VVMConfig, port VVMPortType, vvmIdx VVMIdxType, rebuilder projectors.IRebuilder, appTokensFactory payloads.IAppTokensFactory) router.RouterParams {
 	res := router.RouterParams{
+		AdminHandlers: map[string]http.Handler{
+			RebuildProjectorPath: provideRebuildHandler(rebuilder, appTokensFactory),
+		},
 		WriteTimeout:         cfg.RouterWriteTimeout,
 		ReadTimeout:          cfg.RouterReadTimeout,
 		ConnectionsLimit:     cfg.RouterConnectionsLimit,
+		WSCallsLimit:         cfg.RouterWSCallsLimit,
 		HTTP01ChallengeHosts: cfg.RouterHTTP01ChallengeHosts,
 		RouteDefault:         cfg.RouteDefault,
 		Routes:               cfg.Routes,
 		RoutesRewrite:        cfg.RoutesRewrite,
 		RouteDomains:         cfg.RouteDomains,
 	}
+	if cfg.JWTKeyRing != nil {
+		res.JWKS = cfg.JWTKeyRing.JWKSJSON
+	}
 	if port != 0 {
 		res.Port = int(port) + int(vvmIdx)
 	}
@@ -402,6 +445,29 @@ func provideChannelGroups(cfg *VVMConfig) (res []iprocbusmem.ChannelGroup) {
 	return
 }
 
+func provideAppPartitions(vvmCtx context.Context, vvmCfg *VVMConfig, asp istructs.IAppStructsProvider, metrics imetrics.IMetrics,
+	vvmName commandprocessor.VVMName) (ap appparts.IAppPartitions, cleanup func(), err error) {
+	wasmFactory := iextenginewasm.ProvideExtensionEngineFactory(true)
+	extEngineFactories := iextengine.IExtensionEngineFactories{
+		appdef.ExtensionEngineKind_WASM: wasmFactory,
+	}
+	extConfig := &iextengine.ExtEngineConfig{
+		InstancesPerPackage: vvmCfg.ExtEngineInstancesPerPackage,
+		CompilationCacheDir: vvmCfg.ExtEngineCompilationCacheDir,
+		Metrics:             metrics,
+		VvmName:             string(vvmName),
+	}
+	ap, appPartsCleanup, err := appparts.NewWithExtEngines(vvmCtx, asp, extEngineFactories, extConfig)
+	if err != nil {
+		wasmFactory.Close(vvmCtx)
+		return nil, nil, err
+	}
+	return ap, func() {
+		appPartsCleanup()
+		wasmFactory.Close(vvmCtx)
+	}, nil
+}
+
 func provideCachingAppStorageProvider(vvmCfg *VVMConfig, storageCacheSize StorageCacheSizeType, metrics2 imetrics.IMetrics,
 	vvmName commandprocessor.VVMName, uncachingProivder IAppStorageUncachingProviderFactory) (istorage.IAppStorageProvider, error) {
 	aspNonCaching := uncachingProivder()
@@ -439,13 +505,7 @@ func provideSyncActualizerFactory(vvmApps VVMApps, structsProvider istructs.IApp
 				WorkToEvent: func(work interface{}) istructs.IPLogEvent {
 					return work.(interface{ Event() istructs.IPLogEvent }).Event()
 				},
-				N10nFunc: func(view appdef.QName, wsid istructs.WSID, offset istructs.Offset) {
-					n10nBroker.Update(in10n.ProjectionKey{
-						App:        appStructs.AppQName(),
-						Projection: view,
-						WS:         wsid,
-					}, offset)
-				},
+				N10nFunc:     projectors.ProvideN10nFunc(n10nBroker, appStructs.AppQName()),
 				IntentsLimit: builtin2.MaxCUDs,
 			}
 			actualizer := actualizerFactory(conf, appStructs.SyncProjectors()[0], appStructs.SyncProjectors()[1:]...)
@@ -538,7 +598,7 @@ func provideCommandProcessors(cpCount coreutils.CommandProcessorsCount, ccf Comm
 	return pipeline.ForkOperator(pipeline.ForkSame, forks[0], forks[1:]...)
 }
 
-func provideAsyncActualizersFactory(appStructsProvider istructs.IAppStructsProvider, n10nBroker in10n.IN10nBroker, asyncActualizerFactory projectors.AsyncActualizerFactory, secretReader isecrets.ISecretReader, metrics2 imetrics.IMetrics) AsyncActualizersFactory {
+func provideAsyncActualizersFactory(appStructsProvider istructs.IAppStructsProvider, n10nBroker in10n.IN10nBroker, asyncActualizerFactory projectors.AsyncActualizerFactory, secretReader isecrets.ISecretReader, metrics2 imetrics.IMetrics, rebuilder projectors.IRebuilder) AsyncActualizersFactory {
 	return func(vvmCtx context.Context, appQName istructs.AppQName, asyncProjectorFactories AsyncProjectorFactories, partitionID istructs.PartitionID, opts []state.ActualizerStateOptFunc) pipeline.ISyncOperator {
 		var asyncProjectors []pipeline.ForkOperatorOptionFunc
 		appStructs, err := appStructsProvider.AppStructs(appQName)
@@ -558,6 +618,7 @@ func provideAsyncActualizersFactory(appStructsProvider istructs.IAppStructsProvi
 			IntentsLimit:  builtin2.MaxCUDs,
 			FlushInterval: actualizerFlushInterval,
 			Metrics:       metrics2,
+			Rebuilder:     rebuilder,
 		}
 
 		asyncProjectors = make([]pipeline.ForkOperatorOptionFunc, len(asyncProjectorFactories))
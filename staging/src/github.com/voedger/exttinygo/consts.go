@@ -7,13 +7,17 @@ package extensions
 
 const (
 	StorageEvent       = "sys.EventStorage"
-	StorageSendmail    = "sys.SendMailStorage"
-	StorageRecords     = "sys.RecordsStorage"
-	StorageViewRecords = "sys.ViewRecordsStorage"
-	StorageWLog        = "sys.WLogStorage"
-	StoragePLog        = "sys.PLogStorage"
-	StorageHTTP        = "sys.HTTPStorage"
-	StorageAppSecrets  = "sys.AppSecretsStorage"
+	StorageSendmail    = "sys.SendMail"
+	StorageRecords     = "sys.Record"
+	StorageViewRecords = "sys.View"
+	StorageWLog        = "sys.WLog"
+	StoragePLog        = "sys.PLog"
+	StorageHTTP        = "sys.Http"
+	StorageAppSecrets  = "sys.AppSecret"
+
+	// Storages of command and query processors
+	StorageArgumentObject = "sys.ArgumentObject"
+	StorageResult         = "sys.Result"
 
 	NullEntity = ""
 )
@@ -8,3 +8,130 @@
 package extensions
 
 type extint = int
+
+func Panic(msg string) {
+	host().Panic(msg)
+}
+
+func keyBuilderImpl(storage, entity string) TKeyBuilder {
+	return TKeyBuilder(host().KeyBuilder(storage, entity))
+}
+
+func queryValueImpl(key TKeyBuilder) (bool, TValue) {
+	id, exists := host().QueryValue(uint64(key))
+	return exists, TValue(id)
+}
+
+func mustGetValueImpl(key TKeyBuilder) TValue {
+	return TValue(host().GetValue(uint64(key)))
+}
+
+func updateValueImpl(key TKeyBuilder, existingValue TValue) TIntent {
+	return TIntent(host().UpdateValue(uint64(key), uint64(existingValue)))
+}
+
+func newValueImpl(key TKeyBuilder) TIntent {
+	return TIntent(host().NewValue(uint64(key)))
+}
+
+func readValuesImpl(key TKeyBuilder, callback func(key TKey, value TValue)) {
+	host().ReadValues(uint64(key), func(key, value uint64) {
+		callback(TKey(key), TValue(value))
+	})
+}
+
+func (k TKeyBuilder) PutInt32(name string, value int32) {
+	host().RowWriterPutInt32(uint64(k), false, name, value)
+}
+
+func (k TKeyBuilder) PutInt64(name string, value int64) {
+	host().RowWriterPutInt64(uint64(k), false, name, value)
+}
+
+func (k TKeyBuilder) PutFloat32(name string, value float32) {
+	host().RowWriterPutFloat32(uint64(k), false, name, value)
+}
+
+func (k TKeyBuilder) PutFloat64(name string, value float64) {
+	host().RowWriterPutFloat64(uint64(k), false, name, value)
+}
+
+func (k TKeyBuilder) PutString(name string, value string) {
+	host().RowWriterPutString(uint64(k), false, name, value)
+}
+
+func (k TKeyBuilder) PutBytes(name string, value []byte) {
+	host().RowWriterPutBytes(uint64(k), false, name, value)
+}
+
+func (k TKeyBuilder) PutQName(name string, value QName) {
+	host().RowWriterPutQName(uint64(k), false, name, value)
+}
+
+func (k TKeyBuilder) PutBool(name string, value bool) {
+	host().RowWriterPutBool(uint64(k), false, name, value)
+}
+
+func (i TIntent) PutInt32(name string, value int32) {
+	host().RowWriterPutInt32(uint64(i), true, name, value)
+}
+
+func (i TIntent) PutInt64(name string, value int64) {
+	host().RowWriterPutInt64(uint64(i), true, name, value)
+}
+
+func (i TIntent) PutFloat32(name string, value float32) {
+	host().RowWriterPutFloat32(uint64(i), true, name, value)
+}
+
+func (i TIntent) PutFloat64(name string, value float64) {
+	host().RowWriterPutFloat64(uint64(i), true, name, value)
+}
+
+func (i TIntent) PutString(name string, value string) {
+	host().RowWriterPutString(uint64(i), true, name, value)
+}
+
+func (i TIntent) PutBytes(name string, value []byte) {
+	host().RowWriterPutBytes(uint64(i), true, name, value)
+}
+
+func (i TIntent) PutQName(name string, value QName) {
+	host().RowWriterPutQName(uint64(i), true, name, value)
+}
+
+func (i TIntent) PutBool(name string, value bool) {
+	host().RowWriterPutBool(uint64(i), true, name, value)
+}
+
+type TKey uint64
+
+func (v TKey) AsString(name string) string   { return host().KeyAsString(uint64(v), name) }
+func (v TKey) AsInt32(name string) int32     { return host().KeyAsInt32(uint64(v), name) }
+func (v TKey) AsInt64(name string) int64     { return host().KeyAsInt64(uint64(v), name) }
+func (v TKey) AsFloat32(name string) float32 { return host().KeyAsFloat32(uint64(v), name) }
+func (v TKey) AsFloat64(name string) float64 { return host().KeyAsFloat64(uint64(v), name) }
+func (v TKey) AsBytes(name string) []byte    { return host().KeyAsBytes(uint64(v), name) }
+func (v TKey) AsQName(name string) QName     { return host().KeyAsQName(uint64(v), name) }
+func (v TKey) AsBool(name string) bool       { return host().KeyAsBool(uint64(v), name) }
+
+func (v TValue) Length() uint32                { return host().ValueLength(uint64(v)) }
+func (v TValue) AsString(name string) string   { return host().ValueAsString(uint64(v), name) }
+func (v TValue) AsBytes(name string) []byte    { return host().ValueAsBytes(uint64(v), name) }
+func (v TValue) AsInt32(name string) int32     { return host().ValueAsInt32(uint64(v), name) }
+func (v TValue) AsInt64(name string) int64     { return host().ValueAsInt64(uint64(v), name) }
+func (v TValue) AsFloat32(name string) float32 { return host().ValueAsFloat32(uint64(v), name) }
+func (v TValue) AsFloat64(name string) float64 { return host().ValueAsFloat64(uint64(v), name) }
+func (v TValue) AsQName(name string) QName     { return host().ValueAsQName(uint64(v), name) }
+func (v TValue) AsBool(name string) bool       { return host().ValueAsBool(uint64(v), name) }
+func (v TValue) AsValue(name string) TValue    { return TValue(host().ValueAsValue(uint64(v), name)) }
+
+func (v TValue) GetAsString(index int) string   { return host().ValueGetAsString(uint64(v), index) }
+func (v TValue) GetAsBytes(index int) []byte    { return host().ValueGetAsBytes(uint64(v), index) }
+func (v TValue) GetAsInt32(index int) int32     { return host().ValueGetAsInt32(uint64(v), index) }
+func (v TValue) GetAsInt64(index int) int64     { return host().ValueGetAsInt64(uint64(v), index) }
+func (v TValue) GetAsFloat32(index int) float32 { return host().ValueGetAsFloat32(uint64(v), index) }
+func (v TValue) GetAsFloat64(index int) float64 { return host().ValueGetAsFloat64(uint64(v), index) }
+func (v TValue) GetAsQName(index int) QName     { return host().ValueGetAsQName(uint64(v), index) }
+func (v TValue) GetAsBool(index int) bool       { return host().ValueGetAsBool(uint64(v), index) }
+func (v TValue) GetAsValue(index int) TValue    { return TValue(host().ValueGetAsValue(uint64(v), index)) }
@@ -1,3 +1,5 @@
+//go:build tinygo
+
 /*
 * Copyright (c) 2021-present unTill Pro, Ltd.
 *  @author Michael Saigachenko
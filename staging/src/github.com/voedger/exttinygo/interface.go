@@ -24,6 +24,14 @@ var UpdateValue func(key TKeyBuilder, existingValue TValue) TIntent = updateValu
 // NewValue creates intent for new value
 var NewValue func(key TKeyBuilder) TIntent = newValueImpl
 
+// MustGetArgument gets argument object of command or query
+var MustGetArgument func() TValue = mustGetArgumentImpl
+
+// NewResult creates intent for result object of command or query.
+//
+// Query can create many results, each result is sent to client as a row
+var NewResult func() TIntent = newResultImpl
+
 type IKey interface {
 	AsString(name string) string
 	AsInt32(name string) int32
@@ -0,0 +1,77 @@
+//go:build !tinygo
+
+/*
+* Copyright (c) 2024-present unTill Pro, Ltd.
+ */
+
+package extensions
+
+// INativeHost is the host for extensions which are built and run as plain Go code instead of WASM,
+// e.g. by unit tests of extensions.
+//
+// Values of TKeyBuilder, TKey, TValue and TIntent are handles issued by the host.
+// RowWriterPut* methods write to the key builder (isIntent == false) or to the intent (isIntent == true).
+type INativeHost interface {
+	Panic(msg string)
+
+	KeyBuilder(storage, entity string) (keyBuilder uint64)
+	GetValue(keyBuilder uint64) (value uint64)
+	QueryValue(keyBuilder uint64) (value uint64, exists bool)
+	ReadValues(keyBuilder uint64, callback func(key, value uint64))
+	NewValue(keyBuilder uint64) (intent uint64)
+	UpdateValue(keyBuilder, existingValue uint64) (intent uint64)
+
+	RowWriterPutString(id uint64, isIntent bool, name string, value string)
+	RowWriterPutBytes(id uint64, isIntent bool, name string, value []byte)
+	RowWriterPutQName(id uint64, isIntent bool, name string, value QName)
+	RowWriterPutBool(id uint64, isIntent bool, name string, value bool)
+	RowWriterPutInt32(id uint64, isIntent bool, name string, value int32)
+	RowWriterPutInt64(id uint64, isIntent bool, name string, value int64)
+	RowWriterPutFloat32(id uint64, isIntent bool, name string, value float32)
+	RowWriterPutFloat64(id uint64, isIntent bool, name string, value float64)
+
+	KeyAsString(key uint64, name string) string
+	KeyAsBytes(key uint64, name string) []byte
+	KeyAsQName(key uint64, name string) QName
+	KeyAsBool(key uint64, name string) bool
+	KeyAsInt32(key uint64, name string) int32
+	KeyAsInt64(key uint64, name string) int64
+	KeyAsFloat32(key uint64, name string) float32
+	KeyAsFloat64(key uint64, name string) float64
+
+	ValueLength(value uint64) uint32
+	ValueAsString(value uint64, name string) string
+	ValueAsBytes(value uint64, name string) []byte
+	ValueAsQName(value uint64, name string) QName
+	ValueAsBool(value uint64, name string) bool
+	ValueAsInt32(value uint64, name string) int32
+	ValueAsInt64(value uint64, name string) int64
+	ValueAsFloat32(value uint64, name string) float32
+	ValueAsFloat64(value uint64, name string) float64
+	ValueAsValue(value uint64, name string) uint64
+
+	ValueGetAsString(value uint64, index int) string
+	ValueGetAsBytes(value uint64, index int) []byte
+	ValueGetAsQName(value uint64, index int) QName
+	ValueGetAsBool(value uint64, index int) bool
+	ValueGetAsInt32(value uint64, index int) int32
+	ValueGetAsInt64(value uint64, index int) int64
+	ValueGetAsFloat32(value uint64, index int) float32
+	ValueGetAsFloat64(value uint64, index int) float64
+	ValueGetAsValue(value uint64, index int) uint64
+}
+
+var nativeHost INativeHost
+
+// SetNativeHost sets the host for natively running extensions. Returns the previous host
+func SetNativeHost(host INativeHost) (prev INativeHost) {
+	prev, nativeHost = nativeHost, host
+	return prev
+}
+
+func host() INativeHost {
+	if nativeHost == nil {
+		panic("exttinygo: native host is not set, see SetNativeHost")
+	}
+	return nativeHost
+}